@@ -72,11 +72,58 @@ DB_PASSWORD=samplepass
 # データベース名（テスト用）
 DB_NAME=sampledb_test
 
+# ========================================
+# Storage Settings
+# ========================================
+# ストレージドライバー（postgres: PostgreSQL、memory: メモリ上で動作しDB不要）
+STORAGE_DRIVER=postgres
+
+# ========================================
+# Migration Settings
+# ========================================
+# 起動時にDBマイグレーションを自動適用するか（true/false）
+AUTO_MIGRATE=false
+
 # ========================================
 # Security Settings
 # ========================================
 # JWT署名用のシークレットキー（テスト環境用）
 JWT_SECRET=test_jwt_secret_key_2024
+# JWT署名アルゴリズム（HS256: JWT_SECRET で署名、RS256: 鍵ファイルで署名）
+JWT_ALGORITHM=HS256
+# RS256 の秘密鍵・公開鍵ファイル（PEM、公開鍵は省略時に秘密鍵から導出）
+JWT_PRIVATE_KEY_PATH=
+JWT_PUBLIC_KEY_PATH=
+# アクセストークン・リフレッシュトークンの有効期間
+ACCESS_TOKEN_TTL=15m
+REFRESH_TOKEN_TTL=168h
+# パスワードハッシュアルゴリズム（bcrypt | argon2id）
+PASSWORD_HASH_ALGORITHM=bcrypt
+
+# ========================================
+# Mail Settings
+# ========================================
+# フロントエンドのURL（招待メールなどのリンクに使用）
+APP_BASE_URL=http://localhost:3000
+# SMTPサーバー（空の場合はメールを送信せずログに出力）
+SMTP_HOST=
+SMTP_PORT=587
+SMTP_USERNAME=
+SMTP_PASSWORD=
+# 送信元メールアドレス
+MAIL_FROM=no-reply@localhost
+# チーム招待の有効期間
+TEAM_INVITATION_TTL=168h
+# メールアドレス変更の確認リンクの有効期間
+EMAIL_CHANGE_TTL=24h
+
+# ========================================
+# Realtime Settings
+# ========================================
+# イベントストリームの再送用に保持するイベント数
+EVENTS_REPLAY_BUFFER=1000
+# イベントストリームのハートビート間隔
+EVENTS_HEARTBEAT_INTERVAL=15s
 
 # ========================================
 # Test Settings
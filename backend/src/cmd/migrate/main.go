@@ -0,0 +1,118 @@
+// Command migrate 埋め込みSQLマイグレーションを操作するCLI
+//
+// 使い方:
+//
+//	go run ./cmd/migrate up            未適用のマイグレーションを全て適用
+//	go run ./cmd/migrate down [N]      直近 N 件（省略時 1 件）をロールバック
+//	go run ./cmd/migrate goto VERSION  指定バージョンまで適用・ロールバック（0 で全て戻す）
+//	go run ./cmd/migrate status        適用状況を表示
+//
+// 接続先はサーバーと同じ DB_* 環境変数で指定します。
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"backend/config"
+	"backend/db/migrations"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+
+	cfg := config.LoadConfig()
+	dbConfig := config.NewDatabaseConfig(cfg)
+	db, err := dbConfig.Connect()
+	if err != nil {
+		log.Fatalf("❌ Database connection failed: %v", err)
+	}
+	defer dbConfig.Close(db)
+
+	migrator, err := migrations.New(db)
+	if err != nil {
+		log.Fatalf("❌ Failed to load migrations: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	var steps []migrations.Step
+	switch os.Args[1] {
+	case "up":
+		steps, err = migrator.Up(ctx)
+	case "down":
+		n := 1
+		if len(os.Args) > 2 {
+			if n, err = strconv.Atoi(os.Args[2]); err != nil {
+				usage()
+			}
+		}
+		steps, err = migrator.Down(ctx, n)
+	case "goto":
+		if len(os.Args) < 3 {
+			usage()
+		}
+		version, parseErr := strconv.ParseInt(os.Args[2], 10, 64)
+		if parseErr != nil {
+			usage()
+		}
+		steps, err = migrator.Goto(ctx, version)
+	case "status":
+		printStatus(ctx, migrator)
+		return
+	default:
+		usage()
+	}
+
+	for _, step := range steps {
+		log.Printf("📦 %s", step)
+	}
+	if err != nil {
+		log.Fatalf("❌ Migration failed: %v", err)
+	}
+	if len(steps) == 0 {
+		log.Println("✅ No migrations to run")
+		return
+	}
+	log.Printf("✅ %d migration(s) completed", len(steps))
+}
+
+// printStatus 適用状況を表形式で出力
+func printStatus(ctx context.Context, migrator *migrations.Migrator) {
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		log.Fatalf("❌ Failed to get migration status: %v", err)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tSTATUS\tAPPLIED AT")
+	for _, status := range statuses {
+		state, appliedAt := "pending", "-"
+		if status.Applied {
+			state = "applied"
+			appliedAt = status.AppliedAt.Format(time.RFC3339)
+		}
+		if status.Modified {
+			state = "modified"
+		}
+		if status.Missing {
+			state = "missing"
+		}
+		fmt.Fprintf(w, "%03d\t%s\t%s\t%s\n", status.Version, status.Name, state, appliedAt)
+	}
+	w.Flush()
+}
+
+// usage 使い方を表示して終了
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: migrate up | down [N] | goto VERSION | status")
+	os.Exit(2)
+}
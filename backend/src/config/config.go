@@ -3,29 +3,72 @@ package config
 import (
 	"os"
 	"strconv"
+	"time"
 )
 
 // Config アプリケーション設定構造体
 type Config struct {
-	Port      string // サーバーポート
-	DBHost    string // データベースホスト
-	DBPort    string // データベースポート
-	DBUser    string // データベースユーザー
-	DBPass    string // データベースパスワード
-	DBName    string // データベース名
-	JWTSecret string // JWT秘密鍵
+	Port          string // サーバーポート
+	DBHost        string // データベースホスト
+	DBPort        string // データベースポート
+	DBUser        string // データベースユーザー
+	DBPass        string // データベースパスワード
+	DBName        string // データベース名
+	JWTSecret     string // JWT秘密鍵
+	StorageDriver string // ストレージドライバー（postgres | memory）
+	AutoMigrate   bool   // 起動時にマイグレーションを自動適用するか
+
+	JWTAlgorithm          string        // JWT署名アルゴリズム（HS256 | RS256）
+	JWTPrivateKeyPath     string        // RS256 の秘密鍵ファイル（PEM）
+	JWTPublicKeyPath      string        // RS256 の公開鍵ファイル（PEM、省略時は秘密鍵から導出）
+	AccessTokenTTL        time.Duration // アクセストークンの有効期間
+	RefreshTokenTTL       time.Duration // リフレッシュトークンの有効期間
+	PasswordHashAlgorithm string        // パスワードハッシュ（bcrypt | argon2id）
+
+	AppBaseURL        string        // フロントエンドのURL（メール内のリンクに使用）
+	SMTPHost          string        // SMTPサーバー（空の場合はメールを送信せずログに出力）
+	SMTPPort          string        // SMTPポート
+	SMTPUsername      string        // SMTP認証ユーザー（空の場合は認証なし）
+	SMTPPassword      string        // SMTP認証パスワード
+	MailFrom          string        // 送信元メールアドレス
+	TeamInvitationTTL time.Duration // チーム招待の有効期間
+	EmailChangeTTL    time.Duration // メールアドレス変更の確認リンクの有効期間
+
+	EventReplayBuffer      int           // Last-Event-ID による再開用に保持するイベント数
+	EventHeartbeatInterval time.Duration // イベントストリームのハートビート間隔
 }
 
 // LoadConfig 環境変数から設定を読み込み
 func LoadConfig() *Config {
 	return &Config{
-		Port:      getEnv("PORT", "8080"),
-		DBHost:    getEnv("DB_HOST", "localhost"),
-		DBPort:    getEnv("DB_PORT", "5432"),
-		DBUser:    getEnv("DB_USER", "sampleuser"),
-		DBPass:    getEnv("DB_PASSWORD", "samplepass"),
-		DBName:    getEnv("DB_NAME", "sampledb"),
-		JWTSecret: getEnv("JWT_SECRET", "your_jwt_secret"),
+		Port:          getEnv("PORT", "8080"),
+		DBHost:        getEnv("DB_HOST", "localhost"),
+		DBPort:        getEnv("DB_PORT", "5432"),
+		DBUser:        getEnv("DB_USER", "sampleuser"),
+		DBPass:        getEnv("DB_PASSWORD", "samplepass"),
+		DBName:        getEnv("DB_NAME", "sampledb"),
+		JWTSecret:     getEnv("JWT_SECRET", "your_jwt_secret"),
+		StorageDriver: getEnv("STORAGE_DRIVER", "postgres"),
+		AutoMigrate:   getEnvBool("AUTO_MIGRATE", false),
+
+		JWTAlgorithm:          getEnv("JWT_ALGORITHM", "HS256"),
+		JWTPrivateKeyPath:     getEnv("JWT_PRIVATE_KEY_PATH", ""),
+		JWTPublicKeyPath:      getEnv("JWT_PUBLIC_KEY_PATH", ""),
+		AccessTokenTTL:        getEnvDuration("ACCESS_TOKEN_TTL", 15*time.Minute),
+		RefreshTokenTTL:       getEnvDuration("REFRESH_TOKEN_TTL", 7*24*time.Hour),
+		PasswordHashAlgorithm: getEnv("PASSWORD_HASH_ALGORITHM", "bcrypt"),
+
+		AppBaseURL:        getEnv("APP_BASE_URL", "http://localhost:3000"),
+		SMTPHost:          getEnv("SMTP_HOST", ""),
+		SMTPPort:          getEnv("SMTP_PORT", "587"),
+		SMTPUsername:      getEnv("SMTP_USERNAME", ""),
+		SMTPPassword:      getEnv("SMTP_PASSWORD", ""),
+		MailFrom:          getEnv("MAIL_FROM", "no-reply@localhost"),
+		TeamInvitationTTL: getEnvDuration("TEAM_INVITATION_TTL", 7*24*time.Hour),
+		EmailChangeTTL:    getEnvDuration("EMAIL_CHANGE_TTL", 24*time.Hour),
+
+		EventReplayBuffer:      getEnvInt("EVENTS_REPLAY_BUFFER", 1000),
+		EventHeartbeatInterval: getEnvDuration("EVENTS_HEARTBEAT_INTERVAL", 15*time.Second),
 	}
 }
 
@@ -37,6 +80,38 @@ func getEnv(key, defaultValue string) string {
 	return defaultValue
 }
 
+// getEnvBool 環境変数を真偽値として取得し、未設定・不正な値の場合はデフォルト値を使用
+func getEnvBool(key string, defaultValue bool) bool {
+	value, err := strconv.ParseBool(os.Getenv(key))
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+// getEnvInt 環境変数を正の整数として取得し、未設定・不正な値の場合はデフォルト値を使用
+func getEnvInt(key string, defaultValue int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil || value <= 0 {
+		return defaultValue
+	}
+	return value
+}
+
+// getEnvDuration 環境変数を期間（例: 15m, 168h）として取得し、未設定・不正な値の場合はデフォルト値を使用
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	value, err := time.ParseDuration(os.Getenv(key))
+	if err != nil || value <= 0 {
+		return defaultValue
+	}
+	return value
+}
+
+// UseMemoryStorage メモリストレージを使用するか判定
+func (c *Config) UseMemoryStorage() bool {
+	return c.StorageDriver == "memory"
+}
+
 // GetPort ポート番号を数値で取得
 func (c *Config) GetPort() int {
 	port, err := strconv.Atoi(c.Port)
@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"testing"
+	"time"
 )
 
 // TestLoadConfig 設定読み込みのテスト
@@ -100,6 +101,98 @@ func TestLoadConfigDefaults(t *testing.T) {
 	if cfg.JWTSecret != "your_jwt_secret" {
 		t.Errorf("Expected JWTSecret 'your_jwt_secret', got '%s'", cfg.JWTSecret)
 	}
+
+	if cfg.StorageDriver != "postgres" {
+		t.Errorf("Expected StorageDriver 'postgres', got '%s'", cfg.StorageDriver)
+	}
+
+	if cfg.UseMemoryStorage() {
+		t.Error("Expected UseMemoryStorage to be false by default")
+	}
+
+	if cfg.AutoMigrate {
+		t.Error("Expected AutoMigrate to be false by default")
+	}
+
+	if cfg.JWTAlgorithm != "HS256" || cfg.AccessTokenTTL != 15*time.Minute || cfg.RefreshTokenTTL != 7*24*time.Hour {
+		t.Errorf("Unexpected token defaults: %s %v %v", cfg.JWTAlgorithm, cfg.AccessTokenTTL, cfg.RefreshTokenTTL)
+	}
+
+	if cfg.PasswordHashAlgorithm != "bcrypt" {
+		t.Errorf("Expected PasswordHashAlgorithm 'bcrypt', got '%s'", cfg.PasswordHashAlgorithm)
+	}
+}
+
+// TestLoadConfigTokenTTL トークン有効期間設定のテスト
+func TestLoadConfigTokenTTL(t *testing.T) {
+	os.Setenv("ACCESS_TOKEN_TTL", "5m")
+	os.Setenv("REFRESH_TOKEN_TTL", "invalid")
+	defer os.Unsetenv("ACCESS_TOKEN_TTL")
+	defer os.Unsetenv("REFRESH_TOKEN_TTL")
+
+	cfg := LoadConfig()
+
+	if cfg.AccessTokenTTL != 5*time.Minute {
+		t.Errorf("Expected AccessTokenTTL 5m, got %v", cfg.AccessTokenTTL)
+	}
+	if cfg.RefreshTokenTTL != 7*24*time.Hour {
+		t.Errorf("Expected default RefreshTokenTTL for invalid value, got %v", cfg.RefreshTokenTTL)
+	}
+}
+
+// TestLoadConfigEvents イベントストリーム設定のテスト
+func TestLoadConfigEvents(t *testing.T) {
+	os.Setenv("EVENTS_REPLAY_BUFFER", "-1")
+	os.Setenv("EVENTS_HEARTBEAT_INTERVAL", "30s")
+	defer os.Unsetenv("EVENTS_REPLAY_BUFFER")
+	defer os.Unsetenv("EVENTS_HEARTBEAT_INTERVAL")
+
+	cfg := LoadConfig()
+
+	if cfg.EventReplayBuffer != 1000 {
+		t.Errorf("Expected default EventReplayBuffer for invalid value, got %d", cfg.EventReplayBuffer)
+	}
+	if cfg.EventHeartbeatInterval != 30*time.Second {
+		t.Errorf("Expected EventHeartbeatInterval 30s, got %v", cfg.EventHeartbeatInterval)
+	}
+}
+
+// TestLoadConfigAutoMigrate マイグレーション自動適用設定のテスト
+func TestLoadConfigAutoMigrate(t *testing.T) {
+	defer os.Unsetenv("AUTO_MIGRATE")
+
+	tests := []struct {
+		value    string
+		expected bool
+	}{
+		{"true", true},
+		{"1", true},
+		{"false", false},
+		{"invalid", false},
+	}
+
+	for _, tt := range tests {
+		os.Setenv("AUTO_MIGRATE", tt.value)
+		if cfg := LoadConfig(); cfg.AutoMigrate != tt.expected {
+			t.Errorf("AUTO_MIGRATE=%q: expected %v, got %v", tt.value, tt.expected, cfg.AutoMigrate)
+		}
+	}
+}
+
+// TestLoadConfigStorageDriver ストレージドライバー設定のテスト
+func TestLoadConfigStorageDriver(t *testing.T) {
+	os.Setenv("STORAGE_DRIVER", "memory")
+	defer os.Unsetenv("STORAGE_DRIVER")
+
+	cfg := LoadConfig()
+
+	if cfg.StorageDriver != "memory" {
+		t.Errorf("Expected StorageDriver 'memory', got '%s'", cfg.StorageDriver)
+	}
+
+	if !cfg.UseMemoryStorage() {
+		t.Error("Expected UseMemoryStorage to be true")
+	}
 }
 
 // TestNewDatabaseConfig データベース設定のテスト
@@ -1,3 +1,4 @@
+-- +migrate Up
 -- Hello Worldメッセージテーブル作成
 CREATE TABLE IF NOT EXISTS hello_world_messages (
     id SERIAL PRIMARY KEY,
@@ -11,9 +12,14 @@ CREATE TABLE IF NOT EXISTS hello_world_messages (
 CREATE INDEX IF NOT EXISTS idx_hello_world_messages_created_at ON hello_world_messages(created_at);
 CREATE INDEX IF NOT EXISTS idx_hello_world_messages_name ON hello_world_messages(name);
 
--- サンプルデータ挿入
-INSERT INTO hello_world_messages (name, message) VALUES
+-- サンプルデータ挿入（旧 init.sql で作成済みのテーブルなど、既にデータがある場合は挿入しない）
+INSERT INTO hello_world_messages (name, message)
+SELECT name, message FROM (VALUES
     ('Alice', 'Hello, Alice!'),
     ('Bob', 'Hello, Bob!'),
     ('Charlie', 'Hello, Charlie!')
-ON CONFLICT DO NOTHING; 
\ No newline at end of file
+) AS seed (name, message)
+WHERE NOT EXISTS (SELECT 1 FROM hello_world_messages);
+
+-- +migrate Down
+DROP TABLE IF EXISTS hello_world_messages;
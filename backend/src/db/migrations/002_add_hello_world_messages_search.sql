@@ -0,0 +1,14 @@
+-- +migrate Up
+-- Hello Worldメッセージ全文検索用のtsvectorカラム追加
+ALTER TABLE hello_world_messages
+    ADD COLUMN IF NOT EXISTS search_vector tsvector
+    GENERATED ALWAYS AS (
+        to_tsvector('simple', coalesce(name, '') || ' ' || coalesce(message, ''))
+    ) STORED;
+
+-- 全文検索用GINインデックス
+CREATE INDEX IF NOT EXISTS idx_hello_world_messages_search_vector ON hello_world_messages USING GIN (search_vector);
+
+-- +migrate Down
+DROP INDEX IF EXISTS idx_hello_world_messages_search_vector;
+ALTER TABLE hello_world_messages DROP COLUMN IF EXISTS search_vector;
@@ -0,0 +1,43 @@
+-- +migrate Up
+-- 旧 init.sql で作成されたDBとマイグレーションで作成されたDBのスキーマ差異を解消
+--   init.sql:  name VARCHAR(100), message NULL許可, updated_at トリガーあり
+--   001:       name VARCHAR(255), message NOT NULL, updated_at トリガーなし
+
+-- 生成カラムが参照する列は型変更できないため、全文検索カラムを一旦削除して再作成
+DROP INDEX IF EXISTS idx_hello_world_messages_search_vector;
+ALTER TABLE hello_world_messages DROP COLUMN IF EXISTS search_vector;
+
+ALTER TABLE hello_world_messages ALTER COLUMN name TYPE VARCHAR(255);
+
+UPDATE hello_world_messages SET message = '' WHERE message IS NULL;
+ALTER TABLE hello_world_messages ALTER COLUMN message SET NOT NULL;
+
+ALTER TABLE hello_world_messages
+    ADD COLUMN search_vector tsvector
+    GENERATED ALWAYS AS (
+        to_tsvector('simple', coalesce(name, '') || ' ' || coalesce(message, ''))
+    ) STORED;
+CREATE INDEX idx_hello_world_messages_search_vector ON hello_world_messages USING GIN (search_vector);
+
+CREATE INDEX IF NOT EXISTS idx_hello_world_messages_name ON hello_world_messages(name);
+
+-- 更新時刻を自動更新するためのトリガー関数
+CREATE OR REPLACE FUNCTION update_updated_at_column()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW.updated_at = CURRENT_TIMESTAMP;
+    RETURN NEW;
+END;
+$$ language 'plpgsql';
+
+DROP TRIGGER IF EXISTS update_hello_world_messages_updated_at ON hello_world_messages;
+CREATE TRIGGER update_hello_world_messages_updated_at
+    BEFORE UPDATE ON hello_world_messages
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- +migrate Down
+-- name の桁数はデータ切り詰めを避けるため VARCHAR(255) のまま戻しません
+DROP TRIGGER IF EXISTS update_hello_world_messages_updated_at ON hello_world_messages;
+DROP FUNCTION IF EXISTS update_updated_at_column();
+ALTER TABLE hello_world_messages ALTER COLUMN message DROP NOT NULL;
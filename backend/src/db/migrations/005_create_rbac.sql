@@ -0,0 +1,61 @@
+-- +migrate Up
+-- ロール・権限・ロール権限対応・ユーザーロールのテーブル作成
+CREATE TABLE IF NOT EXISTS roles (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(50) NOT NULL UNIQUE,
+    description TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS permissions (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL UNIQUE,
+    description TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS role_permissions (
+    role_id INTEGER NOT NULL REFERENCES roles(id) ON DELETE CASCADE,
+    permission_id INTEGER NOT NULL REFERENCES permissions(id) ON DELETE CASCADE,
+    PRIMARY KEY (role_id, permission_id)
+);
+
+CREATE TABLE IF NOT EXISTS user_roles (
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role_id INTEGER NOT NULL REFERENCES roles(id) ON DELETE CASCADE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, role_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_roles_role_id ON user_roles(role_id);
+
+-- 初期ロールと権限（services.DefaultRoles と同じ内容）
+INSERT INTO roles (name, description) VALUES
+    ('owner', 'Full access including role management'),
+    ('member', 'Can create and update messages')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO permissions (name, description) VALUES
+    ('messages:create', 'Create hello world messages'),
+    ('messages:update', 'Update hello world messages'),
+    ('messages:delete', 'Delete hello world messages'),
+    ('roles:manage', 'Assign and revoke user roles')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id FROM roles r, permissions p
+WHERE r.name = 'owner'
+   OR (r.name = 'member' AND p.name IN ('messages:create', 'messages:update'))
+ON CONFLICT DO NOTHING;
+
+-- 既存ユーザーへのロール付与（最初のユーザーを owner、それ以外を member）
+INSERT INTO user_roles (user_id, role_id)
+SELECT u.id, r.id FROM users u, roles r
+WHERE r.name = CASE WHEN u.id = (SELECT MIN(id) FROM users) THEN 'owner' ELSE 'member' END
+ON CONFLICT DO NOTHING;
+
+-- +migrate Down
+DROP TABLE IF EXISTS user_roles;
+DROP TABLE IF EXISTS role_permissions;
+DROP TABLE IF EXISTS permissions;
+DROP TABLE IF EXISTS roles;
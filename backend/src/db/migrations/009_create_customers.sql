@@ -0,0 +1,41 @@
+-- +migrate Up
+-- 顧客テーブル作成（ダッシュボードの User 型に対応、email は小文字に正規化して保存）
+CREATE TABLE IF NOT EXISTS customers (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    email VARCHAR(255) NOT NULL UNIQUE,
+    avatar_url TEXT NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL DEFAULT 'subscribed'
+        CHECK (status IN ('subscribed', 'unsubscribed', 'bounced')),
+    location VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    search_vector tsvector GENERATED ALWAYS AS (
+        to_tsvector('simple', name || ' ' || email || ' ' || location)
+    ) STORED
+);
+
+CREATE INDEX IF NOT EXISTS idx_customers_status ON customers(status);
+CREATE INDEX IF NOT EXISTS idx_customers_search_vector ON customers USING GIN (search_vector);
+
+DROP TRIGGER IF EXISTS update_customers_updated_at ON customers;
+CREATE TRIGGER update_customers_updated_at
+    BEFORE UPDATE ON customers
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- 顧客管理の権限（services.DefaultRoles と同じ内容）
+INSERT INTO permissions (name, description) VALUES
+    ('customers:write', 'Create and update customers'),
+    ('customers:delete', 'Delete customers')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id FROM roles r, permissions p
+WHERE (r.name = 'owner' AND p.name IN ('customers:write', 'customers:delete'))
+   OR (r.name = 'member' AND p.name = 'customers:write')
+ON CONFLICT DO NOTHING;
+
+-- +migrate Down
+DELETE FROM permissions WHERE name IN ('customers:write', 'customers:delete');
+DROP TABLE IF EXISTS customers;
@@ -0,0 +1,50 @@
+-- +migrate Up
+-- 受信メールテーブル作成（ダッシュボードの Mail 型に対応、受信箱は全ユーザーで共有）
+-- thread_id はスレッドの最初のメールのID、thread_subject は返信プレフィックスを除いて小文字化した件名
+CREATE TABLE IF NOT EXISTS mails (
+    id SERIAL PRIMARY KEY,
+    message_id VARCHAR(255) NOT NULL UNIQUE,
+    in_reply_to VARCHAR(255) NOT NULL DEFAULT '',
+    thread_id INTEGER NOT NULL,
+    thread_subject VARCHAR(998) NOT NULL,
+    from_name VARCHAR(255) NOT NULL DEFAULT '',
+    from_email VARCHAR(255) NOT NULL,
+    from_avatar_url TEXT NOT NULL DEFAULT '',
+    subject VARCHAR(998) NOT NULL,
+    body TEXT NOT NULL DEFAULT '',
+    date TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    search_vector tsvector GENERATED ALWAYS AS (
+        to_tsvector('simple', subject || ' ' || body || ' ' || from_name || ' ' || from_email)
+    ) STORED
+);
+
+CREATE INDEX IF NOT EXISTS idx_mails_thread_id ON mails(thread_id);
+CREATE INDEX IF NOT EXISTS idx_mails_thread_subject ON mails(thread_subject);
+CREATE INDEX IF NOT EXISTS idx_mails_from_email ON mails(from_email);
+CREATE INDEX IF NOT EXISTS idx_mails_search_vector ON mails USING GIN (search_vector);
+
+-- ユーザーごとの既読状態（行がなければ未読）
+CREATE TABLE IF NOT EXISTS mail_reads (
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    mail_id INTEGER NOT NULL REFERENCES mails(id) ON DELETE CASCADE,
+    read_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, mail_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_mail_reads_mail_id ON mail_reads(mail_id);
+
+-- メール登録の権限（services.DefaultRoles と同じ内容）
+INSERT INTO permissions (name, description) VALUES
+    ('mails:write', 'Deliver mails to the shared inbox')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id FROM roles r, permissions p
+WHERE r.name = 'owner' AND p.name = 'mails:write'
+ON CONFLICT DO NOTHING;
+
+-- +migrate Down
+DELETE FROM permissions WHERE name = 'mails:write';
+DROP TABLE IF EXISTS mail_reads;
+DROP TABLE IF EXISTS mails;
@@ -0,0 +1,20 @@
+-- +migrate Up
+-- 通知テーブル作成（ダッシュボードの Notification 型に対応、宛先ユーザーごとに1行）
+CREATE TABLE IF NOT EXISTS notifications (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    type VARCHAR(50) NOT NULL,
+    sender_name VARCHAR(255) NOT NULL DEFAULT '',
+    sender_email VARCHAR(255) NOT NULL DEFAULT '',
+    sender_avatar_url TEXT NOT NULL DEFAULT '',
+    body TEXT NOT NULL,
+    link TEXT NOT NULL DEFAULT '',
+    read_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_notifications_user_id_created_at ON notifications(user_id, created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_notifications_unread ON notifications(user_id) WHERE read_at IS NULL;
+
+-- +migrate Down
+DROP TABLE IF EXISTS notifications;
@@ -0,0 +1,25 @@
+-- +migrate Up
+-- 売上テーブル作成（ダッシュボードの Sale 型に対応、email は小文字に正規化して保存）
+-- 売上・注文数・顧客数の集計（/api/stats）は status = 'paid' の売上のみを対象とする
+CREATE TABLE IF NOT EXISTS sales (
+    id SERIAL PRIMARY KEY,
+    email VARCHAR(255) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'paid'
+        CHECK (status IN ('paid', 'failed', 'refunded')),
+    amount NUMERIC(12, 2) NOT NULL CHECK (amount >= 0),
+    date TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_sales_date ON sales(date);
+CREATE INDEX IF NOT EXISTS idx_sales_paid_date ON sales(date) WHERE status = 'paid';
+
+DROP TRIGGER IF EXISTS update_sales_updated_at ON sales;
+CREATE TRIGGER update_sales_updated_at
+    BEFORE UPDATE ON sales
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- +migrate Down
+DROP TABLE IF EXISTS sales;
@@ -0,0 +1,42 @@
+-- +migrate Up
+-- 売上台帳: 金額を通貨の最小単位の整数（USD ならセント）と通貨コードで保存し、返金の日時と実行者を記録する
+ALTER TABLE sales ALTER COLUMN amount TYPE BIGINT USING ROUND(amount * 100);
+ALTER TABLE sales ADD COLUMN IF NOT EXISTS currency CHAR(3) NOT NULL DEFAULT 'USD'
+    CHECK (currency ~ '^[A-Z]{3}$');
+ALTER TABLE sales ADD COLUMN IF NOT EXISTS refunded_at TIMESTAMPTZ;
+ALTER TABLE sales ADD COLUMN IF NOT EXISTS refunded_by INTEGER REFERENCES users(id) ON DELETE SET NULL;
+
+-- 返金済みの売上のみ返金日時を持つ（既存の返金済みの売上は更新日時を返金日時とする）
+UPDATE sales SET refunded_at = updated_at WHERE status = 'refunded' AND refunded_at IS NULL;
+ALTER TABLE sales ADD CONSTRAINT sales_refunded_at_check
+    CHECK ((status = 'refunded') = (refunded_at IS NOT NULL));
+
+-- 統計は通貨ごとに集計する
+DROP INDEX IF EXISTS idx_sales_paid_date;
+CREATE INDEX IF NOT EXISTS idx_sales_paid_currency_date ON sales(currency, date) WHERE status = 'paid';
+CREATE INDEX IF NOT EXISTS idx_sales_email ON sales(email);
+CREATE INDEX IF NOT EXISTS idx_sales_status ON sales(status);
+
+-- 売上の登録・返金の権限（services.DefaultRoles と同じ内容）
+INSERT INTO permissions (name, description) VALUES
+    ('sales:write', 'Record sales'),
+    ('sales:refund', 'Refund paid sales')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id FROM roles r, permissions p
+WHERE r.name = 'owner' AND p.name IN ('sales:write', 'sales:refund')
+ON CONFLICT DO NOTHING;
+
+-- +migrate Down
+-- 通貨の最小単位が 1/100 でない通貨の金額は元の単位に戻らない
+DELETE FROM permissions WHERE name IN ('sales:write', 'sales:refund');
+DROP INDEX IF EXISTS idx_sales_status;
+DROP INDEX IF EXISTS idx_sales_email;
+DROP INDEX IF EXISTS idx_sales_paid_currency_date;
+CREATE INDEX IF NOT EXISTS idx_sales_paid_date ON sales(date) WHERE status = 'paid';
+ALTER TABLE sales DROP CONSTRAINT IF EXISTS sales_refunded_at_check;
+ALTER TABLE sales DROP COLUMN IF EXISTS refunded_by;
+ALTER TABLE sales DROP COLUMN IF EXISTS refunded_at;
+ALTER TABLE sales DROP COLUMN IF EXISTS currency;
+ALTER TABLE sales ALTER COLUMN amount TYPE NUMERIC(12, 2) USING amount / 100.0;
@@ -0,0 +1,25 @@
+-- +migrate Up
+-- ユーザーのプロフィール（設定画面で編集する項目）
+-- username は小文字に正規化して保存し、未設定のユーザーは NULL です
+ALTER TABLE users ADD COLUMN IF NOT EXISTS username VARCHAR(30);
+ALTER TABLE users ADD COLUMN IF NOT EXISTS avatar_src TEXT;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS bio TEXT NOT NULL DEFAULT '';
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_username ON users(username);
+
+-- 確認待ちのメールアドレス変更（ユーザーごとに1件、確認トークンは SHA-256 ハッシュのみ保存）
+ALTER TABLE users ADD COLUMN IF NOT EXISTS pending_email VARCHAR(255);
+ALTER TABLE users ADD COLUMN IF NOT EXISTS email_change_token_hash CHAR(64);
+ALTER TABLE users ADD COLUMN IF NOT EXISTS email_change_expires_at TIMESTAMPTZ;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_email_change_token_hash ON users(email_change_token_hash);
+
+-- +migrate Down
+DROP INDEX IF EXISTS idx_users_email_change_token_hash;
+ALTER TABLE users DROP COLUMN IF EXISTS email_change_expires_at;
+ALTER TABLE users DROP COLUMN IF EXISTS email_change_token_hash;
+ALTER TABLE users DROP COLUMN IF EXISTS pending_email;
+DROP INDEX IF EXISTS idx_users_username;
+ALTER TABLE users DROP COLUMN IF EXISTS bio;
+ALTER TABLE users DROP COLUMN IF EXISTS avatar_src;
+ALTER TABLE users DROP COLUMN IF EXISTS username;
@@ -0,0 +1,16 @@
+-- +migrate Up
+-- 通知設定（ユーザー・通知の種類・チャネルごとに1行、行がない組み合わせは既定値を使用）
+CREATE TABLE IF NOT EXISTS notification_preferences (
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    type VARCHAR(50) NOT NULL,
+    channel VARCHAR(20) NOT NULL CHECK (channel IN ('in_app', 'email')),
+    enabled BOOLEAN NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, type, channel)
+);
+
+-- 通知の発行時に種類ごとに宛先ユーザーの設定をまとめて取得する
+CREATE INDEX IF NOT EXISTS idx_notification_preferences_type ON notification_preferences(type, channel);
+
+-- +migrate Down
+DROP TABLE IF EXISTS notification_preferences;
@@ -0,0 +1,14 @@
+-- +migrate Up
+-- 二要素認証コードの試行回数（mfa_token ごと・ユーザーごと）
+-- 複数のレプリカで上限を共有するため、プロセス内ではなくDBで数える
+CREATE TABLE IF NOT EXISTS mfa_attempts (
+    attempt_key VARCHAR(128) PRIMARY KEY,
+    count INTEGER NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL
+);
+
+-- 期限切れの記録の削除用
+CREATE INDEX IF NOT EXISTS idx_mfa_attempts_expires_at ON mfa_attempts(expires_at);
+
+-- +migrate Down
+DROP TABLE IF EXISTS mfa_attempts;
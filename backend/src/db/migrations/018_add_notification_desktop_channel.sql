@@ -0,0 +1,9 @@
+-- +migrate Up
+-- 通知設定にデスクトップ通知のチャネルを追加
+ALTER TABLE notification_preferences DROP CONSTRAINT IF EXISTS notification_preferences_channel_check;
+ALTER TABLE notification_preferences ADD CONSTRAINT notification_preferences_channel_check CHECK (channel IN ('in_app', 'email', 'desktop'));
+
+-- +migrate Down
+DELETE FROM notification_preferences WHERE channel = 'desktop';
+ALTER TABLE notification_preferences DROP CONSTRAINT IF EXISTS notification_preferences_channel_check;
+ALTER TABLE notification_preferences ADD CONSTRAINT notification_preferences_channel_check CHECK (channel IN ('in_app', 'email'));
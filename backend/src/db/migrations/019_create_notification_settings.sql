@@ -0,0 +1,12 @@
+-- +migrate Up
+-- 通知の種類によらない受信設定（設定画面の週次ダイジェスト・製品の更新情報・重要なお知らせ、行がないユーザーは既定値を使用）
+CREATE TABLE IF NOT EXISTS notification_settings (
+    user_id INTEGER PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    weekly_digest BOOLEAN NOT NULL,
+    product_updates BOOLEAN NOT NULL,
+    important_updates BOOLEAN NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS notification_settings;
@@ -0,0 +1,121 @@
+// Package migrations バイナリに埋め込んだバージョン付きSQLマイグレーションと、その実行器
+//
+// マイグレーションファイルは "<version>_<name>.sql" の形式で、
+// "-- +migrate Up" / "-- +migrate Down" の行で適用・ロールバック用のSQLを区切ります。
+package migrations
+
+import (
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+//go:embed *.sql
+var files embed.FS
+
+const (
+	upMarker   = "-- +migrate Up"
+	downMarker = "-- +migrate Down"
+)
+
+// fileNamePattern マイグレーションファイル名の形式（例: 001_create_hello_world_messages.sql）
+var fileNamePattern = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.sql$`)
+
+// Migration 1つのバージョン付きマイグレーション
+type Migration struct {
+	Version  int64  // バージョン番号（ファイル名の先頭の数値）
+	Name     string // 名前（ファイル名のバージョン以降）
+	Up       string // 適用用SQL
+	Down     string // ロールバック用SQL
+	Checksum string // ファイル内容のSHA-256（適用後の改変検知に使用）
+}
+
+// Embedded バイナリに埋め込まれたマイグレーションをバージョン順に取得
+func Embedded() ([]Migration, error) {
+	return Load(files)
+}
+
+// Load ファイルシステム直下の .sql ファイルからマイグレーションをバージョン順に読み込み
+func Load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	var migrations []Migration
+	seen := map[int64]string{}
+	for _, entry := range entries {
+		if entry.IsDir() || path.Ext(entry.Name()) != ".sql" {
+			continue
+		}
+
+		content, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration %s: %w", entry.Name(), err)
+		}
+
+		migration, err := parse(entry.Name(), string(content))
+		if err != nil {
+			return nil, err
+		}
+		if other, ok := seen[migration.Version]; ok {
+			return nil, fmt.Errorf("duplicate migration version %d: %s and %s", migration.Version, other, entry.Name())
+		}
+		seen[migration.Version] = entry.Name()
+
+		migrations = append(migrations, migration)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+// parse ファイル名と内容からマイグレーションを生成
+func parse(fileName, content string) (Migration, error) {
+	match := fileNamePattern.FindStringSubmatch(fileName)
+	if match == nil {
+		return Migration{}, fmt.Errorf("invalid migration file name %q (expected <version>_<name>.sql)", fileName)
+	}
+
+	version, err := strconv.ParseInt(match[1], 10, 64)
+	if err != nil || version <= 0 {
+		return Migration{}, fmt.Errorf("invalid migration version in %q", fileName)
+	}
+
+	upIndex := strings.Index(content, upMarker)
+	if upIndex < 0 {
+		return Migration{}, fmt.Errorf("migration %s has no %q section", fileName, upMarker)
+	}
+
+	up := content[upIndex+len(upMarker):]
+	down := ""
+	if downIndex := strings.Index(up, downMarker); downIndex >= 0 {
+		down = up[downIndex+len(downMarker):]
+		up = up[:downIndex]
+	}
+
+	up = strings.TrimSpace(up)
+	if up == "" {
+		return Migration{}, fmt.Errorf("migration %s has an empty %q section", fileName, upMarker)
+	}
+
+	sum := sha256.Sum256([]byte(content))
+
+	return Migration{
+		Version:  version,
+		Name:     match[2],
+		Up:       up,
+		Down:     strings.TrimSpace(down),
+		Checksum: hex.EncodeToString(sum[:]),
+	}, nil
+}
@@ -0,0 +1,244 @@
+package migrations
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+// TestEmbedded 埋め込みマイグレーションの読み込みテスト
+func TestEmbedded(t *testing.T) {
+	migrations, err := Embedded()
+	if err != nil {
+		t.Fatalf("Embedded() error = %v", err)
+	}
+	if len(migrations) == 0 {
+		t.Fatal("埋め込みマイグレーションが存在しない")
+	}
+
+	for i, migration := range migrations {
+		if i > 0 && migration.Version <= migrations[i-1].Version {
+			t.Errorf("バージョン順に並んでいない: %d after %d", migration.Version, migrations[i-1].Version)
+		}
+		if migration.Up == "" || migration.Down == "" {
+			t.Errorf("%03d_%s: Up/Downセクションが空", migration.Version, migration.Name)
+		}
+		if len(migration.Checksum) != 64 {
+			t.Errorf("%03d_%s: チェックサムが不正: %q", migration.Version, migration.Name, migration.Checksum)
+		}
+	}
+}
+
+// TestLoad ファイルシステムからの読み込みテスト
+func TestLoad(t *testing.T) {
+	t.Run("ParsesSectionsInVersionOrder", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"002_second.sql": {Data: []byte("-- +migrate Up\nCREATE TABLE b ();\n-- +migrate Down\nDROP TABLE b;\n")},
+			"001_first.sql":  {Data: []byte("-- comment\n-- +migrate Up\nCREATE TABLE a ();\n")},
+			"README.md":      {Data: []byte("ignored")},
+		}
+
+		migrations, err := Load(fsys)
+		if err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+		if len(migrations) != 2 {
+			t.Fatalf("Expected 2 migrations, got %d", len(migrations))
+		}
+
+		first, second := migrations[0], migrations[1]
+		if first.Version != 1 || first.Name != "first" || first.Up != "CREATE TABLE a ();" || first.Down != "" {
+			t.Errorf("1件目が不正: %+v", first)
+		}
+		if second.Version != 2 || second.Up != "CREATE TABLE b ();" || second.Down != "DROP TABLE b;" {
+			t.Errorf("2件目が不正: %+v", second)
+		}
+	})
+
+	t.Run("ChecksumChangesWithContent", func(t *testing.T) {
+		load := func(content string) Migration {
+			migrations, err := Load(fstest.MapFS{"001_a.sql": {Data: []byte(content)}})
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			return migrations[0]
+		}
+
+		original := load("-- +migrate Up\nSELECT 1;\n")
+		if original.Checksum != load("-- +migrate Up\nSELECT 1;\n").Checksum {
+			t.Error("同じ内容でチェックサムが異なる")
+		}
+		if original.Checksum == load("-- +migrate Up\nSELECT 2;\n").Checksum {
+			t.Error("内容が異なるのにチェックサムが同じ")
+		}
+	})
+
+	errorCases := []struct {
+		name string
+		fsys fstest.MapFS
+		want string
+	}{
+		{
+			name: "DuplicateVersion",
+			fsys: fstest.MapFS{
+				"001_a.sql":  {Data: []byte("-- +migrate Up\nSELECT 1;")},
+				"0001_b.sql": {Data: []byte("-- +migrate Up\nSELECT 1;")},
+			},
+			want: "duplicate migration version",
+		},
+		{
+			name: "InvalidFileName",
+			fsys: fstest.MapFS{"create_table.sql": {Data: []byte("-- +migrate Up\nSELECT 1;")}},
+			want: "invalid migration file name",
+		},
+		{
+			name: "MissingUpSection",
+			fsys: fstest.MapFS{"001_a.sql": {Data: []byte("SELECT 1;")}},
+			want: "has no",
+		},
+		{
+			name: "EmptyUpSection",
+			fsys: fstest.MapFS{"001_a.sql": {Data: []byte("-- +migrate Up\n-- +migrate Down\nSELECT 1;")}},
+			want: "empty",
+		},
+	}
+	for _, tc := range errorCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Load(tc.fsys)
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Errorf("Expected error containing %q, got %v", tc.want, err)
+			}
+		})
+	}
+}
+
+// testMigrations 計画テスト用のマイグレーション（3件目はロールバック不可）
+func testMigrations() []Migration {
+	return []Migration{
+		{Version: 1, Name: "one", Up: "up 1", Down: "down 1", Checksum: "c1"},
+		{Version: 2, Name: "two", Up: "up 2", Down: "down 2", Checksum: "c2"},
+		{Version: 3, Name: "three", Up: "up 3", Checksum: "c3"},
+	}
+}
+
+// appliedUpTo 指定バージョンまで適用済みの履歴
+func appliedUpTo(migrations []Migration, version int64) map[int64]AppliedMigration {
+	applied := map[int64]AppliedMigration{}
+	for _, migration := range migrations {
+		if migration.Version <= version {
+			applied[migration.Version] = AppliedMigration{
+				Version:   migration.Version,
+				Name:      migration.Name,
+				Checksum:  migration.Checksum,
+				AppliedAt: time.Now(),
+			}
+		}
+	}
+	return applied
+}
+
+// stepNames 実行計画の文字列表現
+func stepNames(steps []Step) []string {
+	names := make([]string, len(steps))
+	for i, step := range steps {
+		names[i] = step.String()
+	}
+	return names
+}
+
+// TestPlan 実行計画の作成テスト
+func TestPlan(t *testing.T) {
+	migrations := testMigrations()
+
+	tests := []struct {
+		name    string
+		applied int64
+		target  int64
+		want    []string
+	}{
+		{name: "UpFromEmpty", applied: 0, target: 3, want: []string{"up 001_one", "up 002_two", "up 003_three"}},
+		{name: "UpPartial", applied: 1, target: 2, want: []string{"up 002_two"}},
+		{name: "AlreadyAtTarget", applied: 2, target: 2, want: []string{}},
+		{name: "DownToVersion", applied: 2, target: 1, want: []string{"down 002_two"}},
+		{name: "DownToZero", applied: 2, target: 0, want: []string{"down 002_two", "down 001_one"}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			steps, err := plan(migrations, appliedUpTo(migrations, tc.applied), tc.target)
+			if err != nil {
+				t.Fatalf("plan() error = %v", err)
+			}
+			got := stepNames(steps)
+			if strings.Join(got, ",") != strings.Join(tc.want, ",") {
+				t.Errorf("Expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+
+	t.Run("UnknownTarget", func(t *testing.T) {
+		if _, err := plan(migrations, nil, 9); !errors.Is(err, ErrUnknownVersion) {
+			t.Errorf("Expected ErrUnknownVersion, got %v", err)
+		}
+	})
+
+	t.Run("Irreversible", func(t *testing.T) {
+		if _, err := plan(migrations, appliedUpTo(migrations, 3), 1); !errors.Is(err, ErrIrreversible) {
+			t.Errorf("Expected ErrIrreversible, got %v", err)
+		}
+	})
+}
+
+// TestVerify 適用済みマイグレーションの検証テスト
+func TestVerify(t *testing.T) {
+	migrations := testMigrations()
+
+	t.Run("Consistent", func(t *testing.T) {
+		if err := verify(migrations, appliedUpTo(migrations, 3)); err != nil {
+			t.Errorf("verify() error = %v", err)
+		}
+	})
+
+	t.Run("ChecksumMismatch", func(t *testing.T) {
+		applied := appliedUpTo(migrations, 2)
+		modified := applied[2]
+		modified.Checksum = "changed"
+		applied[2] = modified
+
+		err := verify(migrations, applied)
+		if !errors.Is(err, ErrChecksumMismatch) || !strings.Contains(err.Error(), "002_two") {
+			t.Errorf("Expected ErrChecksumMismatch for 002_two, got %v", err)
+		}
+	})
+
+	t.Run("AppliedButMissing", func(t *testing.T) {
+		applied := appliedUpTo(migrations, 3)
+		applied[4] = AppliedMigration{Version: 4, Name: "four", Checksum: "c4"}
+
+		if err := verify(migrations, applied); !errors.Is(err, ErrUnknownVersion) {
+			t.Errorf("Expected ErrUnknownVersion, got %v", err)
+		}
+	})
+}
+
+// TestBuildStatus 適用状況の作成テスト
+func TestBuildStatus(t *testing.T) {
+	migrations := testMigrations()
+	applied := appliedUpTo(migrations, 1)
+	applied[4] = AppliedMigration{Version: 4, Name: "four", Checksum: "c4"}
+
+	statuses := buildStatus(migrations, applied)
+	if len(statuses) != 4 {
+		t.Fatalf("Expected 4 statuses, got %d", len(statuses))
+	}
+	if !statuses[0].Applied || statuses[0].AppliedAt == nil || statuses[0].Modified {
+		t.Errorf("001が適用済みになっていない: %+v", statuses[0])
+	}
+	if statuses[1].Applied || statuses[2].Applied {
+		t.Error("未適用のマイグレーションが適用済みになっている")
+	}
+	if statuses[3].Version != 4 || !statuses[3].Missing {
+		t.Errorf("ファイルのない適用済みマイグレーションが検出されていない: %+v", statuses[3])
+	}
+}
@@ -0,0 +1,358 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// advisoryLockID マイグレーション実行を直列化するアドバイザリロックのキー
+// 複数インスタンスが同時に起動しても、適用は1プロセスずつ行われます
+const advisoryLockID int64 = 7_245_218_935
+
+var (
+	// ErrChecksumMismatch 適用済みマイグレーションのファイル内容が変更されている
+	ErrChecksumMismatch = errors.New("migration checksum mismatch")
+	// ErrUnknownVersion 指定されたバージョンのマイグレーションが存在しない
+	ErrUnknownVersion = errors.New("unknown migration version")
+	// ErrIrreversible Down セクションがなくロールバックできない
+	ErrIrreversible = errors.New("migration is irreversible")
+)
+
+// AppliedMigration schema_migrations に記録された適用済みマイグレーション
+type AppliedMigration struct {
+	Version   int64
+	Name      string
+	Checksum  string
+	AppliedAt time.Time
+}
+
+// Status マイグレーションごとの適用状況
+type Status struct {
+	Version   int64      // バージョン番号
+	Name      string     // 名前
+	Applied   bool       // 適用済みか
+	AppliedAt *time.Time // 適用日時（未適用の場合は nil）
+	Modified  bool       // 適用後にファイル内容が変更されているか
+	Missing   bool       // 適用済みだが対応するファイルが存在しないか
+}
+
+// Step 実行計画の1ステップ
+type Step struct {
+	Migration Migration
+	Up        bool // true: 適用, false: ロールバック
+}
+
+// String ログ出力用の表現（例: "up 001_create_hello_world_messages"）
+func (s Step) String() string {
+	direction := "down"
+	if s.Up {
+		direction = "up"
+	}
+	return fmt.Sprintf("%s %03d_%s", direction, s.Migration.Version, s.Migration.Name)
+}
+
+// Migrator PostgreSQLにマイグレーションを適用する実行器
+type Migrator struct {
+	db         *sql.DB
+	migrations []Migration
+}
+
+// New 埋め込みマイグレーションを使用する実行器を新規作成
+func New(db *sql.DB) (*Migrator, error) {
+	migrations, err := Embedded()
+	if err != nil {
+		return nil, err
+	}
+	return NewWithMigrations(db, migrations), nil
+}
+
+// NewWithMigrations 任意のマイグレーションを使用する実行器を新規作成
+func NewWithMigrations(db *sql.DB, migrations []Migration) *Migrator {
+	return &Migrator{db: db, migrations: migrations}
+}
+
+// Up 未適用のマイグレーションを全て適用し、実行したステップを返す
+func (m *Migrator) Up(ctx context.Context) ([]Step, error) {
+	return m.run(ctx, func(applied map[int64]AppliedMigration) (int64, error) {
+		return latestVersion(m.migrations), nil
+	})
+}
+
+// Down 適用済みのマイグレーションを新しい順に steps 件ロールバックし、実行したステップを返す
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Step, error) {
+	if steps <= 0 {
+		return nil, fmt.Errorf("steps must be positive: %d", steps)
+	}
+	return m.run(ctx, func(applied map[int64]AppliedMigration) (int64, error) {
+		versions := appliedVersions(applied)
+		if steps >= len(versions) {
+			return 0, nil
+		}
+		return versions[len(versions)-1-steps], nil
+	})
+}
+
+// Goto 指定バージョンまで適用またはロールバックし、実行したステップを返す
+// version に 0 を指定すると全てロールバックします
+func (m *Migrator) Goto(ctx context.Context, version int64) ([]Step, error) {
+	return m.run(ctx, func(applied map[int64]AppliedMigration) (int64, error) {
+		return version, nil
+	})
+}
+
+// Status 全マイグレーションの適用状況をバージョン順に取得
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	var statuses []Status
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		applied, err := loadApplied(ctx, conn)
+		if err != nil {
+			return err
+		}
+		statuses = buildStatus(m.migrations, applied)
+		return nil
+	})
+	return statuses, err
+}
+
+// run ロックを取得し、target が返すバージョンまでの計画を実行
+func (m *Migrator) run(ctx context.Context, target func(map[int64]AppliedMigration) (int64, error)) ([]Step, error) {
+	var done []Step
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		applied, err := loadApplied(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if err := verify(m.migrations, applied); err != nil {
+			return err
+		}
+
+		version, err := target(applied)
+		if err != nil {
+			return err
+		}
+		steps, err := plan(m.migrations, applied, version)
+		if err != nil {
+			return err
+		}
+
+		for _, step := range steps {
+			if err := apply(ctx, conn, step); err != nil {
+				return err
+			}
+			done = append(done, step)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// withLock 専用コネクション上でアドバイザリロックを保持したまま fn を実行
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	if m.db == nil {
+		return errors.New("database connection is not available")
+	}
+
+	// セッション単位のロックのため、取得から解放まで同じコネクションを使用
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to acquire connection: %w", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock($1)`, advisoryLockID); err != nil {
+		return fmt.Errorf("failed to acquire migration lock: %w", err)
+	}
+	defer conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock($1)`, advisoryLockID)
+
+	if _, err := conn.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version BIGINT PRIMARY KEY,
+			name VARCHAR(255) NOT NULL,
+			checksum CHAR(64) NOT NULL,
+			applied_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+		)
+	`); err != nil {
+		return fmt.Errorf("failed to create schema_migrations table: %w", err)
+	}
+
+	return fn(conn)
+}
+
+// loadApplied 適用済みマイグレーションを取得
+func loadApplied(ctx context.Context, conn *sql.Conn) (map[int64]AppliedMigration, error) {
+	rows, err := conn.QueryContext(ctx, `SELECT version, name, checksum, applied_at FROM schema_migrations`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query schema_migrations: %w", err)
+	}
+	defer rows.Close()
+
+	applied := map[int64]AppliedMigration{}
+	for rows.Next() {
+		var a AppliedMigration
+		if err := rows.Scan(&a.Version, &a.Name, &a.Checksum, &a.AppliedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan schema_migrations: %w", err)
+		}
+		applied[a.Version] = a
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating schema_migrations: %w", err)
+	}
+
+	return applied, nil
+}
+
+// apply 1ステップをトランザクション内で実行し、schema_migrations に記録
+func apply(ctx context.Context, conn *sql.Conn, step Step) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	migration := step.Migration
+	if step.Up {
+		if _, err := tx.ExecContext(ctx, migration.Up); err != nil {
+			return fmt.Errorf("%s failed: %w", step, err)
+		}
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO schema_migrations (version, name, checksum) VALUES ($1, $2, $3)`,
+			migration.Version, migration.Name, migration.Checksum,
+		); err != nil {
+			return fmt.Errorf("failed to record %s: %w", step, err)
+		}
+	} else {
+		if _, err := tx.ExecContext(ctx, migration.Down); err != nil {
+			return fmt.Errorf("%s failed: %w", step, err)
+		}
+		if _, err := tx.ExecContext(ctx, `DELETE FROM schema_migrations WHERE version = $1`, migration.Version); err != nil {
+			return fmt.Errorf("failed to record %s: %w", step, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit %s: %w", step, err)
+	}
+	return nil
+}
+
+// verify 適用済みマイグレーションがファイルと一致しているか検証
+// 適用後に内容が変更されたファイルや、このバイナリが知らないバージョンがあればエラーを返します
+func verify(migrations []Migration, applied map[int64]AppliedMigration) error {
+	known := map[int64]Migration{}
+	for _, migration := range migrations {
+		known[migration.Version] = migration
+	}
+
+	var modified, missing []string
+	for _, version := range appliedVersions(applied) {
+		migration, ok := known[version]
+		switch {
+		case !ok:
+			missing = append(missing, fmt.Sprintf("%03d_%s", version, applied[version].Name))
+		case migration.Checksum != applied[version].Checksum:
+			modified = append(modified, fmt.Sprintf("%03d_%s", version, migration.Name))
+		}
+	}
+
+	if len(modified) > 0 {
+		return fmt.Errorf("%w: %s modified after being applied", ErrChecksumMismatch, strings.Join(modified, ", "))
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("%w: %s applied but not found", ErrUnknownVersion, strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// plan target までの実行計画を作成
+// target より新しい適用済みマイグレーションを新しい順にロールバックした後、
+// target 以下の未適用マイグレーションを古い順に適用します
+func plan(migrations []Migration, applied map[int64]AppliedMigration, target int64) ([]Step, error) {
+	if target != 0 {
+		found := false
+		for _, migration := range migrations {
+			found = found || migration.Version == target
+		}
+		if !found {
+			return nil, fmt.Errorf("%w: %d", ErrUnknownVersion, target)
+		}
+	}
+
+	var steps []Step
+	for i := len(migrations) - 1; i >= 0; i-- {
+		migration := migrations[i]
+		if _, ok := applied[migration.Version]; !ok || migration.Version <= target {
+			continue
+		}
+		if migration.Down == "" {
+			return nil, fmt.Errorf("%w: %03d_%s", ErrIrreversible, migration.Version, migration.Name)
+		}
+		steps = append(steps, Step{Migration: migration, Up: false})
+	}
+	for _, migration := range migrations {
+		if _, ok := applied[migration.Version]; ok || migration.Version > target {
+			continue
+		}
+		steps = append(steps, Step{Migration: migration, Up: true})
+	}
+
+	return steps, nil
+}
+
+// buildStatus ファイルと適用履歴から適用状況を作成
+func buildStatus(migrations []Migration, applied map[int64]AppliedMigration) []Status {
+	statuses := make([]Status, 0, len(migrations))
+	known := map[int64]bool{}
+	for _, migration := range migrations {
+		known[migration.Version] = true
+		status := Status{Version: migration.Version, Name: migration.Name}
+		if a, ok := applied[migration.Version]; ok {
+			appliedAt := a.AppliedAt
+			status.Applied = true
+			status.AppliedAt = &appliedAt
+			status.Modified = a.Checksum != migration.Checksum
+		}
+		statuses = append(statuses, status)
+	}
+
+	for _, a := range applied {
+		if known[a.Version] {
+			continue
+		}
+		appliedAt := a.AppliedAt
+		statuses = append(statuses, Status{
+			Version:   a.Version,
+			Name:      a.Name,
+			Applied:   true,
+			AppliedAt: &appliedAt,
+			Missing:   true,
+		})
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Version < statuses[j].Version
+	})
+	return statuses
+}
+
+// latestVersion 最新のマイグレーションバージョン（マイグレーションがない場合は 0）
+func latestVersion(migrations []Migration) int64 {
+	if len(migrations) == 0 {
+		return 0
+	}
+	return migrations[len(migrations)-1].Version
+}
+
+// appliedVersions 適用済みバージョンを昇順で取得
+func appliedVersions(applied map[int64]AppliedMigration) []int64 {
+	versions := make([]int64, 0, len(applied))
+	for version := range applied {
+		versions = append(versions, version)
+	}
+	sort.Slice(versions, func(i, j int) bool { return versions[i] < versions[j] })
+	return versions
+}
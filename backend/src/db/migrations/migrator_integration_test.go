@@ -0,0 +1,124 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	_ "github.com/lib/pq"
+)
+
+// setupTestDB テスト用DB接続
+func setupTestDB(t *testing.T) *sql.DB {
+	dsn := "host=localhost port=15434 user=sampleuser password=samplepass dbname=sampledb_test sslmode=disable"
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatalf("DB接続失敗: %v", err)
+	}
+	// DB起動待ち
+	for i := 0; i < 10; i++ {
+		if err := db.Ping(); err == nil {
+			return db
+		}
+		time.Sleep(1 * time.Second)
+	}
+	t.Fatal("DB起動待ちタイムアウト")
+	return nil
+}
+
+// TestMigratorIntegration 実DBでの適用・ロールバックの往復テスト
+// 共有テストDBのスキーマに影響しないよう、専用テーブルのみを操作するマイグレーションを使用します
+func TestMigratorIntegration(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	ctx := context.Background()
+
+	// 実スキーマのバージョンと衝突しない大きな番号を使用
+	base := time.Now().Unix()
+	table := fmt.Sprintf("migrator_test_%d", base)
+	migrations := []Migration{
+		{Version: base, Name: "create", Up: fmt.Sprintf("CREATE TABLE %s (id INT)", table), Down: fmt.Sprintf("DROP TABLE %s", table), Checksum: "a"},
+		{Version: base + 1, Name: "insert", Up: fmt.Sprintf("INSERT INTO %s VALUES (1)", table), Down: fmt.Sprintf("DELETE FROM %s", table), Checksum: "b"},
+	}
+	cleanup := func() {
+		db.Exec(fmt.Sprintf("DROP TABLE IF EXISTS %s", table))
+		db.Exec(`DELETE FROM schema_migrations WHERE version >= $1`, base)
+	}
+	defer cleanup()
+
+	// 既存の実マイグレーションは適用済みとして扱う
+	embedded, err := Embedded()
+	if err != nil {
+		t.Fatalf("Embedded() error = %v", err)
+	}
+	if _, err := NewWithMigrations(db, embedded).Up(ctx); err != nil {
+		t.Fatalf("実マイグレーションの適用失敗: %v", err)
+	}
+	migrator := NewWithMigrations(db, append(embedded, migrations...))
+
+	t.Run("ConcurrentUpAppliesOnce", func(t *testing.T) {
+		var wg sync.WaitGroup
+		var mu sync.Mutex
+		total := 0
+		for i := 0; i < 3; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				steps, err := migrator.Up(ctx)
+				if err != nil {
+					t.Errorf("Up() error = %v", err)
+					return
+				}
+				mu.Lock()
+				total += len(steps)
+				mu.Unlock()
+			}()
+		}
+		wg.Wait()
+
+		if total != 2 {
+			t.Errorf("Expected 2 steps in total, got %d", total)
+		}
+	})
+
+	t.Run("Status", func(t *testing.T) {
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			t.Fatalf("Status() error = %v", err)
+		}
+		for _, status := range statuses {
+			if !status.Applied || status.Modified || status.Missing {
+				t.Errorf("適用状況が不正: %+v", status)
+			}
+		}
+	})
+
+	t.Run("DownAndGoto", func(t *testing.T) {
+		steps, err := migrator.Down(ctx, 1)
+		if err != nil || len(steps) != 1 || steps[0].Migration.Version != base+1 {
+			t.Fatalf("Down(1)が不正: steps=%v err=%v", steps, err)
+		}
+
+		var count int
+		if err := db.QueryRow(fmt.Sprintf("SELECT COUNT(*) FROM %s", table)).Scan(&count); err != nil || count != 0 {
+			t.Errorf("ロールバックが反映されていない: count=%d err=%v", count, err)
+		}
+
+		steps, err = migrator.Goto(ctx, base+1)
+		if err != nil || len(steps) != 1 || !steps[0].Up {
+			t.Fatalf("Goto()が不正: steps=%v err=%v", steps, err)
+		}
+	})
+
+	t.Run("ChecksumMismatch", func(t *testing.T) {
+		modified := append(append([]Migration{}, embedded...), migrations...)
+		modified[len(modified)-1].Checksum = "changed"
+
+		if _, err := NewWithMigrations(db, modified).Up(ctx); err == nil {
+			t.Error("改変されたマイグレーションでエラーが返らない")
+		}
+	})
+}
@@ -0,0 +1,109 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"backend/models"
+)
+
+// ListAPIKeysHandler APIキー一覧
+// @Summary APIキー一覧
+// @Description ログイン中のユーザーの失効していないAPIキー（prefix・スコープ・有効期限・最終利用日時）を取得
+// @Description キー本体は作成時にのみ返されます
+// @Tags api-keys
+// @Produce json
+// @Success 200 {object} models.SuccessResponse{data=[]models.APIKey}
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Security BearerAuth
+// @Router /api/auth/api-keys [get]
+func (h *AuthHandler) ListAPIKeysHandler(w http.ResponseWriter, r *http.Request) {
+	user, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	keys, err := h.service.ListAPIKeys(user)
+	if err != nil {
+		sendError(w, err, "error.get_api_keys")
+		return
+	}
+
+	models.SendSuccessResponse(w, "api_key.list_retrieved", keys)
+}
+
+// CreateAPIKeyHandler APIキー作成
+// @Summary APIキー作成
+// @Description 権限をスコープで絞り込んだ個人用APIキーを作成（key は再表示できません）
+// @Description スコープには自分が持っている権限のみ指定できます
+// @Tags api-keys
+// @Accept json
+// @Produce json
+// @Param request body models.CreateAPIKeyRequest true "Create API Key Request"
+// @Success 201 {object} models.SuccessResponse{data=models.CreatedAPIKeyResponse}
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
+// @Failure 413 {object} models.ErrorResponse
+// @Failure 415 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Security BearerAuth
+// @Router /api/auth/api-keys [post]
+func (h *AuthHandler) CreateAPIKeyHandler(w http.ResponseWriter, r *http.Request) {
+	user, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	var request models.CreateAPIKeyRequest
+	if !decodeJSON(w, r, &request) {
+		return
+	}
+
+	created, err := h.service.CreateAPIKey(user, &request)
+	if err != nil {
+		sendError(w, err, "error.create_api_key")
+		return
+	}
+
+	models.SendCreatedResponse(w, "api_key.created", created)
+}
+
+// RevokeAPIKeyHandler APIキー失効
+// @Summary APIキー失効
+// @Description 指定したAPIキーを失効させ、以降のリクエストで使えなくする
+// @Tags api-keys
+// @Produce json
+// @Param id path int true "API Key ID"
+// @Success 200 {object} models.SuccessResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Security BearerAuth
+// @Router /api/auth/api-keys/{id} [delete]
+func (h *AuthHandler) RevokeAPIKeyHandler(w http.ResponseWriter, r *http.Request) {
+	user, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		models.SendValidationError(w, "request.invalid_api_key_id")
+		return
+	}
+
+	if err := h.service.RevokeAPIKey(user, id); err != nil {
+		sendError(w, err, "error.revoke_api_key")
+		return
+	}
+
+	models.SendSuccessResponse(w, "api_key.revoked", nil)
+}
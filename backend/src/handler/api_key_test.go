@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	custommiddleware "backend/middleware"
+	"backend/models"
+)
+
+// TestAPIKeyHandlers APIキーの作成・一覧・失効ハンドラーのテスト
+func TestAPIKeyHandlers(t *testing.T) {
+	h := newTestAuthHandler(t)
+	registered, err := h.service.Register(&models.RegisterRequest{Email: "apikeys@example.com", Password: "password123", Name: "API Keys"}, models.ClientInfo{})
+	if err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	user, err := h.service.Authenticate(registered.AccessToken)
+	if err != nil {
+		t.Fatalf("Authenticate() error = %v", err)
+	}
+
+	serveAs := func(handlerFunc http.HandlerFunc, method, body, id string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, "/", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		req = req.WithContext(custommiddleware.WithUser(req.Context(), user))
+		if id != "" {
+			req = withURLParam(req, "id", id)
+		}
+		w := httptest.NewRecorder()
+		handlerFunc(w, req)
+		return w
+	}
+
+	if w := serveAs(h.CreateAPIKeyHandler, "POST", `{"name":"CI","scopes":["unknown"]}`, ""); w.Code != http.StatusBadRequest {
+		t.Errorf("Create invalid scope: expected 400, got %d", w.Code)
+	}
+
+	w := serveAs(h.CreateAPIKeyHandler, "POST", `{"name":"CI","scopes":["messages:create"],"expires_at":"2099-01-01T00:00:00Z"}`, "")
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Create: expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	var created struct {
+		Data models.CreatedAPIKeyResponse `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &created); err != nil || created.Data.Key == "" || created.Data.ExpiresAt == nil {
+		t.Fatalf("Unexpected created key: %s", w.Body.String())
+	}
+
+	w = serveAs(h.ListAPIKeysHandler, "GET", "", "")
+	if w.Code != http.StatusOK || strings.Contains(w.Body.String(), created.Data.Key) || strings.Contains(w.Body.String(), `"key"`) {
+		t.Errorf("List: unexpected response %d: %s", w.Code, w.Body.String())
+	}
+
+	id := strconv.Itoa(created.Data.ID)
+	if w := serveAs(h.RevokeAPIKeyHandler, "DELETE", "", "abc"); w.Code != http.StatusBadRequest {
+		t.Errorf("Revoke invalid ID: expected 400, got %d", w.Code)
+	}
+	if w := serveAs(h.RevokeAPIKeyHandler, "DELETE", "", id); w.Code != http.StatusOK {
+		t.Errorf("Revoke: expected 200, got %d", w.Code)
+	}
+	if w := serveAs(h.RevokeAPIKeyHandler, "DELETE", "", id); w.Code != http.StatusNotFound {
+		t.Errorf("Revoke again: expected 404, got %d", w.Code)
+	}
+}
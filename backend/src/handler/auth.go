@@ -0,0 +1,232 @@
+package handler
+
+import (
+	"errors"
+	"net"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	custommiddleware "backend/middleware"
+	"backend/models"
+	"backend/services"
+)
+
+// maxUserAgentLength セッションに保存する User-Agent の最大長
+const maxUserAgentLength = 512
+
+// AuthHandler 認証ハンドラー構造体
+type AuthHandler struct {
+	service *services.AuthService
+}
+
+// NewAuthHandler 認証ハンドラーを新規作成
+func NewAuthHandler(service *services.AuthService) *AuthHandler {
+	return &AuthHandler{
+		service: service,
+	}
+}
+
+// RegisterHandler ユーザー登録
+// @Summary ユーザー登録
+// @Description ユーザーを登録し、アクセストークンとリフレッシュトークンを発行
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body models.RegisterRequest true "Register Request"
+// @Success 201 {object} models.SuccessResponse{data=models.AuthResponse}
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
+// @Failure 413 {object} models.ErrorResponse
+// @Failure 415 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /api/auth/register [post]
+func (h *AuthHandler) RegisterHandler(w http.ResponseWriter, r *http.Request) {
+	var request models.RegisterRequest
+	if !decodeJSON(w, r, &request) {
+		return
+	}
+
+	result, err := h.service.Register(&request, clientInfo(r))
+	if err != nil {
+		sendError(w, err, "error.register_user")
+		return
+	}
+
+	models.SendCreatedResponse(w, "auth.registered", result)
+}
+
+// LoginHandler ログイン
+// @Summary ログイン
+// @Description メールアドレスとパスワードで認証し、アクセストークンとリフレッシュトークンを発行
+// @Description 二要素認証が有効なユーザーにはトークンの代わりに mfa_token を返します（/api/auth/login/mfa で完了）
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body models.LoginRequest true "Login Request"
+// @Success 200 {object} models.SuccessResponse{data=models.AuthResponse}
+// @Success 200 {object} models.SuccessResponse{data=models.MFAChallengeResponse}
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 413 {object} models.ErrorResponse
+// @Failure 415 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /api/auth/login [post]
+func (h *AuthHandler) LoginHandler(w http.ResponseWriter, r *http.Request) {
+	var request models.LoginRequest
+	if !decodeJSON(w, r, &request) {
+		return
+	}
+
+	result, err := h.service.Login(&request, clientInfo(r))
+	if err != nil {
+		var mfaRequired *services.MFARequiredError
+		if errors.As(err, &mfaRequired) {
+			models.SendSuccessResponse(w, "auth.mfa_required", mfaRequired.Challenge)
+			return
+		}
+		sendError(w, err, "error.log_in")
+		return
+	}
+
+	models.SendSuccessResponse(w, "auth.logged_in", result)
+}
+
+// RefreshHandler トークン再発行
+// @Summary トークン再発行
+// @Description リフレッシュトークンをローテーションし、新しいアクセストークンとリフレッシュトークンを発行
+// @Description 使用済みのリフレッシュトークンが再利用された場合はセッション全体を失効させます
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body models.RefreshTokenRequest true "Refresh Token Request"
+// @Success 200 {object} models.SuccessResponse{data=models.AuthResponse}
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 413 {object} models.ErrorResponse
+// @Failure 415 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /api/auth/refresh [post]
+func (h *AuthHandler) RefreshHandler(w http.ResponseWriter, r *http.Request) {
+	var request models.RefreshTokenRequest
+	if !decodeJSON(w, r, &request) {
+		return
+	}
+
+	result, err := h.service.Refresh(&request, clientInfo(r))
+	if err != nil {
+		if errors.Is(err, services.ErrRefreshTokenReused) {
+			models.SendUnauthorizedError(w, "auth.refresh_token_reused")
+			return
+		}
+		if errors.Is(err, services.ErrInvalidToken) {
+			models.SendUnauthorizedError(w, "auth.invalid_refresh_token")
+			return
+		}
+		sendError(w, err, "error.refresh_token")
+		return
+	}
+
+	models.SendSuccessResponse(w, "auth.token_refreshed", result)
+}
+
+// ListSessionsHandler セッション一覧
+// @Summary セッション一覧
+// @Description ログイン中のユーザーの有効なセッション（端末・IP・最終利用日時）を取得
+// @Tags auth
+// @Produce json
+// @Success 200 {object} models.SuccessResponse{data=[]models.Session}
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Security BearerAuth
+// @Router /api/auth/sessions [get]
+func (h *AuthHandler) ListSessionsHandler(w http.ResponseWriter, r *http.Request) {
+	user, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	sessions, err := h.service.ListSessions(user)
+	if err != nil {
+		sendError(w, err, "error.get_sessions")
+		return
+	}
+
+	models.SendSuccessResponse(w, "session.list_retrieved", sessions)
+}
+
+// RevokeSessionHandler セッション失効
+// @Summary セッション失効
+// @Description 指定したセッションを失効させ、そのリフレッシュトークンとアクセストークンを無効化
+// @Tags auth
+// @Produce json
+// @Param id path string true "Session ID"
+// @Success 200 {object} models.SuccessResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Security BearerAuth
+// @Router /api/auth/sessions/{id} [delete]
+func (h *AuthHandler) RevokeSessionHandler(w http.ResponseWriter, r *http.Request) {
+	user, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.service.RevokeSession(user, chi.URLParam(r, "id")); err != nil {
+		sendError(w, err, "error.revoke_session")
+		return
+	}
+
+	models.SendSuccessResponse(w, "session.revoked", nil)
+}
+
+// RevokeOtherSessionsHandler 他のセッションを一括失効
+// @Summary 他のセッションを一括失効
+// @Description 現在のセッション以外の有効なセッションを全て失効
+// @Tags auth
+// @Produce json
+// @Success 200 {object} models.SuccessResponse{data=models.RevokeSessionsResponse}
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Security BearerAuth
+// @Router /api/auth/sessions [delete]
+func (h *AuthHandler) RevokeOtherSessionsHandler(w http.ResponseWriter, r *http.Request) {
+	user, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	revoked, err := h.service.RevokeOtherSessions(user)
+	if err != nil {
+		sendError(w, err, "error.revoke_sessions")
+		return
+	}
+
+	models.SendSuccessResponse(w, "session.others_revoked", &models.RevokeSessionsResponse{Revoked: revoked})
+}
+
+// requireUser コンテキストから認証済みユーザーを取得（RequireAuth が適用されていない場合は401を送信）
+func requireUser(w http.ResponseWriter, r *http.Request) (*models.User, bool) {
+	user, ok := custommiddleware.UserFromContext(r.Context())
+	if !ok {
+		models.SendUnauthorizedError(w, "auth.required")
+	}
+	return user, ok
+}
+
+// clientInfo リクエストからセッションに記録するクライアント情報を取得
+// IP は chimiddleware.RealIP により X-Forwarded-For / X-Real-IP が反映された RemoteAddr を使用します
+func clientInfo(r *http.Request) models.ClientInfo {
+	ip := r.RemoteAddr
+	if host, _, err := net.SplitHostPort(ip); err == nil {
+		ip = host
+	}
+
+	userAgent := r.UserAgent()
+	if len(userAgent) > maxUserAgentLength {
+		userAgent = userAgent[:maxUserAgentLength]
+	}
+
+	return models.ClientInfo{UserAgent: userAgent, IP: ip}
+}
@@ -0,0 +1,196 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	custommiddleware "backend/middleware"
+	"backend/models"
+	"backend/services"
+	"backend/utils"
+)
+
+// newTestAuthHandler メモリリポジトリを使用するテスト用認証ハンドラー
+func newTestAuthHandler(t *testing.T) *AuthHandler {
+	t.Helper()
+
+	hasher, err := services.NewPasswordHasher(utils.PasswordHashBcrypt)
+	if err != nil {
+		t.Fatalf("NewPasswordHasher() error = %v", err)
+	}
+	tokens, err := services.NewTokenManager(services.TokenConfig{
+		Algorithm:  utils.JWTAlgorithmHS256,
+		Secret:     "test-secret",
+		AccessTTL:  15 * time.Minute,
+		RefreshTTL: time.Hour,
+	})
+	if err != nil {
+		t.Fatalf("NewTokenManager() error = %v", err)
+	}
+	repos, err := services.NewRepositories(utils.StorageDriverMemory, nil)
+	if err != nil {
+		t.Fatalf("NewRepositories() error = %v", err)
+	}
+	service, err := services.NewAuthService(repos, hasher, tokens)
+	if err != nil {
+		t.Fatalf("NewAuthService() error = %v", err)
+	}
+	return NewAuthHandler(service)
+}
+
+// serveAuth 認証ハンドラーにJSONリクエストを送信
+func serveAuth(handlerFunc http.HandlerFunc, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("POST", "/", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	handlerFunc(w, req)
+	return w
+}
+
+// TestAuthHandlers 登録・ログイン・再発行ハンドラーのテスト
+func TestAuthHandlers(t *testing.T) {
+	h := newTestAuthHandler(t)
+
+	w := serveAuth(h.RegisterHandler, `{"email":"alice@example.com","password":"password123","name":"Alice"}`)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Register: expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var registered struct {
+		Data models.AuthResponse `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &registered); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if registered.Data.AccessToken == "" || registered.Data.RefreshToken == "" || registered.Data.User == nil {
+		t.Errorf("Unexpected register response: %s", w.Body.String())
+	}
+
+	tests := []struct {
+		name           string
+		handler        http.HandlerFunc
+		body           string
+		expectedStatus int
+		expectedError  string
+	}{
+		{"Register invalid body", h.RegisterHandler, `{`, http.StatusBadRequest, "validation_error"},
+		{"Register invalid email", h.RegisterHandler, `{"email":"x","password":"password123","name":"A"}`, http.StatusBadRequest, "validation_error"},
+		{"Register duplicate", h.RegisterHandler, `{"email":"alice@example.com","password":"password123","name":"A"}`, http.StatusConflict, "conflict"},
+		{"Login success", h.LoginHandler, `{"email":"alice@example.com","password":"password123"}`, http.StatusOK, ""},
+		{"Login wrong password", h.LoginHandler, `{"email":"alice@example.com","password":"wrong-password"}`, http.StatusUnauthorized, "unauthorized"},
+		{"Login missing password", h.LoginHandler, `{"email":"alice@example.com"}`, http.StatusBadRequest, "validation_error"},
+		{"Refresh success", h.RefreshHandler, `{"refresh_token":"` + registered.Data.RefreshToken + `"}`, http.StatusOK, ""},
+		{"Refresh with access token", h.RefreshHandler, `{"refresh_token":"` + registered.Data.AccessToken + `"}`, http.StatusUnauthorized, "unauthorized"},
+		{"Refresh missing token", h.RefreshHandler, `{}`, http.StatusBadRequest, "validation_error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serveAuth(tt.handler, tt.body)
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+			if tt.expectedError == "" {
+				return
+			}
+			var response models.ErrorResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+			if response.Error != tt.expectedError {
+				t.Errorf("Expected error %q, got %q", tt.expectedError, response.Error)
+			}
+		})
+	}
+}
+
+// TestRefreshHandlerReuse ローテーション済みリフレッシュトークンの再利用テスト
+func TestRefreshHandlerReuse(t *testing.T) {
+	h := newTestAuthHandler(t)
+	registered, err := h.service.Register(&models.RegisterRequest{Email: "reuse@example.com", Password: "password123", Name: "Reuse"}, models.ClientInfo{})
+	if err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	body := `{"refresh_token":"` + registered.RefreshToken + `"}`
+
+	if w := serveAuth(h.RefreshHandler, body); w.Code != http.StatusOK {
+		t.Fatalf("Refresh: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	w := serveAuth(h.RefreshHandler, body)
+	if w.Code != http.StatusUnauthorized || !strings.Contains(w.Body.String(), "reuse detected") {
+		t.Errorf("Reuse: expected 401 with reuse message, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+// TestSessionHandlers セッション一覧・失効ハンドラーのテスト
+func TestSessionHandlers(t *testing.T) {
+	h := newTestAuthHandler(t)
+	registered, err := h.service.Register(&models.RegisterRequest{Email: "sessions@example.com", Password: "password123", Name: "Sessions"}, models.ClientInfo{})
+	if err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	user, err := h.service.Authenticate(registered.AccessToken)
+	if err != nil {
+		t.Fatalf("Authenticate() error = %v", err)
+	}
+
+	// ログインでセッションを作成（User-Agent と RealIP 適用後の RemoteAddr を記録）
+	req := httptest.NewRequest("POST", "/", bytes.NewBufferString(`{"email":"sessions@example.com","password":"password123"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "curl/8.4.0")
+	req.RemoteAddr = "198.51.100.7:54321"
+	h.LoginHandler(httptest.NewRecorder(), req)
+
+	serveAs := func(handlerFunc http.HandlerFunc, method, id string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, "/", nil)
+		req = req.WithContext(custommiddleware.WithUser(req.Context(), user))
+		if id != "" {
+			req = withURLParam(req, "id", id)
+		}
+		w := httptest.NewRecorder()
+		handlerFunc(w, req)
+		return w
+	}
+
+	w := serveAs(h.ListSessionsHandler, "GET", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("List: expected 200, got %d", w.Code)
+	}
+	var listed struct {
+		Data []models.Session `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &listed); err != nil || len(listed.Data) != 2 {
+		t.Fatalf("Unexpected sessions: %s", w.Body.String())
+	}
+	var other models.Session
+	for _, session := range listed.Data {
+		if !session.Current {
+			other = session
+		}
+	}
+	if other.IP != "198.51.100.7" || other.Device != "curl" {
+		t.Errorf("Unexpected session client info: %+v", other)
+	}
+
+	if w := serveAs(h.RevokeSessionHandler, "DELETE", other.ID); w.Code != http.StatusOK {
+		t.Errorf("Revoke: expected 200, got %d", w.Code)
+	}
+	if w := serveAs(h.RevokeSessionHandler, "DELETE", other.ID); w.Code != http.StatusNotFound {
+		t.Errorf("Revoke again: expected 404, got %d", w.Code)
+	}
+	if w := serveAs(h.RevokeOtherSessionsHandler, "DELETE", ""); w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `"revoked":0`) {
+		t.Errorf("Revoke others: unexpected response %d: %s", w.Code, w.Body.String())
+	}
+
+	// 認証済みユーザーがない場合は401
+	w = httptest.NewRecorder()
+	h.ListSessionsHandler(w, httptest.NewRequest("GET", "/", nil))
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("Unauthenticated: expected 401, got %d", w.Code)
+	}
+}
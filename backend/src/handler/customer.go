@@ -0,0 +1,237 @@
+package handler
+
+import (
+	"net/http"
+
+	"backend/models"
+	"backend/services"
+)
+
+// CustomerHandler 顧客ハンドラー構造体
+type CustomerHandler struct {
+	service *services.CustomerService
+}
+
+// NewCustomerHandler 顧客ハンドラーを新規作成
+func NewCustomerHandler(service *services.CustomerService) *CustomerHandler {
+	return &CustomerHandler{service: service}
+}
+
+// ListCustomersHandler 顧客一覧取得
+// @Summary 顧客一覧取得
+// @Description 顧客を検索・ソートしてページ単位で取得（offset または after カーソルでページング）
+// @Tags customers
+// @Accept json
+// @Produce json
+// @Param limit query int false "取得件数（1〜100、デフォルト20）"
+// @Param offset query int false "オフセット"
+// @Param after query string false "キーセットページング用カーソル（next_cursor の値、デフォルトの並び順のみ）"
+// @Param status query string false "ステータス（subscribed, unsubscribed, bounced）"
+// @Param location query string false "所在地（完全一致）"
+// @Param created_after query string false "作成日時の下限（RFC3339 または YYYY-MM-DD）"
+// @Param created_before query string false "作成日時の上限（RFC3339 または YYYY-MM-DD）"
+// @Param sort query string false "並び順（例: name,-created_at）。対象: id, name, email, status, location, created_at, updated_at"
+// @Param q query string false "name・email・location の全文検索"
+// @Success 200 {object} models.SuccessResponse{data=[]models.Customer}
+// @Header 200 {string} Link "RFC 8288 ページネーションリンク"
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/customers [get]
+func (h *CustomerHandler) ListCustomersHandler(w http.ResponseWriter, r *http.Request) {
+	spec, err := services.CustomerQuerySchema.Parse(r.URL.Query())
+	if err != nil {
+		sendQueryError(w, err)
+		return
+	}
+
+	result, err := h.service.ListCustomers(spec)
+	if err != nil {
+		sendError(w, err, "error.retrieve_customers")
+		return
+	}
+
+	pagination := result.Pagination(spec.Page)
+	setPaginationLinks(w, r, spec.Page, pagination)
+	models.SendPaginatedResponse(w, "customer.list_retrieved", result.Items, pagination)
+}
+
+// GetCustomerHandler 顧客取得
+// @Summary 顧客取得（ID指定）
+// @Description 指定されたIDの顧客を取得
+// @Tags customers
+// @Accept json
+// @Produce json
+// @Param id path int true "Customer ID"
+// @Success 200 {object} models.SuccessResponse{data=models.Customer}
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/customers/{id} [get]
+func (h *CustomerHandler) GetCustomerHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := parseMessageID(r)
+	if err != nil {
+		models.SendValidationError(w, "request.invalid_id")
+		return
+	}
+
+	customer, err := h.service.GetCustomer(id)
+	if err != nil {
+		sendError(w, err, "error.retrieve_customer")
+		return
+	}
+
+	models.SendSuccessResponse(w, "customer.retrieved", customer)
+}
+
+// CreateCustomerHandler 顧客作成
+// @Summary 顧客作成
+// @Description 顧客を作成（status 省略時は subscribed）
+// @Tags customers
+// @Accept json
+// @Produce json
+// @Param request body models.CustomerRequest true "Customer Request"
+// @Success 201 {object} models.SuccessResponse{data=models.Customer}
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
+// @Failure 413 {object} models.ErrorResponse
+// @Failure 415 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/customers [post]
+func (h *CustomerHandler) CreateCustomerHandler(w http.ResponseWriter, r *http.Request) {
+	var request models.CustomerRequest
+	if !decodeJSON(w, r, &request) {
+		return
+	}
+
+	customer, err := h.service.CreateCustomer(&request)
+	if err != nil {
+		sendError(w, err, "error.create_customer")
+		return
+	}
+
+	models.SendCreatedResponse(w, "customer.created", customer)
+}
+
+// UpdateCustomerHandler 顧客更新（全置換）
+// @Summary 顧客更新
+// @Description 指定されたIDの顧客を全置換で更新
+// @Tags customers
+// @Accept json
+// @Produce json
+// @Param id path int true "Customer ID"
+// @Param request body models.CustomerRequest true "Customer Request"
+// @Success 200 {object} models.SuccessResponse{data=models.Customer}
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
+// @Failure 413 {object} models.ErrorResponse
+// @Failure 415 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/customers/{id} [put]
+func (h *CustomerHandler) UpdateCustomerHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := parseMessageID(r)
+	if err != nil {
+		models.SendValidationError(w, "request.invalid_id")
+		return
+	}
+
+	var request models.CustomerRequest
+	if !decodeJSON(w, r, &request) {
+		return
+	}
+
+	customer, err := h.service.UpdateCustomer(id, &request)
+	if err != nil {
+		sendError(w, err, "error.update_customer")
+		return
+	}
+
+	models.SendSuccessResponse(w, "customer.updated", customer)
+}
+
+// PatchCustomerHandler 顧客部分更新（JSON Merge Patch）
+// @Summary 顧客部分更新
+// @Description 指定されたIDの顧客をJSON Merge Patch (RFC 7396) で部分更新（avatar に null を指定すると削除）
+// @Tags customers
+// @Accept json
+// @Accept application/merge-patch+json
+// @Produce json
+// @Param id path int true "Customer ID"
+// @Param request body models.CustomerRequest true "JSON Merge Patch document"
+// @Success 200 {object} models.SuccessResponse{data=models.Customer}
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
+// @Failure 413 {object} models.ErrorResponse
+// @Failure 415 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/customers/{id} [patch]
+func (h *CustomerHandler) PatchCustomerHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := parseMessageID(r)
+	if err != nil {
+		models.SendValidationError(w, "request.invalid_id")
+		return
+	}
+
+	patch, ok := readBody(w, r, mediaTypeMergePatch, mediaTypeJSON)
+	if !ok {
+		return
+	}
+
+	customer, err := h.service.PatchCustomer(id, patch)
+	if err != nil {
+		sendError(w, err, "error.update_customer")
+		return
+	}
+
+	models.SendSuccessResponse(w, "customer.updated", customer)
+}
+
+// DeleteCustomerHandler 顧客削除
+// @Summary 顧客削除
+// @Description 指定されたIDの顧客を削除
+// @Tags customers
+// @Accept json
+// @Produce json
+// @Param id path int true "Customer ID"
+// @Success 200 {object} models.SuccessResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/customers/{id} [delete]
+func (h *CustomerHandler) DeleteCustomerHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := parseMessageID(r)
+	if err != nil {
+		models.SendValidationError(w, "request.invalid_id")
+		return
+	}
+
+	if err := h.service.DeleteCustomer(id); err != nil {
+		sendError(w, err, "error.delete_customer")
+		return
+	}
+
+	models.SendSuccessResponse(w, "customer.deleted", nil)
+}
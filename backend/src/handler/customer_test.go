@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"backend/models"
+	"backend/services"
+)
+
+// TestCustomerHandlers 顧客の作成・取得・更新・削除ハンドラーのテスト
+func TestCustomerHandlers(t *testing.T) {
+	h := NewCustomerHandler(services.NewCustomerService(services.NewMemoryCustomerRepository(), nil))
+
+	serve := func(handlerFunc http.HandlerFunc, method, target, body, id string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		if id != "" {
+			req = withURLParam(req, "id", id)
+		}
+		w := httptest.NewRecorder()
+		handlerFunc(w, req)
+		return w
+	}
+
+	w := serve(h.CreateCustomerHandler, "POST", "/", `{"name":"Alex Smith","email":"alex@example.com","avatar":{"src":"https://i.pravatar.cc/128?u=1"},"location":"New York"}`, "")
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Create: expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	var created struct {
+		Data models.Customer `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &created); err != nil || created.Data.Status != models.CustomerStatusSubscribed {
+		t.Fatalf("Unexpected created customer: %s", w.Body.String())
+	}
+	id := strconv.Itoa(created.Data.ID)
+
+	tests := []struct {
+		name           string
+		handler        http.HandlerFunc
+		method         string
+		body           string
+		id             string
+		expectedStatus int
+	}{
+		{"Create invalid body", h.CreateCustomerHandler, "POST", `{`, "", http.StatusBadRequest},
+		{"Create invalid status", h.CreateCustomerHandler, "POST", `{"name":"B","email":"b@example.com","status":"active"}`, "", http.StatusBadRequest},
+		{"Create duplicate email", h.CreateCustomerHandler, "POST", `{"name":"B","email":"ALEX@example.com"}`, "", http.StatusConflict},
+		{"Get", h.GetCustomerHandler, "GET", "", id, http.StatusOK},
+		{"Get invalid ID", h.GetCustomerHandler, "GET", "", "abc", http.StatusBadRequest},
+		{"Get not found", h.GetCustomerHandler, "GET", "", "999", http.StatusNotFound},
+		{"Update", h.UpdateCustomerHandler, "PUT", `{"name":"Alex Smith","email":"alex@example.com","status":"bounced","location":"Paris"}`, id, http.StatusOK},
+		{"Update not found", h.UpdateCustomerHandler, "PUT", `{"name":"A","email":"a@example.com"}`, "999", http.StatusNotFound},
+		{"Patch", h.PatchCustomerHandler, "PATCH", `{"status":"unsubscribed"}`, id, http.StatusOK},
+		{"Patch empty body", h.PatchCustomerHandler, "PATCH", ``, id, http.StatusBadRequest},
+		{"Patch unknown field", h.PatchCustomerHandler, "PATCH", `{"created_at":"2024-01-01T00:00:00Z"}`, id, http.StatusBadRequest},
+		{"Delete", h.DeleteCustomerHandler, "DELETE", "", id, http.StatusOK},
+		{"Delete again", h.DeleteCustomerHandler, "DELETE", "", id, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if w := serve(tt.handler, tt.method, "/", tt.body, tt.id); w.Code != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+// TestListCustomersHandler 顧客一覧ハンドラーのテスト
+func TestListCustomersHandler(t *testing.T) {
+	service := services.NewCustomerService(services.NewMemoryCustomerRepository(), nil)
+	for i, status := range []models.CustomerStatus{models.CustomerStatusSubscribed, models.CustomerStatusBounced, models.CustomerStatusSubscribed} {
+		if _, err := service.CreateCustomer(&models.CustomerRequest{Name: "Customer " + strconv.Itoa(i), Email: "customer" + strconv.Itoa(i) + "@example.com", Status: status}); err != nil {
+			t.Fatalf("CreateCustomer() error = %v", err)
+		}
+	}
+	h := NewCustomerHandler(service)
+
+	w := httptest.NewRecorder()
+	h.ListCustomersHandler(w, httptest.NewRequest("GET", "/api/customers?status=subscribed&limit=1", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var response struct {
+		Data       []models.Customer `json:"data"`
+		Pagination models.Pagination `json:"pagination"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if len(response.Data) != 1 || response.Data[0].Status != models.CustomerStatusSubscribed || response.Pagination.Total != 2 {
+		t.Errorf("Unexpected list response: %s", w.Body.String())
+	}
+	if !strings.Contains(w.Header().Get("Link"), `rel="next"`) {
+		t.Errorf("Link header missing next: %q", w.Header().Get("Link"))
+	}
+
+	w = httptest.NewRecorder()
+	h.ListCustomersHandler(w, httptest.NewRequest("GET", "/api/customers?sort=password", nil))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Invalid sort: expected 400, got %d", w.Code)
+	}
+}
@@ -0,0 +1,46 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"backend/models"
+	"backend/services"
+)
+
+// errorStatus エラーの種類に対応するレスポンスのステータスとエラーコード
+type errorStatus struct {
+	status    int
+	errorType string
+}
+
+// errorStatuses サービスのエラーの種類とHTTPステータスの対応
+var errorStatuses = map[error]errorStatus{
+	services.ErrValidation:      {http.StatusBadRequest, "validation_error"},
+	services.ErrUnauthorized:    {http.StatusUnauthorized, "unauthorized"},
+	services.ErrForbidden:       {http.StatusForbidden, "forbidden"},
+	services.ErrNotFound:        {http.StatusNotFound, "not_found"},
+	services.ErrConflict:        {http.StatusConflict, "conflict"},
+	services.ErrGone:            {http.StatusGone, "gone"},
+	services.ErrTooManyRequests: {http.StatusTooManyRequests, "too_many_requests"},
+	services.ErrUnavailable:     {http.StatusServiceUnavailable, "service_unavailable"},
+}
+
+// sendError サービスのエラーを種類に応じたエラーレスポンスとして送信
+// バリデーションエラーはフィールドごとの詳細を含め、種類を持たないエラーは message の database_error（500）とします
+func sendError(w http.ResponseWriter, err error, message string) {
+	var validationErr *models.ValidationError
+	if errors.As(err, &validationErr) {
+		models.SendFieldValidationError(w, validationErr)
+		return
+	}
+
+	var domainErr *services.Error
+	if errors.As(err, &domainErr) {
+		if status, ok := errorStatuses[domainErr.Kind]; ok {
+			models.SendErrorResponse(w, status.status, status.errorType, domainErr.Detail)
+			return
+		}
+	}
+	models.SendDatabaseError(w, message)
+}
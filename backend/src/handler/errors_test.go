@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"backend/models"
+	"backend/services"
+)
+
+// TestSendError サービスのエラーの種類とレスポンスの対応のテスト
+func TestSendError(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+		expectedError  string
+		expectedMsg    string
+	}{
+		{"Validation", &models.ValidationError{Field: "name", Code: models.ValidationCodeRequired, Message: "Name is required"}, http.StatusBadRequest, "validation_error", "Name is required"},
+		{"Invalid code", services.ErrInvalidMFACode, http.StatusBadRequest, "validation_error", "Invalid authentication code"},
+		{"Unauthorized", services.ErrInvalidCredentials, http.StatusUnauthorized, "unauthorized", "Invalid email or password"},
+		{"Forbidden", services.ErrNotTeamOwner, http.StatusForbidden, "forbidden", "Only team owners can perform this action"},
+		{"Not found", services.ErrCustomerNotFound, http.StatusNotFound, "not_found", "Customer not found"},
+		{"Wrapped", fmt.Errorf("find sale: %w", services.ErrSaleNotFound), http.StatusNotFound, "not_found", "Sale not found"},
+		{"Conflict", services.ErrEmailAlreadyExists, http.StatusConflict, "conflict", "Email is already registered"},
+		{"Gone", services.ErrInvitationExpired, http.StatusGone, "gone", "Invitation has expired"},
+		{"Too many requests", services.ErrTooManyMFAAttempts, http.StatusTooManyRequests, "too_many_requests", "Too many attempts; log in again"},
+		{"Unavailable", services.ErrDatabaseUnavailable, http.StatusServiceUnavailable, "service_unavailable", "Database is not available"},
+		{"Unknown", errors.New("connection reset"), http.StatusInternalServerError, "database_error", "Failed to load"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+
+			sendError(w, tt.err, "Failed to load")
+
+			var response models.ErrorResponse
+			if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if w.Code != tt.expectedStatus || response.Error != tt.expectedError || response.Message != tt.expectedMsg {
+				t.Errorf("sendError() = %d %s %q, want %d %s %q", w.Code, response.Error, response.Message, tt.expectedStatus, tt.expectedError, tt.expectedMsg)
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"backend/models"
+	"backend/services"
+)
+
+const (
+	// sseWriteTimeout 1回の書き込みの期限（受信しないクライアントの接続を解放する）
+	sseWriteTimeout = 10 * time.Second
+	// sseRetryMillis 切断時にクライアントが再接続するまでの待ち時間（ミリ秒）
+	sseRetryMillis = 3000
+)
+
+// EventsHandler リアルタイムイベント配信（Server-Sent Events）ハンドラー構造体
+type EventsHandler struct {
+	broker            *services.EventBroker
+	heartbeatInterval time.Duration
+}
+
+// NewEventsHandler イベント配信ハンドラーを新規作成
+// heartbeatInterval ごとにコメント行を送信し、プロキシによる無通信切断を防ぎます
+func NewEventsHandler(broker *services.EventBroker, heartbeatInterval time.Duration) *EventsHandler {
+	return &EventsHandler{broker: broker, heartbeatInterval: heartbeatInterval}
+}
+
+// StreamHandler イベントストリーム
+// @Summary イベントストリーム（Server-Sent Events）
+// @Description 認証ユーザー宛てのイベント（message.created, message.updated, message.deleted, notification.created）を text/event-stream で配信。
+// @Description 各イベントの id を Last-Event-ID ヘッダーに指定して再接続すると、保持している範囲のイベントを再送します。
+// @Description 再送できない場合は stream.reset イベントを送信するため、クライアントは状態を再取得してください。
+// @Tags events
+// @Produce text/event-stream
+// @Param Last-Event-ID header string false "最後に受信したイベントID"
+// @Success 200 {string} string "イベントストリーム"
+// @Failure 401 {object} models.ErrorResponse
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/events [get]
+func (h *EventsHandler) StreamHandler(w http.ResponseWriter, r *http.Request) {
+	user, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+	lastEventID := strings.TrimSpace(r.Header.Get("Last-Event-ID"))
+
+	subscription, replay := h.broker.Subscribe(user.ID, lastEventID)
+	defer h.broker.Unsubscribe(subscription)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no") // nginx のバッファリングを無効化
+	w.WriteHeader(http.StatusOK)
+
+	stream := newSSEStream(w, h.broker)
+	if err := stream.write(fmt.Sprintf("retry: %d\n\n", sseRetryMillis)); err != nil {
+		log.Printf("⚠️  Failed to start event stream: %v", err)
+		return
+	}
+	for _, event := range replay {
+		if err := stream.event(event); err != nil {
+			return
+		}
+	}
+
+	heartbeat := time.NewTicker(h.heartbeatInterval)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case event, ok := <-subscription.Events():
+			if !ok {
+				// 受信が遅れて購読が終了した、またはサーバーの停止
+				return
+			}
+			if err := stream.event(event); err != nil {
+				return
+			}
+		case <-heartbeat.C:
+			if err := stream.write(": heartbeat\n\n"); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// sseStream Server-Sent Events の書き込み
+//
+// サーバー全体の WriteTimeout は接続開始からの期限のため、長時間の接続では
+// 書き込みごとに期限を延長します。
+type sseStream struct {
+	w          http.ResponseWriter
+	controller *http.ResponseController
+	broker     *services.EventBroker
+}
+
+func newSSEStream(w http.ResponseWriter, broker *services.EventBroker) *sseStream {
+	return &sseStream{w: w, controller: http.NewResponseController(w), broker: broker}
+}
+
+// write 期限を延長して書き込み、即座にクライアントへ送信
+func (s *sseStream) write(data string) error {
+	if err := s.controller.SetWriteDeadline(time.Now().Add(sseWriteTimeout)); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+	if _, err := s.w.Write([]byte(data)); err != nil {
+		return err
+	}
+	return s.controller.Flush()
+}
+
+// event イベントを id / event / data フィールドとして送信（data は1行のJSON）
+func (s *sseStream) event(event models.Event) error {
+	return s.write(fmt.Sprintf("id: %s\nevent: %s\ndata: %s\n\n", s.broker.EventID(event), event.Type, event.Data))
+}
@@ -0,0 +1,114 @@
+package handler
+
+import (
+	"bufio"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	custommiddleware "backend/middleware"
+	"backend/models"
+	"backend/services"
+)
+
+// readSSEEvent ストリームからイベント（空行区切りのブロック）を1件読み取る
+func readSSEEvent(t *testing.T, reader *bufio.Reader) string {
+	t.Helper()
+	var block strings.Builder
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			t.Fatalf("ストリームの読み取り失敗: %v (読み取り済み: %q)", err, block.String())
+		}
+		if line == "\n" {
+			return block.String()
+		}
+		block.WriteString(line)
+	}
+}
+
+// TestEventsStreamHandler イベントストリームの配信・再送・ハートビートのテスト
+func TestEventsStreamHandler(t *testing.T) {
+	bus := services.NewMemoryEventBus()
+	broker := services.NewEventBroker(10)
+	bus.Subscribe(broker.Dispatch)
+	h := NewEventsHandler(broker, 50*time.Millisecond)
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h.StreamHandler(w, r.WithContext(custommiddleware.WithUser(r.Context(), &models.User{ID: 1})))
+	}))
+	// サーバー全体の WriteTimeout より長く接続を維持できること
+	server.Config.WriteTimeout = 200 * time.Millisecond
+	server.Start()
+	defer server.Close()
+
+	bus.Publish(models.EventMessageCreated, 0, map[string]int{"id": 1})
+	bus.Publish(models.EventNotificationCreated, 2, map[string]int{"id": 1})
+	eventID := func(id uint64) string { return broker.EventID(models.Event{ID: id}) }
+
+	connect := func(lastEventID string) *bufio.Reader {
+		t.Helper()
+		req, _ := http.NewRequest("GET", server.URL, nil)
+		req.Header.Set("Last-Event-ID", lastEventID)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("接続失敗: %v", err)
+		}
+		t.Cleanup(func() { resp.Body.Close() })
+		if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != "text/event-stream" {
+			t.Fatalf("Unexpected response: %d %s", resp.StatusCode, resp.Header.Get("Content-Type"))
+		}
+		return bufio.NewReader(resp.Body)
+	}
+	reader := connect(eventID(0))
+
+	if block := readSSEEvent(t, reader); block != "retry: 3000\n" {
+		t.Errorf("retry が送信されない: %q", block)
+	}
+	if block := readSSEEvent(t, reader); block != "id: "+eventID(1)+"\nevent: message.created\ndata: {\"id\":1}\n" {
+		t.Errorf("再送イベントが不正: %q", block)
+	}
+	if block := readSSEEvent(t, reader); block != ": heartbeat\n" {
+		t.Errorf("ハートビートが送信されない: %q", block)
+	}
+
+	time.Sleep(300 * time.Millisecond)
+	bus.Publish(models.EventNotificationCreated, 1, map[string]int{"id": 2})
+	for {
+		block := readSSEEvent(t, reader)
+		if block == ": heartbeat\n" {
+			continue
+		}
+		if block != "id: "+eventID(3)+"\nevent: notification.created\ndata: {\"id\":2}\n" {
+			t.Errorf("配信イベントが不正: %q", block)
+		}
+		break
+	}
+
+	// 別のプロセスが採番したIDでは再送できないため stream.reset を送信する
+	resumed := connect("other-1")
+	readSSEEvent(t, resumed)
+	if block := readSSEEvent(t, resumed); block != "id: "+eventID(3)+"\nevent: stream.reset\ndata: {}\n" {
+		t.Errorf("stream.reset が送信されない: %q", block)
+	}
+
+	// サーバーの停止で購読が終了し、ストリームが閉じられる
+	broker.Close()
+	for {
+		if _, err := reader.ReadString('\n'); err != nil {
+			break
+		}
+	}
+}
+
+// TestEventsStreamHandlerUnauthorized 認証なしのイベントストリームのテスト
+func TestEventsStreamHandlerUnauthorized(t *testing.T) {
+	h := NewEventsHandler(services.NewEventBroker(10), time.Second)
+
+	w := httptest.NewRecorder()
+	h.StreamHandler(w, httptest.NewRequest("GET", "/api/events", nil))
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("Expected 401 without user, got %d", w.Code)
+	}
+}
@@ -28,19 +28,19 @@ func NewHealthHandler(db *sql.DB) *HealthHandler {
 // @Router /api/health [get]
 func (h *HealthHandler) HealthCheckHandler(w http.ResponseWriter, r *http.Request) {
 	status := "healthy"
-	message := "Application is running"
+	message := "health.ok"
 
 	// データベース接続チェック
 	if h.db != nil {
 		if err := h.db.Ping(); err != nil {
 			status = "unhealthy"
-			message = "Database connection failed"
+			message = "health.database_failed"
 		}
 	}
 
 	response := models.BaseResponse{
 		Status:    status,
-		Message:   message,
+		Message:   models.Translate(models.LocaleOf(w), message, nil),
 		Timestamp: time.Now(),
 	}
 
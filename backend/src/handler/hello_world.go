@@ -2,7 +2,6 @@ package handler
 
 import (
 	"database/sql"
-	"encoding/json"
 	"net/http"
 	"strconv"
 	"time"
@@ -18,10 +17,15 @@ type HelloWorldHandler struct {
 	service *services.HelloWorldService
 }
 
-// NewHelloWorldHandler Hello Worldハンドラーを新規作成
+// NewHelloWorldHandler PostgreSQLを使用するHello Worldハンドラーを新規作成
 func NewHelloWorldHandler(db *sql.DB) *HelloWorldHandler {
+	return NewHelloWorldHandlerWithService(services.NewHelloWorldService(db))
+}
+
+// NewHelloWorldHandlerWithService 任意のサービスを使用するHello Worldハンドラーを新規作成
+func NewHelloWorldHandlerWithService(service *services.HelloWorldService) *HelloWorldHandler {
 	return &HelloWorldHandler{
-		service: services.NewHelloWorldService(db),
+		service: service,
 	}
 }
 
@@ -36,7 +40,7 @@ func NewHelloWorldHandler(db *sql.DB) *HelloWorldHandler {
 func (h *HelloWorldHandler) RootHandler(w http.ResponseWriter, r *http.Request) {
 	response := models.BaseResponse{
 		Status:    "success",
-		Message:   "Go + Chi Starter Project API",
+		Message:   models.Translate(models.LocaleOf(w), "api.name", nil),
 		Timestamp: time.Now(),
 	}
 
@@ -45,15 +49,16 @@ func (h *HelloWorldHandler) RootHandler(w http.ResponseWriter, r *http.Request)
 
 // GetHelloWorldHandler Hello Worldメッセージ取得
 // @Summary Hello World取得
-// @Description Hello Worldメッセージを取得
+// @Description Accept-Language の言語（ja・en）の挨拶を取得
 // @Tags hello-world
 // @Accept json
 // @Produce json
+// @Param Accept-Language header string false "メッセージの言語（例: ja, en;q=0.8）"
 // @Success 200 {object} models.SuccessResponse{data=models.HelloWorldResponse}
 // @Router /api/hello-world [get]
 func (h *HelloWorldHandler) GetHelloWorldHandler(w http.ResponseWriter, r *http.Request) {
-	response := h.service.GetHelloWorld()
-	models.SendSuccessResponse(w, "Hello World message retrieved successfully", response)
+	response := h.service.GetHelloWorld(models.LocaleOf(w))
+	models.SendSuccessResponse(w, "hello_world.retrieved", response)
 }
 
 // CreateHelloWorldHandler Hello Worldメッセージ作成
@@ -65,46 +70,67 @@ func (h *HelloWorldHandler) GetHelloWorldHandler(w http.ResponseWriter, r *http.
 // @Param request body models.HelloWorldRequest true "Hello World Request"
 // @Success 201 {object} models.SuccessResponse{data=models.HelloWorldMessage}
 // @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 413 {object} models.ErrorResponse
+// @Failure 415 {object} models.ErrorResponse
 // @Failure 500 {object} models.ErrorResponse
+// @Failure 503 {object} models.ErrorResponse
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /api/hello-world [post]
 func (h *HelloWorldHandler) CreateHelloWorldHandler(w http.ResponseWriter, r *http.Request) {
 	var request models.HelloWorldRequest
 
-	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
-		models.SendValidationError(w, "Invalid request body")
+	if !decodeJSON(w, r, &request) {
 		return
 	}
 
 	message, err := h.service.CreateHelloWorld(&request)
 	if err != nil {
-		if _, ok := err.(*models.ValidationError); ok {
-			models.SendValidationError(w, err.Error())
-			return
-		}
-		models.SendDatabaseError(w, "Failed to create hello world message")
+		sendError(w, err, "error.create_hello_world_message")
 		return
 	}
 
-	models.SendJSONResponse(w, http.StatusCreated, models.NewSuccessResponse("Hello World message created successfully", message))
+	models.SendCreatedResponse(w, "hello_world.created", message)
 }
 
-// GetHelloWorldMessagesHandler 全てのHello Worldメッセージ取得
+// GetHelloWorldMessagesHandler Hello Worldメッセージ一覧取得
 // @Summary Hello Worldメッセージ一覧取得
-// @Description 全てのHello Worldメッセージを取得
+// @Description Hello Worldメッセージを検索・ソートしてページ単位で取得（offset または after カーソルでページング）
 // @Tags hello-world
 // @Accept json
 // @Produce json
+// @Param limit query int false "取得件数（1〜100、デフォルト20）"
+// @Param offset query int false "オフセット"
+// @Param after query string false "キーセットページング用カーソル（next_cursor の値、デフォルトの並び順のみ）"
+// @Param name query string false "名前（完全一致）"
+// @Param created_after query string false "作成日時の下限（RFC3339 または YYYY-MM-DD）"
+// @Param created_before query string false "作成日時の上限（RFC3339 または YYYY-MM-DD）"
+// @Param sort query string false "並び順（例: -created_at,name）。対象: id, name, created_at, updated_at"
+// @Param q query string false "name と message の全文検索"
 // @Success 200 {object} models.SuccessResponse{data=[]models.HelloWorldMessage}
+// @Header 200 {string} Link "RFC 8288 ページネーションリンク"
+// @Failure 400 {object} models.ErrorResponse
 // @Failure 500 {object} models.ErrorResponse
+// @Failure 503 {object} models.ErrorResponse
 // @Router /api/hello-world/messages [get]
 func (h *HelloWorldHandler) GetHelloWorldMessagesHandler(w http.ResponseWriter, r *http.Request) {
-	messages, err := h.service.GetHelloWorldMessages()
+	spec, err := services.HelloWorldMessageQuerySchema.Parse(r.URL.Query())
 	if err != nil {
-		models.SendDatabaseError(w, "Failed to retrieve hello world messages")
+		sendQueryError(w, err)
 		return
 	}
 
-	models.SendSuccessResponse(w, "Hello World messages retrieved successfully", messages)
+	result, err := h.service.ListHelloWorldMessages(spec)
+	if err != nil {
+		sendError(w, err, "error.retrieve_hello_world_messages")
+		return
+	}
+
+	pagination := result.Pagination(spec.Page)
+	setPaginationLinks(w, r, spec.Page, pagination)
+	models.SendPaginatedResponse(w, "hello_world.list_retrieved", result.Items, pagination)
 }
 
 // GetHelloWorldMessageByIDHandler IDでHello Worldメッセージ取得
@@ -117,24 +143,140 @@ func (h *HelloWorldHandler) GetHelloWorldMessagesHandler(w http.ResponseWriter,
 // @Success 200 {object} models.SuccessResponse{data=models.HelloWorldMessage}
 // @Failure 404 {object} models.ErrorResponse
 // @Failure 500 {object} models.ErrorResponse
+// @Failure 503 {object} models.ErrorResponse
 // @Router /api/hello-world/messages/{id} [get]
 func (h *HelloWorldHandler) GetHelloWorldMessageByIDHandler(w http.ResponseWriter, r *http.Request) {
-	idStr := chi.URLParam(r, "id")
-	id, err := strconv.Atoi(idStr)
+	id, err := parseMessageID(r)
 	if err != nil {
-		models.SendValidationError(w, "Invalid ID format")
+		models.SendValidationError(w, "request.invalid_id")
 		return
 	}
 
 	message, err := h.service.GetHelloWorldMessageByID(id)
 	if err != nil {
-		if err.Error() == "hello world message not found" {
-			models.SendNotFoundError(w, "Hello World message not found")
-			return
-		}
-		models.SendDatabaseError(w, "Failed to retrieve hello world message")
+		sendError(w, err, "error.retrieve_hello_world_message")
+		return
+	}
+
+	models.SendSuccessResponse(w, "hello_world.retrieved", message)
+}
+
+// UpdateHelloWorldMessageHandler Hello Worldメッセージ更新（全置換）
+// @Summary Hello Worldメッセージ更新
+// @Description 指定されたIDのHello Worldメッセージを全置換で更新
+// @Tags hello-world
+// @Accept json
+// @Produce json
+// @Param id path int true "Message ID"
+// @Param request body models.HelloWorldUpdateRequest true "Hello World Update Request"
+// @Success 200 {object} models.SuccessResponse{data=models.HelloWorldMessage}
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 413 {object} models.ErrorResponse
+// @Failure 415 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Failure 503 {object} models.ErrorResponse
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/hello-world/messages/{id} [put]
+func (h *HelloWorldHandler) UpdateHelloWorldMessageHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := parseMessageID(r)
+	if err != nil {
+		models.SendValidationError(w, "request.invalid_id")
 		return
 	}
 
-	models.SendSuccessResponse(w, "Hello World message retrieved successfully", message)
+	var request models.HelloWorldUpdateRequest
+	if !decodeJSON(w, r, &request) {
+		return
+	}
+
+	message, err := h.service.UpdateHelloWorldMessage(id, &request)
+	if err != nil {
+		sendError(w, err, "error.update_hello_world_message")
+		return
+	}
+
+	models.SendSuccessResponse(w, "hello_world.updated", message)
+}
+
+// PatchHelloWorldMessageHandler Hello Worldメッセージ部分更新（JSON Merge Patch）
+// @Summary Hello Worldメッセージ部分更新
+// @Description 指定されたIDのHello WorldメッセージをJSON Merge Patch (RFC 7396) で部分更新
+// @Tags hello-world
+// @Accept json
+// @Accept application/merge-patch+json
+// @Produce json
+// @Param id path int true "Message ID"
+// @Param request body models.HelloWorldUpdateRequest true "JSON Merge Patch document"
+// @Success 200 {object} models.SuccessResponse{data=models.HelloWorldMessage}
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 413 {object} models.ErrorResponse
+// @Failure 415 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Failure 503 {object} models.ErrorResponse
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/hello-world/messages/{id} [patch]
+func (h *HelloWorldHandler) PatchHelloWorldMessageHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := parseMessageID(r)
+	if err != nil {
+		models.SendValidationError(w, "request.invalid_id")
+		return
+	}
+
+	patch, ok := readBody(w, r, mediaTypeMergePatch, mediaTypeJSON)
+	if !ok {
+		return
+	}
+
+	message, err := h.service.PatchHelloWorldMessage(id, patch)
+	if err != nil {
+		sendError(w, err, "error.update_hello_world_message")
+		return
+	}
+
+	models.SendSuccessResponse(w, "hello_world.updated", message)
+}
+
+// DeleteHelloWorldMessageHandler Hello Worldメッセージ削除
+// @Summary Hello Worldメッセージ削除
+// @Description 指定されたIDのHello Worldメッセージを削除
+// @Tags hello-world
+// @Accept json
+// @Produce json
+// @Param id path int true "Message ID"
+// @Success 200 {object} models.SuccessResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Failure 503 {object} models.ErrorResponse
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/hello-world/messages/{id} [delete]
+func (h *HelloWorldHandler) DeleteHelloWorldMessageHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := parseMessageID(r)
+	if err != nil {
+		models.SendValidationError(w, "request.invalid_id")
+		return
+	}
+
+	if err := h.service.DeleteHelloWorldMessage(id); err != nil {
+		sendError(w, err, "error.delete_hello_world_message")
+		return
+	}
+
+	models.SendSuccessResponse(w, "hello_world.deleted", nil)
+}
+
+// parseMessageID URLパラメータからメッセージIDを取得
+func parseMessageID(r *http.Request) (int, error) {
+	return strconv.Atoi(chi.URLParam(r, "id"))
 }
@@ -42,7 +42,7 @@ type MockHelloWorldService struct {
 }
 
 // GetHelloWorld モックGetHelloWorldメソッド
-func (m *MockHelloWorldService) GetHelloWorld() *models.HelloWorldResponse {
+func (m *MockHelloWorldService) GetHelloWorld(locale models.Locale) *models.HelloWorldResponse {
 	return &models.HelloWorldResponse{
 		Message: "Hello, World!",
 		Version: "1.0.0",
@@ -324,3 +324,122 @@ func TestGetHelloWorldMessageByIDHandler(t *testing.T) {
 		})
 	}
 }
+
+// withURLParam chiのURLパラメータをリクエストに設定
+func withURLParam(req *http.Request, key, value string) *http.Request {
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add(key, value)
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+}
+
+// TestUpdateHelloWorldMessageHandler Hello Worldメッセージ更新ハンドラーのテスト
+func TestUpdateHelloWorldMessageHandler(t *testing.T) {
+	tests := []struct {
+		name           string
+		id             string
+		body           string
+		expectedStatus int
+	}{
+		{"Invalid ID", "invalid", `{"name":"Alice","message":"Hi"}`, http.StatusBadRequest},
+		{"Invalid JSON", "1", `invalid json`, http.StatusBadRequest},
+		{"Empty name", "1", `{"name":"","message":"Hi"}`, http.StatusBadRequest},
+		{"Empty message", "1", `{"name":"Alice"}`, http.StatusBadRequest},
+		{"Database unavailable", "1", `{"name":"Alice","message":"Hi"}`, http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewHelloWorldHandler(nil)
+
+			req := httptest.NewRequest("PUT", "/api/hello-world/messages/"+tt.id, bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			req = withURLParam(req, "id", tt.id)
+			w := httptest.NewRecorder()
+
+			handler.UpdateHelloWorldMessageHandler(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, w.Code)
+			}
+		})
+	}
+}
+
+// TestPatchHelloWorldMessageHandler Hello Worldメッセージ部分更新ハンドラーのテスト
+func TestPatchHelloWorldMessageHandler(t *testing.T) {
+	tests := []struct {
+		name           string
+		id             string
+		body           string
+		expectedStatus int
+	}{
+		{"Invalid ID", "invalid", `{"name":"Alice"}`, http.StatusBadRequest},
+		{"Empty body", "1", ``, http.StatusBadRequest},
+		{"Database unavailable", "1", `{"name":"Alice"}`, http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewHelloWorldHandler(nil)
+
+			req := httptest.NewRequest("PATCH", "/api/hello-world/messages/"+tt.id, bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/merge-patch+json")
+			req = withURLParam(req, "id", tt.id)
+			w := httptest.NewRecorder()
+
+			handler.PatchHelloWorldMessageHandler(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, w.Code)
+			}
+		})
+	}
+}
+
+// TestDeleteHelloWorldMessageHandler Hello Worldメッセージ削除ハンドラーのテスト
+func TestDeleteHelloWorldMessageHandler(t *testing.T) {
+	tests := []struct {
+		name           string
+		id             string
+		expectedStatus int
+	}{
+		{"Invalid ID", "invalid", http.StatusBadRequest},
+		{"Database unavailable", "1", http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewHelloWorldHandler(nil)
+
+			req := httptest.NewRequest("DELETE", "/api/hello-world/messages/"+tt.id, nil)
+			req = withURLParam(req, "id", tt.id)
+			w := httptest.NewRecorder()
+
+			handler.DeleteHelloWorldMessageHandler(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, w.Code)
+			}
+		})
+	}
+}
+
+// TestGetHelloWorldMessagesHandlerInvalidPage 不正なページ指定・検索条件のテスト
+func TestGetHelloWorldMessagesHandlerInvalidPage(t *testing.T) {
+	queries := []string{"limit=0", "limit=abc", "offset=-1", "after=broken", "email=a@example.com", "sort=unknown", "created_after=yesterday"}
+
+	for _, query := range queries {
+		t.Run(query, func(t *testing.T) {
+			handler := NewHelloWorldHandler(nil)
+
+			req := httptest.NewRequest("GET", "/api/hello-world/messages?"+query, nil)
+			w := httptest.NewRecorder()
+
+			handler.GetHelloWorldMessagesHandler(w, req)
+
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("Expected status 400, got %d", w.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,247 @@
+package handler
+
+import (
+	"net/http"
+
+	"backend/models"
+	"backend/services"
+)
+
+// MailHandler 受信箱ハンドラー構造体
+type MailHandler struct {
+	service *services.MailService
+}
+
+// NewMailHandler 受信箱ハンドラーを新規作成
+func NewMailHandler(service *services.MailService) *MailHandler {
+	return &MailHandler{service: service}
+}
+
+// ListMailsHandler メール一覧取得
+// @Summary メール一覧取得
+// @Description 受信箱のメールを認証ユーザーの既読状態（unread）付きでページ単位で取得
+// @Tags mails
+// @Accept json
+// @Produce json
+// @Param filter query string false "all（デフォルト）または unread"
+// @Param limit query int false "取得件数（1〜100、デフォルト20）"
+// @Param offset query int false "オフセット"
+// @Param after query string false "キーセットページング用カーソル（next_cursor の値、デフォルトの並び順のみ）"
+// @Param from query string false "送信者のメールアドレス（完全一致）"
+// @Param thread_id query int false "スレッドID"
+// @Param date_after query string false "日時の下限（RFC3339 または YYYY-MM-DD）"
+// @Param date_before query string false "日時の上限（RFC3339 または YYYY-MM-DD）"
+// @Param sort query string false "並び順（例: -date）。対象: id, subject, from, date, created_at"
+// @Param q query string false "件名・本文・送信者の全文検索"
+// @Success 200 {object} models.SuccessResponse{data=[]models.Mail}
+// @Header 200 {string} Link "RFC 8288 ページネーションリンク"
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/mails [get]
+func (h *MailHandler) ListMailsHandler(w http.ResponseWriter, r *http.Request) {
+	user, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	values := r.URL.Query()
+	filter, err := models.ParseMailFilter(values.Get("filter"))
+	if err != nil {
+		sendQueryError(w, err)
+		return
+	}
+	values.Del("filter")
+
+	spec, err := services.MailQuerySchema.Parse(values)
+	if err != nil {
+		sendQueryError(w, err)
+		return
+	}
+
+	result, err := h.service.ListMails(user, filter, spec)
+	if err != nil {
+		sendError(w, err, "error.retrieve_mails")
+		return
+	}
+
+	pagination := result.Pagination(spec.Page)
+	setPaginationLinks(w, r, spec.Page, pagination)
+	models.SendPaginatedResponse(w, "mail.list_retrieved", result.Items, pagination)
+}
+
+// UnreadCountHandler 未読メール件数取得
+// @Summary 未読メール件数取得
+// @Description 認証ユーザーの未読メール件数を取得（受信箱のバッジ表示用）
+// @Tags mails
+// @Accept json
+// @Produce json
+// @Success 200 {object} models.SuccessResponse{data=models.MailUnreadCountResponse}
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/mails/unread-count [get]
+func (h *MailHandler) UnreadCountHandler(w http.ResponseWriter, r *http.Request) {
+	user, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	count, err := h.service.UnreadCount(user)
+	if err != nil {
+		sendError(w, err, "error.count_unread_mails")
+		return
+	}
+
+	models.SendSuccessResponse(w, "unread_count.retrieved", count)
+}
+
+// GetMailHandler メール取得
+// @Summary メール取得（ID指定）
+// @Description 指定されたIDのメールを取得（既読状態は変更しない）
+// @Tags mails
+// @Accept json
+// @Produce json
+// @Param id path int true "Mail ID"
+// @Success 200 {object} models.SuccessResponse{data=models.Mail}
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/mails/{id} [get]
+func (h *MailHandler) GetMailHandler(w http.ResponseWriter, r *http.Request) {
+	user, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+	id, err := parseMessageID(r)
+	if err != nil {
+		models.SendValidationError(w, "request.invalid_id")
+		return
+	}
+
+	mail, err := h.service.GetMail(user, id)
+	if err != nil {
+		sendError(w, err, "error.retrieve_mail")
+		return
+	}
+
+	models.SendSuccessResponse(w, "mail.retrieved", mail)
+}
+
+// GetThreadHandler メールスレッド取得
+// @Summary メールスレッド取得
+// @Description 指定されたメールが属するスレッドの全メールを日時の昇順で取得
+// @Tags mails
+// @Accept json
+// @Produce json
+// @Param id path int true "Mail ID"
+// @Success 200 {object} models.SuccessResponse{data=[]models.Mail}
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/mails/{id}/thread [get]
+func (h *MailHandler) GetThreadHandler(w http.ResponseWriter, r *http.Request) {
+	user, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+	id, err := parseMessageID(r)
+	if err != nil {
+		models.SendValidationError(w, "request.invalid_id")
+		return
+	}
+
+	thread, err := h.service.GetThread(user, id)
+	if err != nil {
+		sendError(w, err, "error.retrieve_mail_thread")
+		return
+	}
+
+	models.SendSuccessResponse(w, "mail.thread_retrieved", thread)
+}
+
+// CreateMailHandler メール登録
+// @Summary メール登録
+// @Description 受信箱にメールを登録（In-Reply-To または返信件名で既存スレッドにまとめる）
+// @Tags mails
+// @Accept json
+// @Produce json
+// @Param request body models.MailRequest true "Mail Request"
+// @Success 201 {object} models.SuccessResponse{data=models.Mail}
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
+// @Failure 413 {object} models.ErrorResponse
+// @Failure 415 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/mails [post]
+func (h *MailHandler) CreateMailHandler(w http.ResponseWriter, r *http.Request) {
+	var request models.MailRequest
+	if !decodeJSON(w, r, &request) {
+		return
+	}
+
+	mail, err := h.service.CreateMail(&request)
+	if err != nil {
+		sendError(w, err, "error.create_mail")
+		return
+	}
+
+	models.SendCreatedResponse(w, "mail.created", mail)
+}
+
+// UpdateMailHandler メールの既読・未読切り替え
+// @Summary メールの既読・未読切り替え
+// @Description 認証ユーザーにとっての既読状態を更新（unread のみ変更可能）
+// @Tags mails
+// @Accept json
+// @Accept application/merge-patch+json
+// @Produce json
+// @Param id path int true "Mail ID"
+// @Param request body models.MailReadStateRequest true "Read state"
+// @Success 200 {object} models.SuccessResponse{data=models.Mail}
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 413 {object} models.ErrorResponse
+// @Failure 415 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Security BearerAuth
+// @Router /api/mails/{id} [patch]
+func (h *MailHandler) UpdateMailHandler(w http.ResponseWriter, r *http.Request) {
+	user, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+	id, err := parseMessageID(r)
+	if err != nil {
+		models.SendValidationError(w, "request.invalid_id")
+		return
+	}
+
+	var request models.MailReadStateRequest
+	if !decodeJSON(w, r, &request) {
+		return
+	}
+
+	mail, err := h.service.UpdateReadState(user, id, &request)
+	if err != nil {
+		sendError(w, err, "error.update_mail")
+		return
+	}
+
+	models.SendSuccessResponse(w, "mail.updated", mail)
+}
@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	custommiddleware "backend/middleware"
+	"backend/models"
+	"backend/services"
+)
+
+// TestMailHandlers 受信箱の一覧・既読切り替え・未読件数ハンドラーのテスト
+func TestMailHandlers(t *testing.T) {
+	service := services.NewMailService(services.NewMemoryMailRepository(), nil)
+	h := NewMailHandler(service)
+	user := &models.User{ID: 1}
+
+	serveAs := func(handlerFunc http.HandlerFunc, method, target, body, id string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		req = req.WithContext(custommiddleware.WithUser(req.Context(), user))
+		if id != "" {
+			req = withURLParam(req, "id", id)
+		}
+		w := httptest.NewRecorder()
+		handlerFunc(w, req)
+		return w
+	}
+
+	w := serveAs(h.CreateMailHandler, "POST", "/", `{"from":{"name":"Alex Smith","email":"alex.smith@example.com"},"subject":"Meeting Schedule","body":"Dear Team"}`, "")
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Create: expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	var created struct {
+		Data models.Mail `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &created); err != nil || !created.Data.Unread {
+		t.Fatalf("Unexpected created mail: %s", w.Body.String())
+	}
+	id := strconv.Itoa(created.Data.ID)
+	if _, err := service.CreateMail(&models.MailRequest{From: models.MailSender{Email: "jordan@example.com"}, Subject: "RE: Meeting Schedule"}); err != nil {
+		t.Fatalf("CreateMail() error = %v", err)
+	}
+
+	tests := []struct {
+		name           string
+		handler        http.HandlerFunc
+		method         string
+		target         string
+		body           string
+		id             string
+		expectedStatus int
+	}{
+		{"Create invalid body", h.CreateMailHandler, "POST", "/", `{`, "", http.StatusBadRequest},
+		{"Create missing subject", h.CreateMailHandler, "POST", "/", `{"from":{"email":"a@example.com"}}`, "", http.StatusBadRequest},
+		{"Create duplicate Message-ID", h.CreateMailHandler, "POST", "/", `{"from":{"email":"a@example.com"},"subject":"A","message_id":"` + created.Data.MessageID + `"}`, "", http.StatusConflict},
+		{"List invalid filter", h.ListMailsHandler, "GET", "/api/mails?filter=starred", "", "", http.StatusBadRequest},
+		{"Get", h.GetMailHandler, "GET", "/", "", id, http.StatusOK},
+		{"Get not found", h.GetMailHandler, "GET", "/", "", "999", http.StatusNotFound},
+		{"Thread", h.GetThreadHandler, "GET", "/", "", id, http.StatusOK},
+		{"Update missing unread", h.UpdateMailHandler, "PATCH", "/", `{}`, id, http.StatusBadRequest},
+		{"Update other field", h.UpdateMailHandler, "PATCH", "/", `{"unread":false,"subject":"x"}`, id, http.StatusBadRequest},
+		{"Update not found", h.UpdateMailHandler, "PATCH", "/", `{"unread":false}`, "999", http.StatusNotFound},
+		{"Update", h.UpdateMailHandler, "PATCH", "/", `{"unread":false}`, id, http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if w := serveAs(tt.handler, tt.method, tt.target, tt.body, tt.id); w.Code != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+
+	w = serveAs(h.ListMailsHandler, "GET", "/api/mails?filter=unread", "", "")
+	var listed struct {
+		Data []models.Mail `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &listed); err != nil || len(listed.Data) != 1 || listed.Data[0].ID == created.Data.ID {
+		t.Errorf("Unread list: unexpected response %d: %s", w.Code, w.Body.String())
+	}
+
+	w = serveAs(h.UnreadCountHandler, "GET", "/", "", "")
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `"unread":1`) {
+		t.Errorf("Unread count: unexpected response %d: %s", w.Code, w.Body.String())
+	}
+
+	// 認証済みユーザーがない場合は401
+	w = httptest.NewRecorder()
+	h.UnreadCountHandler(w, httptest.NewRequest("GET", "/", nil))
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("Unauthenticated: expected 401, got %d", w.Code)
+	}
+}
@@ -0,0 +1,210 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"backend/models"
+	"backend/services"
+)
+
+// LoginMFAHandler ログイン2段階目
+// @Summary ログイン2段階目（二要素認証）
+// @Description ログインで発行された mfa_token と、認証アプリのコードまたはリカバリーコードでログインを完了
+// @Description 1つの mfa_token で試行できる回数には上限があります
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body models.MFALoginRequest true "MFA Login Request"
+// @Success 200 {object} models.SuccessResponse{data=models.AuthResponse}
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 413 {object} models.ErrorResponse
+// @Failure 415 {object} models.ErrorResponse
+// @Failure 429 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /api/auth/login/mfa [post]
+func (h *AuthHandler) LoginMFAHandler(w http.ResponseWriter, r *http.Request) {
+	var request models.MFALoginRequest
+	if !decodeJSON(w, r, &request) {
+		return
+	}
+
+	result, err := h.service.CompleteMFALogin(&request, clientInfo(r))
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrInvalidToken):
+			models.SendUnauthorizedError(w, "auth.invalid_mfa_token")
+		case errors.Is(err, services.ErrInvalidMFACode):
+			models.SendUnauthorizedError(w, "mfa.invalid_code")
+		default:
+			sendError(w, err, "error.log_in")
+		}
+		return
+	}
+
+	models.SendSuccessResponse(w, "auth.logged_in", result)
+}
+
+// MFAStatusHandler 二要素認証の設定状況
+// @Summary 二要素認証の設定状況
+// @Description TOTPが有効か、未使用のリカバリーコードの残数を取得
+// @Tags mfa
+// @Produce json
+// @Success 200 {object} models.SuccessResponse{data=models.MFAStatus}
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Security BearerAuth
+// @Router /api/auth/mfa [get]
+func (h *AuthHandler) MFAStatusHandler(w http.ResponseWriter, r *http.Request) {
+	user, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	status, err := h.service.MFAStatus(user)
+	if err != nil {
+		sendError(w, err, "error.get_mfa_status")
+		return
+	}
+
+	models.SendSuccessResponse(w, "mfa.status_retrieved", status)
+}
+
+// EnrollTOTPHandler TOTP登録開始
+// @Summary TOTP登録開始
+// @Description 新しいTOTP秘密鍵を生成し、otpauth URI とQRコード（PNGのdata URI）を返す
+// @Description /api/auth/mfa/totp/verify でコードを確認するまで有効になりません
+// @Tags mfa
+// @Produce json
+// @Success 200 {object} models.SuccessResponse{data=models.TOTPEnrollment}
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Security BearerAuth
+// @Router /api/auth/mfa/totp/enroll [post]
+func (h *AuthHandler) EnrollTOTPHandler(w http.ResponseWriter, r *http.Request) {
+	user, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	enrollment, err := h.service.EnrollTOTP(user)
+	if err != nil {
+		sendError(w, err, "error.enroll_totp")
+		return
+	}
+
+	models.SendSuccessResponse(w, "mfa.enrollment_started", enrollment)
+}
+
+// VerifyTOTPHandler TOTP登録確認
+// @Summary TOTP登録確認
+// @Description 認証アプリのコードで登録を確認して二要素認証を有効化し、リカバリーコードを発行（再表示不可）
+// @Tags mfa
+// @Accept json
+// @Produce json
+// @Param request body models.MFACodeRequest true "MFA Code Request"
+// @Success 200 {object} models.SuccessResponse{data=models.RecoveryCodesResponse}
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
+// @Failure 413 {object} models.ErrorResponse
+// @Failure 415 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Security BearerAuth
+// @Router /api/auth/mfa/totp/verify [post]
+func (h *AuthHandler) VerifyTOTPHandler(w http.ResponseWriter, r *http.Request) {
+	user, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	var request models.MFACodeRequest
+	if !decodeJSON(w, r, &request) {
+		return
+	}
+
+	codes, err := h.service.ConfirmTOTP(user, &request)
+	if err != nil {
+		if errors.Is(err, services.ErrMFANotEnabled) {
+			models.SendConflictError(w, "mfa.enrollment_not_started")
+			return
+		}
+		sendError(w, err, "error.verify_totp")
+		return
+	}
+
+	models.SendSuccessResponse(w, "mfa.enabled", codes)
+}
+
+// DisableTOTPHandler 二要素認証の無効化
+// @Summary 二要素認証の無効化
+// @Description 認証アプリのコードまたはリカバリーコードを確認して二要素認証を無効化
+// @Tags mfa
+// @Accept json
+// @Produce json
+// @Param request body models.MFACodeRequest true "MFA Code Request"
+// @Success 200 {object} models.SuccessResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
+// @Failure 413 {object} models.ErrorResponse
+// @Failure 415 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Security BearerAuth
+// @Router /api/auth/mfa/totp [delete]
+func (h *AuthHandler) DisableTOTPHandler(w http.ResponseWriter, r *http.Request) {
+	user, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	var request models.MFACodeRequest
+	if !decodeJSON(w, r, &request) {
+		return
+	}
+
+	if err := h.service.DisableTOTP(user, &request); err != nil {
+		sendError(w, err, "error.disable_totp")
+		return
+	}
+
+	models.SendSuccessResponse(w, "mfa.disabled", nil)
+}
+
+// RegenerateRecoveryCodesHandler リカバリーコード再発行
+// @Summary リカバリーコード再発行
+// @Description 認証アプリのコードまたはリカバリーコードを確認してリカバリーコードを再発行（以前のコードは無効）
+// @Tags mfa
+// @Accept json
+// @Produce json
+// @Param request body models.MFACodeRequest true "MFA Code Request"
+// @Success 200 {object} models.SuccessResponse{data=models.RecoveryCodesResponse}
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
+// @Failure 413 {object} models.ErrorResponse
+// @Failure 415 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Security BearerAuth
+// @Router /api/auth/mfa/recovery-codes [post]
+func (h *AuthHandler) RegenerateRecoveryCodesHandler(w http.ResponseWriter, r *http.Request) {
+	user, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	var request models.MFACodeRequest
+	if !decodeJSON(w, r, &request) {
+		return
+	}
+
+	codes, err := h.service.RegenerateRecoveryCodes(user, &request)
+	if err != nil {
+		sendError(w, err, "error.regenerate_recovery_codes")
+		return
+	}
+
+	models.SendSuccessResponse(w, "mfa.recovery_codes_regenerated", codes)
+}
@@ -0,0 +1,101 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pquerna/otp/totp"
+
+	custommiddleware "backend/middleware"
+	"backend/models"
+)
+
+// TestMFAHandlers TOTP登録から二段階ログインまでのハンドラーテスト
+func TestMFAHandlers(t *testing.T) {
+	h := newTestAuthHandler(t)
+	registered, err := h.service.Register(&models.RegisterRequest{Email: "mfa@example.com", Password: "password123", Name: "MFA"}, models.ClientInfo{})
+	if err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	user, err := h.service.Authenticate(registered.AccessToken)
+	if err != nil {
+		t.Fatalf("Authenticate() error = %v", err)
+	}
+
+	serveAs := func(handlerFunc http.HandlerFunc, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", "/", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		req = req.WithContext(custommiddleware.WithUser(req.Context(), user))
+		w := httptest.NewRecorder()
+		handlerFunc(w, req)
+		return w
+	}
+	codeAt := func(secret string, at time.Time) string {
+		code, err := totp.GenerateCode(secret, at)
+		if err != nil {
+			t.Fatalf("GenerateCode() error = %v", err)
+		}
+		return code
+	}
+
+	if w := serveAs(h.VerifyTOTPHandler, `{"code":"123456"}`); w.Code != http.StatusConflict {
+		t.Errorf("Verify before enroll: expected 409, got %d", w.Code)
+	}
+
+	w := serveAs(h.EnrollTOTPHandler, "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("Enroll: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var enrolled struct {
+		Data models.TOTPEnrollment `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &enrolled); err != nil || enrolled.Data.Secret == "" {
+		t.Fatalf("Unexpected enrollment: %s", w.Body.String())
+	}
+	secret := enrolled.Data.Secret
+
+	if w := serveAs(h.VerifyTOTPHandler, `{"code":"000000"}`); w.Code != http.StatusBadRequest {
+		t.Errorf("Verify wrong code: expected 400, got %d", w.Code)
+	}
+	w = serveAs(h.VerifyTOTPHandler, `{"code":"`+codeAt(secret, time.Now())+`"}`)
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `"recovery_codes"`) {
+		t.Fatalf("Verify: unexpected response %d: %s", w.Code, w.Body.String())
+	}
+	if w := serveAs(h.EnrollTOTPHandler, ""); w.Code != http.StatusConflict {
+		t.Errorf("Enroll again: expected 409, got %d", w.Code)
+	}
+	if w := serveAs(h.MFAStatusHandler, ""); !strings.Contains(w.Body.String(), `"totp_enabled":true`) {
+		t.Errorf("Status: unexpected response %s", w.Body.String())
+	}
+
+	// パスワード認証後は mfa_token が返り、トークンは発行されない
+	w = serveAuth(h.LoginHandler, `{"email":"mfa@example.com","password":"password123"}`)
+	var challenge struct {
+		Data models.MFAChallengeResponse `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &challenge); err != nil || w.Code != http.StatusOK || !challenge.Data.MFARequired {
+		t.Fatalf("Login: unexpected response %d: %s", w.Code, w.Body.String())
+	}
+	if strings.Contains(w.Body.String(), "access_token") {
+		t.Error("Login: access token issued before MFA")
+	}
+
+	if w := serveAuth(h.LoginMFAHandler, `{"mfa_token":"invalid","code":"123456"}`); w.Code != http.StatusUnauthorized {
+		t.Errorf("Login MFA invalid token: expected 401, got %d", w.Code)
+	}
+	if w := serveAuth(h.LoginMFAHandler, `{"mfa_token":"`+challenge.Data.MFAToken+`","code":"000000"}`); w.Code != http.StatusUnauthorized {
+		t.Errorf("Login MFA wrong code: expected 401, got %d", w.Code)
+	}
+	w = serveAuth(h.LoginMFAHandler, `{"mfa_token":"`+challenge.Data.MFAToken+`","code":"`+codeAt(secret, time.Now().Add(30*time.Second))+`"}`)
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), "access_token") {
+		t.Errorf("Login MFA: unexpected response %d: %s", w.Code, w.Body.String())
+	}
+	if w := serveAuth(h.LoginMFAHandler, `{"mfa_token":"`+challenge.Data.MFAToken+`","code":"000000"}`); w.Code != http.StatusTooManyRequests {
+		t.Errorf("Login MFA reuse: expected 429, got %d", w.Code)
+	}
+}
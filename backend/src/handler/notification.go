@@ -0,0 +1,237 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"backend/models"
+	"backend/services"
+)
+
+// NotificationHandler 通知ハンドラー構造体
+type NotificationHandler struct {
+	service *services.NotificationService
+}
+
+// NewNotificationHandler 通知ハンドラーを新規作成
+func NewNotificationHandler(service *services.NotificationService) *NotificationHandler {
+	return &NotificationHandler{service: service}
+}
+
+// ListNotificationsHandler 通知一覧取得
+// @Summary 通知一覧取得
+// @Description 認証ユーザー宛ての通知を新しい順にページ単位で取得
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Param filter query string false "all（デフォルト）または unread"
+// @Param limit query int false "取得件数（1〜100、デフォルト20）"
+// @Param offset query int false "オフセット"
+// @Param after query string false "キーセットページング用カーソル（next_cursor の値、デフォルトの並び順のみ）"
+// @Param type query string false "通知の種類（customer.created, mail.received）"
+// @Param created_after query string false "作成日時の下限（RFC3339 または YYYY-MM-DD）"
+// @Param created_before query string false "作成日時の上限（RFC3339 または YYYY-MM-DD）"
+// @Param sort query string false "並び順（例: -created_at）。対象: id, created_at"
+// @Success 200 {object} models.SuccessResponse{data=[]models.Notification}
+// @Header 200 {string} Link "RFC 8288 ページネーションリンク"
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/notifications [get]
+func (h *NotificationHandler) ListNotificationsHandler(w http.ResponseWriter, r *http.Request) {
+	user, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	values := r.URL.Query()
+	filter, err := models.ParseMailFilter(values.Get("filter"))
+	if err != nil {
+		sendQueryError(w, err)
+		return
+	}
+	values.Del("filter")
+
+	spec, err := services.NotificationQuerySchema.Parse(values)
+	if err != nil {
+		sendQueryError(w, err)
+		return
+	}
+
+	result, err := h.service.ListNotifications(user, filter == models.MailFilterUnread, spec)
+	if err != nil {
+		sendError(w, err, "error.retrieve_notifications")
+		return
+	}
+
+	pagination := result.Pagination(spec.Page)
+	setPaginationLinks(w, r, spec.Page, pagination)
+	models.SendPaginatedResponse(w, "notification.list_retrieved", result.Items, pagination)
+}
+
+// UnreadCountHandler 未読通知件数取得
+// @Summary 未読通知件数取得
+// @Description 認証ユーザー宛ての未読通知件数を取得（通知バッジ表示用）
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Success 200 {object} models.SuccessResponse{data=models.NotificationUnreadCountResponse}
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/notifications/unread-count [get]
+func (h *NotificationHandler) UnreadCountHandler(w http.ResponseWriter, r *http.Request) {
+	user, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	count, err := h.service.UnreadCount(user)
+	if err != nil {
+		sendError(w, err, "error.count_unread_notifications")
+		return
+	}
+
+	models.SendSuccessResponse(w, "unread_count.retrieved", count)
+}
+
+// MarkReadHandler 通知を既読にする
+// @Summary 通知を既読にする
+// @Description 指定された通知を既読にする（既読済みの場合は既読日時を変更しない）
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Param id path int true "Notification ID"
+// @Success 200 {object} models.SuccessResponse{data=models.Notification}
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Security BearerAuth
+// @Router /api/notifications/{id}/read [post]
+func (h *NotificationHandler) MarkReadHandler(w http.ResponseWriter, r *http.Request) {
+	user, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+	id, err := parseMessageID(r)
+	if err != nil {
+		models.SendValidationError(w, "request.invalid_id")
+		return
+	}
+
+	notification, err := h.service.MarkRead(user, id)
+	if err != nil {
+		h.sendError(w, err, "error.mark_notification_as_read")
+		return
+	}
+
+	models.SendSuccessResponse(w, "notification.marked_read", notification)
+}
+
+// MarkAllReadHandler 全ての通知を既読にする
+// @Summary 全ての通知を既読にする
+// @Description 認証ユーザー宛ての未読通知を全て既読にし、更新件数を返す
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Success 200 {object} models.SuccessResponse{data=models.NotificationReadAllResponse}
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Security BearerAuth
+// @Router /api/notifications/read-all [post]
+func (h *NotificationHandler) MarkAllReadHandler(w http.ResponseWriter, r *http.Request) {
+	user, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	result, err := h.service.MarkAllRead(user)
+	if err != nil {
+		sendError(w, err, "error.mark_notifications_as_read")
+		return
+	}
+
+	models.SendSuccessResponse(w, "notification.all_marked_read", result)
+}
+
+// GetPreferencesHandler 通知設定取得
+// @Summary 通知設定取得
+// @Description 認証ユーザーの通知設定を全ての通知の種類（customer.created, mail.received）とチャネル（in_app, email, desktop）の組み合わせについて取得
+// @Description 変更していない組み合わせは既定値（アプリ内通知は全て有効、メールは mail.received のみ有効）です
+// @Description 通知の種類によらない設定（weekly_digest, product_updates, important_updates）も返します（既定値は weekly_digest のみ無効）
+// @Tags me
+// @Produce json
+// @Success 200 {object} models.SuccessResponse{data=models.NotificationPreferencesResponse}
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/me/notification-preferences [get]
+func (h *NotificationHandler) GetPreferencesHandler(w http.ResponseWriter, r *http.Request) {
+	user, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	preferences, err := h.service.GetPreferences(user)
+	if err != nil {
+		h.sendError(w, err, "error.retrieve_notification_preferences")
+		return
+	}
+
+	models.SendSuccessResponse(w, "notification.preferences_retrieved", preferences)
+}
+
+// UpdatePreferencesHandler 通知設定更新
+// @Summary 通知設定更新
+// @Description 認証ユーザーの通知設定を置き換え（含まれない組み合わせは既定値に戻る）、更新後の全ての設定を返す
+// @Description 無効にしたチャネルでは、以降その種類の通知を作成・送信しません
+// @Description 通知の種類によらない設定も同時に置き換えます（含まれない項目は既定値）。APIキーでは更新できません
+// @Tags me
+// @Accept json
+// @Produce json
+// @Param request body models.NotificationPreferencesRequest true "Notification preferences"
+// @Success 200 {object} models.SuccessResponse{data=models.NotificationPreferencesResponse}
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 413 {object} models.ErrorResponse
+// @Failure 415 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Security BearerAuth
+// @Router /api/me/notification-preferences [put]
+func (h *NotificationHandler) UpdatePreferencesHandler(w http.ResponseWriter, r *http.Request) {
+	user, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	var request models.NotificationPreferencesRequest
+	if !decodeJSON(w, r, &request) {
+		return
+	}
+
+	preferences, err := h.service.UpdatePreferences(user, &request)
+	if err != nil {
+		h.sendError(w, err, "error.update_notification_preferences")
+		return
+	}
+
+	models.SendSuccessResponse(w, "notification.preferences_updated", preferences)
+}
+
+// sendError 通知サービスのエラーをレスポンスに変換
+func (h *NotificationHandler) sendError(w http.ResponseWriter, err error, message string) {
+	if errors.Is(err, services.ErrUserNotFound) {
+		// 認証済みのユーザーがリクエスト中に削除された
+		models.SendUnauthorizedError(w, "auth.required")
+		return
+	}
+	sendError(w, err, message)
+}
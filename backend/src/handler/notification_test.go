@@ -0,0 +1,180 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	custommiddleware "backend/middleware"
+	"backend/models"
+	"backend/services"
+)
+
+// TestNotificationHandlers 通知の一覧・既読・未読件数ハンドラーのテスト
+func TestNotificationHandlers(t *testing.T) {
+	users := services.NewMemoryUserRepository()
+	service := services.NewNotificationService(services.NewMemoryNotificationRepository(users), services.NewMemoryNotificationPreferenceRepository(users), users, nil, services.NotificationEmailConfig{})
+	h := NewNotificationHandler(service)
+	user, _ := users.Create("alice@example.com", "Alice", "hash")
+	other, _ := users.Create("bob@example.com", "Bob", "hash")
+
+	event := &services.NotificationEvent{Type: models.NotificationTypeCustomerCreated, Sender: models.NotificationSender{Name: "Alex Smith"}, Body: "was added as a new customer"}
+	for i := 0; i < 2; i++ {
+		if err := service.NotifyAll(event); err != nil {
+			t.Fatalf("NotifyAll() error = %v", err)
+		}
+	}
+	spec, _ := services.NotificationQuerySchema.Parse(nil)
+	othersPage, _ := service.ListNotifications(other, false, spec)
+	othersID := strconv.Itoa(othersPage.Items[0].ID)
+
+	serveAs := func(handlerFunc http.HandlerFunc, method, target, id string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, nil)
+		req = req.WithContext(custommiddleware.WithUser(req.Context(), user))
+		if id != "" {
+			req = withURLParam(req, "id", id)
+		}
+		w := httptest.NewRecorder()
+		handlerFunc(w, req)
+		return w
+	}
+
+	w := serveAs(h.ListNotificationsHandler, "GET", "/api/notifications?filter=unread&limit=1", "")
+	var listed struct {
+		Data       []models.Notification `json:"data"`
+		Pagination models.Pagination     `json:"pagination"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &listed); err != nil || w.Code != http.StatusOK {
+		t.Fatalf("List: unexpected response %d: %s", w.Code, w.Body.String())
+	}
+	if len(listed.Data) != 1 || listed.Pagination.Total != 2 || w.Header().Get("Link") == "" {
+		t.Fatalf("List: unexpected page: %s", w.Body.String())
+	}
+	id := strconv.Itoa(listed.Data[0].ID)
+
+	tests := []struct {
+		name           string
+		handler        http.HandlerFunc
+		method         string
+		target         string
+		id             string
+		expectedStatus int
+	}{
+		{"List invalid filter", h.ListNotificationsHandler, "GET", "/api/notifications?filter=starred", "", http.StatusBadRequest},
+		{"List unknown sort", h.ListNotificationsHandler, "GET", "/api/notifications?sort=body", "", http.StatusBadRequest},
+		{"List search unsupported", h.ListNotificationsHandler, "GET", "/api/notifications?q=alex", "", http.StatusBadRequest},
+		{"Read invalid ID", h.MarkReadHandler, "POST", "/", "abc", http.StatusBadRequest},
+		{"Read not found", h.MarkReadHandler, "POST", "/", "999", http.StatusNotFound},
+		{"Read other user's notification", h.MarkReadHandler, "POST", "/", othersID, http.StatusNotFound},
+		{"Read", h.MarkReadHandler, "POST", "/", id, http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if w := serveAs(tt.handler, tt.method, tt.target, tt.id); w.Code != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+
+	var count struct {
+		Data models.NotificationUnreadCountResponse `json:"data"`
+	}
+	w = serveAs(h.UnreadCountHandler, "GET", "/api/notifications/unread-count", "")
+	if err := json.Unmarshal(w.Body.Bytes(), &count); err != nil || count.Data.Unread != 1 {
+		t.Errorf("UnreadCount: unexpected response: %s", w.Body.String())
+	}
+
+	var readAll struct {
+		Data models.NotificationReadAllResponse `json:"data"`
+	}
+	w = serveAs(h.MarkAllReadHandler, "POST", "/api/notifications/read-all", "")
+	if err := json.Unmarshal(w.Body.Bytes(), &readAll); err != nil || readAll.Data.Updated != 1 {
+		t.Errorf("MarkAllRead: unexpected response: %s", w.Body.String())
+	}
+	if remaining, _ := service.UnreadCount(other); remaining.Unread != 2 {
+		t.Errorf("他のユーザーの通知が既読になった: %d", remaining.Unread)
+	}
+
+	// 認証なし
+	w = httptest.NewRecorder()
+	h.ListNotificationsHandler(w, httptest.NewRequest("GET", "/api/notifications", nil))
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("Expected 401 without user, got %d", w.Code)
+	}
+}
+
+// TestNotificationPreferenceHandlers 通知設定の取得・更新ハンドラーのテスト
+func TestNotificationPreferenceHandlers(t *testing.T) {
+	users := services.NewMemoryUserRepository()
+	service := services.NewNotificationService(services.NewMemoryNotificationRepository(users), services.NewMemoryNotificationPreferenceRepository(users), users, nil, services.NotificationEmailConfig{})
+	h := NewNotificationHandler(service)
+	user, _ := users.Create("alice@example.com", "Alice", "hash")
+
+	serve := func(handlerFunc http.HandlerFunc, method, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, "/api/me/notification-preferences", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req = req.WithContext(custommiddleware.WithUser(req.Context(), user))
+		w := httptest.NewRecorder()
+		handlerFunc(w, req)
+		return w
+	}
+
+	w := serve(h.UpdatePreferencesHandler, "PUT", `{"preferences":[{"type":"mail.received","channel":"email","enabled":false}]}`)
+	var updated struct {
+		Data models.NotificationPreferencesResponse `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &updated); err != nil || w.Code != http.StatusOK {
+		t.Fatalf("Update: unexpected response %d: %s", w.Code, w.Body.String())
+	}
+	if len(updated.Data.Preferences) != len(models.NotificationTypes)*len(models.NotificationChannels) {
+		t.Errorf("Update: 全ての組み合わせを返さない: %s", w.Body.String())
+	}
+
+	w = serve(h.GetPreferencesHandler, "GET", "")
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `{"type":"mail.received","channel":"email","enabled":false}`) {
+		t.Errorf("Get: unexpected response %d: %s", w.Code, w.Body.String())
+	}
+
+	tests := []struct {
+		name           string
+		body           string
+		expectedStatus int
+		expectedField  string
+	}{
+		{"Invalid JSON", `{`, http.StatusBadRequest, ""},
+		{"Unknown field", `{"preferences":[],"marketing":true}`, http.StatusBadRequest, ""},
+		{"Missing preferences", `{}`, http.StatusBadRequest, "preferences"},
+		{"Unknown channel", `{"preferences":[{"type":"mail.received","channel":"sms","enabled":true}]}`, http.StatusBadRequest, "preferences[0].channel"},
+		{"Reset to defaults", `{"preferences":[]}`, http.StatusOK, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serve(h.UpdatePreferencesHandler, "PUT", tt.body)
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+			if tt.expectedField != "" && !strings.Contains(w.Body.String(), `"field":"`+tt.expectedField+`"`) {
+				t.Errorf("Expected error for %s: %s", tt.expectedField, w.Body.String())
+			}
+		})
+	}
+
+	// 通知の種類によらない設定は保存して返す（含まれない項目は既定値）
+	w = serve(h.UpdatePreferencesHandler, "PUT", `{"preferences":[],"weekly_digest":true,"product_updates":false}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Update settings: unexpected response %d: %s", w.Code, w.Body.String())
+	}
+	w = serve(h.GetPreferencesHandler, "GET", "")
+	var got struct {
+		Data models.NotificationPreferencesResponse `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil || w.Code != http.StatusOK {
+		t.Fatalf("Get settings: unexpected response %d: %s", w.Code, w.Body.String())
+	}
+	if want := (models.NotificationSettings{WeeklyDigest: true, ProductUpdates: false, ImportantUpdates: true}); got.Data.NotificationSettings != want {
+		t.Errorf("Get settings = %+v, want %+v", got.Data.NotificationSettings, want)
+	}
+}
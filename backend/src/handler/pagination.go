@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"backend/models"
+	"backend/services"
+)
+
+// sendQueryError クエリパラメータの検証エラーを送信（*models.ValidationError はフィールドごとの詳細を含める）
+// それ以外のエラーは内部の詳細を含めず、共通のメッセージで送信します
+func sendQueryError(w http.ResponseWriter, err error) {
+	var validationErr *models.ValidationError
+	if errors.As(err, &validationErr) {
+		models.SendFieldValidationError(w, validationErr)
+		return
+	}
+	models.SendValidationError(w, "query.invalid")
+}
+
+// setPaginationLinks RFC 8288 形式のLinkヘッダーを設定
+// limit/offset/after 以外のクエリパラメータ（検索条件など）はそのまま引き継ぎます
+func setPaginationLinks(w http.ResponseWriter, r *http.Request, page services.PageRequest, pagination *models.Pagination) {
+	var links []string
+
+	link := func(rel string, offset int, after string) {
+		query := r.URL.Query()
+		query.Del("offset")
+		query.Del("after")
+		query.Set("limit", strconv.Itoa(page.Limit))
+		if offset > 0 {
+			query.Set("offset", strconv.Itoa(offset))
+		}
+		if after != "" {
+			query.Set("after", after)
+		}
+		links = append(links, fmt.Sprintf(`<%s?%s>; rel="%s"`, r.URL.Path, query.Encode(), rel))
+	}
+
+	link("first", 0, "")
+
+	if pagination.HasMore {
+		if page.After != nil {
+			link("next", 0, pagination.NextCursor)
+		} else {
+			link("next", page.Offset+page.Limit, "")
+		}
+	}
+
+	if page.After == nil && page.Offset > 0 {
+		prev := page.Offset - page.Limit
+		if prev < 0 {
+			prev = 0
+		}
+		link("prev", prev, "")
+	}
+
+	w.Header().Set("Link", strings.Join(links, ", "))
+}
@@ -0,0 +1,109 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"backend/models"
+	"backend/services"
+)
+
+// TestSetPaginationLinks Linkヘッダー生成のテスト
+func TestSetPaginationLinks(t *testing.T) {
+	cursor := services.Cursor{CreatedAt: time.Now().UTC(), ID: 7}.Encode()
+
+	tests := []struct {
+		name       string
+		url        string
+		page       services.PageRequest
+		pagination *models.Pagination
+		contains   []string
+		excludes   []string
+	}{
+		{
+			name:       "First page with more",
+			url:        "/api/hello-world/messages?limit=2",
+			page:       services.PageRequest{Limit: 2},
+			pagination: &models.Pagination{HasMore: true, NextCursor: cursor},
+			contains: []string{
+				`</api/hello-world/messages?limit=2>; rel="first"`,
+				`</api/hello-world/messages?limit=2&offset=2>; rel="next"`,
+			},
+			excludes: []string{`rel="prev"`},
+		},
+		{
+			name:       "Offset page",
+			url:        "/api/hello-world/messages?limit=2&offset=3",
+			page:       services.PageRequest{Limit: 2, Offset: 3},
+			pagination: &models.Pagination{HasMore: false},
+			contains: []string{
+				`</api/hello-world/messages?limit=2&offset=1>; rel="prev"`,
+			},
+			excludes: []string{`rel="next"`},
+		},
+		{
+			name:       "Cursor page keeps other params",
+			url:        "/api/hello-world/messages?limit=2&after=abc&name=Alice",
+			page:       services.PageRequest{Limit: 2, After: &services.Cursor{ID: 1}},
+			pagination: &models.Pagination{HasMore: true, NextCursor: cursor},
+			contains: []string{
+				`</api/hello-world/messages?after=` + cursor + `&limit=2&name=Alice>; rel="next"`,
+				`</api/hello-world/messages?limit=2&name=Alice>; rel="first"`,
+			},
+			excludes: []string{`rel="prev"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", tt.url, nil)
+			w := httptest.NewRecorder()
+
+			setPaginationLinks(w, req, tt.page, tt.pagination)
+
+			link := w.Header().Get("Link")
+			for _, want := range tt.contains {
+				if !strings.Contains(link, want) {
+					t.Errorf("Expected Link to contain %q, got %q", want, link)
+				}
+			}
+			for _, unwanted := range tt.excludes {
+				if strings.Contains(link, unwanted) {
+					t.Errorf("Expected Link not to contain %q, got %q", unwanted, link)
+				}
+			}
+		})
+	}
+}
+
+// TestSendQueryError クエリパラメータの検証エラーのレスポンスのテスト
+func TestSendQueryError(t *testing.T) {
+	tests := []struct {
+		name        string
+		err         error
+		wantMessage string
+		wantErrors  int
+	}{
+		{"Validation error", models.NewValidationError("limit", models.ValidationCodeInvalid, "pagination.invalid_limit", map[string]interface{}{"max": 100}), "limit must be between 1 and 100", 1},
+		{"Other error", errors.New(`pq: invalid input syntax for type timestamp: "x"`), "Invalid query parameters", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			sendQueryError(rr, tt.err)
+
+			var response models.ErrorResponse
+			if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if rr.Code != http.StatusBadRequest || response.Error != "validation_error" || response.Message != tt.wantMessage || len(response.Errors) != tt.wantErrors {
+				t.Errorf("sendQueryError() = %d %+v", rr.Code, response)
+			}
+		})
+	}
+}
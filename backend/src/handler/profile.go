@@ -0,0 +1,172 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"backend/models"
+	"backend/services"
+)
+
+// ProfileHandler 認証ユーザー自身のプロフィール・アカウント設定ハンドラー構造体
+type ProfileHandler struct {
+	service *services.ProfileService
+}
+
+// NewProfileHandler プロフィールハンドラーを新規作成
+func NewProfileHandler(service *services.ProfileService) *ProfileHandler {
+	return &ProfileHandler{service: service}
+}
+
+// GetProfileHandler プロフィール取得
+// @Summary プロフィール取得
+// @Description 認証ユーザーのプロフィール（名前・メールアドレス・ユーザー名・アバター・自己紹介）を取得
+// @Description メールアドレスの変更が確認待ちの場合は pending_email に新しいアドレスを含みます
+// @Tags me
+// @Produce json
+// @Success 200 {object} models.SuccessResponse{data=models.User}
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/me [get]
+func (h *ProfileHandler) GetProfileHandler(w http.ResponseWriter, r *http.Request) {
+	user, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	profile, err := h.service.GetProfile(user)
+	if err != nil {
+		h.sendError(w, err, "error.retrieve_profile")
+		return
+	}
+
+	models.SendSuccessResponse(w, "profile.retrieved", profile)
+}
+
+// UpdateProfileHandler プロフィール部分更新（JSON Merge Patch）
+// @Summary プロフィール部分更新
+// @Description JSON Merge Patch (RFC 7396) でプロフィールを部分更新（avatar に null を指定すると削除）
+// @Description email を変更すると新しいアドレスに確認メールを送信し、確認されるまでは pending_email として返します
+// @Description バリデーションエラーは errors にフィールドごとのコード（required, too_short, too_long, invalid, taken）を含みます
+// @Tags me
+// @Accept json
+// @Accept application/merge-patch+json
+// @Produce json
+// @Param request body models.ProfileRequest true "JSON Merge Patch document"
+// @Success 200 {object} models.SuccessResponse{data=models.User}
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 413 {object} models.ErrorResponse
+// @Failure 415 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Security BearerAuth
+// @Router /api/me [patch]
+func (h *ProfileHandler) UpdateProfileHandler(w http.ResponseWriter, r *http.Request) {
+	user, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	patch, ok := readBody(w, r, mediaTypeMergePatch, mediaTypeJSON)
+	if !ok {
+		return
+	}
+
+	profile, err := h.service.UpdateProfile(user, patch)
+	if err != nil {
+		h.sendError(w, err, "error.update_profile")
+		return
+	}
+
+	models.SendSuccessResponse(w, "profile.updated", profile)
+}
+
+// ConfirmEmailChangeHandler メールアドレス変更の確認
+// @Summary メールアドレス変更の確認
+// @Description 確認メールのリンクに含まれるトークンでメールアドレスの変更を確定し、変更前のアドレスに通知します
+// @Description トークンは変更を要求したユーザー本人のみ、有効期限内に1回だけ使用できます
+// @Tags me
+// @Accept json
+// @Produce json
+// @Param request body models.ConfirmEmailChangeRequest true "Confirmation token"
+// @Success 200 {object} models.SuccessResponse{data=models.User}
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 413 {object} models.ErrorResponse
+// @Failure 415 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Security BearerAuth
+// @Router /api/me/email/confirm [post]
+func (h *ProfileHandler) ConfirmEmailChangeHandler(w http.ResponseWriter, r *http.Request) {
+	user, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	var request models.ConfirmEmailChangeRequest
+	if !decodeJSON(w, r, &request) {
+		return
+	}
+
+	profile, err := h.service.ConfirmEmailChange(user, &request)
+	if err != nil {
+		h.sendError(w, err, "error.confirm_email_change")
+		return
+	}
+
+	models.SendSuccessResponse(w, "profile.email_changed", profile)
+}
+
+// DeleteAccountHandler アカウント削除
+// @Summary アカウント削除
+// @Description パスワードで再認証してアカウントを削除します（セッション・APIキー・ロールなども削除されます）
+// @Description 最後の owner は、別のユーザーに owner を付与するまで削除できません
+// @Description 唯一の owner であるチームがある場合も、owner を移すかチームを削除するまで削除できません
+// @Tags me
+// @Accept json
+// @Produce json
+// @Param request body models.DeleteAccountRequest true "Current password"
+// @Success 200 {object} models.SuccessResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
+// @Failure 413 {object} models.ErrorResponse
+// @Failure 415 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Security BearerAuth
+// @Router /api/me [delete]
+func (h *ProfileHandler) DeleteAccountHandler(w http.ResponseWriter, r *http.Request) {
+	user, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	var request models.DeleteAccountRequest
+	if !decodeJSON(w, r, &request) {
+		return
+	}
+
+	if err := h.service.DeleteAccount(user, &request); err != nil {
+		h.sendError(w, err, "error.delete_account")
+		return
+	}
+
+	models.SendSuccessResponse(w, "profile.account_deleted", nil)
+}
+
+// sendError プロフィールサービスのエラーをレスポンスに変換
+func (h *ProfileHandler) sendError(w http.ResponseWriter, err error, message string) {
+	switch {
+	case errors.Is(err, services.ErrEmailChangeNotFound):
+		models.SendFieldValidationError(w, models.NewValidationError("token", models.ValidationCodeInvalid, "profile.email_change_invalid", nil))
+	case errors.Is(err, services.ErrUserNotFound):
+		models.SendUnauthorizedError(w, "auth.required")
+	default:
+		sendError(w, err, message)
+	}
+}
@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	custommiddleware "backend/middleware"
+	"backend/models"
+	"backend/services"
+	"backend/utils"
+)
+
+// TestProfileHandlers プロフィール・アカウント設定ハンドラーのテスト
+func TestProfileHandlers(t *testing.T) {
+	hasher, err := services.NewPasswordHasher(utils.PasswordHashBcrypt)
+	if err != nil {
+		t.Fatalf("NewPasswordHasher失敗: %v", err)
+	}
+	hash, _ := hasher.Hash("password123")
+	users := services.NewMemoryUserRepository()
+	roles := services.NewMemoryRoleRepository()
+	mailer := &captureMailer{}
+	accounts := services.NewMemoryAccountRepository(users, roles, services.NewMemoryTeamRepository(users))
+	h := NewProfileHandler(services.NewProfileService(users, accounts, hasher, mailer, services.EmailChangeConfig{
+		ConfirmURL: "http://localhost:3000/settings/confirm-email",
+		TTL:        time.Hour,
+	}))
+
+	owner, _ := users.Create("owner@example.com", "Owner", hash)
+	roles.AssignDefaultRole(owner.ID)
+	member, _ := users.Create("member@example.com", "Member", hash)
+	roles.AssignDefaultRole(member.ID)
+
+	serveAs := func(user *models.User, handlerFunc http.HandlerFunc, method, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, "/api/me", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		req = req.WithContext(custommiddleware.WithUser(req.Context(), user))
+		w := httptest.NewRecorder()
+		handlerFunc(w, req)
+		return w
+	}
+
+	w := serveAs(member, h.UpdateProfileHandler, "PATCH", `{"username":"Member_1","bio":"Hi","email":"new@example.com"}`)
+	var profile struct {
+		Data models.User `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &profile); err != nil || w.Code != http.StatusOK {
+		t.Fatalf("Update: unexpected response %d: %s", w.Code, w.Body.String())
+	}
+	if profile.Data.Username != "member_1" || profile.Data.Email != "member@example.com" || profile.Data.PendingEmail != "new@example.com" {
+		t.Errorf("Update: unexpected profile: %+v", profile.Data)
+	}
+
+	// 複数のフィールドのエラーをコード付きで返す
+	w = serveAs(member, h.UpdateProfileHandler, "PATCH", `{"name":" ","username":"no spaces","avatar":{"src":"javascript:alert(1)"}}`)
+	var validation models.ErrorResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &validation); err != nil || w.Code != http.StatusBadRequest {
+		t.Fatalf("Update invalid: unexpected response %d: %s", w.Code, w.Body.String())
+	}
+	if validation.Error != "validation_error" || len(validation.Errors) != 3 || validation.Errors[0].Field != "name" || validation.Errors[0].Code != models.ValidationCodeRequired {
+		t.Errorf("Update invalid: unexpected errors: %+v", validation)
+	}
+
+	// 他のユーザーが使用中のユーザー名
+	w = serveAs(owner, h.UpdateProfileHandler, "PATCH", `{"username":"member_1"}`)
+	if err := json.Unmarshal(w.Body.Bytes(), &validation); err != nil || w.Code != http.StatusBadRequest ||
+		len(validation.Errors) != 1 || validation.Errors[0].Code != models.ValidationCodeTaken {
+		t.Errorf("Update taken: unexpected response %d: %s", w.Code, w.Body.String())
+	}
+
+	token := mailer.token(t)
+	tests := []struct {
+		name           string
+		user           *models.User
+		handler        http.HandlerFunc
+		method         string
+		body           string
+		expectedStatus int
+	}{
+		{"Get", member, h.GetProfileHandler, "GET", "", http.StatusOK},
+		{"Update empty body", member, h.UpdateProfileHandler, "PATCH", "", http.StatusBadRequest},
+		{"Confirm invalid body", member, h.ConfirmEmailChangeHandler, "POST", `{`, http.StatusBadRequest},
+		{"Confirm other user", owner, h.ConfirmEmailChangeHandler, "POST", `{"token":"` + token + `"}`, http.StatusBadRequest},
+		{"Confirm", member, h.ConfirmEmailChangeHandler, "POST", `{"token":"` + token + `"}`, http.StatusOK},
+		{"Confirm reused token", member, h.ConfirmEmailChangeHandler, "POST", `{"token":"` + token + `"}`, http.StatusBadRequest},
+		{"Delete without password", member, h.DeleteAccountHandler, "DELETE", `{}`, http.StatusBadRequest},
+		{"Delete wrong password", member, h.DeleteAccountHandler, "DELETE", `{"password":"wrong-password"}`, http.StatusBadRequest},
+		{"Delete last owner", owner, h.DeleteAccountHandler, "DELETE", `{"password":"password123"}`, http.StatusConflict},
+		{"Delete", member, h.DeleteAccountHandler, "DELETE", `{"password":"password123"}`, http.StatusOK},
+		{"Get deleted", member, h.GetProfileHandler, "GET", "", http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if w := serveAs(tt.user, tt.handler, tt.method, tt.body); w.Code != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+	if mailer.last.To != "member@example.com" {
+		t.Errorf("変更前のアドレスに通知されていない: %+v", mailer.last)
+	}
+}
@@ -0,0 +1,146 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"backend/models"
+	"backend/services"
+)
+
+// RBACHandler ロール管理ハンドラー構造体
+type RBACHandler struct {
+	service *services.RBACService
+}
+
+// NewRBACHandler ロール管理ハンドラーを新規作成
+func NewRBACHandler(service *services.RBACService) *RBACHandler {
+	return &RBACHandler{
+		service: service,
+	}
+}
+
+// ListRolesHandler ロール一覧を取得
+// @Summary ロール一覧取得
+// @Description 全ロールと付与される権限を取得（roles:manage 権限が必要）
+// @Tags roles
+// @Produce json
+// @Success 200 {object} models.SuccessResponse{data=[]models.Role}
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/roles [get]
+func (h *RBACHandler) ListRolesHandler(w http.ResponseWriter, r *http.Request) {
+	roles, err := h.service.ListRoles()
+	if err != nil {
+		sendError(w, err, "error.get_roles")
+		return
+	}
+
+	models.SendSuccessResponse(w, "role.list_retrieved", roles)
+}
+
+// GetUserRolesHandler ユーザーのロールを取得
+// @Summary ユーザーロール取得
+// @Description ユーザーに付与されたロールを取得（roles:manage 権限が必要）
+// @Tags roles
+// @Produce json
+// @Param id path int true "User ID"
+// @Success 200 {object} models.SuccessResponse{data=models.UserRoles}
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/users/{id}/roles [get]
+func (h *RBACHandler) GetUserRolesHandler(w http.ResponseWriter, r *http.Request) {
+	userID, err := parseUserID(r)
+	if err != nil {
+		models.SendValidationError(w, "request.invalid_user_id")
+		return
+	}
+
+	result, err := h.service.GetUserRoles(userID)
+	if err != nil {
+		sendError(w, err, "error.get_user_roles")
+		return
+	}
+
+	models.SendSuccessResponse(w, "role.user_roles_retrieved", result)
+}
+
+// AssignRoleHandler ユーザーにロールを付与
+// @Summary ロール付与
+// @Description ユーザーにロールを付与（付与済みの場合は何もしない。roles:manage 権限が必要）
+// @Tags roles
+// @Produce json
+// @Param id path int true "User ID"
+// @Param role path string true "Role name" Enums(owner, member)
+// @Success 200 {object} models.SuccessResponse{data=models.UserRoles}
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/users/{id}/roles/{role} [put]
+func (h *RBACHandler) AssignRoleHandler(w http.ResponseWriter, r *http.Request) {
+	userID, err := parseUserID(r)
+	if err != nil {
+		models.SendValidationError(w, "request.invalid_user_id")
+		return
+	}
+
+	result, err := h.service.AssignRole(userID, chi.URLParam(r, "role"))
+	if err != nil {
+		sendError(w, err, "error.assign_role")
+		return
+	}
+
+	models.SendSuccessResponse(w, "role.assigned", result)
+}
+
+// RevokeRoleHandler ユーザーからロールを剥奪
+// @Summary ロール剥奪
+// @Description ユーザーからロールを剥奪（最後の owner は剥奪不可。roles:manage 権限が必要）
+// @Tags roles
+// @Produce json
+// @Param id path int true "User ID"
+// @Param role path string true "Role name" Enums(owner, member)
+// @Success 200 {object} models.SuccessResponse{data=models.UserRoles}
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/users/{id}/roles/{role} [delete]
+func (h *RBACHandler) RevokeRoleHandler(w http.ResponseWriter, r *http.Request) {
+	userID, err := parseUserID(r)
+	if err != nil {
+		models.SendValidationError(w, "request.invalid_user_id")
+		return
+	}
+
+	result, err := h.service.RevokeRole(userID, chi.URLParam(r, "role"))
+	if err != nil {
+		sendError(w, err, "error.revoke_role")
+		return
+	}
+
+	models.SendSuccessResponse(w, "role.revoked", result)
+}
+
+// parseUserID URLパラメータからユーザーIDを取得
+func parseUserID(r *http.Request) (int, error) {
+	return strconv.Atoi(chi.URLParam(r, "id"))
+}
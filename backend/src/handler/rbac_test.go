@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	"backend/models"
+	"backend/services"
+)
+
+// newTestRBACHandler メモリリポジトリを使用するテスト用ロール管理ハンドラー（ユーザー1が owner、2が member）
+func newTestRBACHandler(t *testing.T) *RBACHandler {
+	t.Helper()
+	users := services.NewMemoryUserRepository()
+	roles := services.NewMemoryRoleRepository()
+	for _, email := range []string{"owner@example.com", "member@example.com"} {
+		user, err := users.Create(email, "User", "hash")
+		if err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+		roles.AssignDefaultRole(user.ID)
+	}
+	return NewRBACHandler(services.NewRBACService(roles, users))
+}
+
+// TestRBACHandlers ロール管理ハンドラーのテスト
+func TestRBACHandlers(t *testing.T) {
+	h := newTestRBACHandler(t)
+
+	tests := []struct {
+		name           string
+		handler        http.HandlerFunc
+		id             string
+		role           string
+		expectedStatus int
+	}{
+		{"List roles", h.ListRolesHandler, "", "", http.StatusOK},
+		{"Get user roles", h.GetUserRolesHandler, "2", "", http.StatusOK},
+		{"Get unknown user roles", h.GetUserRolesHandler, "99", "", http.StatusNotFound},
+		{"Invalid user ID", h.GetUserRolesHandler, "abc", "", http.StatusBadRequest},
+		{"Assign role", h.AssignRoleHandler, "2", models.RoleOwner, http.StatusOK},
+		{"Assign unknown role", h.AssignRoleHandler, "2", "superuser", http.StatusNotFound},
+		{"Revoke role", h.RevokeRoleHandler, "2", models.RoleOwner, http.StatusOK},
+		{"Revoke last owner", h.RevokeRoleHandler, "1", models.RoleOwner, http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/", nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", tt.id)
+			rctx.URLParams.Add("role", tt.role)
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+			w := httptest.NewRecorder()
+
+			tt.handler(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"slices"
+
+	"backend/models"
+)
+
+// リクエストボディのメディアタイプ
+const (
+	mediaTypeJSON       = "application/json"
+	mediaTypeMergePatch = "application/merge-patch+json"
+)
+
+// decodeJSON application/json のリクエストボディを v にデコードし、失敗した場合はエラーレスポンスを送信する
+// Content-Type が異なれば 415、ボディが上限（middleware.LimitRequestBody）を超えれば 413、
+// JSON として不正・未知のフィールド・JSON の値の後のデータは 400 とします
+func decodeJSON(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if !requireMediaType(w, r, mediaTypeJSON) {
+		return false
+	}
+	if err := models.DecodeJSON(r.Body, v); err != nil {
+		sendBodyError(w, err)
+		return false
+	}
+	return true
+}
+
+// readBody リクエストボディを読み込み、失敗した場合はエラーレスポンスを送信する（JSON Merge Patch など）
+// Content-Type は mediaTypes のいずれかである必要があり、空のボディは 400 とします
+func readBody(w http.ResponseWriter, r *http.Request, mediaTypes ...string) ([]byte, bool) {
+	if !requireMediaType(w, r, mediaTypes...) {
+		return nil, false
+	}
+	body, err := io.ReadAll(r.Body)
+	if err == nil && len(body) == 0 {
+		err = models.NewValidationError("body", models.ValidationCodeRequired, "request.empty_body", nil)
+	}
+	if err != nil {
+		sendBodyError(w, err)
+		return nil, false
+	}
+	return body, true
+}
+
+// requireMediaType Content-Type が mediaTypes のいずれかか検証し、異なれば 415 を送信する（charset などのパラメータは無視）
+func requireMediaType(w http.ResponseWriter, r *http.Request, mediaTypes ...string) bool {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err == nil && slices.Contains(mediaTypes, mediaType) {
+		return true
+	}
+	models.SendUnsupportedMediaTypeError(w, "request.unsupported_media_type", map[string]interface{}{"types": mediaTypes})
+	return false
+}
+
+// sendBodyError リクエストボディの読み込み・デコードのエラーを送信
+func sendBodyError(w http.ResponseWriter, err error) {
+	var maxBytesErr *http.MaxBytesError
+	var validationErr *models.ValidationError
+	switch {
+	case errors.As(err, &maxBytesErr):
+		models.SendPayloadTooLargeError(w, "request.too_large", map[string]interface{}{"max": maxBytesErr.Limit})
+	case errors.As(err, &validationErr):
+		models.SendFieldValidationError(w, validationErr)
+	default:
+		models.SendValidationError(w, "request.invalid_body")
+	}
+}
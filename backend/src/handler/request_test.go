@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	custommiddleware "backend/middleware"
+	"backend/models"
+)
+
+// TestDecodeJSONRequest リクエストボディのデコードとエラーレスポンスのテスト
+func TestDecodeJSONRequest(t *testing.T) {
+	decode := custommiddleware.LimitRequestBody(64)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request models.HelloWorldRequest
+		if decodeJSON(w, r, &request) {
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	read := custommiddleware.LimitRequestBody(64)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := readBody(w, r, mediaTypeMergePatch, mediaTypeJSON); ok {
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+
+	tests := []struct {
+		name           string
+		handler        http.Handler
+		contentType    string
+		body           string
+		chunked        bool
+		expectedStatus int
+		expectedError  string
+		expectedMsg    string
+	}{
+		{"Valid", decode, "application/json", `{"name":"test"}`, false, http.StatusNoContent, "", ""},
+		{"Charset parameter", decode, "application/json; charset=utf-8", `{"name":"test"}`, false, http.StatusNoContent, "", ""},
+		{"Missing Content-Type", decode, "", `{"name":"test"}`, false, http.StatusUnsupportedMediaType, "unsupported_media_type", "Unsupported Content-Type; use application/json"},
+		{"Text body", decode, "text/plain", `{"name":"test"}`, false, http.StatusUnsupportedMediaType, "unsupported_media_type", ""},
+		{"Content-Length over limit", decode, "application/json", `{"name":"` + strings.Repeat("a", 64) + `"}`, false, http.StatusRequestEntityTooLarge, "payload_too_large", "Request body must be at most 64 bytes"},
+		{"Chunked body over limit", decode, "application/json", `{"name":"` + strings.Repeat("a", 64) + `"}`, true, http.StatusRequestEntityTooLarge, "payload_too_large", "Request body must be at most 64 bytes"},
+		{"Syntax error", decode, "application/json", `{"name":}`, false, http.StatusBadRequest, "validation_error", "Invalid JSON at byte offset 8"},
+		{"Trailing data", decode, "application/json", `{"name":"test"} {}`, false, http.StatusBadRequest, "validation_error", ""},
+		{"Unknown field", decode, "application/json", `{"name":"test","admin":true}`, false, http.StatusBadRequest, "validation_error", ""},
+		{"Merge patch", read, "application/merge-patch+json", `{"name":"test"}`, false, http.StatusNoContent, "", ""},
+		{"Merge patch as JSON", read, "application/json", `{"name":"test"}`, false, http.StatusNoContent, "", ""},
+		{"Merge patch as text", read, "text/plain", `{"name":"test"}`, false, http.StatusUnsupportedMediaType, "unsupported_media_type", "Unsupported Content-Type; use application/merge-patch+json, application/json"},
+		{"Merge patch over limit", read, "application/merge-patch+json", strings.Repeat(" ", 65), true, http.StatusRequestEntityTooLarge, "payload_too_large", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/api/hello-world", strings.NewReader(tt.body))
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			if tt.chunked {
+				req.ContentLength = -1
+			}
+			w := httptest.NewRecorder()
+
+			tt.handler.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+			if tt.expectedError == "" {
+				return
+			}
+			var response models.ErrorResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if response.Error != tt.expectedError {
+				t.Errorf("Expected error %q, got %q", tt.expectedError, response.Error)
+			}
+			message := response.Message
+			if len(response.Errors) > 0 {
+				message = response.Errors[0].Message
+			}
+			if tt.expectedMsg != "" && message != tt.expectedMsg {
+				t.Errorf("Expected message %q, got %q", tt.expectedMsg, message)
+			}
+		})
+	}
+}
@@ -0,0 +1,169 @@
+package handler
+
+import (
+	"net/http"
+
+	"backend/models"
+	"backend/services"
+)
+
+// SaleHandler 売上台帳ハンドラー構造体
+type SaleHandler struct {
+	service *services.SaleService
+}
+
+// NewSaleHandler 売上台帳ハンドラーを新規作成
+func NewSaleHandler(service *services.SaleService) *SaleHandler {
+	return &SaleHandler{service: service}
+}
+
+// ListSalesHandler 売上一覧取得
+// @Summary 売上一覧取得
+// @Description 売上を検索・ソートしてページ単位で取得（金額は通貨の最小単位）
+// @Tags sales
+// @Accept json
+// @Produce json
+// @Param limit query int false "取得件数（1〜100、デフォルト20）"
+// @Param offset query int false "オフセット"
+// @Param after query string false "キーセットページング用カーソル（next_cursor の値、デフォルトの並び順のみ）"
+// @Param status query string false "決済状態（paid, failed, refunded）"
+// @Param email query string false "購入者のメールアドレス（完全一致、小文字）"
+// @Param currency query string false "通貨コード（例: USD）"
+// @Param amount_min query int false "金額の下限（通貨の最小単位、以上）"
+// @Param amount_max query int false "金額の上限（通貨の最小単位、以下）"
+// @Param date_after query string false "日時の下限（RFC3339 または YYYY-MM-DD）"
+// @Param date_before query string false "日時の上限（RFC3339 または YYYY-MM-DD）"
+// @Param sort query string false "並び順（例: -date）。対象: id, date, status, email, amount, created_at"
+// @Success 200 {object} models.SuccessResponse{data=[]models.Sale}
+// @Header 200 {string} Link "RFC 8288 ページネーションリンク"
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/sales [get]
+func (h *SaleHandler) ListSalesHandler(w http.ResponseWriter, r *http.Request) {
+	spec, err := services.SaleQuerySchema.Parse(r.URL.Query())
+	if err != nil {
+		sendQueryError(w, err)
+		return
+	}
+
+	result, err := h.service.ListSales(spec)
+	if err != nil {
+		sendError(w, err, "error.retrieve_sales")
+		return
+	}
+
+	pagination := result.Pagination(spec.Page)
+	setPaginationLinks(w, r, spec.Page, pagination)
+	models.SendPaginatedResponse(w, "sale.list_retrieved", result.Items, pagination)
+}
+
+// GetSaleHandler 売上取得
+// @Summary 売上取得（ID指定）
+// @Description 指定されたIDの売上を取得
+// @Tags sales
+// @Accept json
+// @Produce json
+// @Param id path int true "Sale ID"
+// @Success 200 {object} models.SuccessResponse{data=models.Sale}
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/sales/{id} [get]
+func (h *SaleHandler) GetSaleHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := parseMessageID(r)
+	if err != nil {
+		models.SendValidationError(w, "request.invalid_id")
+		return
+	}
+
+	sale, err := h.service.GetSale(id)
+	if err != nil {
+		sendError(w, err, "error.retrieve_sale")
+		return
+	}
+
+	models.SendSuccessResponse(w, "sale.retrieved", sale)
+}
+
+// CreateSaleHandler 売上登録
+// @Summary 売上登録
+// @Description 売上を登録（status は paid または failed、省略時は paid。currency 省略時は USD）
+// @Tags sales
+// @Accept json
+// @Produce json
+// @Param request body models.SaleRequest true "Sale Request"
+// @Success 201 {object} models.SuccessResponse{data=models.Sale}
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 413 {object} models.ErrorResponse
+// @Failure 415 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/sales [post]
+func (h *SaleHandler) CreateSaleHandler(w http.ResponseWriter, r *http.Request) {
+	var request models.SaleRequest
+	if !decodeJSON(w, r, &request) {
+		return
+	}
+
+	sale, err := h.service.CreateSale(&request)
+	if err != nil {
+		sendError(w, err, "error.create_sale")
+		return
+	}
+
+	models.SendCreatedResponse(w, "sale.created", sale)
+}
+
+// UpdateSaleStatusHandler 売上の決済状態変更（返金）
+// @Summary 売上の決済状態変更（返金）
+// @Description 決済状態を変更します。許可される遷移は paid → refunded（返金）のみで、それ以外は validation_error です。
+// @Description 返金では返金日時（refunded_at）と実行したユーザー（refunded_by）を記録します。
+// @Tags sales
+// @Accept json
+// @Produce json
+// @Param id path int true "Sale ID"
+// @Param request body models.SaleStatusRequest true "Status"
+// @Success 200 {object} models.SuccessResponse{data=models.Sale}
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 413 {object} models.ErrorResponse
+// @Failure 415 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/sales/{id} [patch]
+func (h *SaleHandler) UpdateSaleStatusHandler(w http.ResponseWriter, r *http.Request) {
+	user, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+	id, err := parseMessageID(r)
+	if err != nil {
+		models.SendValidationError(w, "request.invalid_id")
+		return
+	}
+
+	var request models.SaleStatusRequest
+	if !decodeJSON(w, r, &request) {
+		return
+	}
+
+	sale, err := h.service.UpdateSaleStatus(user, id, &request)
+	if err != nil {
+		sendError(w, err, "error.update_sale")
+		return
+	}
+
+	models.SendSuccessResponse(w, "sale.updated", sale)
+}
@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	custommiddleware "backend/middleware"
+	"backend/models"
+	"backend/services"
+)
+
+// TestSaleHandlers 売上の登録・取得・一覧・返金ハンドラーのテスト
+func TestSaleHandlers(t *testing.T) {
+	h := NewSaleHandler(services.NewSaleService(services.NewMemorySaleRepository()))
+	user := &models.User{ID: 7, Name: "Alice"}
+
+	serve := func(handlerFunc http.HandlerFunc, method, target, body, id string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		req = req.WithContext(custommiddleware.WithUser(req.Context(), user))
+		if id != "" {
+			req = withURLParam(req, "id", id)
+		}
+		w := httptest.NewRecorder()
+		handlerFunc(w, req)
+		return w
+	}
+
+	w := serve(h.CreateSaleHandler, "POST", "/", `{"email":"James.Anderson@example.com","amount":59400,"currency":"usd"}`, "")
+	var created struct {
+		Data models.Sale `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &created); err != nil || w.Code != http.StatusCreated {
+		t.Fatalf("Create: unexpected response %d: %s", w.Code, w.Body.String())
+	}
+	if created.Data.Status != models.SaleStatusPaid || created.Data.Email != "james.anderson@example.com" || created.Data.Currency != "USD" {
+		t.Errorf("Create: unexpected sale: %+v", created.Data)
+	}
+	id := strconv.Itoa(created.Data.ID)
+
+	w = serve(h.CreateSaleHandler, "POST", "/", `{"email":"alex@example.com","amount":1000,"status":"failed"}`, "")
+	json.Unmarshal(w.Body.Bytes(), &created)
+	failedID := strconv.Itoa(created.Data.ID)
+
+	tests := []struct {
+		name           string
+		handler        http.HandlerFunc
+		method         string
+		body           string
+		id             string
+		expectedStatus int
+	}{
+		{"Create invalid body", h.CreateSaleHandler, "POST", `{`, "", http.StatusBadRequest},
+		{"Create refunded", h.CreateSaleHandler, "POST", `{"email":"a@example.com","amount":1,"status":"refunded"}`, "", http.StatusBadRequest},
+		{"Create negative amount", h.CreateSaleHandler, "POST", `{"email":"a@example.com","amount":-1}`, "", http.StatusBadRequest},
+		{"Create fractional amount", h.CreateSaleHandler, "POST", `{"email":"a@example.com","amount":5.5}`, "", http.StatusBadRequest},
+		{"Create invalid currency", h.CreateSaleHandler, "POST", `{"email":"a@example.com","amount":1,"currency":"dollars"}`, "", http.StatusBadRequest},
+		{"Get", h.GetSaleHandler, "GET", "", id, http.StatusOK},
+		{"Get not found", h.GetSaleHandler, "GET", "", "999", http.StatusNotFound},
+		{"Refund failed sale", h.UpdateSaleStatusHandler, "PATCH", `{"status":"refunded"}`, failedID, http.StatusBadRequest},
+		{"Paid to failed", h.UpdateSaleStatusHandler, "PATCH", `{"status":"failed"}`, id, http.StatusBadRequest},
+		{"Unknown status", h.UpdateSaleStatusHandler, "PATCH", `{"status":"chargeback"}`, id, http.StatusBadRequest},
+		{"Non-updatable field", h.UpdateSaleStatusHandler, "PATCH", `{"status":"refunded","amount":1}`, id, http.StatusBadRequest},
+		{"Refund not found", h.UpdateSaleStatusHandler, "PATCH", `{"status":"refunded"}`, "999", http.StatusNotFound},
+		{"Refund", h.UpdateSaleStatusHandler, "PATCH", `{"status":"refunded"}`, id, http.StatusOK},
+		{"Refund again", h.UpdateSaleStatusHandler, "PATCH", `{"status":"refunded"}`, id, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if w := serve(tt.handler, tt.method, "/", tt.body, tt.id); w.Code != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+
+	w = serve(h.ListSalesHandler, "GET", "/api/sales?status=refunded", "", "")
+	var listed struct {
+		Data []models.Sale `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &listed); err != nil || w.Code != http.StatusOK || len(listed.Data) != 1 {
+		t.Fatalf("List: unexpected response %d: %s", w.Code, w.Body.String())
+	}
+	if refunded := listed.Data[0]; refunded.RefundedAt == nil || refunded.RefundedBy == nil || *refunded.RefundedBy != user.ID {
+		t.Errorf("返金の実行者が記録されていない: %+v", refunded)
+	}
+	if w := serve(h.ListSalesHandler, "GET", "/api/sales?status=paid&sort=price", "", ""); w.Code != http.StatusBadRequest {
+		t.Errorf("List: expected 400 for unknown sort field, got %d", w.Code)
+	}
+}
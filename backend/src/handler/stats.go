@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"backend/models"
+	"backend/services"
+)
+
+// StatsHandler 売上統計ハンドラー構造体
+type StatsHandler struct {
+	service *services.StatsService
+}
+
+// NewStatsHandler 売上統計ハンドラーを新規作成
+func NewStatsHandler(service *services.StatsService) *StatsHandler {
+	return &StatsHandler{service: service}
+}
+
+// GetStatsHandler 売上統計取得
+// @Summary 売上統計取得
+// @Description 支払い済み（paid）の売上から、期間全体の売上・注文数・顧客数と直前の同じ長さの期間との増減率（%）、
+// @Description 日・週（月曜始まり）・月ごとの内訳を取得します。内訳は tz の暦で区切り、売上のない期間も0で含みます。
+// @Tags stats
+// @Accept json
+// @Produce json
+// @Param period query string false "daily（デフォルト）、weekly、monthly"
+// @Param start query string false "期間の開始（RFC3339 または YYYY-MM-DD、デフォルトは end の14日前）"
+// @Param end query string false "期間の終了（RFC3339 または YYYY-MM-DD、日付のみの場合はその日を含む。デフォルトは現在日時）"
+// @Param tz query string false "集計に使うタイムゾーン（IANA 名、例: Asia/Tokyo。デフォルトは UTC）"
+// @Success 200 {object} models.SuccessResponse{data=models.Stats}
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/stats [get]
+func (h *StatsHandler) GetStatsHandler(w http.ResponseWriter, r *http.Request) {
+	query, err := models.ParseStatsQuery(r.URL.Query(), time.Now())
+	if err != nil {
+		sendQueryError(w, err)
+		return
+	}
+
+	stats, err := h.service.GetStats(query)
+	if err != nil {
+		sendError(w, err, "error.retrieve_stats")
+		return
+	}
+
+	models.SendSuccessResponse(w, "stats.retrieved", stats)
+}
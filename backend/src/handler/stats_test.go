@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"backend/models"
+	"backend/services"
+)
+
+// TestGetStatsHandler 売上統計ハンドラーのテスト
+func TestGetStatsHandler(t *testing.T) {
+	repo := services.NewMemorySaleRepository()
+	h := NewStatsHandler(services.NewStatsService(repo))
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+	repo.Create(&models.Sale{Date: time.Date(2024, 3, 5, 8, 0, 0, 0, tokyo), Status: models.SaleStatusPaid, Email: "alex@example.com", Amount: 59400, Currency: "USD"})
+
+	w := httptest.NewRecorder()
+	h.GetStatsHandler(w, httptest.NewRequest("GET", "/api/stats?period=weekly&start=2024-03-01&end=2024-03-14&tz=Asia/Tokyo", nil))
+	var response struct {
+		Data models.Stats `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil || w.Code != http.StatusOK {
+		t.Fatalf("unexpected response %d: %s", w.Code, w.Body.String())
+	}
+	stats := response.Data
+	if stats.Period != models.StatsPeriodWeekly || stats.Timezone != "Asia/Tokyo" || len(stats.Buckets) != 3 {
+		t.Fatalf("集計結果が不正: %s", w.Body.String())
+	}
+	if stats.Buckets[1].Revenue != 59400 || stats.Summary.Revenue.Value != 59400 || stats.Summary.Revenue.Variation != nil {
+		t.Errorf("集計値が不正: %s", w.Body.String())
+	}
+
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{"Invalid period", "?period=hourly"},
+		{"Invalid timezone", "?tz=Nowhere"},
+		{"Start after end", "?start=2024-03-14&end=2024-03-01"},
+		{"Invalid currency", "?currency=dollar"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			h.GetStatsHandler(w, httptest.NewRequest("GET", "/api/stats"+tt.query, nil))
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("Expected 400, got %d: %s", w.Code, w.Body.String())
+			}
+		})
+	}
+}
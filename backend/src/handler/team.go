@@ -0,0 +1,478 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"backend/models"
+	"backend/services"
+)
+
+// TeamHandler チームハンドラー構造体
+type TeamHandler struct {
+	service *services.TeamService
+}
+
+// NewTeamHandler チームハンドラーを新規作成
+func NewTeamHandler(service *services.TeamService) *TeamHandler {
+	return &TeamHandler{service: service}
+}
+
+// ListTeamsHandler 参加チーム一覧取得
+// @Summary 参加チーム一覧取得
+// @Description 認証ユーザーが参加しているチームを自分のロール付きで名前順に取得
+// @Tags teams
+// @Accept json
+// @Produce json
+// @Success 200 {object} models.SuccessResponse{data=[]models.Team}
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/teams [get]
+func (h *TeamHandler) ListTeamsHandler(w http.ResponseWriter, r *http.Request) {
+	user, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	teams, err := h.service.ListTeams(user)
+	if err != nil {
+		sendError(w, err, "error.retrieve_teams")
+		return
+	}
+
+	models.SendSuccessResponse(w, "team.list_retrieved", teams)
+}
+
+// CreateTeamHandler チーム作成
+// @Summary チーム作成
+// @Description チームを作成し、認証ユーザーを owner として追加
+// @Tags teams
+// @Accept json
+// @Produce json
+// @Param request body models.TeamRequest true "Team Request"
+// @Success 201 {object} models.SuccessResponse{data=models.Team}
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 413 {object} models.ErrorResponse
+// @Failure 415 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Security BearerAuth
+// @Router /api/teams [post]
+func (h *TeamHandler) CreateTeamHandler(w http.ResponseWriter, r *http.Request) {
+	user, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	var request models.TeamRequest
+	if !decodeJSON(w, r, &request) {
+		return
+	}
+
+	team, err := h.service.CreateTeam(user, &request)
+	if err != nil {
+		sendError(w, err, "error.create_team")
+		return
+	}
+
+	models.SendCreatedResponse(w, "team.created", team)
+}
+
+// GetTeamHandler チーム取得
+// @Summary チーム取得（ID指定）
+// @Description 認証ユーザーが参加しているチームを取得（メンバーでない場合は404）
+// @Tags teams
+// @Accept json
+// @Produce json
+// @Param id path int true "Team ID"
+// @Success 200 {object} models.SuccessResponse{data=models.Team}
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/teams/{id} [get]
+func (h *TeamHandler) GetTeamHandler(w http.ResponseWriter, r *http.Request) {
+	user, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+	teamID, err := parseMessageID(r)
+	if err != nil {
+		models.SendValidationError(w, "request.invalid_id")
+		return
+	}
+
+	team, err := h.service.GetTeam(user, teamID)
+	if err != nil {
+		sendError(w, err, "error.retrieve_team")
+		return
+	}
+
+	models.SendSuccessResponse(w, "team.retrieved", team)
+}
+
+// UpdateTeamHandler チーム更新
+// @Summary チーム更新
+// @Description チーム名・アバターを更新（チームの owner のみ）
+// @Tags teams
+// @Accept json
+// @Produce json
+// @Param id path int true "Team ID"
+// @Param request body models.TeamRequest true "Team Request"
+// @Success 200 {object} models.SuccessResponse{data=models.Team}
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 413 {object} models.ErrorResponse
+// @Failure 415 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Security BearerAuth
+// @Router /api/teams/{id} [patch]
+func (h *TeamHandler) UpdateTeamHandler(w http.ResponseWriter, r *http.Request) {
+	user, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+	teamID, err := parseMessageID(r)
+	if err != nil {
+		models.SendValidationError(w, "request.invalid_id")
+		return
+	}
+
+	var request models.TeamRequest
+	if !decodeJSON(w, r, &request) {
+		return
+	}
+
+	team, err := h.service.UpdateTeam(user, teamID, &request)
+	if err != nil {
+		sendError(w, err, "error.update_team")
+		return
+	}
+
+	models.SendSuccessResponse(w, "team.updated", team)
+}
+
+// DeleteTeamHandler チーム削除
+// @Summary チーム削除
+// @Description チームをメンバー・招待ごと削除（チームの owner のみ）
+// @Tags teams
+// @Accept json
+// @Produce json
+// @Param id path int true "Team ID"
+// @Success 200 {object} models.SuccessResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Security BearerAuth
+// @Router /api/teams/{id} [delete]
+func (h *TeamHandler) DeleteTeamHandler(w http.ResponseWriter, r *http.Request) {
+	user, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+	teamID, err := parseMessageID(r)
+	if err != nil {
+		models.SendValidationError(w, "request.invalid_id")
+		return
+	}
+
+	if err := h.service.DeleteTeam(user, teamID); err != nil {
+		sendError(w, err, "error.delete_team")
+		return
+	}
+
+	models.SendSuccessResponse(w, "team.deleted", nil)
+}
+
+// ListMembersHandler チームメンバー一覧取得
+// @Summary チームメンバー一覧取得
+// @Description チームのメンバーを参加日時の昇順で取得（チームのメンバーのみ）
+// @Tags teams
+// @Accept json
+// @Produce json
+// @Param id path int true "Team ID"
+// @Success 200 {object} models.SuccessResponse{data=[]models.TeamMember}
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/teams/{id}/members [get]
+func (h *TeamHandler) ListMembersHandler(w http.ResponseWriter, r *http.Request) {
+	user, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+	teamID, err := parseMessageID(r)
+	if err != nil {
+		models.SendValidationError(w, "request.invalid_id")
+		return
+	}
+
+	members, err := h.service.ListMembers(user, teamID)
+	if err != nil {
+		sendError(w, err, "error.retrieve_team_members")
+		return
+	}
+
+	models.SendSuccessResponse(w, "team.members_retrieved", members)
+}
+
+// UpdateMemberRoleHandler メンバーのロール変更
+// @Summary メンバーのロール変更
+// @Description メンバーのロールを owner / member に変更（チームの owner のみ。最後の owner は降格不可）
+// @Tags teams
+// @Accept json
+// @Produce json
+// @Param id path int true "Team ID"
+// @Param userID path int true "User ID"
+// @Param request body models.TeamMemberRoleRequest true "Role"
+// @Success 200 {object} models.SuccessResponse{data=models.TeamMember}
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
+// @Failure 413 {object} models.ErrorResponse
+// @Failure 415 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Security BearerAuth
+// @Router /api/teams/{id}/members/{userID} [patch]
+func (h *TeamHandler) UpdateMemberRoleHandler(w http.ResponseWriter, r *http.Request) {
+	user, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+	teamID, memberID, ok := parseTeamSubresourceIDs(w, r, "userID")
+	if !ok {
+		return
+	}
+
+	var request models.TeamMemberRoleRequest
+	if !decodeJSON(w, r, &request) {
+		return
+	}
+
+	member, err := h.service.UpdateMemberRole(user, teamID, memberID, &request)
+	if err != nil {
+		sendError(w, err, "error.update_team_member")
+		return
+	}
+
+	models.SendSuccessResponse(w, "team.member_updated", member)
+}
+
+// RemoveMemberHandler メンバー削除・脱退
+// @Summary メンバー削除・脱退
+// @Description owner は任意のメンバーを削除でき、member は自分自身のみ脱退可能（最後の owner は不可）
+// @Tags teams
+// @Accept json
+// @Produce json
+// @Param id path int true "Team ID"
+// @Param userID path int true "User ID"
+// @Success 200 {object} models.SuccessResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Security BearerAuth
+// @Router /api/teams/{id}/members/{userID} [delete]
+func (h *TeamHandler) RemoveMemberHandler(w http.ResponseWriter, r *http.Request) {
+	user, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+	teamID, memberID, ok := parseTeamSubresourceIDs(w, r, "userID")
+	if !ok {
+		return
+	}
+
+	if err := h.service.RemoveMember(user, teamID, memberID); err != nil {
+		sendError(w, err, "error.remove_team_member")
+		return
+	}
+
+	models.SendSuccessResponse(w, "team.member_removed", nil)
+}
+
+// ListInvitationsHandler 招待一覧取得
+// @Summary 招待一覧取得
+// @Description 未承諾の招待（期限切れを含む）を作成日時の降順で取得（チームの owner のみ）
+// @Tags teams
+// @Accept json
+// @Produce json
+// @Param id path int true "Team ID"
+// @Success 200 {object} models.SuccessResponse{data=[]models.TeamInvitation}
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/teams/{id}/invitations [get]
+func (h *TeamHandler) ListInvitationsHandler(w http.ResponseWriter, r *http.Request) {
+	user, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+	teamID, err := parseMessageID(r)
+	if err != nil {
+		models.SendValidationError(w, "request.invalid_id")
+		return
+	}
+
+	invitations, err := h.service.ListInvitations(user, teamID)
+	if err != nil {
+		sendError(w, err, "error.retrieve_invitations")
+		return
+	}
+
+	models.SendSuccessResponse(w, "invitation.list_retrieved", invitations)
+}
+
+// CreateInvitationHandler メンバー招待
+// @Summary メンバー招待
+// @Description 承諾用のトークンを含む招待メールを送信（チームの owner のみ）。同じメールアドレスへの未承諾の招待は置き換える
+// @Tags teams
+// @Accept json
+// @Produce json
+// @Param id path int true "Team ID"
+// @Param request body models.TeamInvitationRequest true "Invitation Request"
+// @Success 201 {object} models.SuccessResponse{data=models.TeamInvitation}
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
+// @Failure 413 {object} models.ErrorResponse
+// @Failure 415 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Security BearerAuth
+// @Router /api/teams/{id}/invitations [post]
+func (h *TeamHandler) CreateInvitationHandler(w http.ResponseWriter, r *http.Request) {
+	user, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+	teamID, err := parseMessageID(r)
+	if err != nil {
+		models.SendValidationError(w, "request.invalid_id")
+		return
+	}
+
+	var request models.TeamInvitationRequest
+	if !decodeJSON(w, r, &request) {
+		return
+	}
+
+	invitation, err := h.service.InviteMember(user, teamID, &request)
+	if err != nil {
+		sendError(w, err, "error.send_invitation")
+		return
+	}
+
+	models.SendCreatedResponse(w, "invitation.sent", invitation)
+}
+
+// RevokeInvitationHandler 招待取り消し
+// @Summary 招待取り消し
+// @Description 未承諾の招待を取り消す（チームの owner のみ）
+// @Tags teams
+// @Accept json
+// @Produce json
+// @Param id path int true "Team ID"
+// @Param invitationID path int true "Invitation ID"
+// @Success 200 {object} models.SuccessResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Security BearerAuth
+// @Router /api/teams/{id}/invitations/{invitationID} [delete]
+func (h *TeamHandler) RevokeInvitationHandler(w http.ResponseWriter, r *http.Request) {
+	user, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+	teamID, invitationID, ok := parseTeamSubresourceIDs(w, r, "invitationID")
+	if !ok {
+		return
+	}
+
+	if err := h.service.RevokeInvitation(user, teamID, invitationID); err != nil {
+		sendError(w, err, "error.revoke_invitation")
+		return
+	}
+
+	models.SendSuccessResponse(w, "invitation.revoked", nil)
+}
+
+// AcceptInvitationHandler 招待承諾
+// @Summary 招待承諾
+// @Description 招待メールのトークンでチームに参加（招待先と同じメールアドレスのユーザーのみ。トークンは1回のみ使用可能）
+// @Tags teams
+// @Accept json
+// @Produce json
+// @Param request body models.AcceptInvitationRequest true "Invitation token"
+// @Success 200 {object} models.SuccessResponse{data=models.Team}
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
+// @Failure 410 {object} models.ErrorResponse
+// @Failure 413 {object} models.ErrorResponse
+// @Failure 415 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Security BearerAuth
+// @Router /api/invitations/accept [post]
+func (h *TeamHandler) AcceptInvitationHandler(w http.ResponseWriter, r *http.Request) {
+	user, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	var request models.AcceptInvitationRequest
+	if !decodeJSON(w, r, &request) {
+		return
+	}
+
+	team, err := h.service.AcceptInvitation(user, &request)
+	if err != nil {
+		sendError(w, err, "error.accept_invitation")
+		return
+	}
+
+	models.SendSuccessResponse(w, "invitation.accepted", team)
+}
+
+// parseTeamSubresourceIDs チームIDと配下のリソースIDを取得（不正な場合は400を返す）
+func parseTeamSubresourceIDs(w http.ResponseWriter, r *http.Request, key string) (int, int, bool) {
+	teamID, err := parseMessageID(r)
+	if err != nil {
+		models.SendValidationError(w, "request.invalid_id")
+		return 0, 0, false
+	}
+	id, err := strconv.Atoi(chi.URLParam(r, key))
+	if err != nil {
+		models.SendValidationError(w, "request.invalid_id")
+		return 0, 0, false
+	}
+	return teamID, id, true
+}
@@ -0,0 +1,162 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	custommiddleware "backend/middleware"
+	"backend/models"
+	"backend/services"
+)
+
+// captureMailer 最後に送信したメールを保持するテスト用 Mailer
+type captureMailer struct {
+	last *services.EmailMessage
+}
+
+func (m *captureMailer) Send(message *services.EmailMessage) error {
+	m.last = message
+	return nil
+}
+
+// token 最後に送信したメール（招待・確認）のリンクからトークンを取り出す
+func (m *captureMailer) token(t *testing.T) string {
+	t.Helper()
+	if m.last == nil {
+		t.Fatal("招待メールが送信されていない")
+	}
+	_, rest, found := strings.Cut(m.last.Body, "?token=")
+	if !found {
+		t.Fatalf("招待メールにトークンがない: %s", m.last.Body)
+	}
+	token, err := url.QueryUnescape(strings.Fields(rest)[0])
+	if err != nil {
+		t.Fatalf("トークンのデコード失敗: %v", err)
+	}
+	return token
+}
+
+// TestTeamHandlers チーム・メンバー・招待ハンドラーのテスト
+func TestTeamHandlers(t *testing.T) {
+	users := services.NewMemoryUserRepository()
+	mailer := &captureMailer{}
+	service := services.NewTeamService(services.NewMemoryTeamRepository(users), users, mailer, services.TeamInvitationConfig{
+		AcceptURL: "http://localhost:3000/invitations/accept",
+		TTL:       time.Hour,
+	})
+	h := NewTeamHandler(service)
+
+	owner, _ := users.Create("owner@example.com", "Owner", "hash")
+	member, _ := users.Create("member@example.com", "Member", "hash")
+	outsider, _ := users.Create("outsider@example.com", "Outsider", "hash")
+
+	serveAs := func(user *models.User, handlerFunc http.HandlerFunc, method, body string, params map[string]string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, "/", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		req = req.WithContext(custommiddleware.WithUser(req.Context(), user))
+		rctx := chi.NewRouteContext()
+		for key, value := range params {
+			rctx.URLParams.Add(key, value)
+		}
+		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+		w := httptest.NewRecorder()
+		handlerFunc(w, req)
+		return w
+	}
+
+	w := serveAs(owner, h.CreateTeamHandler, "POST", `{"name":"Nuxt","avatar":{"src":"https://github.com/nuxt.png"}}`, nil)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Create: expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	var created struct {
+		Data models.Team `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &created); err != nil || created.Data.Role != models.TeamRoleOwner {
+		t.Fatalf("Unexpected created team: %s", w.Body.String())
+	}
+	teamID := strconv.Itoa(created.Data.ID)
+	team := map[string]string{"id": teamID}
+
+	w = serveAs(owner, h.CreateInvitationHandler, "POST", `{"email":"member@example.com"}`, team)
+	if w.Code != http.StatusCreated || strings.Contains(w.Body.String(), "token") {
+		t.Fatalf("Invite: unexpected response %d: %s", w.Code, w.Body.String())
+	}
+	token := mailer.token(t)
+
+	w = serveAs(outsider, h.AcceptInvitationHandler, "POST", `{"token":"`+token+`"}`, nil)
+	if w.Code != http.StatusForbidden {
+		t.Errorf("Accept by other user: expected 403, got %d: %s", w.Code, w.Body.String())
+	}
+	w = serveAs(member, h.AcceptInvitationHandler, "POST", `{"token":"`+token+`"}`, nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Accept: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	serveAs(owner, h.CreateInvitationHandler, "POST", `{"email":"pending@example.com"}`, team)
+	invitations, _ := service.ListInvitations(owner, created.Data.ID)
+	invitationID := strconv.Itoa(invitations[0].ID)
+
+	ownerID, memberID := strconv.Itoa(owner.ID), strconv.Itoa(member.ID)
+	tests := []struct {
+		name           string
+		user           *models.User
+		handler        http.HandlerFunc
+		method         string
+		body           string
+		params         map[string]string
+		expectedStatus int
+	}{
+		{"Create invalid body", owner, h.CreateTeamHandler, "POST", `{`, nil, http.StatusBadRequest},
+		{"Create missing name", owner, h.CreateTeamHandler, "POST", `{"name":" "}`, nil, http.StatusBadRequest},
+		{"Get", member, h.GetTeamHandler, "GET", "", team, http.StatusOK},
+		{"Get invalid id", member, h.GetTeamHandler, "GET", "", map[string]string{"id": "abc"}, http.StatusBadRequest},
+		{"Get by outsider", outsider, h.GetTeamHandler, "GET", "", team, http.StatusNotFound},
+		{"Members", member, h.ListMembersHandler, "GET", "", team, http.StatusOK},
+		{"Members by outsider", outsider, h.ListMembersHandler, "GET", "", team, http.StatusNotFound},
+		{"Update by member", member, h.UpdateTeamHandler, "PATCH", `{"name":"x"}`, team, http.StatusForbidden},
+		{"Update", owner, h.UpdateTeamHandler, "PATCH", `{"name":"Nuxt UI"}`, team, http.StatusOK},
+		{"Invitations by member", member, h.ListInvitationsHandler, "GET", "", team, http.StatusForbidden},
+		{"Invitations", owner, h.ListInvitationsHandler, "GET", "", team, http.StatusOK},
+		{"Invite existing member", owner, h.CreateInvitationHandler, "POST", `{"email":"member@example.com"}`, team, http.StatusConflict},
+		{"Invite invalid role", owner, h.CreateInvitationHandler, "POST", `{"email":"a@example.com","role":"admin"}`, team, http.StatusBadRequest},
+		{"Accept used token", member, h.AcceptInvitationHandler, "POST", `{"token":"` + token + `"}`, nil, http.StatusNotFound},
+		{"Accept missing token", member, h.AcceptInvitationHandler, "POST", `{}`, nil, http.StatusBadRequest},
+		{"Role by member", member, h.UpdateMemberRoleHandler, "PATCH", `{"role":"owner"}`, map[string]string{"id": teamID, "userID": memberID}, http.StatusForbidden},
+		{"Role unknown field", owner, h.UpdateMemberRoleHandler, "PATCH", `{"role":"owner","name":"x"}`, map[string]string{"id": teamID, "userID": memberID}, http.StatusBadRequest},
+		{"Role invalid user id", owner, h.UpdateMemberRoleHandler, "PATCH", `{"role":"owner"}`, map[string]string{"id": teamID, "userID": "abc"}, http.StatusBadRequest},
+		{"Role not member", owner, h.UpdateMemberRoleHandler, "PATCH", `{"role":"owner"}`, map[string]string{"id": teamID, "userID": "999"}, http.StatusNotFound},
+		{"Demote last owner", owner, h.UpdateMemberRoleHandler, "PATCH", `{"role":"member"}`, map[string]string{"id": teamID, "userID": ownerID}, http.StatusConflict},
+		{"Remove owner by member", member, h.RemoveMemberHandler, "DELETE", "", map[string]string{"id": teamID, "userID": ownerID}, http.StatusForbidden},
+		{"Last owner leaves", owner, h.RemoveMemberHandler, "DELETE", "", map[string]string{"id": teamID, "userID": ownerID}, http.StatusConflict},
+		{"Revoke by member", member, h.RevokeInvitationHandler, "DELETE", "", map[string]string{"id": teamID, "invitationID": invitationID}, http.StatusForbidden},
+		{"Revoke", owner, h.RevokeInvitationHandler, "DELETE", "", map[string]string{"id": teamID, "invitationID": invitationID}, http.StatusOK},
+		{"Revoke again", owner, h.RevokeInvitationHandler, "DELETE", "", map[string]string{"id": teamID, "invitationID": invitationID}, http.StatusNotFound},
+		{"Member leaves", member, h.RemoveMemberHandler, "DELETE", "", map[string]string{"id": teamID, "userID": memberID}, http.StatusOK},
+		{"Delete by outsider", outsider, h.DeleteTeamHandler, "DELETE", "", team, http.StatusNotFound},
+		{"Delete", owner, h.DeleteTeamHandler, "DELETE", "", team, http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if w := serveAs(tt.user, tt.handler, tt.method, tt.body, tt.params); w.Code != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+
+	// 認証済みユーザーがない場合は401
+	w = httptest.NewRecorder()
+	h.ListTeamsHandler(w, httptest.NewRequest("GET", "/", nil))
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("Unauthenticated: expected 401, got %d", w.Code)
+	}
+}
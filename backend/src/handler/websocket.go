@@ -0,0 +1,146 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"backend/models"
+	"backend/services"
+	"backend/utils"
+)
+
+// wsWriteTimeout 1回の書き込み（メッセージ・ping）の期限
+const wsWriteTimeout = 10 * time.Second
+
+// WebSocketHandler 双方向のリアルタイム通信（WebSocket）ハンドラー構造体
+type WebSocketHandler struct {
+	hub          *services.RealtimeHub
+	upgrader     websocket.Upgrader
+	pingInterval time.Duration
+}
+
+// NewWebSocketHandler WebSocket ハンドラーを新規作成
+// pingInterval ごとに ping を送信し、その2倍の間 pong もメッセージも届かない接続を切断します
+func NewWebSocketHandler(hub *services.RealtimeHub, pingInterval time.Duration) *WebSocketHandler {
+	return &WebSocketHandler{
+		hub: hub,
+		upgrader: websocket.Upgrader{
+			Subprotocols: []string{utils.WebSocketSubprotocol},
+			// 認証はトークンを明示的に渡す方式（Cookie 不使用）のため、CORS と同様に全てのオリジンを許可する
+			CheckOrigin: func(r *http.Request) bool { return true },
+		},
+		pingInterval: pingInterval,
+	}
+}
+
+// ServeWebSocket WebSocket 接続
+// @Summary WebSocket 接続（プレゼンス・入力中の表示・イベント）
+// @Description WebSocket にアップグレードし、JSON メッセージ {"type", "room", "data"} を送受信します。
+// @Description クライアントからは room.join / room.leave / typing.start / typing.stop を送信し、
+// @Description サーバーからは presence.state / presence.joined / presence.left / typing.started / typing.stopped / error と
+// @Description イベントストリームと同じイベント（message.created など）を送信します。
+// @Description ブラウザからは Sec-WebSocket-Protocol に "realtime.v1" と "bearer.{access_token}" を指定して認証できます。
+// @Description error のメッセージはハンドシェイクの Accept-Language の言語で送信します。
+// @Tags events
+// @Param Sec-WebSocket-Protocol header string false "realtime.v1, bearer.{access_token}"
+// @Success 101 {string} string "Switching Protocols"
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/ws [get]
+func (h *WebSocketHandler) ServeWebSocket(w http.ResponseWriter, r *http.Request) {
+	user, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	// 失敗時は Upgrade がエラーレスポンスを返す
+	conn, err := h.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+
+	client := h.hub.Connect(user)
+	defer h.hub.Disconnect(client)
+	go h.writeLoop(conn, client)
+	h.readLoop(conn, client, models.LocaleOf(w))
+}
+
+// readLoop クライアントからのメッセージを処理（接続が切れるか応答がなくなるまで）
+// エラーのメッセージは locale の言語で送信します
+func (h *WebSocketHandler) readLoop(conn *websocket.Conn, client *services.RealtimeClient, locale models.Locale) {
+	pongWait := 2 * h.pingInterval
+	conn.SetReadLimit(utils.MaxWebSocketMessageSize)
+	_ = conn.SetReadDeadline(time.Now().Add(pongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway, websocket.CloseNoStatusReceived) {
+				log.Printf("⚠️  WebSocket connection closed: %v", err)
+			}
+			return
+		}
+		_ = conn.SetReadDeadline(time.Now().Add(pongWait))
+
+		var message models.RealtimeMessage
+		if err := json.Unmarshal(data, &message); err != nil || message.Type == "" {
+			h.hub.Send(client, realtimeErrorMessage(locale, "", models.NewValidationError("type", models.ValidationCodeInvalid, "realtime.invalid_message", nil)))
+			continue
+		}
+		if err := h.hub.Handle(client, message); err != nil {
+			h.hub.Send(client, realtimeErrorMessage(locale, message.Room, err))
+		}
+	}
+}
+
+// writeLoop 送信バッファのメッセージと ping を送信
+// 送信バッファが閉じられた（切断・受信の遅れ・サーバーの停止）場合は close フレームを送って接続を閉じます
+func (h *WebSocketHandler) writeLoop(conn *websocket.Conn, client *services.RealtimeClient) {
+	defer conn.Close()
+	ping := time.NewTicker(h.pingInterval)
+	defer ping.Stop()
+
+	for {
+		select {
+		case payload, ok := <-client.Messages():
+			_ = conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+			if !ok {
+				code, text := websocket.CloseGoingAway, "connection closed"
+				if client.Evicted() {
+					code, text = websocket.CloseTryAgainLater, "send buffer overflow"
+				}
+				_ = conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(code, text))
+				return
+			}
+			if err := conn.WriteMessage(websocket.TextMessage, payload); err != nil {
+				return
+			}
+		case <-ping.C:
+			_ = conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+			if err := conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// realtimeErrorMessage クライアントのメッセージを処理できなかったことを通知するメッセージ
+func realtimeErrorMessage(locale models.Locale, room string, err error) models.RealtimeMessage {
+	data := models.RealtimeErrorData{Error: "internal_error", Message: models.Translate(locale, "realtime.process_failed", nil)}
+	var validationErr *models.ValidationError
+	if errors.As(err, &validationErr) {
+		data = models.RealtimeErrorData{Error: "validation_error", Message: validationErr.Localize(locale).Message}
+	}
+	payload, _ := json.Marshal(data)
+	return models.RealtimeMessage{Type: models.RealtimeError, Room: room, Data: payload}
+}
@@ -0,0 +1,128 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	custommiddleware "backend/middleware"
+	"backend/models"
+	"backend/services"
+	"backend/utils"
+)
+
+// TestWebSocketHandler WebSocket のメッセージ送受信と ping/pong のテスト
+func TestWebSocketHandler(t *testing.T) {
+	bus := services.NewMemoryEventBus()
+	hub := services.NewRealtimeHub(bus)
+	bus.Subscribe(hub.Dispatch)
+	h := NewWebSocketHandler(hub, 50*time.Millisecond)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h.ServeWebSocket(w, r.WithContext(custommiddleware.WithUser(r.Context(), &models.User{ID: 1, Name: "Alice"})))
+	}))
+	defer server.Close()
+
+	url := "ws" + strings.TrimPrefix(server.URL, "http")
+	dialer := websocket.Dialer{Subprotocols: []string{utils.WebSocketSubprotocol}}
+	conn, _, err := dialer.Dial(url, nil)
+	if err != nil {
+		t.Fatalf("接続失敗: %v", err)
+	}
+	defer conn.Close()
+	if conn.Subprotocol() != utils.WebSocketSubprotocol {
+		t.Errorf("サブプロトコルが選択されない: %q", conn.Subprotocol())
+	}
+
+	// 受信は別の goroutine で続ける（読み取り中は ping に自動で pong を返す）
+	pinged := make(chan struct{}, 1)
+	conn.SetPingHandler(func(data string) error {
+		select {
+		case pinged <- struct{}{}:
+		default:
+		}
+		return conn.WriteControl(websocket.PongMessage, []byte(data), time.Now().Add(time.Second))
+	})
+	messages := make(chan models.RealtimeMessage, 10)
+	go func() {
+		defer close(messages)
+		for {
+			var message models.RealtimeMessage
+			if err := conn.ReadJSON(&message); err != nil {
+				return
+			}
+			messages <- message
+		}
+	}()
+	read := func() models.RealtimeMessage {
+		t.Helper()
+		select {
+		case message, ok := <-messages:
+			if !ok {
+				t.Fatal("接続が閉じられた")
+			}
+			return message
+		case <-time.After(time.Second):
+			t.Fatal("メッセージが送信されない")
+		}
+		return models.RealtimeMessage{}
+	}
+
+	conn.WriteJSON(models.RealtimeMessage{Type: models.RealtimeRoomJoin, Room: "customer:42"})
+	if message := read(); message.Type != models.RealtimePresenceState || string(message.Data) != `{"users":[{"id":1,"name":"Alice"}]}` {
+		t.Errorf("参加者一覧が不正: %+v", message)
+	}
+
+	bus.Publish(models.EventMessageCreated, 0, map[string]int{"id": 1})
+	if message := read(); message.Type != "message.created" || string(message.Data) != `{"id":1}` {
+		t.Errorf("イベントが不正: %+v", message)
+	}
+
+	conn.WriteMessage(websocket.TextMessage, []byte("not json"))
+	if message := read(); message.Type != models.RealtimeError || !strings.Contains(string(message.Data), "validation_error") {
+		t.Errorf("不正なメッセージへの応答が不正: %+v", message)
+	}
+	conn.WriteJSON(models.RealtimeMessage{Type: models.RealtimeTypingStart, Room: "inbox"})
+	if message := read(); message.Type != models.RealtimeError || message.Room != "inbox" {
+		t.Errorf("未参加のルームへの送信の応答が不正: %+v", message)
+	}
+
+	// pong を返していれば、応答の期限（ping 間隔の2倍）を過ぎても接続が維持される
+	time.Sleep(300 * time.Millisecond)
+	select {
+	case <-pinged:
+	default:
+		t.Error("ping が送信されない")
+	}
+	conn.WriteJSON(models.RealtimeMessage{Type: models.RealtimeRoomJoin, Room: "customer:42"})
+	if message := read(); message.Type != models.RealtimePresenceState {
+		t.Errorf("参加者一覧が不正: %+v", message)
+	}
+
+	// 読み取らない（pong を返さない）接続は切断される
+	silent, _, err := dialer.Dial(url, nil)
+	if err != nil {
+		t.Fatalf("接続失敗: %v", err)
+	}
+	defer silent.Close()
+	time.Sleep(300 * time.Millisecond)
+	silent.SetPingHandler(func(string) error { return nil })
+	_ = silent.SetReadDeadline(time.Now().Add(time.Second))
+	if _, _, err := silent.ReadMessage(); !websocket.IsCloseError(err, websocket.CloseGoingAway) {
+		t.Errorf("応答のない接続が切断されない: %v", err)
+	}
+}
+
+// TestWebSocketHandlerUnauthorized 認証なしの WebSocket 接続のテスト
+func TestWebSocketHandlerUnauthorized(t *testing.T) {
+	h := NewWebSocketHandler(services.NewRealtimeHub(services.NewMemoryEventBus()), time.Second)
+
+	w := httptest.NewRecorder()
+	h.ServeWebSocket(w, httptest.NewRequest("GET", "/api/ws", nil))
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("Expected 401 without user, got %d", w.Code)
+	}
+}
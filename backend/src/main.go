@@ -8,13 +8,18 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
+	_ "time/tzdata" // タイムゾーンデータベースのないイメージでも統計の tz を解決できるよう埋め込む
 
 	"backend/config"
+	"backend/db/migrations"
 	_ "backend/docs" // Swagger docs
 	"backend/handler"
 	"backend/router"
+	"backend/services"
+	"backend/utils"
 )
 
 // @title Go + Chi Starter Project API
@@ -32,29 +37,97 @@ import (
 // @host localhost:8080
 // @BasePath /
 // @schemes http https
+
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
+// @description "Bearer {access_token}" 形式のアクセストークン（"Bearer {api_key}" 形式のAPIキーも可）
+
+// @securityDefinitions.apikey ApiKeyAuth
+// @in header
+// @name X-API-Key
+// @description 個人用APIキー（/api/auth/api-keys で作成）
 func main() {
 	// 設定読み込み
 	cfg := config.LoadConfig()
 
-	// データベース接続
+	// データベース接続（メモリストレージの場合は接続しない）
 	var db *sql.DB
 	var err error
 
 	dbConfig := config.NewDatabaseConfig(cfg)
-	db, err = dbConfig.Connect()
-	if err != nil {
-		log.Printf("⚠️  Database connection failed: %v", err)
-		log.Println("⚠️  Running without database...")
-		db = nil
+	if cfg.UseMemoryStorage() {
+		log.Println("💾 Using in-memory storage (STORAGE_DRIVER=memory)")
+	} else {
+		db, err = dbConfig.Connect()
+		if err != nil {
+			log.Printf("⚠️  Database connection failed: %v", err)
+			log.Println("⚠️  Running without database...")
+			db = nil
+		}
 	}
 	defer dbConfig.Close(db)
 
+	// マイグレーション自動適用（AUTO_MIGRATE=true の場合）
+	if cfg.AutoMigrate && db != nil {
+		runMigrations(db)
+	}
+
+	// リポジトリ・サービス初期化
+	repos, err := services.NewRepositories(cfg.StorageDriver, db)
+	if err != nil {
+		log.Fatalf("❌ Failed to initialize storage: %v", err)
+	}
+	eventBus := newEventBus(cfg, db, dbConfig)
+	defer eventBus.Close()
+	eventBroker := services.NewEventBroker(cfg.EventReplayBuffer)
+	eventBus.Subscribe(eventBroker.Dispatch)
+	realtimeHub := services.NewRealtimeHub(eventBus)
+	eventBus.Subscribe(realtimeHub.Dispatch)
+	helloWorldService := services.NewHelloWorldServiceWithEvents(repos.HelloWorld, eventBus)
+	hasher, err := services.NewPasswordHasher(cfg.PasswordHashAlgorithm)
+	if err != nil {
+		log.Fatalf("❌ Failed to initialize password hashing: %v", err)
+	}
+	authService, err := newAuthService(cfg, repos, hasher)
+	if err != nil {
+		log.Fatalf("❌ Failed to initialize authentication: %v", err)
+	}
+	rbacService := services.NewRBACService(repos.Roles, repos.Users)
+	mailer := newMailer(cfg)
+	notificationService := services.NewNotificationService(repos.Notifications, repos.Preferences, repos.Users, eventBus, services.NotificationEmailConfig{
+		Mailer:  mailer,
+		BaseURL: strings.TrimRight(cfg.AppBaseURL, "/"),
+	})
+	teamService := services.NewTeamService(repos.Teams, repos.Users, mailer, services.TeamInvitationConfig{
+		AcceptURL: strings.TrimRight(cfg.AppBaseURL, "/") + "/invitations/accept",
+		TTL:       cfg.TeamInvitationTTL,
+	})
+	profileService := services.NewProfileService(repos.Users, repos.Accounts, hasher, mailer, services.EmailChangeConfig{
+		ConfirmURL: strings.TrimRight(cfg.AppBaseURL, "/") + "/settings/confirm-email",
+		TTL:        cfg.EmailChangeTTL,
+	})
+
 	// ハンドラー初期化
-	healthHandler := handler.NewHealthHandler(db)
-	helloWorldHandler := handler.NewHelloWorldHandler(db)
+	handlers := router.Handlers{
+		Health:        handler.NewHealthHandler(db),
+		HelloWorld:    handler.NewHelloWorldHandlerWithService(helloWorldService),
+		Auth:          handler.NewAuthHandler(authService),
+		Profile:       handler.NewProfileHandler(profileService),
+		RBAC:          handler.NewRBACHandler(rbacService),
+		Customers:     handler.NewCustomerHandler(services.NewCustomerService(repos.Customers, notificationService)),
+		Mails:         handler.NewMailHandler(services.NewMailService(repos.Mails, notificationService)),
+		Teams:         handler.NewTeamHandler(teamService),
+		Notifications: handler.NewNotificationHandler(notificationService),
+		Sales:         handler.NewSaleHandler(services.NewSaleService(repos.Sales)),
+		Stats:         handler.NewStatsHandler(services.NewStatsService(repos.Sales)),
+		Events:        handler.NewEventsHandler(eventBroker, cfg.EventHeartbeatInterval),
+		Realtime:      handler.NewWebSocketHandler(realtimeHub, cfg.EventHeartbeatInterval),
+		Authenticator: authService,
+	}
 
 	// ルーター設定
-	r := router.NewRouter(healthHandler, helloWorldHandler)
+	r := router.NewRouter(handlers)
 
 	// サーバー設定
 	server := &http.Server{
@@ -64,6 +137,8 @@ func main() {
 		WriteTimeout: 15 * time.Second,
 		IdleTimeout:  60 * time.Second,
 	}
+	// イベントストリームは接続を維持し続けるため、シャットダウン開始時に購読を終了する
+	server.RegisterOnShutdown(eventBroker.Close)
 
 	// グレースフルシャットダウン用のチャネル
 	done := make(chan os.Signal, 1)
@@ -91,6 +166,91 @@ func main() {
 	if err := server.Shutdown(ctx); err != nil {
 		log.Printf("❌ Server forced to shutdown: %v", err)
 	}
+	// WebSocket の接続は Shutdown の対象外のため、イベントバスを閉じる前に退出を通知して終了する
+	realtimeHub.Close()
+	// リクエストの処理後に送信待ちに追加した通知メールを送り切ってから終了する
+	notificationService.Close()
 
 	log.Println("✅ Server exited")
 }
+
+// newAuthService 設定に従ってJWTを構成した認証サービスを作成
+func newAuthService(cfg *config.Config, repos *services.Repositories, hasher *services.PasswordHasher) (*services.AuthService, error) {
+	var err error
+	tokenConfig := services.TokenConfig{
+		Algorithm:  cfg.JWTAlgorithm,
+		Secret:     cfg.JWTSecret,
+		AccessTTL:  cfg.AccessTokenTTL,
+		RefreshTTL: cfg.RefreshTokenTTL,
+	}
+	if cfg.JWTAlgorithm == utils.JWTAlgorithmRS256 {
+		if tokenConfig.PrivateKeyPEM, err = os.ReadFile(cfg.JWTPrivateKeyPath); err != nil {
+			return nil, fmt.Errorf("failed to read JWT private key: %w", err)
+		}
+		if cfg.JWTPublicKeyPath != "" {
+			if tokenConfig.PublicKeyPEM, err = os.ReadFile(cfg.JWTPublicKeyPath); err != nil {
+				return nil, fmt.Errorf("failed to read JWT public key: %w", err)
+			}
+		}
+	} else if cfg.JWTSecret == utils.DefaultJWTSecret {
+		log.Println("⚠️  JWT_SECRET is the default value. Set a strong secret outside local development.")
+	}
+
+	tokens, err := services.NewTokenManager(tokenConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return services.NewAuthService(repos, hasher, tokens)
+}
+
+// newMailer 設定に従ってメール送信を作成（SMTP_HOST 未設定時は送信せずログに出力）
+func newMailer(cfg *config.Config) services.Mailer {
+	if cfg.SMTPHost == "" {
+		log.Println("⚠️  SMTP_HOST is not set. Emails will be logged instead of sent.")
+	}
+	return services.NewMailer(services.MailerConfig{
+		Host:     cfg.SMTPHost,
+		Port:     cfg.SMTPPort,
+		Username: cfg.SMTPUsername,
+		Password: cfg.SMTPPassword,
+		From:     cfg.MailFrom,
+	})
+}
+
+// newEventBus STORAGE_DRIVER に応じたイベントバスを作成
+// データベースに接続できていない場合はレプリカ間で共有しないメモリバスを使用します
+func newEventBus(cfg *config.Config, db *sql.DB, dbConfig *config.DatabaseConfig) services.EventBus {
+	driver := cfg.StorageDriver
+	if driver == utils.StorageDriverPostgres && db == nil {
+		log.Println("⚠️  Database is unavailable. Realtime events will not be shared between replicas.")
+		driver = utils.StorageDriverMemory
+	}
+	bus, err := services.NewEventBus(driver, db, dbConfig.GetConnectionString())
+	if err != nil {
+		log.Fatalf("❌ Failed to initialize event bus: %v", err)
+	}
+	return bus
+}
+
+// runMigrations 未適用のマイグレーションを適用（失敗時は中途半端なスキーマで起動しないよう終了）
+func runMigrations(db *sql.DB) {
+	migrator, err := migrations.New(db)
+	if err != nil {
+		log.Fatalf("❌ Failed to load migrations: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	steps, err := migrator.Up(ctx)
+	for _, step := range steps {
+		log.Printf("📦 Migration applied: %s", step)
+	}
+	if err != nil {
+		log.Fatalf("❌ Migration failed: %v", err)
+	}
+	if len(steps) == 0 {
+		log.Println("✅ Database schema is up to date")
+	}
+}
@@ -0,0 +1,117 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"backend/models"
+	"backend/services"
+	"backend/utils"
+)
+
+// Authenticator アクセストークンまたはAPIキーからユーザーを解決するインターフェース
+// services.AuthService が実装します
+type Authenticator interface {
+	Authenticate(token string) (*models.User, error)
+}
+
+// contextKey リクエストコンテキストのキー型
+type contextKey string
+
+// userContextKey 認証済みユーザーのコンテキストキー
+const userContextKey contextKey = "user"
+
+// RequireAuth Authorization: Bearer ヘッダーのアクセストークンまたはAPIキーを検証するミドルウェア
+// APIキーは X-API-Key ヘッダーでも受け付けます
+// WebSocket のハンドシェイクでは Sec-WebSocket-Protocol の "bearer.{token}" も受け付けます（ブラウザ用）
+// 検証に成功するとユーザーをリクエストコンテキストに格納し、失敗すると401を返します
+func RequireAuth(auth Authenticator) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token, ok := bearerToken(r)
+			if !ok {
+				token = strings.TrimSpace(r.Header.Get(utils.APIKeyHeader))
+				ok = token != ""
+			}
+			if !ok {
+				token, ok = webSocketToken(r)
+			}
+			if !ok {
+				w.Header().Set("WWW-Authenticate", `Bearer realm="api"`)
+				models.SendUnauthorizedError(w, "auth.required")
+				return
+			}
+
+			user, err := auth.Authenticate(token)
+			if err != nil {
+				if errors.Is(err, services.ErrInvalidToken) {
+					w.Header().Set("WWW-Authenticate", `Bearer realm="api", error="invalid_token"`)
+					models.SendUnauthorizedError(w, "auth.invalid_access_token")
+					return
+				}
+				models.SendDatabaseError(w, "error.authenticate")
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(WithUser(r.Context(), user)))
+		})
+	}
+}
+
+// RequireSession ログインセッション（アクセストークン）による認証を要求するミドルウェア
+// セッション・二要素認証・APIキーなど認証情報自体の管理をAPIキーで行えないようにします
+// RequireAuth の後に適用してください
+func RequireSession(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, ok := UserFromContext(r.Context())
+		if !ok {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="api"`)
+			models.SendUnauthorizedError(w, "auth.required")
+			return
+		}
+		if user.APIKeyID != 0 {
+			models.SendForbiddenError(w, "auth.api_key_not_allowed")
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// WithUser 認証済みユーザーを格納したコンテキストを返す
+func WithUser(ctx context.Context, user *models.User) context.Context {
+	return context.WithValue(ctx, userContextKey, user)
+}
+
+// UserFromContext コンテキストから認証済みユーザーを取得
+func UserFromContext(ctx context.Context) (*models.User, bool) {
+	user, ok := ctx.Value(userContextKey).(*models.User)
+	return user, ok && user != nil
+}
+
+// bearerToken Authorization ヘッダーから Bearer トークンを取り出す（スキーム名は大文字小文字を区別しない）
+func bearerToken(r *http.Request) (string, bool) {
+	scheme, token, found := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
+
+// webSocketToken WebSocket のハンドシェイクの Sec-WebSocket-Protocol から "bearer.{token}" 形式のトークンを取り出す
+// ブラウザの WebSocket API はヘッダーを指定できないため、サブプロトコルとして受け付けます
+func webSocketToken(r *http.Request) (string, bool) {
+	if !strings.EqualFold(r.Header.Get("Upgrade"), "websocket") {
+		return "", false
+	}
+	for _, protocol := range strings.Split(r.Header.Get("Sec-WebSocket-Protocol"), ",") {
+		token, found := strings.CutPrefix(strings.TrimSpace(protocol), utils.WebSocketTokenProtocolPrefix)
+		if found && token != "" {
+			return token, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,199 @@
+package middleware
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"backend/models"
+	"backend/services"
+)
+
+// stubAuthenticator テスト用の認証スタブ
+type stubAuthenticator struct {
+	users map[string]*models.User
+	err   error
+}
+
+// Authenticate トークンに対応するユーザーを返す
+func (s *stubAuthenticator) Authenticate(token string) (*models.User, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	if user, ok := s.users[token]; ok {
+		return user, nil
+	}
+	return nil, services.ErrInvalidToken
+}
+
+// TestRequireAuth 認証ミドルウェアのテスト
+func TestRequireAuth(t *testing.T) {
+	auth := &stubAuthenticator{users: map[string]*models.User{"valid-token": {ID: 7, Email: "alice@example.com"}}}
+
+	var gotUser *models.User
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotUser, _ = UserFromContext(r.Context())
+		w.WriteHeader(http.StatusNoContent)
+	})
+	handler := RequireAuth(auth)(next)
+
+	tests := []struct {
+		name           string
+		header         string
+		expectedStatus int
+		expectedUser   int
+	}{
+		{"Valid token", "Bearer valid-token", http.StatusNoContent, 7},
+		{"Lowercase scheme", "bearer valid-token", http.StatusNoContent, 7},
+		{"Missing header", "", http.StatusUnauthorized, 0},
+		{"Wrong scheme", "Basic dXNlcjpwYXNz", http.StatusUnauthorized, 0},
+		{"Empty token", "Bearer ", http.StatusUnauthorized, 0},
+		{"Invalid token", "Bearer invalid-token", http.StatusUnauthorized, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotUser = nil
+			req := httptest.NewRequest("POST", "/", nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Fatalf("Expected status %d, got %d", tt.expectedStatus, rr.Code)
+			}
+			if tt.expectedStatus == http.StatusUnauthorized {
+				if rr.Header().Get("WWW-Authenticate") == "" {
+					t.Error("WWW-Authenticate header is missing")
+				}
+				var response models.ErrorResponse
+				if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil || response.Error != "unauthorized" {
+					t.Errorf("Unexpected error response: %s", rr.Body.String())
+				}
+				return
+			}
+			if gotUser == nil || gotUser.ID != tt.expectedUser {
+				t.Errorf("Expected user %d in context, got %+v", tt.expectedUser, gotUser)
+			}
+		})
+	}
+}
+
+// TestRequireAuthBackendError 認証処理の内部エラーのテスト
+func TestRequireAuthBackendError(t *testing.T) {
+	handler := RequireAuth(&stubAuthenticator{err: errors.New("db down")})(http.NotFoundHandler())
+
+	req := httptest.NewRequest("POST", "/", nil)
+	req.Header.Set("Authorization", "Bearer token")
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusInternalServerError {
+		t.Errorf("Expected status 500, got %d", rr.Code)
+	}
+}
+
+// TestUserFromContextEmpty ユーザー未設定のコンテキストのテスト
+func TestUserFromContextEmpty(t *testing.T) {
+	if _, ok := UserFromContext(httptest.NewRequest("GET", "/", nil).Context()); ok {
+		t.Error("Expected no user in empty context")
+	}
+}
+
+// TestRequireAuthAPIKeyHeader X-API-Key ヘッダーによる認証のテスト
+func TestRequireAuthAPIKeyHeader(t *testing.T) {
+	auth := &stubAuthenticator{users: map[string]*models.User{"gcs_key": {ID: 9, APIKeyID: 3}}}
+	var gotUser *models.User
+	handler := RequireAuth(auth)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotUser, _ = UserFromContext(r.Context())
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("X-API-Key", "gcs_key")
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	if rr.Code != http.StatusNoContent || gotUser == nil || gotUser.ID != 9 {
+		t.Errorf("Expected user 9 via X-API-Key, got %d %+v", rr.Code, gotUser)
+	}
+
+	req = httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("X-API-Key", "gcs_unknown")
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	if rr.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status 401 for unknown key, got %d", rr.Code)
+	}
+}
+
+// TestRequireAuthWebSocketProtocol Sec-WebSocket-Protocol によるトークンの受け渡しのテスト
+func TestRequireAuthWebSocketProtocol(t *testing.T) {
+	auth := &stubAuthenticator{users: map[string]*models.User{"valid-token": {ID: 7}}}
+	handler := RequireAuth(auth)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	tests := []struct {
+		name           string
+		upgrade        string
+		protocol       string
+		expectedStatus int
+	}{
+		{"Token protocol", "websocket", "realtime.v1, bearer.valid-token", http.StatusNoContent},
+		{"Invalid token", "websocket", "realtime.v1, bearer.invalid-token", http.StatusUnauthorized},
+		{"No token protocol", "websocket", "realtime.v1", http.StatusUnauthorized},
+		{"Not a WebSocket handshake", "", "bearer.valid-token", http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/api/ws", nil)
+			if tt.upgrade != "" {
+				req.Header.Set("Connection", "Upgrade")
+				req.Header.Set("Upgrade", tt.upgrade)
+			}
+			req.Header.Set("Sec-WebSocket-Protocol", tt.protocol)
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, rr.Code)
+			}
+		})
+	}
+}
+
+// TestRequireSession APIキーによる認証を拒否するミドルウェアのテスト
+func TestRequireSession(t *testing.T) {
+	handler := RequireSession(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	tests := []struct {
+		name           string
+		user           *models.User
+		expectedStatus int
+	}{
+		{"Session", &models.User{ID: 1, SessionID: "abc"}, http.StatusNoContent},
+		{"API key", &models.User{ID: 1, APIKeyID: 2}, http.StatusForbidden},
+		{"Unauthenticated", nil, http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/", nil)
+			if tt.user != nil {
+				req = req.WithContext(WithUser(req.Context(), tt.user))
+			}
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, rr.Code)
+			}
+		})
+	}
+}
@@ -1,7 +1,6 @@
 package middleware
 
 import (
-	"encoding/json"
 	"log"
 	"net/http"
 	"time"
@@ -15,16 +14,7 @@ func ErrorHandler(next http.Handler) http.Handler {
 		defer func() {
 			if rec := recover(); rec != nil {
 				// パニックが発生した場合の処理
-				response := models.ErrorResponse{
-					Status:    "error",
-					Error:     "internal_error",
-					Message:   "Internal Server Error",
-					Timestamp: time.Now(),
-				}
-
-				w.Header().Set("Content-Type", "application/json")
-				w.WriteHeader(http.StatusInternalServerError)
-				_ = json.NewEncoder(w).Encode(response)
+				models.SendInternalError(w, "error.internal")
 			}
 		}()
 
@@ -36,8 +26,8 @@ func ErrorHandler(next http.Handler) http.Handler {
 func CORS(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Access-Control-Allow-Origin", "*")
-		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-API-Key")
 
 		if r.Method == "OPTIONS" {
 			w.WriteHeader(http.StatusOK)
@@ -0,0 +1,18 @@
+package middleware
+
+import (
+	"net/http"
+
+	"backend/models"
+)
+
+// Localize Accept-Language ヘッダーからレスポンスのメッセージの言語（ja・en）を選択するミドルウェア
+// 対応する言語がなければ英語とし、選択した言語を Content-Language ヘッダーで返します
+func Localize(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		locale := models.NegotiateLocale(r.Header.Get("Accept-Language"))
+		w.Header().Set("Content-Language", string(locale))
+		w.Header().Add("Vary", "Accept-Language")
+		next.ServeHTTP(models.NewLocaleWriter(w, locale), r)
+	})
+}
@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"backend/models"
+)
+
+// TestLocalize Accept-Language の言語でメッセージを送信するミドルウェアのテスト
+func TestLocalize(t *testing.T) {
+	handler := Localize(ProblemDetails(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		models.SendForbiddenError(w, "auth.permission_denied")
+	})))
+
+	tests := []struct {
+		name           string
+		acceptLanguage string
+		accept         string
+		wantLanguage   string
+		wantMessage    string
+	}{
+		{"Japanese", "ja-JP,ja;q=0.9", "", "ja", "権限がありません"},
+		{"English", "en-US", "", "en", "Permission denied"},
+		{"Fallback", "fr-FR", "", "en", "Permission denied"},
+		{"Problem details in Japanese", "ja", models.ProblemContentType, "ja", "権限がありません"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/api/admin/roles", nil)
+			req.Header.Set("Accept-Language", tt.acceptLanguage)
+			req.Header.Set("Accept", tt.accept)
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			var body struct {
+				Message string `json:"message"`
+				Detail  string `json:"detail"`
+			}
+			if err := json.NewDecoder(rr.Body).Decode(&body); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if message := body.Message + body.Detail; message != tt.wantMessage {
+				t.Errorf("message = %q, want %q", message, tt.wantMessage)
+			}
+			if got := rr.Header().Get("Content-Language"); got != tt.wantLanguage {
+				t.Errorf("Content-Language = %q, want %q", got, tt.wantLanguage)
+			}
+			if rr.Header().Get("Vary") != "Accept-Language" {
+				t.Errorf("Vary = %q", rr.Header().Get("Vary"))
+			}
+		})
+	}
+}
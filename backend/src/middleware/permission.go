@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"net/http"
+
+	"backend/models"
+)
+
+// RequirePermission 認証済みユーザーが権限を持つか検証するミドルウェア
+// RequireAuth の後に適用します。権限がない場合は 403 forbidden を返します
+//
+//	r.With(RequirePermission(models.PermissionMessagesDelete)).Delete("/messages/{id}", h)
+func RequirePermission(permission string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			user, ok := UserFromContext(r.Context())
+			if !ok {
+				w.Header().Set("WWW-Authenticate", `Bearer realm="api"`)
+				models.SendUnauthorizedError(w, "auth.required")
+				return
+			}
+
+			if !user.HasPermission(permission) {
+				models.SendErrorResponseWithParams(w, http.StatusForbidden, "forbidden", "auth.missing_permission", map[string]interface{}{"permission": permission})
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
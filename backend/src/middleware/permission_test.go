@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"backend/models"
+)
+
+// TestRequirePermission 権限ミドルウェアのテスト
+func TestRequirePermission(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	handler := RequirePermission(models.PermissionMessagesDelete)(next)
+
+	tests := []struct {
+		name           string
+		user           *models.User
+		expectedStatus int
+		expectedError  string
+	}{
+		{"Granted", &models.User{ID: 1, Permissions: []string{models.PermissionMessagesCreate, models.PermissionMessagesDelete}}, http.StatusNoContent, ""},
+		{"Denied", &models.User{ID: 2, Permissions: []string{models.PermissionMessagesCreate}}, http.StatusForbidden, "forbidden"},
+		{"No permissions", &models.User{ID: 3}, http.StatusForbidden, "forbidden"},
+		{"Unauthenticated", nil, http.StatusUnauthorized, "unauthorized"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("DELETE", "/", nil)
+			if tt.user != nil {
+				req = req.WithContext(WithUser(req.Context(), tt.user))
+			}
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Fatalf("Expected status %d, got %d", tt.expectedStatus, rr.Code)
+			}
+			if tt.expectedError == "" {
+				return
+			}
+			var response models.ErrorResponse
+			if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil || response.Error != tt.expectedError {
+				t.Errorf("Unexpected error response: %s", rr.Body.String())
+			}
+		})
+	}
+}
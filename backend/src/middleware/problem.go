@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
+
+	"backend/models"
+)
+
+// ProblemDetails Accept ヘッダーで application/problem+json を優先したリクエストのエラーレスポンスを
+// RFC 7807 Problem Details 形式にするミドルウェア（それ以外は従来の ErrorResponse 形式）
+// instance にはリクエストのパス、request_id には chi の RequestID ミドルウェアのIDを設定します
+// 差し替えたライターは http.Hijacker を実装しないため、それ以外のリクエスト（WebSocket のハンドシェイクなど）はそのまま渡します
+func ProblemDetails(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if prefersProblemJSON(r.Header.Get("Accept")) {
+			w = models.NewProblemWriter(w, r.URL.Path, chimiddleware.GetReqID(r.Context()))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// prefersProblemJSON Accept ヘッダーで application/problem+json の品質値が application/json 以上か判定
+// ワイルドカード（*/*・application/*）は従来の形式を選びます
+func prefersProblemJSON(accept string) bool {
+	problemQ, jsonQ := 0.0, 0.0
+	for _, mediaRange := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(mediaRange))
+		if err != nil {
+			continue
+		}
+		q := 1.0
+		if value, ok := params["q"]; ok {
+			if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+				q = parsed
+			}
+		}
+		switch mediaType {
+		case models.ProblemContentType:
+			problemQ = max(problemQ, q)
+		case "application/json":
+			jsonQ = max(jsonQ, q)
+		}
+	}
+	return problemQ > 0 && problemQ >= jsonQ
+}
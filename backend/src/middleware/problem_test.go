@@ -0,0 +1,75 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
+
+	"backend/models"
+)
+
+// TestPrefersProblemJSON Accept ヘッダーのネゴシエーションのテスト
+func TestPrefersProblemJSON(t *testing.T) {
+	tests := []struct {
+		accept string
+		want   bool
+	}{
+		{"", false},
+		{"*/*", false},
+		{"application/json", false},
+		{"application/problem+json", true},
+		{"application/problem+json, application/json", true},
+		{"application/json, application/problem+json;q=0.5", false},
+		{"application/json;q=0.5, application/problem+json", true},
+		{"application/problem+json;q=0", false},
+		{"text/html, application/problem+json;q=0.9, */*;q=0.8", true},
+	}
+	for _, tt := range tests {
+		if got := prefersProblemJSON(tt.accept); got != tt.want {
+			t.Errorf("prefersProblemJSON(%q) = %v, want %v", tt.accept, got, tt.want)
+		}
+	}
+}
+
+// TestProblemDetails Problem Details ミドルウェアのテスト
+func TestProblemDetails(t *testing.T) {
+	handler := chimiddleware.RequestID(ProblemDetails(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		models.SendForbiddenError(w, "Permission denied")
+	})))
+
+	t.Run("Problem details", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/api/customers", nil)
+		req.Header.Set("Accept", "application/problem+json")
+		rr := httptest.NewRecorder()
+
+		handler.ServeHTTP(rr, req)
+
+		var problem models.ProblemDetails
+		if err := json.NewDecoder(rr.Body).Decode(&problem); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		if rr.Header().Get("Content-Type") != models.ProblemContentType || problem.Status != http.StatusForbidden ||
+			problem.Instance != "/api/customers" || problem.RequestID == "" {
+			t.Errorf("problem = %+v (Content-Type %q)", problem, rr.Header().Get("Content-Type"))
+		}
+	})
+
+	t.Run("Default error response", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/api/customers", nil)
+		req.Header.Set("Accept", "application/json")
+		rr := httptest.NewRecorder()
+
+		handler.ServeHTTP(rr, req)
+
+		var response models.ErrorResponse
+		if err := json.NewDecoder(rr.Body).Decode(&response); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		if rr.Header().Get("Content-Type") != "application/json" || response.Error != "forbidden" {
+			t.Errorf("response = %+v", response)
+		}
+	})
+}
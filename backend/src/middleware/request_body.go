@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"net/http"
+
+	"backend/models"
+)
+
+// LimitRequestBody リクエストボディの上限（バイト）を設定するミドルウェア
+// Content-Length が上限を超えるリクエストは 413 で拒否し、それ以外は上限を超えて読み込むと
+// *http.MaxBytesError を返すボディに差し替えます（ハンドラーのデコードで 413 を返します）
+// ルートごとに重ねて適用した場合は小さい方の上限になります
+func LimitRequestBody(limit int64) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.ContentLength > limit {
+				models.SendPayloadTooLargeError(w, "request.too_large", map[string]interface{}{"max": limit})
+				return
+			}
+			if r.Body != nil && r.Body != http.NoBody {
+				r.Body = http.MaxBytesReader(w, r.Body, limit)
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"backend/models"
+)
+
+// TestLimitRequestBody リクエストボディの上限を設定するミドルウェアのテスト
+func TestLimitRequestBody(t *testing.T) {
+	var readErr error
+	handler := LimitRequestBody(16)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, readErr = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	tests := []struct {
+		name           string
+		body           string
+		contentLength  int64
+		expectedStatus int
+		wantReadErr    bool
+	}{
+		{"Within limit", `{"name":"test"}`, -1, http.StatusNoContent, false},
+		{"Content-Length over limit", strings.Repeat("a", 32), 32, http.StatusRequestEntityTooLarge, false},
+		{"Chunked body over limit", strings.Repeat("a", 32), -1, http.StatusNoContent, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			readErr = nil
+			req := httptest.NewRequest("POST", "/api/hello-world", strings.NewReader(tt.body))
+			req.ContentLength = tt.contentLength
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.expectedStatus, rr.Code, rr.Body.String())
+			}
+			var maxBytesErr *http.MaxBytesError
+			if got := readErr != nil; got != tt.wantReadErr {
+				t.Errorf("read error = %v, want error: %v", readErr, tt.wantReadErr)
+			} else if got && (!errors.As(readErr, &maxBytesErr) || maxBytesErr.Limit != 16) {
+				t.Errorf("read error = %v, want *http.MaxBytesError with limit 16", readErr)
+			}
+			if rr.Code != http.StatusRequestEntityTooLarge {
+				return
+			}
+			var body models.ErrorResponse
+			if err := json.NewDecoder(rr.Body).Decode(&body); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if body.Error != "payload_too_large" || body.Message != "Request body must be at most 16 bytes" {
+				t.Errorf("unexpected error response: %+v", body)
+			}
+		})
+	}
+}
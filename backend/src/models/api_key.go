@@ -0,0 +1,51 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// APIKeyNameMaxLength APIキー名の最大長
+const APIKeyNameMaxLength = 100
+
+// APIKey 個人用APIキー構造体（キー本体はハッシュのみ保持）
+type APIKey struct {
+	ID         int        `json:"id"`
+	UserID     int        `json:"-"`
+	Name       string     `json:"name" example:"CI"`
+	Prefix     string     `json:"prefix" example:"3f9a1c7b2e4d"`
+	KeyHash    string     `json:"-"`
+	Scopes     []string   `json:"scopes" example:"messages:create"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"-"`
+}
+
+// Active APIキーが失効・期限切れでないか判定
+func (k *APIKey) Active(now time.Time) bool {
+	return k.RevokedAt == nil && (k.ExpiresAt == nil || now.Before(*k.ExpiresAt))
+}
+
+// CreateAPIKeyRequest APIキー作成リクエスト
+type CreateAPIKeyRequest struct {
+	Name      string     `json:"name" example:"CI"`
+	Scopes    []string   `json:"scopes" example:"messages:create"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// CreatedAPIKeyResponse APIキー作成レスポンス（key は作成時の一度だけ返す）
+type CreatedAPIKeyResponse struct {
+	APIKey
+	Key string `json:"key" example:"gcs_3f9a1c7b2e4d_..."`
+}
+
+// Validate APIキー作成リクエストのバリデーション（全てのフィールドのエラーをまとめて返す）
+func (r *CreateAPIKeyRequest) Validate(now time.Time) error {
+	v := NewValidator()
+	r.Name = strings.TrimSpace(r.Name)
+	v.String("name", r.Name, Required(), MaxLength(APIKeyNameMaxLength))
+	v.Check("scopes", len(r.Scopes) > 0, ValidationCodeRequired, "validation.scopes_required", nil)
+	v.Check("expires_at", r.ExpiresAt == nil || r.ExpiresAt.After(now), ValidationCodeInvalid, "validation.future", nil)
+	return v.Err()
+}
@@ -0,0 +1,87 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// CustomerStatus 顧客のメール購読状態（ダッシュボードの UserStatus に対応）
+type CustomerStatus string
+
+const (
+	CustomerStatusSubscribed   CustomerStatus = "subscribed"
+	CustomerStatusUnsubscribed CustomerStatus = "unsubscribed"
+	CustomerStatusBounced      CustomerStatus = "bounced"
+)
+
+// CustomerStatuses 有効な購読状態の一覧
+var CustomerStatuses = []CustomerStatus{CustomerStatusSubscribed, CustomerStatusUnsubscribed, CustomerStatusBounced}
+
+// 顧客フィールドの長さ制限
+const (
+	CustomerNameMaxLength     = 255
+	CustomerLocationMaxLength = 255
+	AvatarURLMaxLength        = 2048
+)
+
+// Avatar アバター画像（ダッシュボードの AvatarProps に対応）
+type Avatar struct {
+	Src string `json:"src" example:"https://i.pravatar.cc/128?u=1"`
+}
+
+// Customer 顧客構造体（ダッシュボードの User 型に対応）
+type Customer struct {
+	ID        int            `json:"id"`
+	Name      string         `json:"name" example:"Alex Smith"`
+	Email     string         `json:"email" example:"alex.smith@example.com"`
+	Avatar    *Avatar        `json:"avatar,omitempty"`
+	Status    CustomerStatus `json:"status" example:"subscribed"`
+	Location  string         `json:"location" example:"New York, USA"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+}
+
+// CustomerRequest 顧客作成・更新リクエスト構造体（POST/PUT/PATCH共通）
+type CustomerRequest struct {
+	Name     string         `json:"name" example:"Alex Smith"`
+	Email    string         `json:"email" example:"alex.smith@example.com"`
+	Avatar   *Avatar        `json:"avatar,omitempty"`
+	Status   CustomerStatus `json:"status,omitempty" example:"subscribed"`
+	Location string         `json:"location" example:"New York, USA"`
+}
+
+// Validate 顧客リクエストのバリデーション（全てのフィールドのエラーをまとめて返す）
+// email を正規化し、status 省略時は subscribed を設定します
+func (r *CustomerRequest) Validate() error {
+	v := NewValidator()
+
+	r.Name = strings.TrimSpace(r.Name)
+	v.String("name", r.Name, Required(), MaxLength(CustomerNameMaxLength))
+	r.Email = NormalizeEmail(r.Email)
+	validateEmail(v, "email", r.Email)
+
+	if r.Status == "" {
+		r.Status = CustomerStatusSubscribed
+	}
+	v.String("status", string(r.Status), OneOf(CustomerStatuses...))
+
+	r.Location = strings.TrimSpace(r.Location)
+	v.String("location", r.Location, MaxLength(CustomerLocationMaxLength))
+	validateAvatar(v, "avatar", &r.Avatar)
+	return v.Err()
+}
+
+// validateAvatar アバターを検証（空の src は未設定として nil にする）
+func validateAvatar(v *Validator, field string, avatar **Avatar) {
+	if *avatar == nil {
+		return
+	}
+	(*avatar).Src = strings.TrimSpace((*avatar).Src)
+	if (*avatar).Src == "" {
+		*avatar = nil
+		return
+	}
+	v.Nested(field, func(v *Validator) {
+		v.String("src", (*avatar).Src, MaxLength(AvatarURLMaxLength), HTTPURL())
+	})
+}
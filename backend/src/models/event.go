@@ -0,0 +1,47 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// EventType リアルタイム配信するイベントの種類
+type EventType string
+
+const (
+	EventMessageCreated      EventType = "message.created"
+	EventMessageUpdated      EventType = "message.updated"
+	EventMessageDeleted      EventType = "message.deleted"
+	EventNotificationCreated EventType = "notification.created"
+
+	// EventStreamReset Last-Event-ID 以降のイベントを再送できない場合に送信（クライアントは再取得が必要）
+	EventStreamReset EventType = "stream.reset"
+
+	// ルーム内のイベント（WebSocket でのみ配信）
+	EventPresenceJoined EventType = "presence.joined"
+	EventPresenceLeft   EventType = "presence.left"
+	EventTypingStarted  EventType = "typing.started"
+	EventTypingStopped  EventType = "typing.stopped"
+	// EventPresenceSync 他のレプリカに参加中の接続の再通知を求める（ルームは RoomAll）
+	EventPresenceSync EventType = "presence.sync"
+)
+
+// RoomAll 全てのルーム宛てのイベントのルーム名
+const RoomAll = "*"
+
+// Event 購読者に配信するイベント
+//
+// ID はサーバー起動ごとに1から採番する連番で、SSE の Last-Event-ID による再開に使用します。
+type Event struct {
+	ID     uint64          `json:"id"`
+	Type   EventType       `json:"type"`
+	UserID int             `json:"-"` // 宛先ユーザー（0 の場合は全ユーザー）
+	Room   string          `json:"-"` // 宛先ルーム（ルーム内のイベントの場合のみ）
+	Data   json.RawMessage `json:"data"`
+	Time   time.Time       `json:"time"`
+}
+
+// EventResourceID 削除などIDのみを通知するイベントのデータ
+type EventResourceID struct {
+	ID int `json:"id" example:"1"`
+}
@@ -9,6 +9,12 @@ type HelloWorldRequest struct {
 	Name string `json:"name"`
 }
 
+// HelloWorldUpdateRequest Hello World更新リクエスト構造体（PUT/PATCH共通）
+type HelloWorldUpdateRequest struct {
+	Name    string `json:"name"`
+	Message string `json:"message"`
+}
+
 // HelloWorldMessage Hello Worldメッセージ構造体
 type HelloWorldMessage struct {
 	ID        int       `json:"id"`
@@ -25,21 +31,20 @@ type HelloWorldResponse struct {
 	Version   string    `json:"version"`
 }
 
+// HelloWorldNameMaxLength 名前の最大長
+const HelloWorldNameMaxLength = 255
+
 // Validate Hello Worldリクエストのバリデーション
 func (h *HelloWorldRequest) Validate() error {
-	if h.Name == "" {
-		return &ValidationError{Field: "name", Message: "Name is required"}
-	}
-	return nil
-}
-
-// ValidationError バリデーションエラー構造体
-type ValidationError struct {
-	Field   string `json:"field"`
-	Message string `json:"message"`
+	v := NewValidator()
+	v.String("name", h.Name, Required(), MaxLength(HelloWorldNameMaxLength))
+	return v.Err()
 }
 
-// Error エラーメッセージを返す
-func (v *ValidationError) Error() string {
-	return v.Message
+// Validate Hello World更新リクエストのバリデーション（全てのフィールドのエラーをまとめて返す）
+func (h *HelloWorldUpdateRequest) Validate() error {
+	v := NewValidator()
+	v.String("name", h.Name, Required(), MaxLength(HelloWorldNameMaxLength))
+	v.String("message", h.Message, Required())
+	return v.Err()
 }
@@ -47,6 +47,39 @@ func TestHelloWorldRequestValidation(t *testing.T) {
 	}
 }
 
+// TestHelloWorldUpdateRequestValidation Hello World更新リクエストバリデーションのテスト
+func TestHelloWorldUpdateRequestValidation(t *testing.T) {
+	tests := []struct {
+		name      string
+		request   HelloWorldUpdateRequest
+		wantField string
+	}{
+		{"Valid request", HelloWorldUpdateRequest{Name: "Alice", Message: "Hi"}, ""},
+		{"Empty name", HelloWorldUpdateRequest{Name: "", Message: "Hi"}, "name"},
+		{"Empty message", HelloWorldUpdateRequest{Name: "Alice", Message: ""}, "message"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.request.Validate()
+			if tt.wantField == "" {
+				if err != nil {
+					t.Errorf("Expected no error, got %v", err)
+				}
+				return
+			}
+
+			validationErr, ok := err.(*ValidationError)
+			if !ok {
+				t.Fatalf("Expected *ValidationError, got %T", err)
+			}
+			if validationErr.Field != tt.wantField {
+				t.Errorf("Expected field '%s', got '%s'", tt.wantField, validationErr.Field)
+			}
+		})
+	}
+}
+
 // TestValidationError ValidationErrorのテスト
 func TestValidationError(t *testing.T) {
 	tests := []struct {
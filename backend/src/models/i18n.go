@@ -0,0 +1,117 @@
+package models
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Locale APIメッセージの言語
+type Locale string
+
+// 対応する言語
+const (
+	LocaleEN Locale = "en"
+	LocaleJA Locale = "ja"
+)
+
+// DefaultLocale Accept-Language に対応する言語がない場合の言語
+const DefaultLocale = LocaleEN
+
+// Locales 対応する言語の一覧
+var Locales = []Locale{LocaleEN, LocaleJA}
+
+// NegotiateLocale Accept-Language ヘッダーから品質値の最も高い対応言語を選択する
+// 地域の指定（ja-JP など）は言語のみで比較し、対応する言語がなければ DefaultLocale を返します
+func NegotiateLocale(acceptLanguage string) Locale {
+	type candidate struct {
+		locale Locale
+		q      float64
+	}
+	var candidates []candidate
+	for _, languageRange := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(languageRange), ";")
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		language, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+		for _, locale := range Locales {
+			if language == string(locale) && q > 0 {
+				candidates = append(candidates, candidate{locale: locale, q: q})
+			}
+		}
+	}
+	if len(candidates) == 0 {
+		return DefaultLocale
+	}
+	// 品質値が同じ場合はヘッダーでの順序を優先
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].q > candidates[j].q })
+	return candidates[0].locale
+}
+
+// Translate メッセージのキーを言語のメッセージに変換し、{name} を params の値で置き換える
+// 言語にメッセージがなければ DefaultLocale、それもなければキー自体を返します（キーでない文字列はそのまま）
+func Translate(locale Locale, key string, params map[string]interface{}) string {
+	message, ok := messages[key][locale]
+	if !ok {
+		message, ok = messages[key][DefaultLocale]
+	}
+	if !ok {
+		return key
+	}
+	return interpolate(message, params)
+}
+
+// interpolate メッセージの {name} を params の値で置き換える（配列は ", " で連結）
+func interpolate(message string, params map[string]interface{}) string {
+	if len(params) == 0 || !strings.Contains(message, "{") {
+		return message
+	}
+	replacements := make([]string, 0, len(params)*2)
+	for name, value := range params {
+		text := fmt.Sprint(value)
+		if values, ok := value.([]string); ok {
+			text = strings.Join(values, ", ")
+		}
+		replacements = append(replacements, "{"+name+"}", text)
+	}
+	return strings.NewReplacer(replacements...).Replace(message)
+}
+
+// localeWriter レスポンスのメッセージの言語を保持するレスポンスライター
+type localeWriter struct {
+	http.ResponseWriter
+	locale Locale
+}
+
+// NewLocaleWriter Send* で送信するメッセージを指定した言語に変換するレスポンスライターを作成
+func NewLocaleWriter(w http.ResponseWriter, locale Locale) http.ResponseWriter {
+	return &localeWriter{ResponseWriter: w, locale: locale}
+}
+
+// Unwrap 元のレスポンスライターを返す（http.ResponseController で Flush などを使用するため）
+func (w *localeWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// Hijack 元のレスポンスライターの接続を引き継ぐ（WebSocket のハンドシェイク用）
+func (w *localeWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return http.NewResponseController(w.ResponseWriter).Hijack()
+}
+
+// LocaleOf レスポンスライターのメッセージの言語を取得（NewLocaleWriter を経由していなければ DefaultLocale）
+func LocaleOf(w http.ResponseWriter) Locale {
+	if writer, ok := findWriter[*localeWriter](w); ok {
+		return writer.locale
+	}
+	return DefaultLocale
+}
@@ -0,0 +1,110 @@
+package models
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestMessagesHaveAllLocales 全てのメッセージキーに全ての言語のメッセージがあることのテスト
+func TestMessagesHaveAllLocales(t *testing.T) {
+	for key, translations := range messages {
+		for _, locale := range Locales {
+			if strings.TrimSpace(translations[locale]) == "" {
+				t.Errorf("%s: %s のメッセージがない", key, locale)
+			}
+		}
+	}
+}
+
+// TestNegotiateLocale Accept-Language からの言語の選択のテスト
+func TestNegotiateLocale(t *testing.T) {
+	tests := []struct {
+		header string
+		want   Locale
+	}{
+		{"", LocaleEN},
+		{"ja", LocaleJA},
+		{"ja-JP", LocaleJA},
+		{"JA-jp,en;q=0.5", LocaleJA},
+		{"en-US,en;q=0.9,ja;q=0.8", LocaleEN},
+		{"fr-FR,ja;q=0.7,en;q=0.6", LocaleJA},
+		{"en;q=0.5,ja;q=0.9", LocaleJA},
+		{"ja;q=0,en", LocaleEN},
+		{"fr, de", LocaleEN},
+		{"*", LocaleEN},
+		{"ja;q=abc, en;q=0.1", LocaleEN},
+	}
+	for _, tt := range tests {
+		if got := NegotiateLocale(tt.header); got != tt.want {
+			t.Errorf("NegotiateLocale(%q) = %s, want %s", tt.header, got, tt.want)
+		}
+	}
+}
+
+// TestTranslate メッセージの変換と値の埋め込みのテスト
+func TestTranslate(t *testing.T) {
+	tests := []struct {
+		name   string
+		locale Locale
+		key    string
+		params map[string]interface{}
+		want   string
+	}{
+		{"English", LocaleEN, "customer.retrieved", nil, "Customer retrieved successfully"},
+		{"Japanese", LocaleJA, "customer.retrieved", nil, "顧客を取得しました"},
+		{"Params", LocaleJA, "validation.too_long", map[string]interface{}{"label": "名前", "max": 30}, "名前は30文字以内で入力してください"},
+		{"Slice param", LocaleEN, "validation.one_of", map[string]interface{}{"label": "Role", "values": []string{"admin", "member"}}, "Role must be one of admin, member"},
+		{"Unknown locale falls back to English", Locale("fr"), "customer.retrieved", nil, "Customer retrieved successfully"},
+		{"Unknown key is returned as is", LocaleJA, "Failed to load", nil, "Failed to load"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Translate(tt.locale, tt.key, tt.params); got != tt.want {
+				t.Errorf("Translate() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestLocaleWriter レスポンスライターの言語でメッセージを送信するテスト
+func TestLocaleWriter(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	w := NewLocaleWriter(recorder, LocaleJA)
+	SendFieldValidationError(w, NewValidationError("email", ValidationCodeRequired, "validation.required", nil))
+
+	var response ErrorResponse
+	if err := json.NewDecoder(recorder.Body).Decode(&response); err != nil {
+		t.Fatalf("レスポンスをデコードできない: %v", err)
+	}
+	if response.Message != "メールアドレスは必須です" || len(response.Errors) != 1 || response.Errors[0].Message != response.Message {
+		t.Errorf("日本語のメッセージで送信されていない: %+v", response)
+	}
+
+	// Problem Details のライターを重ねても言語を参照できる
+	if got := LocaleOf(NewProblemWriter(w, "/", "")); got != LocaleJA {
+		t.Errorf("LocaleOf() = %s, want ja", got)
+	}
+	if got := LocaleOf(recorder); got != DefaultLocale {
+		t.Errorf("LocaleOf() = %s, want %s", got, DefaultLocale)
+	}
+	if _, ok := w.(http.Hijacker); !ok {
+		t.Error("WebSocket のため http.Hijacker を実装するべき")
+	}
+}
+
+// TestSendErrorResponseWithParams パラメータ付きのメッセージをレスポンスライターの言語で送信するテスト
+func TestSendErrorResponseWithParams(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	SendErrorResponseWithParams(NewLocaleWriter(recorder, LocaleJA), http.StatusForbidden, "forbidden", "auth.missing_permission", map[string]interface{}{"permission": PermissionMessagesDelete})
+
+	var response ErrorResponse
+	if err := json.NewDecoder(recorder.Body).Decode(&response); err != nil {
+		t.Fatalf("レスポンスをデコードできない: %v", err)
+	}
+	if recorder.Code != http.StatusForbidden || response.Error != "forbidden" || response.Message != "権限 "+PermissionMessagesDelete+" がありません" {
+		t.Errorf("パラメータ付きのメッセージで送信されていない: %d %+v", recorder.Code, response)
+	}
+}
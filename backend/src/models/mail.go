@@ -0,0 +1,134 @@
+package models
+
+import (
+	"regexp"
+	"strings"
+	"time"
+)
+
+// メールフィールドの長さ制限
+const (
+	MailSubjectMaxLength   = 998 // RFC 5322 の1行の上限
+	MailBodyMaxLength      = 100000
+	MailMessageIDMaxLength = 255
+)
+
+// MailFilter メール一覧の絞り込み（ダッシュボードの All / Unread タブに対応）
+type MailFilter string
+
+const (
+	MailFilterAll    MailFilter = "all"
+	MailFilterUnread MailFilter = "unread"
+)
+
+// MailSender メールの送信者（ダッシュボードの Mail.from に対応）
+type MailSender struct {
+	Name   string  `json:"name" example:"Alex Smith"`
+	Email  string  `json:"email" example:"alex.smith@example.com"`
+	Avatar *Avatar `json:"avatar,omitempty"`
+}
+
+// Mail 受信メール構造体（ダッシュボードの Mail 型に対応）
+//
+// 受信箱は全ユーザーで共有し、Unread はリクエストしたユーザーの既読状態です。
+type Mail struct {
+	ID            int        `json:"id"`
+	Unread        bool       `json:"unread"`
+	From          MailSender `json:"from"`
+	Subject       string     `json:"subject" example:"Meeting Schedule: Q1 Marketing Strategy Review"`
+	Body          string     `json:"body"`
+	Date          time.Time  `json:"date"`
+	MessageID     string     `json:"message_id" example:"<q1-review@example.com>"`
+	InReplyTo     string     `json:"in_reply_to,omitempty" example:"<q1-kickoff@example.com>"`
+	ThreadID      int        `json:"thread_id"`
+	ThreadSubject string     `json:"-"` // スレッド判定用に正規化した件名
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+// MailRequest メール受信（登録）リクエスト構造体
+type MailRequest struct {
+	From      MailSender `json:"from"`
+	Subject   string     `json:"subject" example:"RE: Project Phoenix - Sprint 3 Update"`
+	Body      string     `json:"body"`
+	Date      *time.Time `json:"date,omitempty"`                                             // 省略時は受信日時
+	MessageID string     `json:"message_id,omitempty" example:"<sprint3@example.com>"`       // 省略時は自動採番
+	InReplyTo string     `json:"in_reply_to,omitempty" example:"<sprint3-plan@example.com>"` // 返信元の Message-ID
+}
+
+// MailReadStateRequest 既読状態の更新リクエスト構造体
+type MailReadStateRequest struct {
+	Unread *bool `json:"unread" example:"false"`
+}
+
+// MailUnreadCountResponse 未読件数レスポンス構造体
+type MailUnreadCountResponse struct {
+	Unread int `json:"unread" example:"3"`
+}
+
+// mailReplyPrefix 件名の返信・転送プレフィックス（"Re:", "RE[2]:", "Fwd:" など）
+var mailReplyPrefix = regexp.MustCompile(`(?i)^\s*(re|fwd?)(\[\d+\])?\s*:\s*`)
+
+// IsMailReplySubject 件名が返信・転送プレフィックスで始まるか判定
+func IsMailReplySubject(subject string) bool {
+	return mailReplyPrefix.MatchString(subject)
+}
+
+// NormalizeMailSubject スレッド判定用に件名を正規化
+// 返信・転送プレフィックスを繰り返し取り除き、空白をまとめて小文字化します
+func NormalizeMailSubject(subject string) string {
+	for mailReplyPrefix.MatchString(subject) {
+		subject = mailReplyPrefix.ReplaceAllString(subject, "")
+	}
+	return strings.ToLower(strings.Join(strings.Fields(subject), " "))
+}
+
+// NormalizeMessageID Message-ID を比較用に正規化（前後の空白除去、山括弧で囲む）
+func NormalizeMessageID(id string) string {
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return ""
+	}
+	return "<" + strings.TrimSuffix(strings.TrimPrefix(id, "<"), ">") + ">"
+}
+
+// Validate メール受信リクエストのバリデーション（全てのフィールドのエラーをまとめて返す）
+// 送信者・件名・Message-ID を正規化します
+func (r *MailRequest) Validate() error {
+	v := NewValidator()
+	v.Nested("from", func(v *Validator) {
+		r.From.Name = strings.TrimSpace(r.From.Name)
+		v.String("name", r.From.Name, MaxLength(CustomerNameMaxLength))
+		r.From.Email = NormalizeEmail(r.From.Email)
+		validateEmail(v, "email", r.From.Email)
+		validateAvatar(v, "avatar", &r.From.Avatar)
+	})
+
+	r.Subject = strings.TrimSpace(r.Subject)
+	v.String("subject", r.Subject, Required(), MaxLength(MailSubjectMaxLength))
+	v.String("body", r.Body, MaxLength(MailBodyMaxLength))
+
+	r.MessageID = NormalizeMessageID(r.MessageID)
+	v.String("message_id", r.MessageID, MaxLength(MailMessageIDMaxLength))
+	r.InReplyTo = NormalizeMessageID(r.InReplyTo)
+	v.String("in_reply_to", r.InReplyTo, MaxLength(MailMessageIDMaxLength))
+	return v.Err()
+}
+
+// Validate 既読状態の更新リクエストのバリデーション
+func (r *MailReadStateRequest) Validate() error {
+	v := NewValidator()
+	v.Present("unread", r.Unread != nil)
+	return v.Err()
+}
+
+// ParseMailFilter filter クエリパラメータをパース（空の場合は all）
+func ParseMailFilter(raw string) (MailFilter, error) {
+	switch filter := MailFilter(raw); filter {
+	case "":
+		return MailFilterAll, nil
+	case MailFilterAll, MailFilterUnread:
+		return filter, nil
+	default:
+		return "", NewValidationError("filter", ValidationCodeInvalid, "validation.one_of", map[string]interface{}{"values": []string{string(MailFilterAll), string(MailFilterUnread)}})
+	}
+}
@@ -0,0 +1,64 @@
+package models
+
+import "testing"
+
+// TestNormalizeMailSubject スレッド判定用の件名正規化テスト
+func TestNormalizeMailSubject(t *testing.T) {
+	tests := []struct {
+		subject string
+		want    string
+		reply   bool
+	}{
+		{"Project Phoenix - Sprint 3 Update", "project phoenix - sprint 3 update", false},
+		{"RE: Project Phoenix - Sprint 3 Update", "project phoenix - sprint 3 update", true},
+		{"Re: Fwd:  re[2]: Project   Phoenix", "project phoenix", true},
+		{"FW: Budget", "budget", true},
+		{"Regarding the budget", "regarding the budget", false},
+	}
+
+	for _, tt := range tests {
+		if got := NormalizeMailSubject(tt.subject); got != tt.want {
+			t.Errorf("NormalizeMailSubject(%q) = %q, want %q", tt.subject, got, tt.want)
+		}
+		if got := IsMailReplySubject(tt.subject); got != tt.reply {
+			t.Errorf("IsMailReplySubject(%q) = %v, want %v", tt.subject, got, tt.reply)
+		}
+	}
+}
+
+// TestMailRequestValidate メール登録リクエストのバリデーションテスト
+func TestMailRequestValidate(t *testing.T) {
+	valid := func() MailRequest {
+		return MailRequest{From: MailSender{Name: "Alex", Email: "alex@example.com"}, Subject: "Hello"}
+	}
+
+	tests := []struct {
+		name   string
+		modify func(r *MailRequest)
+		field  string
+	}{
+		{"送信者メールアドレスなし", func(r *MailRequest) { r.From.Email = "" }, "from.email"},
+		{"不正なアバターURL", func(r *MailRequest) { r.From.Avatar = &Avatar{Src: "ftp://example.com/a.png"} }, "from.avatar.src"},
+		{"件名なし", func(r *MailRequest) { r.Subject = "  " }, "subject"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := valid()
+			tt.modify(&request)
+			err := request.Validate()
+			if validationErr, ok := err.(*ValidationError); !ok || validationErr.Field != tt.field {
+				t.Errorf("Expected validation error on %q, got %v", tt.field, err)
+			}
+		})
+	}
+
+	request := valid()
+	request.From.Email = " Alex@Example.com "
+	request.MessageID = " abc@example.com "
+	if err := request.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if request.From.Email != "alex@example.com" || request.MessageID != "<abc@example.com>" {
+		t.Errorf("正規化されていない: %+v", request)
+	}
+}
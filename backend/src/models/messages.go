@@ -0,0 +1,245 @@
+package models
+
+// messages APIメッセージのカタログ（キー → 言語 → メッセージ）
+// 全てのキーに Locales の全ての言語のメッセージを定義します。{name} は Translate の params の値に置き換えます
+var messages = map[string]map[Locale]string{
+	// 共通・APIの情報
+	"api.name":                 {LocaleEN: "Go + Chi Starter Project API", LocaleJA: "Go + Chi スタータープロジェクト API"},
+	"auth.missing_permission":  {LocaleEN: "Missing permission: {permission}", LocaleJA: "権限 {permission} がありません"},
+	"health.database_failed":   {LocaleEN: "Database connection failed", LocaleJA: "データベースに接続できません"},
+	"health.ok":                {LocaleEN: "Application is running", LocaleJA: "アプリケーションは稼働中です"},
+	"hello_world.greeting":     {LocaleEN: "Hello, World!", LocaleJA: "こんにちは、世界！"},
+	"realtime.invalid_message": {LocaleEN: "Invalid message; expected a JSON object with a type", LocaleJA: "メッセージが不正です。type を含むJSONオブジェクトを送信してください"},
+	"realtime.not_joined":      {LocaleEN: "Not joined to this room", LocaleJA: "このルームに参加していません"},
+	"realtime.process_failed":  {LocaleEN: "Failed to process message", LocaleJA: "メッセージの処理に失敗しました"},
+	"realtime.too_many_rooms":  {LocaleEN: "Cannot join more than {max} rooms", LocaleJA: "参加できるルームは{max}件までです"},
+	"realtime.unknown_type":    {LocaleEN: "Unknown message type: \"{type}\"", LocaleJA: "未知のメッセージ種別です: \"{type}\""},
+
+	// リクエストボディ
+	"request.empty_body":             {LocaleEN: "Request body is empty", LocaleJA: "リクエストボディが空です"},
+	"request.invalid_json":           {LocaleEN: "Invalid JSON at byte offset {offset}", LocaleJA: "JSONが不正です（バイト位置 {offset}）"},
+	"request.unexpected_eof":         {LocaleEN: "Unexpected end of JSON at byte offset {offset}", LocaleJA: "JSONが途中で終わっています（バイト位置 {offset}）"},
+	"request.trailing_data":          {LocaleEN: "Unexpected data after the JSON value at byte offset {offset}", LocaleJA: "JSONの値の後に不要なデータがあります（バイト位置 {offset}）"},
+	"request.too_large":              {LocaleEN: "Request body must be at most {max} bytes", LocaleJA: "リクエストボディは{max}バイト以内にしてください"},
+	"request.unsupported_media_type": {LocaleEN: "Unsupported Content-Type; use {types}", LocaleJA: "対応していないContent-Typeです。{types} を指定してください"},
+
+	// ハンドラー・ミドルウェアのレスポンス
+	"api_key.created":                    {LocaleEN: "API key created successfully", LocaleJA: "APIキーを作成しました"},
+	"api_key.list_retrieved":             {LocaleEN: "API keys retrieved successfully", LocaleJA: "APIキー一覧を取得しました"},
+	"api_key.revoked":                    {LocaleEN: "API key revoked successfully", LocaleJA: "APIキーを無効化しました"},
+	"auth.api_key_not_allowed":           {LocaleEN: "API keys cannot be used for this endpoint; log in with a user session", LocaleJA: "このエンドポイントではAPIキーを使用できません。ユーザーとしてログインしてください"},
+	"auth.invalid_access_token":          {LocaleEN: "Invalid or expired access token", LocaleJA: "アクセストークンが無効か期限切れです"},
+	"auth.invalid_mfa_token":             {LocaleEN: "Invalid or expired MFA token", LocaleJA: "MFAトークンが無効か期限切れです"},
+	"auth.invalid_refresh_token":         {LocaleEN: "Invalid or expired refresh token", LocaleJA: "リフレッシュトークンが無効か期限切れです"},
+	"auth.logged_in":                     {LocaleEN: "Logged in successfully", LocaleJA: "ログインしました"},
+	"auth.mfa_required":                  {LocaleEN: "MFA verification required", LocaleJA: "二要素認証による確認が必要です"},
+	"auth.permission_denied":             {LocaleEN: "Permission denied", LocaleJA: "権限がありません"},
+	"auth.refresh_token_reused":          {LocaleEN: "Refresh token reuse detected; the session has been revoked", LocaleJA: "リフレッシュトークンの再利用を検出したため、セッションを無効化しました"},
+	"auth.registered":                    {LocaleEN: "User registered successfully", LocaleJA: "ユーザーを登録しました"},
+	"auth.required":                      {LocaleEN: "Authentication required", LocaleJA: "認証が必要です"},
+	"auth.token_refreshed":               {LocaleEN: "Token refreshed successfully", LocaleJA: "トークンを更新しました"},
+	"customer.created":                   {LocaleEN: "Customer created successfully", LocaleJA: "顧客を作成しました"},
+	"customer.deleted":                   {LocaleEN: "Customer deleted successfully", LocaleJA: "顧客を削除しました"},
+	"customer.list_retrieved":            {LocaleEN: "Customers retrieved successfully", LocaleJA: "顧客一覧を取得しました"},
+	"customer.retrieved":                 {LocaleEN: "Customer retrieved successfully", LocaleJA: "顧客を取得しました"},
+	"customer.updated":                   {LocaleEN: "Customer updated successfully", LocaleJA: "顧客を更新しました"},
+	"hello_world.created":                {LocaleEN: "Hello World message created successfully", LocaleJA: "Hello Worldメッセージを作成しました"},
+	"hello_world.deleted":                {LocaleEN: "Hello World message deleted successfully", LocaleJA: "Hello Worldメッセージを削除しました"},
+	"hello_world.list_retrieved":         {LocaleEN: "Hello World messages retrieved successfully", LocaleJA: "Hello Worldメッセージ一覧を取得しました"},
+	"hello_world.retrieved":              {LocaleEN: "Hello World message retrieved successfully", LocaleJA: "Hello Worldメッセージを取得しました"},
+	"hello_world.updated":                {LocaleEN: "Hello World message updated successfully", LocaleJA: "Hello Worldメッセージを更新しました"},
+	"invitation.accepted":                {LocaleEN: "Invitation accepted successfully", LocaleJA: "招待を承諾しました"},
+	"invitation.list_retrieved":          {LocaleEN: "Invitations retrieved successfully", LocaleJA: "招待一覧を取得しました"},
+	"invitation.revoked":                 {LocaleEN: "Invitation revoked successfully", LocaleJA: "招待を取り消しました"},
+	"invitation.sent":                    {LocaleEN: "Invitation sent successfully", LocaleJA: "招待を送信しました"},
+	"mail.created":                       {LocaleEN: "Mail created successfully", LocaleJA: "メールを作成しました"},
+	"mail.list_retrieved":                {LocaleEN: "Mails retrieved successfully", LocaleJA: "メール一覧を取得しました"},
+	"mail.retrieved":                     {LocaleEN: "Mail retrieved successfully", LocaleJA: "メールを取得しました"},
+	"mail.thread_retrieved":              {LocaleEN: "Mail thread retrieved successfully", LocaleJA: "メールスレッドを取得しました"},
+	"mail.updated":                       {LocaleEN: "Mail updated successfully", LocaleJA: "メールを更新しました"},
+	"mfa.disabled":                       {LocaleEN: "Two-factor authentication disabled", LocaleJA: "二要素認証を無効にしました"},
+	"mfa.enabled":                        {LocaleEN: "Two-factor authentication enabled", LocaleJA: "二要素認証を有効にしました"},
+	"mfa.enrollment_not_started":         {LocaleEN: "TOTP enrollment has not been started", LocaleJA: "TOTPの登録が開始されていません"},
+	"mfa.enrollment_started":             {LocaleEN: "TOTP enrollment started", LocaleJA: "TOTPの登録を開始しました"},
+	"mfa.invalid_code":                   {LocaleEN: "Invalid authentication code", LocaleJA: "認証コードが正しくありません"},
+	"mfa.recovery_codes_regenerated":     {LocaleEN: "Recovery codes regenerated", LocaleJA: "リカバリーコードを再生成しました"},
+	"mfa.status_retrieved":               {LocaleEN: "MFA status retrieved successfully", LocaleJA: "二要素認証の状態を取得しました"},
+	"notification.all_marked_read":       {LocaleEN: "Notifications marked as read", LocaleJA: "通知をすべて既読にしました"},
+	"notification.list_retrieved":        {LocaleEN: "Notifications retrieved successfully", LocaleJA: "通知一覧を取得しました"},
+	"notification.marked_read":           {LocaleEN: "Notification marked as read", LocaleJA: "通知を既読にしました"},
+	"notification.preferences_retrieved": {LocaleEN: "Notification preferences retrieved successfully", LocaleJA: "通知設定を取得しました"},
+	"notification.preferences_updated":   {LocaleEN: "Notification preferences updated successfully", LocaleJA: "通知設定を更新しました"},
+	"profile.account_deleted":            {LocaleEN: "Account deleted successfully", LocaleJA: "アカウントを削除しました"},
+	"profile.email_changed":              {LocaleEN: "Email address changed successfully", LocaleJA: "メールアドレスを変更しました"},
+	"profile.retrieved":                  {LocaleEN: "Profile retrieved successfully", LocaleJA: "プロフィールを取得しました"},
+	"profile.updated":                    {LocaleEN: "Profile updated successfully", LocaleJA: "プロフィールを更新しました"},
+	"request.invalid_api_key_id":         {LocaleEN: "Invalid API key ID format", LocaleJA: "APIキーIDの形式が不正です"},
+	"request.invalid_body":               {LocaleEN: "Invalid request body", LocaleJA: "リクエストボディが不正です"},
+	"request.invalid_id":                 {LocaleEN: "Invalid ID format", LocaleJA: "IDの形式が不正です"},
+	"request.invalid_user_id":            {LocaleEN: "Invalid user ID format", LocaleJA: "ユーザーIDの形式が不正です"},
+	"role.assigned":                      {LocaleEN: "Role assigned successfully", LocaleJA: "ロールを付与しました"},
+	"role.list_retrieved":                {LocaleEN: "Roles retrieved successfully", LocaleJA: "ロール一覧を取得しました"},
+	"role.revoked":                       {LocaleEN: "Role revoked successfully", LocaleJA: "ロールを剥奪しました"},
+	"role.user_roles_retrieved":          {LocaleEN: "User roles retrieved successfully", LocaleJA: "ユーザーのロールを取得しました"},
+	"sale.created":                       {LocaleEN: "Sale created successfully", LocaleJA: "売上を登録しました"},
+	"sale.list_retrieved":                {LocaleEN: "Sales retrieved successfully", LocaleJA: "売上一覧を取得しました"},
+	"sale.retrieved":                     {LocaleEN: "Sale retrieved successfully", LocaleJA: "売上を取得しました"},
+	"sale.updated":                       {LocaleEN: "Sale updated successfully", LocaleJA: "売上を更新しました"},
+	"session.list_retrieved":             {LocaleEN: "Sessions retrieved successfully", LocaleJA: "セッション一覧を取得しました"},
+	"session.others_revoked":             {LocaleEN: "Other sessions revoked successfully", LocaleJA: "他のセッションを無効化しました"},
+	"session.revoked":                    {LocaleEN: "Session revoked successfully", LocaleJA: "セッションを無効化しました"},
+	"stats.retrieved":                    {LocaleEN: "Stats retrieved successfully", LocaleJA: "統計を取得しました"},
+	"team.created":                       {LocaleEN: "Team created successfully", LocaleJA: "チームを作成しました"},
+	"team.deleted":                       {LocaleEN: "Team deleted successfully", LocaleJA: "チームを削除しました"},
+	"team.list_retrieved":                {LocaleEN: "Teams retrieved successfully", LocaleJA: "チーム一覧を取得しました"},
+	"team.member_removed":                {LocaleEN: "Team member removed successfully", LocaleJA: "チームメンバーを削除しました"},
+	"team.member_updated":                {LocaleEN: "Team member updated successfully", LocaleJA: "チームメンバーを更新しました"},
+	"team.members_retrieved":             {LocaleEN: "Team members retrieved successfully", LocaleJA: "チームメンバーを取得しました"},
+	"team.retrieved":                     {LocaleEN: "Team retrieved successfully", LocaleJA: "チームを取得しました"},
+	"team.updated":                       {LocaleEN: "Team updated successfully", LocaleJA: "チームを更新しました"},
+	"unread_count.retrieved":             {LocaleEN: "Unread count retrieved successfully", LocaleJA: "未読件数を取得しました"},
+
+	// 処理の失敗（500）
+	"error.accept_invitation":                 {LocaleEN: "Failed to accept invitation", LocaleJA: "招待の承諾に失敗しました"},
+	"error.assign_role":                       {LocaleEN: "Failed to assign role", LocaleJA: "ロールの付与に失敗しました"},
+	"error.authenticate":                      {LocaleEN: "Failed to authenticate", LocaleJA: "認証に失敗しました"},
+	"error.confirm_email_change":              {LocaleEN: "Failed to confirm email change", LocaleJA: "メールアドレス変更の確認に失敗しました"},
+	"error.count_unread_mails":                {LocaleEN: "Failed to count unread mails", LocaleJA: "未読メール数の取得に失敗しました"},
+	"error.count_unread_notifications":        {LocaleEN: "Failed to count unread notifications", LocaleJA: "未読通知数の取得に失敗しました"},
+	"error.create_api_key":                    {LocaleEN: "Failed to create API key", LocaleJA: "APIキーの作成に失敗しました"},
+	"error.create_customer":                   {LocaleEN: "Failed to create customer", LocaleJA: "顧客の作成に失敗しました"},
+	"error.create_hello_world_message":        {LocaleEN: "Failed to create hello world message", LocaleJA: "Hello Worldメッセージの作成に失敗しました"},
+	"error.create_mail":                       {LocaleEN: "Failed to create mail", LocaleJA: "メールの作成に失敗しました"},
+	"error.create_sale":                       {LocaleEN: "Failed to create sale", LocaleJA: "売上の登録に失敗しました"},
+	"error.create_team":                       {LocaleEN: "Failed to create team", LocaleJA: "チームの作成に失敗しました"},
+	"error.delete_account":                    {LocaleEN: "Failed to delete account", LocaleJA: "アカウントの削除に失敗しました"},
+	"error.delete_customer":                   {LocaleEN: "Failed to delete customer", LocaleJA: "顧客の削除に失敗しました"},
+	"error.delete_hello_world_message":        {LocaleEN: "Failed to delete hello world message", LocaleJA: "Hello Worldメッセージの削除に失敗しました"},
+	"error.delete_team":                       {LocaleEN: "Failed to delete team", LocaleJA: "チームの削除に失敗しました"},
+	"error.disable_totp":                      {LocaleEN: "Failed to disable TOTP", LocaleJA: "TOTPの無効化に失敗しました"},
+	"error.enroll_totp":                       {LocaleEN: "Failed to enroll TOTP", LocaleJA: "TOTPの登録に失敗しました"},
+	"error.get_api_keys":                      {LocaleEN: "Failed to get API keys", LocaleJA: "APIキー一覧の取得に失敗しました"},
+	"error.get_mfa_status":                    {LocaleEN: "Failed to get MFA status", LocaleJA: "二要素認証の状態の取得に失敗しました"},
+	"error.get_roles":                         {LocaleEN: "Failed to get roles", LocaleJA: "ロール一覧の取得に失敗しました"},
+	"error.get_sessions":                      {LocaleEN: "Failed to get sessions", LocaleJA: "セッション一覧の取得に失敗しました"},
+	"error.get_user_roles":                    {LocaleEN: "Failed to get user roles", LocaleJA: "ユーザーのロールの取得に失敗しました"},
+	"error.internal":                          {LocaleEN: "Internal Server Error", LocaleJA: "サーバー内部エラーが発生しました"},
+	"error.log_in":                            {LocaleEN: "Failed to log in", LocaleJA: "ログインに失敗しました"},
+	"error.mark_notification_as_read":         {LocaleEN: "Failed to mark notification as read", LocaleJA: "通知の既読化に失敗しました"},
+	"error.mark_notifications_as_read":        {LocaleEN: "Failed to mark notifications as read", LocaleJA: "通知の既読化に失敗しました"},
+	"error.refresh_token":                     {LocaleEN: "Failed to refresh token", LocaleJA: "トークンの更新に失敗しました"},
+	"error.regenerate_recovery_codes":         {LocaleEN: "Failed to regenerate recovery codes", LocaleJA: "リカバリーコードの再生成に失敗しました"},
+	"error.register_user":                     {LocaleEN: "Failed to register user", LocaleJA: "ユーザー登録に失敗しました"},
+	"error.remove_team_member":                {LocaleEN: "Failed to remove team member", LocaleJA: "チームメンバーの削除に失敗しました"},
+	"error.retrieve_customer":                 {LocaleEN: "Failed to retrieve customer", LocaleJA: "顧客の取得に失敗しました"},
+	"error.retrieve_customers":                {LocaleEN: "Failed to retrieve customers", LocaleJA: "顧客一覧の取得に失敗しました"},
+	"error.retrieve_hello_world_message":      {LocaleEN: "Failed to retrieve hello world message", LocaleJA: "Hello Worldメッセージの取得に失敗しました"},
+	"error.retrieve_hello_world_messages":     {LocaleEN: "Failed to retrieve hello world messages", LocaleJA: "Hello Worldメッセージ一覧の取得に失敗しました"},
+	"error.retrieve_invitations":              {LocaleEN: "Failed to retrieve invitations", LocaleJA: "招待一覧の取得に失敗しました"},
+	"error.retrieve_mail":                     {LocaleEN: "Failed to retrieve mail", LocaleJA: "メールの取得に失敗しました"},
+	"error.retrieve_mail_thread":              {LocaleEN: "Failed to retrieve mail thread", LocaleJA: "メールスレッドの取得に失敗しました"},
+	"error.retrieve_mails":                    {LocaleEN: "Failed to retrieve mails", LocaleJA: "メール一覧の取得に失敗しました"},
+	"error.retrieve_notification_preferences": {LocaleEN: "Failed to retrieve notification preferences", LocaleJA: "通知設定の取得に失敗しました"},
+	"error.retrieve_notifications":            {LocaleEN: "Failed to retrieve notifications", LocaleJA: "通知一覧の取得に失敗しました"},
+	"error.retrieve_profile":                  {LocaleEN: "Failed to retrieve profile", LocaleJA: "プロフィールの取得に失敗しました"},
+	"error.retrieve_sale":                     {LocaleEN: "Failed to retrieve sale", LocaleJA: "売上の取得に失敗しました"},
+	"error.retrieve_sales":                    {LocaleEN: "Failed to retrieve sales", LocaleJA: "売上一覧の取得に失敗しました"},
+	"error.retrieve_stats":                    {LocaleEN: "Failed to retrieve stats", LocaleJA: "統計の取得に失敗しました"},
+	"error.retrieve_team":                     {LocaleEN: "Failed to retrieve team", LocaleJA: "チームの取得に失敗しました"},
+	"error.retrieve_team_members":             {LocaleEN: "Failed to retrieve team members", LocaleJA: "チームメンバーの取得に失敗しました"},
+	"error.retrieve_teams":                    {LocaleEN: "Failed to retrieve teams", LocaleJA: "チーム一覧の取得に失敗しました"},
+	"error.revoke_api_key":                    {LocaleEN: "Failed to revoke API key", LocaleJA: "APIキーの無効化に失敗しました"},
+	"error.revoke_invitation":                 {LocaleEN: "Failed to revoke invitation", LocaleJA: "招待の取り消しに失敗しました"},
+	"error.revoke_role":                       {LocaleEN: "Failed to revoke role", LocaleJA: "ロールの剥奪に失敗しました"},
+	"error.revoke_session":                    {LocaleEN: "Failed to revoke session", LocaleJA: "セッションの無効化に失敗しました"},
+	"error.revoke_sessions":                   {LocaleEN: "Failed to revoke sessions", LocaleJA: "セッションの無効化に失敗しました"},
+	"error.send_invitation":                   {LocaleEN: "Failed to send invitation", LocaleJA: "招待の送信に失敗しました"},
+	"error.update_customer":                   {LocaleEN: "Failed to update customer", LocaleJA: "顧客の更新に失敗しました"},
+	"error.update_hello_world_message":        {LocaleEN: "Failed to update hello world message", LocaleJA: "Hello Worldメッセージの更新に失敗しました"},
+	"error.update_mail":                       {LocaleEN: "Failed to update mail", LocaleJA: "メールの更新に失敗しました"},
+	"error.update_notification_preferences":   {LocaleEN: "Failed to update notification preferences", LocaleJA: "通知設定の更新に失敗しました"},
+	"error.update_profile":                    {LocaleEN: "Failed to update profile", LocaleJA: "プロフィールの更新に失敗しました"},
+	"error.update_sale":                       {LocaleEN: "Failed to update sale", LocaleJA: "売上の更新に失敗しました"},
+	"error.update_team":                       {LocaleEN: "Failed to update team", LocaleJA: "チームの更新に失敗しました"},
+	"error.update_team_member":                {LocaleEN: "Failed to update team member", LocaleJA: "チームメンバーの更新に失敗しました"},
+	"error.verify_totp":                       {LocaleEN: "Failed to verify TOTP", LocaleJA: "TOTPの検証に失敗しました"},
+
+	// サービスのエラー（services.Error の Detail）
+	"api_key.limit_reached":        {LocaleEN: "API key limit reached; revoke an existing key first", LocaleJA: "APIキーの上限に達しました。既存のキーを無効化してください"},
+	"api_key.not_found":            {LocaleEN: "API key not found", LocaleJA: "APIキーが見つかりません"},
+	"api_key.prefix_taken":         {LocaleEN: "API key prefix already exists", LocaleJA: "APIキーのプレフィックスが既に存在します"},
+	"auth.invalid_credentials":     {LocaleEN: "Invalid email or password", LocaleJA: "メールアドレスまたはパスワードが正しくありません"},
+	"auth.invalid_token":           {LocaleEN: "Invalid or expired token", LocaleJA: "トークンが無効か期限切れです"},
+	"customer.email_taken":         {LocaleEN: "Customer email is already registered", LocaleJA: "この顧客のメールアドレスは既に登録されています"},
+	"customer.not_found":           {LocaleEN: "Customer not found", LocaleJA: "顧客が見つかりません"},
+	"database.unavailable":         {LocaleEN: "Database is not available", LocaleJA: "データベースを利用できません"},
+	"hello_world.not_found":        {LocaleEN: "Hello World message not found", LocaleJA: "Hello Worldメッセージが見つかりません"},
+	"invitation.email_mismatch":    {LocaleEN: "Invitation was sent to a different email address", LocaleJA: "招待は別のメールアドレスに送信されています"},
+	"invitation.expired":           {LocaleEN: "Invitation has expired", LocaleJA: "招待の有効期限が切れています"},
+	"invitation.not_found":         {LocaleEN: "Invitation not found", LocaleJA: "招待が見つかりません"},
+	"mail.message_id_taken":        {LocaleEN: "Mail with this Message-ID already exists", LocaleJA: "このMessage-IDのメールは既に存在します"},
+	"mail.not_found":               {LocaleEN: "Mail not found", LocaleJA: "メールが見つかりません"},
+	"mfa.already_enabled":          {LocaleEN: "Two-factor authentication is already enabled", LocaleJA: "二要素認証は既に有効です"},
+	"mfa.not_enabled":              {LocaleEN: "Two-factor authentication is not enabled", LocaleJA: "二要素認証は有効になっていません"},
+	"mfa.too_many_attempts":        {LocaleEN: "Too many attempts; log in again", LocaleJA: "試行回数が上限に達しました。再度ログインしてください"},
+	"mfa.too_many_failures":        {LocaleEN: "Too many failed attempts; try again later", LocaleJA: "認証コードの誤りが多すぎます。しばらくしてから再度お試しください"},
+	"mfa.totp_not_enrolled":        {LocaleEN: "TOTP is not enrolled", LocaleJA: "TOTPが登録されていません"},
+	"notification.not_found":       {LocaleEN: "Notification not found", LocaleJA: "通知が見つかりません"},
+	"profile.email_change_invalid": {LocaleEN: "Confirmation token is invalid or has expired", LocaleJA: "確認トークンが無効か期限切れです"},
+	"profile.last_owner":           {LocaleEN: "The last owner cannot delete their account; assign the owner role to another user first", LocaleJA: "最後のオーナーはアカウントを削除できません。先に他のユーザーにオーナーのロールを付与してください"},
+	"profile.sole_team_owner":      {LocaleEN: "Transfer ownership of the teams you solely own, or delete them, before deleting your account", LocaleJA: "アカウントを削除する前に、唯一のオーナーであるチームのオーナーを他のメンバーに移すか、チームを削除してください"},
+	"role.last_owner":              {LocaleEN: "Cannot revoke the last owner", LocaleJA: "最後のオーナーのロールは剥奪できません"},
+	"role.not_found":               {LocaleEN: "Role not found", LocaleJA: "ロールが見つかりません"},
+	"sale.not_found":               {LocaleEN: "Sale not found", LocaleJA: "売上が見つかりません"},
+	"sale.not_refundable":          {LocaleEN: "Only paid sales can be refunded", LocaleJA: "返金できるのは支払済みの売上のみです"},
+	"session.not_found":            {LocaleEN: "Session not found", LocaleJA: "セッションが見つかりません"},
+	"team.already_member":          {LocaleEN: "User is already a team member", LocaleJA: "ユーザーは既にチームのメンバーです"},
+	"team.last_owner":              {LocaleEN: "Team must have at least one owner", LocaleJA: "チームには少なくとも1人のオーナーが必要です"},
+	"team.member_not_found":        {LocaleEN: "Team member not found", LocaleJA: "チームメンバーが見つかりません"},
+	"team.not_found":               {LocaleEN: "Team not found", LocaleJA: "チームが見つかりません"},
+	"team.owner_required":          {LocaleEN: "Only team owners can perform this action", LocaleJA: "この操作はチームのオーナーのみ実行できます"},
+	"user.email_taken":             {LocaleEN: "Email is already registered", LocaleJA: "このメールアドレスは既に登録されています"},
+	"user.not_found":               {LocaleEN: "User not found", LocaleJA: "ユーザーが見つかりません"},
+	"user.username_taken":          {LocaleEN: "Username is already taken", LocaleJA: "このユーザー名は既に使用されています"},
+
+	// バリデーション（{label} はフィールドの表示名）
+	"api_key.unknown_scope":              {LocaleEN: "Unknown or unavailable scope: {scope}", LocaleJA: "未知または利用できないスコープです: {scope}"},
+	"pagination.after_with_offset":       {LocaleEN: "after cannot be combined with offset", LocaleJA: "afterとoffsetは同時に指定できません"},
+	"pagination.invalid_cursor":          {LocaleEN: "Invalid cursor", LocaleJA: "カーソルが不正です"},
+	"pagination.invalid_limit":           {LocaleEN: "limit must be between 1 and {max}", LocaleJA: "limitには1から{max}までの整数を指定してください"},
+	"pagination.invalid_offset":          {LocaleEN: "offset must be a non-negative integer", LocaleJA: "offsetには0以上の整数を指定してください"},
+	"profile.password_incorrect":         {LocaleEN: "Password is incorrect", LocaleJA: "パスワードが正しくありません"},
+	"query.cursor_requires_default_sort": {LocaleEN: "after cursor can only be used with the default sort order", LocaleJA: "afterカーソルは既定の並び順でのみ使用できます"},
+	"query.invalid":                      {LocaleEN: "Invalid query parameters", LocaleJA: "クエリパラメータが不正です"},
+	"query.invalid_filter_value":         {LocaleEN: "Invalid value for {field}", LocaleJA: "{field}の値が不正です"},
+	"query.search_unsupported":           {LocaleEN: "Full-text search is not supported for this resource", LocaleJA: "このリソースは全文検索に対応していません"},
+	"query.unknown_filter":               {LocaleEN: "Unknown filter field: {field}", LocaleJA: "未知の絞り込みフィールドです: {field}"},
+	"query.unknown_sort":                 {LocaleEN: "Unknown sort field: {name}", LocaleJA: "未知の並び替えフィールドです: {name}"},
+	"sale.invalid_transition":            {LocaleEN: "Cannot change status from {from} to {to}; only paid sales can be refunded", LocaleJA: "ステータスを{from}から{to}に変更できません。返金できるのは支払済みの売上のみです"},
+	"sale.no_longer_refundable":          {LocaleEN: "Sale is no longer paid and cannot be refunded", LocaleJA: "売上が支払済みではなくなったため返金できません"},
+	"stats.start_after_end":              {LocaleEN: "Start must be before end", LocaleJA: "開始日時は終了日時より前を指定してください"},
+	"stats.too_many_buckets":             {LocaleEN: "Range must contain at most {max} buckets; use a longer period", LocaleJA: "集計期間の区切りは{max}件以内にしてください。より長い集計単位を指定してください"},
+	"validation.currency_format":         {LocaleEN: "{label} must be an ISO 4217 code (e.g. USD)", LocaleJA: "{label}にはISO 4217の通貨コード（例: USD）を指定してください"},
+	"validation.datetime":                {LocaleEN: "{label} must be RFC3339 or YYYY-MM-DD", LocaleJA: "{label}はRFC3339またはYYYY-MM-DD形式で指定してください"},
+	"validation.duplicate_preference":    {LocaleEN: "Duplicate preference for {type} via {channel}", LocaleJA: "{type}の{channel}の設定が重複しています"},
+	"validation.future":                  {LocaleEN: "{label} must be in the future", LocaleJA: "{label}には未来の日時を指定してください"},
+	"validation.http_url":                {LocaleEN: "{label} must be an absolute http or https URL", LocaleJA: "{label}にはhttpまたはhttpsの絶対URLを指定してください"},
+	"validation.invalid":                 {LocaleEN: "{label} is invalid", LocaleJA: "{label}が不正です"},
+	"validation.invalid_merge_patch":     {LocaleEN: "Invalid merge patch document", LocaleJA: "マージパッチのドキュメントが不正です"},
+	"validation.one_of":                  {LocaleEN: "{label} must be one of {values}", LocaleJA: "{label}は{values}のいずれかを指定してください"},
+	"validation.required":                {LocaleEN: "{label} is required", LocaleJA: "{label}は必須です"},
+	"validation.room_format":             {LocaleEN: "{label} must be a lowercase kind optionally followed by :id (e.g. customer:42)", LocaleJA: "{label}は英小文字の種別と任意の :id（例: customer:42）で指定してください"},
+	"validation.scopes_required":         {LocaleEN: "At least one scope is required", LocaleJA: "スコープを1つ以上指定してください"},
+	"validation.timezone":                {LocaleEN: "{label} must be an IANA time zone name (e.g. Asia/Tokyo)", LocaleJA: "{label}にはIANAのタイムゾーン名（例: Asia/Tokyo）を指定してください"},
+	"validation.too_long":                {LocaleEN: "{label} must be at most {max} characters", LocaleJA: "{label}は{max}文字以内で入力してください"},
+	"validation.too_long_bytes":          {LocaleEN: "{label} must be at most {max} bytes", LocaleJA: "{label}は{max}バイト以内で入力してください"},
+	"validation.too_short":               {LocaleEN: "{label} must be at least {min} characters", LocaleJA: "{label}は{min}文字以上で入力してください"},
+	"validation.too_small":               {LocaleEN: "{label} must be at least {min}", LocaleJA: "{label}は{min}以上を指定してください"},
+	"validation.type_array":              {LocaleEN: "{label} must be an array", LocaleJA: "{label}には配列を指定してください"},
+	"validation.type_boolean":            {LocaleEN: "{label} must be a boolean", LocaleJA: "{label}には真偽値を指定してください"},
+	"validation.type_number":             {LocaleEN: "{label} must be a number", LocaleJA: "{label}には数値を指定してください"},
+	"validation.type_object":             {LocaleEN: "{label} must be an object", LocaleJA: "{label}にはオブジェクトを指定してください"},
+	"validation.type_string":             {LocaleEN: "{label} must be a string", LocaleJA: "{label}には文字列を指定してください"},
+	"validation.unknown_field":           {LocaleEN: "Unknown field \"{field}\"", LocaleJA: "未知のフィールド \"{field}\" が指定されました"},
+	"validation.username_format":         {LocaleEN: "{label} may only contain letters, digits, underscores and hyphens", LocaleJA: "{label}には英小文字・数字・アンダースコア・ハイフンのみ使用できます"},
+}
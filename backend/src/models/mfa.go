@@ -0,0 +1,70 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// TOTPCredential ユーザーのTOTP登録情報
+type TOTPCredential struct {
+	UserID       int
+	Secret       string
+	EnabledAt    *time.Time
+	LastUsedStep int64 // 最後に受理したコードの時間ステップ（同じコードの再利用防止）
+}
+
+// Enabled 登録確認済みか判定
+func (c *TOTPCredential) Enabled() bool {
+	return c.EnabledAt != nil
+}
+
+// TOTPEnrollment TOTP登録開始のレスポンス
+type TOTPEnrollment struct {
+	Secret     string `json:"secret"`
+	OTPAuthURI string `json:"otpauth_uri"`
+	QRCode     string `json:"qr_code"` // otpauth URI の QR コード（data:image/png;base64,...）
+}
+
+// MFAStatus 二要素認証の設定状況
+type MFAStatus struct {
+	TOTPEnabled            bool `json:"totp_enabled"`
+	RecoveryCodesRemaining int  `json:"recovery_codes_remaining"`
+}
+
+// RecoveryCodesResponse 発行したリカバリーコード（この応答でのみ平文を返す）
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// MFAChallengeResponse ログイン1段階目のレスポンス（二要素認証が必要な場合）
+type MFAChallengeResponse struct {
+	MFARequired bool   `json:"mfa_required"`
+	MFAToken    string `json:"mfa_token"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
+
+// MFACodeRequest 認証コードのリクエスト（TOTPコードまたはリカバリーコード）
+type MFACodeRequest struct {
+	Code string `json:"code" example:"123456"`
+}
+
+// Validate 認証コードのバリデーション
+func (r *MFACodeRequest) Validate() error {
+	v := NewValidator()
+	v.String("code", strings.TrimSpace(r.Code), Required())
+	return v.Err()
+}
+
+// MFALoginRequest ログイン2段階目のリクエスト
+type MFALoginRequest struct {
+	MFAToken string `json:"mfa_token"`
+	Code     string `json:"code" example:"123456"`
+}
+
+// Validate ログイン2段階目のバリデーション
+func (r *MFALoginRequest) Validate() error {
+	v := NewValidator()
+	v.String("mfa_token", strings.TrimSpace(r.MFAToken), Required())
+	v.String("code", strings.TrimSpace(r.Code), Required())
+	return v.Err()
+}
@@ -0,0 +1,150 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// NotificationType 通知の種類（通知の元になったドメインイベント）
+type NotificationType string
+
+const (
+	NotificationTypeCustomerCreated NotificationType = "customer.created"
+	NotificationTypeMailReceived    NotificationType = "mail.received"
+)
+
+// NotificationTypes 有効な通知の種類の一覧
+var NotificationTypes = []NotificationType{NotificationTypeCustomerCreated, NotificationTypeMailReceived}
+
+// NotificationSender 通知の送信者（ダッシュボードの Notification.sender に対応）
+type NotificationSender struct {
+	Name   string  `json:"name" example:"Jordan Brown"`
+	Email  string  `json:"email,omitempty" example:"jordan.brown@example.com"`
+	Avatar *Avatar `json:"avatar,omitempty"`
+}
+
+// Notification 通知構造体（ダッシュボードの Notification 型に対応）
+//
+// 通知は宛先ユーザーごとに保存し、ReadAt が未設定の間は未読です。
+type Notification struct {
+	ID      int                `json:"id"`
+	UserID  int                `json:"-"` // 宛先ユーザー
+	Type    NotificationType   `json:"type" example:"mail.received"`
+	Unread  bool               `json:"unread"`
+	Sender  NotificationSender `json:"sender"`
+	Body    string             `json:"body" example:"sent you a message: Project Phoenix - Sprint 3 Update"`
+	Link    string             `json:"link,omitempty" example:"/inbox?mail=1"`
+	Desktop bool               `json:"desktop,omitempty"` // デスクトップ通知として表示するか（notification.created イベントでのみ設定）
+	ReadAt  *time.Time         `json:"read_at,omitempty"`
+	Date    time.Time          `json:"date"`
+}
+
+// NotificationReadAllResponse 一括既読レスポンス構造体
+type NotificationReadAllResponse struct {
+	Updated int `json:"updated" example:"3"`
+}
+
+// NotificationUnreadCountResponse 未読通知件数レスポンス構造体
+type NotificationUnreadCountResponse struct {
+	Unread int `json:"unread" example:"3"`
+}
+
+// NotificationChannel 通知の配信チャネル
+type NotificationChannel string
+
+const (
+	NotificationChannelInApp   NotificationChannel = "in_app"  // アプリ内通知（通知一覧・リアルタイム配信）
+	NotificationChannelEmail   NotificationChannel = "email"   // 宛先ユーザーのメールアドレスへのメール
+	NotificationChannelDesktop NotificationChannel = "desktop" // ブラウザのデスクトップ通知（notification.created イベントに desktop を付けて配信）
+)
+
+// NotificationChannels 有効な配信チャネルの一覧
+// 設定画面の週次ダイジェストなど通知の種類によらない設定は NotificationSettings で扱います
+var NotificationChannels = []NotificationChannel{NotificationChannelInApp, NotificationChannelEmail, NotificationChannelDesktop}
+
+// NotificationPreference 通知の種類・チャネルごとの受信設定
+type NotificationPreference struct {
+	UserID  int                 `json:"-"`
+	Type    NotificationType    `json:"type" example:"mail.received"`
+	Channel NotificationChannel `json:"channel" example:"email"`
+	Enabled bool                `json:"enabled" example:"true"`
+}
+
+// DefaultNotificationPreference 設定がない組み合わせの受信有無
+// アプリ内通知は全て受信し、メールは受信したメールの通知のみ送信します（デスクトップ通知は全て無効）
+func DefaultNotificationPreference(notificationType NotificationType, channel NotificationChannel) bool {
+	switch channel {
+	case NotificationChannelInApp:
+		return true
+	case NotificationChannelEmail:
+		return notificationType == NotificationTypeMailReceived
+	}
+	return false
+}
+
+// NotificationSettings 通知の種類によらないユーザーごとの受信設定（設定画面のアカウントの更新情報）
+type NotificationSettings struct {
+	WeeklyDigest     bool `json:"weekly_digest" example:"false"`    // 週次ダイジェストのメール
+	ProductUpdates   bool `json:"product_updates" example:"true"`   // 新機能・改善のお知らせ
+	ImportantUpdates bool `json:"important_updates" example:"true"` // セキュリティ・利用規約などの重要なお知らせ
+}
+
+// DefaultNotificationSettings 保存されていないユーザーの受信設定（週次ダイジェストのみ無効）
+func DefaultNotificationSettings() NotificationSettings {
+	return NotificationSettings{WeeklyDigest: false, ProductUpdates: true, ImportantUpdates: true}
+}
+
+// NotificationPreferencesResponse 通知設定レスポンス構造体
+type NotificationPreferencesResponse struct {
+	Preferences []NotificationPreference `json:"preferences"`
+	NotificationSettings
+}
+
+// NotificationPreferencesRequest 通知設定更新リクエスト構造体
+//
+// 設定は全体を置き換え、含まれない組み合わせ・項目は既定値に戻ります。
+type NotificationPreferencesRequest struct {
+	Preferences      []NotificationPreference `json:"preferences"`
+	WeeklyDigest     *bool                    `json:"weekly_digest,omitempty" example:"false"`
+	ProductUpdates   *bool                    `json:"product_updates,omitempty" example:"true"`
+	ImportantUpdates *bool                    `json:"important_updates,omitempty" example:"true"`
+}
+
+// Settings 含まれない項目を既定値にした、通知の種類によらない受信設定
+func (r *NotificationPreferencesRequest) Settings() NotificationSettings {
+	settings := DefaultNotificationSettings()
+	if r.WeeklyDigest != nil {
+		settings.WeeklyDigest = *r.WeeklyDigest
+	}
+	if r.ProductUpdates != nil {
+		settings.ProductUpdates = *r.ProductUpdates
+	}
+	if r.ImportantUpdates != nil {
+		settings.ImportantUpdates = *r.ImportantUpdates
+	}
+	return settings
+}
+
+// Validate 通知設定更新リクエストのバリデーション（全ての項目のエラーをまとめて返す）
+func (r *NotificationPreferencesRequest) Validate() error {
+	v := NewValidator()
+	if !v.Present("preferences", r.Preferences != nil) {
+		return v.Err()
+	}
+
+	seen := make(map[NotificationPreference]bool, len(r.Preferences))
+	for i, preference := range r.Preferences {
+		field := fmt.Sprintf("preferences[%d]", i)
+		v.Nested(field, func(v *Validator) {
+			v.String("type", string(preference.Type), Required(), OneOf(NotificationTypes...))
+			v.String("channel", string(preference.Channel), Required(), OneOf(NotificationChannels...))
+		})
+
+		key := NotificationPreference{Type: preference.Type, Channel: preference.Channel}
+		if !v.Has(field+".type") && !v.Has(field+".channel") {
+			v.Check(field, !seen[key], ValidationCodeInvalid, "validation.duplicate_preference", map[string]interface{}{"type": preference.Type, "channel": preference.Channel})
+		}
+		seen[key] = true
+	}
+	return v.Err()
+}
@@ -0,0 +1,70 @@
+package models
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// ProblemContentType RFC 7807 Problem Details のメディアタイプ
+const ProblemContentType = "application/problem+json"
+
+// ProblemTypeBaseURI 問題の種類（type）のURIの接頭辞（後ろにエラーコードが続く）
+const ProblemTypeBaseURI = "urn:problem-type:"
+
+// ProblemDetails RFC 7807 Problem Details 形式のエラーレスポンス
+// Accept: application/problem+json を指定したクライアントに ErrorResponse の代わりに返します
+type ProblemDetails struct {
+	Type      string       `json:"type" example:"urn:problem-type:not_found"`
+	Title     string       `json:"title" example:"Not Found"`
+	Status    int          `json:"status" example:"404"`
+	Detail    string       `json:"detail,omitempty" example:"Customer not found"`
+	Instance  string       `json:"instance,omitempty" example:"/api/customers/42"`
+	RequestID string       `json:"request_id,omitempty" example:"host/abcdef-000001"`
+	Errors    []FieldError `json:"errors,omitempty"` // validation_error のフィールドごとの詳細
+}
+
+// problemWriter エラーレスポンスを Problem Details 形式で送信するレスポンスライター
+type problemWriter struct {
+	http.ResponseWriter
+	instance  string
+	requestID string
+}
+
+// NewProblemWriter エラーレスポンスを Problem Details 形式で送信するレスポンスライターを作成
+// instance にはリクエストのパス、requestID にはリクエストIDを指定します
+func NewProblemWriter(w http.ResponseWriter, instance, requestID string) http.ResponseWriter {
+	return &problemWriter{ResponseWriter: w, instance: instance, requestID: requestID}
+}
+
+// Unwrap 元のレスポンスライターを返す（http.ResponseController で Flush などを使用するため）
+func (w *problemWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// NewProblemDetails エラーレスポンスを Problem Details 形式に変換
+func NewProblemDetails(statusCode int, response *ErrorResponse, instance, requestID string) *ProblemDetails {
+	return &ProblemDetails{
+		Type:      ProblemTypeBaseURI + response.Error,
+		Title:     http.StatusText(statusCode),
+		Status:    statusCode,
+		Detail:    response.Message,
+		Instance:  instance,
+		RequestID: requestID,
+		Errors:    response.Errors,
+	}
+}
+
+// sendError エラーレスポンスを送信（NewProblemWriter のライターには Problem Details 形式）
+func sendError(w http.ResponseWriter, statusCode int, response *ErrorResponse) {
+	problem, ok := findWriter[*problemWriter](w)
+	if !ok {
+		SendJSONResponse(w, statusCode, response)
+		return
+	}
+
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.WriteHeader(statusCode)
+	if err := json.NewEncoder(w).Encode(NewProblemDetails(statusCode, response, problem.instance, problem.requestID)); err != nil {
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
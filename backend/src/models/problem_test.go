@@ -0,0 +1,70 @@
+package models
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestSendErrorResponseProblemDetails Problem Details 形式のエラーレスポンスのテスト
+func TestSendErrorResponseProblemDetails(t *testing.T) {
+	rr := httptest.NewRecorder()
+	w := NewProblemWriter(rr, "/api/customers/42", "req-1")
+
+	SendNotFoundError(w, "Customer not found")
+
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404, got %d", rr.Code)
+	}
+	if contentType := rr.Header().Get("Content-Type"); contentType != ProblemContentType {
+		t.Errorf("Content-Type = %q, want %q", contentType, ProblemContentType)
+	}
+
+	var problem ProblemDetails
+	if err := json.NewDecoder(rr.Body).Decode(&problem); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	want := ProblemDetails{
+		Type:      "urn:problem-type:not_found",
+		Title:     "Not Found",
+		Status:    http.StatusNotFound,
+		Detail:    "Customer not found",
+		Instance:  "/api/customers/42",
+		RequestID: "req-1",
+	}
+	if problem.Type != want.Type || problem.Title != want.Title || problem.Status != want.Status ||
+		problem.Detail != want.Detail || problem.Instance != want.Instance || problem.RequestID != want.RequestID {
+		t.Errorf("problem = %+v, want %+v", problem, want)
+	}
+}
+
+// TestSendFieldValidationErrorProblemDetails フィールドの詳細を含む Problem Details のテスト
+func TestSendFieldValidationErrorProblemDetails(t *testing.T) {
+	rr := httptest.NewRecorder()
+	w := NewProblemWriter(rr, "/api/customers", "")
+
+	v := NewValidator()
+	v.String("name", "", Required())
+	v.String("email", "x", Email())
+	SendFieldValidationError(w, v.Err().(*ValidationError))
+
+	var problem ProblemDetails
+	if err := json.NewDecoder(rr.Body).Decode(&problem); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if rr.Code != http.StatusBadRequest || problem.Type != "urn:problem-type:validation_error" || len(problem.Errors) != 2 {
+		t.Errorf("problem = %d %+v", rr.Code, problem)
+	}
+}
+
+// TestSendSuccessResponseWithProblemWriter 成功レスポンスは Problem Details の対象外であることのテスト
+func TestSendSuccessResponseWithProblemWriter(t *testing.T) {
+	rr := httptest.NewRecorder()
+
+	SendSuccessResponse(NewProblemWriter(rr, "/", ""), "OK", nil)
+
+	if contentType := rr.Header().Get("Content-Type"); contentType != "application/json" {
+		t.Errorf("Content-Type = %q, want application/json", contentType)
+	}
+}
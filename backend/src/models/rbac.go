@@ -0,0 +1,40 @@
+package models
+
+import "slices"
+
+// ロール名
+const (
+	RoleOwner  = "owner"
+	RoleMember = "member"
+)
+
+// 権限名（"<リソース>:<操作>" 形式）
+const (
+	PermissionMessagesCreate  = "messages:create"
+	PermissionMessagesUpdate  = "messages:update"
+	PermissionMessagesDelete  = "messages:delete"
+	PermissionRolesManage     = "roles:manage"
+	PermissionCustomersWrite  = "customers:write"
+	PermissionCustomersDelete = "customers:delete"
+	PermissionMailsWrite      = "mails:write"
+	PermissionSalesWrite      = "sales:write"
+	PermissionSalesRefund     = "sales:refund"
+)
+
+// Role ロール構造体
+type Role struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Permissions []string `json:"permissions"`
+}
+
+// UserRoles ユーザーに付与されたロール構造体
+type UserRoles struct {
+	UserID int      `json:"user_id"`
+	Roles  []string `json:"roles"`
+}
+
+// HasPermission ユーザーが権限を持つか判定
+func (u *User) HasPermission(permission string) bool {
+	return slices.Contains(u.Permissions, permission)
+}
@@ -0,0 +1,64 @@
+package models
+
+import (
+	"encoding/json"
+	"regexp"
+)
+
+// RealtimeMessageType WebSocket で送受信するメッセージの種類
+//
+// サーバーからはこのほかに message.created などのイベント（EventType）も同じ形式で送信します。
+type RealtimeMessageType string
+
+const (
+	// クライアントから送信
+	RealtimeRoomJoin    RealtimeMessageType = "room.join"
+	RealtimeRoomLeave   RealtimeMessageType = "room.leave"
+	RealtimeTypingStart RealtimeMessageType = "typing.start"
+	RealtimeTypingStop  RealtimeMessageType = "typing.stop"
+
+	// サーバーから送信（presence.joined などのルーム内のイベントは EventType を使用）
+	RealtimePresenceState RealtimeMessageType = "presence.state"
+	RealtimeError         RealtimeMessageType = "error"
+)
+
+// MaxRealtimeRoomLength ルーム名の最大文字数
+const MaxRealtimeRoomLength = 100
+
+// realtimeRoomPattern ルーム名の形式（"種類" または "種類:ID"、例: inbox, customer:42）
+var realtimeRoomPattern = regexp.MustCompile(`^[a-z][a-z0-9_-]*(:[A-Za-z0-9_-]+)?$`)
+
+// RealtimeMessage WebSocket で送受信するメッセージ（JSONテキストフレーム1つに1件）
+type RealtimeMessage struct {
+	Type RealtimeMessageType `json:"type" example:"room.join"`
+	Room string              `json:"room,omitempty" example:"customer:42"`
+	Data json.RawMessage     `json:"data,omitempty" swaggertype:"object"`
+}
+
+// RealtimeUser ルームに参加しているユーザー
+type RealtimeUser struct {
+	ID   int    `json:"id" example:"1"`
+	Name string `json:"name" example:"Jordan Brown"`
+}
+
+// RealtimePresenceStateData presence.state のデータ（ルームの参加者一覧）
+type RealtimePresenceStateData struct {
+	Users []RealtimeUser `json:"users"`
+}
+
+// RealtimeErrorData error のデータ
+type RealtimeErrorData struct {
+	Error   string `json:"error" example:"validation_error"`
+	Message string `json:"message" example:"Not joined to this room"`
+}
+
+// ValidateRealtimeRoom ルーム名を検証
+func ValidateRealtimeRoom(room string) error {
+	if room == "" {
+		return NewValidationError("room", ValidationCodeRequired, "validation.required", nil)
+	}
+	if len(room) > MaxRealtimeRoomLength || !realtimeRoomPattern.MatchString(room) {
+		return NewValidationError("room", ValidationCodeInvalid, "validation.room_format", nil)
+	}
+	return nil
+}
@@ -16,15 +16,26 @@ type BaseResponse struct {
 // SuccessResponse 成功レスポンス構造体
 type SuccessResponse struct {
 	BaseResponse
-	Data interface{} `json:"data,omitempty"`
+	Data       interface{} `json:"data,omitempty"`
+	Pagination *Pagination `json:"pagination,omitempty"`
+}
+
+// Pagination ページネーションメタデータ構造体
+type Pagination struct {
+	Total      int    `json:"total"`
+	Limit      int    `json:"limit"`
+	Offset     int    `json:"offset"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	HasMore    bool   `json:"has_more"`
 }
 
 // ErrorResponse エラーレスポンス構造体
 type ErrorResponse struct {
-	Status    string    `json:"status"`
-	Error     string    `json:"error"`
-	Message   string    `json:"message"`
-	Timestamp time.Time `json:"timestamp"`
+	Status    string       `json:"status"`
+	Error     string       `json:"error"`
+	Message   string       `json:"message"`
+	Errors    []FieldError `json:"errors,omitempty"` // validation_error のフィールドごとの詳細
+	Timestamp time.Time    `json:"timestamp"`
 }
 
 // NewSuccessResponse 成功レスポンスを新規作成
@@ -39,6 +50,13 @@ func NewSuccessResponse(message string, data interface{}) *SuccessResponse {
 	}
 }
 
+// NewPaginatedResponse ページネーション付き成功レスポンスを新規作成
+func NewPaginatedResponse(message string, data interface{}, pagination *Pagination) *SuccessResponse {
+	response := NewSuccessResponse(message, data)
+	response.Pagination = pagination
+	return response
+}
+
 // NewErrorResponse エラーレスポンスを新規作成
 func NewErrorResponse(errorType, message string) *ErrorResponse {
 	return &ErrorResponse{
@@ -49,6 +67,21 @@ func NewErrorResponse(errorType, message string) *ErrorResponse {
 	}
 }
 
+// findWriter レスポンスライターを Unwrap で辿り、T のライターを探す（ミドルウェアが重ねたライターの設定を参照するため）
+func findWriter[T http.ResponseWriter](w http.ResponseWriter) (T, bool) {
+	for {
+		if writer, ok := w.(T); ok {
+			return writer, true
+		}
+		unwrapper, ok := w.(interface{ Unwrap() http.ResponseWriter })
+		if !ok {
+			var zero T
+			return zero, false
+		}
+		w = unwrapper.Unwrap()
+	}
+}
+
 // SendJSONResponse JSONレスポンスを送信
 func SendJSONResponse(w http.ResponseWriter, statusCode int, data interface{}) {
 	w.Header().Set("Content-Type", "application/json")
@@ -59,16 +92,35 @@ func SendJSONResponse(w http.ResponseWriter, statusCode int, data interface{}) {
 	}
 }
 
+// 以下の Send* の message はメッセージカタログのキーで、レスポンスライターの言語（NewLocaleWriter）のメッセージに変換して送信します
+// カタログにない文字列はそのまま送信します
+
 // SendSuccessResponse 成功レスポンスを送信
 func SendSuccessResponse(w http.ResponseWriter, message string, data interface{}) {
-	response := NewSuccessResponse(message, data)
+	response := NewSuccessResponse(Translate(LocaleOf(w), message, nil), data)
+	SendJSONResponse(w, http.StatusOK, response)
+}
+
+// SendCreatedResponse 作成成功レスポンス（201）を送信
+func SendCreatedResponse(w http.ResponseWriter, message string, data interface{}) {
+	response := NewSuccessResponse(Translate(LocaleOf(w), message, nil), data)
+	SendJSONResponse(w, http.StatusCreated, response)
+}
+
+// SendPaginatedResponse ページネーション付き成功レスポンスを送信
+func SendPaginatedResponse(w http.ResponseWriter, message string, data interface{}, pagination *Pagination) {
+	response := NewPaginatedResponse(Translate(LocaleOf(w), message, nil), data, pagination)
 	SendJSONResponse(w, http.StatusOK, response)
 }
 
 // SendErrorResponse エラーレスポンスを送信
 func SendErrorResponse(w http.ResponseWriter, statusCode int, errorType, message string) {
-	response := NewErrorResponse(errorType, message)
-	SendJSONResponse(w, statusCode, response)
+	SendErrorResponseWithParams(w, statusCode, errorType, message, nil)
+}
+
+// SendErrorResponseWithParams メッセージの {name} を params の値に置き換えてエラーレスポンスを送信
+func SendErrorResponseWithParams(w http.ResponseWriter, statusCode int, errorType, message string, params map[string]interface{}) {
+	sendError(w, statusCode, NewErrorResponse(errorType, Translate(LocaleOf(w), message, params)))
 }
 
 // SendValidationError バリデーションエラーレスポンスを送信
@@ -76,6 +128,22 @@ func SendValidationError(w http.ResponseWriter, message string) {
 	SendErrorResponse(w, http.StatusBadRequest, "validation_error", message)
 }
 
+// SendFieldValidationError フィールドごとの詳細を含むバリデーションエラーレスポンスを送信
+// メッセージはレスポンスライターの言語で生成し直します
+func SendFieldValidationError(w http.ResponseWriter, err *ValidationError) {
+	err = err.Localize(LocaleOf(w))
+	response := NewErrorResponse("validation_error", err.Message)
+	response.Errors = err.Errors
+	if len(response.Errors) == 0 && err.Field != "" {
+		code := err.Code
+		if code == "" {
+			code = ValidationCodeInvalid
+		}
+		response.Errors = []FieldError{{Field: err.Field, Code: code, Message: err.Message}}
+	}
+	sendError(w, http.StatusBadRequest, response)
+}
+
 // SendNotFoundError リソース未発見エラーレスポンスを送信
 func SendNotFoundError(w http.ResponseWriter, message string) {
 	SendErrorResponse(w, http.StatusNotFound, "not_found", message)
@@ -90,3 +158,28 @@ func SendInternalError(w http.ResponseWriter, message string) {
 func SendDatabaseError(w http.ResponseWriter, message string) {
 	SendErrorResponse(w, http.StatusInternalServerError, "database_error", message)
 }
+
+// SendUnauthorizedError 認証エラーレスポンスを送信
+func SendUnauthorizedError(w http.ResponseWriter, message string) {
+	SendErrorResponse(w, http.StatusUnauthorized, "unauthorized", message)
+}
+
+// SendForbiddenError 権限不足エラーレスポンスを送信
+func SendForbiddenError(w http.ResponseWriter, message string) {
+	SendErrorResponse(w, http.StatusForbidden, "forbidden", message)
+}
+
+// SendPayloadTooLargeError リクエストボディの上限超過エラーレスポンスを送信
+func SendPayloadTooLargeError(w http.ResponseWriter, message string, params map[string]interface{}) {
+	SendErrorResponseWithParams(w, http.StatusRequestEntityTooLarge, "payload_too_large", message, params)
+}
+
+// SendUnsupportedMediaTypeError 未対応の Content-Type のエラーレスポンスを送信
+func SendUnsupportedMediaTypeError(w http.ResponseWriter, message string, params map[string]interface{}) {
+	SendErrorResponseWithParams(w, http.StatusUnsupportedMediaType, "unsupported_media_type", message, params)
+}
+
+// SendConflictError 競合エラーレスポンスを送信
+func SendConflictError(w http.ResponseWriter, message string) {
+	SendErrorResponse(w, http.StatusConflict, "conflict", message)
+}
@@ -0,0 +1,111 @@
+package models
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+// SaleStatus 売上の決済状態（ダッシュボードの Sale.status に対応）
+type SaleStatus string
+
+const (
+	SaleStatusPaid     SaleStatus = "paid"
+	SaleStatusFailed   SaleStatus = "failed"
+	SaleStatusRefunded SaleStatus = "refunded"
+)
+
+// SaleStatuses 有効な決済状態の一覧
+var SaleStatuses = []SaleStatus{SaleStatusPaid, SaleStatusFailed, SaleStatusRefunded}
+
+// saleTransitions 決済状態の遷移表（登録後に変更できるのは paid → refunded のみ）
+var saleTransitions = map[SaleStatus][]SaleStatus{
+	SaleStatusPaid: {SaleStatusRefunded},
+}
+
+// CanTransitionTo 決済状態を next に変更できるか判定
+func (s SaleStatus) CanTransitionTo(next SaleStatus) bool {
+	return slices.Contains(saleTransitions[s], next)
+}
+
+// DefaultCurrency 通貨の省略時の値
+const DefaultCurrency = "USD"
+
+// currencyPattern ISO 4217 の通貨コード（英大文字3文字）
+var currencyPattern = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// Sale 売上構造体（ダッシュボードの Sale 型に対応）
+//
+// 金額は浮動小数点の誤差を避けるため、通貨の最小単位（USD ならセント）の整数で扱います。
+type Sale struct {
+	ID         int        `json:"id"`
+	Date       time.Time  `json:"date"`
+	Status     SaleStatus `json:"status" example:"paid"`
+	Email      string     `json:"email" example:"james.anderson@example.com"`
+	Amount     int64      `json:"amount" example:"59400"` // 通貨の最小単位
+	Currency   string     `json:"currency" example:"USD"`
+	RefundedAt *time.Time `json:"refunded_at,omitempty"`
+	RefundedBy *int       `json:"refunded_by,omitempty" example:"1"` // 返金を実行したユーザーのID（ユーザー削除後は省略）
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+// SaleRequest 売上登録リクエスト構造体
+type SaleRequest struct {
+	Date     *time.Time `json:"date,omitempty"` // 省略時は登録日時
+	Status   SaleStatus `json:"status,omitempty" example:"paid"`
+	Email    string     `json:"email" example:"james.anderson@example.com"`
+	Amount   int64      `json:"amount" example:"59400"` // 通貨の最小単位
+	Currency string     `json:"currency,omitempty" example:"USD"`
+}
+
+// Validate 売上登録リクエストのバリデーション（全てのフィールドのエラーをまとめて返す）
+// email・currency を正規化し、status 省略時は paid、currency 省略時は USD を設定します
+// 返金済みの売上は登録できません（paid の売上を返金してください）
+func (r *SaleRequest) Validate() error {
+	v := NewValidator()
+	r.Email = NormalizeEmail(r.Email)
+	validateEmail(v, "email", r.Email)
+
+	if r.Status == "" {
+		r.Status = SaleStatusPaid
+	}
+	v.String("status", string(r.Status), OneOf(SaleStatusPaid, SaleStatusFailed))
+	v.Int("amount", r.Amount, Min(0))
+
+	r.Currency = strings.ToUpper(strings.TrimSpace(r.Currency))
+	if r.Currency == "" {
+		r.Currency = DefaultCurrency
+	}
+	v.String("currency", r.Currency, Matches(currencyPattern, "validation.currency_format"))
+	return v.Err()
+}
+
+// ParseCurrency 通貨コードを検証して英大文字に正規化（空の場合は USD）
+func ParseCurrency(raw string) (string, error) {
+	currency := strings.ToUpper(strings.TrimSpace(raw))
+	if currency == "" {
+		return DefaultCurrency, nil
+	}
+	if !currencyPattern.MatchString(currency) {
+		return "", NewValidationError("currency", ValidationCodeInvalid, "validation.currency_format", nil)
+	}
+	return currency, nil
+}
+
+// SaleStatusRequest 決済状態の変更リクエスト構造体
+type SaleStatusRequest struct {
+	Status SaleStatus `json:"status" example:"refunded"`
+}
+
+// ValidateTransition 現在の決済状態からの変更を検証
+func (r *SaleStatusRequest) ValidateTransition(current SaleStatus) error {
+	if !slices.Contains(SaleStatuses, r.Status) {
+		return NewValidationError("status", ValidationCodeInvalid, "validation.one_of", map[string]interface{}{"values": []string{string(SaleStatusPaid), string(SaleStatusFailed), string(SaleStatusRefunded)}})
+	}
+	if !current.CanTransitionTo(r.Status) {
+		return NewValidationError("status", ValidationCodeInvalid, "sale.invalid_transition", map[string]interface{}{"from": current, "to": r.Status})
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// ClientInfo ログイン・トークン再発行時のクライアント情報
+type ClientInfo struct {
+	UserAgent string
+	IP        string
+}
+
+// Session ログインセッション（リフレッシュトークンのファミリー）構造体
+type Session struct {
+	ID         string     `json:"id"`
+	UserID     int        `json:"-"`
+	Device     string     `json:"device"`
+	UserAgent  string     `json:"user_agent"`
+	IP         string     `json:"ip"`
+	Current    bool       `json:"current"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastSeenAt time.Time  `json:"last_seen_at"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	RevokedAt  *time.Time `json:"-"`
+}
+
+// Active セッションが失効・期限切れでないか判定
+func (s *Session) Active(now time.Time) bool {
+	return s.RevokedAt == nil && now.Before(s.ExpiresAt)
+}
+
+// RevokeSessionsResponse セッション一括失効のレスポンス
+type RevokeSessionsResponse struct {
+	Revoked int `json:"revoked"`
+}
+
+// DeviceFromUserAgent User-Agent から「ブラウザ on OS」形式の表示名を推定
+func DeviceFromUserAgent(userAgent string) string {
+	if strings.TrimSpace(userAgent) == "" {
+		return "Unknown device"
+	}
+
+	// 他ブラウザの名前を含む User-Agent があるため、判定順に意味があります
+	browser := "Unknown browser"
+	for _, b := range []struct{ token, name string }{
+		{"Edg/", "Edge"},
+		{"OPR/", "Opera"},
+		{"Firefox/", "Firefox"},
+		{"Chrome/", "Chrome"},
+		{"CriOS/", "Chrome"},
+		{"Safari/", "Safari"},
+		{"curl/", "curl"},
+	} {
+		if strings.Contains(userAgent, b.token) {
+			browser = b.name
+			break
+		}
+	}
+
+	os := ""
+	for _, o := range []struct{ token, name string }{
+		{"iPhone", "iOS"},
+		{"iPad", "iPadOS"},
+		{"Android", "Android"},
+		{"Windows", "Windows"},
+		{"Mac OS X", "macOS"},
+		{"CrOS", "ChromeOS"},
+		{"Linux", "Linux"},
+	} {
+		if strings.Contains(userAgent, o.token) {
+			os = o.name
+			break
+		}
+	}
+
+	if os == "" {
+		return browser
+	}
+	return browser + " on " + os
+}
@@ -0,0 +1,44 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+// TestDeviceFromUserAgent User-Agent からの端末名推定のテスト
+func TestDeviceFromUserAgent(t *testing.T) {
+	tests := []struct {
+		userAgent string
+		want      string
+	}{
+		{"Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36", "Chrome on macOS"},
+		{"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36 Edg/120.0.0.0", "Edge on Windows"},
+		{"Mozilla/5.0 (iPhone; CPU iPhone OS 17_0 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.0 Mobile/15E148 Safari/604.1", "Safari on iOS"},
+		{"Mozilla/5.0 (Linux; Android 14) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Mobile Safari/537.36", "Chrome on Android"},
+		{"Mozilla/5.0 (X11; Linux x86_64; rv:121.0) Gecko/20100101 Firefox/121.0", "Firefox on Linux"},
+		{"curl/8.4.0", "curl"},
+		{"", "Unknown device"},
+	}
+
+	for _, tt := range tests {
+		if got := DeviceFromUserAgent(tt.userAgent); got != tt.want {
+			t.Errorf("DeviceFromUserAgent(%q) = %q, want %q", tt.userAgent, got, tt.want)
+		}
+	}
+}
+
+// TestSessionActive セッションの有効判定テスト
+func TestSessionActive(t *testing.T) {
+	now := time.Now()
+	revokedAt := now.Add(-time.Minute)
+
+	if !(&Session{ExpiresAt: now.Add(time.Hour)}).Active(now) {
+		t.Error("有効なセッションが無効と判定された")
+	}
+	if (&Session{ExpiresAt: now.Add(-time.Second)}).Active(now) {
+		t.Error("期限切れのセッションが有効と判定された")
+	}
+	if (&Session{ExpiresAt: now.Add(time.Hour), RevokedAt: &revokedAt}).Active(now) {
+		t.Error("失効したセッションが有効と判定された")
+	}
+}
@@ -0,0 +1,240 @@
+package models
+
+import (
+	"math"
+	"net/url"
+	"time"
+)
+
+// StatsPeriod 集計の単位（ダッシュボードの Period 型に対応）
+type StatsPeriod string
+
+const (
+	StatsPeriodDaily   StatsPeriod = "daily"
+	StatsPeriodWeekly  StatsPeriod = "weekly"
+	StatsPeriodMonthly StatsPeriod = "monthly"
+)
+
+// 統計の集計期間の制限
+const (
+	DefaultStatsRangeDays = 14  // 期間省略時の日数（ダッシュボードの初期表示と同じ）
+	MaxStatsBuckets       = 366 // 1回の集計で返すバケット数の上限
+)
+
+// Unit PostgreSQL の date_trunc に渡す単位
+func (p StatsPeriod) Unit() string {
+	switch p {
+	case StatsPeriodWeekly:
+		return "week"
+	case StatsPeriodMonthly:
+		return "month"
+	default:
+		return "day"
+	}
+}
+
+// Truncate t を含むバケットの開始日時（loc の暦で日・週・月の初め、週は date_trunc と同じ月曜始まり）
+func (p StatsPeriod) Truncate(t time.Time, loc *time.Location) time.Time {
+	t = t.In(loc)
+	year, month, day := t.Date()
+	switch p {
+	case StatsPeriodWeekly:
+		day -= (int(t.Weekday()) + 6) % 7
+	case StatsPeriodMonthly:
+		day = 1
+	}
+	return time.Date(year, month, day, 0, 0, 0, 0, loc)
+}
+
+// Next 次のバケットの開始日時
+// 夏時間の切り替え日でも暦の上で1単位進めるため、経過時間ではなく日付から計算します
+func (p StatsPeriod) Next(start time.Time) time.Time {
+	year, month, day := start.Date()
+	switch p {
+	case StatsPeriodWeekly:
+		day += 7
+	case StatsPeriodMonthly:
+		month++
+	default:
+		day++
+	}
+	return time.Date(year, month, day, 0, 0, 0, 0, start.Location())
+}
+
+// StatsRange 集計期間（start 以上 end 未満、ダッシュボードの Range 型に対応）
+type StatsRange struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// StatsQuery 統計の集計条件
+type StatsQuery struct {
+	Period   StatsPeriod
+	Location *time.Location
+	Currency string // 集計対象の通貨（通貨の異なる売上は合算しない）
+	Range    StatsRange
+}
+
+// ParseStatsQuery クエリパラメータから集計条件を生成
+//
+// start・end は RFC3339 または YYYY-MM-DD（tz の日付として解釈し、end はその日を含む）で指定します。
+// 省略時は end が now、start が end の14日前、通貨は USD です。
+func ParseStatsQuery(values url.Values, now time.Time) (*StatsQuery, error) {
+	query := &StatsQuery{Period: StatsPeriodDaily, Location: time.UTC}
+
+	currency, err := ParseCurrency(values.Get("currency"))
+	if err != nil {
+		return nil, err
+	}
+	query.Currency = currency
+
+	switch period := StatsPeriod(values.Get("period")); period {
+	case "":
+	case StatsPeriodDaily, StatsPeriodWeekly, StatsPeriodMonthly:
+		query.Period = period
+	default:
+		return nil, NewValidationError("period", ValidationCodeInvalid, "validation.one_of", map[string]interface{}{"values": []string{string(StatsPeriodDaily), string(StatsPeriodWeekly), string(StatsPeriodMonthly)}})
+	}
+
+	if tz := values.Get("tz"); tz != "" {
+		// "Local" はサーバーの設定に依存するため受け付けない
+		loc, err := time.LoadLocation(tz)
+		if err != nil || tz == "Local" {
+			return nil, NewValidationError("tz", ValidationCodeInvalid, "validation.timezone", nil)
+		}
+		query.Location = loc
+	}
+
+	end := now
+	if raw := values.Get("end"); raw != "" {
+		parsed, err := parseStatsTime(raw, query.Location, true)
+		if err != nil {
+			return nil, NewValidationError("end", ValidationCodeInvalid, "validation.datetime", nil)
+		}
+		end = parsed
+	}
+	start := end.AddDate(0, 0, -DefaultStatsRangeDays)
+	if raw := values.Get("start"); raw != "" {
+		parsed, err := parseStatsTime(raw, query.Location, false)
+		if err != nil {
+			return nil, NewValidationError("start", ValidationCodeInvalid, "validation.datetime", nil)
+		}
+		start = parsed
+	}
+	if !start.Before(end) {
+		return nil, NewValidationError("start", ValidationCodeInvalid, "stats.start_after_end", nil)
+	}
+	query.Range = StatsRange{Start: start.In(query.Location), End: end.In(query.Location)}
+
+	count := 0
+	for bucket := query.Period.Truncate(start, query.Location); bucket.Before(end); bucket = query.Period.Next(bucket) {
+		if count++; count > MaxStatsBuckets {
+			return nil, NewValidationError("period", ValidationCodeInvalid, "stats.too_many_buckets", map[string]interface{}{"max": MaxStatsBuckets})
+		}
+	}
+	return query, nil
+}
+
+// parseStatsTime RFC3339 または YYYY-MM-DD の日時を解析
+// 日付のみの場合は loc の0時とし、inclusiveEnd の場合は翌日の0時（その日の終わり）とします
+func parseStatsTime(raw string, loc *time.Location, inclusiveEnd bool) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339Nano, raw); err == nil {
+		return t, nil
+	}
+	t, err := time.ParseInLocation("2006-01-02", raw, loc)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if inclusiveEnd {
+		t = StatsPeriodDaily.Next(t)
+	}
+	return t, nil
+}
+
+// Buckets 集計期間に含まれる全てのバケットの開始日時
+// 最初のバケットは start を含むバケットで、start より前から始まる場合があります
+func (q *StatsQuery) Buckets() []time.Time {
+	var buckets []time.Time
+	for bucket := q.Period.Truncate(q.Range.Start, q.Location); bucket.Before(q.Range.End); bucket = q.Period.Next(bucket) {
+		buckets = append(buckets, bucket)
+	}
+	return buckets
+}
+
+// PreviousRange 比較対象とする直前の同じ長さの期間
+// 両端が tz の0時の場合は暦の日数でずらし（夏時間の切り替えを含んでも日単位の期間になる）、それ以外は経過時間でずらします
+func (q *StatsQuery) PreviousRange() StatsRange {
+	start, end := q.Range.Start.In(q.Location), q.Range.End.In(q.Location)
+	if isMidnight(start) && isMidnight(end) {
+		year, month, day := start.Date()
+		return StatsRange{Start: time.Date(year, month, day-calendarDays(start, end), 0, 0, 0, 0, q.Location), End: start}
+	}
+	return StatsRange{Start: start.Add(-end.Sub(start)), End: start}
+}
+
+// isMidnight 日時がそのタイムゾーンの0時か判定
+func isMidnight(t time.Time) bool {
+	return t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0 && t.Nanosecond() == 0
+}
+
+// calendarDays 2つの日時の間の暦の日数
+func calendarDays(start, end time.Time) int {
+	sy, sm, sd := start.Date()
+	ey, em, ed := end.Date()
+	days := time.Date(ey, em, ed, 0, 0, 0, 0, time.UTC).Sub(time.Date(sy, sm, sd, 0, 0, 0, 0, time.UTC))
+	return int(days.Hours() / 24)
+}
+
+// SaleTotals 売上の集計値（status = paid の売上のみ）
+type SaleTotals struct {
+	Revenue   int64 `json:"revenue" example:"1248050"` // 通貨の最小単位
+	Orders    int   `json:"orders" example:"42"`
+	Customers int   `json:"customers" example:"37"` // 購入者のメールアドレスの重複を除いた数
+}
+
+// StatsBucket 期間ごとの集計値（売上のない期間は0）
+type StatsBucket struct {
+	Date      time.Time `json:"date"`                     // バケットの開始日時（tz の日・週・月の初め）
+	Revenue   int64     `json:"revenue" example:"152000"` // 通貨の最小単位
+	Orders    int       `json:"orders" example:"5"`
+	Customers int       `json:"customers" example:"5"`
+}
+
+// StatValue 指標の値と直前の期間との比較（ダッシュボードの Stat.value・variation に対応）
+type StatValue struct {
+	Value     float64  `json:"value" example:"1248050"`
+	Previous  float64  `json:"previous" example:"1040000"`
+	Variation *float64 `json:"variation" example:"20"` // 増減率（%、小数第1位まで）。直前の期間が0で今回が0でない場合は null
+}
+
+// NewStatValue 今回と直前の期間の値から StatValue を生成
+func NewStatValue(value, previous float64) StatValue {
+	stat := StatValue{Value: value, Previous: previous}
+	switch {
+	case previous != 0:
+		variation := math.Round((value-previous)/previous*1000) / 10
+		stat.Variation = &variation
+	case value == 0:
+		variation := 0.0
+		stat.Variation = &variation
+	}
+	return stat
+}
+
+// StatsSummary 集計期間全体の指標
+type StatsSummary struct {
+	Revenue   StatValue `json:"revenue"`
+	Orders    StatValue `json:"orders"`
+	Customers StatValue `json:"customers"`
+}
+
+// Stats 売上統計レスポンス構造体
+type Stats struct {
+	Period        StatsPeriod   `json:"period" example:"weekly"`
+	Timezone      string        `json:"timezone" example:"Asia/Tokyo"`
+	Currency      string        `json:"currency" example:"USD"` // revenue の通貨（金額は最小単位）
+	Range         StatsRange    `json:"range"`
+	PreviousRange StatsRange    `json:"previous_range"`
+	Summary       StatsSummary  `json:"summary"`
+	Buckets       []StatsBucket `json:"buckets"`
+}
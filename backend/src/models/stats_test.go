@@ -0,0 +1,114 @@
+package models
+
+import (
+	"net/url"
+	"testing"
+	"time"
+)
+
+// TestParseStatsQuery 統計の集計条件の解析テスト
+func TestParseStatsQuery(t *testing.T) {
+	now := time.Date(2024, 3, 15, 10, 30, 0, 0, time.UTC)
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+
+	query, err := ParseStatsQuery(url.Values{}, now)
+	if err != nil {
+		t.Fatalf("ParseStatsQuery() error = %v", err)
+	}
+	if query.Period != StatsPeriodDaily || query.Location != time.UTC || query.Currency != DefaultCurrency ||
+		!query.Range.End.Equal(now) || !query.Range.Start.Equal(now.AddDate(0, 0, -14)) {
+		t.Errorf("デフォルトの集計条件が不正: %+v", query)
+	}
+	if buckets := query.Buckets(); len(buckets) != 15 || !buckets[0].Equal(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("デフォルトのバケットが不正: %v", buckets)
+	}
+
+	// 日付のみの end はその日を含み、tz の0時で区切る
+	query, err = ParseStatsQuery(url.Values{"period": {"weekly"}, "start": {"2024-03-01"}, "end": {"2024-03-31"}, "tz": {"Asia/Tokyo"}}, now)
+	if err != nil {
+		t.Fatalf("ParseStatsQuery() error = %v", err)
+	}
+	if !query.Range.Start.Equal(time.Date(2024, 3, 1, 0, 0, 0, 0, tokyo)) || !query.Range.End.Equal(time.Date(2024, 4, 1, 0, 0, 0, 0, tokyo)) {
+		t.Errorf("期間が不正: %+v", query.Range)
+	}
+	buckets := query.Buckets()
+	if len(buckets) != 5 || !buckets[0].Equal(time.Date(2024, 2, 26, 0, 0, 0, 0, tokyo)) || !buckets[4].Equal(time.Date(2024, 3, 25, 0, 0, 0, 0, tokyo)) {
+		t.Errorf("週ごとのバケットが不正（月曜始まり）: %v", buckets)
+	}
+
+	tests := []struct {
+		name   string
+		values url.Values
+		field  string
+	}{
+		{"不正な単位", url.Values{"period": {"yearly"}}, "period"},
+		{"不正な通貨", url.Values{"currency": {"US"}}, "currency"},
+		{"不正なタイムゾーン", url.Values{"tz": {"Mars/Olympus"}}, "tz"},
+		{"Local は不可", url.Values{"tz": {"Local"}}, "tz"},
+		{"不正な開始日時", url.Values{"start": {"yesterday"}}, "start"},
+		{"不正な終了日時", url.Values{"end": {"2024-13-01"}}, "end"},
+		{"開始が終了より後", url.Values{"start": {"2024-03-10"}, "end": {"2024-03-01"}}, "start"},
+		{"バケット数の上限超過", url.Values{"start": {"2020-01-01"}, "end": {"2024-01-01"}}, "period"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseStatsQuery(tt.values, now)
+			if validationErr, ok := err.(*ValidationError); !ok || validationErr.Field != tt.field {
+				t.Errorf("Expected validation error on %q, got %v", tt.field, err)
+			}
+		})
+	}
+
+	if _, err := ParseStatsQuery(url.Values{"period": {"monthly"}, "start": {"2020-01-01"}, "end": {"2024-01-01"}}, now); err != nil {
+		t.Errorf("月単位なら4年分を集計できるべき: %v", err)
+	}
+}
+
+// TestStatsQueryPreviousRange 比較対象の期間の計算テスト
+func TestStatsQueryPreviousRange(t *testing.T) {
+	newYork, _ := time.LoadLocation("America/New_York")
+
+	// 夏時間の開始（3月10日）をまたいでも暦の日数でずらす
+	query := &StatsQuery{Location: newYork, Range: StatsRange{
+		Start: time.Date(2024, 3, 8, 0, 0, 0, 0, newYork),
+		End:   time.Date(2024, 3, 15, 0, 0, 0, 0, newYork),
+	}}
+	previous := query.PreviousRange()
+	if !previous.Start.Equal(time.Date(2024, 3, 1, 0, 0, 0, 0, newYork)) || !previous.End.Equal(query.Range.Start) {
+		t.Errorf("日単位の直前の期間が不正: %+v", previous)
+	}
+
+	// 0時でない場合は経過時間でずらす
+	query.Range = StatsRange{
+		Start: time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC),
+		End:   time.Date(2024, 3, 2, 18, 0, 0, 0, time.UTC),
+	}
+	previous = query.PreviousRange()
+	if !previous.Start.Equal(time.Date(2024, 2, 29, 6, 0, 0, 0, time.UTC)) || !previous.End.Equal(query.Range.Start) {
+		t.Errorf("経過時間でずらした直前の期間が不正: %+v", previous)
+	}
+}
+
+// TestNewStatValue 増減率の計算テスト
+func TestNewStatValue(t *testing.T) {
+	tests := []struct {
+		value, previous float64
+		want            *float64
+	}{
+		{120, 100, ptrFloat(20)},
+		{50, 150, ptrFloat(-66.7)},
+		{0, 0, ptrFloat(0)},
+		{10, 0, nil},
+	}
+	for _, tt := range tests {
+		got := NewStatValue(tt.value, tt.previous).Variation
+		if (got == nil) != (tt.want == nil) || (got != nil && *got != *tt.want) {
+			t.Errorf("NewStatValue(%v, %v).Variation = %v, want %v", tt.value, tt.previous, got, tt.want)
+		}
+	}
+}
+
+// ptrFloat float64 のポインタを返す
+func ptrFloat(v float64) *float64 {
+	return &v
+}
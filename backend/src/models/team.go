@@ -0,0 +1,116 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// TeamRole チーム内のロール（ダッシュボードの Member.role に対応）
+type TeamRole string
+
+const (
+	TeamRoleOwner  TeamRole = "owner"
+	TeamRoleMember TeamRole = "member"
+)
+
+// TeamRoles 有効なチームロールの一覧
+var TeamRoles = []TeamRole{TeamRoleOwner, TeamRoleMember}
+
+// TeamNameMaxLength チーム名の長さ制限
+const TeamNameMaxLength = 100
+
+// Team チーム構造体（ダッシュボードの TeamsMenu に対応）
+type Team struct {
+	ID        int       `json:"id"`
+	Name      string    `json:"name" example:"Nuxt"`
+	Avatar    *Avatar   `json:"avatar,omitempty"`
+	Role      TeamRole  `json:"role,omitempty" example:"owner"` // リクエストしたユーザーのロール
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TeamMember チームメンバー構造体（ダッシュボードの Member 型に対応）
+type TeamMember struct {
+	UserID   int       `json:"user_id"`
+	Name     string    `json:"name" example:"Benjamin Canac"`
+	Email    string    `json:"email" example:"benjamin@example.com"`
+	Role     TeamRole  `json:"role" example:"owner"`
+	JoinedAt time.Time `json:"joined_at"`
+}
+
+// TeamInvitation チームへの招待構造体（トークンはメールでのみ送信し、ハッシュを保存）
+type TeamInvitation struct {
+	ID         int        `json:"id"`
+	TeamID     int        `json:"team_id"`
+	Email      string     `json:"email" example:"daniel@example.com"`
+	Role       TeamRole   `json:"role" example:"member"`
+	TokenHash  string     `json:"-"`
+	InvitedBy  int        `json:"invited_by"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	AcceptedAt *time.Time `json:"accepted_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// TeamRequest チーム作成・更新リクエスト構造体
+type TeamRequest struct {
+	Name   string  `json:"name" example:"Nuxt"`
+	Avatar *Avatar `json:"avatar,omitempty"`
+}
+
+// TeamMemberRoleRequest メンバーのロール変更リクエスト構造体
+type TeamMemberRoleRequest struct {
+	Role TeamRole `json:"role" example:"owner"`
+}
+
+// TeamInvitationRequest 招待リクエスト構造体
+type TeamInvitationRequest struct {
+	Email string   `json:"email" example:"daniel@example.com"`
+	Role  TeamRole `json:"role,omitempty" example:"member"` // 省略時は member
+}
+
+// AcceptInvitationRequest 招待承諾リクエスト構造体
+type AcceptInvitationRequest struct {
+	Token string `json:"token"`
+}
+
+// Pending 招待が未承諾かつ有効期限内か判定
+func (i *TeamInvitation) Pending(now time.Time) bool {
+	return i.AcceptedAt == nil && now.Before(i.ExpiresAt)
+}
+
+// Validate チームリクエストのバリデーション（全てのフィールドのエラーをまとめて返す）
+func (r *TeamRequest) Validate() error {
+	v := NewValidator()
+	r.Name = strings.TrimSpace(r.Name)
+	v.String("name", r.Name, Required(), MaxLength(TeamNameMaxLength))
+	validateAvatar(v, "avatar", &r.Avatar)
+	return v.Err()
+}
+
+// Validate ロール変更リクエストのバリデーション
+func (r *TeamMemberRoleRequest) Validate() error {
+	v := NewValidator()
+	v.String("role", string(r.Role), Required(), OneOf(TeamRoles...))
+	return v.Err()
+}
+
+// Validate 招待リクエストのバリデーション（全てのフィールドのエラーをまとめて返す）
+// email を正規化し、role 省略時は member を設定します
+func (r *TeamInvitationRequest) Validate() error {
+	v := NewValidator()
+	r.Email = NormalizeEmail(r.Email)
+	validateEmail(v, "email", r.Email)
+	if r.Role == "" {
+		r.Role = TeamRoleMember
+	}
+	v.String("role", string(r.Role), OneOf(TeamRoles...))
+	return v.Err()
+}
+
+// Validate 招待承諾リクエストのバリデーション
+func (r *AcceptInvitationRequest) Validate() error {
+	v := NewValidator()
+	r.Token = strings.TrimSpace(r.Token)
+	v.String("token", r.Token, Required())
+	return v.Err()
+}
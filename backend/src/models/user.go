@@ -0,0 +1,164 @@
+package models
+
+import (
+	"regexp"
+	"strings"
+	"time"
+)
+
+// パスワードの長さ制限（bcrypt は72バイトを超える部分を無視するため上限を設ける）
+const (
+	PasswordMinLength = 8
+	PasswordMaxLength = 72
+)
+
+// EmailMaxLength メールアドレスの最大長
+const EmailMaxLength = 255
+
+// プロフィールの長さ制限
+const (
+	UserNameMaxLength = 255
+	UsernameMinLength = 3
+	UsernameMaxLength = 30
+	BioMaxLength      = 500
+)
+
+// usernamePattern ユーザー名に使用できる文字（小文字に正規化した後の形式）
+var usernamePattern = regexp.MustCompile(`^[a-z0-9_-]+$`)
+
+// User ユーザー構造体
+type User struct {
+	ID           int       `json:"id"`
+	Email        string    `json:"email"`
+	Name         string    `json:"name"`
+	Username     string    `json:"username,omitempty"` // 未設定の場合は空
+	Avatar       *Avatar   `json:"avatar,omitempty"`
+	Bio          string    `json:"bio"`
+	PendingEmail string    `json:"pending_email,omitempty"` // 確認待ちの新しいメールアドレス
+	PasswordHash string    `json:"-"`
+	Roles        []string  `json:"roles,omitempty"`       // 認証時に読み込まれるロール
+	Permissions  []string  `json:"permissions,omitempty"` // 認証時に読み込まれる権限
+	SessionID    string    `json:"-"`                     // 認証に使用したアクセストークンのセッションID
+	APIKeyID     int       `json:"-"`                     // 認証に使用したAPIキーのID（アクセストークンの場合は0）
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// RegisterRequest ユーザー登録リクエスト構造体
+type RegisterRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+	Name     string `json:"name"`
+}
+
+// LoginRequest ログインリクエスト構造体
+type LoginRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+// RefreshTokenRequest トークン再発行リクエスト構造体
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// AuthResponse 認証レスポンス構造体
+type AuthResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int64  `json:"expires_in"` // アクセストークンの有効期間（秒）
+	User         *User  `json:"user,omitempty"`
+}
+
+// ProfileRequest プロフィール更新リクエスト構造体（PATCH /api/me の JSON Merge Patch の適用先）
+type ProfileRequest struct {
+	Name     string  `json:"name" example:"Benjamin Canac"`
+	Email    string  `json:"email" example:"ben@nuxtlabs.com"`
+	Username string  `json:"username" example:"benjamincanac"`
+	Avatar   *Avatar `json:"avatar,omitempty"`
+	Bio      string  `json:"bio" example:"Nuxt UI maintainer"`
+}
+
+// ConfirmEmailChangeRequest メールアドレス変更の確認リクエスト構造体
+type ConfirmEmailChangeRequest struct {
+	Token string `json:"token"`
+}
+
+// DeleteAccountRequest アカウント削除リクエスト構造体（パスワードで再認証）
+type DeleteAccountRequest struct {
+	Password string `json:"password"`
+}
+
+// NormalizeEmail メールアドレスを比較用に正規化（前後の空白除去・小文字化）
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// Validate ユーザー登録リクエストのバリデーション（全てのフィールドのエラーをまとめて返す）
+// email を正規化し、name の前後の空白を取り除きます
+func (r *RegisterRequest) Validate() error {
+	v := NewValidator()
+	r.Email = NormalizeEmail(r.Email)
+	validateEmail(v, "email", r.Email)
+	v.String("password", r.Password, Required(), MinLength(PasswordMinLength), MaxBytes(PasswordMaxLength))
+	r.Name = strings.TrimSpace(r.Name)
+	v.String("name", r.Name, Required(), MaxLength(UserNameMaxLength))
+	return v.Err()
+}
+
+// Validate ログインリクエストのバリデーション
+func (r *LoginRequest) Validate() error {
+	v := NewValidator()
+	v.String("email", strings.TrimSpace(r.Email), Required())
+	v.String("password", r.Password, Required())
+	return v.Err()
+}
+
+// Validate トークン再発行リクエストのバリデーション
+func (r *RefreshTokenRequest) Validate() error {
+	v := NewValidator()
+	v.String("refresh_token", r.RefreshToken, Required())
+	return v.Err()
+}
+
+// Validate プロフィール更新リクエストのバリデーション（全てのフィールドのエラーをまとめて返す）
+// email と username を正規化し、空の username・avatar は未設定として扱います
+func (r *ProfileRequest) Validate() error {
+	v := NewValidator()
+
+	r.Name = strings.TrimSpace(r.Name)
+	v.String("name", r.Name, Required(), MaxLength(UserNameMaxLength))
+	r.Email = NormalizeEmail(r.Email)
+	validateEmail(v, "email", r.Email)
+
+	r.Username = strings.ToLower(strings.TrimSpace(r.Username))
+	v.String("username", r.Username, MinLength(UsernameMinLength), MaxLength(UsernameMaxLength),
+		Matches(usernamePattern, "validation.username_format"))
+
+	validateAvatar(v, "avatar", &r.Avatar)
+
+	r.Bio = strings.TrimSpace(r.Bio)
+	v.String("bio", r.Bio, MaxLength(BioMaxLength))
+	return v.Err()
+}
+
+// Validate メールアドレス変更の確認リクエストのバリデーション
+func (r *ConfirmEmailChangeRequest) Validate() error {
+	v := NewValidator()
+	r.Token = strings.TrimSpace(r.Token)
+	v.String("token", r.Token, Required())
+	return v.Err()
+}
+
+// Validate アカウント削除リクエストのバリデーション
+func (r *DeleteAccountRequest) Validate() error {
+	v := NewValidator()
+	v.String("password", r.Password, Required())
+	return v.Err()
+}
+
+// validateEmail メールアドレスを検証（正規化済みの値を渡す）
+func validateEmail(v *Validator, field, email string) bool {
+	return v.String(field, email, Required(), MaxLength(EmailMaxLength), Email())
+}
@@ -0,0 +1,159 @@
+package models
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// TestRegisterRequestValidation ユーザー登録リクエストバリデーションのテスト
+func TestRegisterRequestValidation(t *testing.T) {
+	tests := []struct {
+		name      string
+		request   RegisterRequest
+		wantField string
+	}{
+		{
+			name:    "Valid request",
+			request: RegisterRequest{Email: "alice@example.com", Password: "password123", Name: "Alice"},
+		},
+		{
+			name:      "Empty email",
+			request:   RegisterRequest{Email: "", Password: "password123", Name: "Alice"},
+			wantField: "email",
+		},
+		{
+			name:      "Invalid email",
+			request:   RegisterRequest{Email: "not-an-email", Password: "password123", Name: "Alice"},
+			wantField: "email",
+		},
+		{
+			name:      "Email with display name",
+			request:   RegisterRequest{Email: "Alice <alice@example.com>", Password: "password123", Name: "Alice"},
+			wantField: "email",
+		},
+		{
+			name:      "Short password",
+			request:   RegisterRequest{Email: "alice@example.com", Password: "short", Name: "Alice"},
+			wantField: "password",
+		},
+		{
+			name:      "Too long password",
+			request:   RegisterRequest{Email: "alice@example.com", Password: strings.Repeat("a", 73), Name: "Alice"},
+			wantField: "password",
+		},
+		{
+			name:      "Whitespace name",
+			request:   RegisterRequest{Email: "alice@example.com", Password: "password123", Name: "   "},
+			wantField: "name",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.request.Validate()
+			if tt.wantField == "" {
+				if err != nil {
+					t.Errorf("Validate() error = %v, want nil", err)
+				}
+				return
+			}
+
+			validationErr, ok := err.(*ValidationError)
+			if !ok {
+				t.Fatalf("Expected *ValidationError, got %T", err)
+			}
+			if validationErr.Field != tt.wantField {
+				t.Errorf("Expected field %q, got %q", tt.wantField, validationErr.Field)
+			}
+		})
+	}
+}
+
+// TestLoginRequestValidation ログインリクエストバリデーションのテスト
+func TestLoginRequestValidation(t *testing.T) {
+	if err := (&LoginRequest{Email: "alice@example.com", Password: "x"}).Validate(); err != nil {
+		t.Errorf("Validate() error = %v, want nil", err)
+	}
+	if err := (&LoginRequest{Email: "", Password: "x"}).Validate(); err == nil {
+		t.Error("Expected error for empty email")
+	}
+	if err := (&LoginRequest{Email: "alice@example.com"}).Validate(); err == nil {
+		t.Error("Expected error for empty password")
+	}
+}
+
+// TestNormalizeEmail メールアドレス正規化のテスト
+func TestNormalizeEmail(t *testing.T) {
+	if got := NormalizeEmail("  Alice@Example.COM "); got != "alice@example.com" {
+		t.Errorf("NormalizeEmail() = %q", got)
+	}
+}
+
+// TestUserJSONOmitsPasswordHash パスワードハッシュがJSONに含まれないことのテスト
+func TestUserJSONOmitsPasswordHash(t *testing.T) {
+	data, err := json.Marshal(User{ID: 1, Email: "alice@example.com", PasswordHash: "secret-hash"})
+	if err != nil {
+		t.Fatalf("Marshal error = %v", err)
+	}
+	if strings.Contains(string(data), "secret-hash") || strings.Contains(string(data), "password") {
+		t.Errorf("password hash leaked: %s", data)
+	}
+}
+
+// TestProfileRequestValidation プロフィール更新リクエストのバリデーションのテスト
+func TestProfileRequestValidation(t *testing.T) {
+	request := ProfileRequest{Name: " Ben ", Email: " Ben@NuxtLabs.com ", Username: " BenjaminCanac ", Avatar: &Avatar{Src: " "}, Bio: " Hi "}
+	if err := request.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if request.Name != "Ben" || request.Email != "ben@nuxtlabs.com" || request.Username != "benjamincanac" || request.Avatar != nil || request.Bio != "Hi" {
+		t.Errorf("正規化されていない: %+v", request)
+	}
+
+	// 全てのフィールドのエラーをまとめて返す
+	request = ProfileRequest{
+		Name:     "",
+		Email:    "not-an-email",
+		Username: "a!",
+		Avatar:   &Avatar{Src: "ftp://example.com/a.png"},
+		Bio:      strings.Repeat("あ", BioMaxLength+1),
+	}
+	err := request.Validate()
+	validationErr, ok := err.(*ValidationError)
+	if !ok {
+		t.Fatalf("Expected ValidationError, got %v", err)
+	}
+	got := make(map[string]string)
+	for _, fieldErr := range validationErr.Errors {
+		got[fieldErr.Field] = fieldErr.Code
+	}
+	want := map[string]string{
+		"name":       ValidationCodeRequired,
+		"email":      ValidationCodeInvalid,
+		"username":   ValidationCodeTooShort,
+		"avatar.src": ValidationCodeInvalid,
+		"bio":        ValidationCodeTooLong,
+	}
+	if len(got) != len(want) {
+		t.Errorf("エラーの件数が不正: %+v", validationErr.Errors)
+	}
+	for field, code := range want {
+		if got[field] != code {
+			t.Errorf("%s のコード = %q, want %q", field, got[field], code)
+		}
+	}
+	if validationErr.Field != "name" || validationErr.Message != "Name is required" {
+		t.Errorf("最初のエラーが Field・Message に設定されていない: %+v", validationErr)
+	}
+
+	// 500文字ちょうどは許可（バイト数ではなく文字数で数える）
+	request = ProfileRequest{Name: "Ben", Email: "ben@example.com", Username: "ben-canac_1", Bio: strings.Repeat("あ", BioMaxLength)}
+	if err := request.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+	request.Username = "ben.canac"
+	if err := request.Validate(); err == nil || err.(*ValidationError).Code != ValidationCodeInvalid {
+		t.Errorf("使用できない文字で invalid が返らない: %v", err)
+	}
+}
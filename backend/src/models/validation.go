@@ -0,0 +1,481 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/mail"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// バリデーションエラーの種類（クライアントがメッセージに依存せずに判別するためのコード）
+const (
+	ValidationCodeRequired     = "required"      // 必須項目が空
+	ValidationCodeTooShort     = "too_short"     // 最小長より短い
+	ValidationCodeTooLong      = "too_long"      // 最大長を超える
+	ValidationCodeTooSmall     = "too_small"     // 最小値より小さい
+	ValidationCodeInvalid      = "invalid"       // 形式・値が不正
+	ValidationCodeTaken        = "taken"         // 他のユーザーが使用中
+	ValidationCodeUnknownField = "unknown_field" // リクエストに定義されていないフィールド
+)
+
+// FieldError フィールド単位のバリデーションエラー
+type FieldError struct {
+	Field   string                 `json:"field" example:"username"`
+	Code    string                 `json:"code" example:"too_long"`
+	Message string                 `json:"message" example:"Username must be at most 30 characters"`
+	Params  map[string]interface{} `json:"params,omitempty"` // メッセージに埋め込んだ値（例: {"max": 30}）
+	key     string                 // メッセージカタログのキー（Localize で言語を切り替えるため）
+}
+
+// localize メッセージを指定した言語で生成し直したコピーを返す（キーを持たないエラーはそのまま）
+// メッセージの {label} はフィールドの表示名、{field} はフィールドのパスに置き換えます
+func (e FieldError) localize(locale Locale) FieldError {
+	if e.key == "" {
+		return e
+	}
+	params := map[string]interface{}{"label": fieldLabel(locale, e.Field), "field": e.Field}
+	for name, value := range e.Params {
+		params[name] = value
+	}
+	e.Message = Translate(locale, e.key, params)
+	return e
+}
+
+// ValidationError バリデーションエラー構造体
+//
+// 複数のフィールドをまとめて検証した場合は Errors に全てのエラーを含め、
+// Field・Code・Message には最初のエラーを設定します（単一のエラーを前提とする呼び出し側との互換性のため）。
+type ValidationError struct {
+	Field   string       `json:"field"`
+	Code    string       `json:"code,omitempty"`
+	Message string       `json:"message"`
+	Errors  []FieldError `json:"errors,omitempty"`
+}
+
+// NewValidationError 単一のフィールドのバリデーションエラーを作成
+// key はメッセージカタログのキー、params はメッセージに埋め込む値です
+func NewValidationError(field, code, key string, params map[string]interface{}) *ValidationError {
+	var errs FieldErrors
+	errs.Add(field, code, key, params)
+	return errs.Err().(*ValidationError)
+}
+
+// Error エラーメッセージを返す
+func (v *ValidationError) Error() string {
+	return v.Message
+}
+
+// Localize メッセージを指定した言語で生成し直したコピーを返す
+func (v *ValidationError) Localize(locale Locale) *ValidationError {
+	if len(v.Errors) == 0 {
+		return v
+	}
+	errs := make(FieldErrors, len(v.Errors))
+	for i, fieldErr := range v.Errors {
+		errs[i] = fieldErr.localize(locale)
+	}
+	return errs.Err().(*ValidationError)
+}
+
+// FieldErrors フィールドのエラーを検証順に収集する
+type FieldErrors []FieldError
+
+// Add フィールドのエラーを追加（key はメッセージカタログのキー、Message は DefaultLocale で生成）
+func (e *FieldErrors) Add(field, code, key string, params map[string]interface{}) {
+	fieldErr := FieldError{Field: field, Code: code, Params: params, key: key}
+	*e = append(*e, fieldErr.localize(DefaultLocale))
+}
+
+// Has フィールドのエラーが追加済みか判定
+func (e FieldErrors) Has(field string) bool {
+	for _, fieldErr := range e {
+		if fieldErr.Field == field {
+			return true
+		}
+	}
+	return false
+}
+
+// Err 収集したエラーを *ValidationError にまとめる（エラーがなければ nil）
+func (e FieldErrors) Err() error {
+	if len(e) == 0 {
+		return nil
+	}
+	first := e[0]
+	return &ValidationError{Field: first.Field, Code: first.Code, Message: first.Message, Errors: e}
+}
+
+// Validator 宣言的な検証規則でリクエストの全てのフィールドを検証し、エラーを収集する
+//
+//	v := NewValidator()
+//	v.String("name", r.Name, Required(), MaxLength(255))
+//	v.Nested("avatar", func(v *Validator) {
+//		v.String("src", r.Avatar.Src, HTTPURL())
+//	})
+//	return v.Err()
+type Validator struct {
+	prefix string       // Nested で検証中のフィールドのパス（例: "from."）
+	errs   *FieldErrors // Nested の検証と共有する
+}
+
+// NewValidator バリデーターを新規作成
+func NewValidator() *Validator {
+	return &Validator{errs: &FieldErrors{}}
+}
+
+// StringRule 文字列フィールドの検証規則
+// 違反していれば Code・Params とメッセージのキーを設定したエラーを返します。Required 以外の規則は空文字列を検証しません（任意項目）
+type StringRule func(value string) *FieldError
+
+// IntRule 整数フィールドの検証規則
+type IntRule func(value int64) *FieldError
+
+// String 文字列フィールドを規則の順に検証し、最初に違反した規則のエラーを追加する
+// 全ての規則を満たせば true を返します（検証後の正規化に使用）
+func (v *Validator) String(field, value string, rules ...StringRule) bool {
+	path := v.prefix + field
+	for _, rule := range rules {
+		if fieldErr := rule(value); fieldErr != nil {
+			v.add(path, fieldErr)
+			return false
+		}
+	}
+	return true
+}
+
+// Int 整数フィールドを規則の順に検証し、最初に違反した規則のエラーを追加する
+func (v *Validator) Int(field string, value int64, rules ...IntRule) bool {
+	path := v.prefix + field
+	for _, rule := range rules {
+		if fieldErr := rule(value); fieldErr != nil {
+			v.add(path, fieldErr)
+			return false
+		}
+	}
+	return true
+}
+
+// Present 値が指定されていること（nil でないこと）を検証する
+func (v *Validator) Present(field string, present bool) bool {
+	path := v.prefix + field
+	if !present {
+		v.add(path, &FieldError{Code: ValidationCodeRequired, key: "validation.required"})
+	}
+	return present
+}
+
+// Check 規則で表せない条件を検証し、満たさなければ指定したエラーを追加する（key はメッセージカタログのキー）
+func (v *Validator) Check(field string, ok bool, code, key string, params map[string]interface{}) bool {
+	if !ok {
+		v.add(v.prefix+field, &FieldError{Code: code, Params: params, key: key})
+	}
+	return ok
+}
+
+// Nested ネストしたオブジェクトのフィールドを検証する（フィールド名は "field.子のフィールド"）
+func (v *Validator) Nested(field string, validate func(v *Validator)) {
+	validate(&Validator{prefix: v.prefix + field + ".", errs: v.errs})
+}
+
+// Has フィールドのエラーが追加済みか判定
+func (v *Validator) Has(field string) bool {
+	return v.errs.Has(v.prefix + field)
+}
+
+// Err 収集したエラーを *ValidationError にまとめる（エラーがなければ nil）
+func (v *Validator) Err() error {
+	return v.errs.Err()
+}
+
+// add フィールドのパスを設定してエラーを追加
+func (v *Validator) add(path string, fieldErr *FieldError) {
+	v.errs.Add(path, fieldErr.Code, fieldErr.key, fieldErr.Params)
+}
+
+// Required 空でないこと
+func Required() StringRule {
+	return func(value string) *FieldError {
+		if value == "" {
+			return &FieldError{Code: ValidationCodeRequired, key: "validation.required"}
+		}
+		return nil
+	}
+}
+
+// MinLength 文字数（バイト数ではない）が min 以上であること
+func MinLength(min int) StringRule {
+	return func(value string) *FieldError {
+		if value != "" && utf8.RuneCountInString(value) < min {
+			return &FieldError{Code: ValidationCodeTooShort, Params: map[string]interface{}{"min": min}, key: "validation.too_short"}
+		}
+		return nil
+	}
+}
+
+// MaxLength 文字数（バイト数ではない）が max 以下であること
+func MaxLength(max int) StringRule {
+	return func(value string) *FieldError {
+		if utf8.RuneCountInString(value) > max {
+			return &FieldError{Code: ValidationCodeTooLong, Params: map[string]interface{}{"max": max}, key: "validation.too_long"}
+		}
+		return nil
+	}
+}
+
+// MaxBytes バイト数が max 以下であること（bcrypt の入力長の上限など）
+func MaxBytes(max int) StringRule {
+	return func(value string) *FieldError {
+		if len(value) > max {
+			return &FieldError{Code: ValidationCodeTooLong, Params: map[string]interface{}{"max": max}, key: "validation.too_long_bytes"}
+		}
+		return nil
+	}
+}
+
+// Email 表示名などを含まない単一のメールアドレスであること
+func Email() StringRule {
+	return func(value string) *FieldError {
+		if value == "" {
+			return nil
+		}
+		if addr, err := mail.ParseAddress(value); err != nil || addr.Address != value {
+			return &FieldError{Code: ValidationCodeInvalid, key: "validation.invalid"}
+		}
+		return nil
+	}
+}
+
+// OneOf 許可された値のいずれかであること
+func OneOf[T ~string](values ...T) StringRule {
+	allowed := make([]string, len(values))
+	for i, value := range values {
+		allowed[i] = string(value)
+	}
+	return func(value string) *FieldError {
+		if value == "" {
+			return nil
+		}
+		for _, candidate := range allowed {
+			if value == candidate {
+				return nil
+			}
+		}
+		return &FieldError{Code: ValidationCodeInvalid, Params: map[string]interface{}{"values": allowed}, key: "validation.one_of"}
+	}
+}
+
+// Matches 正規表現に一致すること（key は "{label} may only contain ..." のような形式の説明のメッセージキー）
+func Matches(pattern *regexp.Regexp, key string) StringRule {
+	return func(value string) *FieldError {
+		if value != "" && !pattern.MatchString(value) {
+			return &FieldError{Code: ValidationCodeInvalid, key: key}
+		}
+		return nil
+	}
+}
+
+// HTTPURL http/https の絶対URLであること
+func HTTPURL() StringRule {
+	return func(value string) *FieldError {
+		if value == "" {
+			return nil
+		}
+		u, err := url.Parse(value)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return &FieldError{Code: ValidationCodeInvalid, key: "validation.http_url"}
+		}
+		return nil
+	}
+}
+
+// Min 値が min 以上であること
+func Min(min int64) IntRule {
+	return func(value int64) *FieldError {
+		if value < min {
+			return &FieldError{Code: ValidationCodeTooSmall, Params: map[string]interface{}{"min": min}, key: "validation.too_small"}
+		}
+		return nil
+	}
+}
+
+// fieldLabels メッセージに使用する言語ごとのフィールドの表示名
+// 登録のないフィールドは英語の表示名をパスの末尾の名前から生成します（例: refresh_token → "Refresh token"）
+var fieldLabels = map[Locale]map[string]string{
+	LocaleEN: {
+		"avatar.src":  "Avatar URL",
+		"from.name":   "Sender name",
+		"mfa_token":   "MFA token",
+		"message_id":  "Message-ID",
+		"in_reply_to": "In-Reply-To",
+		"expires_at":  "Expiry",
+		"tz":          "Timezone",
+	},
+	LocaleJA: {
+		"after":         "カーソル",
+		"amount":        "金額",
+		"avatar.src":    "アバターURL",
+		"bio":           "自己紹介",
+		"body":          "本文",
+		"channel":       "チャネル",
+		"code":          "認証コード",
+		"currency":      "通貨",
+		"email":         "メールアドレス",
+		"end":           "終了日時",
+		"expires_at":    "有効期限",
+		"filter":        "絞り込み",
+		"from.name":     "送信者名",
+		"in_reply_to":   "返信元のMessage-ID",
+		"location":      "所在地",
+		"message":       "メッセージ",
+		"message_id":    "Message-ID",
+		"mfa_token":     "MFAトークン",
+		"name":          "名前",
+		"nickname":      "ニックネーム",
+		"offset":        "オフセット",
+		"password":      "パスワード",
+		"period":        "集計単位",
+		"preferences":   "通知設定",
+		"refresh_token": "リフレッシュトークン",
+		"role":          "ロール",
+		"room":          "ルーム",
+		"scopes":        "スコープ",
+		"sort":          "並び順",
+		"start":         "開始日時",
+		"status":        "ステータス",
+		"subject":       "件名",
+		"token":         "トークン",
+		"type":          "種別",
+		"tz":            "タイムゾーン",
+		"unread":        "未読",
+		"username":      "ユーザー名",
+	},
+}
+
+// arrayIndexPattern フィールドのパスの配列の添字（例: preferences[0]）
+var arrayIndexPattern = regexp.MustCompile(`\[\d+\]`)
+
+// fieldLabel フィールドのパスから言語の表示名を取得
+// パス全体、親を除いたパスの順に言語の fieldLabels を探し、なければ英語の表示名を返します
+func fieldLabel(locale Locale, path string) string {
+	path = arrayIndexPattern.ReplaceAllString(path, "")
+	if label, ok := lookupFieldLabel(fieldLabels[locale], path); ok {
+		return label
+	}
+	if label, ok := lookupFieldLabel(fieldLabels[LocaleEN], path); ok {
+		return label
+	}
+
+	name := path[strings.LastIndex(path, ".")+1:]
+	name = strings.ReplaceAll(name, "_", " ")
+	if name == "" {
+		return "Value"
+	}
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
+// lookupFieldLabel パス全体、親を除いたパスの順に表示名を探す
+func lookupFieldLabel(labels map[string]string, path string) (string, bool) {
+	for suffix := path; ; {
+		if label, ok := labels[suffix]; ok {
+			return label, true
+		}
+		_, rest, found := strings.Cut(suffix, ".")
+		if !found {
+			break
+		}
+		suffix = rest
+	}
+	return "", false
+}
+
+// DecodeJSON リクエストボディの JSON をデコードする（未知のフィールドと JSON の値の後のデータは拒否）
+// 未知のフィールドは unknown_field、型の不一致はそのフィールドの invalid、空のボディは body の required、
+// JSON として不正な場合は params の offset に位置（0始まりのバイト位置）を含めた body の invalid のバリデーションエラーを返します
+// ボディの読み込みに失敗した場合（上限を超えた場合の *http.MaxBytesError など）はそのエラーをそのまま返します
+func DecodeJSON(r io.Reader, v interface{}) error {
+	reader := &readErrorRecorder{r: r}
+	decoder := json.NewDecoder(reader)
+	decoder.DisallowUnknownFields()
+	err := decoder.Decode(v)
+	if err == nil {
+		// 空白以外のデータが続く場合は拒否（{"a":1}{"a":2} など）
+		offset := decoder.InputOffset() + leadingSpaceLength(decoder.Buffered())
+		if _, err = decoder.Token(); errors.Is(err, io.EOF) {
+			return nil
+		}
+		if reader.err != nil && errors.Is(err, reader.err) {
+			return err
+		}
+		return NewValidationError("body", ValidationCodeInvalid, "request.trailing_data", map[string]interface{}{"offset": offset})
+	}
+	if reader.err != nil && !errors.Is(reader.err, io.EOF) && errors.Is(err, reader.err) {
+		return err
+	}
+
+	var errs FieldErrors
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.Is(err, io.EOF):
+		errs.Add("body", ValidationCodeRequired, "request.empty_body", nil)
+	case errors.As(err, &syntaxErr):
+		// Offset は不正な文字までに読み込んだバイト数
+		errs.Add("body", ValidationCodeInvalid, "request.invalid_json", map[string]interface{}{"offset": syntaxErr.Offset - 1})
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		errs.Add("body", ValidationCodeInvalid, "request.unexpected_eof", map[string]interface{}{"offset": reader.n})
+	case errors.As(err, &typeErr) && typeErr.Field != "":
+		errs.Add(typeErr.Field, ValidationCodeInvalid, "validation.type_"+jsonTypeName(typeErr.Type.Kind()), nil)
+	case strings.HasPrefix(err.Error(), "json: unknown field "):
+		// DisallowUnknownFields のエラーは型を持たないためメッセージからフィールド名を取り出す
+		field := strings.Trim(strings.TrimPrefix(err.Error(), "json: unknown field "), `"`)
+		errs.Add(field, ValidationCodeUnknownField, "validation.unknown_field", nil)
+	default:
+		errs.Add("body", ValidationCodeInvalid, "request.invalid_body", nil)
+	}
+	return errs.Err()
+}
+
+// leadingSpaceLength 読み込み済みのデータの先頭の空白のバイト数
+func leadingSpaceLength(buffered io.Reader) int64 {
+	data, _ := io.ReadAll(buffered)
+	return int64(len(data) - len(bytes.TrimLeft(data, " \t\r\n")))
+}
+
+// readErrorRecorder 読み込んだバイト数とエラーを記録するリーダー（JSON のエラーと読み込みのエラーを区別するため）
+type readErrorRecorder struct {
+	r   io.Reader
+	n   int64
+	err error
+}
+
+// Read 元のリーダーから読み込み、バイト数とエラーを記録する
+func (r *readErrorRecorder) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.n += int64(n)
+	if err != nil {
+		r.err = err
+	}
+	return n, err
+}
+
+// jsonTypeName Go の型の種類に対応する JSON の型の名前（メッセージキー validation.type_* の接尾辞）
+func jsonTypeName(kind reflect.Kind) string {
+	switch kind {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	case reflect.Struct, reflect.Map, reflect.Pointer:
+		return "object"
+	default:
+		return "number"
+	}
+}
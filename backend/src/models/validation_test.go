@@ -0,0 +1,186 @@
+package models
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+// TestValidatorCollectsAllErrors 全てのフィールドのエラーを検証順に収集するテスト
+func TestValidatorCollectsAllErrors(t *testing.T) {
+	v := NewValidator()
+	v.String("name", "", Required(), MaxLength(5))
+	v.String("nickname", "abcdefg", Required(), MaxLength(5))
+	v.String("email", "not-an-email", Email())
+	v.String("role", "owner", OneOf("admin", "member"))
+	v.String("username", "Alice!", Matches(regexp.MustCompile(`^[a-z]+$`), "validation.username_format"))
+	v.Int("amount", -1, Min(0))
+	v.Nested("avatar", func(v *Validator) {
+		v.String("src", "ftp://example.com/a.png", HTTPURL())
+	})
+	v.String("bio", "", MaxLength(5), Email())
+
+	var validationErr *ValidationError
+	if !errors.As(v.Err(), &validationErr) {
+		t.Fatalf("Expected ValidationError, got %v", v.Err())
+	}
+	want := []FieldError{
+		{Field: "name", Code: ValidationCodeRequired, Message: "Name is required", key: "validation.required"},
+		{Field: "nickname", Code: ValidationCodeTooLong, Message: "Nickname must be at most 5 characters", Params: map[string]interface{}{"max": 5}, key: "validation.too_long"},
+		{Field: "email", Code: ValidationCodeInvalid, Message: "Email is invalid", key: "validation.invalid"},
+		{Field: "role", Code: ValidationCodeInvalid, Message: "Role must be one of admin, member", Params: map[string]interface{}{"values": []string{"admin", "member"}}, key: "validation.one_of"},
+		{Field: "username", Code: ValidationCodeInvalid, Message: "Username may only contain letters, digits, underscores and hyphens", key: "validation.username_format"},
+		{Field: "amount", Code: ValidationCodeTooSmall, Message: "Amount must be at least 0", Params: map[string]interface{}{"min": int64(0)}, key: "validation.too_small"},
+		{Field: "avatar.src", Code: ValidationCodeInvalid, Message: "Avatar URL must be an absolute http or https URL", key: "validation.http_url"},
+	}
+	if !reflect.DeepEqual(validationErr.Errors, want) {
+		t.Errorf("Errors = %+v\nwant %+v", validationErr.Errors, want)
+	}
+	if validationErr.Field != "name" || validationErr.Message != "Name is required" {
+		t.Errorf("先頭のエラーが設定されていない: %+v", validationErr)
+	}
+	if !v.Has("avatar.src") || v.Has("bio") {
+		t.Error("Has の結果が不正")
+	}
+
+	if err := NewValidator().Err(); err != nil {
+		t.Errorf("エラーがない場合は nil を返すべき: %v", err)
+	}
+}
+
+// TestValidatorLengthCountsRunes 文字数を rune 単位で数えるテスト
+func TestValidatorLengthCountsRunes(t *testing.T) {
+	v := NewValidator()
+	if !v.String("name", "こんにちは", MinLength(5), MaxLength(5)) {
+		t.Errorf("5文字の文字列が拒否された: %v", v.Err())
+	}
+	if v.String("password", "こんにちは", MaxBytes(5)) {
+		t.Error("バイト数の上限を超える文字列が許可された")
+	}
+}
+
+// TestFieldLabel フィールドのパスから表示名を取得するテスト
+func TestFieldLabel(t *testing.T) {
+	tests := []struct {
+		locale Locale
+		path   string
+		want   string
+	}{
+		{LocaleEN, "name", "Name"},
+		{LocaleEN, "refresh_token", "Refresh token"},
+		{LocaleEN, "avatar.src", "Avatar URL"},
+		{LocaleEN, "from.name", "Sender name"},
+		{LocaleEN, "from.email", "Email"},
+		{LocaleEN, "preferences[2].channel", "Channel"},
+		{LocaleEN, "mfa_token", "MFA token"},
+		{LocaleJA, "name", "名前"},
+		{LocaleJA, "from.name", "送信者名"},
+		{LocaleJA, "from.email", "メールアドレス"},
+		{LocaleJA, "preferences[2].channel", "チャネル"},
+		{LocaleJA, "display_name", "Display name"}, // 日本語の表示名がなければ英語
+	}
+	for _, tt := range tests {
+		if got := fieldLabel(tt.locale, tt.path); got != tt.want {
+			t.Errorf("fieldLabel(%s, %q) = %q, want %q", tt.locale, tt.path, got, tt.want)
+		}
+	}
+}
+
+// TestValidationErrorLocalize バリデーションエラーのメッセージを言語ごとに生成し直すテスト
+func TestValidationErrorLocalize(t *testing.T) {
+	v := NewValidator()
+	v.String("name", "", Required())
+	v.String("username", "abcdefg", MaxLength(5))
+	v.String("status", "archived", OneOf("active", "inactive"))
+	validationErr := v.Err().(*ValidationError)
+
+	localized := validationErr.Localize(LocaleJA)
+	want := []string{"名前は必須です", "ユーザー名は5文字以内で入力してください", "ステータスはactive, inactiveのいずれかを指定してください"}
+	for i, message := range want {
+		if localized.Errors[i].Message != message {
+			t.Errorf("Errors[%d].Message = %q, want %q", i, localized.Errors[i].Message, message)
+		}
+	}
+	if localized.Message != want[0] || localized.Field != "name" {
+		t.Errorf("先頭のエラーが設定されていない: %+v", localized)
+	}
+	if validationErr.Message != "Name is required" {
+		t.Errorf("元のエラーが変更された: %q", validationErr.Message)
+	}
+
+	unknown := NewValidationError("admin", ValidationCodeUnknownField, "validation.unknown_field", nil)
+	if got := unknown.Localize(LocaleJA).Message; got != `未知のフィールド "admin" が指定されました` {
+		t.Errorf("フィールド名が埋め込まれていない: %q", got)
+	}
+
+	// キーを持たないエラーはそのまま
+	literal := &ValidationError{Field: "name", Message: "Name is required"}
+	if got := literal.Localize(LocaleJA); got.Message != "Name is required" {
+		t.Errorf("キーのないエラーが変更された: %+v", got)
+	}
+}
+
+// TestDecodeJSON リクエストボディのデコードのテスト
+func TestDecodeJSON(t *testing.T) {
+	type request struct {
+		Name  string `json:"name"`
+		Count int    `json:"count"`
+	}
+	tests := []struct {
+		name       string
+		body       string
+		wantField  string
+		wantCode   string
+		wantOffset interface{}
+	}{
+		{name: "Valid body", body: `{"name":"Alice","count":1}`},
+		{name: "Trailing whitespace", body: "{\"name\":\"Alice\"}\n"},
+		{name: "Unknown field", body: `{"name":"Alice","admin":true}`, wantField: "admin", wantCode: ValidationCodeUnknownField},
+		{name: "Type mismatch", body: `{"name":1}`, wantField: "name", wantCode: ValidationCodeInvalid},
+		{name: "Syntax error", body: `{"name" "Alice"}`, wantField: "body", wantCode: ValidationCodeInvalid, wantOffset: int64(8)},
+		{name: "Truncated JSON", body: `{"name":`, wantField: "body", wantCode: ValidationCodeInvalid, wantOffset: int64(8)},
+		{name: "Trailing data", body: `{"name":"Alice"} {"name":"Bob"}`, wantField: "body", wantCode: ValidationCodeInvalid, wantOffset: int64(17)},
+		{name: "Trailing garbage", body: `{"name":"Alice"}x`, wantField: "body", wantCode: ValidationCodeInvalid, wantOffset: int64(16)},
+		{name: "Empty body", body: ``, wantField: "body", wantCode: ValidationCodeRequired},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got request
+			err := DecodeJSON(strings.NewReader(tt.body), &got)
+			if tt.wantField == "" {
+				if err != nil {
+					t.Errorf("DecodeJSON() error = %v, want nil", err)
+				}
+				return
+			}
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("DecodeJSON() error = %v, want ValidationError", err)
+			}
+			if validationErr.Field != tt.wantField || validationErr.Code != tt.wantCode || len(validationErr.Errors) != 1 {
+				t.Errorf("DecodeJSON() error = %+v, want field %q code %q", validationErr, tt.wantField, tt.wantCode)
+			}
+			if tt.wantOffset != nil && validationErr.Errors[0].Params["offset"] != tt.wantOffset {
+				t.Errorf("offset = %v, want %v", validationErr.Errors[0].Params["offset"], tt.wantOffset)
+			}
+		})
+	}
+
+	var got request
+	if err := DecodeJSON(strings.NewReader(`{"name":1}`), &got); err.Error() != "Name must be a string" {
+		t.Errorf("型の不一致のメッセージ = %q", err.Error())
+	}
+	if err := DecodeJSON(strings.NewReader(`{"name" "Alice"}`), &got); err.Error() != "Invalid JSON at byte offset 8" {
+		t.Errorf("構文エラーのメッセージ = %q", err.Error())
+	}
+
+	// 読み込みのエラーはそのまま返す
+	readErr := errors.New("connection reset")
+	if err := DecodeJSON(io.MultiReader(strings.NewReader(`{"name":`), iotest.ErrReader(readErr)), &got); !errors.Is(err, readErr) {
+		t.Errorf("DecodeJSON() error = %v, want %v", err, readErr)
+	}
+}
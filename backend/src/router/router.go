@@ -3,16 +3,51 @@ package router
 import (
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	chimiddleware "github.com/go-chi/chi/v5/middleware"
 
 	"backend/handler"
 	custommiddleware "backend/middleware"
+	"backend/models"
+	"backend/utils"
 )
 
+// Handlers ルーターに登録するハンドラーと認証処理の一式
+type Handlers struct {
+	Health        *handler.HealthHandler
+	HelloWorld    *handler.HelloWorldHandler
+	Auth          *handler.AuthHandler
+	Profile       *handler.ProfileHandler
+	RBAC          *handler.RBACHandler
+	Customers     *handler.CustomerHandler
+	Mails         *handler.MailHandler
+	Teams         *handler.TeamHandler
+	Notifications *handler.NotificationHandler
+	Sales         *handler.SaleHandler
+	Stats         *handler.StatsHandler
+	Events        *handler.EventsHandler
+	Realtime      *handler.WebSocketHandler
+	Authenticator custommiddleware.Authenticator // 認証必須ルートのアクセストークン検証
+}
+
+// streamingPaths 接続を維持してレスポンスを送り続けるエンドポイント
+// 同時実行数の制限（Throttle）とタイムアウト（Timeout）の対象外にします
+var streamingPaths = map[string]bool{
+	"/api/events": true,
+	"/api/ws":     true,
+}
+
+// unlessStreaming ストリーミング以外のリクエストにのみミドルウェアを適用
+func unlessStreaming(mw func(http.Handler) http.Handler) func(http.Handler) http.Handler {
+	return chimiddleware.Maybe(mw, func(r *http.Request) bool {
+		return !streamingPaths[r.URL.Path]
+	})
+}
+
 // NewRouter 新しいルーターを作成
-func NewRouter(healthHandler *handler.HealthHandler, helloWorldHandler *handler.HelloWorldHandler) http.Handler {
+func NewRouter(h Handlers) http.Handler {
 	r := chi.NewRouter()
 
 	// ミドルウェア設定
@@ -22,27 +57,180 @@ func NewRouter(healthHandler *handler.HealthHandler, helloWorldHandler *handler.
 	r.Use(chimiddleware.RealIP)
 	r.Use(chimiddleware.NoCache)
 	r.Use(chimiddleware.GetHead)
-	r.Use(chimiddleware.Throttle(100))
-	r.Use(chimiddleware.Timeout(60))
+	r.Use(unlessStreaming(chimiddleware.Throttle(100)))
+	r.Use(unlessStreaming(chimiddleware.Timeout(60 * time.Second)))
 
 	// カスタムミドルウェア
+	r.Use(custommiddleware.Localize)
+	r.Use(custommiddleware.ProblemDetails)
 	r.Use(custommiddleware.ErrorHandler)
 	r.Use(custommiddleware.CORS)
+	r.Use(custommiddleware.LimitRequestBody(utils.MaxRequestSize))
+
+	requireAuth := custommiddleware.RequireAuth(h.Authenticator)
 
 	// ルートエンドポイント
-	r.Get("/", helloWorldHandler.RootHandler)
+	r.Get("/", h.HelloWorld.RootHandler)
 
 	// APIグループ
 	r.Route("/api", func(api chi.Router) {
 		// ヘルスチェック
-		api.Get("/health", healthHandler.HealthCheckHandler)
+		api.Get("/health", h.Health.HealthCheckHandler)
+
+		// 認証 API
+		api.Route("/auth", func(auth chi.Router) {
+			auth.Use(custommiddleware.LimitRequestBody(utils.MaxAuthRequestSize))
+			auth.Post("/register", h.Auth.RegisterHandler)
+			auth.Post("/login", h.Auth.LoginHandler)
+			auth.Post("/login/mfa", h.Auth.LoginMFAHandler)
+			auth.Post("/refresh", h.Auth.RefreshHandler)
+
+			// 認証情報の管理（APIキーでは操作できない）
+			auth.Group(func(account chi.Router) {
+				account.Use(requireAuth)
+				account.Use(custommiddleware.RequireSession)
+
+				account.Get("/sessions", h.Auth.ListSessionsHandler)
+				account.Delete("/sessions", h.Auth.RevokeOtherSessionsHandler)
+				account.Delete("/sessions/{id}", h.Auth.RevokeSessionHandler)
+
+				// 二要素認証の設定
+				account.Route("/mfa", func(mfa chi.Router) {
+					mfa.Get("/", h.Auth.MFAStatusHandler)
+					mfa.Post("/totp/enroll", h.Auth.EnrollTOTPHandler)
+					mfa.Post("/totp/verify", h.Auth.VerifyTOTPHandler)
+					mfa.Delete("/totp", h.Auth.DisableTOTPHandler)
+					mfa.Post("/recovery-codes", h.Auth.RegenerateRecoveryCodesHandler)
+				})
+
+				// 個人用APIキー
+				account.Get("/api-keys", h.Auth.ListAPIKeysHandler)
+				account.Post("/api-keys", h.Auth.CreateAPIKeyHandler)
+				account.Delete("/api-keys/{id}", h.Auth.RevokeAPIKeyHandler)
+			})
+		})
+
+		// プロフィール・アカウント設定 API（プロフィール・通知設定はAPIキーのスコープで絞り込めないため、変更はAPIキーでは操作できない）
+		api.Route("/me", func(me chi.Router) {
+			me.Use(requireAuth)
+			me.Get("/", h.Profile.GetProfileHandler)
+			me.With(custommiddleware.RequireSession).Patch("/", h.Profile.UpdateProfileHandler)
+			me.With(custommiddleware.RequireSession).Post("/email/confirm", h.Profile.ConfirmEmailChangeHandler)
+			me.With(custommiddleware.RequireSession).Delete("/", h.Profile.DeleteAccountHandler)
+			me.Get("/notification-preferences", h.Notifications.GetPreferencesHandler)
+			me.With(custommiddleware.RequireSession).Put("/notification-preferences", h.Notifications.UpdatePreferencesHandler)
+		})
+
+		// ロール管理 API（roles:manage 権限が必要）
+		api.Group(func(admin chi.Router) {
+			admin.Use(requireAuth)
+			admin.Use(custommiddleware.RequirePermission(models.PermissionRolesManage))
+			admin.Get("/roles", h.RBAC.ListRolesHandler)
+			admin.Get("/users/{id}/roles", h.RBAC.GetUserRolesHandler)
+			admin.Put("/users/{id}/roles/{role}", h.RBAC.AssignRoleHandler)
+			admin.Delete("/users/{id}/roles/{role}", h.RBAC.RevokeRoleHandler)
+		})
+
+		// 顧客 API（参照は認証済みユーザー、作成・更新・削除はそれぞれの権限が必要）
+		api.Route("/customers", func(customers chi.Router) {
+			customers.Use(requireAuth)
+			customers.Get("/", h.Customers.ListCustomersHandler)
+			customers.Get("/{id}", h.Customers.GetCustomerHandler)
+			customers.With(custommiddleware.RequirePermission(models.PermissionCustomersWrite)).
+				Post("/", h.Customers.CreateCustomerHandler)
+			customers.With(custommiddleware.RequirePermission(models.PermissionCustomersWrite)).
+				Put("/{id}", h.Customers.UpdateCustomerHandler)
+			customers.With(custommiddleware.RequirePermission(models.PermissionCustomersWrite)).
+				Patch("/{id}", h.Customers.PatchCustomerHandler)
+			customers.With(custommiddleware.RequirePermission(models.PermissionCustomersDelete)).
+				Delete("/{id}", h.Customers.DeleteCustomerHandler)
+		})
+
+		// 受信箱 API（既読状態はユーザーごと、メールの登録は mails:write 権限が必要）
+		// 既読状態はAPIキーのスコープで絞り込めないため、変更はAPIキーでは行えない
+		api.Route("/mails", func(mails chi.Router) {
+			mails.Use(requireAuth)
+			mails.Get("/", h.Mails.ListMailsHandler)
+			mails.Get("/unread-count", h.Mails.UnreadCountHandler)
+			mails.Get("/{id}", h.Mails.GetMailHandler)
+			mails.Get("/{id}/thread", h.Mails.GetThreadHandler)
+			mails.With(custommiddleware.RequireSession).Patch("/{id}", h.Mails.UpdateMailHandler)
+			mails.With(custommiddleware.RequirePermission(models.PermissionMailsWrite)).
+				Post("/", h.Mails.CreateMailHandler)
+		})
+
+		// チーム API（各操作はリクエストしたユーザーのチーム内ロールで認可）
+		// チームの権限はAPIキーのスコープで絞り込めないため、変更はAPIキーでは行えない
+		api.Route("/teams", func(teams chi.Router) {
+			teams.Use(requireAuth)
+			teams.Get("/", h.Teams.ListTeamsHandler)
+			teams.Get("/{id}", h.Teams.GetTeamHandler)
+			teams.Get("/{id}/members", h.Teams.ListMembersHandler)
+			teams.Get("/{id}/invitations", h.Teams.ListInvitationsHandler)
+
+			teams.Group(func(manage chi.Router) {
+				manage.Use(custommiddleware.RequireSession)
+				manage.Post("/", h.Teams.CreateTeamHandler)
+				manage.Patch("/{id}", h.Teams.UpdateTeamHandler)
+				manage.Delete("/{id}", h.Teams.DeleteTeamHandler)
+				manage.Patch("/{id}/members/{userID}", h.Teams.UpdateMemberRoleHandler)
+				manage.Delete("/{id}/members/{userID}", h.Teams.RemoveMemberHandler)
+				manage.Post("/{id}/invitations", h.Teams.CreateInvitationHandler)
+				manage.Delete("/{id}/invitations/{invitationID}", h.Teams.RevokeInvitationHandler)
+			})
+		})
+		api.With(requireAuth, custommiddleware.RequireSession).Post("/invitations/accept", h.Teams.AcceptInvitationHandler)
+
+		// 通知 API（認証ユーザー宛ての通知のみ参照・既読化できる、既読化はAPIキーでは行えない）
+		api.Route("/notifications", func(notifications chi.Router) {
+			notifications.Use(requireAuth)
+			notifications.Get("/", h.Notifications.ListNotificationsHandler)
+			notifications.Get("/unread-count", h.Notifications.UnreadCountHandler)
 
-		// Hello World API
+			notifications.Group(func(read chi.Router) {
+				read.Use(custommiddleware.RequireSession)
+				read.Post("/read-all", h.Notifications.MarkAllReadHandler)
+				read.Post("/{id}/read", h.Notifications.MarkReadHandler)
+			})
+		})
+
+		// 売上台帳 API（参照は認証済みユーザー、登録は sales:write、返金は sales:refund 権限が必要）
+		api.Route("/sales", func(sales chi.Router) {
+			sales.Use(requireAuth)
+			sales.Get("/", h.Sales.ListSalesHandler)
+			sales.Get("/{id}", h.Sales.GetSaleHandler)
+			sales.With(custommiddleware.RequirePermission(models.PermissionSalesWrite)).
+				Post("/", h.Sales.CreateSaleHandler)
+			sales.With(custommiddleware.RequirePermission(models.PermissionSalesRefund)).
+				Patch("/{id}", h.Sales.UpdateSaleStatusHandler)
+		})
+
+		// 売上統計 API（ダッシュボードの集計表示用）
+		api.With(requireAuth).Get("/stats", h.Stats.GetStatsHandler)
+
+		// イベントストリーム（Server-Sent Events）
+		api.With(requireAuth).Get("/events", h.Events.StreamHandler)
+
+		// WebSocket（ルームのプレゼンス・入力中の表示とイベントの配信）
+		api.With(requireAuth).Get("/ws", h.Realtime.ServeWebSocket)
+
+		// Hello World API（参照は公開、作成・更新・削除は認証とそれぞれの権限が必要）
 		api.Route("/hello-world", func(hello chi.Router) {
-			hello.Get("/", helloWorldHandler.GetHelloWorldHandler)
-			hello.Post("/", helloWorldHandler.CreateHelloWorldHandler)
-			hello.Get("/messages", helloWorldHandler.GetHelloWorldMessagesHandler)
-			hello.Get("/messages/{id}", helloWorldHandler.GetHelloWorldMessageByIDHandler)
+			hello.Get("/", h.HelloWorld.GetHelloWorldHandler)
+			hello.Get("/messages", h.HelloWorld.GetHelloWorldMessagesHandler)
+			hello.Get("/messages/{id}", h.HelloWorld.GetHelloWorldMessageByIDHandler)
+
+			hello.Group(func(protected chi.Router) {
+				protected.Use(requireAuth)
+				protected.With(custommiddleware.RequirePermission(models.PermissionMessagesCreate)).
+					Post("/", h.HelloWorld.CreateHelloWorldHandler)
+				protected.With(custommiddleware.RequirePermission(models.PermissionMessagesUpdate)).
+					Put("/messages/{id}", h.HelloWorld.UpdateHelloWorldMessageHandler)
+				protected.With(custommiddleware.RequirePermission(models.PermissionMessagesUpdate)).
+					Patch("/messages/{id}", h.HelloWorld.PatchHelloWorldMessageHandler)
+				protected.With(custommiddleware.RequirePermission(models.PermissionMessagesDelete)).
+					Delete("/messages/{id}", h.HelloWorld.DeleteHelloWorldMessageHandler)
+			})
 		})
 	})
 
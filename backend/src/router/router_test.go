@@ -4,19 +4,74 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"backend/handler"
+	"backend/models"
+	"backend/services"
+	"backend/utils"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
+// newTestHandlers DBなしで動作するテスト用ハンドラー一式（認証はメモリリポジトリ）
+func newTestHandlers(t *testing.T) (Handlers, *services.AuthService) {
+	t.Helper()
+
+	hasher, err := services.NewPasswordHasher(utils.PasswordHashBcrypt)
+	require.NoError(t, err)
+	tokens, err := services.NewTokenManager(services.TokenConfig{
+		Algorithm:  utils.JWTAlgorithmHS256,
+		Secret:     "test-secret",
+		AccessTTL:  15 * time.Minute,
+		RefreshTTL: time.Hour,
+	})
+	require.NoError(t, err)
+	repos, err := services.NewRepositories(utils.StorageDriverMemory, nil)
+	require.NoError(t, err)
+	authService, err := services.NewAuthService(repos, hasher, tokens)
+	require.NoError(t, err)
+
+	eventBus := services.NewMemoryEventBus()
+	eventBroker := services.NewEventBroker(100)
+	eventBus.Subscribe(eventBroker.Dispatch)
+	realtimeHub := services.NewRealtimeHub(eventBus)
+	eventBus.Subscribe(realtimeHub.Dispatch)
+	notificationService := services.NewNotificationService(repos.Notifications, repos.Preferences, repos.Users, eventBus, services.NotificationEmailConfig{})
+	teamService := services.NewTeamService(repos.Teams, repos.Users, services.LogMailer{}, services.TeamInvitationConfig{
+		AcceptURL: "http://localhost:3000/invitations/accept",
+		TTL:       time.Hour,
+	})
+	profileService := services.NewProfileService(repos.Users, repos.Accounts, hasher, services.LogMailer{}, services.EmailChangeConfig{
+		ConfirmURL: "http://localhost:3000/settings/confirm-email",
+		TTL:        time.Hour,
+	})
+
+	return Handlers{
+		Health:        handler.NewHealthHandler(nil),
+		HelloWorld:    handler.NewHelloWorldHandler(nil),
+		Auth:          handler.NewAuthHandler(authService),
+		Profile:       handler.NewProfileHandler(profileService),
+		RBAC:          handler.NewRBACHandler(services.NewRBACService(repos.Roles, repos.Users)),
+		Customers:     handler.NewCustomerHandler(services.NewCustomerService(repos.Customers, notificationService)),
+		Mails:         handler.NewMailHandler(services.NewMailService(repos.Mails, notificationService)),
+		Teams:         handler.NewTeamHandler(teamService),
+		Notifications: handler.NewNotificationHandler(notificationService),
+		Sales:         handler.NewSaleHandler(services.NewSaleService(repos.Sales)),
+		Stats:         handler.NewStatsHandler(services.NewStatsService(repos.Sales)),
+		Events:        handler.NewEventsHandler(eventBroker, time.Second),
+		Realtime:      handler.NewWebSocketHandler(realtimeHub, time.Second),
+		Authenticator: authService,
+	}, authService
+}
+
 // TestNewRouter ルーター作成のテスト
 func TestNewRouter(t *testing.T) {
 	// ハンドラーを作成
-	healthHandler := handler.NewHealthHandler(nil)
-	helloWorldHandler := handler.NewHelloWorldHandler(nil)
+	handlers, _ := newTestHandlers(t)
 
 	// ルーターを作成
-	r := NewRouter(healthHandler, helloWorldHandler)
+	r := NewRouter(handlers)
 
 	// ルーターがnilでないことを確認
 	assert.NotNil(t, r)
@@ -25,11 +80,10 @@ func TestNewRouter(t *testing.T) {
 // TestRouterEndpoints ルーターのエンドポイントテスト
 func TestRouterEndpoints(t *testing.T) {
 	// ハンドラーを作成
-	healthHandler := handler.NewHealthHandler(nil)
-	helloWorldHandler := handler.NewHelloWorldHandler(nil)
+	handlers, _ := newTestHandlers(t)
 
 	// ルーターを作成
-	r := NewRouter(healthHandler, helloWorldHandler)
+	r := NewRouter(handlers)
 
 	// テストケース
 	testCases := []struct {
@@ -43,6 +97,36 @@ func TestRouterEndpoints(t *testing.T) {
 		{"Hello World GET", "GET", "/api/hello-world", http.StatusOK},
 		{"Not found", "GET", "/api/nonexistent", http.StatusNotFound},
 		{"Method not allowed", "PUT", "/api/hello-world", http.StatusMethodNotAllowed},
+		{"Hello World POST requires auth", "POST", "/api/hello-world", http.StatusUnauthorized},
+		{"Hello World message PUT requires auth", "PUT", "/api/hello-world/messages/1", http.StatusUnauthorized},
+		{"Hello World message PATCH requires auth", "PATCH", "/api/hello-world/messages/1", http.StatusUnauthorized},
+		{"Hello World message DELETE requires auth", "DELETE", "/api/hello-world/messages/1", http.StatusUnauthorized},
+		{"Auth login GET not allowed", "GET", "/api/auth/login", http.StatusMethodNotAllowed},
+		{"Roles requires auth", "GET", "/api/roles", http.StatusUnauthorized},
+		{"Sessions requires auth", "GET", "/api/auth/sessions", http.StatusUnauthorized},
+		{"Session revoke requires auth", "DELETE", "/api/auth/sessions/abc", http.StatusUnauthorized},
+		{"MFA status requires auth", "GET", "/api/auth/mfa", http.StatusUnauthorized},
+		{"TOTP enroll requires auth", "POST", "/api/auth/mfa/totp/enroll", http.StatusUnauthorized},
+		{"API keys requires auth", "GET", "/api/auth/api-keys", http.StatusUnauthorized},
+		{"Profile requires auth", "GET", "/api/me", http.StatusUnauthorized},
+		{"Account delete requires auth", "DELETE", "/api/me", http.StatusUnauthorized},
+		{"Email confirm requires auth", "POST", "/api/me/email/confirm", http.StatusUnauthorized},
+		{"Notification preferences require auth", "GET", "/api/me/notification-preferences", http.StatusUnauthorized},
+		{"Sales requires auth", "GET", "/api/sales", http.StatusUnauthorized},
+		{"Sale refund requires auth", "PATCH", "/api/sales/1", http.StatusUnauthorized},
+		{"Stats requires auth", "GET", "/api/stats", http.StatusUnauthorized},
+		{"Customers requires auth", "GET", "/api/customers", http.StatusUnauthorized},
+		{"Customer DELETE requires auth", "DELETE", "/api/customers/1", http.StatusUnauthorized},
+		{"Mails requires auth", "GET", "/api/mails", http.StatusUnauthorized},
+		{"Mail unread count requires auth", "GET", "/api/mails/unread-count", http.StatusUnauthorized},
+		{"Teams requires auth", "GET", "/api/teams", http.StatusUnauthorized},
+		{"Team members requires auth", "GET", "/api/teams/1/members", http.StatusUnauthorized},
+		{"Accept invitation requires auth", "POST", "/api/invitations/accept", http.StatusUnauthorized},
+		{"Notifications requires auth", "GET", "/api/notifications", http.StatusUnauthorized},
+		{"Notification unread count requires auth", "GET", "/api/notifications/unread-count", http.StatusUnauthorized},
+		{"Mark notifications read requires auth", "POST", "/api/notifications/read-all", http.StatusUnauthorized},
+		{"Events requires auth", "GET", "/api/events", http.StatusUnauthorized},
+		{"WebSocket requires auth", "GET", "/api/ws", http.StatusUnauthorized},
 	}
 
 	for _, tc := range testCases {
@@ -63,14 +147,30 @@ func TestRouterEndpoints(t *testing.T) {
 	}
 }
 
+// TestUnlessStreaming ストリーミングのエンドポイントを Throttle・Timeout の対象外にするテスト
+func TestUnlessStreaming(t *testing.T) {
+	applied := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Applied", "true")
+			next.ServeHTTP(w, r)
+		})
+	}
+	h := unlessStreaming(applied)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	for path, expected := range map[string]string{"/api/health": "true", "/api/events": "", "/api/ws": ""} {
+		rr := httptest.NewRecorder()
+		h.ServeHTTP(rr, httptest.NewRequest("GET", path, nil))
+		assert.Equal(t, expected, rr.Header().Get("X-Applied"), path)
+	}
+}
+
 // TestRouterMiddleware ルーターのミドルウェアテスト
 func TestRouterMiddleware(t *testing.T) {
 	// ハンドラーを作成
-	healthHandler := handler.NewHealthHandler(nil)
-	helloWorldHandler := handler.NewHelloWorldHandler(nil)
+	handlers, _ := newTestHandlers(t)
 
 	// ルーターを作成
-	r := NewRouter(healthHandler, helloWorldHandler)
+	r := NewRouter(handlers)
 
 	// テストリクエストを作成
 	req, err := http.NewRequest("GET", "/api/health", nil)
@@ -85,4 +185,78 @@ func TestRouterMiddleware(t *testing.T) {
 	// レスポンスヘッダーを確認（ミドルウェアが適用されていることを確認）
 	assert.Equal(t, http.StatusOK, rr.Code)
 	assert.NotEmpty(t, rr.Header().Get("Content-Type"))
-} 
\ No newline at end of file
+}
+
+// TestRouterAuthenticatedRoutes 認証済みリクエストのテスト
+func TestRouterAuthenticatedRoutes(t *testing.T) {
+	handlers, authService := newTestHandlers(t)
+	r := NewRouter(handlers)
+
+	tokens, err := authService.Register(&models.RegisterRequest{Email: "router@example.com", Password: "password123", Name: "Router"}, models.ClientInfo{})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		method   string
+		path     string
+		expected int
+	}{
+		{"Hello World message PUT invalid ID", "PUT", "/api/hello-world/messages/abc", http.StatusBadRequest},
+		{"Hello World message PATCH invalid ID", "PATCH", "/api/hello-world/messages/abc", http.StatusBadRequest},
+		{"Hello World message DELETE invalid ID", "DELETE", "/api/hello-world/messages/abc", http.StatusBadRequest},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req, err := http.NewRequest(tc.method, tc.path, nil)
+			assert.NoError(t, err)
+			req.Header.Set("Authorization", "Bearer "+tokens.AccessToken)
+
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expected, rr.Code)
+		})
+	}
+}
+
+// TestRouterPermissions ルートごとの権限宣言のテスト（最初の登録者が owner、以降は member）
+func TestRouterPermissions(t *testing.T) {
+	handlers, authService := newTestHandlers(t)
+	r := NewRouter(handlers)
+
+	owner, err := authService.Register(&models.RegisterRequest{Email: "owner@example.com", Password: "password123", Name: "Owner"}, models.ClientInfo{})
+	require.NoError(t, err)
+	member, err := authService.Register(&models.RegisterRequest{Email: "member@example.com", Password: "password123", Name: "Member"}, models.ClientInfo{})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		token    string
+		method   string
+		path     string
+		expected int
+	}{
+		{"Member can update", member.AccessToken, "PUT", "/api/hello-world/messages/abc", http.StatusBadRequest},
+		{"Member cannot delete", member.AccessToken, "DELETE", "/api/hello-world/messages/1", http.StatusForbidden},
+		{"Member cannot list roles", member.AccessToken, "GET", "/api/roles", http.StatusForbidden},
+		{"Owner can delete", owner.AccessToken, "DELETE", "/api/hello-world/messages/abc", http.StatusBadRequest},
+		{"Owner can list roles", owner.AccessToken, "GET", "/api/roles", http.StatusOK},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req, err := http.NewRequest(tc.method, tc.path, nil)
+			require.NoError(t, err)
+			req.Header.Set("Authorization", "Bearer "+tc.token)
+
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expected, rr.Code)
+			if tc.expected == http.StatusForbidden {
+				assert.Contains(t, rr.Body.String(), `"error":"forbidden"`)
+			}
+		})
+	}
+}
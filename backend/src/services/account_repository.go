@@ -0,0 +1,23 @@
+package services
+
+import "backend/models"
+
+var (
+	// ErrLastOwnerAccount 最後の owner がアカウントを削除しようとした
+	ErrLastOwnerAccount = newError(ErrConflict, "cannot delete the last owner account", "profile.last_owner")
+
+	// ErrSoleTeamOwnerAccount 唯一の owner であるチームがあるユーザーがアカウントを削除しようとした
+	ErrSoleTeamOwnerAccount = newError(ErrConflict, "cannot delete the sole owner of a team", "profile.sole_team_owner")
+)
+
+// AccountRepository ユーザー・ロール・チームにまたがるアカウントの作成・削除
+type AccountRepository interface {
+	// CreateAccount ユーザーを作成して初期ロール（最初のユーザーは owner、以降は member）を付与する
+	// 作成と付与は不可分に行い、どちらかに失敗した場合はユーザーを残さない
+	// メールアドレスが使用済みであれば ErrEmailAlreadyExists を返す
+	CreateAccount(email, name, passwordHash string) (*models.User, error)
+	// DeleteAccount ユーザーをロール・チームのメンバーシップごと削除する
+	// 最後の owner であれば ErrLastOwnerAccount、唯一の owner であるチームがあれば ErrSoleTeamOwnerAccount を返して何も削除しない
+	// owner の判定と削除は、ロールの剥奪・チームのメンバー変更と同時に実行されても owner が0人にならないよう不可分に行う
+	DeleteAccount(userID int) error
+}
@@ -0,0 +1,165 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"backend/models"
+	"backend/utils"
+)
+
+// TestMemoryAccountRepositoryConformance メモリアカウントリポジトリの適合テスト
+func TestMemoryAccountRepositoryConformance(t *testing.T) {
+	runAccountRepositoryConformance(t, func(t *testing.T) *Repositories {
+		users := NewMemoryUserRepository()
+		roles := NewMemoryRoleRepository()
+		teams := NewMemoryTeamRepository(users)
+		return &Repositories{Users: users, Roles: roles, Teams: teams, Accounts: NewMemoryAccountRepository(users, roles, teams)}
+	})
+}
+
+// TestPostgresAccountRepositoryConformance PostgreSQLアカウントリポジトリの適合テスト
+func TestPostgresAccountRepositoryConformance(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	runAccountRepositoryConformance(t, func(t *testing.T) *Repositories {
+		repos, err := NewRepositories(utils.StorageDriverPostgres, db)
+		if err != nil {
+			t.Fatalf("NewRepositories失敗: %v", err)
+		}
+		return repos
+	})
+}
+
+// runAccountRepositoryConformance 全てのAccountRepository実装が満たすべき振る舞い
+// 共有DBでも動作するよう、ユーザーは一意なメールアドレスで都度作成します
+func runAccountRepositoryConformance(t *testing.T, newRepos func(t *testing.T) *Repositories) {
+	createUser := func(t *testing.T, repos *Repositories, role string) *models.User {
+		t.Helper()
+		user, err := repos.Users.Create(fmt.Sprintf("account%d@example.com", time.Now().UnixNano()), "Account", "hash")
+		if err != nil {
+			t.Fatalf("ユーザー作成失敗: %v", err)
+		}
+		if err := repos.Roles.AssignRole(user.ID, role); err != nil {
+			t.Fatalf("AssignRole失敗: %v", err)
+		}
+		return user
+	}
+
+	t.Run("CreateAccount", func(t *testing.T) {
+		repos := newRepos(t)
+		createUser(t, repos, models.RoleOwner)
+		email := fmt.Sprintf("account%d@example.com", time.Now().UnixNano())
+
+		user, err := repos.Accounts.CreateAccount(email, "Account", "hash")
+		if err != nil {
+			t.Fatalf("CreateAccount失敗: %v", err)
+		}
+		if user.ID == 0 || user.Email != email {
+			t.Errorf("作成したユーザーが不正: %+v", user)
+		}
+		// owner が既にいるため member を付与する
+		if roles, _ := repos.Roles.RolesForUser(user.ID); len(roles) != 1 || roles[0] != models.RoleMember {
+			t.Errorf("初期ロール = %v, want [member]", roles)
+		}
+
+		if _, err := repos.Accounts.CreateAccount(email, "Again", "hash"); !errors.Is(err, ErrEmailAlreadyExists) {
+			t.Errorf("使用済みのメールアドレスで CreateAccount() error = %v, want ErrEmailAlreadyExists", err)
+		}
+	})
+
+	t.Run("DeleteAccount", func(t *testing.T) {
+		repos := newRepos(t)
+		createUser(t, repos, models.RoleOwner)
+		user := createUser(t, repos, models.RoleMember)
+		team, err := repos.Teams.Create(&models.Team{Name: "Account Team"}, createUser(t, repos, models.RoleMember).ID)
+		if err != nil {
+			t.Fatalf("チーム作成失敗: %v", err)
+		}
+		invitation, err := repos.Teams.CreateInvitation(&models.TeamInvitation{
+			TeamID: team.ID, Email: user.Email, Role: models.TeamRoleMember,
+			TokenHash: hashToken(user.Email), ExpiresAt: time.Now().Add(time.Hour),
+		})
+		if err != nil {
+			t.Fatalf("CreateInvitation失敗: %v", err)
+		}
+		if _, err := repos.Teams.AcceptInvitation(invitation.ID, user.ID); err != nil {
+			t.Fatalf("AcceptInvitation失敗: %v", err)
+		}
+
+		if err := repos.Accounts.DeleteAccount(user.ID); err != nil {
+			t.Fatalf("DeleteAccount失敗: %v", err)
+		}
+		if _, err := repos.Users.FindByID(user.ID); !errors.Is(err, ErrUserNotFound) {
+			t.Errorf("削除後もユーザーを取得できる: %v", err)
+		}
+		if roles, _ := repos.Roles.RolesForUser(user.ID); len(roles) != 0 {
+			t.Errorf("削除したユーザーのロールが残っている: %v", roles)
+		}
+		if members, _ := repos.Teams.ListMembers(team.ID); len(members) != 1 {
+			t.Errorf("削除したユーザーがチームに残っている: %+v", members)
+		}
+		if err := repos.Accounts.DeleteAccount(user.ID); !errors.Is(err, ErrUserNotFound) {
+			t.Errorf("削除済みのユーザーで ErrUserNotFound が返らない: %v", err)
+		}
+	})
+
+	t.Run("SoleTeamOwner", func(t *testing.T) {
+		repos := newRepos(t)
+		createUser(t, repos, models.RoleOwner)
+		owner := createUser(t, repos, models.RoleMember)
+		if _, err := repos.Teams.Create(&models.Team{Name: "Sole Owner Team"}, owner.ID); err != nil {
+			t.Fatalf("チーム作成失敗: %v", err)
+		}
+
+		if err := repos.Accounts.DeleteAccount(owner.ID); !errors.Is(err, ErrSoleTeamOwnerAccount) {
+			t.Errorf("ErrSoleTeamOwnerAccount が返らない: %v", err)
+		}
+		if _, err := repos.Users.FindByID(owner.ID); err != nil {
+			t.Errorf("拒否されたのにユーザーが削除されている: %v", err)
+		}
+		if roles, _ := repos.Roles.RolesForUser(owner.ID); len(roles) != 1 {
+			t.Errorf("拒否されたのにロールが剥奪されている: %v", roles)
+		}
+	})
+
+	t.Run("LastOwnerConcurrent", func(t *testing.T) {
+		repos := newRepos(t)
+		first := createUser(t, repos, models.RoleOwner)
+		second := createUser(t, repos, models.RoleOwner)
+		if count, err := repos.Roles.CountUsersWithRole(models.RoleOwner); err != nil || count != 2 {
+			t.Skipf("共有DBに他の owner が存在するためスキップ: %d, %v", count, err)
+		}
+
+		// 2人の owner が同時に削除しても、どちらか一方は拒否される
+		var wg sync.WaitGroup
+		errs := make([]error, 2)
+		for i, userID := range []int{first.ID, second.ID} {
+			wg.Add(1)
+			go func(i, userID int) {
+				defer wg.Done()
+				errs[i] = repos.Accounts.DeleteAccount(userID)
+			}(i, userID)
+		}
+		wg.Wait()
+
+		failed := 0
+		for _, err := range errs {
+			if errors.Is(err, ErrLastOwnerAccount) {
+				failed++
+			} else if err != nil {
+				t.Errorf("予期しないエラー: %v", err)
+			}
+		}
+		if failed != 1 {
+			t.Errorf("ErrLastOwnerAccount がちょうど1回返らない: %v", errs)
+		}
+		if count, err := repos.Roles.CountUsersWithRole(models.RoleOwner); err != nil || count != 1 {
+			t.Errorf("owner が1人残っていない: %d, %v", count, err)
+		}
+	})
+}
@@ -0,0 +1,69 @@
+package services
+
+import (
+	"errors"
+
+	"backend/models"
+)
+
+// MemoryAccountRepository メモリ上で動作するアカウントリポジトリ
+// 各メモリリポジトリのロックを teams → roles → users の順に取得して判定と作成・削除を行います
+type MemoryAccountRepository struct {
+	users *MemoryUserRepository
+	roles *MemoryRoleRepository
+	teams *MemoryTeamRepository
+}
+
+// NewMemoryAccountRepository メモリアカウントリポジトリを新規作成
+func NewMemoryAccountRepository(users *MemoryUserRepository, roles *MemoryRoleRepository, teams *MemoryTeamRepository) *MemoryAccountRepository {
+	return &MemoryAccountRepository{users: users, roles: roles, teams: teams}
+}
+
+// CreateAccount ユーザーの作成と初期ロールの付与を roles → users のロックを保持したまま実行
+func (r *MemoryAccountRepository) CreateAccount(email, name, passwordHash string) (*models.User, error) {
+	r.roles.mu.Lock()
+	defer r.roles.mu.Unlock()
+	r.users.mu.Lock()
+	defer r.users.mu.Unlock()
+
+	user, err := r.users.createLocked(email, name, passwordHash)
+	if err != nil {
+		return nil, err
+	}
+	r.roles.assignDefaultLocked(user.ID)
+	return user, nil
+}
+
+// DeleteAccount ユーザーをロール・チームのメンバーシップごと削除
+func (r *MemoryAccountRepository) DeleteAccount(userID int) error {
+	r.teams.mu.Lock()
+	defer r.teams.mu.Unlock()
+	r.roles.mu.Lock()
+	defer r.roles.mu.Unlock()
+	r.users.mu.Lock()
+	defer r.users.mu.Unlock()
+
+	if _, ok := r.users.users[userID]; !ok {
+		return ErrUserNotFound
+	}
+	if r.roles.userRoles[userID][models.RoleOwner] && r.roles.countLocked(models.RoleOwner) <= 1 {
+		return ErrLastOwnerAccount
+	}
+	for key := range r.teams.members {
+		if key.userID != userID {
+			continue
+		}
+		if err := r.teams.checkOwnerRemains(key.teamID, userID, false); errors.Is(err, ErrLastTeamOwner) {
+			return ErrSoleTeamOwnerAccount
+		}
+	}
+
+	for key := range r.teams.members {
+		if key.userID == userID {
+			delete(r.teams.members, key)
+		}
+	}
+	delete(r.roles.userRoles, userID)
+	r.users.deleteLocked(userID)
+	return nil
+}
@@ -0,0 +1,120 @@
+package services
+
+import (
+	"database/sql"
+	"fmt"
+
+	"backend/models"
+)
+
+// PostgresAccountRepository PostgreSQLによるアカウントリポジトリ
+type PostgresAccountRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresAccountRepository PostgreSQLアカウントリポジトリを新規作成
+// db が nil の場合、全ての操作は ErrDatabaseUnavailable を返します
+func NewPostgresAccountRepository(db *sql.DB) *PostgresAccountRepository {
+	return &PostgresAccountRepository{db: db}
+}
+
+// CreateAccount ユーザーの作成と初期ロールの付与を1つのトランザクションで実行
+func (r *PostgresAccountRepository) CreateAccount(email, name, passwordHash string) (*models.User, error) {
+	if r.db == nil {
+		return nil, ErrDatabaseUnavailable
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO users (email, name, password_hash)
+		VALUES ($1, $2, $3)
+		RETURNING ` + userColumns
+
+	user, err := scanUser(tx.QueryRow(query, email, name, passwordHash))
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, ErrEmailAlreadyExists
+		}
+		return nil, fmt.Errorf("failed to create user: %w", err)
+	}
+	if _, err := assignDefaultRole(tx, user.ID); err != nil {
+		return nil, fmt.Errorf("failed to assign default role: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit account creation: %w", err)
+	}
+	return user, nil
+}
+
+// DeleteAccount ユーザーを削除（ロール・チームのメンバーシップは外部キーで削除）
+// owner ロールの行と、ユーザーが owner のチームの行をロックしてから判定するため、
+// RevokeRole・checkOwnerRemains と同時に実行されても owner は0人になりません
+func (r *PostgresAccountRepository) DeleteAccount(userID int) error {
+	if r.db == nil {
+		return ErrDatabaseUnavailable
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	ownerRoleID, err := lockRole(tx, models.RoleOwner)
+	if err != nil {
+		return err
+	}
+	var isOwner bool
+	if err := tx.QueryRow(`SELECT EXISTS (SELECT 1 FROM user_roles WHERE user_id = $1 AND role_id = $2)`, userID, ownerRoleID).Scan(&isOwner); err != nil {
+		return fmt.Errorf("failed to check owner: %w", err)
+	}
+	if isOwner {
+		var owners int
+		if err := tx.QueryRow(`SELECT COUNT(*) FROM user_roles WHERE role_id = $1`, ownerRoleID).Scan(&owners); err != nil {
+			return fmt.Errorf("failed to count owners: %w", err)
+		}
+		if owners <= 1 {
+			return ErrLastOwnerAccount
+		}
+	}
+
+	var soleOwner bool
+	err = tx.QueryRow(`
+		WITH owned AS (
+			SELECT t.id FROM teams t
+			JOIN team_members tm ON tm.team_id = t.id
+			WHERE tm.user_id = $1 AND tm.role = $2
+			ORDER BY t.id
+			FOR UPDATE OF t
+		)
+		SELECT EXISTS (
+			SELECT 1 FROM owned o
+			WHERE (SELECT COUNT(*) FROM team_members WHERE team_id = o.id AND role = $2) <= 1
+		)
+	`, userID, models.TeamRoleOwner).Scan(&soleOwner)
+	if err != nil {
+		return fmt.Errorf("failed to check team owners: %w", err)
+	}
+	if soleOwner {
+		return ErrSoleTeamOwnerAccount
+	}
+
+	result, err := tx.Exec(`DELETE FROM users WHERE id = $1`, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete user: %w", err)
+	}
+	if err := requireAffected(result, ErrUserNotFound); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit account deletion: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package services
+
+import (
+	"time"
+
+	"backend/models"
+)
+
+var (
+	// ErrAPIKeyNotFound APIキーが存在しない、または失効済み
+	ErrAPIKeyNotFound = newError(ErrNotFound, "api key not found", "api_key.not_found")
+
+	// ErrAPIKeyPrefixTaken APIキーの prefix が既に使われている
+	ErrAPIKeyPrefixTaken = newError(ErrConflict, "api key prefix already exists", "api_key.prefix_taken")
+)
+
+// APIKeyRepository 個人用APIキーの永続化インターフェース
+//
+// キー本体は hashToken によるハッシュで受け渡し、検索には prefix を使用します。
+type APIKeyRepository interface {
+	// Create APIキーを保存する（prefix が重複する場合は ErrAPIKeyPrefixTaken）
+	Create(key *models.APIKey) (*models.APIKey, error)
+	// FindByPrefix prefix でAPIキーを返す（失効済み・期限切れも含む）
+	FindByPrefix(prefix string) (*models.APIKey, error)
+	// ListByUser ユーザーの失効していないAPIキーを作成日時の新しい順に返す
+	ListByUser(userID int) ([]models.APIKey, error)
+	// CountActive ユーザーの失効していないAPIキー数を返す
+	CountActive(userID int) (int, error)
+	// Revoke ユーザーのAPIキーを失効させる
+	// 他ユーザーのキーや失効済みのキーは ErrAPIKeyNotFound を返す
+	Revoke(userID, id int) error
+	// TouchLastUsed 最終利用日時を記録する
+	TouchLastUsed(id int, usedAt time.Time) error
+}
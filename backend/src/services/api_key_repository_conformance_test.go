@@ -0,0 +1,135 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"backend/models"
+)
+
+// TestMemoryAPIKeyRepositoryConformance メモリAPIキーリポジトリの適合テスト
+func TestMemoryAPIKeyRepositoryConformance(t *testing.T) {
+	runAPIKeyRepositoryConformance(t, func(t *testing.T) (APIKeyRepository, UserRepository) {
+		return NewMemoryAPIKeyRepository(), NewMemoryUserRepository()
+	})
+}
+
+// TestPostgresAPIKeyRepositoryConformance PostgreSQL APIキーリポジトリの適合テスト
+func TestPostgresAPIKeyRepositoryConformance(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	runAPIKeyRepositoryConformance(t, func(t *testing.T) (APIKeyRepository, UserRepository) {
+		return NewPostgresAPIKeyRepository(db), NewPostgresUserRepository(db)
+	})
+}
+
+// runAPIKeyRepositoryConformance 全てのAPIKeyRepository実装が満たすべき振る舞い
+// 共有DBでも動作するよう、ユーザーと prefix は都度一意な値で作成します
+func runAPIKeyRepositoryConformance(t *testing.T, newRepos func(t *testing.T) (APIKeyRepository, UserRepository)) {
+	createUser := func(t *testing.T, users UserRepository) int {
+		t.Helper()
+		user, err := users.Create(fmt.Sprintf("apikey%d@example.com", time.Now().UnixNano()), "API Key", "hash")
+		if err != nil {
+			t.Fatalf("ユーザー作成失敗: %v", err)
+		}
+		return user.ID
+	}
+	newKey := func(userID int) *models.APIKey {
+		prefix := fmt.Sprintf("%012x", time.Now().UnixNano()&0xffffffffffff)
+		return &models.APIKey{
+			UserID:  userID,
+			Name:    "CI",
+			Prefix:  prefix,
+			KeyHash: hashToken(prefix),
+			Scopes:  []string{models.PermissionMessagesCreate},
+		}
+	}
+
+	t.Run("CreateAndFind", func(t *testing.T) {
+		repo, users := newRepos(t)
+		userID := createUser(t, users)
+		expiresAt := time.Now().Add(time.Hour).Truncate(time.Microsecond)
+		key := newKey(userID)
+		key.ExpiresAt = &expiresAt
+
+		created, err := repo.Create(key)
+		if err != nil {
+			t.Fatalf("Create失敗: %v", err)
+		}
+		if created.ID == 0 || created.CreatedAt.IsZero() || created.LastUsedAt != nil {
+			t.Errorf("作成結果が不正: %+v", created)
+		}
+
+		found, err := repo.FindByPrefix(key.Prefix)
+		if err != nil {
+			t.Fatalf("FindByPrefix失敗: %v", err)
+		}
+		if found.ID != created.ID || found.UserID != userID || found.KeyHash != key.KeyHash ||
+			len(found.Scopes) != 1 || found.Scopes[0] != models.PermissionMessagesCreate ||
+			found.ExpiresAt == nil || !found.ExpiresAt.Equal(expiresAt) {
+			t.Errorf("取得結果が不正: %+v", found)
+		}
+
+		if _, err := repo.Create(key); !errors.Is(err, ErrAPIKeyPrefixTaken) {
+			t.Errorf("重複 prefix で ErrAPIKeyPrefixTaken が返らない: %v", err)
+		}
+		if _, err := repo.FindByPrefix("000000000000"); !errors.Is(err, ErrAPIKeyNotFound) {
+			t.Errorf("未知の prefix で ErrAPIKeyNotFound が返らない: %v", err)
+		}
+	})
+
+	t.Run("ListCountAndRevoke", func(t *testing.T) {
+		repo, users := newRepos(t)
+		userID := createUser(t, users)
+		otherID := createUser(t, users)
+
+		first, _ := repo.Create(newKey(userID))
+		second, _ := repo.Create(newKey(userID))
+		if _, err := repo.Create(newKey(otherID)); err != nil {
+			t.Fatalf("Create失敗: %v", err)
+		}
+
+		keys, err := repo.ListByUser(userID)
+		if err != nil {
+			t.Fatalf("ListByUser失敗: %v", err)
+		}
+		if len(keys) != 2 || keys[0].ID != second.ID {
+			t.Errorf("一覧が新しい順の2件でない: %+v", keys)
+		}
+
+		if err := repo.Revoke(otherID, first.ID); !errors.Is(err, ErrAPIKeyNotFound) {
+			t.Errorf("他ユーザーのキーを失効できてしまう: %v", err)
+		}
+		if err := repo.Revoke(userID, first.ID); err != nil {
+			t.Fatalf("Revoke失敗: %v", err)
+		}
+		if err := repo.Revoke(userID, first.ID); !errors.Is(err, ErrAPIKeyNotFound) {
+			t.Errorf("失効済みのキーで ErrAPIKeyNotFound が返らない: %v", err)
+		}
+
+		if count, _ := repo.CountActive(userID); count != 1 {
+			t.Errorf("有効なキー数 = %d, want 1", count)
+		}
+		revoked, _ := repo.FindByPrefix(first.Prefix)
+		if revoked == nil || revoked.RevokedAt == nil || revoked.Active(time.Now()) {
+			t.Errorf("失効したキーが有効なまま: %+v", revoked)
+		}
+	})
+
+	t.Run("TouchLastUsed", func(t *testing.T) {
+		repo, users := newRepos(t)
+		created, _ := repo.Create(newKey(createUser(t, users)))
+		usedAt := time.Now().Truncate(time.Microsecond)
+
+		if err := repo.TouchLastUsed(created.ID, usedAt); err != nil {
+			t.Fatalf("TouchLastUsed失敗: %v", err)
+		}
+		found, _ := repo.FindByPrefix(created.Prefix)
+		if found.LastUsedAt == nil || !found.LastUsedAt.Equal(usedAt) {
+			t.Errorf("最終利用日時が記録されていない: %+v", found.LastUsedAt)
+		}
+	})
+}
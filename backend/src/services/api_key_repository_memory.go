@@ -0,0 +1,128 @@
+package services
+
+import (
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"backend/models"
+)
+
+// MemoryAPIKeyRepository メモリ上で動作するAPIキーリポジトリ
+type MemoryAPIKeyRepository struct {
+	mu     sync.RWMutex
+	keys   map[int]*models.APIKey
+	nextID int
+}
+
+// NewMemoryAPIKeyRepository メモリAPIキーリポジトリを新規作成
+func NewMemoryAPIKeyRepository() *MemoryAPIKeyRepository {
+	return &MemoryAPIKeyRepository{
+		keys:   make(map[int]*models.APIKey),
+		nextID: 1,
+	}
+}
+
+// Create APIキーを保存
+func (r *MemoryAPIKeyRepository) Create(key *models.APIKey) (*models.APIKey, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.keys {
+		if existing.Prefix == key.Prefix {
+			return nil, ErrAPIKeyPrefixTaken
+		}
+	}
+
+	stored := copyAPIKey(key)
+	stored.ID = r.nextID
+	stored.CreatedAt = time.Now()
+	stored.LastUsedAt = nil
+	stored.RevokedAt = nil
+	r.keys[stored.ID] = stored
+	r.nextID++
+
+	return copyAPIKey(stored), nil
+}
+
+// FindByPrefix prefix でAPIキーを取得
+func (r *MemoryAPIKeyRepository) FindByPrefix(prefix string) (*models.APIKey, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, key := range r.keys {
+		if key.Prefix == prefix {
+			return copyAPIKey(key), nil
+		}
+	}
+	return nil, ErrAPIKeyNotFound
+}
+
+// ListByUser ユーザーの失効していないAPIキー一覧を取得
+func (r *MemoryAPIKeyRepository) ListByUser(userID int) ([]models.APIKey, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	keys := []models.APIKey{}
+	for _, key := range r.keys {
+		if key.UserID == userID && key.RevokedAt == nil {
+			keys = append(keys, *copyAPIKey(key))
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].CreatedAt.Equal(keys[j].CreatedAt) {
+			return keys[i].ID > keys[j].ID
+		}
+		return keys[i].CreatedAt.After(keys[j].CreatedAt)
+	})
+	return keys, nil
+}
+
+// CountActive ユーザーの失効していないAPIキー数を取得
+func (r *MemoryAPIKeyRepository) CountActive(userID int) (int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	count := 0
+	for _, key := range r.keys {
+		if key.UserID == userID && key.RevokedAt == nil {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// Revoke ユーザーのAPIキーを失効
+func (r *MemoryAPIKeyRepository) Revoke(userID, id int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key, ok := r.keys[id]
+	if !ok || key.UserID != userID || key.RevokedAt != nil {
+		return ErrAPIKeyNotFound
+	}
+	now := time.Now()
+	key.RevokedAt = &now
+	return nil
+}
+
+// TouchLastUsed 最終利用日時を記録
+func (r *MemoryAPIKeyRepository) TouchLastUsed(id int, usedAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key, ok := r.keys[id]
+	if !ok {
+		return ErrAPIKeyNotFound
+	}
+	key.LastUsedAt = &usedAt
+	return nil
+}
+
+// copyAPIKey 呼び出し側の変更が保存データに影響しないようAPIキーを複製
+func copyAPIKey(key *models.APIKey) *models.APIKey {
+	copied := *key
+	copied.Scopes = slices.Clone(key.Scopes)
+	return &copied
+}
@@ -0,0 +1,159 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+
+	"backend/models"
+)
+
+// apiKeyColumns APIキー取得時の列
+const apiKeyColumns = `id, user_id, name, prefix, key_hash, scopes, created_at, expires_at, last_used_at, revoked_at`
+
+// PostgresAPIKeyRepository PostgreSQLによるAPIキーリポジトリ
+type PostgresAPIKeyRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresAPIKeyRepository PostgreSQL APIキーリポジトリを新規作成
+// db が nil の場合、全ての操作は ErrDatabaseUnavailable を返します
+func NewPostgresAPIKeyRepository(db *sql.DB) *PostgresAPIKeyRepository {
+	return &PostgresAPIKeyRepository{db: db}
+}
+
+// Create APIキーを保存
+func (r *PostgresAPIKeyRepository) Create(key *models.APIKey) (*models.APIKey, error) {
+	if r.db == nil {
+		return nil, ErrDatabaseUnavailable
+	}
+
+	query := `
+		INSERT INTO api_keys (user_id, name, prefix, key_hash, scopes, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING ` + apiKeyColumns
+
+	created, err := scanAPIKey(r.db.QueryRow(query, key.UserID, key.Name, key.Prefix, key.KeyHash, pq.Array(key.Scopes), key.ExpiresAt))
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, ErrAPIKeyPrefixTaken
+		}
+		if isForeignKeyViolation(err) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to create api key: %w", err)
+	}
+	return created, nil
+}
+
+// FindByPrefix prefix でAPIキーを取得
+func (r *PostgresAPIKeyRepository) FindByPrefix(prefix string) (*models.APIKey, error) {
+	if r.db == nil {
+		return nil, ErrDatabaseUnavailable
+	}
+
+	key, err := scanAPIKey(r.db.QueryRow(`SELECT `+apiKeyColumns+` FROM api_keys WHERE prefix = $1`, prefix))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrAPIKeyNotFound
+		}
+		return nil, fmt.Errorf("failed to get api key: %w", err)
+	}
+	return key, nil
+}
+
+// ListByUser ユーザーの失効していないAPIキー一覧を取得
+func (r *PostgresAPIKeyRepository) ListByUser(userID int) ([]models.APIKey, error) {
+	if r.db == nil {
+		return nil, ErrDatabaseUnavailable
+	}
+
+	rows, err := r.db.Query(`
+		SELECT `+apiKeyColumns+` FROM api_keys
+		WHERE user_id = $1 AND revoked_at IS NULL
+		ORDER BY created_at DESC, id DESC
+	`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list api keys: %w", err)
+	}
+	defer rows.Close()
+
+	keys := []models.APIKey{}
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan api key: %w", err)
+		}
+		keys = append(keys, *key)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate api keys: %w", err)
+	}
+	return keys, nil
+}
+
+// CountActive ユーザーの失効していないAPIキー数を取得
+func (r *PostgresAPIKeyRepository) CountActive(userID int) (int, error) {
+	if r.db == nil {
+		return 0, ErrDatabaseUnavailable
+	}
+
+	var count int
+	if err := r.db.QueryRow(`SELECT COUNT(*) FROM api_keys WHERE user_id = $1 AND revoked_at IS NULL`, userID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count api keys: %w", err)
+	}
+	return count, nil
+}
+
+// Revoke ユーザーのAPIキーを失効
+func (r *PostgresAPIKeyRepository) Revoke(userID, id int) error {
+	if r.db == nil {
+		return ErrDatabaseUnavailable
+	}
+
+	result, err := r.db.Exec(`
+		UPDATE api_keys SET revoked_at = NOW()
+		WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL
+	`, id, userID)
+	if err != nil {
+		return fmt.Errorf("failed to revoke api key: %w", err)
+	}
+	return requireAffected(result, ErrAPIKeyNotFound)
+}
+
+// TouchLastUsed 最終利用日時を記録
+func (r *PostgresAPIKeyRepository) TouchLastUsed(id int, usedAt time.Time) error {
+	if r.db == nil {
+		return ErrDatabaseUnavailable
+	}
+
+	result, err := r.db.Exec(`UPDATE api_keys SET last_used_at = $2 WHERE id = $1`, id, usedAt)
+	if err != nil {
+		return fmt.Errorf("failed to update api key last used: %w", err)
+	}
+	return requireAffected(result, ErrAPIKeyNotFound)
+}
+
+// scanAPIKey 1行をAPIキーに変換
+func scanAPIKey(row rowScanner) (*models.APIKey, error) {
+	var key models.APIKey
+	var expiresAt, lastUsedAt, revokedAt sql.NullTime
+	err := row.Scan(&key.ID, &key.UserID, &key.Name, &key.Prefix, &key.KeyHash, pq.Array(&key.Scopes),
+		&key.CreatedAt, &expiresAt, &lastUsedAt, &revokedAt)
+	if err != nil {
+		return nil, err
+	}
+	if expiresAt.Valid {
+		key.ExpiresAt = &expiresAt.Time
+	}
+	if lastUsedAt.Valid {
+		key.LastUsedAt = &lastUsedAt.Time
+	}
+	if revokedAt.Valid {
+		key.RevokedAt = &revokedAt.Time
+	}
+	return &key, nil
+}
@@ -0,0 +1,156 @@
+package services
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"backend/models"
+	"backend/utils"
+)
+
+// ErrAPIKeyLimitReached ユーザーが作成できるAPIキー数の上限に達した
+var ErrAPIKeyLimitReached = newError(ErrConflict, "api key limit reached", "api_key.limit_reached")
+
+// apiKeyPrefixBytes 検索用 prefix の乱数バイト数（16進数で12文字）
+const apiKeyPrefixBytes = 6
+
+// apiKeyCreateAttempts prefix が衝突した場合の作成試行回数
+const apiKeyCreateAttempts = 3
+
+// CreateAPIKey 個人用APIキーを作成し、キー本体を含むレスポンスを返す
+// スコープは作成者が現在持っている権限の中から指定する必要があります
+func (s *AuthService) CreateAPIKey(user *models.User, req *models.CreateAPIKeyRequest) (*models.CreatedAPIKeyResponse, error) {
+	if err := req.Validate(time.Now()); err != nil {
+		return nil, err
+	}
+
+	scopes := slices.Clone(req.Scopes)
+	slices.Sort(scopes)
+	scopes = slices.Compact(scopes)
+	for _, scope := range scopes {
+		if !user.HasPermission(scope) {
+			return nil, models.NewValidationError("scopes", models.ValidationCodeInvalid, "api_key.unknown_scope", map[string]interface{}{"scope": scope})
+		}
+	}
+
+	count, err := s.apiKeys.CountActive(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= utils.MaxAPIKeysPerUser {
+		return nil, ErrAPIKeyLimitReached
+	}
+
+	for attempt := 0; ; attempt++ {
+		prefix, key, err := newAPIKey()
+		if err != nil {
+			return nil, err
+		}
+
+		created, err := s.apiKeys.Create(&models.APIKey{
+			UserID:    user.ID,
+			Name:      req.Name,
+			Prefix:    prefix,
+			KeyHash:   hashToken(key),
+			Scopes:    scopes,
+			ExpiresAt: req.ExpiresAt,
+		})
+		if errors.Is(err, ErrAPIKeyPrefixTaken) && attempt+1 < apiKeyCreateAttempts {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return &models.CreatedAPIKeyResponse{APIKey: *created, Key: key}, nil
+	}
+}
+
+// ListAPIKeys ユーザーの失効していないAPIキー一覧を取得（キー本体は含まない）
+func (s *AuthService) ListAPIKeys(user *models.User) ([]models.APIKey, error) {
+	return s.apiKeys.ListByUser(user.ID)
+}
+
+// RevokeAPIKey ユーザーのAPIキーを失効させる
+func (s *AuthService) RevokeAPIKey(user *models.User, id int) error {
+	return s.apiKeys.Revoke(user.ID, id)
+}
+
+// authenticateAPIKey APIキーを検証し、スコープで絞り込んだ権限を持つユーザーを返す
+// 権限は作成時のスコープとユーザーの現在の権限の共通部分になるため、ロールを外せばキーの権限も失われます
+func (s *AuthService) authenticateAPIKey(raw string) (*models.User, error) {
+	prefix, ok := parseAPIKey(raw)
+	if !ok {
+		return nil, ErrInvalidToken
+	}
+
+	key, err := s.apiKeys.FindByPrefix(prefix)
+	if err != nil {
+		if errors.Is(err, ErrAPIKeyNotFound) {
+			return nil, ErrInvalidToken
+		}
+		return nil, err
+	}
+
+	now := time.Now()
+	if subtle.ConstantTimeCompare([]byte(hashToken(raw)), []byte(key.KeyHash)) != 1 || !key.Active(now) {
+		return nil, ErrInvalidToken
+	}
+
+	user, err := s.users.FindByID(key.UserID)
+	if err != nil {
+		if errors.Is(err, ErrUserNotFound) {
+			return nil, ErrInvalidToken
+		}
+		return nil, err
+	}
+	if err := s.loadAccess(user); err != nil {
+		return nil, err
+	}
+	user.Permissions = slices.DeleteFunc(user.Permissions, func(permission string) bool {
+		return !slices.Contains(key.Scopes, permission)
+	})
+	user.APIKeyID = key.ID
+
+	// 毎リクエストの書き込みを避けるため、一定間隔でのみ最終利用日時を更新
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) >= utils.APIKeyLastUsedGranularity*time.Second {
+		if err := s.apiKeys.TouchLastUsed(key.ID, now); err != nil {
+			return nil, err
+		}
+	}
+
+	return user, nil
+}
+
+// newAPIKey 「gcs_<prefix>_<secret>」形式のAPIキーを生成し、prefix とキー本体を返す
+func newAPIKey() (prefix, key string, err error) {
+	b := make([]byte, apiKeyPrefixBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", fmt.Errorf("failed to generate api key prefix: %w", err)
+	}
+	prefix = hex.EncodeToString(b)
+
+	secret, err := randomToken(refreshTokenBytes)
+	if err != nil {
+		return "", "", err
+	}
+	return prefix, utils.APIKeyPrefix + prefix + "_" + secret, nil
+}
+
+// parseAPIKey APIキーから検索用の prefix を取り出す
+func parseAPIKey(raw string) (string, bool) {
+	rest, ok := strings.CutPrefix(raw, utils.APIKeyPrefix)
+	if !ok {
+		return "", false
+	}
+	prefix, secret, ok := strings.Cut(rest, "_")
+	if !ok || len(prefix) != apiKeyPrefixBytes*2 || secret == "" {
+		return "", false
+	}
+	return prefix, true
+}
@@ -0,0 +1,120 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"backend/models"
+	"backend/utils"
+)
+
+// TestAuthServiceAPIKeys APIキーの作成・認証・失効のテスト
+func TestAuthServiceAPIKeys(t *testing.T) {
+	service, _ := newTestAuthService(t)
+	registered, _ := service.Register(&models.RegisterRequest{Email: "judy@example.com", Password: "password123", Name: "Judy"}, testClient)
+	owner, err := service.Authenticate(registered.AccessToken)
+	if err != nil {
+		t.Fatalf("Authenticate() error = %v", err)
+	}
+
+	created, err := service.CreateAPIKey(owner, &models.CreateAPIKeyRequest{
+		Name:   " CI ",
+		Scopes: []string{models.PermissionMessagesCreate, models.PermissionMessagesCreate},
+	})
+	if err != nil {
+		t.Fatalf("CreateAPIKey() error = %v", err)
+	}
+	if !strings.HasPrefix(created.Key, utils.APIKeyPrefix+created.Prefix+"_") || created.Name != "CI" || len(created.Scopes) != 1 {
+		t.Errorf("作成結果が不正: %+v", created)
+	}
+
+	// スコープに含まれる権限だけを持つユーザーとして認証される
+	user, err := service.Authenticate(created.Key)
+	if err != nil {
+		t.Fatalf("Authenticate(api key) error = %v", err)
+	}
+	if user.ID != owner.ID || user.APIKeyID != created.ID || user.SessionID != "" {
+		t.Errorf("APIキーの認証結果が不正: %+v", user)
+	}
+	if !user.HasPermission(models.PermissionMessagesCreate) || user.HasPermission(models.PermissionRolesManage) {
+		t.Errorf("スコープ外の権限を持っている: %v", user.Permissions)
+	}
+
+	keys, _ := service.ListAPIKeys(owner)
+	if len(keys) != 1 || keys[0].LastUsedAt == nil {
+		t.Errorf("最終利用日時が記録されていない: %+v", keys)
+	}
+
+	for _, invalid := range []string{created.Key + "x", utils.APIKeyPrefix + created.Prefix + "_wrong", utils.APIKeyPrefix + "short_secret"} {
+		if _, err := service.Authenticate(invalid); !errors.Is(err, ErrInvalidToken) {
+			t.Errorf("不正なキー %q で ErrInvalidToken が返らない: %v", invalid, err)
+		}
+	}
+
+	if err := service.RevokeAPIKey(owner, created.ID); err != nil {
+		t.Fatalf("RevokeAPIKey() error = %v", err)
+	}
+	if _, err := service.Authenticate(created.Key); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("失効したキーで認証できてしまう: %v", err)
+	}
+}
+
+// TestAuthServiceAPIKeyValidation APIキー作成時の検証のテスト
+func TestAuthServiceAPIKeyValidation(t *testing.T) {
+	service, _ := newTestAuthService(t)
+	service.Register(&models.RegisterRequest{Email: "owner@example.com", Password: "password123", Name: "Owner"}, testClient)
+	registered, _ := service.Register(&models.RegisterRequest{Email: "member@example.com", Password: "password123", Name: "Member"}, testClient)
+	member, _ := service.Authenticate(registered.AccessToken)
+	past := time.Now().Add(-time.Minute)
+
+	tests := []struct {
+		name    string
+		request models.CreateAPIKeyRequest
+	}{
+		{"Missing name", models.CreateAPIKeyRequest{Scopes: []string{models.PermissionMessagesCreate}}},
+		{"No scopes", models.CreateAPIKeyRequest{Name: "CI"}},
+		{"Scope not held", models.CreateAPIKeyRequest{Name: "CI", Scopes: []string{models.PermissionMessagesDelete}}},
+		{"Unknown scope", models.CreateAPIKeyRequest{Name: "CI", Scopes: []string{"unknown"}}},
+		{"Expired", models.CreateAPIKeyRequest{Name: "CI", Scopes: []string{models.PermissionMessagesCreate}, ExpiresAt: &past}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var validationErr *models.ValidationError
+			if _, err := service.CreateAPIKey(member, &tt.request); !errors.As(err, &validationErr) {
+				t.Errorf("ValidationError が返らない: %v", err)
+			}
+		})
+	}
+
+	for i := 0; i < utils.MaxAPIKeysPerUser; i++ {
+		if _, err := service.CreateAPIKey(member, &models.CreateAPIKeyRequest{Name: "CI", Scopes: []string{models.PermissionMessagesCreate}}); err != nil {
+			t.Fatalf("CreateAPIKey() error = %v", err)
+		}
+	}
+	if _, err := service.CreateAPIKey(member, &models.CreateAPIKeyRequest{Name: "CI", Scopes: []string{models.PermissionMessagesCreate}}); !errors.Is(err, ErrAPIKeyLimitReached) {
+		t.Errorf("上限超過で ErrAPIKeyLimitReached が返らない: %v", err)
+	}
+}
+
+// TestAuthServiceAPIKeyExpiry 期限切れのAPIキーのテスト
+func TestAuthServiceAPIKeyExpiry(t *testing.T) {
+	service, _ := newTestAuthService(t)
+	registered, _ := service.Register(&models.RegisterRequest{Email: "ken@example.com", Password: "password123", Name: "Ken"}, testClient)
+	user, _ := service.Authenticate(registered.AccessToken)
+
+	expiresAt := time.Now().Add(50 * time.Millisecond)
+	created, err := service.CreateAPIKey(user, &models.CreateAPIKeyRequest{Name: "Short", Scopes: []string{models.PermissionMessagesCreate}, ExpiresAt: &expiresAt})
+	if err != nil {
+		t.Fatalf("CreateAPIKey() error = %v", err)
+	}
+	if _, err := service.Authenticate(created.Key); err != nil {
+		t.Fatalf("期限内のキーで認証できない: %v", err)
+	}
+
+	time.Sleep(100 * time.Millisecond)
+	if _, err := service.Authenticate(created.Key); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("期限切れのキーで認証できてしまう: %v", err)
+	}
+}
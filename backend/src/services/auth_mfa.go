@@ -0,0 +1,363 @@
+package services
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"image/png"
+	"strings"
+	"time"
+
+	"github.com/pquerna/otp"
+	"github.com/pquerna/otp/totp"
+
+	"backend/models"
+	"backend/utils"
+)
+
+var (
+	// ErrMFAAlreadyEnabled 二要素認証が既に有効
+	ErrMFAAlreadyEnabled = newError(ErrConflict, "mfa already enabled", "mfa.already_enabled")
+
+	// ErrMFANotEnabled 二要素認証が有効でない（登録確認時は登録開始前）
+	ErrMFANotEnabled = newError(ErrConflict, "mfa not enabled", "mfa.not_enabled")
+
+	// ErrInvalidMFACode 認証コードが正しくない、または使用済み
+	ErrInvalidMFACode = newError(ErrValidation, "invalid mfa code", "mfa.invalid_code")
+
+	// ErrTooManyMFAAttempts mfa_token に対するコード入力回数が上限に達した
+	ErrTooManyMFAAttempts = newError(ErrTooManyRequests, "too many mfa attempts", "mfa.too_many_attempts")
+
+	// ErrTooManyMFAFailures ユーザーのコードの失敗回数が上限に達した（しばらくの間は正しいコードも拒否）
+	ErrTooManyMFAFailures = newError(ErrTooManyRequests, "too many failed mfa attempts", "mfa.too_many_failures")
+)
+
+// totpPeriod TOTPの時間ステップ（秒）
+const totpPeriod = 30
+
+// totpOptions 認証アプリと互換性のあるTOTPの設定（SHA1・6桁・30秒）
+var totpOptions = totp.ValidateOpts{
+	Period:    totpPeriod,
+	Digits:    otp.DigitsSix,
+	Algorithm: otp.AlgorithmSHA1,
+}
+
+// MFARequiredError パスワード認証は成功したが二要素認証が必要
+// Login はトークンの代わりにこのエラーで mfa_token を返します
+type MFARequiredError struct {
+	Challenge *models.MFAChallengeResponse
+}
+
+// Error エラーメッセージを返す
+func (e *MFARequiredError) Error() string {
+	return "mfa verification required"
+}
+
+// EnrollTOTP TOTPの登録を開始し、秘密鍵・otpauth URI・QRコードを返す
+// 確認待ちの登録がある場合は新しい秘密鍵で置き換えます
+func (s *AuthService) EnrollTOTP(user *models.User) (*models.TOTPEnrollment, error) {
+	credential, err := s.mfa.GetTOTP(user.ID)
+	if err != nil && !errors.Is(err, ErrTOTPNotFound) {
+		return nil, err
+	}
+	if credential != nil && credential.Enabled() {
+		return nil, ErrMFAAlreadyEnabled
+	}
+
+	key, err := totp.Generate(totp.GenerateOpts{
+		Issuer:      utils.TOTPIssuer,
+		AccountName: user.Email,
+		Period:      totpPeriod,
+		Digits:      totpOptions.Digits,
+		Algorithm:   totpOptions.Algorithm,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate totp key: %w", err)
+	}
+
+	// QRコードは外部サービスを使わずローカルで生成（秘密鍵を外部に送らない）
+	img, err := key.Image(utils.TOTPQRCodeImageSize, utils.TOTPQRCodeImageSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate qr code: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("failed to encode qr code: %w", err)
+	}
+
+	if err := s.mfa.SaveTOTPSecret(user.ID, key.Secret()); err != nil {
+		return nil, err
+	}
+
+	return &models.TOTPEnrollment{
+		Secret:     key.Secret(),
+		OTPAuthURI: key.URL(),
+		QRCode:     "data:image/png;base64," + base64.StdEncoding.EncodeToString(buf.Bytes()),
+	}, nil
+}
+
+// ConfirmTOTP 認証アプリのコードで登録を確認してTOTPを有効化し、リカバリーコードを発行
+func (s *AuthService) ConfirmTOTP(user *models.User, req *models.MFACodeRequest) (*models.RecoveryCodesResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	credential, err := s.mfa.GetTOTP(user.ID)
+	if err != nil {
+		if errors.Is(err, ErrTOTPNotFound) {
+			return nil, ErrMFANotEnabled
+		}
+		return nil, err
+	}
+	if credential.Enabled() {
+		return nil, ErrMFAAlreadyEnabled
+	}
+
+	step, ok := matchTOTP(credential.Secret, req.Code, time.Now())
+	if !ok {
+		return nil, ErrInvalidMFACode
+	}
+	if err := s.mfa.EnableTOTP(user.ID, step); err != nil {
+		return nil, err
+	}
+
+	return s.issueRecoveryCodes(user.ID)
+}
+
+// DisableTOTP TOTPコードまたはリカバリーコードを確認して二要素認証を無効化
+func (s *AuthService) DisableTOTP(user *models.User, req *models.MFACodeRequest) error {
+	if err := req.Validate(); err != nil {
+		return err
+	}
+	if err := s.verifySecondFactor(user.ID, req.Code); err != nil {
+		return err
+	}
+	return s.mfa.DeleteTOTP(user.ID)
+}
+
+// RegenerateRecoveryCodes TOTPコードまたはリカバリーコードを確認してリカバリーコードを再発行
+// 以前のリカバリーコードは全て無効になります
+func (s *AuthService) RegenerateRecoveryCodes(user *models.User, req *models.MFACodeRequest) (*models.RecoveryCodesResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.verifySecondFactor(user.ID, req.Code); err != nil {
+		return nil, err
+	}
+	return s.issueRecoveryCodes(user.ID)
+}
+
+// MFAStatus 二要素認証の設定状況を取得
+func (s *AuthService) MFAStatus(user *models.User) (*models.MFAStatus, error) {
+	enabled, err := s.totpEnabled(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	remaining, err := s.mfa.CountRecoveryCodes(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &models.MFAStatus{TOTPEnabled: enabled, RecoveryCodesRemaining: remaining}, nil
+}
+
+// CompleteMFALogin ログイン2段階目: mfa_token と認証コードを検証してセッションを開始
+// 1つの mfa_token で試行できるのは utils.MaxMFAAttempts 回までで、成功後は再利用できません
+// 試行回数はリポジトリで数えるため、複数のレプリカに振り分けられても上限は変わりません
+func (s *AuthService) CompleteMFALogin(req *models.MFALoginRequest, client models.ClientInfo) (*models.AuthResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	claims, err := s.tokens.Parse(req.MFAToken, TokenTypeMFAPending)
+	if err != nil {
+		return nil, err
+	}
+	userID, err := claims.UserID()
+	if err != nil {
+		return nil, err
+	}
+
+	attemptKey := mfaTokenAttemptKey(claims.ID)
+	attempts, err := s.mfa.AddMFAAttempts(attemptKey, 1, claims.ExpiresAt.Time)
+	if err != nil {
+		return nil, err
+	}
+	if attempts > utils.MaxMFAAttempts {
+		return nil, ErrTooManyMFAAttempts
+	}
+	if err := s.verifySecondFactor(userID, req.Code); err != nil {
+		if errors.Is(err, ErrMFANotEnabled) {
+			return nil, ErrInvalidToken
+		}
+		return nil, err
+	}
+	// 成功後の mfa_token を再利用できないよう、残りの試行回数を使い切る
+	if _, err := s.mfa.AddMFAAttempts(attemptKey, utils.MaxMFAAttempts, claims.ExpiresAt.Time); err != nil {
+		return nil, err
+	}
+
+	user, err := s.users.FindByID(userID)
+	if err != nil {
+		if errors.Is(err, ErrUserNotFound) {
+			return nil, ErrInvalidToken
+		}
+		return nil, err
+	}
+
+	return s.startSession(user, client)
+}
+
+// mfaChallenge 二要素認証が有効なユーザーに mfa_token を発行して MFARequiredError を返す
+// 二要素認証が無効なユーザーには nil を返します
+func (s *AuthService) mfaChallenge(user *models.User) error {
+	enabled, err := s.totpEnabled(user.ID)
+	if err != nil || !enabled {
+		return err
+	}
+
+	token, err := s.tokens.IssueMFAToken(user)
+	if err != nil {
+		return err
+	}
+	return &MFARequiredError{Challenge: &models.MFAChallengeResponse{
+		MFARequired: true,
+		MFAToken:    token,
+		ExpiresIn:   utils.MFATokenTTLSeconds,
+	}}
+}
+
+// totpEnabled ユーザーのTOTPが有効か判定
+func (s *AuthService) totpEnabled(userID int) (bool, error) {
+	credential, err := s.mfa.GetTOTP(userID)
+	if err != nil {
+		if errors.Is(err, ErrTOTPNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return credential.Enabled(), nil
+}
+
+// verifySecondFactor TOTPコード（6桁）またはリカバリーコードを検証して消費
+// ログイン・無効化・リカバリーコードの再発行で共通の失敗回数の上限があり、
+// utils.MFAFailureWindowSeconds の間に utils.MaxMFAFailures 回失敗すると ErrTooManyMFAFailures を返します（成功でリセット）
+func (s *AuthService) verifySecondFactor(userID int, code string) error {
+	credential, err := s.mfa.GetTOTP(userID)
+	if err != nil {
+		if errors.Is(err, ErrTOTPNotFound) {
+			return ErrMFANotEnabled
+		}
+		return err
+	}
+	if !credential.Enabled() {
+		return ErrMFANotEnabled
+	}
+
+	// 検証の前に数えるため、同時に試行されても上限を超えて検証されることはない
+	failureKey := mfaUserAttemptKey(userID)
+	failures, err := s.mfa.AddMFAAttempts(failureKey, 1, time.Now().Add(utils.MFAFailureWindowSeconds*time.Second))
+	if err != nil {
+		return err
+	}
+	if failures > utils.MaxMFAFailures {
+		return ErrTooManyMFAFailures
+	}
+
+	code = strings.Join(strings.Fields(code), "")
+	var ok bool
+	if isTOTPCode(code) {
+		step, matched := matchTOTP(credential.Secret, code, time.Now())
+		if matched {
+			ok, err = s.mfa.UseTOTPStep(userID, step)
+		}
+	} else {
+		ok, err = s.mfa.UseRecoveryCode(userID, hashToken(normalizeRecoveryCode(code)))
+	}
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrInvalidMFACode
+	}
+	return s.mfa.ResetMFAAttempts(failureKey)
+}
+
+// mfaTokenAttemptKey mfa_token ごとの試行回数のキー
+func mfaTokenAttemptKey(tokenID string) string {
+	return "token:" + tokenID
+}
+
+// mfaUserAttemptKey ユーザーごとの失敗回数のキー
+func mfaUserAttemptKey(userID int) string {
+	return fmt.Sprintf("user:%d", userID)
+}
+
+// issueRecoveryCodes リカバリーコードを生成し、ハッシュを保存して平文を返す
+func (s *AuthService) issueRecoveryCodes(userID int) (*models.RecoveryCodesResponse, error) {
+	codes := make([]string, utils.RecoveryCodeCount)
+	hashes := make([]string, utils.RecoveryCodeCount)
+	for i := range codes {
+		code, err := newRecoveryCode()
+		if err != nil {
+			return nil, err
+		}
+		codes[i] = code
+		hashes[i] = hashToken(normalizeRecoveryCode(code))
+	}
+
+	if err := s.mfa.ReplaceRecoveryCodes(userID, hashes); err != nil {
+		return nil, err
+	}
+	return &models.RecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+// matchTOTP 前後1ステップの時刻ずれを許容してコードを照合し、一致した時間ステップを返す
+func matchTOTP(secret, code string, now time.Time) (int64, bool) {
+	if !isTOTPCode(code) {
+		return 0, false
+	}
+
+	current := now.Unix() / totpPeriod
+	for _, step := range []int64{current - 1, current, current + 1} {
+		expected, err := totp.GenerateCodeCustom(secret, time.Unix(step*totpPeriod, 0), totpOptions)
+		if err != nil {
+			return 0, false
+		}
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+// isTOTPCode 6桁の数字か判定
+func isTOTPCode(code string) bool {
+	if len(code) != 6 {
+		return false
+	}
+	for _, c := range code {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// newRecoveryCode 「xxxxx-xxxxx」形式のリカバリーコードを生成（約50ビット）
+func newRecoveryCode() (string, error) {
+	b := make([]byte, 7)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate recovery code: %w", err)
+	}
+	encoded := strings.ToLower(base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(b))
+	return encoded[:5] + "-" + encoded[5:10], nil
+}
+
+// normalizeRecoveryCode 照合用にリカバリーコードを正規化（大文字小文字・区切り文字を無視）
+func normalizeRecoveryCode(code string) string {
+	return strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+}
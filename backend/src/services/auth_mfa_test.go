@@ -0,0 +1,236 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pquerna/otp/totp"
+
+	"backend/models"
+	"backend/utils"
+)
+
+// enableTestTOTP ユーザーを登録してTOTPを有効化し、秘密鍵とリカバリーコードを返す
+func enableTestTOTP(t *testing.T, service *AuthService, email string) (*models.User, string, []string) {
+	t.Helper()
+
+	registered, err := service.Register(&models.RegisterRequest{Email: email, Password: "password123", Name: "MFA"}, testClient)
+	if err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	user, err := service.Authenticate(registered.AccessToken)
+	if err != nil {
+		t.Fatalf("Authenticate() error = %v", err)
+	}
+
+	enrollment, err := service.EnrollTOTP(user)
+	if err != nil {
+		t.Fatalf("EnrollTOTP() error = %v", err)
+	}
+	codes, err := service.ConfirmTOTP(user, &models.MFACodeRequest{Code: testTOTPCode(t, enrollment.Secret, time.Now())})
+	if err != nil {
+		t.Fatalf("ConfirmTOTP() error = %v", err)
+	}
+	return user, enrollment.Secret, codes.RecoveryCodes
+}
+
+// testTOTPCode 指定時刻のTOTPコードを生成
+func testTOTPCode(t *testing.T, secret string, at time.Time) string {
+	t.Helper()
+	code, err := totp.GenerateCode(secret, at)
+	if err != nil {
+		t.Fatalf("GenerateCode() error = %v", err)
+	}
+	return code
+}
+
+// TestAuthServiceEnrollTOTP TOTP登録と有効化のテスト
+func TestAuthServiceEnrollTOTP(t *testing.T) {
+	service, _ := newTestAuthService(t)
+	registered, _ := service.Register(&models.RegisterRequest{Email: "enroll@example.com", Password: "password123", Name: "Enroll"}, testClient)
+	user, _ := service.Authenticate(registered.AccessToken)
+
+	if _, err := service.ConfirmTOTP(user, &models.MFACodeRequest{Code: "123456"}); !errors.Is(err, ErrMFANotEnabled) {
+		t.Errorf("登録開始前の確認で ErrMFANotEnabled が返らない: %v", err)
+	}
+
+	enrollment, err := service.EnrollTOTP(user)
+	if err != nil {
+		t.Fatalf("EnrollTOTP() error = %v", err)
+	}
+	if !strings.HasPrefix(enrollment.OTPAuthURI, "otpauth://totp/") || !strings.Contains(enrollment.OTPAuthURI, "secret="+enrollment.Secret) {
+		t.Errorf("otpauth URI が不正: %s", enrollment.OTPAuthURI)
+	}
+	if !strings.HasPrefix(enrollment.QRCode, "data:image/png;base64,") {
+		t.Errorf("QRコードがPNGのdata URIでない: %.40s", enrollment.QRCode)
+	}
+
+	// 確認前はログインに二要素認証を要求しない
+	if _, err := service.Login(&models.LoginRequest{Email: "enroll@example.com", Password: "password123"}, testClient); err != nil {
+		t.Errorf("確認前の Login() error = %v", err)
+	}
+
+	if _, err := service.ConfirmTOTP(user, &models.MFACodeRequest{Code: "abc"}); err == nil {
+		t.Error("不正な形式のコードが受け付けられた")
+	}
+	if _, err := service.ConfirmTOTP(user, &models.MFACodeRequest{Code: testTOTPCode(t, enrollment.Secret, time.Now().Add(-5*time.Minute))}); !errors.Is(err, ErrInvalidMFACode) {
+		t.Errorf("時間外のコードで ErrInvalidMFACode が返らない: %v", err)
+	}
+
+	codes, err := service.ConfirmTOTP(user, &models.MFACodeRequest{Code: testTOTPCode(t, enrollment.Secret, time.Now())})
+	if err != nil {
+		t.Fatalf("ConfirmTOTP() error = %v", err)
+	}
+	if len(codes.RecoveryCodes) != utils.RecoveryCodeCount {
+		t.Errorf("リカバリーコード数 = %d, want %d", len(codes.RecoveryCodes), utils.RecoveryCodeCount)
+	}
+
+	if _, err := service.EnrollTOTP(user); !errors.Is(err, ErrMFAAlreadyEnabled) {
+		t.Errorf("有効化後の再登録で ErrMFAAlreadyEnabled が返らない: %v", err)
+	}
+
+	status, err := service.MFAStatus(user)
+	if err != nil {
+		t.Fatalf("MFAStatus() error = %v", err)
+	}
+	if !status.TOTPEnabled || status.RecoveryCodesRemaining != utils.RecoveryCodeCount {
+		t.Errorf("設定状況が不正: %+v", status)
+	}
+}
+
+// TestAuthServiceMFALogin 二段階ログインのテスト
+func TestAuthServiceMFALogin(t *testing.T) {
+	service, _ := newTestAuthService(t)
+	_, secret, recoveryCodes := enableTestTOTP(t, service, "grace@example.com")
+	login := &models.LoginRequest{Email: "grace@example.com", Password: "password123"}
+
+	_, err := service.Login(login, testClient)
+	var required *MFARequiredError
+	if !errors.As(err, &required) {
+		t.Fatalf("Login() で MFARequiredError が返らない: %v", err)
+	}
+	if !required.Challenge.MFARequired || required.Challenge.MFAToken == "" {
+		t.Errorf("チャレンジが不正: %+v", required.Challenge)
+	}
+
+	// mfa_token はアクセストークンとして使えない
+	if _, err := service.Authenticate(required.Challenge.MFAToken); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("mfa_token で認証できてしまう: %v", err)
+	}
+
+	// 有効化に使ったコードは再利用できない
+	reused := testTOTPCode(t, secret, time.Now())
+	if _, err := service.CompleteMFALogin(&models.MFALoginRequest{MFAToken: required.Challenge.MFAToken, Code: reused}, testClient); !errors.Is(err, ErrInvalidMFACode) {
+		t.Errorf("使用済みコードで ErrInvalidMFACode が返らない: %v", err)
+	}
+
+	result, err := service.CompleteMFALogin(&models.MFALoginRequest{
+		MFAToken: required.Challenge.MFAToken,
+		Code:     testTOTPCode(t, secret, time.Now().Add(30*time.Second)),
+	}, testClient)
+	if err != nil {
+		t.Fatalf("CompleteMFALogin() error = %v", err)
+	}
+	if _, err := service.Authenticate(result.AccessToken); err != nil {
+		t.Errorf("発行されたアクセストークンで認証できない: %v", err)
+	}
+
+	// 成功後の mfa_token は再利用できない
+	if _, err := service.CompleteMFALogin(&models.MFALoginRequest{MFAToken: required.Challenge.MFAToken, Code: strings.ToUpper(recoveryCodes[0])}, testClient); !errors.Is(err, ErrTooManyMFAAttempts) {
+		t.Errorf("使用済み mfa_token で ErrTooManyMFAAttempts が返らない: %v", err)
+	}
+
+	// リカバリーコードでもログインでき、使用後は無効になる
+	_, err = service.Login(login, testClient)
+	errors.As(err, &required)
+	if _, err := service.CompleteMFALogin(&models.MFALoginRequest{MFAToken: required.Challenge.MFAToken, Code: strings.ToUpper(recoveryCodes[0])}, testClient); err != nil {
+		t.Fatalf("リカバリーコードでの CompleteMFALogin() error = %v", err)
+	}
+	_, err = service.Login(login, testClient)
+	errors.As(err, &required)
+	if _, err := service.CompleteMFALogin(&models.MFALoginRequest{MFAToken: required.Challenge.MFAToken, Code: recoveryCodes[0]}, testClient); !errors.Is(err, ErrInvalidMFACode) {
+		t.Errorf("使用済みリカバリーコードで ErrInvalidMFACode が返らない: %v", err)
+	}
+}
+
+// TestAuthServiceMFAAttemptLimit mfa_token ごとの試行回数制限のテスト
+func TestAuthServiceMFAAttemptLimit(t *testing.T) {
+	service, _ := newTestAuthService(t)
+	_, _, recoveryCodes := enableTestTOTP(t, service, "heidi@example.com")
+
+	_, err := service.Login(&models.LoginRequest{Email: "heidi@example.com", Password: "password123"}, testClient)
+	var required *MFARequiredError
+	if !errors.As(err, &required) {
+		t.Fatalf("Login() で MFARequiredError が返らない: %v", err)
+	}
+
+	for i := 0; i < utils.MaxMFAAttempts; i++ {
+		if _, err := service.CompleteMFALogin(&models.MFALoginRequest{MFAToken: required.Challenge.MFAToken, Code: "aaaaa-aaaaa"}, testClient); !errors.Is(err, ErrInvalidMFACode) {
+			t.Fatalf("%d回目: ErrInvalidMFACode が返らない: %v", i+1, err)
+		}
+	}
+	if _, err := service.CompleteMFALogin(&models.MFALoginRequest{MFAToken: required.Challenge.MFAToken, Code: recoveryCodes[0]}, testClient); !errors.Is(err, ErrTooManyMFAAttempts) {
+		t.Errorf("上限超過で ErrTooManyMFAAttempts が返らない: %v", err)
+	}
+}
+
+// TestAuthServiceMFAFailureLimit ユーザーごとの失敗回数制限のテスト（ログイン以外の確認にも適用）
+func TestAuthServiceMFAFailureLimit(t *testing.T) {
+	service, _ := newTestAuthService(t)
+	user, _, recoveryCodes := enableTestTOTP(t, service, "judy@example.com")
+
+	// 成功すると失敗回数はリセットされる
+	for i := 0; i < utils.MaxMFAFailures-1; i++ {
+		if _, err := service.RegenerateRecoveryCodes(user, &models.MFACodeRequest{Code: "aaaaa-aaaaa"}); !errors.Is(err, ErrInvalidMFACode) {
+			t.Fatalf("%d回目: ErrInvalidMFACode が返らない: %v", i+1, err)
+		}
+	}
+	regenerated, err := service.RegenerateRecoveryCodes(user, &models.MFACodeRequest{Code: recoveryCodes[0]})
+	if err != nil {
+		t.Fatalf("RegenerateRecoveryCodes() error = %v", err)
+	}
+
+	for i := 0; i < utils.MaxMFAFailures; i++ {
+		if err := service.DisableTOTP(user, &models.MFACodeRequest{Code: "aaaaa-aaaaa"}); !errors.Is(err, ErrInvalidMFACode) {
+			t.Fatalf("%d回目: ErrInvalidMFACode が返らない: %v", i+1, err)
+		}
+	}
+	// 上限に達すると正しいコードも拒否し、新しい mfa_token でも同じ
+	if err := service.DisableTOTP(user, &models.MFACodeRequest{Code: regenerated.RecoveryCodes[0]}); !errors.Is(err, ErrTooManyMFAFailures) {
+		t.Errorf("上限超過の DisableTOTP で ErrTooManyMFAFailures が返らない: %v", err)
+	}
+	_, err = service.Login(&models.LoginRequest{Email: "judy@example.com", Password: "password123"}, testClient)
+	var required *MFARequiredError
+	if !errors.As(err, &required) {
+		t.Fatalf("Login() で MFARequiredError が返らない: %v", err)
+	}
+	if _, err := service.CompleteMFALogin(&models.MFALoginRequest{MFAToken: required.Challenge.MFAToken, Code: regenerated.RecoveryCodes[0]}, testClient); !errors.Is(err, ErrTooManyMFAFailures) {
+		t.Errorf("上限超過の CompleteMFALogin で ErrTooManyMFAFailures が返らない: %v", err)
+	}
+}
+
+// TestAuthServiceDisableTOTP 二要素認証の無効化とリカバリーコード再発行のテスト
+func TestAuthServiceDisableTOTP(t *testing.T) {
+	service, _ := newTestAuthService(t)
+	user, _, recoveryCodes := enableTestTOTP(t, service, "ivan@example.com")
+
+	regenerated, err := service.RegenerateRecoveryCodes(user, &models.MFACodeRequest{Code: recoveryCodes[0]})
+	if err != nil {
+		t.Fatalf("RegenerateRecoveryCodes() error = %v", err)
+	}
+	if err := service.DisableTOTP(user, &models.MFACodeRequest{Code: recoveryCodes[1]}); !errors.Is(err, ErrInvalidMFACode) {
+		t.Errorf("再発行前のリカバリーコードで ErrInvalidMFACode が返らない: %v", err)
+	}
+
+	if err := service.DisableTOTP(user, &models.MFACodeRequest{Code: regenerated.RecoveryCodes[0]}); err != nil {
+		t.Fatalf("DisableTOTP() error = %v", err)
+	}
+	if err := service.DisableTOTP(user, &models.MFACodeRequest{Code: regenerated.RecoveryCodes[1]}); !errors.Is(err, ErrMFANotEnabled) {
+		t.Errorf("無効化後に ErrMFANotEnabled が返らない: %v", err)
+	}
+	if _, err := service.Login(&models.LoginRequest{Email: "ivan@example.com", Password: "password123"}, testClient); err != nil {
+		t.Errorf("無効化後の Login() error = %v", err)
+	}
+}
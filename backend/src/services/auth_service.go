@@ -0,0 +1,259 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"backend/models"
+	"backend/utils"
+)
+
+// ErrInvalidCredentials メールアドレスまたはパスワードが正しくない
+var ErrInvalidCredentials = newError(ErrUnauthorized, "invalid email or password", "auth.invalid_credentials")
+
+// AuthService ユーザー登録・ログイン・トークン検証・セッション管理・二要素認証
+type AuthService struct {
+	users    UserRepository
+	roles    RoleRepository
+	accounts AccountRepository
+	sessions SessionRepository
+	mfa      MFARepository
+	apiKeys  APIKeyRepository
+	hasher   *PasswordHasher
+	tokens   *TokenManager
+
+	// dummyHash 存在しないユーザーのログインでも照合時間を揃えるためのハッシュ
+	dummyHash string
+}
+
+// NewAuthService リポジトリ一式から認証サービスを新規作成
+func NewAuthService(repos *Repositories, hasher *PasswordHasher, tokens *TokenManager) (*AuthService, error) {
+	dummyHash, err := hasher.Hash("dummy-password-for-timing")
+	if err != nil {
+		return nil, err
+	}
+
+	return &AuthService{
+		users:     repos.Users,
+		roles:     repos.Roles,
+		accounts:  repos.Accounts,
+		sessions:  repos.Sessions,
+		mfa:       repos.MFA,
+		apiKeys:   repos.APIKeys,
+		hasher:    hasher,
+		tokens:    tokens,
+		dummyHash: dummyHash,
+	}, nil
+}
+
+// Register ユーザーを登録し、新しいセッションのトークンを発行
+// 最初に登録したユーザーには owner、以降のユーザーには member ロールを付与します
+func (s *AuthService) Register(req *models.RegisterRequest, client models.ClientInfo) (*models.AuthResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	hash, err := s.hasher.Hash(req.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.accounts.CreateAccount(models.NormalizeEmail(req.Email), strings.TrimSpace(req.Name), hash)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.startSession(user, client)
+}
+
+// Login メールアドレスとパスワードで認証し、新しいセッションのトークンを発行
+// 二要素認証が有効なユーザーにはトークンを発行せず、mfa_token を含む *MFARequiredError を返します
+func (s *AuthService) Login(req *models.LoginRequest, client models.ClientInfo) (*models.AuthResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	user, err := s.users.FindByEmail(models.NormalizeEmail(req.Email))
+	if err != nil {
+		if errors.Is(err, ErrUserNotFound) {
+			// ユーザーの存在を応答時間から推測されないよう、照合処理は常に実行
+			s.hasher.Verify(s.dummyHash, req.Password)
+			return nil, ErrInvalidCredentials
+		}
+		return nil, err
+	}
+
+	ok, err := s.hasher.Verify(user.PasswordHash, req.Password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify password: %w", err)
+	}
+	if !ok {
+		return nil, ErrInvalidCredentials
+	}
+	if err := s.mfaChallenge(user); err != nil {
+		return nil, err
+	}
+
+	return s.startSession(user, client)
+}
+
+// Refresh リフレッシュトークンをローテーションし、新しいトークンを発行
+// ローテーション済みのトークンが再利用された場合はセッション全体を失効させ、
+// ErrRefreshTokenReused（ErrInvalidToken としても判定可能）を返します
+func (s *AuthService) Refresh(req *models.RefreshTokenRequest, client models.ClientInfo) (*models.AuthResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	refreshToken, refreshHash, err := s.tokens.NewRefreshToken()
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := s.sessions.Rotate(hashToken(req.RefreshToken), refreshHash, client, s.refreshExpiry())
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrRefreshTokenReused):
+			return nil, fmt.Errorf("%w: %w", ErrInvalidToken, err)
+		case errors.Is(err, ErrSessionNotFound):
+			return nil, ErrInvalidToken
+		}
+		return nil, err
+	}
+
+	user, err := s.users.FindByID(session.UserID)
+	if err != nil {
+		if errors.Is(err, ErrUserNotFound) {
+			return nil, ErrInvalidToken
+		}
+		return nil, err
+	}
+
+	return s.issueTokens(user, session.ID, refreshToken)
+}
+
+// Authenticate アクセストークンまたはAPIキーを検証し、ロールと権限を読み込んだユーザーを返す
+// 削除されたユーザーや失効したセッションのトークンは有効期限内でも ErrInvalidToken になります
+// 権限はトークンに含めず毎回読み込むため、ロールの変更は即座に反映されます
+func (s *AuthService) Authenticate(accessToken string) (*models.User, error) {
+	if strings.HasPrefix(accessToken, utils.APIKeyPrefix) {
+		return s.authenticateAPIKey(accessToken)
+	}
+
+	claims, err := s.tokens.Parse(accessToken, TokenTypeAccess)
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := claims.UserID()
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := s.sessions.FindByID(claims.SessionID)
+	if err != nil {
+		if errors.Is(err, ErrSessionNotFound) {
+			return nil, ErrInvalidToken
+		}
+		return nil, err
+	}
+	if session.UserID != userID || !session.Active(time.Now()) {
+		return nil, ErrInvalidToken
+	}
+
+	user, err := s.users.FindByID(userID)
+	if err != nil {
+		if errors.Is(err, ErrUserNotFound) {
+			return nil, ErrInvalidToken
+		}
+		return nil, err
+	}
+	if err := s.loadAccess(user); err != nil {
+		return nil, err
+	}
+	user.SessionID = session.ID
+
+	return user, nil
+}
+
+// ListSessions ユーザーの有効なセッションを取得（認証に使用中のセッションに current を付与）
+func (s *AuthService) ListSessions(user *models.User) ([]models.Session, error) {
+	sessions, err := s.sessions.ListActive(user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range sessions {
+		sessions[i].Device = models.DeviceFromUserAgent(sessions[i].UserAgent)
+		sessions[i].Current = sessions[i].ID == user.SessionID
+	}
+	return sessions, nil
+}
+
+// RevokeSession ユーザーのセッションを失効（以降そのセッションのトークンは使用不可）
+func (s *AuthService) RevokeSession(user *models.User, sessionID string) error {
+	return s.sessions.Revoke(user.ID, sessionID)
+}
+
+// RevokeOtherSessions 認証に使用中のセッション以外を全て失効
+func (s *AuthService) RevokeOtherSessions(user *models.User) (int, error) {
+	return s.sessions.RevokeAllExcept(user.ID, user.SessionID)
+}
+
+// startSession 新しいセッションを作成してトークンを発行
+func (s *AuthService) startSession(user *models.User, client models.ClientInfo) (*models.AuthResponse, error) {
+	refreshToken, refreshHash, err := s.tokens.NewRefreshToken()
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := s.sessions.Create(user.ID, client, refreshHash, s.refreshExpiry())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create session: %w", err)
+	}
+
+	return s.issueTokens(user, session.ID, refreshToken)
+}
+
+// issueTokens ロールと権限を読み込み、アクセストークンを発行してレスポンスを組み立てる
+func (s *AuthService) issueTokens(user *models.User, sessionID, refreshToken string) (*models.AuthResponse, error) {
+	if err := s.loadAccess(user); err != nil {
+		return nil, err
+	}
+
+	accessToken, err := s.tokens.IssueAccessToken(user, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.AuthResponse{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		TokenType:    utils.TokenTypeBearer,
+		ExpiresIn:    int64(s.tokens.AccessTTL() / time.Second),
+		User:         user,
+	}, nil
+}
+
+// refreshExpiry 新しいリフレッシュトークンの有効期限
+func (s *AuthService) refreshExpiry() time.Time {
+	return s.tokens.now().Add(s.tokens.RefreshTTL())
+}
+
+// loadAccess ユーザーのロールと権限を読み込む
+func (s *AuthService) loadAccess(user *models.User) error {
+	roles, err := s.roles.RolesForUser(user.ID)
+	if err != nil {
+		return fmt.Errorf("failed to load roles: %w", err)
+	}
+	permissions, err := s.roles.PermissionsForUser(user.ID)
+	if err != nil {
+		return fmt.Errorf("failed to load permissions: %w", err)
+	}
+
+	user.Roles = roles
+	user.Permissions = permissions
+	return nil
+}
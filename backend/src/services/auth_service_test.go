@@ -0,0 +1,245 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"backend/models"
+	"backend/utils"
+)
+
+// testClient テスト用のクライアント情報
+var testClient = models.ClientInfo{UserAgent: "Mozilla/5.0 (X11; Linux x86_64; rv:121.0) Gecko/20100101 Firefox/121.0", IP: "192.0.2.1"}
+
+// newTestAuthService メモリリポジトリを使用するテスト用認証サービス
+func newTestAuthService(t *testing.T) (*AuthService, UserRepository) {
+	t.Helper()
+	repos, err := NewRepositories(utils.StorageDriverMemory, nil)
+	if err != nil {
+		t.Fatalf("NewRepositories() error = %v", err)
+	}
+	service, err := NewAuthService(repos, newTestPasswordHasher(utils.PasswordHashBcrypt), newTestTokenManager(t))
+	if err != nil {
+		t.Fatalf("NewAuthService() error = %v", err)
+	}
+	return service, repos.Users
+}
+
+// TestAuthServiceRegisterAndLogin 登録・ログイン・トークン検証のテスト
+func TestAuthServiceRegisterAndLogin(t *testing.T) {
+	service, users := newTestAuthService(t)
+
+	registered, err := service.Register(&models.RegisterRequest{Email: " Alice@Example.com ", Password: "password123", Name: "Alice"}, testClient)
+	if err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	if registered.User.Email != "alice@example.com" || registered.AccessToken == "" {
+		t.Errorf("登録結果が不正: %+v", registered)
+	}
+
+	stored, _ := users.FindByEmail("alice@example.com")
+	if stored.PasswordHash == "password123" {
+		t.Error("パスワードが平文で保存されている")
+	}
+
+	loggedIn, err := service.Login(&models.LoginRequest{Email: "ALICE@example.com", Password: "password123"}, testClient)
+	if err != nil {
+		t.Fatalf("Login() error = %v", err)
+	}
+
+	user, err := service.Authenticate(loggedIn.AccessToken)
+	if err != nil || user.ID != registered.User.ID {
+		t.Errorf("Authenticate()が不正: %+v, %v", user, err)
+	}
+
+	if _, err := service.Authenticate(loggedIn.RefreshToken); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("リフレッシュトークンで認証できてしまう: %v", err)
+	}
+}
+
+// TestAuthServiceRegisterErrors 登録エラーのテスト
+func TestAuthServiceRegisterErrors(t *testing.T) {
+	service, _ := newTestAuthService(t)
+
+	if _, err := service.Register(&models.RegisterRequest{Email: "invalid", Password: "password123", Name: "A"}, testClient); err == nil {
+		t.Error("不正なメールアドレスで登録できてしまう")
+	} else if _, ok := err.(*models.ValidationError); !ok {
+		t.Errorf("Expected *models.ValidationError, got %T", err)
+	}
+
+	req := &models.RegisterRequest{Email: "bob@example.com", Password: "password123", Name: "Bob"}
+	if _, err := service.Register(req, testClient); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	req.Email = "BOB@example.com"
+	if _, err := service.Register(req, testClient); !errors.Is(err, ErrEmailAlreadyExists) {
+		t.Errorf("大文字小文字違いの重複登録でErrEmailAlreadyExistsが返らない: %v", err)
+	}
+}
+
+// TestAuthServiceLoginErrors ログインエラーのテスト
+func TestAuthServiceLoginErrors(t *testing.T) {
+	service, _ := newTestAuthService(t)
+	service.Register(&models.RegisterRequest{Email: "carol@example.com", Password: "password123", Name: "Carol"}, testClient)
+
+	tests := []models.LoginRequest{
+		{Email: "carol@example.com", Password: "wrong-password"},
+		{Email: "unknown@example.com", Password: "password123"},
+	}
+	for _, req := range tests {
+		if _, err := service.Login(&req, testClient); !errors.Is(err, ErrInvalidCredentials) {
+			t.Errorf("Login(%s): expected ErrInvalidCredentials, got %v", req.Email, err)
+		}
+	}
+}
+
+// TestAuthServiceRefresh トークン再発行のテスト
+func TestAuthServiceRefresh(t *testing.T) {
+	service, _ := newTestAuthService(t)
+	registered, _ := service.Register(&models.RegisterRequest{Email: "dave@example.com", Password: "password123", Name: "Dave"}, testClient)
+
+	refreshed, err := service.Refresh(&models.RefreshTokenRequest{RefreshToken: registered.RefreshToken}, testClient)
+	if err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	if _, err := service.Authenticate(refreshed.AccessToken); err != nil {
+		t.Errorf("再発行したアクセストークンで認証できない: %v", err)
+	}
+
+	if _, err := service.Refresh(&models.RefreshTokenRequest{RefreshToken: registered.AccessToken}, testClient); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("アクセストークンで再発行できてしまう: %v", err)
+	}
+}
+
+// TestAuthServiceAuthenticateDeletedUser 存在しないユーザーのトークンのテスト
+func TestAuthServiceAuthenticateDeletedUser(t *testing.T) {
+	service, _ := newTestAuthService(t)
+
+	session, err := service.sessions.Create(999, testClient, "hash", time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	accessToken, err := service.tokens.IssueAccessToken(&models.User{ID: 999, Email: "ghost@example.com"}, session.ID)
+	if err != nil {
+		t.Fatalf("IssueAccessToken() error = %v", err)
+	}
+	if _, err := service.Authenticate(accessToken); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("存在しないユーザーのトークンで認証できてしまう: %v", err)
+	}
+}
+
+// TestAuthServiceRefreshRotation リフレッシュトークンのローテーションと再利用検知のテスト
+func TestAuthServiceRefreshRotation(t *testing.T) {
+	service, _ := newTestAuthService(t)
+	registered, _ := service.Register(&models.RegisterRequest{Email: "erin@example.com", Password: "password123", Name: "Erin"}, testClient)
+
+	rotated, err := service.Refresh(&models.RefreshTokenRequest{RefreshToken: registered.RefreshToken}, testClient)
+	if err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	if rotated.RefreshToken == registered.RefreshToken {
+		t.Error("リフレッシュトークンがローテーションされていない")
+	}
+
+	// ローテーション済みのトークンを再利用するとセッション全体が失効する
+	_, err = service.Refresh(&models.RefreshTokenRequest{RefreshToken: registered.RefreshToken}, testClient)
+	if !errors.Is(err, ErrRefreshTokenReused) || !errors.Is(err, ErrInvalidToken) {
+		t.Fatalf("再利用でErrRefreshTokenReusedが返らない: %v", err)
+	}
+	if _, err := service.Refresh(&models.RefreshTokenRequest{RefreshToken: rotated.RefreshToken}, testClient); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("失効したファミリーの最新トークンで再発行できてしまう: %v", err)
+	}
+	if _, err := service.Authenticate(rotated.AccessToken); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("失効したセッションのアクセストークンで認証できてしまう: %v", err)
+	}
+
+	// 別のセッションには影響しない
+	other, err := service.Login(&models.LoginRequest{Email: "erin@example.com", Password: "password123"}, testClient)
+	if err != nil {
+		t.Fatalf("Login() error = %v", err)
+	}
+	if _, err := service.Authenticate(other.AccessToken); err != nil {
+		t.Errorf("別セッションのアクセストークンで認証できない: %v", err)
+	}
+}
+
+// TestAuthServiceSessions セッション一覧と失効のテスト
+func TestAuthServiceSessions(t *testing.T) {
+	service, _ := newTestAuthService(t)
+	first, _ := service.Register(&models.RegisterRequest{Email: "frank@example.com", Password: "password123", Name: "Frank"}, testClient)
+	second, _ := service.Login(&models.LoginRequest{Email: "frank@example.com", Password: "password123"}, models.ClientInfo{UserAgent: "curl/8.4.0", IP: "198.51.100.7"})
+
+	user, err := service.Authenticate(second.AccessToken)
+	if err != nil {
+		t.Fatalf("Authenticate() error = %v", err)
+	}
+
+	sessions, err := service.ListSessions(user)
+	if err != nil {
+		t.Fatalf("ListSessions() error = %v", err)
+	}
+	if len(sessions) != 2 {
+		t.Fatalf("Expected 2 sessions, got %d", len(sessions))
+	}
+	current := 0
+	for _, session := range sessions {
+		if session.Current {
+			current++
+			if session.ID != user.SessionID || session.Device != "curl" || session.IP != "198.51.100.7" {
+				t.Errorf("現在のセッションが不正: %+v", session)
+			}
+		}
+	}
+	if current != 1 {
+		t.Errorf("current のセッションが%d件", current)
+	}
+
+	// 他のセッションを一括失効
+	revoked, err := service.RevokeOtherSessions(user)
+	if err != nil || revoked != 1 {
+		t.Errorf("RevokeOtherSessions() = %d, %v", revoked, err)
+	}
+	if _, err := service.Authenticate(first.AccessToken); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("失効したセッションのアクセストークンで認証できてしまう: %v", err)
+	}
+
+	// 現在のセッションを失効
+	if err := service.RevokeSession(user, user.SessionID); err != nil {
+		t.Fatalf("RevokeSession() error = %v", err)
+	}
+	if err := service.RevokeSession(user, user.SessionID); !errors.Is(err, ErrSessionNotFound) {
+		t.Errorf("失効済みセッションでErrSessionNotFoundが返らない: %v", err)
+	}
+	if _, err := service.Refresh(&models.RefreshTokenRequest{RefreshToken: second.RefreshToken}, testClient); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("失効したセッションで再発行できてしまう: %v", err)
+	}
+}
+
+// TestAuthServiceDefaultRoles 初期ロール付与と権限読み込みのテスト
+func TestAuthServiceDefaultRoles(t *testing.T) {
+	service, _ := newTestAuthService(t)
+
+	owner, err := service.Register(&models.RegisterRequest{Email: "owner@example.com", Password: "password123", Name: "Owner"}, testClient)
+	if err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	if len(owner.User.Roles) != 1 || owner.User.Roles[0] != models.RoleOwner {
+		t.Errorf("最初のユーザーが owner でない: %v", owner.User.Roles)
+	}
+
+	member, err := service.Register(&models.RegisterRequest{Email: "member@example.com", Password: "password123", Name: "Member"}, testClient)
+	if err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	user, err := service.Authenticate(member.AccessToken)
+	if err != nil {
+		t.Fatalf("Authenticate() error = %v", err)
+	}
+	if len(user.Roles) != 1 || user.Roles[0] != models.RoleMember {
+		t.Errorf("2人目のユーザーが member でない: %v", user.Roles)
+	}
+	if !user.HasPermission(models.PermissionMessagesCreate) || user.HasPermission(models.PermissionMessagesDelete) {
+		t.Errorf("member の権限が不正: %v", user.Permissions)
+	}
+}
@@ -0,0 +1,28 @@
+package services
+
+import "backend/models"
+
+var (
+	// ErrCustomerNotFound 指定された顧客が存在しない
+	ErrCustomerNotFound = newError(ErrNotFound, "customer not found", "customer.not_found")
+
+	// ErrCustomerEmailExists メールアドレスが他の顧客で使用されている
+	ErrCustomerEmailExists = newError(ErrConflict, "customer email already exists", "customer.email_taken")
+)
+
+// CustomerRepository 顧客の永続化インターフェース
+//
+// email は正規化済み（小文字）の値で受け渡します。
+type CustomerRepository interface {
+	// Create 顧客を保存し、採番されたIDとタイムスタンプを含めて返す
+	// email が重複する場合は ErrCustomerEmailExists を返す
+	Create(customer *models.Customer) (*models.Customer, error)
+	// List 検索条件に一致する顧客をページ単位で返す
+	List(spec *QuerySpec) (*Page[models.Customer], error)
+	// FindByID IDで顧客を返す
+	FindByID(id int) (*models.Customer, error)
+	// Update 顧客の全項目を置き換え、updated_at を更新する
+	Update(id int, customer *models.Customer) (*models.Customer, error)
+	// Delete 顧客を削除する
+	Delete(id int) error
+}
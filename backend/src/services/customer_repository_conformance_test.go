@@ -0,0 +1,177 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"testing"
+	"time"
+
+	"backend/models"
+)
+
+// TestMemoryCustomerRepositoryConformance メモリ顧客リポジトリの適合テスト
+func TestMemoryCustomerRepositoryConformance(t *testing.T) {
+	runCustomerRepositoryConformance(t, func(t *testing.T) CustomerRepository {
+		return NewMemoryCustomerRepository()
+	})
+}
+
+// TestPostgresCustomerRepositoryConformance PostgreSQL顧客リポジトリの適合テスト
+func TestPostgresCustomerRepositoryConformance(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	runCustomerRepositoryConformance(t, func(t *testing.T) CustomerRepository {
+		return NewPostgresCustomerRepository(db)
+	})
+}
+
+// runCustomerRepositoryConformance 全てのCustomerRepository実装が満たすべき振る舞い
+// 共有DBでも動作するよう、email と location は都度一意な値で作成します
+func runCustomerRepositoryConformance(t *testing.T, newRepo func(t *testing.T) CustomerRepository) {
+	unique := func(prefix string) string {
+		return fmt.Sprintf("%s%d", prefix, time.Now().UnixNano())
+	}
+	newCustomer := func(location string) *models.Customer {
+		return &models.Customer{
+			Name:     "Alex Smith",
+			Email:    unique("customer") + "@example.com",
+			Status:   models.CustomerStatusSubscribed,
+			Location: location,
+		}
+	}
+
+	t.Run("CreateAndFind", func(t *testing.T) {
+		repo := newRepo(t)
+		customer := newCustomer("New York")
+		customer.Avatar = &models.Avatar{Src: "https://i.pravatar.cc/128?u=1"}
+
+		created, err := repo.Create(customer)
+		if err != nil {
+			t.Fatalf("Create失敗: %v", err)
+		}
+		if created.ID == 0 || created.CreatedAt.IsZero() || created.UpdatedAt.IsZero() {
+			t.Errorf("ID・タイムスタンプが設定されていない: %+v", created)
+		}
+
+		found, err := repo.FindByID(created.ID)
+		if err != nil {
+			t.Fatalf("FindByID失敗: %v", err)
+		}
+		if found.Email != customer.Email || found.Avatar == nil || found.Avatar.Src != customer.Avatar.Src || found.Status != models.CustomerStatusSubscribed {
+			t.Errorf("取得結果が不正: %+v", found)
+		}
+
+		if _, err := repo.FindByID(999999999); !errors.Is(err, ErrCustomerNotFound) {
+			t.Errorf("Expected ErrCustomerNotFound, got %v", err)
+		}
+	})
+
+	t.Run("WithoutAvatar", func(t *testing.T) {
+		repo := newRepo(t)
+		created, err := repo.Create(newCustomer(""))
+		if err != nil {
+			t.Fatalf("Create失敗: %v", err)
+		}
+		found, err := repo.FindByID(created.ID)
+		if err != nil {
+			t.Fatalf("FindByID失敗: %v", err)
+		}
+		if found.Avatar != nil {
+			t.Errorf("アバター未設定なのに avatar が返る: %+v", found.Avatar)
+		}
+	})
+
+	t.Run("DuplicateEmail", func(t *testing.T) {
+		repo := newRepo(t)
+		first := newCustomer("")
+		if _, err := repo.Create(first); err != nil {
+			t.Fatalf("Create失敗: %v", err)
+		}
+		second := newCustomer("")
+		second.Email = first.Email
+		if _, err := repo.Create(second); !errors.Is(err, ErrCustomerEmailExists) {
+			t.Errorf("Expected ErrCustomerEmailExists, got %v", err)
+		}
+
+		other, err := repo.Create(newCustomer(""))
+		if err != nil {
+			t.Fatalf("Create失敗: %v", err)
+		}
+		other.Email = first.Email
+		if _, err := repo.Update(other.ID, other); !errors.Is(err, ErrCustomerEmailExists) {
+			t.Errorf("更新でExpected ErrCustomerEmailExists, got %v", err)
+		}
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		repo := newRepo(t)
+		created, err := repo.Create(newCustomer("Paris"))
+		if err != nil {
+			t.Fatalf("Create失敗: %v", err)
+		}
+
+		replacement := newCustomer("Tokyo")
+		replacement.Status = models.CustomerStatusBounced
+		updated, err := repo.Update(created.ID, replacement)
+		if err != nil {
+			t.Fatalf("Update失敗: %v", err)
+		}
+		if updated.ID != created.ID || updated.Location != "Tokyo" || updated.Status != models.CustomerStatusBounced {
+			t.Errorf("更新結果が不正: %+v", updated)
+		}
+		if !updated.CreatedAt.Equal(created.CreatedAt) || updated.UpdatedAt.Before(created.UpdatedAt) {
+			t.Errorf("タイムスタンプが不正: created=%+v updated=%+v", created, updated)
+		}
+
+		if _, err := repo.Update(999999999, replacement); !errors.Is(err, ErrCustomerNotFound) {
+			t.Errorf("Expected ErrCustomerNotFound, got %v", err)
+		}
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		repo := newRepo(t)
+		created, err := repo.Create(newCustomer(""))
+		if err != nil {
+			t.Fatalf("Create失敗: %v", err)
+		}
+		if err := repo.Delete(created.ID); err != nil {
+			t.Fatalf("Delete失敗: %v", err)
+		}
+		if err := repo.Delete(created.ID); !errors.Is(err, ErrCustomerNotFound) {
+			t.Errorf("Expected ErrCustomerNotFound, got %v", err)
+		}
+	})
+
+	t.Run("ListFilterAndSort", func(t *testing.T) {
+		repo := newRepo(t)
+		location := unique("Location")
+		for _, status := range []models.CustomerStatus{models.CustomerStatusSubscribed, models.CustomerStatusBounced, models.CustomerStatusSubscribed} {
+			customer := newCustomer(location)
+			customer.Status = status
+			if _, err := repo.Create(customer); err != nil {
+				t.Fatalf("Create失敗: %v", err)
+			}
+		}
+
+		spec, err := CustomerQuerySchema.Parse(url.Values{
+			"location": {location},
+			"status":   {"subscribed"},
+			"sort":     {"id"},
+		})
+		if err != nil {
+			t.Fatalf("Parse失敗: %v", err)
+		}
+		page, err := repo.List(spec)
+		if err != nil {
+			t.Fatalf("List失敗: %v", err)
+		}
+		if page.Total != 2 || len(page.Items) != 2 {
+			t.Fatalf("Expected 2 customers, got total=%d items=%d", page.Total, len(page.Items))
+		}
+		if page.Items[0].ID > page.Items[1].ID {
+			t.Errorf("id 昇順になっていない: %d, %d", page.Items[0].ID, page.Items[1].ID)
+		}
+	})
+}
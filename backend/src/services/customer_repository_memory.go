@@ -0,0 +1,141 @@
+package services
+
+import (
+	"sync"
+	"time"
+
+	"backend/models"
+)
+
+// MemoryCustomerRepository メモリ上で動作する顧客リポジトリ
+type MemoryCustomerRepository struct {
+	mu        sync.RWMutex
+	customers map[int]models.Customer
+	nextID    int
+}
+
+// NewMemoryCustomerRepository メモリ顧客リポジトリを新規作成
+func NewMemoryCustomerRepository() *MemoryCustomerRepository {
+	return &MemoryCustomerRepository{
+		customers: make(map[int]models.Customer),
+		nextID:    1,
+	}
+}
+
+// Create 顧客を保存
+func (r *MemoryCustomerRepository) Create(customer *models.Customer) (*models.Customer, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.emailTaken(customer.Email, 0) {
+		return nil, ErrCustomerEmailExists
+	}
+
+	now := time.Now()
+	stored := copyCustomer(*customer)
+	stored.ID = r.nextID
+	stored.CreatedAt = now
+	stored.UpdatedAt = now
+	r.customers[stored.ID] = stored
+	r.nextID++
+
+	result := copyCustomer(stored)
+	return &result, nil
+}
+
+// List 検索条件に一致する顧客をページ単位で取得
+func (r *MemoryCustomerRepository) List(spec *QuerySpec) (*Page[models.Customer], error) {
+	r.mu.RLock()
+	customers := make([]models.Customer, 0, len(r.customers))
+	for _, customer := range r.customers {
+		customers = append(customers, copyCustomer(customer))
+	}
+	r.mu.RUnlock()
+
+	return applyQuerySpec(customers, spec, customerFields, customerCursor), nil
+}
+
+// FindByID IDで顧客を取得
+func (r *MemoryCustomerRepository) FindByID(id int) (*models.Customer, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	customer, ok := r.customers[id]
+	if !ok {
+		return nil, ErrCustomerNotFound
+	}
+	result := copyCustomer(customer)
+	return &result, nil
+}
+
+// Update 顧客を全置換で更新
+func (r *MemoryCustomerRepository) Update(id int, customer *models.Customer) (*models.Customer, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current, ok := r.customers[id]
+	if !ok {
+		return nil, ErrCustomerNotFound
+	}
+	if r.emailTaken(customer.Email, id) {
+		return nil, ErrCustomerEmailExists
+	}
+
+	updated := copyCustomer(*customer)
+	updated.ID = id
+	updated.CreatedAt = current.CreatedAt
+	updated.UpdatedAt = time.Now()
+	r.customers[id] = updated
+
+	result := copyCustomer(updated)
+	return &result, nil
+}
+
+// Delete 顧客を削除
+func (r *MemoryCustomerRepository) Delete(id int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.customers[id]; !ok {
+		return ErrCustomerNotFound
+	}
+	delete(r.customers, id)
+	return nil
+}
+
+// emailTaken 指定ID以外の顧客が email を使用しているか判定（呼び出し側でロックを保持すること）
+func (r *MemoryCustomerRepository) emailTaken(email string, exceptID int) bool {
+	for id, customer := range r.customers {
+		if id != exceptID && customer.Email == email {
+			return true
+		}
+	}
+	return false
+}
+
+// copyCustomer 呼び出し側の変更が保存データに影響しないよう顧客を複製
+func copyCustomer(customer models.Customer) models.Customer {
+	if customer.Avatar != nil {
+		avatar := *customer.Avatar
+		customer.Avatar = &avatar
+	}
+	return customer
+}
+
+// customerFields QuerySpec評価用のフィールド値
+func customerFields(customer models.Customer) map[string]interface{} {
+	return map[string]interface{}{
+		"id":         customer.ID,
+		"name":       customer.Name,
+		"email":      customer.Email,
+		"status":     string(customer.Status),
+		"location":   customer.Location,
+		"created_at": customer.CreatedAt,
+		"updated_at": customer.UpdatedAt,
+	}
+}
+
+// customerCursor 顧客の位置を表すカーソル
+func customerCursor(customer models.Customer) Cursor {
+	return Cursor{CreatedAt: customer.CreatedAt, ID: customer.ID}
+}
@@ -0,0 +1,164 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"backend/models"
+)
+
+// customerColumns 顧客取得時の列
+const customerColumns = `id, name, email, avatar_url, status, location, created_at, updated_at`
+
+// PostgresCustomerRepository PostgreSQLによる顧客リポジトリ
+type PostgresCustomerRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresCustomerRepository PostgreSQL顧客リポジトリを新規作成
+// db が nil の場合、全ての操作は ErrDatabaseUnavailable を返します
+func NewPostgresCustomerRepository(db *sql.DB) *PostgresCustomerRepository {
+	return &PostgresCustomerRepository{db: db}
+}
+
+// Create 顧客を保存
+func (r *PostgresCustomerRepository) Create(customer *models.Customer) (*models.Customer, error) {
+	if r.db == nil {
+		return nil, ErrDatabaseUnavailable
+	}
+
+	query := `
+		INSERT INTO customers (name, email, avatar_url, status, location)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING ` + customerColumns
+
+	created, err := scanCustomer(r.db.QueryRow(query, customer.Name, customer.Email, avatarURL(customer.Avatar), customer.Status, customer.Location))
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, ErrCustomerEmailExists
+		}
+		return nil, fmt.Errorf("failed to create customer: %w", err)
+	}
+	return created, nil
+}
+
+// List 検索条件に一致する顧客をページ単位で取得
+func (r *PostgresCustomerRepository) List(spec *QuerySpec) (*Page[models.Customer], error) {
+	if r.db == nil {
+		return nil, ErrDatabaseUnavailable
+	}
+
+	var countArgs []interface{}
+	countQuery := `SELECT COUNT(*) FROM customers` + spec.WhereClause(&countArgs, false)
+
+	var total int
+	if err := r.db.QueryRow(countQuery, countArgs...).Scan(&total); err != nil {
+		return nil, fmt.Errorf("failed to count customers: %w", err)
+	}
+
+	var args []interface{}
+	query := `SELECT ` + customerColumns + ` FROM customers` + spec.WhereClause(&args, true) + spec.OrderClause()
+
+	// 次ページの有無を判定するため1件多く取得
+	page := spec.Page
+	args = append(args, page.Limit+1, page.Offset)
+	query += fmt.Sprintf(` LIMIT $%d OFFSET $%d`, len(args)-1, len(args))
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query customers: %w", err)
+	}
+	defer rows.Close()
+
+	customers := make([]models.Customer, 0, page.Limit+1)
+	for rows.Next() {
+		customer, err := scanCustomer(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan customer: %w", err)
+		}
+		customers = append(customers, *customer)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating customers: %w", err)
+	}
+
+	return newPage(spec, customers, total, customerCursor), nil
+}
+
+// FindByID IDで顧客を取得
+func (r *PostgresCustomerRepository) FindByID(id int) (*models.Customer, error) {
+	if r.db == nil {
+		return nil, ErrDatabaseUnavailable
+	}
+
+	customer, err := scanCustomer(r.db.QueryRow(`SELECT `+customerColumns+` FROM customers WHERE id = $1`, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrCustomerNotFound
+		}
+		return nil, fmt.Errorf("failed to get customer: %w", err)
+	}
+	return customer, nil
+}
+
+// Update 顧客を全置換で更新（updated_at はトリガーで更新）
+func (r *PostgresCustomerRepository) Update(id int, customer *models.Customer) (*models.Customer, error) {
+	if r.db == nil {
+		return nil, ErrDatabaseUnavailable
+	}
+
+	query := `
+		UPDATE customers
+		SET name = $2, email = $3, avatar_url = $4, status = $5, location = $6
+		WHERE id = $1
+		RETURNING ` + customerColumns
+
+	updated, err := scanCustomer(r.db.QueryRow(query, id, customer.Name, customer.Email, avatarURL(customer.Avatar), customer.Status, customer.Location))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrCustomerNotFound
+		}
+		if isUniqueViolation(err) {
+			return nil, ErrCustomerEmailExists
+		}
+		return nil, fmt.Errorf("failed to update customer: %w", err)
+	}
+	return updated, nil
+}
+
+// Delete 顧客を削除
+func (r *PostgresCustomerRepository) Delete(id int) error {
+	if r.db == nil {
+		return ErrDatabaseUnavailable
+	}
+
+	result, err := r.db.Exec(`DELETE FROM customers WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete customer: %w", err)
+	}
+	return requireAffected(result, ErrCustomerNotFound)
+}
+
+// scanCustomer 1行を顧客に変換（空の avatar_url はアバターなし）
+func scanCustomer(row rowScanner) (*models.Customer, error) {
+	var customer models.Customer
+	var avatar string
+	err := row.Scan(&customer.ID, &customer.Name, &customer.Email, &avatar, &customer.Status,
+		&customer.Location, &customer.CreatedAt, &customer.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if avatar != "" {
+		customer.Avatar = &models.Avatar{Src: avatar}
+	}
+	return &customer, nil
+}
+
+// avatarURL 保存用のアバターURL（アバターなしは空文字）
+func avatarURL(avatar *models.Avatar) string {
+	if avatar == nil {
+		return ""
+	}
+	return avatar.Src
+}
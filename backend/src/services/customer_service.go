@@ -0,0 +1,130 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"backend/models"
+	"backend/utils"
+)
+
+// CustomerQuerySchema 顧客一覧の検索・ソート定義
+var CustomerQuerySchema = &QuerySchema{
+	Filters: map[string]FilterDef{
+		"status":         {Field: "status", Op: FilterEq, Type: FieldString},
+		"location":       {Field: "location", Op: FilterEq, Type: FieldString},
+		"created_after":  {Field: "created_at", Op: FilterGt, Type: FieldTime},
+		"created_before": {Field: "created_at", Op: FilterLt, Type: FieldTime},
+	},
+	Columns: map[string]string{
+		"id":         "id",
+		"name":       "name",
+		"email":      "email",
+		"status":     "status",
+		"location":   "location",
+		"created_at": "created_at",
+		"updated_at": "updated_at",
+	},
+	Sortable:     []string{"id", "name", "email", "status", "location", "created_at", "updated_at"},
+	DefaultSort:  []SortField{{Field: "created_at", Desc: true}},
+	SearchColumn: "search_vector",
+	SearchFields: []string{"name", "email", "location"},
+}
+
+// CustomerService 顧客サービス構造体
+type CustomerService struct {
+	repo     CustomerRepository
+	notifier Notifier
+}
+
+// NewCustomerService 顧客サービスを新規作成
+// notifier が nil の場合、顧客の登録を通知しません
+func NewCustomerService(repo CustomerRepository, notifier Notifier) *CustomerService {
+	return &CustomerService{repo: repo, notifier: notifier}
+}
+
+// ListCustomers 検索条件に一致する顧客をページ単位で取得
+func (s *CustomerService) ListCustomers(spec *QuerySpec) (*Page[models.Customer], error) {
+	return s.repo.List(spec)
+}
+
+// GetCustomer IDで顧客を取得
+func (s *CustomerService) GetCustomer(id int) (*models.Customer, error) {
+	return s.repo.FindByID(id)
+}
+
+// CreateCustomer 顧客を作成
+func (s *CustomerService) CreateCustomer(request *models.CustomerRequest) (*models.Customer, error) {
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+	customer, err := s.repo.Create(customerFromRequest(request))
+	if err != nil {
+		return nil, err
+	}
+
+	notifyAll(s.notifier, &NotificationEvent{
+		Type:   models.NotificationTypeCustomerCreated,
+		Sender: models.NotificationSender{Name: customer.Name, Email: customer.Email, Avatar: customer.Avatar},
+		Body:   "was added as a new customer",
+		Link:   fmt.Sprintf("/customers?id=%d", customer.ID),
+	})
+	return customer, nil
+}
+
+// UpdateCustomer 顧客を全置換で更新
+func (s *CustomerService) UpdateCustomer(id int, request *models.CustomerRequest) (*models.Customer, error) {
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+	return s.repo.Update(id, customerFromRequest(request))
+}
+
+// PatchCustomer JSON Merge Patch (RFC 7396) で顧客を部分更新
+func (s *CustomerService) PatchCustomer(id int, patch []byte) (*models.Customer, error) {
+	current, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	// 更新可能なフィールドのみを対象にパッチを適用
+	original, err := json.Marshal(models.CustomerRequest{
+		Name:     current.Name,
+		Email:    current.Email,
+		Avatar:   current.Avatar,
+		Status:   current.Status,
+		Location: current.Location,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode customer: %w", err)
+	}
+
+	merged, err := utils.ApplyMergePatch(original, patch)
+	if err != nil {
+		return nil, models.NewValidationError("body", models.ValidationCodeInvalid, "validation.invalid_merge_patch", nil)
+	}
+
+	var request models.CustomerRequest
+	if err := models.DecodeJSON(bytes.NewReader(merged), &request); err != nil {
+		return nil, err
+	}
+
+	return s.UpdateCustomer(id, &request)
+}
+
+// DeleteCustomer 顧客を削除
+func (s *CustomerService) DeleteCustomer(id int) error {
+	return s.repo.Delete(id)
+}
+
+// customerFromRequest 検証済みリクエストから保存用の顧客を作成
+func customerFromRequest(request *models.CustomerRequest) *models.Customer {
+	return &models.Customer{
+		Name:     request.Name,
+		Email:    request.Email,
+		Avatar:   request.Avatar,
+		Status:   request.Status,
+		Location: request.Location,
+	}
+}
@@ -0,0 +1,78 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"backend/models"
+)
+
+// TestCustomerServiceCreateValidation 顧客作成のバリデーションテスト
+func TestCustomerServiceCreateValidation(t *testing.T) {
+	service := NewCustomerService(NewMemoryCustomerRepository(), nil)
+
+	tests := []struct {
+		name    string
+		request models.CustomerRequest
+		field   string
+	}{
+		{"名前なし", models.CustomerRequest{Name: " ", Email: "a@example.com"}, "name"},
+		{"不正なメールアドレス", models.CustomerRequest{Name: "A", Email: "invalid"}, "email"},
+		{"不正なステータス", models.CustomerRequest{Name: "A", Email: "a@example.com", Status: "active"}, "status"},
+		{"不正なアバターURL", models.CustomerRequest{Name: "A", Email: "a@example.com", Avatar: &models.Avatar{Src: "javascript:alert(1)"}}, "avatar.src"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.CreateCustomer(&tt.request)
+			var validationErr *models.ValidationError
+			if !errors.As(err, &validationErr) || validationErr.Field != tt.field {
+				t.Errorf("Expected validation error on %q, got %v", tt.field, err)
+			}
+		})
+	}
+
+	created, err := service.CreateCustomer(&models.CustomerRequest{Name: " Alex ", Email: " Alex@Example.com ", Avatar: &models.Avatar{Src: ""}})
+	if err != nil {
+		t.Fatalf("CreateCustomer() error = %v", err)
+	}
+	if created.Name != "Alex" || created.Email != "alex@example.com" || created.Status != models.CustomerStatusSubscribed || created.Avatar != nil {
+		t.Errorf("正規化・デフォルト値が不正: %+v", created)
+	}
+
+	if _, err := service.CreateCustomer(&models.CustomerRequest{Name: "Other", Email: "ALEX@example.com"}); !errors.Is(err, ErrCustomerEmailExists) {
+		t.Errorf("大文字小文字違いの重複でErrCustomerEmailExistsが返らない: %v", err)
+	}
+}
+
+// TestCustomerServicePatch 顧客の部分更新テスト
+func TestCustomerServicePatch(t *testing.T) {
+	service := NewCustomerService(NewMemoryCustomerRepository(), nil)
+	created, err := service.CreateCustomer(&models.CustomerRequest{
+		Name:     "Alex",
+		Email:    "alex@example.com",
+		Avatar:   &models.Avatar{Src: "https://i.pravatar.cc/128?u=1"},
+		Location: "New York",
+	})
+	if err != nil {
+		t.Fatalf("CreateCustomer() error = %v", err)
+	}
+
+	patched, err := service.PatchCustomer(created.ID, []byte(`{"status":"unsubscribed","avatar":null}`))
+	if err != nil {
+		t.Fatalf("PatchCustomer() error = %v", err)
+	}
+	if patched.Status != models.CustomerStatusUnsubscribed || patched.Avatar != nil || patched.Location != "New York" || patched.Name != "Alex" {
+		t.Errorf("部分更新の結果が不正: %+v", patched)
+	}
+
+	var validationErr *models.ValidationError
+	if _, err := service.PatchCustomer(created.ID, []byte(`{"id":5}`)); !errors.As(err, &validationErr) {
+		t.Errorf("更新不可フィールドでValidationErrorが返らない: %v", err)
+	}
+	if _, err := service.PatchCustomer(created.ID, []byte(`{"status":"bogus"}`)); !errors.As(err, &validationErr) || validationErr.Field != "status" {
+		t.Errorf("不正なステータスでValidationErrorが返らない: %v", err)
+	}
+	if _, err := service.PatchCustomer(999, []byte(`{"name":"X"}`)); !errors.Is(err, ErrCustomerNotFound) {
+		t.Errorf("Expected ErrCustomerNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,53 @@
+package services
+
+import "errors"
+
+// エラーの種類
+// サービス・リポジトリのエラーは以下のいずれかを種類として持ち、errors.Is で種類を判定できます
+var (
+	// ErrNotFound 対象のリソースが存在しない
+	ErrNotFound = errors.New("not found")
+
+	// ErrConflict リソースの現在の状態と競合する
+	ErrConflict = errors.New("conflict")
+
+	// ErrValidation リクエストの値が不正（フィールド単位のエラーは *models.ValidationError）
+	ErrValidation = errors.New("validation failed")
+
+	// ErrUnauthorized 認証情報が不正・期限切れ
+	ErrUnauthorized = errors.New("unauthorized")
+
+	// ErrForbidden 認証済みだが操作が許可されていない
+	ErrForbidden = errors.New("forbidden")
+
+	// ErrGone リソースが期限切れで利用できない
+	ErrGone = errors.New("gone")
+
+	// ErrTooManyRequests 試行回数の上限に達した
+	ErrTooManyRequests = errors.New("too many requests")
+
+	// ErrUnavailable 依存するサービス（データベースなど）が利用できない
+	ErrUnavailable = errors.New("service unavailable")
+)
+
+// Error 種類とクライアント向けの説明を持つドメインエラー
+type Error struct {
+	Kind    error  // ErrNotFound などのエラーの種類
+	Detail  string // クライアントに返す説明のメッセージキー（models のメッセージカタログ）
+	message string
+}
+
+// newError ドメインエラーを新規作成（message はログ向け、detail はクライアント向けの説明のメッセージキー）
+func newError(kind error, message, detail string) error {
+	return &Error{Kind: kind, Detail: detail, message: message}
+}
+
+// Error エラーメッセージを返す
+func (e *Error) Error() string {
+	return e.message
+}
+
+// Unwrap エラーの種類を返す（errors.Is(err, ErrNotFound) などで判定するため）
+func (e *Error) Unwrap() error {
+	return e.Kind
+}
@@ -0,0 +1,27 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// TestErrorKind ドメインエラーを種類で判定できることのテスト
+func TestErrorKind(t *testing.T) {
+	wrapped := fmt.Errorf("find customer 42: %w", ErrCustomerNotFound)
+
+	if !errors.Is(wrapped, ErrCustomerNotFound) || !errors.Is(wrapped, ErrNotFound) {
+		t.Errorf("ErrCustomerNotFound と ErrNotFound の両方で判定できるべき: %v", wrapped)
+	}
+	if errors.Is(wrapped, ErrConflict) || errors.Is(wrapped, ErrSaleNotFound) {
+		t.Errorf("別の種類・エラーと判定された: %v", wrapped)
+	}
+
+	var domainErr *Error
+	if !errors.As(wrapped, &domainErr) || domainErr.Detail != "customer.not_found" {
+		t.Errorf("クライアント向けの説明を取得できない: %+v", domainErr)
+	}
+	if ErrCustomerNotFound.Error() != "customer not found" {
+		t.Errorf("Error() = %q", ErrCustomerNotFound.Error())
+	}
+}
@@ -0,0 +1,171 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"backend/models"
+)
+
+// EventBroker イベントバスから受け取ったイベントを購読者に配信し、再開用に直近のイベントを保持するブローカー
+//
+// イベントIDはブローカー（プロセス）ごとに採番し、起動ごとに異なる接頭辞を付けます。
+// 別のレプリカやサーバー再起動前のIDで再接続された場合は stream.reset を返します。
+//
+// 購読者ごとのバッファが溢れた場合（受信の遅いクライアント）は購読を終了します。
+// クライアントは Last-Event-ID で再接続すれば、保持している範囲のイベントを受け取れます。
+type EventBroker struct {
+	mu          sync.Mutex
+	epoch       string // イベントIDの接頭辞（起動ごとに異なる）
+	nextID      uint64
+	buffer      []models.Event // 直近のイベント（古い順、最大 bufferSize 件）
+	bufferSize  int
+	evictedID   uint64 // バッファから押し出された最新のイベントID
+	subscribers map[*EventSubscription]struct{}
+	closed      bool
+}
+
+// EventSubscription 1つの接続によるイベントの購読
+type EventSubscription struct {
+	userID int
+	events chan models.Event
+	once   sync.Once
+}
+
+// eventSubscriptionBuffer 購読者ごとの未送信イベントの上限
+const eventSubscriptionBuffer = 64
+
+// NewEventBroker イベントブローカーを新規作成
+// bufferSize は Last-Event-ID による再開用に保持するイベント数です
+func NewEventBroker(bufferSize int) *EventBroker {
+	if bufferSize < 1 {
+		bufferSize = 1
+	}
+	return &EventBroker{
+		epoch:       strconv.FormatInt(time.Now().UnixNano(), 36),
+		nextID:      1,
+		bufferSize:  bufferSize,
+		subscribers: make(map[*EventSubscription]struct{}),
+	}
+}
+
+// Dispatch イベントを採番して購読者に配信（EventBus.Subscribe に登録して使用）
+// ルーム内のイベントは WebSocket でのみ配信するため無視します
+func (b *EventBroker) Dispatch(event models.Event) {
+	if event.Room != "" {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return
+	}
+
+	event.ID = b.nextID
+	b.nextID++
+	if len(b.buffer) == b.bufferSize {
+		b.evictedID = b.buffer[0].ID
+		b.buffer = b.buffer[1:]
+	}
+	b.buffer = append(b.buffer, event)
+
+	for subscription := range b.subscribers {
+		if !subscription.accepts(event) {
+			continue
+		}
+		select {
+		case subscription.events <- event:
+		default:
+			// 受信が追いつかない購読者は切断し、再接続時の再送に任せる
+			b.remove(subscription)
+		}
+	}
+}
+
+// Subscribe ユーザー宛てのイベントを購読
+// lastEventID が指定された場合はそれ以降の保持しているイベントを replay として返します。
+// 途中のイベントが既に破棄されている、または別のプロセスが採番したIDの場合は、代わりに
+// 最新のイベントIDを持つ stream.reset イベントのみを返します（クライアントは状態を再取得してください）
+func (b *EventBroker) Subscribe(userID int, lastEventID string) (subscription *EventSubscription, replay []models.Event) {
+	subscription = &EventSubscription{userID: userID, events: make(chan models.Event, eventSubscriptionBuffer)}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		subscription.close()
+		return subscription, nil
+	}
+	b.subscribers[subscription] = struct{}{}
+
+	if lastEventID == "" {
+		return subscription, nil
+	}
+	last, ok := b.parseEventID(lastEventID)
+	if !ok || last < b.evictedID || last >= b.nextID {
+		reset := models.Event{ID: b.nextID - 1, Type: models.EventStreamReset, Data: json.RawMessage("{}"), Time: time.Now()}
+		return subscription, []models.Event{reset}
+	}
+	for _, event := range b.buffer {
+		if event.ID > last && subscription.accepts(event) {
+			replay = append(replay, event)
+		}
+	}
+	return subscription, replay
+}
+
+// Unsubscribe 購読を終了
+func (b *EventBroker) Unsubscribe(subscription *EventSubscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.remove(subscription)
+}
+
+// Close 全ての購読を終了し、以降のイベントを破棄（グレースフルシャットダウン時に使用）
+func (b *EventBroker) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.closed = true
+	for subscription := range b.subscribers {
+		b.remove(subscription)
+	}
+}
+
+// EventID クライアントに送信するイベントID（"接頭辞-連番" 形式）
+func (b *EventBroker) EventID(event models.Event) string {
+	return fmt.Sprintf("%s-%d", b.epoch, event.ID)
+}
+
+// parseEventID EventID 形式のIDから連番を取り出す（別のプロセスのIDの場合は false）
+func (b *EventBroker) parseEventID(id string) (uint64, bool) {
+	epoch, raw, found := strings.Cut(id, "-")
+	if !found || epoch != b.epoch {
+		return 0, false
+	}
+	seq, err := strconv.ParseUint(raw, 10, 64)
+	return seq, err == nil
+}
+
+// remove 購読者を削除してチャネルを閉じる（呼び出し側でロックを取得すること）
+func (b *EventBroker) remove(subscription *EventSubscription) {
+	delete(b.subscribers, subscription)
+	subscription.close()
+}
+
+// Events 配信されたイベントを受け取るチャネル（購読の終了時に閉じられる）
+func (s *EventSubscription) Events() <-chan models.Event {
+	return s.events
+}
+
+// accepts イベントが購読者宛てか判定
+func (s *EventSubscription) accepts(event models.Event) bool {
+	return event.UserID == 0 || event.UserID == s.userID
+}
+
+func (s *EventSubscription) close() {
+	s.once.Do(func() { close(s.events) })
+}
@@ -0,0 +1,144 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"backend/models"
+)
+
+// receiveEvent 購読からイベントを1件受信（一定時間内に届かなければ失敗）
+func receiveEvent(t *testing.T, subscription *EventSubscription) models.Event {
+	t.Helper()
+	select {
+	case event, ok := <-subscription.Events():
+		if !ok {
+			t.Fatal("購読が終了している")
+		}
+		return event
+	case <-time.After(time.Second):
+		t.Fatal("イベントが配信されない")
+	}
+	return models.Event{}
+}
+
+// newTestEventBroker メモリイベントバスから配信を受けるブローカーを作成
+func newTestEventBroker(bufferSize int) (*EventBroker, *MemoryEventBus) {
+	bus := NewMemoryEventBus()
+	broker := NewEventBroker(bufferSize)
+	bus.Subscribe(broker.Dispatch)
+	return broker, bus
+}
+
+// TestEventBrokerDispatch 宛先ごとの配信のテスト
+func TestEventBrokerDispatch(t *testing.T) {
+	broker, bus := newTestEventBroker(10)
+	alice, _ := broker.Subscribe(1, "")
+	bob, _ := broker.Subscribe(2, "")
+	defer broker.Unsubscribe(alice)
+	defer broker.Unsubscribe(bob)
+
+	// ルーム内のイベントは SSE では配信せず、採番もしない
+	bus.PublishToRoom("inbox", models.EventTypingStarted, map[string]int{"id": 1})
+	bus.Publish(models.EventNotificationCreated, 1, map[string]int{"id": 10})
+	bus.Publish(models.EventMessageCreated, 0, map[string]string{"name": "World"})
+
+	if event := receiveEvent(t, alice); event.ID != 1 || event.Type != models.EventNotificationCreated || string(event.Data) != `{"id":10}` {
+		t.Errorf("alice の1件目が不正: %+v", event)
+	}
+	if event := receiveEvent(t, alice); event.ID != 2 || event.Type != models.EventMessageCreated {
+		t.Errorf("alice の2件目が不正: %+v", event)
+	}
+	// 他のユーザー宛てのイベントは届かない
+	if event := receiveEvent(t, bob); event.ID != 2 {
+		t.Errorf("bob に届いたイベントが不正: %+v", event)
+	}
+}
+
+// TestEventBrokerReplay Last-Event-ID による再送のテスト
+func TestEventBrokerReplay(t *testing.T) {
+	broker, bus := newTestEventBroker(3)
+	for i := 0; i < 4; i++ {
+		bus.Publish(models.EventMessageCreated, 0, i)
+	}
+	bus.Publish(models.EventNotificationCreated, 2, "for bob")
+	eventID := func(id uint64) string { return broker.EventID(models.Event{ID: id}) }
+
+	// 保持している範囲（3〜5）は再送する。他のユーザー宛ては除く
+	subscription, replay := broker.Subscribe(1, eventID(2))
+	broker.Unsubscribe(subscription)
+	if len(replay) != 2 || replay[0].ID != 3 || replay[1].ID != 4 {
+		t.Errorf("再送イベントが不正: %+v", replay)
+	}
+
+	// 破棄済みのイベントがある、未発行、別のプロセスが採番した、または不正なIDの場合は stream.reset
+	for _, lastEventID := range []string{eventID(1), eventID(6), "other-3", "3"} {
+		subscription, replay := broker.Subscribe(1, lastEventID)
+		broker.Unsubscribe(subscription)
+		if len(replay) != 1 || replay[0].Type != models.EventStreamReset || replay[0].ID != 5 {
+			t.Errorf("Last-Event-ID %s: stream.reset が返されない: %+v", lastEventID, replay)
+		}
+	}
+
+	// 最新のイベントIDの場合は再送なし
+	subscription, replay = broker.Subscribe(1, eventID(5))
+	broker.Unsubscribe(subscription)
+	if len(replay) != 0 {
+		t.Errorf("再送イベントがある: %+v", replay)
+	}
+}
+
+// TestEventBrokerSlowSubscriber 受信の遅い購読者の切断とシャットダウンのテスト
+func TestEventBrokerSlowSubscriber(t *testing.T) {
+	broker, bus := newTestEventBroker(10)
+	slow, _ := broker.Subscribe(1, "")
+	active, _ := broker.Subscribe(2, "")
+
+	for i := 0; i <= eventSubscriptionBuffer; i++ {
+		bus.Publish(models.EventMessageCreated, 0, i)
+		receiveEvent(t, active)
+	}
+	received := 0
+	for range slow.Events() {
+		received++
+	}
+	if received != eventSubscriptionBuffer {
+		t.Errorf("切断までに受信した件数 = %d, want %d", received, eventSubscriptionBuffer)
+	}
+
+	broker.Close()
+	if _, ok := <-active.Events(); ok {
+		t.Error("Close 後も購読が継続している")
+	}
+	subscription, _ := broker.Subscribe(1, "")
+	if _, ok := <-subscription.Events(); ok {
+		t.Error("Close 後の購読が終了していない")
+	}
+	broker.Unsubscribe(slow)
+}
+
+// TestHelloWorldServiceEvents メッセージの変更イベントの発行テスト
+func TestHelloWorldServiceEvents(t *testing.T) {
+	broker, bus := newTestEventBroker(10)
+	subscription, _ := broker.Subscribe(1, "")
+	defer broker.Unsubscribe(subscription)
+	service := NewHelloWorldServiceWithEvents(NewMemoryHelloWorldRepository(), bus)
+
+	message, err := service.CreateHelloWorld(&models.HelloWorldRequest{Name: "World"})
+	if err != nil {
+		t.Fatalf("CreateHelloWorld() error = %v", err)
+	}
+	if _, err := service.CreateHelloWorld(&models.HelloWorldRequest{}); err == nil {
+		t.Fatal("Expected validation error")
+	}
+	if err := service.DeleteHelloWorldMessage(message.ID); err != nil {
+		t.Fatalf("DeleteHelloWorldMessage() error = %v", err)
+	}
+
+	if event := receiveEvent(t, subscription); event.Type != models.EventMessageCreated {
+		t.Errorf("1件目 = %s, want message.created", event.Type)
+	}
+	if event := receiveEvent(t, subscription); event.Type != models.EventMessageDeleted || string(event.Data) != `{"id":1}` {
+		t.Errorf("2件目が不正: %+v", event)
+	}
+}
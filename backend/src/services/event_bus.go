@@ -0,0 +1,70 @@
+package services
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"backend/models"
+	"backend/utils"
+)
+
+// EventPublisher イベントの発行インターフェース
+//
+// メッセージの作成や通知の作成など、購読者にリアルタイムで配信したい操作を行うサービスから呼び出します。
+type EventPublisher interface {
+	// Publish イベントを発行する（userID が 0 の場合は全ユーザー宛て）
+	Publish(eventType models.EventType, userID int, data interface{})
+}
+
+// EventBus 発行されたイベントを全てのレプリカの購読者に届けるバス
+//
+// イベントの発行はドメインの操作の付随処理のため、失敗しても呼び出し元には返さずログに記録します。
+type EventBus interface {
+	EventPublisher
+	// PublishToRoom ルーム内のイベントを発行する（WebSocket のルームの参加者にのみ配信）
+	PublishToRoom(room string, eventType models.EventType, data interface{})
+	// Subscribe バスに流れるイベントを受け取るハンドラーを登録する（ID は未採番）
+	Subscribe(handler func(models.Event))
+	// Close バスを停止する
+	Close() error
+}
+
+// NewEventBus STORAGE_DRIVER に応じたイベントバスを生成
+// postgres の場合は connInfo の接続で LISTEN し、db から NOTIFY します
+func NewEventBus(driver string, db *sql.DB, connInfo string) (EventBus, error) {
+	switch driver {
+	case utils.StorageDriverPostgres:
+		return NewPostgresEventBus(db, connInfo), nil
+	case utils.StorageDriverMemory:
+		return NewMemoryEventBus(), nil
+	default:
+		return nil, fmt.Errorf("unknown storage driver: %q (expected %q or %q)",
+			driver, utils.StorageDriverPostgres, utils.StorageDriverMemory)
+	}
+}
+
+// newEvent 発行するイベントを作成（data はJSONにエンコード）
+func newEvent(eventType models.EventType, userID int, data interface{}) (models.Event, error) {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return models.Event{}, fmt.Errorf("failed to encode %s event: %w", eventType, err)
+	}
+	return models.Event{Type: eventType, UserID: userID, Data: payload, Time: time.Now()}, nil
+}
+
+// newRoomEvent ルーム内のイベントを作成
+func newRoomEvent(room string, eventType models.EventType, data interface{}) (models.Event, error) {
+	event, err := newEvent(eventType, 0, data)
+	event.Room = room
+	return event, err
+}
+
+// publishEvent イベントを発行（publisher が nil の場合は何もしない）
+func publishEvent(publisher EventPublisher, eventType models.EventType, userID int, data interface{}) {
+	if publisher == nil {
+		return
+	}
+	publisher.Publish(eventType, userID, data)
+}
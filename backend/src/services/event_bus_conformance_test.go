@@ -0,0 +1,196 @@
+package services
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"backend/models"
+	"backend/utils"
+)
+
+// TestMemoryEventBusConformance メモリイベントバスの適合テスト
+func TestMemoryEventBusConformance(t *testing.T) {
+	runEventBusConformance(t, func(t *testing.T) EventBus {
+		return NewMemoryEventBus()
+	})
+}
+
+// TestPostgresEventBusConformance PostgreSQLイベントバスの適合テスト
+func TestPostgresEventBusConformance(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	runEventBusConformance(t, func(t *testing.T) EventBus {
+		bus := NewPostgresEventBus(db, testDSN)
+		t.Cleanup(func() { bus.Close() })
+		waitForListener(t, bus)
+		return bus
+	})
+}
+
+// TestPostgresEventBusFanOut 別のレプリカで発行したイベントが届くことのテスト
+func TestPostgresEventBusFanOut(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	publisher := NewPostgresEventBus(db, testDSN)
+	defer publisher.Close()
+	replica := NewPostgresEventBus(db, testDSN)
+	defer replica.Close()
+	received := collectBusEvents(replica)
+	waitForListener(t, publisher)
+	waitForListener(t, replica)
+
+	publisher.Publish(models.EventMessageCreated, 0, map[string]string{"name": "Replica"})
+	if event := nextBusEvent(t, received, models.EventMessageCreated); string(event.Data) != `{"name":"Replica"}` {
+		t.Errorf("別レプリカに届いたイベントが不正: %+v", event)
+	}
+}
+
+// runEventBusConformance 全てのEventBus実装が満たすべき振る舞い
+func runEventBusConformance(t *testing.T, newBus func(t *testing.T) EventBus) {
+	t.Run("PublishAndSubscribe", func(t *testing.T) {
+		bus := newBus(t)
+		received := collectBusEvents(bus)
+
+		bus.Publish(models.EventNotificationCreated, 7, map[string]int{"id": 1})
+		event := nextBusEvent(t, received, models.EventNotificationCreated)
+		if event.UserID != 7 || string(event.Data) != `{"id":1}` || event.Time.IsZero() || event.ID != 0 {
+			t.Errorf("受信したイベントが不正: %+v", event)
+		}
+
+		bus.Publish(models.EventMessageDeleted, 0, models.EventResourceID{ID: 3})
+		if event := nextBusEvent(t, received, models.EventMessageDeleted); event.UserID != 0 || string(event.Data) != `{"id":3}` {
+			t.Errorf("全ユーザー宛てのイベントが不正: %+v", event)
+		}
+	})
+
+	t.Run("PublishToRoom", func(t *testing.T) {
+		bus := newBus(t)
+		received := collectBusEvents(bus)
+
+		bus.PublishToRoom("customer:42", models.EventPresenceJoined, map[string]int{"id": 1})
+		event := nextBusEvent(t, received, models.EventPresenceJoined)
+		if event.Room != "customer:42" || event.UserID != 0 || string(event.Data) != `{"id":1}` {
+			t.Errorf("ルーム内のイベントが不正: %+v", event)
+		}
+	})
+
+	t.Run("UnencodableData", func(t *testing.T) {
+		bus := newBus(t)
+		received := collectBusEvents(bus)
+
+		bus.Publish(models.EventMessageCreated, 0, func() {})
+		bus.Publish(models.EventMessageUpdated, 0, "ok")
+		if event := nextBusEvent(t, received, models.EventMessageCreated, models.EventMessageUpdated); event.Type != models.EventMessageUpdated {
+			t.Errorf("エンコードできないイベントが配信された: %+v", event)
+		}
+	})
+}
+
+// collectBusEvents バスに流れるイベントを受け取るチャネルを登録
+func collectBusEvents(bus EventBus) <-chan models.Event {
+	received := make(chan models.Event, 16)
+	bus.Subscribe(func(event models.Event) { received <- event })
+	return received
+}
+
+// nextBusEvent 指定した種類のイベントを1件受信（stream.reset などそれ以外の種類は読み飛ばす）
+func nextBusEvent(t *testing.T, received <-chan models.Event, types ...models.EventType) models.Event {
+	t.Helper()
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case event := <-received:
+			for _, eventType := range types {
+				if event.Type == eventType {
+					return event
+				}
+			}
+		case <-timeout:
+			t.Fatalf("%v イベントが届かない", types)
+			return models.Event{}
+		}
+	}
+}
+
+// waitForListener LISTEN の開始を待つ（開始前の NOTIFY は届かないため）
+func waitForListener(t *testing.T, bus *PostgresEventBus) {
+	t.Helper()
+	deadline := time.Now().Add(10 * time.Second)
+	for bus.listener.Ping() != nil {
+		if time.Now().After(deadline) {
+			t.Fatal("LISTEN の接続待ちタイムアウト")
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+// TestPostgresEventPayload NOTIFY ペイロードのエンコード・デコードのテスト
+func TestPostgresEventPayload(t *testing.T) {
+	event, err := newEvent(models.EventNotificationCreated, 3, map[string]string{"body": "hello"})
+	if err != nil {
+		t.Fatalf("newEvent() error = %v", err)
+	}
+	payload, err := encodePostgresEvent(event)
+	if err != nil {
+		t.Fatalf("encodePostgresEvent() error = %v", err)
+	}
+	decoded, err := decodePostgresEvent(string(payload))
+	if err != nil {
+		t.Fatalf("decodePostgresEvent() error = %v", err)
+	}
+	if decoded.Type != event.Type || decoded.UserID != 3 || string(decoded.Data) != `{"body":"hello"}` || !decoded.Time.Equal(event.Time) {
+		t.Errorf("復元したイベントが不正: %+v", decoded)
+	}
+
+	// 上限を超えるイベントは宛先を保ったまま stream.reset に置き換える
+	large, _ := newEvent(models.EventMessageCreated, 3, strings.Repeat("x", utils.MaxEventPayloadSize))
+	payload, err = encodePostgresEvent(large)
+	if err != nil || len(payload) > utils.MaxEventPayloadSize {
+		t.Fatalf("encodePostgresEvent(large) = %d bytes, %v", len(payload), err)
+	}
+	decoded, _ = decodePostgresEvent(string(payload))
+	if decoded.Type != models.EventStreamReset || decoded.UserID != 3 || !json.Valid(decoded.Data) {
+		t.Errorf("上限超過時のイベントが不正: %+v", decoded)
+	}
+
+	roomEvent, _ := newRoomEvent("customer:1", models.EventTypingStarted, map[string]int{"id": 1})
+	payload, _ = encodePostgresEvent(roomEvent)
+	if decoded, _ = decodePostgresEvent(string(payload)); decoded.Room != "customer:1" || decoded.Type != models.EventTypingStarted {
+		t.Errorf("ルーム内のイベントの復元が不正: %+v", decoded)
+	}
+	largeRoomEvent, _ := newRoomEvent("customer:1", models.EventTypingStarted, strings.Repeat("x", utils.MaxEventPayloadSize))
+	if _, err := encodePostgresEvent(largeRoomEvent); err == nil {
+		t.Error("上限を超えるルーム内のイベントはエラーになるべき")
+	}
+
+	for _, malformed := range []string{"not json", `{"data":{}}`} {
+		if _, err := decodePostgresEvent(malformed); err == nil {
+			t.Errorf("decodePostgresEvent(%q) expected error", malformed)
+		}
+	}
+}
+
+// TestNewEventBus ストレージドライバーに応じたイベントバスの選択テスト
+func TestNewEventBus(t *testing.T) {
+	bus, err := NewEventBus(utils.StorageDriverMemory, nil, "")
+	if _, ok := bus.(*MemoryEventBus); err != nil || !ok {
+		t.Errorf("memory: got %T, %v", bus, err)
+	}
+
+	// 接続できない場合もバックグラウンドで再試行し、Close で停止できる
+	bus, err = NewEventBus(utils.StorageDriverPostgres, nil, "host=127.0.0.1 port=1 sslmode=disable connect_timeout=1")
+	if _, ok := bus.(*PostgresEventBus); err != nil || !ok {
+		t.Fatalf("postgres: got %T, %v", bus, err)
+	}
+	if err := bus.Close(); err != nil {
+		t.Errorf("Close() error = %v", err)
+	}
+
+	if _, err := NewEventBus("mysql", nil, ""); err == nil {
+		t.Error("Expected error for unknown driver")
+	}
+}
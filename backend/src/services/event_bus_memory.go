@@ -0,0 +1,60 @@
+package services
+
+import (
+	"log"
+	"sync"
+
+	"backend/models"
+)
+
+// MemoryEventBus 同一プロセス内でイベントを配信するバス（単一インスタンス・メモリストレージ用）
+type MemoryEventBus struct {
+	mu       sync.RWMutex
+	handlers []func(models.Event)
+}
+
+// NewMemoryEventBus メモリイベントバスを新規作成
+func NewMemoryEventBus() *MemoryEventBus {
+	return &MemoryEventBus{}
+}
+
+// Publish 登録済みのハンドラーにイベントを同期的に配信
+func (b *MemoryEventBus) Publish(eventType models.EventType, userID int, data interface{}) {
+	event, err := newEvent(eventType, userID, data)
+	if err != nil {
+		log.Printf("⚠️  Failed to publish event: %v", err)
+		return
+	}
+	b.dispatch(event)
+}
+
+// PublishToRoom 登録済みのハンドラーにルーム内のイベントを同期的に配信
+func (b *MemoryEventBus) PublishToRoom(room string, eventType models.EventType, data interface{}) {
+	event, err := newRoomEvent(room, eventType, data)
+	if err != nil {
+		log.Printf("⚠️  Failed to publish event: %v", err)
+		return
+	}
+	b.dispatch(event)
+}
+
+// dispatch 登録済みのハンドラーにイベントを配信
+func (b *MemoryEventBus) dispatch(event models.Event) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for _, handler := range b.handlers {
+		handler(event)
+	}
+}
+
+// Subscribe イベントを受け取るハンドラーを登録
+func (b *MemoryEventBus) Subscribe(handler func(models.Event)) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers = append(b.handlers, handler)
+}
+
+// Close 何もしない（EventBus インターフェースを満たすため）
+func (b *MemoryEventBus) Close() error {
+	return nil
+}
@@ -0,0 +1,209 @@
+package services
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/lib/pq"
+
+	"backend/models"
+	"backend/utils"
+)
+
+const (
+	eventListenerMinReconnect = time.Second
+	eventListenerMaxReconnect = time.Minute
+	eventListenerPingInterval = 90 * time.Second // 無通信の接続断を検出する間隔
+)
+
+// PostgresEventBus PostgreSQL の LISTEN/NOTIFY でレプリカ間にイベントを配信するバス
+//
+// 発行したイベントは自身を含む全てのレプリカが LISTEN で受け取ってから配信するため、
+// どのレプリカでも同じ順序になります。LISTEN の接続が切れた場合は自動で再接続・再購読し、
+// 切断中のイベントは失われるため、再接続時に全ユーザー宛ての stream.reset を配信します。
+type PostgresEventBus struct {
+	db       *sql.DB
+	listener *pq.Listener
+
+	mu       sync.RWMutex
+	handlers []func(models.Event)
+
+	done      chan struct{}
+	stopped   chan struct{}
+	closeOnce sync.Once
+}
+
+// postgresEventPayload NOTIFY で送信するイベント
+type postgresEventPayload struct {
+	Type   models.EventType `json:"type"`
+	UserID int              `json:"user_id,omitempty"`
+	Room   string           `json:"room,omitempty"`
+	Data   json.RawMessage  `json:"data"`
+	Time   time.Time        `json:"time"`
+}
+
+// NewPostgresEventBus PostgreSQLイベントバスを新規作成
+// connInfo の接続を LISTEN 専用に確立し（接続できるまでバックグラウンドで再試行）、db から NOTIFY します
+func NewPostgresEventBus(db *sql.DB, connInfo string) *PostgresEventBus {
+	bus := &PostgresEventBus{
+		db:      db,
+		done:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+	bus.listener = pq.NewListener(connInfo, eventListenerMinReconnect, eventListenerMaxReconnect, logListenerEvent)
+	go bus.run()
+	return bus
+}
+
+// Publish イベントを NOTIFY で全てのレプリカに送信
+func (b *PostgresEventBus) Publish(eventType models.EventType, userID int, data interface{}) {
+	event, err := newEvent(eventType, userID, data)
+	if err != nil {
+		log.Printf("⚠️  Failed to publish event: %v", err)
+		return
+	}
+	b.notify(event)
+}
+
+// PublishToRoom ルーム内のイベントを NOTIFY で全てのレプリカに送信
+func (b *PostgresEventBus) PublishToRoom(room string, eventType models.EventType, data interface{}) {
+	event, err := newRoomEvent(room, eventType, data)
+	if err != nil {
+		log.Printf("⚠️  Failed to publish event: %v", err)
+		return
+	}
+	b.notify(event)
+}
+
+// notify イベントを NOTIFY で送信
+func (b *PostgresEventBus) notify(event models.Event) {
+	payload, err := encodePostgresEvent(event)
+	if err != nil {
+		log.Printf("⚠️  Failed to publish event: %v", err)
+		return
+	}
+
+	if _, err := b.db.Exec("SELECT pg_notify($1, $2)", utils.EventBusChannel, string(payload)); err != nil {
+		log.Printf("⚠️  Failed to publish %s event: %v", event.Type, err)
+	}
+}
+
+// Subscribe イベントを受け取るハンドラーを登録
+func (b *PostgresEventBus) Subscribe(handler func(models.Event)) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers = append(b.handlers, handler)
+}
+
+// Close LISTEN の接続を閉じて受信を停止
+func (b *PostgresEventBus) Close() error {
+	var err error
+	b.closeOnce.Do(func() {
+		close(b.done)
+		err = b.listener.Close()
+		<-b.stopped
+	})
+	return err
+}
+
+// run LISTEN で受信したイベントをハンドラーに配信
+func (b *PostgresEventBus) run() {
+	defer close(b.stopped)
+
+	// 接続できるまでブロックし、以降の再接続時は pq.Listener が自動で再購読する
+	if err := b.listener.Listen(utils.EventBusChannel); err != nil {
+		select {
+		case <-b.done:
+		default:
+			log.Printf("❌ Failed to listen for events: %v", err)
+		}
+		return
+	}
+
+	ping := time.NewTicker(eventListenerPingInterval)
+	defer ping.Stop()
+	for {
+		select {
+		case <-b.done:
+			return
+		case notification, ok := <-b.listener.Notify:
+			if !ok {
+				return
+			}
+			if notification == nil {
+				// 再接続した（切断中のイベントは届かないため、クライアントに再取得を促す）
+				b.dispatch(models.Event{Type: models.EventStreamReset, Data: json.RawMessage("{}"), Time: time.Now()})
+				continue
+			}
+			event, err := decodePostgresEvent(notification.Extra)
+			if err != nil {
+				log.Printf("⚠️  Ignoring malformed event notification: %v", err)
+				continue
+			}
+			b.dispatch(event)
+		case <-ping.C:
+			go b.listener.Ping()
+		}
+	}
+}
+
+// dispatch 登録済みのハンドラーにイベントを配信
+func (b *PostgresEventBus) dispatch(event models.Event) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for _, handler := range b.handlers {
+		handler(event)
+	}
+}
+
+// encodePostgresEvent イベントを NOTIFY のペイロードにエンコード
+// 上限を超える場合はデータを省略した stream.reset に置き換えます（宛先のクライアントは状態を再取得します）。
+// ルーム内のイベントの場合はエラーを返します
+func encodePostgresEvent(event models.Event) ([]byte, error) {
+	payload, err := json.Marshal(postgresEventPayload{Type: event.Type, UserID: event.UserID, Room: event.Room, Data: event.Data, Time: event.Time})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s event: %w", event.Type, err)
+	}
+	if len(payload) <= utils.MaxEventPayloadSize {
+		return payload, nil
+	}
+
+	if event.Room != "" {
+		// ルーム内のイベントは一時的な状態のため、再取得を促さずに破棄する
+		return nil, fmt.Errorf("%s event for room %q exceeds the NOTIFY payload limit (%d bytes)", event.Type, event.Room, len(payload))
+	}
+
+	log.Printf("⚠️  %s event exceeds the NOTIFY payload limit (%d bytes); sending stream.reset instead", event.Type, len(payload))
+	return json.Marshal(postgresEventPayload{Type: models.EventStreamReset, UserID: event.UserID, Data: json.RawMessage("{}"), Time: event.Time})
+}
+
+// decodePostgresEvent NOTIFY のペイロードからイベントを復元
+func decodePostgresEvent(payload string) (models.Event, error) {
+	var decoded postgresEventPayload
+	if err := json.Unmarshal([]byte(payload), &decoded); err != nil {
+		return models.Event{}, err
+	}
+	if decoded.Type == "" {
+		return models.Event{}, fmt.Errorf("event type is missing")
+	}
+	if len(decoded.Data) == 0 {
+		decoded.Data = json.RawMessage("{}")
+	}
+	return models.Event{Type: decoded.Type, UserID: decoded.UserID, Room: decoded.Room, Data: decoded.Data, Time: decoded.Time}, nil
+}
+
+// logListenerEvent LISTEN 接続の状態変化をログに記録
+func logListenerEvent(event pq.ListenerEventType, err error) {
+	switch event {
+	case pq.ListenerEventDisconnected:
+		log.Printf("⚠️  Event listener disconnected: %v", err)
+	case pq.ListenerEventReconnected:
+		log.Println("✅ Event listener reconnected")
+	case pq.ListenerEventConnectionAttemptFailed:
+		log.Printf("⚠️  Event listener connection attempt failed: %v", err)
+	}
+}
@@ -0,0 +1,31 @@
+package services
+
+import "backend/models"
+
+var (
+	// ErrHelloWorldMessageNotFound 指定されたHello Worldメッセージが存在しない
+	ErrHelloWorldMessageNotFound = newError(ErrNotFound, "hello world message not found", "hello_world.not_found")
+
+	// ErrDatabaseUnavailable データベース接続が利用できない
+	ErrDatabaseUnavailable = newError(ErrUnavailable, "database connection is not available", "database.unavailable")
+)
+
+// HelloWorldRepository Hello Worldメッセージの永続化インターフェース
+//
+// 実装は PostgreSQL 版（NewPostgresHelloWorldRepository）とメモリ版
+// （NewMemoryHelloWorldRepository）があり、どちらも同じ適合テストを満たします。
+// 該当するメッセージが存在しない場合は ErrHelloWorldMessageNotFound を返します。
+type HelloWorldRepository interface {
+	// Create メッセージを保存し、採番されたIDとタイムスタンプを含めて返す
+	Create(name, message string) (*models.HelloWorldMessage, error)
+	// FindAll 全メッセージを作成日時の降順で返す
+	FindAll() ([]models.HelloWorldMessage, error)
+	// List 検索条件に一致するメッセージをページ単位で返す
+	List(spec *QuerySpec) (*Page[models.HelloWorldMessage], error)
+	// FindByID IDでメッセージを返す
+	FindByID(id int) (*models.HelloWorldMessage, error)
+	// Update name と message を置き換え、updated_at を更新する
+	Update(id int, name, message string) (*models.HelloWorldMessage, error)
+	// Delete メッセージを削除する
+	Delete(id int) error
+}
@@ -0,0 +1,273 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestMemoryHelloWorldRepositoryConformance メモリリポジトリの適合テスト
+func TestMemoryHelloWorldRepositoryConformance(t *testing.T) {
+	runHelloWorldRepositoryConformance(t, func(t *testing.T) HelloWorldRepository {
+		return NewMemoryHelloWorldRepository()
+	})
+}
+
+// TestPostgresHelloWorldRepositoryConformance PostgreSQLリポジトリの適合テスト
+func TestPostgresHelloWorldRepositoryConformance(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	runHelloWorldRepositoryConformance(t, func(t *testing.T) HelloWorldRepository {
+		return NewPostgresHelloWorldRepository(db)
+	})
+}
+
+// runHelloWorldRepositoryConformance 全てのHelloWorldRepository実装が満たすべき振る舞い
+// 共有DBでも動作するよう、一覧系の検証は一意なトークンで対象を絞り込みます
+func runHelloWorldRepositoryConformance(t *testing.T, newRepo func(t *testing.T) HelloWorldRepository) {
+	unique := func(prefix string) string {
+		return fmt.Sprintf("%s%d", prefix, time.Now().UnixNano())
+	}
+
+	mustParse := func(t *testing.T, values url.Values) *QuerySpec {
+		spec, err := HelloWorldMessageQuerySchema.Parse(values)
+		if err != nil {
+			t.Fatalf("Parse失敗: %v", err)
+		}
+		return spec
+	}
+
+	t.Run("CreateAndFindByID", func(t *testing.T) {
+		repo := newRepo(t)
+
+		created, err := repo.Create("Alice", "Hello, Alice!")
+		if err != nil {
+			t.Fatalf("Create失敗: %v", err)
+		}
+		defer repo.Delete(created.ID)
+
+		if created.ID <= 0 || created.CreatedAt.IsZero() || created.UpdatedAt.IsZero() {
+			t.Errorf("採番・タイムスタンプが不正: %+v", created)
+		}
+
+		found, err := repo.FindByID(created.ID)
+		if err != nil {
+			t.Fatalf("FindByID失敗: %v", err)
+		}
+		if found.Name != "Alice" || found.Message != "Hello, Alice!" {
+			t.Errorf("取得内容不一致: %+v", found)
+		}
+	})
+
+	t.Run("FindByIDNotFound", func(t *testing.T) {
+		repo := newRepo(t)
+
+		if _, err := repo.FindByID(2147483000); !errors.Is(err, ErrHelloWorldMessageNotFound) {
+			t.Errorf("ErrHelloWorldMessageNotFoundが返らない: %v", err)
+		}
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		repo := newRepo(t)
+
+		created, err := repo.Create("Before", "before")
+		if err != nil {
+			t.Fatalf("Create失敗: %v", err)
+		}
+		defer repo.Delete(created.ID)
+
+		updated, err := repo.Update(created.ID, "After", "after")
+		if err != nil {
+			t.Fatalf("Update失敗: %v", err)
+		}
+		if updated.Name != "After" || updated.Message != "after" {
+			t.Errorf("更新内容不一致: %+v", updated)
+		}
+		if !updated.CreatedAt.Equal(created.CreatedAt) {
+			t.Error("created_atが変更されている")
+		}
+		if updated.UpdatedAt.Before(created.UpdatedAt) {
+			t.Error("updated_atが更新されていない")
+		}
+
+		if _, err := repo.Update(2147483000, "x", "y"); !errors.Is(err, ErrHelloWorldMessageNotFound) {
+			t.Errorf("存在しないIDの更新でErrHelloWorldMessageNotFoundが返らない: %v", err)
+		}
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		repo := newRepo(t)
+
+		created, err := repo.Create("ToDelete", "bye")
+		if err != nil {
+			t.Fatalf("Create失敗: %v", err)
+		}
+
+		if err := repo.Delete(created.ID); err != nil {
+			t.Fatalf("Delete失敗: %v", err)
+		}
+		if _, err := repo.FindByID(created.ID); !errors.Is(err, ErrHelloWorldMessageNotFound) {
+			t.Errorf("削除後にFindByIDでErrHelloWorldMessageNotFoundが返らない: %v", err)
+		}
+		if err := repo.Delete(created.ID); !errors.Is(err, ErrHelloWorldMessageNotFound) {
+			t.Errorf("二重削除でErrHelloWorldMessageNotFoundが返らない: %v", err)
+		}
+	})
+
+	t.Run("FindAll", func(t *testing.T) {
+		repo := newRepo(t)
+
+		created, err := repo.Create(unique("FindAll"), "all")
+		if err != nil {
+			t.Fatalf("Create失敗: %v", err)
+		}
+		defer repo.Delete(created.ID)
+
+		messages, err := repo.FindAll()
+		if err != nil {
+			t.Fatalf("FindAll失敗: %v", err)
+		}
+		found := false
+		for _, m := range messages {
+			found = found || m.ID == created.ID
+		}
+		if !found {
+			t.Error("作成したメッセージがFindAllに含まれない")
+		}
+	})
+
+	t.Run("ListPagination", func(t *testing.T) {
+		repo := newRepo(t)
+		name := unique("Page")
+
+		var ids []int
+		for i := 0; i < 3; i++ {
+			created, err := repo.Create(name, fmt.Sprintf("page %d", i))
+			if err != nil {
+				t.Fatalf("Create失敗: %v", err)
+			}
+			ids = append(ids, created.ID)
+		}
+		defer func() {
+			for _, id := range ids {
+				repo.Delete(id)
+			}
+		}()
+
+		first, err := repo.List(mustParse(t, url.Values{"name": {name}, "limit": {"2"}}))
+		if err != nil {
+			t.Fatalf("List失敗: %v", err)
+		}
+		if first.Total != 3 || len(first.Items) != 2 || !first.HasMore || first.NextCursor == nil {
+			t.Fatalf("1ページ目が不正: total=%d items=%d has_more=%v", first.Total, len(first.Items), first.HasMore)
+		}
+		// 作成日時の降順（同時刻はid降順）
+		if first.Items[0].ID != ids[2] || first.Items[1].ID != ids[1] {
+			t.Errorf("並び順が不正: %d, %d", first.Items[0].ID, first.Items[1].ID)
+		}
+
+		second, err := repo.List(mustParse(t, url.Values{"name": {name}, "limit": {"2"}, "after": {first.NextCursor.Encode()}}))
+		if err != nil {
+			t.Fatalf("List失敗: %v", err)
+		}
+		if len(second.Items) != 1 || second.HasMore || second.Items[0].ID != ids[0] {
+			t.Errorf("カーソルによる2ページ目が不正: %+v", second)
+		}
+
+		offset, err := repo.List(mustParse(t, url.Values{"name": {name}, "limit": {"2"}, "offset": {"2"}}))
+		if err != nil {
+			t.Fatalf("List失敗: %v", err)
+		}
+		if len(offset.Items) != 1 || offset.HasMore || offset.Items[0].ID != ids[0] {
+			t.Errorf("オフセットによる2ページ目が不正: %+v", offset)
+		}
+	})
+
+	t.Run("ListSortAndSearch", func(t *testing.T) {
+		repo := newRepo(t)
+		token := unique("token")
+
+		var ids []int
+		for _, name := range []string{"Charlie", "Alice", "Bob"} {
+			created, err := repo.Create(name, "Hello "+token)
+			if err != nil {
+				t.Fatalf("Create失敗: %v", err)
+			}
+			ids = append(ids, created.ID)
+		}
+		defer func() {
+			for _, id := range ids {
+				repo.Delete(id)
+			}
+		}()
+
+		result, err := repo.List(mustParse(t, url.Values{"q": {token}, "sort": {"name"}}))
+		if err != nil {
+			t.Fatalf("List失敗: %v", err)
+		}
+		if result.Total != 3 || len(result.Items) != 3 {
+			t.Fatalf("検索結果の件数が不正: total=%d items=%d", result.Total, len(result.Items))
+		}
+		for i, want := range []string{"Alice", "Bob", "Charlie"} {
+			if result.Items[i].Name != want {
+				t.Errorf("並び順が不正: index %d expected %s, got %s", i, want, result.Items[i].Name)
+			}
+		}
+		if result.NextCursor != nil {
+			t.Error("デフォルト以外の並び順でカーソルが発行されている")
+		}
+
+		excluded, err := repo.List(mustParse(t, url.Values{"q": {token + " -alice"}}))
+		if err != nil {
+			t.Fatalf("List失敗: %v", err)
+		}
+		if excluded.Total != 2 {
+			t.Errorf("除外検索の件数が不正: expected 2, got %d", excluded.Total)
+		}
+
+		none, err := repo.List(mustParse(t, url.Values{"q": {token}, "created_after": {"2999-01-01"}}))
+		if err != nil {
+			t.Fatalf("List失敗: %v", err)
+		}
+		if none.Total != 0 || len(none.Items) != 0 {
+			t.Errorf("日付フィルターが効いていない: total=%d", none.Total)
+		}
+	})
+
+	t.Run("ConcurrentCreate", func(t *testing.T) {
+		repo := newRepo(t)
+		name := unique("Concurrent")
+
+		var wg sync.WaitGroup
+		var mu sync.Mutex
+		ids := map[int]bool{}
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				created, err := repo.Create(name, "concurrent")
+				if err != nil {
+					t.Errorf("Create失敗: %v", err)
+					return
+				}
+				mu.Lock()
+				ids[created.ID] = true
+				mu.Unlock()
+			}()
+		}
+		wg.Wait()
+		defer func() {
+			for id := range ids {
+				repo.Delete(id)
+			}
+		}()
+
+		if len(ids) != 20 {
+			t.Errorf("IDが重複している: %d件", len(ids))
+		}
+	})
+}
@@ -0,0 +1,137 @@
+package services
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"backend/models"
+)
+
+// MemoryHelloWorldRepository メモリ上で動作するHello Worldリポジトリ
+// PostgreSQL なしでのローカルデモやフロントエンド開発向けで、複数goroutineから安全に利用できます
+type MemoryHelloWorldRepository struct {
+	mu       sync.RWMutex
+	messages map[int]models.HelloWorldMessage
+	nextID   int
+}
+
+// NewMemoryHelloWorldRepository メモリリポジトリを新規作成
+func NewMemoryHelloWorldRepository() *MemoryHelloWorldRepository {
+	return &MemoryHelloWorldRepository{
+		messages: make(map[int]models.HelloWorldMessage),
+		nextID:   1,
+	}
+}
+
+// Create メッセージを保存
+func (r *MemoryHelloWorldRepository) Create(name, message string) (*models.HelloWorldMessage, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	msg := models.HelloWorldMessage{
+		ID:        r.nextID,
+		Name:      name,
+		Message:   message,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	r.messages[msg.ID] = msg
+	r.nextID++
+
+	return &msg, nil
+}
+
+// FindAll 全メッセージを作成日時の降順で取得
+func (r *MemoryHelloWorldRepository) FindAll() ([]models.HelloWorldMessage, error) {
+	r.mu.RLock()
+	messages := r.snapshot()
+	r.mu.RUnlock()
+
+	sort.Slice(messages, func(i, j int) bool {
+		if !messages[i].CreatedAt.Equal(messages[j].CreatedAt) {
+			return messages[i].CreatedAt.After(messages[j].CreatedAt)
+		}
+		return messages[i].ID > messages[j].ID
+	})
+
+	return messages, nil
+}
+
+// List 検索条件に一致するメッセージをページ単位で取得
+func (r *MemoryHelloWorldRepository) List(spec *QuerySpec) (*Page[models.HelloWorldMessage], error) {
+	r.mu.RLock()
+	messages := r.snapshot()
+	r.mu.RUnlock()
+
+	return applyQuerySpec(messages, spec, helloWorldMessageFields, helloWorldMessageCursor), nil
+}
+
+// FindByID IDでメッセージを取得
+func (r *MemoryHelloWorldRepository) FindByID(id int) (*models.HelloWorldMessage, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	msg, ok := r.messages[id]
+	if !ok {
+		return nil, ErrHelloWorldMessageNotFound
+	}
+	return &msg, nil
+}
+
+// Update メッセージを全置換で更新（PostgreSQL のトリガーと同様に updated_at を更新）
+func (r *MemoryHelloWorldRepository) Update(id int, name, message string) (*models.HelloWorldMessage, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	msg, ok := r.messages[id]
+	if !ok {
+		return nil, ErrHelloWorldMessageNotFound
+	}
+
+	msg.Name = name
+	msg.Message = message
+	msg.UpdatedAt = time.Now()
+	r.messages[id] = msg
+
+	return &msg, nil
+}
+
+// Delete メッセージを削除
+func (r *MemoryHelloWorldRepository) Delete(id int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.messages[id]; !ok {
+		return ErrHelloWorldMessageNotFound
+	}
+	delete(r.messages, id)
+
+	return nil
+}
+
+// snapshot 現在のメッセージのコピーを返す（呼び出し側でロックを保持すること）
+func (r *MemoryHelloWorldRepository) snapshot() []models.HelloWorldMessage {
+	messages := make([]models.HelloWorldMessage, 0, len(r.messages))
+	for _, msg := range r.messages {
+		messages = append(messages, msg)
+	}
+	return messages
+}
+
+// helloWorldMessageFields QuerySpec評価用のフィールド値
+func helloWorldMessageFields(msg models.HelloWorldMessage) map[string]interface{} {
+	return map[string]interface{}{
+		"id":         msg.ID,
+		"name":       msg.Name,
+		"message":    msg.Message,
+		"created_at": msg.CreatedAt,
+		"updated_at": msg.UpdatedAt,
+	}
+}
+
+// helloWorldMessageCursor メッセージの位置を表すカーソル
+func helloWorldMessageCursor(msg models.HelloWorldMessage) Cursor {
+	return Cursor{CreatedAt: msg.CreatedAt, ID: msg.ID}
+}
@@ -0,0 +1,229 @@
+package services
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"backend/models"
+)
+
+// PostgresHelloWorldRepository PostgreSQLによるHello Worldリポジトリ
+type PostgresHelloWorldRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresHelloWorldRepository PostgreSQLリポジトリを新規作成
+// db が nil の場合、全ての操作は ErrDatabaseUnavailable を返します
+func NewPostgresHelloWorldRepository(db *sql.DB) *PostgresHelloWorldRepository {
+	return &PostgresHelloWorldRepository{db: db}
+}
+
+// Create メッセージを保存
+func (r *PostgresHelloWorldRepository) Create(name, message string) (*models.HelloWorldMessage, error) {
+	if r.db == nil {
+		return nil, ErrDatabaseUnavailable
+	}
+
+	query := `
+		INSERT INTO hello_world_messages (name, message, created_at, updated_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, name, message, created_at, updated_at
+	`
+
+	now := time.Now()
+
+	var result models.HelloWorldMessage
+	err := r.db.QueryRow(
+		query,
+		name,
+		message,
+		now,
+		now,
+	).Scan(
+		&result.ID,
+		&result.Name,
+		&result.Message,
+		&result.CreatedAt,
+		&result.UpdatedAt,
+	)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to create hello world message: %w", err)
+	}
+
+	return &result, nil
+}
+
+// FindAll 全メッセージを取得
+func (r *PostgresHelloWorldRepository) FindAll() ([]models.HelloWorldMessage, error) {
+	if r.db == nil {
+		return nil, ErrDatabaseUnavailable
+	}
+
+	query := `
+		SELECT id, name, message, created_at, updated_at
+		FROM hello_world_messages
+		ORDER BY created_at DESC
+	`
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query hello world messages: %w", err)
+	}
+	defer rows.Close()
+
+	return scanHelloWorldMessages(rows, 0)
+}
+
+// List 検索条件に一致するメッセージをページ単位で取得
+// キーセットページングは idx_hello_world_messages_created_at インデックスを、
+// 全文検索 (q) は search_vector の GIN インデックスを利用します
+func (r *PostgresHelloWorldRepository) List(spec *QuerySpec) (*Page[models.HelloWorldMessage], error) {
+	if r.db == nil {
+		return nil, ErrDatabaseUnavailable
+	}
+
+	var countArgs []interface{}
+	countQuery := `SELECT COUNT(*) FROM hello_world_messages` + spec.WhereClause(&countArgs, false)
+
+	var total int
+	if err := r.db.QueryRow(countQuery, countArgs...).Scan(&total); err != nil {
+		return nil, fmt.Errorf("failed to count hello world messages: %w", err)
+	}
+
+	var args []interface{}
+	query := `
+		SELECT id, name, message, created_at, updated_at
+		FROM hello_world_messages
+	` + spec.WhereClause(&args, true) + spec.OrderClause()
+
+	// 次ページの有無を判定するため1件多く取得
+	page := spec.Page
+	args = append(args, page.Limit+1, page.Offset)
+	query += fmt.Sprintf(` LIMIT $%d OFFSET $%d`, len(args)-1, len(args))
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query hello world messages: %w", err)
+	}
+	defer rows.Close()
+
+	messages, err := scanHelloWorldMessages(rows, page.Limit+1)
+	if err != nil {
+		return nil, err
+	}
+
+	return newPage(spec, messages, total, helloWorldMessageCursor), nil
+}
+
+// FindByID IDでメッセージを取得
+func (r *PostgresHelloWorldRepository) FindByID(id int) (*models.HelloWorldMessage, error) {
+	if r.db == nil {
+		return nil, ErrDatabaseUnavailable
+	}
+
+	query := `
+		SELECT id, name, message, created_at, updated_at
+		FROM hello_world_messages
+		WHERE id = $1
+	`
+
+	var msg models.HelloWorldMessage
+	err := r.db.QueryRow(query, id).Scan(
+		&msg.ID,
+		&msg.Name,
+		&msg.Message,
+		&msg.CreatedAt,
+		&msg.UpdatedAt,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrHelloWorldMessageNotFound
+		}
+		return nil, fmt.Errorf("failed to get hello world message: %w", err)
+	}
+
+	return &msg, nil
+}
+
+// Update メッセージを全置換で更新
+// updated_at は update_updated_at_column トリガーで自動更新されます
+func (r *PostgresHelloWorldRepository) Update(id int, name, message string) (*models.HelloWorldMessage, error) {
+	if r.db == nil {
+		return nil, ErrDatabaseUnavailable
+	}
+
+	query := `
+		UPDATE hello_world_messages
+		SET name = $1, message = $2
+		WHERE id = $3
+		RETURNING id, name, message, created_at, updated_at
+	`
+
+	var msg models.HelloWorldMessage
+	err := r.db.QueryRow(query, name, message, id).Scan(
+		&msg.ID,
+		&msg.Name,
+		&msg.Message,
+		&msg.CreatedAt,
+		&msg.UpdatedAt,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrHelloWorldMessageNotFound
+		}
+		return nil, fmt.Errorf("failed to update hello world message: %w", err)
+	}
+
+	return &msg, nil
+}
+
+// Delete メッセージを削除
+func (r *PostgresHelloWorldRepository) Delete(id int) error {
+	if r.db == nil {
+		return ErrDatabaseUnavailable
+	}
+
+	result, err := r.db.Exec(`DELETE FROM hello_world_messages WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete hello world message: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to delete hello world message: %w", err)
+	}
+	if affected == 0 {
+		return ErrHelloWorldMessageNotFound
+	}
+
+	return nil
+}
+
+// scanHelloWorldMessages クエリ結果をHello Worldメッセージのスライスに変換
+func scanHelloWorldMessages(rows *sql.Rows, capacity int) ([]models.HelloWorldMessage, error) {
+	messages := make([]models.HelloWorldMessage, 0, capacity)
+	for rows.Next() {
+		var msg models.HelloWorldMessage
+		err := rows.Scan(
+			&msg.ID,
+			&msg.Name,
+			&msg.Message,
+			&msg.CreatedAt,
+			&msg.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan hello world message: %w", err)
+		}
+		messages = append(messages, msg)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating hello world messages: %w", err)
+	}
+
+	return messages, nil
+}
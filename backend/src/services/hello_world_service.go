@@ -1,28 +1,61 @@
 package services
 
 import (
+	"bytes"
 	"database/sql"
-	"errors"
+	"encoding/json"
 	"fmt"
 	"time"
 
 	"backend/models"
+	"backend/utils"
 )
 
+// HelloWorldMessageQuerySchema Hello Worldメッセージ一覧の検索・ソート定義
+var HelloWorldMessageQuerySchema = &QuerySchema{
+	Filters: map[string]FilterDef{
+		"name":           {Field: "name", Op: FilterEq, Type: FieldString},
+		"created_after":  {Field: "created_at", Op: FilterGt, Type: FieldTime},
+		"created_before": {Field: "created_at", Op: FilterLt, Type: FieldTime},
+	},
+	Columns: map[string]string{
+		"id":         "id",
+		"name":       "name",
+		"message":    "message",
+		"created_at": "created_at",
+		"updated_at": "updated_at",
+	},
+	Sortable:     []string{"id", "name", "created_at", "updated_at"},
+	DefaultSort:  []SortField{{Field: "created_at", Desc: true}},
+	SearchColumn: "search_vector",
+	SearchFields: []string{"name", "message"},
+}
+
 // HelloWorldService Hello Worldサービス構造体
 type HelloWorldService struct {
-	db *sql.DB
+	repo   HelloWorldRepository
+	events EventPublisher // メッセージの作成・更新・削除イベントの発行先（nil の場合は発行しない）
 }
 
-// NewHelloWorldService Hello Worldサービスを新規作成
+// NewHelloWorldService PostgreSQLリポジトリを使用するHello Worldサービスを新規作成
 func NewHelloWorldService(db *sql.DB) *HelloWorldService {
-	return &HelloWorldService{db: db}
+	return NewHelloWorldServiceWithRepository(NewPostgresHelloWorldRepository(db))
+}
+
+// NewHelloWorldServiceWithRepository 任意のリポジトリを使用するHello Worldサービスを新規作成
+func NewHelloWorldServiceWithRepository(repo HelloWorldRepository) *HelloWorldService {
+	return &HelloWorldService{repo: repo}
+}
+
+// NewHelloWorldServiceWithEvents メッセージの変更をイベントとして発行するHello Worldサービスを新規作成
+func NewHelloWorldServiceWithEvents(repo HelloWorldRepository, events EventPublisher) *HelloWorldService {
+	return &HelloWorldService{repo: repo, events: events}
 }
 
-// GetHelloWorld Hello Worldメッセージを取得
-func (s *HelloWorldService) GetHelloWorld() *models.HelloWorldResponse {
+// GetHelloWorld 指定した言語のHello Worldメッセージを取得
+func (s *HelloWorldService) GetHelloWorld(locale models.Locale) *models.HelloWorldResponse {
 	return &models.HelloWorldResponse{
-		Message:   "Hello, World!",
+		Message:   models.Translate(locale, "hello_world.greeting", nil),
 		Timestamp: time.Now(),
 		Version:   "1.0.0",
 	}
@@ -35,110 +68,78 @@ func (s *HelloWorldService) CreateHelloWorld(request *models.HelloWorldRequest)
 		return nil, err
 	}
 
-	if s.db == nil {
-		return nil, errors.New("database connection is not available")
-	}
-
-	// データベースに保存
-	query := `
-		INSERT INTO hello_world_messages (name, message, created_at, updated_at)
-		VALUES ($1, $2, $3, $4)
-		RETURNING id, name, message, created_at, updated_at
-	`
-
-	message := fmt.Sprintf("Hello, %s!", request.Name)
-	now := time.Now()
-
-	var result models.HelloWorldMessage
-	err := s.db.QueryRow(
-		query,
-		request.Name,
-		message,
-		now,
-		now,
-	).Scan(
-		&result.ID,
-		&result.Name,
-		&result.Message,
-		&result.CreatedAt,
-		&result.UpdatedAt,
-	)
-
+	message, err := s.repo.Create(request.Name, fmt.Sprintf("Hello, %s!", request.Name))
 	if err != nil {
-		return nil, fmt.Errorf("failed to create hello world message: %w", err)
+		return nil, err
 	}
-
-	return &result, nil
+	publishEvent(s.events, models.EventMessageCreated, 0, message)
+	return message, nil
 }
 
 // GetHelloWorldMessages 全てのHello Worldメッセージを取得
 func (s *HelloWorldService) GetHelloWorldMessages() ([]models.HelloWorldMessage, error) {
-	if s.db == nil {
-		return nil, errors.New("database connection is not available")
-	}
+	return s.repo.FindAll()
+}
 
-	query := `
-		SELECT id, name, message, created_at, updated_at
-		FROM hello_world_messages
-		ORDER BY created_at DESC
-	`
+// ListHelloWorldMessages 検索条件に一致するHello Worldメッセージをページ単位で取得
+func (s *HelloWorldService) ListHelloWorldMessages(spec *QuerySpec) (*Page[models.HelloWorldMessage], error) {
+	return s.repo.List(spec)
+}
 
-	rows, err := s.db.Query(query)
-	if err != nil {
-		return nil, fmt.Errorf("failed to query hello world messages: %w", err)
-	}
-	defer rows.Close()
-
-	var messages []models.HelloWorldMessage
-	for rows.Next() {
-		var msg models.HelloWorldMessage
-		err := rows.Scan(
-			&msg.ID,
-			&msg.Name,
-			&msg.Message,
-			&msg.CreatedAt,
-			&msg.UpdatedAt,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan hello world message: %w", err)
-		}
-		messages = append(messages, msg)
-	}
+// GetHelloWorldMessageByID IDでHello Worldメッセージを取得
+func (s *HelloWorldService) GetHelloWorldMessageByID(id int) (*models.HelloWorldMessage, error) {
+	return s.repo.FindByID(id)
+}
 
-	if err = rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating hello world messages: %w", err)
+// UpdateHelloWorldMessage Hello Worldメッセージを全置換で更新
+func (s *HelloWorldService) UpdateHelloWorldMessage(id int, request *models.HelloWorldUpdateRequest) (*models.HelloWorldMessage, error) {
+	// バリデーション
+	if err := request.Validate(); err != nil {
+		return nil, err
 	}
 
-	return messages, nil
+	message, err := s.repo.Update(id, request.Name, request.Message)
+	if err != nil {
+		return nil, err
+	}
+	publishEvent(s.events, models.EventMessageUpdated, 0, message)
+	return message, nil
 }
 
-// GetHelloWorldMessageByID IDでHello Worldメッセージを取得
-func (s *HelloWorldService) GetHelloWorldMessageByID(id int) (*models.HelloWorldMessage, error) {
-	if s.db == nil {
-		return nil, errors.New("database connection is not available")
+// PatchHelloWorldMessage JSON Merge Patch (RFC 7396) でHello Worldメッセージを部分更新
+func (s *HelloWorldService) PatchHelloWorldMessage(id int, patch []byte) (*models.HelloWorldMessage, error) {
+	current, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, err
 	}
 
-	query := `
-		SELECT id, name, message, created_at, updated_at
-		FROM hello_world_messages
-		WHERE id = $1
-	`
-
-	var msg models.HelloWorldMessage
-	err := s.db.QueryRow(query, id).Scan(
-		&msg.ID,
-		&msg.Name,
-		&msg.Message,
-		&msg.CreatedAt,
-		&msg.UpdatedAt,
-	)
+	// 更新可能なフィールドのみを対象にパッチを適用
+	original, err := json.Marshal(models.HelloWorldUpdateRequest{
+		Name:    current.Name,
+		Message: current.Message,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode hello world message: %w", err)
+	}
 
+	merged, err := utils.ApplyMergePatch(original, patch)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("hello world message not found")
-		}
-		return nil, fmt.Errorf("failed to get hello world message: %w", err)
+		return nil, models.NewValidationError("body", models.ValidationCodeInvalid, "validation.invalid_merge_patch", nil)
 	}
 
-	return &msg, nil
+	var request models.HelloWorldUpdateRequest
+	if err := models.DecodeJSON(bytes.NewReader(merged), &request); err != nil {
+		return nil, err
+	}
+
+	return s.UpdateHelloWorldMessage(id, &request)
+}
+
+// DeleteHelloWorldMessage Hello Worldメッセージを削除
+func (s *HelloWorldService) DeleteHelloWorldMessage(id int) error {
+	if err := s.repo.Delete(id); err != nil {
+		return err
+	}
+	publishEvent(s.events, models.EventMessageDeleted, 0, models.EventResourceID{ID: id})
+	return nil
 }
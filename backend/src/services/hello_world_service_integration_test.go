@@ -2,6 +2,8 @@ package services
 
 import (
 	"database/sql"
+	"errors"
+	"net/url"
 	"testing"
 	"time"
 
@@ -10,9 +12,11 @@ import (
 	_ "github.com/lib/pq"
 )
 
+// testDSN テスト用DBの接続文字列
+const testDSN = "host=localhost port=15434 user=sampleuser password=samplepass dbname=sampledb_test sslmode=disable"
+
 func setupTestDB(t *testing.T) *sql.DB {
-	dsn := "host=localhost port=15434 user=sampleuser password=samplepass dbname=sampledb_test sslmode=disable"
-	db, err := sql.Open("postgres", dsn)
+	db, err := sql.Open("postgres", testDSN)
 	if err != nil {
 		t.Fatalf("DB接続失敗: %v", err)
 	}
@@ -74,3 +78,150 @@ func TestCreateAndGetHelloWorldIntegration(t *testing.T) {
 		t.Errorf("テストデータ削除失敗: %v", err)
 	}
 }
+
+func TestUpdatePatchDeleteHelloWorldIntegration(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	service := NewHelloWorldService(db)
+
+	created, err := service.CreateHelloWorld(&models.HelloWorldRequest{Name: "UpdateTarget"})
+	if err != nil {
+		t.Fatalf("CreateHelloWorld失敗: %v", err)
+	}
+
+	// 1. PUT（全置換）
+	updated, err := service.UpdateHelloWorldMessage(created.ID, &models.HelloWorldUpdateRequest{
+		Name:    "Updated",
+		Message: "Updated message",
+	})
+	if err != nil {
+		t.Fatalf("UpdateHelloWorldMessage失敗: %v", err)
+	}
+	if updated.Name != "Updated" || updated.Message != "Updated message" {
+		t.Errorf("更新内容不一致: got %+v", updated)
+	}
+	if !updated.UpdatedAt.After(created.UpdatedAt) {
+		t.Error("updated_atがトリガーで更新されていない")
+	}
+
+	// 2. PATCH（nameのみ変更、messageは維持）
+	patched, err := service.PatchHelloWorldMessage(created.ID, []byte(`{"name":"Patched"}`))
+	if err != nil {
+		t.Fatalf("PatchHelloWorldMessage失敗: %v", err)
+	}
+	if patched.Name != "Patched" || patched.Message != "Updated message" {
+		t.Errorf("パッチ内容不一致: got %+v", patched)
+	}
+
+	// 3. PATCHでnullを指定すると必須項目違反
+	if _, err := service.PatchHelloWorldMessage(created.ID, []byte(`{"message":null}`)); err == nil {
+		t.Error("messageの削除でバリデーションエラーが発生しない")
+	}
+
+	// 4. DELETE
+	if err := service.DeleteHelloWorldMessage(created.ID); err != nil {
+		t.Fatalf("DeleteHelloWorldMessage失敗: %v", err)
+	}
+	if err := service.DeleteHelloWorldMessage(created.ID); !errors.Is(err, ErrHelloWorldMessageNotFound) {
+		t.Errorf("削除済みIDでErrHelloWorldMessageNotFoundが返らない: %v", err)
+	}
+	if _, err := service.UpdateHelloWorldMessage(created.ID, &models.HelloWorldUpdateRequest{Name: "x", Message: "y"}); !errors.Is(err, ErrHelloWorldMessageNotFound) {
+		t.Errorf("削除済みIDの更新でErrHelloWorldMessageNotFoundが返らない: %v", err)
+	}
+}
+
+func TestListHelloWorldMessagesPaginationIntegration(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	service := NewHelloWorldService(db)
+
+	var ids []int
+	for i := 0; i < 3; i++ {
+		msg, err := service.CreateHelloWorld(&models.HelloWorldRequest{Name: "PageTest"})
+		if err != nil {
+			t.Fatalf("CreateHelloWorld失敗: %v", err)
+		}
+		ids = append(ids, msg.ID)
+	}
+	defer func() {
+		for _, id := range ids {
+			_ = service.DeleteHelloWorldMessage(id)
+		}
+	}()
+
+	// 1ページ目（オフセット）
+	spec, _ := HelloWorldMessageQuerySchema.Parse(url.Values{"limit": {"2"}})
+	first, err := service.ListHelloWorldMessages(spec)
+	if err != nil {
+		t.Fatalf("ListHelloWorldMessages失敗: %v", err)
+	}
+	if len(first.Items) != 2 || !first.HasMore || first.NextCursor == nil {
+		t.Fatalf("1ページ目の結果が不正: %+v", first)
+	}
+	if first.Total < 3 {
+		t.Errorf("totalが不正: got %d", first.Total)
+	}
+
+	// 2ページ目（キーセット）は1ページ目と重複しない
+	spec, _ = HelloWorldMessageQuerySchema.Parse(url.Values{"limit": {"2"}, "after": {first.NextCursor.Encode()}})
+	second, err := service.ListHelloWorldMessages(spec)
+	if err != nil {
+		t.Fatalf("ListHelloWorldMessages失敗: %v", err)
+	}
+	for _, a := range first.Items {
+		for _, b := range second.Items {
+			if a.ID == b.ID {
+				t.Errorf("ページ間でID %d が重複", a.ID)
+			}
+		}
+	}
+}
+
+func TestListHelloWorldMessagesFilterSearchIntegration(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	service := NewHelloWorldService(db)
+
+	created, err := service.CreateHelloWorld(&models.HelloWorldRequest{Name: "SearchableZebra"})
+	if err != nil {
+		t.Fatalf("CreateHelloWorld失敗: %v", err)
+	}
+	defer service.DeleteHelloWorldMessage(created.ID)
+
+	tests := []struct {
+		name  string
+		query url.Values
+		found bool
+	}{
+		{"Name filter", url.Values{"name": {"SearchableZebra"}}, true},
+		{"Name filter mismatch", url.Values{"name": {"Nobody"}}, false},
+		{"Full-text search", url.Values{"q": {"searchablezebra"}}, true},
+		{"Created after future", url.Values{"created_after": {"2999-01-01"}}, false},
+		{"Created before future", url.Values{"created_before": {"2999-01-01"}, "sort": {"-created_at,name"}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec, err := HelloWorldMessageQuerySchema.Parse(tt.query)
+			if err != nil {
+				t.Fatalf("Parse失敗: %v", err)
+			}
+			result, err := service.ListHelloWorldMessages(spec)
+			if err != nil {
+				t.Fatalf("ListHelloWorldMessages失敗: %v", err)
+			}
+			found := false
+			for _, m := range result.Items {
+				if m.ID == created.ID {
+					found = true
+				}
+			}
+			if found != tt.found {
+				t.Errorf("検索結果不一致: expected found=%v", tt.found)
+			}
+		})
+	}
+}
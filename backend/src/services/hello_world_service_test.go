@@ -41,7 +41,12 @@ func TestNewHelloWorldService(t *testing.T) {
 		t.Error("Expected service to be created, got nil")
 	}
 	
-	if service.db != db {
+	repo, ok := service.repo.(*PostgresHelloWorldRepository)
+	if !ok {
+		t.Fatalf("Expected *PostgresHelloWorldRepository, got %T", service.repo)
+	}
+
+	if repo.db != db {
 		t.Error("Expected database to be set correctly")
 	}
 }
@@ -50,12 +55,16 @@ func TestNewHelloWorldService(t *testing.T) {
 func TestGetHelloWorld(t *testing.T) {
 	service := NewHelloWorldService(nil)
 
-	response := service.GetHelloWorld()
+	response := service.GetHelloWorld(models.LocaleEN)
 
 	if response.Message != "Hello, World!" {
 		t.Errorf("Expected 'Hello, World!', got '%s'", response.Message)
 	}
 
+	if got := service.GetHelloWorld(models.LocaleJA).Message; got != "こんにちは、世界！" {
+		t.Errorf("Expected 'こんにちは、世界！', got '%s'", got)
+	}
+
 	if response.Version != "1.0.0" {
 		t.Errorf("Expected '1.0.0', got '%s'", response.Version)
 	}
@@ -0,0 +1,35 @@
+package services
+
+import "backend/models"
+
+var (
+	// ErrMailNotFound 指定されたメールが存在しない
+	ErrMailNotFound = newError(ErrNotFound, "mail not found", "mail.not_found")
+
+	// ErrMailMessageIDExists Message-ID が他のメールで使用されている
+	ErrMailMessageIDExists = newError(ErrConflict, "mail message id already exists", "mail.message_id_taken")
+)
+
+// MailRepository 受信メールとユーザーごとの既読状態の永続化インターフェース
+//
+// userID を受け取るメソッドは、そのユーザーの既読状態を Mail.Unread に設定して返します。
+type MailRepository interface {
+	// Create メールを保存し、採番されたIDを含めて返す（返り値の Unread は true）
+	// ThreadID が0の場合は自身のIDを新しいスレッドIDとする
+	// Message-ID が重複する場合は ErrMailMessageIDExists を返す
+	Create(mail *models.Mail) (*models.Mail, error)
+	// FindThreadID 既存スレッドのIDを返す（見つからなければ0）
+	// inReplyTo に一致する Message-ID のメールを優先し、次に threadSubject が一致する最新のメールを探す
+	// いずれも空文字列の場合は検索しない
+	FindThreadID(inReplyTo, threadSubject string) (int, error)
+	// List 検索条件に一致するメールをページ単位で返す（unreadOnly の場合は未読のみ）
+	List(userID int, unreadOnly bool, spec *QuerySpec) (*Page[models.Mail], error)
+	// FindByID IDでメールを返す
+	FindByID(userID, id int) (*models.Mail, error)
+	// ListThread スレッドのメールを日時の昇順で返す
+	ListThread(userID, threadID int) ([]models.Mail, error)
+	// SetRead ユーザーの既読状態を設定する（冪等）
+	SetRead(userID, id int, read bool) error
+	// CountUnread ユーザーの未読メール件数を返す
+	CountUnread(userID int) (int, error)
+}
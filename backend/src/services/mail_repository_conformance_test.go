@@ -0,0 +1,183 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"backend/models"
+)
+
+// TestMemoryMailRepositoryConformance メモリメールリポジトリの適合テスト
+func TestMemoryMailRepositoryConformance(t *testing.T) {
+	runMailRepositoryConformance(t, func(t *testing.T) (MailRepository, UserRepository) {
+		return NewMemoryMailRepository(), NewMemoryUserRepository()
+	})
+}
+
+// TestPostgresMailRepositoryConformance PostgreSQLメールリポジトリの適合テスト
+func TestPostgresMailRepositoryConformance(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	runMailRepositoryConformance(t, func(t *testing.T) (MailRepository, UserRepository) {
+		return NewPostgresMailRepository(db), NewPostgresUserRepository(db)
+	})
+}
+
+// runMailRepositoryConformance 全てのMailRepository実装が満たすべき振る舞い
+// 共有DBでも動作するよう、ユーザー・Message-ID・件名は都度一意な値で作成し、件数は差分で検証します
+func runMailRepositoryConformance(t *testing.T, newRepos func(t *testing.T) (MailRepository, UserRepository)) {
+	unique := func(prefix string) string {
+		return fmt.Sprintf("%s%d", prefix, time.Now().UnixNano())
+	}
+	createUser := func(t *testing.T, users UserRepository) int {
+		t.Helper()
+		user, err := users.Create(unique("mail")+"@example.com", "Mail", "hash")
+		if err != nil {
+			t.Fatalf("ユーザー作成失敗: %v", err)
+		}
+		return user.ID
+	}
+	newMail := func(subject string, threadID int) *models.Mail {
+		return &models.Mail{
+			From:          models.MailSender{Name: "Alex Smith", Email: "alex.smith@example.com"},
+			Subject:       subject,
+			Body:          "Hello",
+			Date:          time.Now().Truncate(time.Microsecond),
+			MessageID:     "<" + unique("msg") + "@example.com>",
+			ThreadID:      threadID,
+			ThreadSubject: models.NormalizeMailSubject(subject),
+		}
+	}
+
+	t.Run("CreateAndFind", func(t *testing.T) {
+		repo, users := newRepos(t)
+		userID := createUser(t, users)
+		mail := newMail(unique("Subject "), 0)
+		mail.From.Avatar = &models.Avatar{Src: "https://i.pravatar.cc/128?u=1"}
+
+		created, err := repo.Create(mail)
+		if err != nil {
+			t.Fatalf("Create失敗: %v", err)
+		}
+		if created.ID == 0 || created.ThreadID != created.ID || !created.Unread || created.CreatedAt.IsZero() {
+			t.Errorf("作成結果が不正: %+v", created)
+		}
+
+		found, err := repo.FindByID(userID, created.ID)
+		if err != nil {
+			t.Fatalf("FindByID失敗: %v", err)
+		}
+		if found.MessageID != mail.MessageID || found.From.Avatar == nil || !found.Date.Equal(mail.Date) || !found.Unread {
+			t.Errorf("取得結果が不正: %+v", found)
+		}
+
+		if _, err := repo.FindByID(userID, 999999999); !errors.Is(err, ErrMailNotFound) {
+			t.Errorf("Expected ErrMailNotFound, got %v", err)
+		}
+
+		duplicate := newMail("Duplicate", 0)
+		duplicate.MessageID = mail.MessageID
+		if _, err := repo.Create(duplicate); !errors.Is(err, ErrMailMessageIDExists) {
+			t.Errorf("Expected ErrMailMessageIDExists, got %v", err)
+		}
+	})
+
+	t.Run("Threads", func(t *testing.T) {
+		repo, users := newRepos(t)
+		userID := createUser(t, users)
+		subject := unique("Sprint ")
+
+		root, err := repo.Create(newMail(subject, 0))
+		if err != nil {
+			t.Fatalf("Create失敗: %v", err)
+		}
+
+		if threadID, err := repo.FindThreadID(root.MessageID, ""); err != nil || threadID != root.ID {
+			t.Errorf("In-Reply-To で検索したスレッドが不正: %d, %v", threadID, err)
+		}
+		if threadID, err := repo.FindThreadID("", models.NormalizeMailSubject("RE: "+subject)); err != nil || threadID != root.ID {
+			t.Errorf("件名で検索したスレッドが不正: %d, %v", threadID, err)
+		}
+		if threadID, err := repo.FindThreadID("<unknown@example.com>", unique("other")); err != nil || threadID != 0 {
+			t.Errorf("該当なしで0が返らない: %d, %v", threadID, err)
+		}
+
+		reply := newMail("RE: "+subject, root.ID)
+		reply.Date = root.Date.Add(time.Minute)
+		if _, err := repo.Create(reply); err != nil {
+			t.Fatalf("Create失敗: %v", err)
+		}
+
+		thread, err := repo.ListThread(userID, root.ID)
+		if err != nil {
+			t.Fatalf("ListThread失敗: %v", err)
+		}
+		if len(thread) != 2 || thread[0].ID != root.ID || thread[1].Subject != "RE: "+subject {
+			t.Errorf("スレッドが日時の昇順で返らない: %+v", thread)
+		}
+	})
+
+	t.Run("ReadState", func(t *testing.T) {
+		repo, users := newRepos(t)
+		userID := createUser(t, users)
+		otherID := createUser(t, users)
+
+		before, err := repo.CountUnread(userID)
+		if err != nil {
+			t.Fatalf("CountUnread失敗: %v", err)
+		}
+		first, _ := repo.Create(newMail(unique("First "), 0))
+		second, _ := repo.Create(newMail(unique("Second "), first.ID))
+
+		if err := repo.SetRead(userID, first.ID, true); err != nil {
+			t.Fatalf("SetRead失敗: %v", err)
+		}
+		// 既読の重複設定はエラーにならない
+		if err := repo.SetRead(userID, first.ID, true); err != nil {
+			t.Fatalf("SetRead（重複）失敗: %v", err)
+		}
+		if count, err := repo.CountUnread(userID); err != nil || count != before+1 {
+			t.Errorf("未読件数が不正: %d（変更前 %d）, %v", count, before, err)
+		}
+		if mail, _ := repo.FindByID(userID, first.ID); mail.Unread {
+			t.Error("既読にしたメールが未読のまま")
+		}
+		if mail, _ := repo.FindByID(otherID, first.ID); !mail.Unread {
+			t.Error("他のユーザーの既読状態が影響している")
+		}
+
+		spec, err := MailQuerySchema.Parse(url.Values{"thread_id": {strconv.Itoa(first.ID)}})
+		if err != nil {
+			t.Fatalf("Parse失敗: %v", err)
+		}
+		unread, err := repo.List(userID, true, spec)
+		if err != nil {
+			t.Fatalf("List失敗: %v", err)
+		}
+		if unread.Total != 1 || len(unread.Items) != 1 || unread.Items[0].ID != second.ID {
+			t.Errorf("未読のみの一覧が不正: %+v", unread)
+		}
+		all, err := repo.List(userID, false, spec)
+		if err != nil || all.Total != 2 {
+			t.Errorf("全件の一覧が不正: %+v, %v", all, err)
+		}
+
+		if err := repo.SetRead(userID, first.ID, false); err != nil {
+			t.Fatalf("SetRead（未読）失敗: %v", err)
+		}
+		if mail, _ := repo.FindByID(userID, first.ID); !mail.Unread {
+			t.Error("未読に戻したメールが既読のまま")
+		}
+
+		for _, read := range []bool{true, false} {
+			if err := repo.SetRead(userID, 999999999, read); !errors.Is(err, ErrMailNotFound) {
+				t.Errorf("SetRead(%v): Expected ErrMailNotFound, got %v", read, err)
+			}
+		}
+	})
+}
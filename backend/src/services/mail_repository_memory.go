@@ -0,0 +1,204 @@
+package services
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"backend/models"
+)
+
+// mailReadKey ユーザーごとの既読状態のキー
+type mailReadKey struct {
+	userID int
+	mailID int
+}
+
+// MemoryMailRepository メモリ上で動作するメールリポジトリ
+type MemoryMailRepository struct {
+	mu     sync.RWMutex
+	mails  map[int]models.Mail
+	reads  map[mailReadKey]time.Time
+	nextID int
+}
+
+// NewMemoryMailRepository メモリメールリポジトリを新規作成
+func NewMemoryMailRepository() *MemoryMailRepository {
+	return &MemoryMailRepository{
+		mails:  make(map[int]models.Mail),
+		reads:  make(map[mailReadKey]time.Time),
+		nextID: 1,
+	}
+}
+
+// Create メールを保存
+func (r *MemoryMailRepository) Create(mail *models.Mail) (*models.Mail, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.mails {
+		if existing.MessageID == mail.MessageID {
+			return nil, ErrMailMessageIDExists
+		}
+	}
+
+	stored := copyMail(*mail)
+	stored.ID = r.nextID
+	stored.Unread = false
+	stored.CreatedAt = time.Now()
+	if stored.ThreadID == 0 {
+		stored.ThreadID = stored.ID
+	}
+	r.mails[stored.ID] = stored
+	r.nextID++
+
+	result := copyMail(stored)
+	result.Unread = true
+	return &result, nil
+}
+
+// FindThreadID 返信先の Message-ID、次に正規化した件名で既存スレッドを検索
+func (r *MemoryMailRepository) FindThreadID(inReplyTo, threadSubject string) (int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if inReplyTo != "" {
+		for _, mail := range r.mails {
+			if mail.MessageID == inReplyTo {
+				return mail.ThreadID, nil
+			}
+		}
+	}
+	if threadSubject != "" {
+		var latest *models.Mail
+		for _, mail := range r.mails {
+			if mail.ThreadSubject != threadSubject {
+				continue
+			}
+			if latest == nil || mail.Date.After(latest.Date) || (mail.Date.Equal(latest.Date) && mail.ID > latest.ID) {
+				m := mail
+				latest = &m
+			}
+		}
+		if latest != nil {
+			return latest.ThreadID, nil
+		}
+	}
+	return 0, nil
+}
+
+// List 検索条件に一致するメールをページ単位で取得
+func (r *MemoryMailRepository) List(userID int, unreadOnly bool, spec *QuerySpec) (*Page[models.Mail], error) {
+	r.mu.RLock()
+	mails := make([]models.Mail, 0, len(r.mails))
+	for _, mail := range r.mails {
+		mail = r.withReadState(userID, mail)
+		if unreadOnly && !mail.Unread {
+			continue
+		}
+		mails = append(mails, mail)
+	}
+	r.mu.RUnlock()
+
+	return applyQuerySpec(mails, spec, mailFields, mailCursor), nil
+}
+
+// FindByID IDでメールを取得
+func (r *MemoryMailRepository) FindByID(userID, id int) (*models.Mail, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	mail, ok := r.mails[id]
+	if !ok {
+		return nil, ErrMailNotFound
+	}
+	result := r.withReadState(userID, mail)
+	return &result, nil
+}
+
+// ListThread スレッドのメールを日時の昇順で取得
+func (r *MemoryMailRepository) ListThread(userID, threadID int) ([]models.Mail, error) {
+	r.mu.RLock()
+	mails := []models.Mail{}
+	for _, mail := range r.mails {
+		if mail.ThreadID == threadID {
+			mails = append(mails, r.withReadState(userID, mail))
+		}
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(mails, func(i, j int) bool {
+		if !mails[i].Date.Equal(mails[j].Date) {
+			return mails[i].Date.Before(mails[j].Date)
+		}
+		return mails[i].ID < mails[j].ID
+	})
+	return mails, nil
+}
+
+// SetRead ユーザーの既読状態を設定
+func (r *MemoryMailRepository) SetRead(userID, id int, read bool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.mails[id]; !ok {
+		return ErrMailNotFound
+	}
+	key := mailReadKey{userID: userID, mailID: id}
+	if !read {
+		delete(r.reads, key)
+	} else if _, ok := r.reads[key]; !ok {
+		r.reads[key] = time.Now()
+	}
+	return nil
+}
+
+// CountUnread ユーザーの未読メール件数を取得
+func (r *MemoryMailRepository) CountUnread(userID int) (int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	count := 0
+	for id := range r.mails {
+		if _, ok := r.reads[mailReadKey{userID: userID, mailID: id}]; !ok {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// withReadState ユーザーの既読状態を設定したメールの複製を返す（呼び出し側でロックを保持すること）
+func (r *MemoryMailRepository) withReadState(userID int, mail models.Mail) models.Mail {
+	result := copyMail(mail)
+	_, read := r.reads[mailReadKey{userID: userID, mailID: mail.ID}]
+	result.Unread = !read
+	return result
+}
+
+// copyMail 呼び出し側の変更が保存データに影響しないようメールを複製
+func copyMail(mail models.Mail) models.Mail {
+	if mail.From.Avatar != nil {
+		avatar := *mail.From.Avatar
+		mail.From.Avatar = &avatar
+	}
+	return mail
+}
+
+// mailFields QuerySpec評価用のフィールド値
+func mailFields(mail models.Mail) map[string]interface{} {
+	return map[string]interface{}{
+		"id":         mail.ID,
+		"subject":    mail.Subject,
+		"body":       mail.Body,
+		"from":       mail.From.Email,
+		"from_name":  mail.From.Name,
+		"date":       mail.Date,
+		"thread_id":  mail.ThreadID,
+		"created_at": mail.CreatedAt,
+	}
+}
+
+// mailCursor メールの位置を表すカーソル
+func mailCursor(mail models.Mail) Cursor {
+	return Cursor{CreatedAt: mail.CreatedAt, ID: mail.ID}
+}
@@ -0,0 +1,233 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"backend/models"
+)
+
+// mailColumns メール取得時の列（r は mail_reads の LEFT JOIN）
+const mailColumns = `m.id, r.mail_id IS NULL, m.from_name, m.from_email, m.from_avatar_url, m.subject, m.body,
+	m.date, m.message_id, m.in_reply_to, m.thread_id, m.thread_subject, m.created_at`
+
+// mailFrom 既読状態を結合したメールの FROM 句（$1 はユーザーID）
+const mailFrom = ` FROM mails m LEFT JOIN mail_reads r ON r.mail_id = m.id AND r.user_id = $1`
+
+// PostgresMailRepository PostgreSQLによるメールリポジトリ
+type PostgresMailRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresMailRepository PostgreSQLメールリポジトリを新規作成
+// db が nil の場合、全ての操作は ErrDatabaseUnavailable を返します
+func NewPostgresMailRepository(db *sql.DB) *PostgresMailRepository {
+	return &PostgresMailRepository{db: db}
+}
+
+// Create メールを保存
+// 新しいスレッドの場合に thread_id へ自身のIDを設定するため、IDを先に採番します
+func (r *PostgresMailRepository) Create(mail *models.Mail) (*models.Mail, error) {
+	if r.db == nil {
+		return nil, ErrDatabaseUnavailable
+	}
+
+	query := `
+		WITH next AS (SELECT nextval(pg_get_serial_sequence('mails', 'id')) AS id)
+		INSERT INTO mails (id, message_id, in_reply_to, thread_id, thread_subject,
+			from_name, from_email, from_avatar_url, subject, body, date)
+		SELECT next.id, $1, $2, COALESCE(NULLIF($3, 0), next.id), $4, $5, $6, $7, $8, $9, $10
+		FROM next
+		RETURNING id, TRUE, from_name, from_email, from_avatar_url, subject, body,
+			date, message_id, in_reply_to, thread_id, thread_subject, created_at`
+
+	created, err := scanMail(r.db.QueryRow(query, mail.MessageID, mail.InReplyTo, mail.ThreadID, mail.ThreadSubject,
+		mail.From.Name, mail.From.Email, avatarURL(mail.From.Avatar), mail.Subject, mail.Body, mail.Date))
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, ErrMailMessageIDExists
+		}
+		return nil, fmt.Errorf("failed to create mail: %w", err)
+	}
+	return created, nil
+}
+
+// FindThreadID 返信先の Message-ID、次に正規化した件名で既存スレッドを検索
+func (r *PostgresMailRepository) FindThreadID(inReplyTo, threadSubject string) (int, error) {
+	if r.db == nil {
+		return 0, ErrDatabaseUnavailable
+	}
+
+	lookups := []struct {
+		query string
+		value string
+	}{
+		{`SELECT thread_id FROM mails WHERE message_id = $1`, inReplyTo},
+		{`SELECT thread_id FROM mails WHERE thread_subject = $1 ORDER BY date DESC, id DESC LIMIT 1`, threadSubject},
+	}
+	for _, lookup := range lookups {
+		if lookup.value == "" {
+			continue
+		}
+		var threadID int
+		err := r.db.QueryRow(lookup.query, lookup.value).Scan(&threadID)
+		if err == nil {
+			return threadID, nil
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return 0, fmt.Errorf("failed to find mail thread: %w", err)
+		}
+	}
+	return 0, nil
+}
+
+// List 検索条件に一致するメールをページ単位で取得
+func (r *PostgresMailRepository) List(userID int, unreadOnly bool, spec *QuerySpec) (*Page[models.Mail], error) {
+	if r.db == nil {
+		return nil, ErrDatabaseUnavailable
+	}
+
+	where := func(args *[]interface{}, withCursor bool) string {
+		clause := spec.WhereClause(args, withCursor)
+		if !unreadOnly {
+			return clause
+		}
+		if clause == "" {
+			return " WHERE r.mail_id IS NULL"
+		}
+		return clause + " AND r.mail_id IS NULL"
+	}
+
+	countArgs := []interface{}{userID}
+	countQuery := `SELECT COUNT(*)` + mailFrom + where(&countArgs, false)
+
+	var total int
+	if err := r.db.QueryRow(countQuery, countArgs...).Scan(&total); err != nil {
+		return nil, fmt.Errorf("failed to count mails: %w", err)
+	}
+
+	args := []interface{}{userID}
+	query := `SELECT ` + mailColumns + mailFrom + where(&args, true) + spec.OrderClause()
+
+	// 次ページの有無を判定するため1件多く取得
+	page := spec.Page
+	args = append(args, page.Limit+1, page.Offset)
+	query += fmt.Sprintf(` LIMIT $%d OFFSET $%d`, len(args)-1, len(args))
+
+	mails, err := r.queryMails(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return newPage(spec, mails, total, mailCursor), nil
+}
+
+// FindByID IDでメールを取得
+func (r *PostgresMailRepository) FindByID(userID, id int) (*models.Mail, error) {
+	if r.db == nil {
+		return nil, ErrDatabaseUnavailable
+	}
+
+	mail, err := scanMail(r.db.QueryRow(`SELECT `+mailColumns+mailFrom+` WHERE m.id = $2`, userID, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrMailNotFound
+		}
+		return nil, fmt.Errorf("failed to get mail: %w", err)
+	}
+	return mail, nil
+}
+
+// ListThread スレッドのメールを日時の昇順で取得
+func (r *PostgresMailRepository) ListThread(userID, threadID int) ([]models.Mail, error) {
+	if r.db == nil {
+		return nil, ErrDatabaseUnavailable
+	}
+
+	return r.queryMails(`SELECT `+mailColumns+mailFrom+` WHERE m.thread_id = $2 ORDER BY m.date, m.id`, userID, threadID)
+}
+
+// SetRead ユーザーの既読状態を設定（既読は mail_reads に行を追加、未読は削除）
+func (r *PostgresMailRepository) SetRead(userID, id int, read bool) error {
+	if r.db == nil {
+		return ErrDatabaseUnavailable
+	}
+
+	if read {
+		_, err := r.db.Exec(`
+			INSERT INTO mail_reads (user_id, mail_id) VALUES ($1, $2)
+			ON CONFLICT (user_id, mail_id) DO NOTHING`, userID, id)
+		if err != nil {
+			if isForeignKeyViolation(err) {
+				return ErrMailNotFound
+			}
+			return fmt.Errorf("failed to mark mail as read: %w", err)
+		}
+		return nil
+	}
+
+	var exists bool
+	if err := r.db.QueryRow(`SELECT EXISTS (SELECT 1 FROM mails WHERE id = $1)`, id).Scan(&exists); err != nil {
+		return fmt.Errorf("failed to get mail: %w", err)
+	}
+	if !exists {
+		return ErrMailNotFound
+	}
+	if _, err := r.db.Exec(`DELETE FROM mail_reads WHERE user_id = $1 AND mail_id = $2`, userID, id); err != nil {
+		return fmt.Errorf("failed to mark mail as unread: %w", err)
+	}
+	return nil
+}
+
+// CountUnread ユーザーの未読メール件数を取得
+func (r *PostgresMailRepository) CountUnread(userID int) (int, error) {
+	if r.db == nil {
+		return 0, ErrDatabaseUnavailable
+	}
+
+	var count int
+	err := r.db.QueryRow(`
+		SELECT COUNT(*) FROM mails m
+		WHERE NOT EXISTS (SELECT 1 FROM mail_reads r WHERE r.mail_id = m.id AND r.user_id = $1)`, userID).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count unread mails: %w", err)
+	}
+	return count, nil
+}
+
+// queryMails クエリ結果をメールの一覧に変換
+func (r *PostgresMailRepository) queryMails(query string, args ...interface{}) ([]models.Mail, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query mails: %w", err)
+	}
+	defer rows.Close()
+
+	mails := []models.Mail{}
+	for rows.Next() {
+		mail, err := scanMail(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan mail: %w", err)
+		}
+		mails = append(mails, *mail)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating mails: %w", err)
+	}
+	return mails, nil
+}
+
+// scanMail 1行をメールに変換（空の from_avatar_url はアバターなし）
+func scanMail(row rowScanner) (*models.Mail, error) {
+	var mail models.Mail
+	var avatar string
+	err := row.Scan(&mail.ID, &mail.Unread, &mail.From.Name, &mail.From.Email, &avatar, &mail.Subject, &mail.Body,
+		&mail.Date, &mail.MessageID, &mail.InReplyTo, &mail.ThreadID, &mail.ThreadSubject, &mail.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if avatar != "" {
+		mail.From.Avatar = &models.Avatar{Src: avatar}
+	}
+	return &mail, nil
+}
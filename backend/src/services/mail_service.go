@@ -0,0 +1,136 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"backend/models"
+	"backend/utils"
+)
+
+// MailQuerySchema メール一覧の検索・ソート定義
+var MailQuerySchema = &QuerySchema{
+	Filters: map[string]FilterDef{
+		"from":        {Field: "from", Op: FilterEq, Type: FieldString},
+		"thread_id":   {Field: "thread_id", Op: FilterEq, Type: FieldInt},
+		"date_after":  {Field: "date", Op: FilterGt, Type: FieldTime},
+		"date_before": {Field: "date", Op: FilterLt, Type: FieldTime},
+	},
+	Columns: map[string]string{
+		"id":         "m.id",
+		"subject":    "m.subject",
+		"from":       "m.from_email",
+		"date":       "m.date",
+		"thread_id":  "m.thread_id",
+		"created_at": "m.created_at",
+	},
+	Sortable:     []string{"id", "subject", "from", "date", "created_at"},
+	DefaultSort:  []SortField{{Field: "created_at", Desc: true}},
+	SearchColumn: "m.search_vector",
+	SearchFields: []string{"subject", "body", "from_name", "from"},
+}
+
+// MailService 受信箱サービス構造体
+type MailService struct {
+	repo     MailRepository
+	notifier Notifier
+}
+
+// NewMailService 受信箱サービスを新規作成
+// notifier が nil の場合、メールの受信を通知しません
+func NewMailService(repo MailRepository, notifier Notifier) *MailService {
+	return &MailService{repo: repo, notifier: notifier}
+}
+
+// ListMails ユーザーの既読状態を含むメールをページ単位で取得
+func (s *MailService) ListMails(user *models.User, filter models.MailFilter, spec *QuerySpec) (*Page[models.Mail], error) {
+	return s.repo.List(user.ID, filter == models.MailFilterUnread, spec)
+}
+
+// GetMail IDでメールを取得
+func (s *MailService) GetMail(user *models.User, id int) (*models.Mail, error) {
+	return s.repo.FindByID(user.ID, id)
+}
+
+// GetThread メールが属するスレッドの全メールを日時の昇順で取得
+func (s *MailService) GetThread(user *models.User, id int) ([]models.Mail, error) {
+	mail, err := s.repo.FindByID(user.ID, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.ListThread(user.ID, mail.ThreadID)
+}
+
+// CreateMail メールを受信箱に登録
+//
+// In-Reply-To が既存メールの Message-ID と一致すればそのスレッドに、
+// 件名が返信・転送（"Re:" など）であれば正規化した件名が一致する最新のスレッドに追加し、
+// どちらにも該当しなければ新しいスレッドを作成します。
+func (s *MailService) CreateMail(request *models.MailRequest) (*models.Mail, error) {
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+
+	mail := &models.Mail{
+		From:          request.From,
+		Subject:       request.Subject,
+		Body:          request.Body,
+		Date:          time.Now(),
+		MessageID:     request.MessageID,
+		InReplyTo:     request.InReplyTo,
+		ThreadSubject: models.NormalizeMailSubject(request.Subject),
+	}
+	if request.Date != nil {
+		mail.Date = *request.Date
+	}
+	if mail.MessageID == "" {
+		token, err := randomToken(16)
+		if err != nil {
+			return nil, err
+		}
+		mail.MessageID = fmt.Sprintf("<%s@%s>", token, utils.MailMessageIDDomain)
+	}
+
+	subjectLookup := ""
+	if models.IsMailReplySubject(request.Subject) {
+		subjectLookup = mail.ThreadSubject
+	}
+	threadID, err := s.repo.FindThreadID(mail.InReplyTo, subjectLookup)
+	if err != nil {
+		return nil, err
+	}
+	mail.ThreadID = threadID
+
+	created, err := s.repo.Create(mail)
+	if err != nil {
+		return nil, err
+	}
+
+	notifyAll(s.notifier, &NotificationEvent{
+		Type:   models.NotificationTypeMailReceived,
+		Sender: models.NotificationSender{Name: created.From.Name, Email: created.From.Email, Avatar: created.From.Avatar},
+		Body:   "sent you a message: " + created.Subject,
+		Link:   fmt.Sprintf("/inbox?mail=%d", created.ID),
+	})
+	return created, nil
+}
+
+// UpdateReadState ユーザーの既読・未読を切り替え、更新後のメールを返す
+func (s *MailService) UpdateReadState(user *models.User, id int, request *models.MailReadStateRequest) (*models.Mail, error) {
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.repo.SetRead(user.ID, id, !*request.Unread); err != nil {
+		return nil, err
+	}
+	return s.repo.FindByID(user.ID, id)
+}
+
+// UnreadCount ユーザーの未読メール件数を取得
+func (s *MailService) UnreadCount(user *models.User) (*models.MailUnreadCountResponse, error) {
+	count, err := s.repo.CountUnread(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &models.MailUnreadCountResponse{Unread: count}, nil
+}
@@ -0,0 +1,86 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"backend/models"
+)
+
+// TestMailServiceThreading In-Reply-To と件名によるスレッド判定のテスト
+func TestMailServiceThreading(t *testing.T) {
+	service := NewMailService(NewMemoryMailRepository(), nil)
+	from := models.MailSender{Name: "Jordan Brown", Email: "jordan.brown@example.com"}
+
+	root, err := service.CreateMail(&models.MailRequest{From: from, Subject: "Project Phoenix - Sprint 3 Update"})
+	if err != nil {
+		t.Fatalf("CreateMail() error = %v", err)
+	}
+	if root.ThreadID != root.ID || !strings.HasSuffix(root.MessageID, "@mail.localhost>") {
+		t.Errorf("新しいスレッドまたは Message-ID が不正: %+v", root)
+	}
+
+	// 返信件名は同じ件名のスレッドにまとめる
+	reply, err := service.CreateMail(&models.MailRequest{From: from, Subject: "RE: Project Phoenix - Sprint 3 Update"})
+	if err != nil || reply.ThreadID != root.ID {
+		t.Errorf("返信件名のメールが同じスレッドにならない: %+v, %v", reply, err)
+	}
+
+	// 件名が異なっても In-Reply-To で返信先のスレッドにまとめる
+	followUp, err := service.CreateMail(&models.MailRequest{From: from, Subject: "Blockers", InReplyTo: strings.Trim(reply.MessageID, "<>")})
+	if err != nil || followUp.ThreadID != root.ID {
+		t.Errorf("In-Reply-To のメールが同じスレッドにならない: %+v, %v", followUp, err)
+	}
+
+	// 返信でない同じ件名は別スレッド
+	other, err := service.CreateMail(&models.MailRequest{From: from, Subject: "Project Phoenix - Sprint 3 Update"})
+	if err != nil || other.ThreadID != other.ID {
+		t.Errorf("返信でない同名の件名が同じスレッドになる: %+v, %v", other, err)
+	}
+
+	thread, err := service.GetThread(&models.User{ID: 1}, followUp.ID)
+	if err != nil || len(thread) != 3 {
+		t.Errorf("GetThread() = %d件, %v", len(thread), err)
+	}
+}
+
+// TestMailServiceReadState 既読状態と未読件数のテスト
+func TestMailServiceReadState(t *testing.T) {
+	service := NewMailService(NewMemoryMailRepository(), nil)
+	alice, bob := &models.User{ID: 1}, &models.User{ID: 2}
+	date := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	mail, err := service.CreateMail(&models.MailRequest{
+		From:    models.MailSender{Email: "alex@example.com"},
+		Subject: "Hello",
+		Date:    &date,
+	})
+	if err != nil {
+		t.Fatalf("CreateMail() error = %v", err)
+	}
+	if !mail.Date.Equal(date) {
+		t.Errorf("指定した日時が保存されていない: %v", mail.Date)
+	}
+
+	read := false
+	updated, err := service.UpdateReadState(alice, mail.ID, &models.MailReadStateRequest{Unread: &read})
+	if err != nil || updated.Unread {
+		t.Fatalf("UpdateReadState() = %+v, %v", updated, err)
+	}
+	if count, _ := service.UnreadCount(alice); count.Unread != 0 {
+		t.Errorf("alice の未読件数 = %d, want 0", count.Unread)
+	}
+	if count, _ := service.UnreadCount(bob); count.Unread != 1 {
+		t.Errorf("bob の未読件数 = %d, want 1", count.Unread)
+	}
+
+	var validationErr *models.ValidationError
+	if _, err := service.UpdateReadState(alice, mail.ID, &models.MailReadStateRequest{}); !errors.As(err, &validationErr) {
+		t.Errorf("unread なしでValidationErrorが返らない: %v", err)
+	}
+	if _, err := service.UpdateReadState(alice, 999, &models.MailReadStateRequest{Unread: &read}); !errors.Is(err, ErrMailNotFound) {
+		t.Errorf("Expected ErrMailNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,88 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"mime"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+// EmailMessage 送信するメール（本文はプレーンテキスト）
+type EmailMessage struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer メール送信インターフェース
+type Mailer interface {
+	// Send メールを送信する
+	Send(message *EmailMessage) error
+}
+
+// MailerConfig メール送信設定
+type MailerConfig struct {
+	Host     string // SMTPサーバー（空の場合は LogMailer）
+	Port     string
+	Username string // 空の場合は認証なし
+	Password string
+	From     string
+}
+
+// NewMailer 設定に応じたメール送信を作成
+// SMTPサーバーが未設定の場合は送信内容をログに出力する LogMailer を返します
+func NewMailer(cfg MailerConfig) Mailer {
+	if cfg.Host == "" {
+		return LogMailer{}
+	}
+	return &SMTPMailer{config: cfg}
+}
+
+// LogMailer メールを送信せずログに出力する開発用の実装
+type LogMailer struct{}
+
+// Send メールの内容をログに出力
+func (LogMailer) Send(message *EmailMessage) error {
+	log.Printf("📧 Mail to %s: %s\n%s", message.To, message.Subject, message.Body)
+	return nil
+}
+
+// SMTPMailer SMTPサーバー経由でメールを送信する実装
+type SMTPMailer struct {
+	config MailerConfig
+}
+
+// Send SMTPでメールを送信
+func (m *SMTPMailer) Send(message *EmailMessage) error {
+	var auth smtp.Auth
+	if m.config.Username != "" {
+		auth = smtp.PlainAuth("", m.config.Username, m.config.Password, m.config.Host)
+	}
+
+	addr := net.JoinHostPort(m.config.Host, m.config.Port)
+	if err := smtp.SendMail(addr, auth, m.config.From, []string{message.To}, buildEmail(m.config.From, message, time.Now())); err != nil {
+		return fmt.Errorf("failed to send mail: %w", err)
+	}
+	return nil
+}
+
+// buildEmail RFC 5322 形式のメッセージを組み立てる
+// ヘッダーインジェクションを防ぐため、ヘッダー値の改行は空白に置き換えます
+func buildEmail(from string, message *EmailMessage, now time.Time) []byte {
+	header := strings.NewReplacer("\r", " ", "\n", " ")
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", header.Replace(from))
+	fmt.Fprintf(&b, "To: %s\r\n", header.Replace(message.To))
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", header.Replace(message.Subject)))
+	fmt.Fprintf(&b, "Date: %s\r\n", now.Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(strings.ReplaceAll(message.Body, "\r\n", "\n"), "\n", "\r\n"))
+	return []byte(b.String())
+}
@@ -0,0 +1,44 @@
+package services
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestNewMailer 設定に応じた実装の選択テスト
+func TestNewMailer(t *testing.T) {
+	if _, ok := NewMailer(MailerConfig{}).(LogMailer); !ok {
+		t.Error("SMTPサーバー未設定の場合は LogMailer を返すこと")
+	}
+	if _, ok := NewMailer(MailerConfig{Host: "smtp.example.com", Port: "587"}).(*SMTPMailer); !ok {
+		t.Error("SMTPサーバー設定時は *SMTPMailer を返すこと")
+	}
+}
+
+// TestBuildEmail メッセージの組み立てとヘッダーインジェクション対策のテスト
+func TestBuildEmail(t *testing.T) {
+	now := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	raw := string(buildEmail("no-reply@example.com", &EmailMessage{
+		To:      "daniel@example.com\r\nBcc: attacker@example.com",
+		Subject: "チームへの招待",
+		Body:    "line1\nline2\r\nline3",
+	}, now))
+
+	header, body, found := strings.Cut(raw, "\r\n\r\n")
+	if !found {
+		t.Fatalf("ヘッダーと本文の区切りがない: %q", raw)
+	}
+	if strings.Contains(header, "\r\nBcc:") {
+		t.Errorf("ヘッダーに改行が注入された: %q", header)
+	}
+	if !strings.Contains(header, "Subject: =?utf-8?q?") {
+		t.Errorf("件名がエンコードされていない: %q", header)
+	}
+	if !strings.Contains(header, "Date: Mon, 01 Jan 2024 10:00:00 +0000") {
+		t.Errorf("Date ヘッダーが不正: %q", header)
+	}
+	if body != "line1\r\nline2\r\nline3" {
+		t.Errorf("本文の改行が CRLF に統一されていない: %q", body)
+	}
+}
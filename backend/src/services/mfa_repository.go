@@ -0,0 +1,37 @@
+package services
+
+import (
+	"time"
+
+	"backend/models"
+)
+
+// ErrTOTPNotFound ユーザーのTOTPが登録されていない
+var ErrTOTPNotFound = newError(ErrNotFound, "totp not found", "mfa.totp_not_enrolled")
+
+// MFARepository TOTPとリカバリーコードの永続化インターフェース
+//
+// リカバリーコードは hashToken によるハッシュで受け渡します。
+type MFARepository interface {
+	// GetTOTP ユーザーのTOTP登録情報を返す（未登録は ErrTOTPNotFound）
+	GetTOTP(userID int) (*models.TOTPCredential, error)
+	// SaveTOTPSecret 確認待ちのTOTP秘密鍵を保存する（既存の登録は置き換える）
+	SaveTOTPSecret(userID int, secret string) error
+	// EnableTOTP 登録を確認済みにし、確認に使ったコードの時間ステップを記録する
+	EnableTOTP(userID int, step int64) error
+	// UseTOTPStep 時間ステップが前回より新しければ記録して true を返す（同じコードの再利用防止）
+	UseTOTPStep(userID int, step int64) (bool, error)
+	// DeleteTOTP TOTPとリカバリーコードを削除する
+	DeleteTOTP(userID int) error
+	// ReplaceRecoveryCodes リカバリーコードを全て置き換える
+	ReplaceRecoveryCodes(userID int, codeHashes []string) error
+	// UseRecoveryCode 未使用のリカバリーコードであれば使用済みにして true を返す
+	UseRecoveryCode(userID int, codeHash string) (bool, error)
+	// CountRecoveryCodes 未使用のリカバリーコード数を返す
+	CountRecoveryCodes(userID int) (int, error)
+	// AddMFAAttempts キーの試行回数に n を加え、加えた後の回数を返す
+	// 記録は expiresAt まで有効で、期限切れの記録は0回から数え直す
+	AddMFAAttempts(key string, n int, expiresAt time.Time) (int, error)
+	// ResetMFAAttempts キーの試行回数の記録を削除する
+	ResetMFAAttempts(key string) error
+}
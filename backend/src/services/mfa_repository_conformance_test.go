@@ -0,0 +1,167 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// TestMemoryMFARepositoryConformance メモリMFAリポジトリの適合テスト
+func TestMemoryMFARepositoryConformance(t *testing.T) {
+	runMFARepositoryConformance(t, func(t *testing.T) (MFARepository, UserRepository) {
+		return NewMemoryMFARepository(), NewMemoryUserRepository()
+	})
+}
+
+// TestPostgresMFARepositoryConformance PostgreSQL MFAリポジトリの適合テスト
+func TestPostgresMFARepositoryConformance(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	runMFARepositoryConformance(t, func(t *testing.T) (MFARepository, UserRepository) {
+		return NewPostgresMFARepository(db), NewPostgresUserRepository(db)
+	})
+}
+
+// runMFARepositoryConformance 全てのMFARepository実装が満たすべき振る舞い
+func runMFARepositoryConformance(t *testing.T, newRepos func(t *testing.T) (MFARepository, UserRepository)) {
+	createUser := func(t *testing.T, users UserRepository) int {
+		t.Helper()
+		user, err := users.Create(fmt.Sprintf("mfa%d@example.com", time.Now().UnixNano()), "MFA", "hash")
+		if err != nil {
+			t.Fatalf("ユーザー作成失敗: %v", err)
+		}
+		return user.ID
+	}
+
+	t.Run("TOTPLifecycle", func(t *testing.T) {
+		repo, users := newRepos(t)
+		userID := createUser(t, users)
+
+		if _, err := repo.GetTOTP(userID); !errors.Is(err, ErrTOTPNotFound) {
+			t.Fatalf("未登録は ErrTOTPNotFound であるべき: %v", err)
+		}
+
+		if err := repo.SaveTOTPSecret(userID, "SECRET1"); err != nil {
+			t.Fatalf("SaveTOTPSecret失敗: %v", err)
+		}
+		if err := repo.SaveTOTPSecret(userID, "SECRET2"); err != nil {
+			t.Fatalf("SaveTOTPSecret（置き換え）失敗: %v", err)
+		}
+		credential, err := repo.GetTOTP(userID)
+		if err != nil {
+			t.Fatalf("GetTOTP失敗: %v", err)
+		}
+		if credential.Secret != "SECRET2" || credential.Enabled() {
+			t.Errorf("確認待ちの登録が不正: %+v", credential)
+		}
+
+		if err := repo.EnableTOTP(userID, 100); err != nil {
+			t.Fatalf("EnableTOTP失敗: %v", err)
+		}
+		credential, _ = repo.GetTOTP(userID)
+		if !credential.Enabled() || credential.LastUsedStep != 100 {
+			t.Errorf("有効化結果が不正: %+v", credential)
+		}
+
+		if err := repo.DeleteTOTP(userID); err != nil {
+			t.Fatalf("DeleteTOTP失敗: %v", err)
+		}
+		if _, err := repo.GetTOTP(userID); !errors.Is(err, ErrTOTPNotFound) {
+			t.Errorf("削除後は ErrTOTPNotFound であるべき: %v", err)
+		}
+		if err := repo.EnableTOTP(userID, 101); !errors.Is(err, ErrTOTPNotFound) {
+			t.Errorf("未登録の有効化は ErrTOTPNotFound であるべき: %v", err)
+		}
+	})
+
+	t.Run("UseTOTPStepRejectsReplay", func(t *testing.T) {
+		repo, users := newRepos(t)
+		userID := createUser(t, users)
+		_ = repo.SaveTOTPSecret(userID, "SECRET")
+		_ = repo.EnableTOTP(userID, 100)
+
+		for _, tc := range []struct {
+			step int64
+			want bool
+		}{{100, false}, {99, false}, {101, true}, {101, false}, {103, true}} {
+			ok, err := repo.UseTOTPStep(userID, tc.step)
+			if err != nil {
+				t.Fatalf("UseTOTPStep(%d)失敗: %v", tc.step, err)
+			}
+			if ok != tc.want {
+				t.Errorf("UseTOTPStep(%d) = %v, want %v", tc.step, ok, tc.want)
+			}
+		}
+	})
+
+	t.Run("RecoveryCodes", func(t *testing.T) {
+		repo, users := newRepos(t)
+		userID := createUser(t, users)
+		_ = repo.SaveTOTPSecret(userID, "SECRET")
+
+		if err := repo.ReplaceRecoveryCodes(userID, []string{hashToken("a"), hashToken("b")}); err != nil {
+			t.Fatalf("ReplaceRecoveryCodes失敗: %v", err)
+		}
+		if count, _ := repo.CountRecoveryCodes(userID); count != 2 {
+			t.Errorf("残数 = %d, want 2", count)
+		}
+
+		if ok, err := repo.UseRecoveryCode(userID, hashToken("a")); err != nil || !ok {
+			t.Fatalf("UseRecoveryCode = %v, %v", ok, err)
+		}
+		if ok, _ := repo.UseRecoveryCode(userID, hashToken("a")); ok {
+			t.Error("使用済みのリカバリーコードが再利用できてしまう")
+		}
+		if ok, _ := repo.UseRecoveryCode(userID, hashToken("unknown")); ok {
+			t.Error("未発行のリカバリーコードが使用できてしまう")
+		}
+		if count, _ := repo.CountRecoveryCodes(userID); count != 1 {
+			t.Errorf("使用後の残数 = %d, want 1", count)
+		}
+
+		_ = repo.ReplaceRecoveryCodes(userID, []string{hashToken("c")})
+		if ok, _ := repo.UseRecoveryCode(userID, hashToken("b")); ok {
+			t.Error("置き換え前のリカバリーコードが使用できてしまう")
+		}
+
+		if err := repo.DeleteTOTP(userID); err != nil {
+			t.Fatalf("DeleteTOTP失敗: %v", err)
+		}
+		if count, _ := repo.CountRecoveryCodes(userID); count != 0 {
+			t.Errorf("TOTP削除後の残数 = %d, want 0", count)
+		}
+	})
+	t.Run("Attempts", func(t *testing.T) {
+		repo, _ := newRepos(t)
+		key := fmt.Sprintf("test:%d", time.Now().UnixNano())
+		expiresAt := time.Now().Add(time.Minute)
+
+		for want := 1; want <= 3; want++ {
+			if count, err := repo.AddMFAAttempts(key, 1, expiresAt); err != nil || count != want {
+				t.Fatalf("AddMFAAttempts = %d, %v, want %d", count, err, want)
+			}
+		}
+		if count, err := repo.AddMFAAttempts(key, 5, expiresAt); err != nil || count != 8 {
+			t.Errorf("AddMFAAttempts（n=5）= %d, %v, want 8", count, err)
+		}
+		if count, _ := repo.AddMFAAttempts(key+":other", 1, expiresAt); count != 1 {
+			t.Errorf("別のキーの回数 = %d, want 1", count)
+		}
+
+		if err := repo.ResetMFAAttempts(key); err != nil {
+			t.Fatalf("ResetMFAAttempts失敗: %v", err)
+		}
+		if count, _ := repo.AddMFAAttempts(key, 1, expiresAt); count != 1 {
+			t.Errorf("リセット後の回数 = %d, want 1", count)
+		}
+
+		// 期限切れの記録は数え直す
+		expired := key + ":expired"
+		_, _ = repo.AddMFAAttempts(expired, 3, time.Now().Add(-time.Second))
+		if count, err := repo.AddMFAAttempts(expired, 1, expiresAt); err != nil || count != 1 {
+			t.Errorf("期限切れ後の回数 = %d, %v, want 1", count, err)
+		}
+	})
+}
@@ -0,0 +1,162 @@
+package services
+
+import (
+	"sync"
+	"time"
+
+	"backend/models"
+)
+
+// MemoryMFARepository メモリ上で動作する二要素認証リポジトリ
+type MemoryMFARepository struct {
+	mu            sync.RWMutex
+	totp          map[int]models.TOTPCredential
+	recoveryCodes map[int]map[string]bool // ユーザーID → コードハッシュ → 使用済みか
+	attempts      map[string]mfaAttempts
+}
+
+// mfaAttempts 試行回数と記録の有効期限
+type mfaAttempts struct {
+	count     int
+	expiresAt time.Time
+}
+
+// NewMemoryMFARepository メモリ二要素認証リポジトリを新規作成
+func NewMemoryMFARepository() *MemoryMFARepository {
+	return &MemoryMFARepository{
+		totp:          make(map[int]models.TOTPCredential),
+		recoveryCodes: make(map[int]map[string]bool),
+		attempts:      make(map[string]mfaAttempts),
+	}
+}
+
+// GetTOTP ユーザーのTOTP登録情報を取得
+func (r *MemoryMFARepository) GetTOTP(userID int) (*models.TOTPCredential, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	credential, ok := r.totp[userID]
+	if !ok {
+		return nil, ErrTOTPNotFound
+	}
+	return &credential, nil
+}
+
+// SaveTOTPSecret 確認待ちのTOTP秘密鍵を保存
+func (r *MemoryMFARepository) SaveTOTPSecret(userID int, secret string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.totp[userID] = models.TOTPCredential{UserID: userID, Secret: secret}
+	return nil
+}
+
+// EnableTOTP 登録を確認済みにする
+func (r *MemoryMFARepository) EnableTOTP(userID int, step int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	credential, ok := r.totp[userID]
+	if !ok {
+		return ErrTOTPNotFound
+	}
+	now := time.Now()
+	credential.EnabledAt = &now
+	credential.LastUsedStep = step
+	r.totp[userID] = credential
+	return nil
+}
+
+// UseTOTPStep 時間ステップを記録
+func (r *MemoryMFARepository) UseTOTPStep(userID int, step int64) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	credential, ok := r.totp[userID]
+	if !ok || credential.LastUsedStep >= step {
+		return false, nil
+	}
+	credential.LastUsedStep = step
+	r.totp[userID] = credential
+	return true, nil
+}
+
+// DeleteTOTP TOTPとリカバリーコードを削除
+func (r *MemoryMFARepository) DeleteTOTP(userID int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.totp, userID)
+	delete(r.recoveryCodes, userID)
+	return nil
+}
+
+// ReplaceRecoveryCodes リカバリーコードを全て置き換え
+func (r *MemoryMFARepository) ReplaceRecoveryCodes(userID int, codeHashes []string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	codes := make(map[string]bool, len(codeHashes))
+	for _, hash := range codeHashes {
+		codes[hash] = false
+	}
+	r.recoveryCodes[userID] = codes
+	return nil
+}
+
+// UseRecoveryCode リカバリーコードを使用済みにする
+func (r *MemoryMFARepository) UseRecoveryCode(userID int, codeHash string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	used, ok := r.recoveryCodes[userID][codeHash]
+	if !ok || used {
+		return false, nil
+	}
+	r.recoveryCodes[userID][codeHash] = true
+	return true, nil
+}
+
+// CountRecoveryCodes 未使用のリカバリーコード数を取得
+func (r *MemoryMFARepository) CountRecoveryCodes(userID int) (int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	count := 0
+	for _, used := range r.recoveryCodes[userID] {
+		if !used {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// AddMFAAttempts キーの試行回数を加算
+func (r *MemoryMFARepository) AddMFAAttempts(key string, n int, expiresAt time.Time) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	for k, entry := range r.attempts {
+		if !now.Before(entry.expiresAt) {
+			delete(r.attempts, k)
+		}
+	}
+
+	entry, ok := r.attempts[key]
+	if !ok {
+		entry.expiresAt = expiresAt
+	}
+	entry.count += n
+	r.attempts[key] = entry
+	return entry.count, nil
+}
+
+// ResetMFAAttempts キーの試行回数の記録を削除
+func (r *MemoryMFARepository) ResetMFAAttempts(key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.attempts, key)
+	return nil
+}
@@ -0,0 +1,235 @@
+package services
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"backend/models"
+)
+
+// PostgresMFARepository PostgreSQLによる二要素認証リポジトリ
+type PostgresMFARepository struct {
+	db *sql.DB
+}
+
+// NewPostgresMFARepository PostgreSQL二要素認証リポジトリを新規作成
+// db が nil の場合、全ての操作は ErrDatabaseUnavailable を返します
+func NewPostgresMFARepository(db *sql.DB) *PostgresMFARepository {
+	return &PostgresMFARepository{db: db}
+}
+
+// GetTOTP ユーザーのTOTP登録情報を取得
+func (r *PostgresMFARepository) GetTOTP(userID int) (*models.TOTPCredential, error) {
+	if r.db == nil {
+		return nil, ErrDatabaseUnavailable
+	}
+
+	credential := models.TOTPCredential{UserID: userID}
+	var enabledAt sql.NullTime
+	err := r.db.QueryRow(`
+		SELECT secret, enabled_at, last_used_step FROM user_totp WHERE user_id = $1
+	`, userID).Scan(&credential.Secret, &enabledAt, &credential.LastUsedStep)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrTOTPNotFound
+		}
+		return nil, fmt.Errorf("failed to get totp: %w", err)
+	}
+	if enabledAt.Valid {
+		credential.EnabledAt = &enabledAt.Time
+	}
+	return &credential, nil
+}
+
+// SaveTOTPSecret 確認待ちのTOTP秘密鍵を保存
+func (r *PostgresMFARepository) SaveTOTPSecret(userID int, secret string) error {
+	if r.db == nil {
+		return ErrDatabaseUnavailable
+	}
+
+	_, err := r.db.Exec(`
+		INSERT INTO user_totp (user_id, secret) VALUES ($1, $2)
+		ON CONFLICT (user_id) DO UPDATE
+		SET secret = EXCLUDED.secret, enabled_at = NULL, last_used_step = 0, created_at = NOW()
+	`, userID, secret)
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return ErrUserNotFound
+		}
+		return fmt.Errorf("failed to save totp secret: %w", err)
+	}
+	return nil
+}
+
+// EnableTOTP 登録を確認済みにする
+func (r *PostgresMFARepository) EnableTOTP(userID int, step int64) error {
+	if r.db == nil {
+		return ErrDatabaseUnavailable
+	}
+
+	result, err := r.db.Exec(`
+		UPDATE user_totp SET enabled_at = NOW(), last_used_step = $2 WHERE user_id = $1
+	`, userID, step)
+	if err != nil {
+		return fmt.Errorf("failed to enable totp: %w", err)
+	}
+	return requireAffected(result, ErrTOTPNotFound)
+}
+
+// UseTOTPStep 時間ステップを記録
+// 条件付き UPDATE のため、同じコードによる同時リクエストは1件だけが成功します
+func (r *PostgresMFARepository) UseTOTPStep(userID int, step int64) (bool, error) {
+	if r.db == nil {
+		return false, ErrDatabaseUnavailable
+	}
+
+	result, err := r.db.Exec(`
+		UPDATE user_totp SET last_used_step = $2 WHERE user_id = $1 AND last_used_step < $2
+	`, userID, step)
+	if err != nil {
+		return false, fmt.Errorf("failed to use totp step: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	return affected > 0, nil
+}
+
+// DeleteTOTP TOTPとリカバリーコードを削除
+func (r *PostgresMFARepository) DeleteTOTP(userID int) error {
+	if r.db == nil {
+		return ErrDatabaseUnavailable
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM mfa_recovery_codes WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("failed to delete recovery codes: %w", err)
+	}
+	if _, err := tx.Exec(`DELETE FROM user_totp WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("failed to delete totp: %w", err)
+	}
+	return tx.Commit()
+}
+
+// ReplaceRecoveryCodes リカバリーコードを全て置き換え
+func (r *PostgresMFARepository) ReplaceRecoveryCodes(userID int, codeHashes []string) error {
+	if r.db == nil {
+		return ErrDatabaseUnavailable
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM mfa_recovery_codes WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("failed to delete recovery codes: %w", err)
+	}
+	for _, hash := range codeHashes {
+		if _, err := tx.Exec(`
+			INSERT INTO mfa_recovery_codes (user_id, code_hash) VALUES ($1, $2)
+		`, userID, hash); err != nil {
+			if isForeignKeyViolation(err) {
+				return ErrUserNotFound
+			}
+			return fmt.Errorf("failed to store recovery code: %w", err)
+		}
+	}
+	return tx.Commit()
+}
+
+// UseRecoveryCode リカバリーコードを使用済みにする
+func (r *PostgresMFARepository) UseRecoveryCode(userID int, codeHash string) (bool, error) {
+	if r.db == nil {
+		return false, ErrDatabaseUnavailable
+	}
+
+	result, err := r.db.Exec(`
+		UPDATE mfa_recovery_codes SET used_at = NOW()
+		WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL
+	`, userID, codeHash)
+	if err != nil {
+		return false, fmt.Errorf("failed to use recovery code: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	return affected > 0, nil
+}
+
+// CountRecoveryCodes 未使用のリカバリーコード数を取得
+func (r *PostgresMFARepository) CountRecoveryCodes(userID int) (int, error) {
+	if r.db == nil {
+		return 0, ErrDatabaseUnavailable
+	}
+
+	var count int
+	err := r.db.QueryRow(`
+		SELECT COUNT(*) FROM mfa_recovery_codes WHERE user_id = $1 AND used_at IS NULL
+	`, userID).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count recovery codes: %w", err)
+	}
+	return count, nil
+}
+
+// AddMFAAttempts キーの試行回数を加算
+// 同時に試行されても回数を取りこぼさないよう、加算と期限切れの判定を1つの文で行います
+func (r *PostgresMFARepository) AddMFAAttempts(key string, n int, expiresAt time.Time) (int, error) {
+	if r.db == nil {
+		return 0, ErrDatabaseUnavailable
+	}
+
+	if _, err := r.db.Exec(`DELETE FROM mfa_attempts WHERE expires_at <= CURRENT_TIMESTAMP AND attempt_key <> $1`, key); err != nil {
+		return 0, fmt.Errorf("failed to delete expired mfa attempts: %w", err)
+	}
+
+	var count int
+	err := r.db.QueryRow(`
+		INSERT INTO mfa_attempts (attempt_key, count, expires_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (attempt_key) DO UPDATE SET
+			count = CASE WHEN mfa_attempts.expires_at <= CURRENT_TIMESTAMP
+				THEN EXCLUDED.count ELSE mfa_attempts.count + EXCLUDED.count END,
+			expires_at = CASE WHEN mfa_attempts.expires_at <= CURRENT_TIMESTAMP
+				THEN EXCLUDED.expires_at ELSE mfa_attempts.expires_at END
+		RETURNING count
+	`, key, n, expiresAt).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to add mfa attempts: %w", err)
+	}
+	return count, nil
+}
+
+// ResetMFAAttempts キーの試行回数の記録を削除
+func (r *PostgresMFARepository) ResetMFAAttempts(key string) error {
+	if r.db == nil {
+		return ErrDatabaseUnavailable
+	}
+
+	if _, err := r.db.Exec(`DELETE FROM mfa_attempts WHERE attempt_key = $1`, key); err != nil {
+		return fmt.Errorf("failed to reset mfa attempts: %w", err)
+	}
+	return nil
+}
+
+// requireAffected 更新件数が0件の場合に notFound を返す
+func requireAffected(result sql.Result, notFound error) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if affected == 0 {
+		return notFound
+	}
+	return nil
+}
@@ -0,0 +1,17 @@
+package services
+
+import "backend/models"
+
+// NotificationPreferenceRepository 通知設定の永続化インターフェース
+//
+// ユーザーが明示的に指定した組み合わせのみ保存し、既定値の適用は NotificationService が行います。
+type NotificationPreferenceRepository interface {
+	// List ユーザーの保存済みの設定を通知の種類・チャネル順に返す
+	List(userID int) ([]models.NotificationPreference, error)
+	// GetSettings ユーザーの保存済みの通知の種類によらない設定を返す（保存されていなければ nil）
+	GetSettings(userID int) (*models.NotificationSettings, error)
+	// Replace ユーザーの設定と通知の種類によらない設定を不可分に全て置き換える（ユーザーが存在しなければ ErrUserNotFound）
+	Replace(userID int, preferences []models.NotificationPreference, settings models.NotificationSettings) error
+	// ListForType 指定したユーザーそれぞれの、通知の種類に対する保存済みの設定を返す
+	ListForType(notificationType models.NotificationType, userIDs []int) ([]models.NotificationPreference, error)
+}
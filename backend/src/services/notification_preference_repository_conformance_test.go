@@ -0,0 +1,156 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"backend/models"
+)
+
+// TestMemoryNotificationPreferenceRepositoryConformance メモリ通知設定リポジトリの適合テスト
+func TestMemoryNotificationPreferenceRepositoryConformance(t *testing.T) {
+	runNotificationPreferenceRepositoryConformance(t, func(t *testing.T) (NotificationPreferenceRepository, UserRepository) {
+		users := NewMemoryUserRepository()
+		return NewMemoryNotificationPreferenceRepository(users), users
+	})
+}
+
+// TestPostgresNotificationPreferenceRepositoryConformance PostgreSQL通知設定リポジトリの適合テスト
+func TestPostgresNotificationPreferenceRepositoryConformance(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	runNotificationPreferenceRepositoryConformance(t, func(t *testing.T) (NotificationPreferenceRepository, UserRepository) {
+		return NewPostgresNotificationPreferenceRepository(db), NewPostgresUserRepository(db)
+	})
+}
+
+// runNotificationPreferenceRepositoryConformance 全てのNotificationPreferenceRepository実装が満たすべき振る舞い
+// 共有DBでも動作するよう、ユーザーは都度作成します
+func runNotificationPreferenceRepositoryConformance(t *testing.T, newRepos func(t *testing.T) (NotificationPreferenceRepository, UserRepository)) {
+	createUser := func(t *testing.T, users UserRepository) int {
+		t.Helper()
+		user, err := users.Create(fmt.Sprintf("prefs%d@example.com", time.Now().UnixNano()), "Prefs", "hash")
+		if err != nil {
+			t.Fatalf("ユーザー作成失敗: %v", err)
+		}
+		return user.ID
+	}
+	preference := func(userID int, notificationType models.NotificationType, channel models.NotificationChannel, enabled bool) models.NotificationPreference {
+		return models.NotificationPreference{UserID: userID, Type: notificationType, Channel: channel, Enabled: enabled}
+	}
+
+	t.Run("ReplaceAndList", func(t *testing.T) {
+		repo, users := newRepos(t)
+		alice := createUser(t, users)
+
+		if stored, err := repo.List(alice); err != nil || len(stored) != 0 {
+			t.Fatalf("未設定のユーザーの設定が空でない: %+v, %v", stored, err)
+		}
+
+		err := repo.Replace(alice, []models.NotificationPreference{
+			preference(0, models.NotificationTypeMailReceived, models.NotificationChannelEmail, false),
+			preference(0, models.NotificationTypeCustomerCreated, models.NotificationChannelInApp, false),
+			preference(0, models.NotificationTypeCustomerCreated, models.NotificationChannelEmail, true),
+		}, models.DefaultNotificationSettings())
+		if err != nil {
+			t.Fatalf("Replace失敗: %v", err)
+		}
+		want := []models.NotificationPreference{
+			preference(alice, models.NotificationTypeCustomerCreated, models.NotificationChannelEmail, true),
+			preference(alice, models.NotificationTypeCustomerCreated, models.NotificationChannelInApp, false),
+			preference(alice, models.NotificationTypeMailReceived, models.NotificationChannelEmail, false),
+		}
+		if stored, err := repo.List(alice); err != nil || !reflect.DeepEqual(stored, want) {
+			t.Errorf("List = %+v, %v; want %+v", stored, err, want)
+		}
+
+		// 置き換えで含まれない組み合わせは削除する
+		if err := repo.Replace(alice, []models.NotificationPreference{
+			preference(alice, models.NotificationTypeMailReceived, models.NotificationChannelInApp, false),
+		}, models.DefaultNotificationSettings()); err != nil {
+			t.Fatalf("Replace失敗: %v", err)
+		}
+		want = []models.NotificationPreference{preference(alice, models.NotificationTypeMailReceived, models.NotificationChannelInApp, false)}
+		if stored, err := repo.List(alice); err != nil || !reflect.DeepEqual(stored, want) {
+			t.Errorf("置き換え後の List = %+v, %v; want %+v", stored, err, want)
+		}
+
+		if err := repo.Replace(alice, nil, models.DefaultNotificationSettings()); err != nil {
+			t.Fatalf("Replace失敗: %v", err)
+		}
+		if stored, err := repo.List(alice); err != nil || len(stored) != 0 {
+			t.Errorf("空の置き換え後に設定が残る: %+v, %v", stored, err)
+		}
+	})
+
+	t.Run("Settings", func(t *testing.T) {
+		repo, users := newRepos(t)
+		alice := createUser(t, users)
+
+		if settings, err := repo.GetSettings(alice); err != nil || settings != nil {
+			t.Fatalf("未設定のユーザーの設定が nil でない: %+v, %v", settings, err)
+		}
+
+		want := models.NotificationSettings{WeeklyDigest: true, ProductUpdates: false, ImportantUpdates: true}
+		if err := repo.Replace(alice, nil, want); err != nil {
+			t.Fatalf("Replace失敗: %v", err)
+		}
+		if settings, err := repo.GetSettings(alice); err != nil || settings == nil || *settings != want {
+			t.Errorf("GetSettings = %+v, %v; want %+v", settings, err, want)
+		}
+
+		// 置き換えると全ての項目を更新する
+		want = models.NotificationSettings{ImportantUpdates: false}
+		if err := repo.Replace(alice, nil, want); err != nil {
+			t.Fatalf("Replace失敗: %v", err)
+		}
+		if settings, err := repo.GetSettings(alice); err != nil || settings == nil || *settings != want {
+			t.Errorf("置き換え後の GetSettings = %+v, %v; want %+v", settings, err, want)
+		}
+	})
+
+	t.Run("ListForType", func(t *testing.T) {
+		repo, users := newRepos(t)
+		alice, bob, carol := createUser(t, users), createUser(t, users), createUser(t, users)
+
+		if err := repo.Replace(alice, []models.NotificationPreference{
+			preference(alice, models.NotificationTypeMailReceived, models.NotificationChannelEmail, false),
+			preference(alice, models.NotificationTypeCustomerCreated, models.NotificationChannelEmail, true),
+		}, models.DefaultNotificationSettings()); err != nil {
+			t.Fatalf("Replace失敗: %v", err)
+		}
+		if err := repo.Replace(bob, []models.NotificationPreference{
+			preference(bob, models.NotificationTypeMailReceived, models.NotificationChannelInApp, false),
+		}, models.DefaultNotificationSettings()); err != nil {
+			t.Fatalf("Replace失敗: %v", err)
+		}
+
+		// 指定したユーザー・通知の種類の設定のみ返す
+		got, err := repo.ListForType(models.NotificationTypeMailReceived, []int{alice, carol})
+		want := []models.NotificationPreference{preference(alice, models.NotificationTypeMailReceived, models.NotificationChannelEmail, false)}
+		if err != nil || !reflect.DeepEqual(got, want) {
+			t.Errorf("ListForType = %+v, %v; want %+v", got, err, want)
+		}
+		if got, err := repo.ListForType(models.NotificationTypeMailReceived, []int{alice, bob}); err != nil || len(got) != 2 {
+			t.Errorf("ListForType(alice, bob) = %+v, %v", got, err)
+		}
+		if got, err := repo.ListForType(models.NotificationTypeCustomerCreated, []int{bob, carol}); err != nil || len(got) != 0 {
+			t.Errorf("設定のないユーザーの ListForType = %+v, %v", got, err)
+		}
+	})
+
+	t.Run("UnknownUser", func(t *testing.T) {
+		repo, _ := newRepos(t)
+
+		err := repo.Replace(2147483000, []models.NotificationPreference{
+			preference(0, models.NotificationTypeMailReceived, models.NotificationChannelEmail, false),
+		}, models.DefaultNotificationSettings())
+		if !errors.Is(err, ErrUserNotFound) {
+			t.Errorf("ErrUserNotFoundが返らない: %v", err)
+		}
+	})
+}
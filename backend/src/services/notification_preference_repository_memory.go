@@ -0,0 +1,90 @@
+package services
+
+import (
+	"cmp"
+	"slices"
+	"sync"
+
+	"backend/models"
+)
+
+// MemoryNotificationPreferenceRepository メモリ上で動作する通知設定リポジトリ
+type MemoryNotificationPreferenceRepository struct {
+	mu          sync.RWMutex
+	users       UserRepository
+	preferences map[int][]models.NotificationPreference // ユーザーIDごとの保存済みの設定
+	settings    map[int]models.NotificationSettings     // ユーザーIDごとの通知の種類によらない設定
+}
+
+// NewMemoryNotificationPreferenceRepository メモリ通知設定リポジトリを新規作成
+// ユーザーの存在は users で確認します
+func NewMemoryNotificationPreferenceRepository(users UserRepository) *MemoryNotificationPreferenceRepository {
+	return &MemoryNotificationPreferenceRepository{
+		users:       users,
+		preferences: make(map[int][]models.NotificationPreference),
+		settings:    make(map[int]models.NotificationSettings),
+	}
+}
+
+// List ユーザーの保存済みの設定を取得
+func (r *MemoryNotificationPreferenceRepository) List(userID int) ([]models.NotificationPreference, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return append([]models.NotificationPreference{}, r.preferences[userID]...), nil
+}
+
+// GetSettings ユーザーの保存済みの通知の種類によらない設定を取得
+func (r *MemoryNotificationPreferenceRepository) GetSettings(userID int) (*models.NotificationSettings, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	settings, ok := r.settings[userID]
+	if !ok {
+		return nil, nil
+	}
+	return &settings, nil
+}
+
+// Replace ユーザーの設定を全て置き換える
+func (r *MemoryNotificationPreferenceRepository) Replace(userID int, preferences []models.NotificationPreference, settings models.NotificationSettings) error {
+	if _, err := r.users.FindByID(userID); err != nil {
+		return err
+	}
+
+	stored := make([]models.NotificationPreference, 0, len(preferences))
+	for _, preference := range preferences {
+		preference.UserID = userID
+		stored = append(stored, preference)
+	}
+	slices.SortFunc(stored, compareNotificationPreferences)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.preferences[userID] = stored
+	r.settings[userID] = settings
+	return nil
+}
+
+// ListForType 指定したユーザーそれぞれの、通知の種類に対する保存済みの設定を取得
+func (r *MemoryNotificationPreferenceRepository) ListForType(notificationType models.NotificationType, userIDs []int) ([]models.NotificationPreference, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	preferences := []models.NotificationPreference{}
+	for _, userID := range userIDs {
+		for _, preference := range r.preferences[userID] {
+			if preference.Type == notificationType {
+				preferences = append(preferences, preference)
+			}
+		}
+	}
+	return preferences, nil
+}
+
+// compareNotificationPreferences 通知の種類・チャネル順に並べるための比較関数
+func compareNotificationPreferences(a, b models.NotificationPreference) int {
+	if c := cmp.Compare(a.Type, b.Type); c != 0 {
+		return c
+	}
+	return cmp.Compare(a.Channel, b.Channel)
+}
@@ -0,0 +1,146 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+
+	"backend/models"
+)
+
+// PostgresNotificationPreferenceRepository PostgreSQLによる通知設定リポジトリ
+type PostgresNotificationPreferenceRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresNotificationPreferenceRepository PostgreSQL通知設定リポジトリを新規作成
+// db が nil の場合、全ての操作は ErrDatabaseUnavailable を返します
+func NewPostgresNotificationPreferenceRepository(db *sql.DB) *PostgresNotificationPreferenceRepository {
+	return &PostgresNotificationPreferenceRepository{db: db}
+}
+
+// List ユーザーの保存済みの設定を取得
+func (r *PostgresNotificationPreferenceRepository) List(userID int) ([]models.NotificationPreference, error) {
+	if r.db == nil {
+		return nil, ErrDatabaseUnavailable
+	}
+
+	rows, err := r.db.Query(`
+		SELECT user_id, type, channel, enabled FROM notification_preferences
+		WHERE user_id = $1
+		ORDER BY type, channel`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query notification preferences: %w", err)
+	}
+	return scanNotificationPreferences(rows)
+}
+
+// GetSettings ユーザーの保存済みの通知の種類によらない設定を取得
+func (r *PostgresNotificationPreferenceRepository) GetSettings(userID int) (*models.NotificationSettings, error) {
+	if r.db == nil {
+		return nil, ErrDatabaseUnavailable
+	}
+
+	var settings models.NotificationSettings
+	err := r.db.QueryRow(`
+		SELECT weekly_digest, product_updates, important_updates FROM notification_settings
+		WHERE user_id = $1`, userID).Scan(&settings.WeeklyDigest, &settings.ProductUpdates, &settings.ImportantUpdates)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get notification settings: %w", err)
+	}
+	return &settings, nil
+}
+
+// Replace ユーザーの設定と通知の種類によらない設定を1トランザクションで全て置き換える
+func (r *PostgresNotificationPreferenceRepository) Replace(userID int, preferences []models.NotificationPreference, settings models.NotificationSettings) error {
+	if r.db == nil {
+		return ErrDatabaseUnavailable
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// 同じユーザーの同時更新を直列化し、ユーザーの存在を確認する
+	var id int
+	if err := tx.QueryRow(`SELECT id FROM users WHERE id = $1 FOR UPDATE`, userID).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrUserNotFound
+		}
+		return fmt.Errorf("failed to lock user: %w", err)
+	}
+
+	if _, err := tx.Exec(`DELETE FROM notification_preferences WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("failed to delete notification preferences: %w", err)
+	}
+	for _, preference := range preferences {
+		if _, err := tx.Exec(`
+			INSERT INTO notification_preferences (user_id, type, channel, enabled)
+			VALUES ($1, $2, $3, $4)`,
+			userID, preference.Type, preference.Channel, preference.Enabled); err != nil {
+			return fmt.Errorf("failed to save notification preference: %w", err)
+		}
+	}
+	_, err = tx.Exec(`
+		INSERT INTO notification_settings (user_id, weekly_digest, product_updates, important_updates)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (user_id) DO UPDATE SET
+			weekly_digest = EXCLUDED.weekly_digest,
+			product_updates = EXCLUDED.product_updates,
+			important_updates = EXCLUDED.important_updates,
+			updated_at = CURRENT_TIMESTAMP`,
+		userID, settings.WeeklyDigest, settings.ProductUpdates, settings.ImportantUpdates)
+	if err != nil {
+		return fmt.Errorf("failed to save notification settings: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit notification preferences: %w", err)
+	}
+	return nil
+}
+
+// ListForType 指定したユーザーそれぞれの、通知の種類に対する保存済みの設定を取得
+func (r *PostgresNotificationPreferenceRepository) ListForType(notificationType models.NotificationType, userIDs []int) ([]models.NotificationPreference, error) {
+	if r.db == nil {
+		return nil, ErrDatabaseUnavailable
+	}
+
+	ids := make([]int64, len(userIDs))
+	for i, id := range userIDs {
+		ids[i] = int64(id)
+	}
+	rows, err := r.db.Query(`
+		SELECT user_id, type, channel, enabled FROM notification_preferences
+		WHERE type = $1 AND user_id = ANY($2)
+		ORDER BY user_id, channel`, notificationType, pq.Array(ids))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query notification preferences: %w", err)
+	}
+	return scanNotificationPreferences(rows)
+}
+
+// scanNotificationPreferences 通知設定の行を読み取り、rows を閉じる
+func scanNotificationPreferences(rows *sql.Rows) ([]models.NotificationPreference, error) {
+	defer rows.Close()
+
+	preferences := []models.NotificationPreference{}
+	for rows.Next() {
+		var preference models.NotificationPreference
+		if err := rows.Scan(&preference.UserID, &preference.Type, &preference.Channel, &preference.Enabled); err != nil {
+			return nil, fmt.Errorf("failed to scan notification preference: %w", err)
+		}
+		preferences = append(preferences, preference)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate notification preferences: %w", err)
+	}
+	return preferences, nil
+}
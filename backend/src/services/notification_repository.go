@@ -0,0 +1,22 @@
+package services
+
+import "backend/models"
+
+// ErrNotificationNotFound 指定された通知が存在しない（または他のユーザー宛て）
+var ErrNotificationNotFound = newError(ErrNotFound, "notification not found", "notification.not_found")
+
+// NotificationRepository 通知の永続化インターフェース
+//
+// 通知は宛先ユーザー（UserID）ごとに保存し、取得・更新は宛先ユーザーの通知に限定します。
+type NotificationRepository interface {
+	// CreateBatch 通知をまとめて保存し、採番されたIDと作成日時を含めて返す
+	CreateBatch(notifications []models.Notification) ([]models.Notification, error)
+	// List ユーザー宛ての通知をページ単位で返す（unreadOnly なら未読のみ）
+	List(userID int, unreadOnly bool, spec *QuerySpec) (*Page[models.Notification], error)
+	// MarkRead 通知を既読にする（既読済みの場合は最初の既読日時を維持）
+	MarkRead(userID, id int) (*models.Notification, error)
+	// MarkAllRead ユーザー宛ての未読通知を全て既読にし、更新件数を返す
+	MarkAllRead(userID int) (int, error)
+	// CountUnread ユーザー宛ての未読通知件数を返す
+	CountUnread(userID int) (int, error)
+}
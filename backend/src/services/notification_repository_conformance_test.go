@@ -0,0 +1,161 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"testing"
+	"time"
+
+	"backend/models"
+)
+
+// TestMemoryNotificationRepositoryConformance メモリ通知リポジトリの適合テスト
+func TestMemoryNotificationRepositoryConformance(t *testing.T) {
+	runNotificationRepositoryConformance(t, func(t *testing.T) (NotificationRepository, UserRepository) {
+		users := NewMemoryUserRepository()
+		return NewMemoryNotificationRepository(users), users
+	})
+}
+
+// TestPostgresNotificationRepositoryConformance PostgreSQL通知リポジトリの適合テスト
+func TestPostgresNotificationRepositoryConformance(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	runNotificationRepositoryConformance(t, func(t *testing.T) (NotificationRepository, UserRepository) {
+		return NewPostgresNotificationRepository(db), NewPostgresUserRepository(db)
+	})
+}
+
+// runNotificationRepositoryConformance 全てのNotificationRepository実装が満たすべき振る舞い
+// 共有DBでも動作するよう、宛先ユーザーは都度作成します
+func runNotificationRepositoryConformance(t *testing.T, newRepos func(t *testing.T) (NotificationRepository, UserRepository)) {
+	createUser := func(t *testing.T, users UserRepository) int {
+		t.Helper()
+		user, err := users.Create(fmt.Sprintf("notify%d@example.com", time.Now().UnixNano()), "Notify", "hash")
+		if err != nil {
+			t.Fatalf("ユーザー作成失敗: %v", err)
+		}
+		return user.ID
+	}
+	newNotification := func(userID int, notificationType models.NotificationType, body string) models.Notification {
+		return models.Notification{
+			UserID: userID,
+			Type:   notificationType,
+			Sender: models.NotificationSender{Name: "Jordan Brown", Email: "jordan.brown@example.com"},
+			Body:   body,
+			Link:   "/inbox",
+		}
+	}
+	parseSpec := func(t *testing.T, query string) *QuerySpec {
+		t.Helper()
+		values, _ := url.ParseQuery(query)
+		spec, err := NotificationQuerySchema.Parse(values)
+		if err != nil {
+			t.Fatalf("Parse(%q) error = %v", query, err)
+		}
+		return spec
+	}
+
+	t.Run("CreateBatchAndList", func(t *testing.T) {
+		repo, users := newRepos(t)
+		alice, bob := createUser(t, users), createUser(t, users)
+
+		first := newNotification(alice, models.NotificationTypeMailReceived, "first")
+		first.Sender.Avatar = &models.Avatar{Src: "https://i.pravatar.cc/128?u=2"}
+		created, err := repo.CreateBatch([]models.Notification{first, newNotification(bob, models.NotificationTypeMailReceived, "first")})
+		if err != nil {
+			t.Fatalf("CreateBatch失敗: %v", err)
+		}
+		if len(created) != 2 || created[0].ID == 0 || !created[0].Unread || created[0].ReadAt != nil || created[0].Date.IsZero() {
+			t.Fatalf("作成結果が不正: %+v", created)
+		}
+		if _, err := repo.CreateBatch([]models.Notification{newNotification(alice, models.NotificationTypeCustomerCreated, "second")}); err != nil {
+			t.Fatalf("CreateBatch失敗: %v", err)
+		}
+
+		page, err := repo.List(alice, false, parseSpec(t, ""))
+		if err != nil {
+			t.Fatalf("List失敗: %v", err)
+		}
+		if page.Total != 2 || page.Items[0].Body != "second" || page.Items[1].Body != "first" {
+			t.Fatalf("Expected alice's notifications newest first, got %+v", page.Items)
+		}
+		if page.Items[1].Sender.Avatar == nil || page.Items[1].Link != "/inbox" || page.Items[1].UserID != alice {
+			t.Errorf("取得結果が不正: %+v", page.Items[1])
+		}
+
+		page, err = repo.List(alice, false, parseSpec(t, "type=customer.created"))
+		if err != nil || page.Total != 1 || page.Items[0].Type != models.NotificationTypeCustomerCreated {
+			t.Errorf("type フィルターが不正: %+v, %v", page, err)
+		}
+
+		if _, err := repo.CreateBatch([]models.Notification{newNotification(2147483000, models.NotificationTypeMailReceived, "x")}); !errors.Is(err, ErrUserNotFound) {
+			t.Errorf("Expected ErrUserNotFound for unknown recipient, got %v", err)
+		}
+	})
+
+	t.Run("MarkRead", func(t *testing.T) {
+		repo, users := newRepos(t)
+		alice, bob := createUser(t, users), createUser(t, users)
+		created, err := repo.CreateBatch([]models.Notification{
+			newNotification(alice, models.NotificationTypeMailReceived, "one"),
+			newNotification(alice, models.NotificationTypeMailReceived, "two"),
+		})
+		if err != nil {
+			t.Fatalf("CreateBatch失敗: %v", err)
+		}
+
+		// 他のユーザー宛ての通知は存在しない扱い
+		if _, err := repo.MarkRead(bob, created[0].ID); !errors.Is(err, ErrNotificationNotFound) {
+			t.Errorf("Expected ErrNotificationNotFound, got %v", err)
+		}
+
+		read, err := repo.MarkRead(alice, created[0].ID)
+		if err != nil {
+			t.Fatalf("MarkRead失敗: %v", err)
+		}
+		if read.Unread || read.ReadAt == nil {
+			t.Errorf("既読になっていない: %+v", read)
+		}
+		again, err := repo.MarkRead(alice, created[0].ID)
+		if err != nil || !again.ReadAt.Equal(*read.ReadAt) {
+			t.Errorf("再度の既読で既読日時が変わった: %+v, %v", again, err)
+		}
+
+		if count, _ := repo.CountUnread(alice); count != 1 {
+			t.Errorf("CountUnread = %d, want 1", count)
+		}
+		page, err := repo.List(alice, true, parseSpec(t, ""))
+		if err != nil || page.Total != 1 || page.Items[0].ID != created[1].ID {
+			t.Errorf("未読のみの一覧が不正: %+v, %v", page, err)
+		}
+	})
+
+	t.Run("MarkAllRead", func(t *testing.T) {
+		repo, users := newRepos(t)
+		alice, bob := createUser(t, users), createUser(t, users)
+		if _, err := repo.CreateBatch([]models.Notification{
+			newNotification(alice, models.NotificationTypeMailReceived, "one"),
+			newNotification(alice, models.NotificationTypeMailReceived, "two"),
+			newNotification(bob, models.NotificationTypeMailReceived, "one"),
+		}); err != nil {
+			t.Fatalf("CreateBatch失敗: %v", err)
+		}
+
+		updated, err := repo.MarkAllRead(alice)
+		if err != nil || updated != 2 {
+			t.Fatalf("MarkAllRead = %d, %v; want 2", updated, err)
+		}
+		if updated, _ := repo.MarkAllRead(alice); updated != 0 {
+			t.Errorf("既読済みの再更新件数 = %d, want 0", updated)
+		}
+		if count, _ := repo.CountUnread(alice); count != 0 {
+			t.Errorf("alice の未読件数 = %d, want 0", count)
+		}
+		if count, _ := repo.CountUnread(bob); count != 1 {
+			t.Errorf("bob の未読件数 = %d, want 1", count)
+		}
+	})
+}
@@ -0,0 +1,147 @@
+package services
+
+import (
+	"sync"
+	"time"
+
+	"backend/models"
+)
+
+// MemoryNotificationRepository メモリ上で動作する通知リポジトリ
+type MemoryNotificationRepository struct {
+	mu            sync.RWMutex
+	users         UserRepository
+	notifications map[int]models.Notification
+	nextID        int
+}
+
+// NewMemoryNotificationRepository メモリ通知リポジトリを新規作成
+// 宛先ユーザーの存在は users で確認します
+func NewMemoryNotificationRepository(users UserRepository) *MemoryNotificationRepository {
+	return &MemoryNotificationRepository{
+		users:         users,
+		notifications: make(map[int]models.Notification),
+		nextID:        1,
+	}
+}
+
+// CreateBatch 通知をまとめて保存（宛先ユーザーが1人でも存在しなければ何も保存しない）
+func (r *MemoryNotificationRepository) CreateBatch(notifications []models.Notification) ([]models.Notification, error) {
+	for _, notification := range notifications {
+		if _, err := r.users.FindByID(notification.UserID); err != nil {
+			return nil, err
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	created := make([]models.Notification, 0, len(notifications))
+	for _, notification := range notifications {
+		stored := copyNotification(notification)
+		stored.ID = r.nextID
+		stored.Unread = true
+		stored.ReadAt = nil
+		stored.Date = now
+		r.notifications[stored.ID] = stored
+		r.nextID++
+		created = append(created, copyNotification(stored))
+	}
+	return created, nil
+}
+
+// List ユーザー宛ての通知をページ単位で取得
+func (r *MemoryNotificationRepository) List(userID int, unreadOnly bool, spec *QuerySpec) (*Page[models.Notification], error) {
+	r.mu.RLock()
+	notifications := []models.Notification{}
+	for _, notification := range r.notifications {
+		if notification.UserID != userID || (unreadOnly && !notification.Unread) {
+			continue
+		}
+		notifications = append(notifications, copyNotification(notification))
+	}
+	r.mu.RUnlock()
+
+	return applyQuerySpec(notifications, spec, notificationFields, notificationCursor), nil
+}
+
+// MarkRead 通知を既読にする
+func (r *MemoryNotificationRepository) MarkRead(userID, id int) (*models.Notification, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	notification, ok := r.notifications[id]
+	if !ok || notification.UserID != userID {
+		return nil, ErrNotificationNotFound
+	}
+	if notification.ReadAt == nil {
+		now := time.Now()
+		notification.ReadAt = &now
+		notification.Unread = false
+		r.notifications[id] = notification
+	}
+	result := copyNotification(notification)
+	return &result, nil
+}
+
+// MarkAllRead ユーザー宛ての未読通知を全て既読にする
+func (r *MemoryNotificationRepository) MarkAllRead(userID int) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	updated := 0
+	for id, notification := range r.notifications {
+		if notification.UserID != userID || notification.ReadAt != nil {
+			continue
+		}
+		readAt := now
+		notification.ReadAt = &readAt
+		notification.Unread = false
+		r.notifications[id] = notification
+		updated++
+	}
+	return updated, nil
+}
+
+// CountUnread ユーザー宛ての未読通知件数を取得
+func (r *MemoryNotificationRepository) CountUnread(userID int) (int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	count := 0
+	for _, notification := range r.notifications {
+		if notification.UserID == userID && notification.ReadAt == nil {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// copyNotification 呼び出し側の変更が保存データに影響しないよう通知を複製
+func copyNotification(notification models.Notification) models.Notification {
+	if notification.Sender.Avatar != nil {
+		avatar := *notification.Sender.Avatar
+		notification.Sender.Avatar = &avatar
+	}
+	if notification.ReadAt != nil {
+		readAt := *notification.ReadAt
+		notification.ReadAt = &readAt
+	}
+	return notification
+}
+
+// notificationFields QuerySpec評価用のフィールド値
+func notificationFields(notification models.Notification) map[string]interface{} {
+	return map[string]interface{}{
+		"id":         notification.ID,
+		"type":       string(notification.Type),
+		"created_at": notification.Date,
+	}
+}
+
+// notificationCursor 通知の位置を表すカーソル
+func notificationCursor(notification models.Notification) Cursor {
+	return Cursor{CreatedAt: notification.Date, ID: notification.ID}
+}
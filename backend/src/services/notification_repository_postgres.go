@@ -0,0 +1,187 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"backend/models"
+)
+
+// notificationColumns 通知取得時の列
+const notificationColumns = `n.id, n.user_id, n.type, n.sender_name, n.sender_email, n.sender_avatar_url,
+	n.body, n.link, n.read_at, n.created_at`
+
+// PostgresNotificationRepository PostgreSQLによる通知リポジトリ
+type PostgresNotificationRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresNotificationRepository PostgreSQL通知リポジトリを新規作成
+// db が nil の場合、全ての操作は ErrDatabaseUnavailable を返します
+func NewPostgresNotificationRepository(db *sql.DB) *PostgresNotificationRepository {
+	return &PostgresNotificationRepository{db: db}
+}
+
+// CreateBatch 通知を1トランザクションでまとめて保存
+func (r *PostgresNotificationRepository) CreateBatch(notifications []models.Notification) ([]models.Notification, error) {
+	if r.db == nil {
+		return nil, ErrDatabaseUnavailable
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare(`
+		INSERT INTO notifications AS n (user_id, type, sender_name, sender_email, sender_avatar_url, body, link)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING ` + notificationColumns)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare notification insert: %w", err)
+	}
+	defer stmt.Close()
+
+	created := make([]models.Notification, 0, len(notifications))
+	for _, notification := range notifications {
+		saved, err := scanNotification(stmt.QueryRow(notification.UserID, notification.Type,
+			notification.Sender.Name, notification.Sender.Email, avatarURL(notification.Sender.Avatar),
+			notification.Body, notification.Link))
+		if err != nil {
+			if isForeignKeyViolation(err) {
+				return nil, ErrUserNotFound
+			}
+			return nil, fmt.Errorf("failed to create notification: %w", err)
+		}
+		created = append(created, *saved)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit notifications: %w", err)
+	}
+	return created, nil
+}
+
+// List ユーザー宛ての通知をページ単位で取得
+func (r *PostgresNotificationRepository) List(userID int, unreadOnly bool, spec *QuerySpec) (*Page[models.Notification], error) {
+	if r.db == nil {
+		return nil, ErrDatabaseUnavailable
+	}
+
+	// $1 は宛先ユーザーID
+	where := func(args *[]interface{}, withCursor bool) string {
+		clause := " WHERE n.user_id = $1"
+		if unreadOnly {
+			clause += " AND n.read_at IS NULL"
+		}
+		if conditions := spec.WhereClause(args, withCursor); conditions != "" {
+			clause += " AND " + strings.TrimPrefix(conditions, " WHERE ")
+		}
+		return clause
+	}
+
+	countArgs := []interface{}{userID}
+	var total int
+	if err := r.db.QueryRow(`SELECT COUNT(*) FROM notifications n`+where(&countArgs, false), countArgs...).Scan(&total); err != nil {
+		return nil, fmt.Errorf("failed to count notifications: %w", err)
+	}
+
+	args := []interface{}{userID}
+	query := `SELECT ` + notificationColumns + ` FROM notifications n` + where(&args, true) + spec.OrderClause()
+
+	// 次ページの有無を判定するため1件多く取得
+	page := spec.Page
+	args = append(args, page.Limit+1, page.Offset)
+	query += fmt.Sprintf(` LIMIT $%d OFFSET $%d`, len(args)-1, len(args))
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query notifications: %w", err)
+	}
+	defer rows.Close()
+
+	notifications := []models.Notification{}
+	for rows.Next() {
+		notification, err := scanNotification(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan notification: %w", err)
+		}
+		notifications = append(notifications, *notification)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating notifications: %w", err)
+	}
+	return newPage(spec, notifications, total, notificationCursor), nil
+}
+
+// MarkRead 通知を既読にする
+func (r *PostgresNotificationRepository) MarkRead(userID, id int) (*models.Notification, error) {
+	if r.db == nil {
+		return nil, ErrDatabaseUnavailable
+	}
+
+	notification, err := scanNotification(r.db.QueryRow(`
+		UPDATE notifications AS n SET read_at = COALESCE(n.read_at, NOW())
+		WHERE n.id = $1 AND n.user_id = $2
+		RETURNING `+notificationColumns, id, userID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotificationNotFound
+		}
+		return nil, fmt.Errorf("failed to mark notification as read: %w", err)
+	}
+	return notification, nil
+}
+
+// MarkAllRead ユーザー宛ての未読通知を全て既読にする
+func (r *PostgresNotificationRepository) MarkAllRead(userID int) (int, error) {
+	if r.db == nil {
+		return 0, ErrDatabaseUnavailable
+	}
+
+	result, err := r.db.Exec(`UPDATE notifications SET read_at = NOW() WHERE user_id = $1 AND read_at IS NULL`, userID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to mark notifications as read: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	return int(affected), nil
+}
+
+// CountUnread ユーザー宛ての未読通知件数を取得
+func (r *PostgresNotificationRepository) CountUnread(userID int) (int, error) {
+	if r.db == nil {
+		return 0, ErrDatabaseUnavailable
+	}
+
+	var count int
+	if err := r.db.QueryRow(`SELECT COUNT(*) FROM notifications WHERE user_id = $1 AND read_at IS NULL`, userID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count unread notifications: %w", err)
+	}
+	return count, nil
+}
+
+// scanNotification 1行を通知に変換（空の sender_avatar_url はアバターなし）
+func scanNotification(row rowScanner) (*models.Notification, error) {
+	var notification models.Notification
+	var avatar string
+	var readAt sql.NullTime
+	err := row.Scan(&notification.ID, &notification.UserID, &notification.Type, &notification.Sender.Name,
+		&notification.Sender.Email, &avatar, &notification.Body, &notification.Link, &readAt, &notification.Date)
+	if err != nil {
+		return nil, err
+	}
+	if avatar != "" {
+		notification.Sender.Avatar = &models.Avatar{Src: avatar}
+	}
+	if readAt.Valid {
+		notification.ReadAt = &readAt.Time
+	}
+	notification.Unread = notification.ReadAt == nil
+	return &notification, nil
+}
@@ -0,0 +1,342 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"sync"
+
+	"backend/models"
+	"backend/utils"
+)
+
+// NotificationQuerySchema 通知一覧の検索・ソート定義
+var NotificationQuerySchema = &QuerySchema{
+	Filters: map[string]FilterDef{
+		"type":           {Field: "type", Op: FilterEq, Type: FieldString},
+		"created_after":  {Field: "created_at", Op: FilterGt, Type: FieldTime},
+		"created_before": {Field: "created_at", Op: FilterLt, Type: FieldTime},
+	},
+	Columns: map[string]string{
+		"id":         "n.id",
+		"type":       "n.type",
+		"created_at": "n.created_at",
+	},
+	Sortable:    []string{"id", "created_at"},
+	DefaultSort: []SortField{{Field: "created_at", Desc: true}},
+}
+
+// NotificationEvent 通知の元になるドメインイベント（宛先ユーザーごとの通知として保存）
+type NotificationEvent struct {
+	Type   models.NotificationType
+	Sender models.NotificationSender
+	Body   string
+	Link   string // ダッシュボード内のリンク先（任意）
+}
+
+// Notifier 通知の発行インターフェース
+//
+// 顧客の登録やメールの受信などのドメインイベントを通知に変換するサービスから呼び出します。
+type Notifier interface {
+	// Notify 指定したユーザーそれぞれに通知を作成する
+	Notify(event *NotificationEvent, recipientIDs ...int) error
+	// NotifyAll 全ユーザーに通知を作成する
+	NotifyAll(event *NotificationEvent) error
+}
+
+// NotificationEmailConfig 通知メールの送信設定
+type NotificationEmailConfig struct {
+	Mailer  Mailer // nil の場合はメールで通知しない
+	BaseURL string // 通知のリンク先・通知設定画面のURLの基準（ダッシュボードのURL）
+}
+
+// notificationEmail 送信待ちの通知メール（done のみ設定されたものは Flush の目印）
+type notificationEmail struct {
+	event   NotificationEvent
+	userIDs []int
+	done    chan struct{}
+}
+
+// NotificationService 通知サービス構造体
+type NotificationService struct {
+	repo        NotificationRepository
+	preferences NotificationPreferenceRepository
+	users       UserRepository
+	events      EventPublisher // 作成した通知の発行先（nil の場合は発行しない）
+	email       NotificationEmailConfig
+
+	// 通知メールはリクエストの処理から切り離し、1つのワーカーが順に送信する
+	emailMu     sync.RWMutex
+	emails      chan notificationEmail
+	emailClosed bool
+	workerDone  chan struct{}
+}
+
+// NewNotificationService 通知サービスを新規作成
+// 宛先ユーザーの通知設定に応じてアプリ内通知の作成とメールの送信を行い、
+// 作成した通知は notification.created イベントとして宛先ユーザーに発行します
+// Mailer を指定した場合はメールを送信するワーカーを起動するため、終了時に Close を呼び出してください
+func NewNotificationService(repo NotificationRepository, preferences NotificationPreferenceRepository, users UserRepository, events EventPublisher, email NotificationEmailConfig) *NotificationService {
+	s := &NotificationService{repo: repo, preferences: preferences, users: users, events: events, email: email}
+	if email.Mailer != nil {
+		s.emails = make(chan notificationEmail, utils.NotificationEmailQueueSize)
+		s.workerDone = make(chan struct{})
+		go s.runEmailWorker()
+	}
+	return s
+}
+
+// Notify 指定したユーザーそれぞれに、通知設定で有効なチャネルで通知（重複した宛先は1件にまとめる）
+// アプリ内通知の作成とイベントの発行のみを行い、メールは送信待ちに追加して戻ります
+// メールの送信に失敗しても他の宛先への通知は続行し、ログに記録します
+func (s *NotificationService) Notify(event *NotificationEvent, recipientIDs ...int) error {
+	seen := make(map[int]bool, len(recipientIDs))
+	userIDs := make([]int, 0, len(recipientIDs))
+	for _, userID := range recipientIDs {
+		if !seen[userID] {
+			seen[userID] = true
+			userIDs = append(userIDs, userID)
+		}
+	}
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	enabled, err := s.enabledChannels(event.Type, userIDs)
+	if err != nil {
+		return err
+	}
+
+	notifications := make([]models.Notification, 0, len(userIDs))
+	for _, userID := range userIDs {
+		if !enabled(userID, models.NotificationChannelInApp) {
+			continue
+		}
+		notifications = append(notifications, models.Notification{
+			UserID: userID,
+			Type:   event.Type,
+			Sender: event.Sender,
+			Body:   event.Body,
+			Link:   event.Link,
+		})
+	}
+	if len(notifications) > 0 {
+		created, err := s.repo.CreateBatch(notifications)
+		if err != nil {
+			return err
+		}
+		for _, notification := range created {
+			notification.Desktop = enabled(notification.UserID, models.NotificationChannelDesktop)
+			publishEvent(s.events, models.EventNotificationCreated, notification.UserID, notification)
+		}
+	}
+
+	if s.email.Mailer == nil {
+		return nil
+	}
+	emailIDs := make([]int, 0, len(userIDs))
+	for _, userID := range userIDs {
+		if enabled(userID, models.NotificationChannelEmail) {
+			emailIDs = append(emailIDs, userID)
+		}
+	}
+	if len(emailIDs) > 0 {
+		s.enqueueEmail(notificationEmail{event: *event, userIDs: emailIDs})
+	}
+	return nil
+}
+
+// NotifyAll 全ユーザーに通知を作成
+func (s *NotificationService) NotifyAll(event *NotificationEvent) error {
+	userIDs, err := s.users.ListIDs()
+	if err != nil {
+		return err
+	}
+	return s.Notify(event, userIDs...)
+}
+
+// ListNotifications ユーザー宛ての通知をページ単位で取得
+func (s *NotificationService) ListNotifications(user *models.User, unreadOnly bool, spec *QuerySpec) (*Page[models.Notification], error) {
+	return s.repo.List(user.ID, unreadOnly, spec)
+}
+
+// MarkRead 通知を既読にする
+func (s *NotificationService) MarkRead(user *models.User, id int) (*models.Notification, error) {
+	return s.repo.MarkRead(user.ID, id)
+}
+
+// MarkAllRead ユーザー宛ての未読通知を全て既読にする
+func (s *NotificationService) MarkAllRead(user *models.User) (*models.NotificationReadAllResponse, error) {
+	updated, err := s.repo.MarkAllRead(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &models.NotificationReadAllResponse{Updated: updated}, nil
+}
+
+// UnreadCount ユーザー宛ての未読通知件数を取得
+func (s *NotificationService) UnreadCount(user *models.User) (*models.NotificationUnreadCountResponse, error) {
+	count, err := s.repo.CountUnread(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &models.NotificationUnreadCountResponse{Unread: count}, nil
+}
+
+// GetPreferences ユーザーの通知設定を全ての通知の種類・チャネルと、通知の種類によらない設定について取得（未設定のものは既定値）
+func (s *NotificationService) GetPreferences(user *models.User) (*models.NotificationPreferencesResponse, error) {
+	stored, err := s.preferences.List(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	settings, err := s.preferences.GetSettings(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if settings == nil {
+		defaults := models.DefaultNotificationSettings()
+		settings = &defaults
+	}
+
+	enabled := make(map[models.NotificationPreference]bool, len(stored))
+	for _, preference := range stored {
+		enabled[models.NotificationPreference{Type: preference.Type, Channel: preference.Channel}] = preference.Enabled
+	}
+
+	preferences := make([]models.NotificationPreference, 0, len(models.NotificationTypes)*len(models.NotificationChannels))
+	for _, notificationType := range models.NotificationTypes {
+		for _, channel := range models.NotificationChannels {
+			key := models.NotificationPreference{Type: notificationType, Channel: channel}
+			value, ok := enabled[key]
+			if !ok {
+				value = models.DefaultNotificationPreference(notificationType, channel)
+			}
+			preferences = append(preferences, models.NotificationPreference{UserID: user.ID, Type: notificationType, Channel: channel, Enabled: value})
+		}
+	}
+	return &models.NotificationPreferencesResponse{Preferences: preferences, NotificationSettings: *settings}, nil
+}
+
+// UpdatePreferences ユーザーの通知設定を置き換え、更新後の設定を返す
+func (s *NotificationService) UpdatePreferences(user *models.User, request *models.NotificationPreferencesRequest) (*models.NotificationPreferencesResponse, error) {
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.preferences.Replace(user.ID, request.Preferences, request.Settings()); err != nil {
+		return nil, err
+	}
+	return s.GetPreferences(user)
+}
+
+// enabledChannels 宛先ユーザーの通知設定を取得し、ユーザー・チャネルごとの受信有無を判定する関数を返す
+func (s *NotificationService) enabledChannels(notificationType models.NotificationType, userIDs []int) (func(userID int, channel models.NotificationChannel) bool, error) {
+	stored, err := s.preferences.ListForType(notificationType, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	enabled := make(map[int]map[models.NotificationChannel]bool, len(stored))
+	for _, preference := range stored {
+		if enabled[preference.UserID] == nil {
+			enabled[preference.UserID] = make(map[models.NotificationChannel]bool)
+		}
+		enabled[preference.UserID][preference.Channel] = preference.Enabled
+	}
+	return func(userID int, channel models.NotificationChannel) bool {
+		if value, ok := enabled[userID][channel]; ok {
+			return value
+		}
+		return models.DefaultNotificationPreference(notificationType, channel)
+	}, nil
+}
+
+// Flush 呼び出し時点までに送信待ちに追加した通知メールの送信が終わるまで待つ
+func (s *NotificationService) Flush() {
+	done := make(chan struct{})
+	s.emailMu.RLock()
+	if s.emails == nil || s.emailClosed {
+		s.emailMu.RUnlock()
+		return
+	}
+	s.emails <- notificationEmail{done: done}
+	s.emailMu.RUnlock()
+	<-done
+}
+
+// Close 送信待ちの通知メールを送信してワーカーを終了（以降のメールは送信しない）
+func (s *NotificationService) Close() {
+	s.emailMu.Lock()
+	if s.emails == nil || s.emailClosed {
+		s.emailMu.Unlock()
+		return
+	}
+	s.emailClosed = true
+	close(s.emails)
+	s.emailMu.Unlock()
+	<-s.workerDone
+}
+
+// enqueueEmail 通知メールを送信待ちに追加
+// リクエストの処理を止めないよう、送信待ちが上限に達している場合や終了後は送信せずログに記録します
+func (s *NotificationService) enqueueEmail(email notificationEmail) {
+	s.emailMu.RLock()
+	defer s.emailMu.RUnlock()
+
+	if s.emailClosed {
+		log.Printf("⚠️  Dropped %s notification email to %d users: notification service is closed", email.event.Type, len(email.userIDs))
+		return
+	}
+	select {
+	case s.emails <- email:
+	default:
+		log.Printf("⚠️  Dropped %s notification email to %d users: queue is full", email.event.Type, len(email.userIDs))
+	}
+}
+
+// runEmailWorker 送信待ちの通知メールを順に送信（Close で送信待ちを送り切ってから終了）
+func (s *NotificationService) runEmailWorker() {
+	defer close(s.workerDone)
+	for email := range s.emails {
+		if email.done != nil {
+			close(email.done)
+			continue
+		}
+		for _, userID := range email.userIDs {
+			s.sendEmail(&email.event, userID)
+		}
+	}
+}
+
+// sendEmail 宛先ユーザーのメールアドレスに通知を送信（失敗はログに記録）
+func (s *NotificationService) sendEmail(event *NotificationEvent, userID int) {
+	user, err := s.users.FindByID(userID)
+	if err != nil {
+		log.Printf("⚠️  Failed to find recipient %d of %s notification: %v", userID, event.Type, err)
+		return
+	}
+
+	body := fmt.Sprintf("Hi %s,\n\n%s %s\n", user.Name, event.Sender.Name, event.Body)
+	if event.Link != "" {
+		body += fmt.Sprintf("\n%s%s\n", s.email.BaseURL, event.Link)
+	}
+	body += fmt.Sprintf("\nYou can change which notifications you receive by email at %s/settings/notifications\n", s.email.BaseURL)
+
+	message := &EmailMessage{
+		To:      user.Email,
+		Subject: fmt.Sprintf("%s %s", event.Sender.Name, event.Body),
+		Body:    body,
+	}
+	if err := s.email.Mailer.Send(message); err != nil {
+		log.Printf("⚠️  Failed to send %s notification email to user %d: %v", event.Type, userID, err)
+	}
+}
+
+// notifyAll 全ユーザーに通知（notifier が nil の場合は何もしない）
+// 通知はドメインの操作の付随処理のため、失敗しても呼び出し元の操作は成功として扱いログに記録します
+func notifyAll(notifier Notifier, event *NotificationEvent) {
+	if notifier == nil {
+		return
+	}
+	if err := notifier.NotifyAll(event); err != nil {
+		log.Printf("⚠️  Failed to create %s notifications: %v", event.Type, err)
+	}
+}
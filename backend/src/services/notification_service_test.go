@@ -0,0 +1,322 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"backend/models"
+)
+
+// TestNotificationServiceNotify 通知の発行と宛先解決のテスト
+func TestNotificationServiceNotify(t *testing.T) {
+	users := NewMemoryUserRepository()
+	service := NewNotificationService(NewMemoryNotificationRepository(users), NewMemoryNotificationPreferenceRepository(users), users, nil, NotificationEmailConfig{})
+	alice, _ := users.Create("alice@example.com", "Alice", "hash")
+	bob, _ := users.Create("bob@example.com", "Bob", "hash")
+	spec, _ := NotificationQuerySchema.Parse(url.Values{})
+
+	event := &NotificationEvent{Type: models.NotificationTypeMailReceived, Sender: models.NotificationSender{Name: "Jordan"}, Body: "sent you a message"}
+
+	// 重複した宛先は1件にまとめる
+	if err := service.Notify(event, alice.ID, alice.ID); err != nil {
+		t.Fatalf("Notify() error = %v", err)
+	}
+	if count, _ := service.UnreadCount(alice); count.Unread != 1 {
+		t.Errorf("alice の未読件数 = %d, want 1", count.Unread)
+	}
+	if err := service.Notify(event); err != nil {
+		t.Errorf("宛先なしの Notify() error = %v", err)
+	}
+
+	if err := service.NotifyAll(event); err != nil {
+		t.Fatalf("NotifyAll() error = %v", err)
+	}
+	if count, _ := service.UnreadCount(bob); count.Unread != 1 {
+		t.Errorf("bob の未読件数 = %d, want 1", count.Unread)
+	}
+
+	page, err := service.ListNotifications(alice, true, spec)
+	if err != nil || page.Total != 2 {
+		t.Fatalf("ListNotifications() = %+v, %v", page, err)
+	}
+	if _, err := service.MarkRead(alice, page.Items[0].ID); err != nil {
+		t.Errorf("MarkRead() error = %v", err)
+	}
+	result, err := service.MarkAllRead(alice)
+	if err != nil || result.Updated != 1 {
+		t.Errorf("MarkAllRead() = %+v, %v; want 1 updated", result, err)
+	}
+}
+
+// TestNotificationProducers 顧客登録・メール受信による通知のテスト
+func TestNotificationProducers(t *testing.T) {
+	users := NewMemoryUserRepository()
+	notifications := NewNotificationService(NewMemoryNotificationRepository(users), NewMemoryNotificationPreferenceRepository(users), users, nil, NotificationEmailConfig{})
+	alice, _ := users.Create("alice@example.com", "Alice", "hash")
+	spec, _ := NotificationQuerySchema.Parse(url.Values{})
+
+	customers := NewCustomerService(NewMemoryCustomerRepository(), notifications)
+	if _, err := customers.CreateCustomer(&models.CustomerRequest{Name: "Alex Smith", Email: "alex@example.com"}); err != nil {
+		t.Fatalf("CreateCustomer() error = %v", err)
+	}
+	mails := NewMailService(NewMemoryMailRepository(), notifications)
+	mail, err := mails.CreateMail(&models.MailRequest{From: models.MailSender{Name: "Jordan Brown", Email: "jordan@example.com"}, Subject: "Sprint 3"})
+	if err != nil {
+		t.Fatalf("CreateMail() error = %v", err)
+	}
+
+	page, err := notifications.ListNotifications(alice, false, spec)
+	if err != nil || page.Total != 2 {
+		t.Fatalf("ListNotifications() = %+v, %v", page, err)
+	}
+	received, created := page.Items[0], page.Items[1]
+	if received.Type != models.NotificationTypeMailReceived || received.Sender.Email != "jordan@example.com" ||
+		received.Body != "sent you a message: Sprint 3" || received.Link != "/inbox?mail="+strconv.Itoa(mail.ID) {
+		t.Errorf("メール受信の通知が不正: %+v", received)
+	}
+	if created.Type != models.NotificationTypeCustomerCreated || created.Sender.Name != "Alex Smith" {
+		t.Errorf("顧客登録の通知が不正: %+v", created)
+	}
+
+	// バリデーションエラーでは通知しない
+	if _, err := customers.CreateCustomer(&models.CustomerRequest{Name: "", Email: "x@example.com"}); err == nil {
+		t.Fatal("Expected validation error")
+	}
+	if count, _ := notifications.UnreadCount(alice); count.Unread != 2 {
+		t.Errorf("未読件数 = %d, want 2", count.Unread)
+	}
+}
+
+// TestNotificationServicePreferences 通知設定の取得・更新と配信チャネルへの反映のテスト
+func TestNotificationServicePreferences(t *testing.T) {
+	users := NewMemoryUserRepository()
+	mailer := &recordingMailer{}
+	service := NewNotificationService(NewMemoryNotificationRepository(users), NewMemoryNotificationPreferenceRepository(users), users, nil, NotificationEmailConfig{
+		Mailer:  mailer,
+		BaseURL: "http://localhost:3000",
+	})
+	alice, _ := users.Create("alice@example.com", "Alice", "hash")
+	bob, _ := users.Create("bob@example.com", "Bob", "hash")
+
+	// 未設定の組み合わせは既定値
+	preferences, err := service.GetPreferences(alice)
+	if err != nil || len(preferences.Preferences) != len(models.NotificationTypes)*len(models.NotificationChannels) {
+		t.Fatalf("GetPreferences() = %+v, %v", preferences, err)
+	}
+	if preferences.NotificationSettings != models.DefaultNotificationSettings() {
+		t.Errorf("通知の種類によらない設定が既定値でない: %+v", preferences.NotificationSettings)
+	}
+	for _, preference := range preferences.Preferences {
+		if preference.Enabled != models.DefaultNotificationPreference(preference.Type, preference.Channel) {
+			t.Errorf("既定値でない: %+v", preference)
+		}
+	}
+
+	mailReceived := &NotificationEvent{Type: models.NotificationTypeMailReceived, Sender: models.NotificationSender{Name: "Jordan Brown"}, Body: "sent you a message: Sprint 3", Link: "/inbox?mail=1"}
+	if err := service.NotifyAll(mailReceived); err != nil {
+		t.Fatalf("NotifyAll() error = %v", err)
+	}
+	service.Flush()
+	if len(mailer.messages) != 2 {
+		t.Fatalf("既定では全員にメールを送信する: %d件", len(mailer.messages))
+	}
+	sent := mailer.messages[0]
+	if sent.Subject != "Jordan Brown sent you a message: Sprint 3" || !strings.Contains(sent.Body, "http://localhost:3000/inbox?mail=1") ||
+		!strings.Contains(sent.Body, "http://localhost:3000/settings/notifications") {
+		t.Errorf("通知メールが不正: %+v", sent)
+	}
+
+	// alice はメールを停止し、顧客登録のアプリ内通知も受け取らない（週次ダイジェストは受け取る）
+	weeklyDigest := true
+	updated, err := service.UpdatePreferences(alice, &models.NotificationPreferencesRequest{Preferences: []models.NotificationPreference{
+		{Type: models.NotificationTypeMailReceived, Channel: models.NotificationChannelEmail, Enabled: false},
+		{Type: models.NotificationTypeCustomerCreated, Channel: models.NotificationChannelInApp, Enabled: false},
+	}, WeeklyDigest: &weeklyDigest})
+	if err != nil {
+		t.Fatalf("UpdatePreferences() error = %v", err)
+	}
+	if want := (models.NotificationSettings{WeeklyDigest: true, ProductUpdates: true, ImportantUpdates: true}); updated.NotificationSettings != want {
+		t.Errorf("通知の種類によらない設定 = %+v, want %+v", updated.NotificationSettings, want)
+	}
+	if stored, _ := service.GetPreferences(alice); stored.NotificationSettings != updated.NotificationSettings {
+		t.Errorf("更新した設定が保存されていない: %+v", stored.NotificationSettings)
+	}
+	for _, preference := range updated.Preferences {
+		disabled := preference == models.NotificationPreference{UserID: alice.ID, Type: models.NotificationTypeMailReceived, Channel: models.NotificationChannelEmail} ||
+			preference == models.NotificationPreference{UserID: alice.ID, Type: models.NotificationTypeCustomerCreated, Channel: models.NotificationChannelInApp}
+		if !disabled && preference.Enabled != models.DefaultNotificationPreference(preference.Type, preference.Channel) {
+			t.Errorf("更新していない組み合わせが既定値でない: %+v", preference)
+		}
+	}
+
+	mailer.messages = nil
+	if err := service.NotifyAll(mailReceived); err != nil {
+		t.Fatalf("NotifyAll() error = %v", err)
+	}
+	service.Flush()
+	if len(mailer.messages) != 1 || mailer.messages[0].To != bob.Email {
+		t.Errorf("メールを停止したユーザーに送信された: %+v", mailer.messages)
+	}
+	if count, _ := service.UnreadCount(alice); count.Unread != 2 {
+		t.Errorf("メールを停止してもアプリ内通知は作成する: 未読件数 = %d, want 2", count.Unread)
+	}
+
+	customerCreated := &NotificationEvent{Type: models.NotificationTypeCustomerCreated, Sender: models.NotificationSender{Name: "Alex Smith"}, Body: "was added as a new customer"}
+	if err := service.NotifyAll(customerCreated); err != nil {
+		t.Fatalf("NotifyAll() error = %v", err)
+	}
+	if count, _ := service.UnreadCount(alice); count.Unread != 2 {
+		t.Errorf("アプリ内通知を停止した種類の通知が作成された: 未読件数 = %d", count.Unread)
+	}
+	if count, _ := service.UnreadCount(bob); count.Unread != 3 {
+		t.Errorf("bob の未読件数 = %d, want 3", count.Unread)
+	}
+
+	// メールの送信に失敗しても通知は成功として扱う
+	mailer.err = errors.New("smtp unavailable")
+	if err := service.Notify(mailReceived, bob.ID); err != nil {
+		t.Errorf("メール送信失敗で Notify() error = %v", err)
+	}
+	if count, _ := service.UnreadCount(bob); count.Unread != 4 {
+		t.Errorf("メール送信失敗でアプリ内通知が作成されない: 未読件数 = %d", count.Unread)
+	}
+	service.Close()
+}
+
+// blockingMailer release が閉じられるまで送信を止めるテスト用 Mailer
+type blockingMailer struct {
+	release chan struct{}
+	sent    chan string
+}
+
+func (m *blockingMailer) Send(message *EmailMessage) error {
+	<-m.release
+	m.sent <- message.To
+	return nil
+}
+
+// TestNotificationServiceEmailQueue 通知メールをリクエストの処理から切り離して送信するテスト
+func TestNotificationServiceEmailQueue(t *testing.T) {
+	users := NewMemoryUserRepository()
+	mailer := &blockingMailer{release: make(chan struct{}), sent: make(chan string, 2)}
+	service := NewNotificationService(NewMemoryNotificationRepository(users), NewMemoryNotificationPreferenceRepository(users), users, nil, NotificationEmailConfig{Mailer: mailer})
+	alice, _ := users.Create("alice@example.com", "Alice", "hash")
+	users.Create("bob@example.com", "Bob", "hash")
+
+	// SMTP が応答しなくても Notify はアプリ内通知を作成して戻る
+	event := &NotificationEvent{Type: models.NotificationTypeMailReceived, Sender: models.NotificationSender{Name: "Jordan"}, Body: "sent you a message"}
+	if err := service.NotifyAll(event); err != nil {
+		t.Fatalf("NotifyAll() error = %v", err)
+	}
+	if count, _ := service.UnreadCount(alice); count.Unread != 1 {
+		t.Errorf("alice の未読件数 = %d, want 1", count.Unread)
+	}
+	select {
+	case to := <-mailer.sent:
+		t.Fatalf("Notify の処理中にメールが送信された: %s", to)
+	default:
+	}
+
+	// Close は送信待ちのメールを送り切ってから戻る
+	close(mailer.release)
+	service.Close()
+	if len(mailer.sent) != 2 {
+		t.Errorf("送信したメール = %d件, want 2", len(mailer.sent))
+	}
+
+	// 終了後の通知はアプリ内通知のみ作成する
+	if err := service.Notify(event, alice.ID); err != nil {
+		t.Errorf("Close 後の Notify() error = %v", err)
+	}
+	if count, _ := service.UnreadCount(alice); count.Unread != 2 {
+		t.Errorf("Close 後にアプリ内通知が作成されない: 未読件数 = %d", count.Unread)
+	}
+	service.Flush()
+	service.Close()
+}
+
+// TestNotificationServiceDesktop デスクトップ通知の設定を notification.created イベントに反映するテスト
+func TestNotificationServiceDesktop(t *testing.T) {
+	users := NewMemoryUserRepository()
+	bus := NewMemoryEventBus()
+	service := NewNotificationService(NewMemoryNotificationRepository(users), NewMemoryNotificationPreferenceRepository(users), users, bus, NotificationEmailConfig{})
+	alice, _ := users.Create("alice@example.com", "Alice", "hash")
+	bob, _ := users.Create("bob@example.com", "Bob", "hash")
+
+	desktop := map[int]bool{}
+	bus.Subscribe(func(event models.Event) {
+		var notification models.Notification
+		if err := json.Unmarshal(event.Data, &notification); err != nil {
+			t.Errorf("イベントのデータが不正: %v", err)
+		}
+		desktop[event.UserID] = notification.Desktop
+	})
+
+	if _, err := service.UpdatePreferences(alice, &models.NotificationPreferencesRequest{Preferences: []models.NotificationPreference{
+		{Type: models.NotificationTypeMailReceived, Channel: models.NotificationChannelDesktop, Enabled: true},
+	}}); err != nil {
+		t.Fatalf("UpdatePreferences() error = %v", err)
+	}
+	event := &NotificationEvent{Type: models.NotificationTypeMailReceived, Sender: models.NotificationSender{Name: "Jordan"}, Body: "sent you a message"}
+	if err := service.NotifyAll(event); err != nil {
+		t.Fatalf("NotifyAll() error = %v", err)
+	}
+	if want := map[int]bool{alice.ID: true, bob.ID: false}; !reflect.DeepEqual(desktop, want) {
+		t.Errorf("desktop = %v, want %v", desktop, want)
+	}
+
+	// 保存した通知の一覧には含めない
+	spec, _ := NotificationQuerySchema.Parse(url.Values{})
+	page, err := service.ListNotifications(alice, false, spec)
+	if err != nil || page.Total != 1 || page.Items[0].Desktop {
+		t.Errorf("ListNotifications() = %+v, %v", page, err)
+	}
+}
+
+// TestNotificationPreferencesValidation 通知設定更新リクエストのバリデーションのテスト
+func TestNotificationPreferencesValidation(t *testing.T) {
+	users := NewMemoryUserRepository()
+	service := NewNotificationService(NewMemoryNotificationRepository(users), NewMemoryNotificationPreferenceRepository(users), users, nil, NotificationEmailConfig{})
+	alice, _ := users.Create("alice@example.com", "Alice", "hash")
+
+	tests := []struct {
+		name    string
+		request models.NotificationPreferencesRequest
+		want    map[string]string // フィールド → コード
+	}{
+		{"Missing preferences", models.NotificationPreferencesRequest{}, map[string]string{"preferences": models.ValidationCodeRequired}},
+		{"Unknown type and channel", models.NotificationPreferencesRequest{Preferences: []models.NotificationPreference{
+			{Type: "sale.created", Channel: "sms"},
+		}}, map[string]string{"preferences[0].type": models.ValidationCodeInvalid, "preferences[0].channel": models.ValidationCodeInvalid}},
+		{"Duplicate", models.NotificationPreferencesRequest{Preferences: []models.NotificationPreference{
+			{Type: models.NotificationTypeMailReceived, Channel: models.NotificationChannelEmail},
+			{Type: models.NotificationTypeMailReceived, Channel: models.NotificationChannelEmail, Enabled: true},
+		}}, map[string]string{"preferences[1]": models.ValidationCodeInvalid}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.UpdatePreferences(alice, &tt.request)
+			var validationErr *models.ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Expected validation error, got %v", err)
+			}
+			got := make(map[string]string)
+			for _, fieldErr := range validationErr.Errors {
+				got[fieldErr.Field] = fieldErr.Code
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("errors = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// 空の一覧は全て既定値に戻す
+	if _, err := service.UpdatePreferences(alice, &models.NotificationPreferencesRequest{Preferences: []models.NotificationPreference{}}); err != nil {
+		t.Errorf("UpdatePreferences(empty) error = %v", err)
+	}
+}
@@ -0,0 +1,119 @@
+package services
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/url"
+	"strconv"
+	"time"
+
+	"backend/models"
+	"backend/utils"
+)
+
+// Cursor キーセットページネーション用のカーソル
+// (created_at, id) の組で一覧上の位置を一意に表します
+type Cursor struct {
+	CreatedAt time.Time `json:"created_at"`
+	ID        int       `json:"id"`
+}
+
+// Encode カーソルをクライアント向けの不透明な文字列に変換
+func (c Cursor) Encode() string {
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// DecodeCursor 不透明なカーソル文字列をデコード
+func DecodeCursor(value string) (*Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, models.NewValidationError("after", models.ValidationCodeInvalid, "pagination.invalid_cursor", nil)
+	}
+
+	var cursor Cursor
+	if err := json.Unmarshal(raw, &cursor); err != nil || cursor.CreatedAt.IsZero() || cursor.ID <= 0 {
+		return nil, models.NewValidationError("after", models.ValidationCodeInvalid, "pagination.invalid_cursor", nil)
+	}
+
+	return &cursor, nil
+}
+
+// PageRequest 一覧取得時のページ指定
+// After が指定された場合はキーセット、それ以外はオフセットでページングします
+type PageRequest struct {
+	Limit  int
+	Offset int
+	After  *Cursor
+}
+
+// Page 一覧取得結果
+type Page[T any] struct {
+	Items      []T
+	Total      int
+	NextCursor *Cursor
+	HasMore    bool
+}
+
+// newPage Limit+1 件取得した結果から Page を組み立てる
+// 余分な1件があれば次ページありとし、デフォルトの並び順の場合のみ次カーソルを設定します
+func newPage[T any](spec *QuerySpec, items []T, total int, cursor func(T) Cursor) *Page[T] {
+	result := &Page[T]{Items: items, Total: total}
+	if len(items) > spec.Page.Limit {
+		result.Items = items[:spec.Page.Limit]
+		result.HasMore = true
+		if spec.IsDefaultSort() {
+			next := cursor(result.Items[len(result.Items)-1])
+			result.NextCursor = &next
+		}
+	}
+	return result
+}
+
+// ParsePageRequest クエリパラメータ（limit, offset, after）からページ指定を生成
+func ParsePageRequest(values url.Values) (PageRequest, error) {
+	page := PageRequest{Limit: utils.DefaultPageLimit}
+
+	if raw := values.Get("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit < 1 || limit > utils.MaxPageLimit {
+			return page, models.NewValidationError("limit", models.ValidationCodeInvalid, "pagination.invalid_limit", map[string]interface{}{"max": utils.MaxPageLimit})
+		}
+		page.Limit = limit
+	}
+
+	if raw := values.Get("offset"); raw != "" {
+		offset, err := strconv.Atoi(raw)
+		if err != nil || offset < 0 {
+			return page, models.NewValidationError("offset", models.ValidationCodeInvalid, "pagination.invalid_offset", nil)
+		}
+		page.Offset = offset
+	}
+
+	if raw := values.Get("after"); raw != "" {
+		if page.Offset > 0 {
+			return page, models.NewValidationError("after", models.ValidationCodeInvalid, "pagination.after_with_offset", nil)
+		}
+		cursor, err := DecodeCursor(raw)
+		if err != nil {
+			return page, err
+		}
+		page.After = cursor
+	}
+
+	return page, nil
+}
+
+// Pagination レスポンス用のページネーションメタデータを生成
+func (p *Page[T]) Pagination(page PageRequest) *models.Pagination {
+	pagination := &models.Pagination{
+		Total:   p.Total,
+		Limit:   page.Limit,
+		Offset:  page.Offset,
+		HasMore: p.HasMore,
+	}
+	if p.NextCursor != nil {
+		pagination.NextCursor = p.NextCursor.Encode()
+	}
+	return pagination
+}
@@ -0,0 +1,115 @@
+package services
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"backend/models"
+	"backend/utils"
+)
+
+// TestCursorEncodeDecode カーソルのエンコード・デコードのテスト
+func TestCursorEncodeDecode(t *testing.T) {
+	cursor := Cursor{CreatedAt: time.Date(2025, 7, 26, 1, 55, 51, 425125000, time.UTC), ID: 42}
+
+	decoded, err := DecodeCursor(cursor.Encode())
+	if err != nil {
+		t.Fatalf("DecodeCursor() error = %v", err)
+	}
+
+	if !decoded.CreatedAt.Equal(cursor.CreatedAt) {
+		t.Errorf("Expected CreatedAt %v, got %v", cursor.CreatedAt, decoded.CreatedAt)
+	}
+	if decoded.ID != cursor.ID {
+		t.Errorf("Expected ID %d, got %d", cursor.ID, decoded.ID)
+	}
+}
+
+// TestDecodeCursorInvalid 不正なカーソルのテスト
+func TestDecodeCursorInvalid(t *testing.T) {
+	invalid := []string{
+		"not base64!",
+		"e30",                  // {}
+		"eyJpZCI6MX0",          // {"id":1}
+		"W10",                  // []
+		Cursor{ID: 1}.Encode(), // created_at なし
+	}
+
+	for _, value := range invalid {
+		t.Run(value, func(t *testing.T) {
+			_, err := DecodeCursor(value)
+			if _, ok := err.(*models.ValidationError); !ok {
+				t.Errorf("Expected *models.ValidationError, got %v", err)
+			}
+		})
+	}
+}
+
+// TestParsePageRequest ページ指定パースのテスト
+func TestParsePageRequest(t *testing.T) {
+	validCursor := Cursor{CreatedAt: time.Now().UTC(), ID: 1}.Encode()
+
+	tests := []struct {
+		name       string
+		query      string
+		wantErr    bool
+		wantLimit  int
+		wantOffset int
+		wantAfter  bool
+	}{
+		{"Defaults", "", false, utils.DefaultPageLimit, 0, false},
+		{"Limit and offset", "limit=5&offset=10", false, 5, 10, false},
+		{"Max limit", "limit=100", false, 100, 0, false},
+		{"Cursor", "limit=5&after=" + validCursor, false, 5, 0, true},
+		{"Zero limit", "limit=0", true, 0, 0, false},
+		{"Too large limit", "limit=101", true, 0, 0, false},
+		{"Non-numeric limit", "limit=abc", true, 0, 0, false},
+		{"Negative offset", "offset=-1", true, 0, 0, false},
+		{"Invalid cursor", "after=broken", true, 0, 0, false},
+		{"Cursor with offset", "offset=5&after=" + validCursor, true, 0, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, _ := url.ParseQuery(tt.query)
+			page, err := ParsePageRequest(values)
+			if tt.wantErr {
+				if _, ok := err.(*models.ValidationError); !ok {
+					t.Errorf("Expected *models.ValidationError, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParsePageRequest() error = %v", err)
+			}
+			if page.Limit != tt.wantLimit {
+				t.Errorf("Expected limit %d, got %d", tt.wantLimit, page.Limit)
+			}
+			if page.Offset != tt.wantOffset {
+				t.Errorf("Expected offset %d, got %d", tt.wantOffset, page.Offset)
+			}
+			if (page.After != nil) != tt.wantAfter {
+				t.Errorf("Expected after set = %v, got %v", tt.wantAfter, page.After != nil)
+			}
+		})
+	}
+}
+
+// TestPagePagination ページネーションメタデータ生成のテスト
+func TestPagePagination(t *testing.T) {
+	next := &Cursor{CreatedAt: time.Now().UTC(), ID: 3}
+	page := &Page[models.HelloWorldMessage]{Total: 10, HasMore: true, NextCursor: next}
+
+	pagination := page.Pagination(PageRequest{Limit: 3, Offset: 6})
+
+	if pagination.Total != 10 || pagination.Limit != 3 || pagination.Offset != 6 {
+		t.Errorf("Unexpected pagination: %+v", pagination)
+	}
+	if !pagination.HasMore {
+		t.Error("Expected has_more to be true")
+	}
+	if pagination.NextCursor != next.Encode() {
+		t.Errorf("Expected next_cursor %s, got %s", next.Encode(), pagination.NextCursor)
+	}
+}
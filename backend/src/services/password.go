@@ -0,0 +1,141 @@
+package services
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+
+	"backend/utils"
+)
+
+// ErrUnsupportedPasswordHash 保存されているハッシュの形式を判別できない
+var ErrUnsupportedPasswordHash = errors.New("unsupported password hash format")
+
+// Argon2idParams argon2id のパラメータ
+type Argon2idParams struct {
+	Time    uint32 // 反復回数
+	Memory  uint32 // メモリ使用量（KiB）
+	Threads uint8  // 並列度
+	SaltLen uint32 // ソルト長（バイト）
+	KeyLen  uint32 // ハッシュ長（バイト）
+}
+
+// DefaultArgon2idParams RFC 9106 の推奨設定（メモリ64MiB）に基づくデフォルト値
+var DefaultArgon2idParams = Argon2idParams{
+	Time:    3,
+	Memory:  64 * 1024,
+	Threads: 2,
+	SaltLen: 16,
+	KeyLen:  32,
+}
+
+// PasswordHasher パスワードのハッシュ化と照合
+//
+// 新しいハッシュは設定されたアルゴリズムで生成し、照合はハッシュの形式から
+// アルゴリズムを判別するため、PASSWORD_HASH_ALGORITHM を切り替えても
+// 既存ユーザーはそのままログインできます。
+type PasswordHasher struct {
+	algorithm  string
+	bcryptCost int
+	argon2id   Argon2idParams
+}
+
+// NewPasswordHasher 指定アルゴリズム（bcrypt | argon2id）のハッシャーを新規作成
+func NewPasswordHasher(algorithm string) (*PasswordHasher, error) {
+	switch algorithm {
+	case utils.PasswordHashBcrypt, utils.PasswordHashArgon2id:
+	default:
+		return nil, fmt.Errorf("unknown password hash algorithm: %q (expected %q or %q)",
+			algorithm, utils.PasswordHashBcrypt, utils.PasswordHashArgon2id)
+	}
+
+	return &PasswordHasher{
+		algorithm:  algorithm,
+		bcryptCost: bcrypt.DefaultCost,
+		argon2id:   DefaultArgon2idParams,
+	}, nil
+}
+
+// Hash パスワードをハッシュ化
+func (h *PasswordHasher) Hash(password string) (string, error) {
+	if h.algorithm == utils.PasswordHashArgon2id {
+		return h.hashArgon2id(password)
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), h.bcryptCost)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash password: %w", err)
+	}
+	return string(hash), nil
+}
+
+// Verify パスワードがハッシュと一致するか照合
+func (h *PasswordHasher) Verify(encoded, password string) (bool, error) {
+	switch {
+	case strings.HasPrefix(encoded, "$argon2id$"):
+		return verifyArgon2id(encoded, password)
+	case strings.HasPrefix(encoded, "$2a$"), strings.HasPrefix(encoded, "$2b$"), strings.HasPrefix(encoded, "$2y$"):
+		err := bcrypt.CompareHashAndPassword([]byte(encoded), []byte(password))
+		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+			return false, nil
+		}
+		if err != nil {
+			return false, fmt.Errorf("failed to verify password: %w", err)
+		}
+		return true, nil
+	}
+	return false, ErrUnsupportedPasswordHash
+}
+
+// hashArgon2id PHC文字列形式（$argon2id$v=19$m=...,t=...,p=...$salt$hash）でハッシュ化
+func (h *PasswordHasher) hashArgon2id(password string) (string, error) {
+	p := h.argon2id
+	salt := make([]byte, p.SaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("failed to generate salt: %w", err)
+	}
+
+	key := argon2.IDKey([]byte(password), salt, p.Time, p.Memory, p.Threads, p.KeyLen)
+
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, p.Memory, p.Time, p.Threads,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+// verifyArgon2id PHC文字列に含まれるパラメータで再計算して照合
+func verifyArgon2id(encoded, password string) (bool, error) {
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 6 {
+		return false, ErrUnsupportedPasswordHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return false, ErrUnsupportedPasswordHash
+	}
+
+	var p Argon2idParams
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &p.Memory, &p.Time, &p.Threads); err != nil {
+		return false, ErrUnsupportedPasswordHash
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return false, ErrUnsupportedPasswordHash
+	}
+	want, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil {
+		return false, ErrUnsupportedPasswordHash
+	}
+
+	got := argon2.IDKey([]byte(password), salt, p.Time, p.Memory, p.Threads, uint32(len(want)))
+	return subtle.ConstantTimeCompare(got, want) == 1, nil
+}
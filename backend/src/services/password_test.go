@@ -0,0 +1,93 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"backend/utils"
+)
+
+// newTestPasswordHasher テスト用に計算量を下げたハッシャー
+func newTestPasswordHasher(algorithm string) *PasswordHasher {
+	return &PasswordHasher{
+		algorithm:  algorithm,
+		bcryptCost: bcrypt.MinCost,
+		argon2id:   Argon2idParams{Time: 1, Memory: 1024, Threads: 1, SaltLen: 16, KeyLen: 32},
+	}
+}
+
+// TestNewPasswordHasher アルゴリズム指定のテスト
+func TestNewPasswordHasher(t *testing.T) {
+	for _, algorithm := range []string{utils.PasswordHashBcrypt, utils.PasswordHashArgon2id} {
+		if _, err := NewPasswordHasher(algorithm); err != nil {
+			t.Errorf("NewPasswordHasher(%q) error = %v", algorithm, err)
+		}
+	}
+	if _, err := NewPasswordHasher("md5"); err == nil {
+		t.Error("Expected error for unknown algorithm")
+	}
+}
+
+// TestPasswordHasher ハッシュ化と照合のテスト
+func TestPasswordHasher(t *testing.T) {
+	tests := []struct {
+		algorithm string
+		prefix    string
+	}{
+		{utils.PasswordHashBcrypt, "$2a$"},
+		{utils.PasswordHashArgon2id, "$argon2id$v=19$"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.algorithm, func(t *testing.T) {
+			hasher := newTestPasswordHasher(tt.algorithm)
+
+			hash, err := hasher.Hash("correct horse")
+			if err != nil {
+				t.Fatalf("Hash() error = %v", err)
+			}
+			if !strings.HasPrefix(hash, tt.prefix) {
+				t.Errorf("Expected prefix %q, got %q", tt.prefix, hash)
+			}
+
+			other, _ := hasher.Hash("correct horse")
+			if hash == other {
+				t.Error("同じパスワードで同じハッシュが生成された（ソルトが効いていない）")
+			}
+
+			if ok, err := hasher.Verify(hash, "correct horse"); err != nil || !ok {
+				t.Errorf("正しいパスワードで照合失敗: ok=%v err=%v", ok, err)
+			}
+			if ok, err := hasher.Verify(hash, "wrong horse"); err != nil || ok {
+				t.Errorf("誤ったパスワードで照合成功: ok=%v err=%v", ok, err)
+			}
+		})
+	}
+}
+
+// TestPasswordHasherVerifiesOtherAlgorithm アルゴリズム切り替え後も既存ハッシュを照合できることのテスト
+func TestPasswordHasherVerifiesOtherAlgorithm(t *testing.T) {
+	bcryptHash, _ := newTestPasswordHasher(utils.PasswordHashBcrypt).Hash("password")
+	argonHash, _ := newTestPasswordHasher(utils.PasswordHashArgon2id).Hash("password")
+
+	if ok, err := newTestPasswordHasher(utils.PasswordHashArgon2id).Verify(bcryptHash, "password"); err != nil || !ok {
+		t.Errorf("argon2id設定でbcryptハッシュを照合できない: ok=%v err=%v", ok, err)
+	}
+	if ok, err := newTestPasswordHasher(utils.PasswordHashBcrypt).Verify(argonHash, "password"); err != nil || !ok {
+		t.Errorf("bcrypt設定でargon2idハッシュを照合できない: ok=%v err=%v", ok, err)
+	}
+}
+
+// TestPasswordHasherUnsupportedHash 不明な形式のハッシュのテスト
+func TestPasswordHasherUnsupportedHash(t *testing.T) {
+	hasher := newTestPasswordHasher(utils.PasswordHashBcrypt)
+
+	for _, hash := range []string{"", "plaintext", "$argon2id$v=19$broken", "$argon2id$v=18$m=1,t=1,p=1$c2FsdA$aGFzaA"} {
+		if _, err := hasher.Verify(hash, "password"); !errors.Is(err, ErrUnsupportedPasswordHash) {
+			t.Errorf("Verify(%q): expected ErrUnsupportedPasswordHash, got %v", hash, err)
+		}
+	}
+}
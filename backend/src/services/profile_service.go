@@ -0,0 +1,227 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"time"
+
+	"backend/models"
+	"backend/utils"
+)
+
+// emailChangeTokenBytes メールアドレス変更の確認トークンの乱数バイト数
+const emailChangeTokenBytes = 32
+
+// EmailChangeConfig メールアドレス変更の確認メールの設定
+type EmailChangeConfig struct {
+	ConfirmURL string        // 確認ページのURL（?token= を付与してメールに記載）
+	TTL        time.Duration // 確認リンクの有効期間
+}
+
+// ProfileService 認証ユーザー自身のプロフィールとアカウントの管理
+//
+// メールアドレスの変更は即座には反映せず、新しいアドレスに送信した確認トークンで確定します。
+// アカウントの削除にはパスワードによる再認証が必要です。
+type ProfileService struct {
+	users    UserRepository
+	accounts AccountRepository
+	hasher   *PasswordHasher
+	mailer   Mailer
+	config   EmailChangeConfig
+	now      func() time.Time
+}
+
+// NewProfileService プロフィールサービスを新規作成
+func NewProfileService(users UserRepository, accounts AccountRepository, hasher *PasswordHasher, mailer Mailer, config EmailChangeConfig) *ProfileService {
+	return &ProfileService{
+		users:    users,
+		accounts: accounts,
+		hasher:   hasher,
+		mailer:   mailer,
+		config:   config,
+		now:      time.Now,
+	}
+}
+
+// GetProfile 認証ユーザーのプロフィールを取得（確認待ちのメールアドレスを含む）
+func (s *ProfileService) GetProfile(user *models.User) (*models.User, error) {
+	return s.profile(user)
+}
+
+// UpdateProfile JSON Merge Patch (RFC 7396) でプロフィールを部分更新
+// email を変更した場合は確認メールを送信し、確認されるまで pending_email として返します
+func (s *ProfileService) UpdateProfile(user *models.User, patch []byte) (*models.User, error) {
+	current, err := s.users.FindByID(user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 更新可能なフィールドのみを対象にパッチを適用
+	original, err := json.Marshal(models.ProfileRequest{
+		Name:     current.Name,
+		Email:    current.Email,
+		Username: current.Username,
+		Avatar:   current.Avatar,
+		Bio:      current.Bio,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode profile: %w", err)
+	}
+
+	merged, err := utils.ApplyMergePatch(original, patch)
+	if err != nil {
+		return nil, models.NewValidationError("body", models.ValidationCodeInvalid, "validation.invalid_merge_patch", nil)
+	}
+
+	var request models.ProfileRequest
+	if err := models.DecodeJSON(bytes.NewReader(merged), &request); err != nil {
+		return nil, err
+	}
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+
+	emailChanged := request.Email != current.Email
+	if emailChanged {
+		if _, err := s.users.FindByEmail(request.Email); err == nil {
+			return nil, emailTakenError()
+		} else if !errors.Is(err, ErrUserNotFound) {
+			return nil, err
+		}
+	}
+
+	_, err = s.users.UpdateProfile(user.ID, &models.User{
+		Name:     request.Name,
+		Username: request.Username,
+		Avatar:   request.Avatar,
+		Bio:      request.Bio,
+	})
+	if err != nil {
+		if errors.Is(err, ErrUsernameAlreadyExists) {
+			var errs models.FieldErrors
+			errs.Add("username", models.ValidationCodeTaken, "user.username_taken", nil)
+			return nil, errs.Err()
+		}
+		return nil, err
+	}
+
+	if emailChanged {
+		if err := s.requestEmailChange(current, request.Email); err != nil {
+			return nil, err
+		}
+	}
+	return s.profile(user)
+}
+
+// ConfirmEmailChange 確認トークンでメールアドレスの変更を確定
+// トークンは変更を要求したユーザー本人のみ、有効期限内に1回だけ使用できます
+func (s *ProfileService) ConfirmEmailChange(user *models.User, request *models.ConfirmEmailChangeRequest) (*models.User, error) {
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+
+	previous, err := s.users.FindByID(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	updated, err := s.users.ConfirmEmailChange(user.ID, hashToken(request.Token), s.now())
+	if err != nil {
+		if errors.Is(err, ErrEmailAlreadyExists) {
+			return nil, emailTakenError()
+		}
+		return nil, err
+	}
+
+	// 乗っ取りに気付けるよう、変更前のアドレスにも通知する（失敗しても変更は確定済み）
+	if err := s.mailer.Send(emailChangedNotice(previous.Email, updated.Email)); err != nil {
+		log.Printf("⚠️  Failed to send email change notice to user %d: %v", user.ID, err)
+	}
+	return updated, nil
+}
+
+// DeleteAccount パスワードで再認証してアカウントを削除
+// 最後の owner は、別のユーザーに owner を付与するまで削除できません（ErrLastOwnerAccount）
+// 唯一の owner であるチームがある場合も、owner を移すかチームを削除するまで削除できません（ErrSoleTeamOwnerAccount）
+func (s *ProfileService) DeleteAccount(user *models.User, request *models.DeleteAccountRequest) error {
+	if err := request.Validate(); err != nil {
+		return err
+	}
+
+	current, err := s.users.FindByID(user.ID)
+	if err != nil {
+		return err
+	}
+	ok, err := s.hasher.Verify(current.PasswordHash, request.Password)
+	if err != nil {
+		return fmt.Errorf("failed to verify password: %w", err)
+	}
+	if !ok {
+		return models.NewValidationError("password", models.ValidationCodeInvalid, "profile.password_incorrect", nil)
+	}
+
+	return s.accounts.DeleteAccount(user.ID)
+}
+
+// profile 保存されている最新のプロフィールを取得
+func (s *ProfileService) profile(user *models.User) (*models.User, error) {
+	profile, err := s.users.FindByID(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	profile.Roles = user.Roles
+	profile.Permissions = user.Permissions
+	return profile, nil
+}
+
+// requestEmailChange 確認トークンを発行し、新しいメールアドレスに確認メールを送信
+func (s *ProfileService) requestEmailChange(user *models.User, email string) error {
+	token, err := randomToken(emailChangeTokenBytes)
+	if err != nil {
+		return err
+	}
+	expiresAt := s.now().Add(s.config.TTL)
+	if err := s.users.RequestEmailChange(user.ID, email, hashToken(token), expiresAt); err != nil {
+		return err
+	}
+
+	if err := s.mailer.Send(s.confirmationEmail(user, email, token, expiresAt)); err != nil {
+		// 届かない確認待ちの変更を残さない
+		if cancelErr := s.users.CancelEmailChange(user.ID); cancelErr != nil {
+			return errors.Join(err, cancelErr)
+		}
+		return err
+	}
+	return nil
+}
+
+// confirmationEmail メールアドレス変更の確認メールを組み立てる
+func (s *ProfileService) confirmationEmail(user *models.User, email, token string, expiresAt time.Time) *EmailMessage {
+	link := s.config.ConfirmURL + "?token=" + url.QueryEscape(token)
+	return &EmailMessage{
+		To:      email,
+		Subject: "Confirm your new email address",
+		Body: fmt.Sprintf("Hi %s,\n\nConfirm that you want to use this address for your account:\n%s\n\nThis link expires at %s. If you did not request this change, you can ignore this email.\n",
+			user.Name, link, expiresAt.UTC().Format(time.RFC1123)),
+	}
+}
+
+// emailChangedNotice 変更前のメールアドレスへの変更通知を組み立てる
+func emailChangedNotice(previous, current string) *EmailMessage {
+	return &EmailMessage{
+		To:      previous,
+		Subject: "Your email address has been changed",
+		Body: fmt.Sprintf("The email address for your account has been changed to %s.\n\nIf you did not make this change, contact an administrator immediately.\n",
+			current),
+	}
+}
+
+// emailTakenError メールアドレスが登録済みであることを表すバリデーションエラー
+func emailTakenError() error {
+	var errs models.FieldErrors
+	errs.Add("email", models.ValidationCodeTaken, "user.email_taken", nil)
+	return errs.Err()
+}
@@ -0,0 +1,218 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"backend/models"
+	"backend/utils"
+)
+
+// newTestProfileService テスト用のプロフィールサービスと、パスワード "password123" のユーザー作成関数
+func newTestProfileService(t *testing.T) (*ProfileService, *recordingMailer, *MemoryRoleRepository, *MemoryTeamRepository, func(email string) *models.User) {
+	t.Helper()
+	hasher, err := NewPasswordHasher(utils.PasswordHashBcrypt)
+	if err != nil {
+		t.Fatalf("NewPasswordHasher失敗: %v", err)
+	}
+	hash, err := hasher.Hash("password123")
+	if err != nil {
+		t.Fatalf("Hash失敗: %v", err)
+	}
+
+	users := NewMemoryUserRepository()
+	roles := NewMemoryRoleRepository()
+	teams := NewMemoryTeamRepository(users)
+	mailer := &recordingMailer{}
+	service := NewProfileService(users, NewMemoryAccountRepository(users, roles, teams), hasher, mailer, EmailChangeConfig{
+		ConfirmURL: "http://localhost:3000/settings/confirm-email",
+		TTL:        time.Hour,
+	})
+	createUser := func(email string) *models.User {
+		user, err := users.Create(email, strings.Split(email, "@")[0], hash)
+		if err != nil {
+			t.Fatalf("ユーザー作成失敗: %v", err)
+		}
+		if _, err := roles.AssignDefaultRole(user.ID); err != nil {
+			t.Fatalf("ロール付与失敗: %v", err)
+		}
+		return user
+	}
+	return service, mailer, roles, teams, createUser
+}
+
+// TestProfileServiceUpdateProfile プロフィール更新のテスト
+func TestProfileServiceUpdateProfile(t *testing.T) {
+	service, mailer, _, _, createUser := newTestProfileService(t)
+	alice, bob := createUser("alice@example.com"), createUser("bob@example.com")
+
+	updated, err := service.UpdateProfile(alice, []byte(`{"name":"Alice Smith","username":"Alice","avatar":{"src":"https://i.pravatar.cc/128?u=1"},"bio":"Hello"}`))
+	if err != nil {
+		t.Fatalf("UpdateProfile() error = %v", err)
+	}
+	if updated.Name != "Alice Smith" || updated.Username != "alice" || updated.Avatar == nil || updated.Bio != "Hello" || updated.Email != "alice@example.com" {
+		t.Errorf("更新結果が不正: %+v", updated)
+	}
+	if len(mailer.messages) != 0 {
+		t.Errorf("メールアドレスを変更していないのに確認メールが送信された")
+	}
+
+	// パッチに含まれないフィールドは維持し、null で削除する
+	updated, err = service.UpdateProfile(alice, []byte(`{"avatar":null}`))
+	if err != nil || updated.Avatar != nil || updated.Username != "alice" || updated.Bio != "Hello" {
+		t.Errorf("部分更新が不正: %+v, %v", updated, err)
+	}
+
+	tests := []struct {
+		name   string
+		patch  string
+		fields []string
+	}{
+		{"複数のフィールドのエラー", `{"name":"","username":"x"}`, []string{"name", "username"}},
+		{"使用中のユーザー名", `{"username":"ALICE"}`, []string{"username"}},
+		{"登録済みのメールアドレス", `{"email":"Alice@example.com"}`, []string{"email"}},
+		{"更新できないフィールド", `{"password_hash":"x"}`, []string{"password_hash"}},
+		{"不正なパッチ", `[]`, []string{"body"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.UpdateProfile(bob, []byte(tt.patch))
+			var validationErr *models.ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Expected ValidationError, got %v", err)
+			}
+			var fields []string
+			for _, fieldErr := range validationErr.Errors {
+				fields = append(fields, fieldErr.Field)
+			}
+			if validationErr.Errors == nil {
+				fields = []string{validationErr.Field}
+			}
+			if strings.Join(fields, ",") != strings.Join(tt.fields, ",") {
+				t.Errorf("エラーのフィールド = %v, want %v", fields, tt.fields)
+			}
+		})
+	}
+}
+
+// TestProfileServiceEmailChange メールアドレス変更の確認のテスト
+func TestProfileServiceEmailChange(t *testing.T) {
+	service, mailer, _, _, createUser := newTestProfileService(t)
+	alice, bob := createUser("alice@example.com"), createUser("bob@example.com")
+
+	updated, err := service.UpdateProfile(alice, []byte(`{"email":"Alice.New@Example.com"}`))
+	if err != nil {
+		t.Fatalf("UpdateProfile() error = %v", err)
+	}
+	// 確認されるまでは変更しない
+	if updated.Email != "alice@example.com" || updated.PendingEmail != "alice.new@example.com" {
+		t.Errorf("確認前のメールアドレスが不正: %+v", updated)
+	}
+	if len(mailer.messages) != 1 || mailer.messages[0].To != "alice.new@example.com" {
+		t.Fatalf("新しいアドレスに確認メールが送信されていない: %+v", mailer.messages)
+	}
+	token := mailer.linkToken(t)
+
+	// 他のユーザーはトークンを使用できない
+	if _, err := service.ConfirmEmailChange(bob, &models.ConfirmEmailChangeRequest{Token: token}); !errors.Is(err, ErrEmailChangeNotFound) {
+		t.Errorf("他のユーザーの確認で ErrEmailChangeNotFound が返らない: %v", err)
+	}
+	if _, err := service.ConfirmEmailChange(alice, &models.ConfirmEmailChangeRequest{Token: " "}); err == nil {
+		t.Error("空のトークンでエラーにならない")
+	}
+
+	confirmed, err := service.ConfirmEmailChange(alice, &models.ConfirmEmailChangeRequest{Token: token})
+	if err != nil {
+		t.Fatalf("ConfirmEmailChange() error = %v", err)
+	}
+	if confirmed.Email != "alice.new@example.com" || confirmed.PendingEmail != "" {
+		t.Errorf("確認後のメールアドレスが不正: %+v", confirmed)
+	}
+	if notice := mailer.messages[len(mailer.messages)-1]; notice.To != "alice@example.com" {
+		t.Errorf("変更前のアドレスに通知されていない: %+v", notice)
+	}
+	if _, err := service.ConfirmEmailChange(alice, &models.ConfirmEmailChangeRequest{Token: token}); !errors.Is(err, ErrEmailChangeNotFound) {
+		t.Errorf("使用済みトークンで ErrEmailChangeNotFound が返らない: %v", err)
+	}
+
+	// 期限切れのトークン
+	if _, err := service.UpdateProfile(bob, []byte(`{"email":"bob.new@example.com"}`)); err != nil {
+		t.Fatalf("UpdateProfile() error = %v", err)
+	}
+	token = mailer.linkToken(t)
+	service.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+	if _, err := service.ConfirmEmailChange(bob, &models.ConfirmEmailChangeRequest{Token: token}); !errors.Is(err, ErrEmailChangeNotFound) {
+		t.Errorf("期限切れのトークンで ErrEmailChangeNotFound が返らない: %v", err)
+	}
+	service.now = time.Now
+
+	// 送信に失敗した場合は確認待ちの変更を残さない
+	mailer.err = errors.New("smtp unavailable")
+	if _, err := service.UpdateProfile(bob, []byte(`{"email":"bob.other@example.com"}`)); err == nil {
+		t.Error("送信失敗がエラーにならない")
+	}
+	mailer.err = nil
+	if profile, _ := service.GetProfile(bob); profile.PendingEmail != "" {
+		t.Errorf("送信に失敗した変更が残っている: %+v", profile)
+	}
+}
+
+// TestProfileServiceDeleteAccount パスワード再認証によるアカウント削除のテスト
+func TestProfileServiceDeleteAccount(t *testing.T) {
+	service, _, roles, teams, createUser := newTestProfileService(t)
+	owner, member := createUser("owner@example.com"), createUser("member@example.com")
+
+	var validationErr *models.ValidationError
+	if err := service.DeleteAccount(member, &models.DeleteAccountRequest{}); !errors.As(err, &validationErr) || validationErr.Code != models.ValidationCodeRequired {
+		t.Errorf("パスワード未指定で required が返らない: %v", err)
+	}
+	if err := service.DeleteAccount(member, &models.DeleteAccountRequest{Password: "wrong-password"}); !errors.As(err, &validationErr) || validationErr.Field != "password" {
+		t.Errorf("誤ったパスワードでエラーにならない: %v", err)
+	}
+
+	// 最後の owner は削除できない
+	if err := service.DeleteAccount(owner, &models.DeleteAccountRequest{Password: "password123"}); !errors.Is(err, ErrLastOwnerAccount) {
+		t.Errorf("ErrLastOwnerAccount が返らない: %v", err)
+	}
+
+	if err := service.DeleteAccount(member, &models.DeleteAccountRequest{Password: "password123"}); err != nil {
+		t.Fatalf("DeleteAccount() error = %v", err)
+	}
+	if _, err := service.GetProfile(member); !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("削除後もプロフィールを取得できる: %v", err)
+	}
+	if count, _ := roles.CountUsersWithRole(models.RoleMember); count != 0 {
+		t.Errorf("削除したユーザーのロールが残っている: %d", count)
+	}
+
+	// 別のユーザーが owner になれば削除できる
+	other := createUser("other@example.com")
+	if err := roles.AssignRole(other.ID, models.RoleOwner); err != nil {
+		t.Fatalf("AssignRole失敗: %v", err)
+	}
+
+	// 唯一の owner であるチームがあれば、owner を移すまで削除できない
+	team, err := teams.Create(&models.Team{Name: "Team"}, owner.ID)
+	if err != nil {
+		t.Fatalf("チーム作成失敗: %v", err)
+	}
+	invitation, _ := teams.CreateInvitation(&models.TeamInvitation{TeamID: team.ID, Email: other.Email, Role: models.TeamRoleMember, TokenHash: "hash", ExpiresAt: time.Now().Add(time.Hour)})
+	if _, err := teams.AcceptInvitation(invitation.ID, other.ID); err != nil {
+		t.Fatalf("AcceptInvitation失敗: %v", err)
+	}
+	if err := service.DeleteAccount(owner, &models.DeleteAccountRequest{Password: "password123"}); !errors.Is(err, ErrSoleTeamOwnerAccount) {
+		t.Errorf("ErrSoleTeamOwnerAccount が返らない: %v", err)
+	}
+	if _, err := teams.UpdateMemberRole(team.ID, other.ID, models.TeamRoleOwner); err != nil {
+		t.Fatalf("UpdateMemberRole失敗: %v", err)
+	}
+
+	if err := service.DeleteAccount(owner, &models.DeleteAccountRequest{Password: "password123"}); err != nil {
+		t.Errorf("DeleteAccount() error = %v", err)
+	}
+	if members, _ := teams.ListMembers(team.ID); len(members) != 1 || members[0].UserID != other.ID {
+		t.Errorf("削除したユーザーがチームに残っている: %+v", members)
+	}
+}
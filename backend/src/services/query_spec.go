@@ -0,0 +1,249 @@
+package services
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"backend/models"
+)
+
+// FilterOp フィルターの比較演算子
+type FilterOp string
+
+const (
+	FilterEq  FilterOp = "="
+	FilterGt  FilterOp = ">"
+	FilterGte FilterOp = ">="
+	FilterLt  FilterOp = "<"
+	FilterLte FilterOp = "<="
+)
+
+// FieldType フィルター値の型
+type FieldType int
+
+const (
+	FieldString FieldType = iota
+	FieldTime
+	FieldInt
+)
+
+// FilterDef クエリパラメータとして受け付けるフィルターの定義
+type FilterDef struct {
+	Field string    // 対象フィールド名（ソート・メモリ実装の評価にも使用）
+	Op    FilterOp  // 比較演算子
+	Type  FieldType // 値の型
+}
+
+// QuerySchema リソースごとに検索・ソート可能なフィールドを定義します
+//
+// Columns はフィールド名からSQLカラム式への対応で、フィルター・ソートに
+// 使用できるのはここに登録されたフィールドのみです。
+type QuerySchema struct {
+	Filters      map[string]FilterDef // クエリパラメータ名 → フィルター定義
+	Columns      map[string]string    // フィールド名 → SQLカラム
+	Sortable     []string             // ソート可能なフィールド名
+	DefaultSort  []SortField          // sort 未指定時の並び順
+	SearchColumn string               // 全文検索に使用する tsvector カラム（空なら検索不可）
+	SearchFields []string             // メモリ実装で全文検索の対象とするフィールド
+}
+
+// Filter 検索条件
+type Filter struct {
+	Field string
+	Op    FilterOp
+	Value interface{}
+}
+
+// SortField 並び順の指定
+type SortField struct {
+	Field string
+	Desc  bool
+}
+
+// QuerySpec 一覧取得の検索・ソート・ページング条件
+type QuerySpec struct {
+	Filters []Filter
+	Sort    []SortField
+	Search  string
+	Page    PageRequest
+
+	schema *QuerySchema
+}
+
+// reservedParams フィルター以外の予約済みクエリパラメータ
+var reservedParams = map[string]bool{
+	"limit":  true,
+	"offset": true,
+	"after":  true,
+	"sort":   true,
+	"q":      true,
+}
+
+// Parse クエリパラメータから QuerySpec を生成
+// スキーマに定義されていないパラメータやフィールドは validation_error になります
+func (s *QuerySchema) Parse(values url.Values) (*QuerySpec, error) {
+	page, err := ParsePageRequest(values)
+	if err != nil {
+		return nil, err
+	}
+
+	spec := &QuerySpec{Page: page, schema: s}
+
+	// 生成されるSQLを安定させるためパラメータ名順に処理
+	params := make([]string, 0, len(values))
+	for param := range values {
+		params = append(params, param)
+	}
+	sort.Strings(params)
+
+	for _, param := range params {
+		if reservedParams[param] {
+			continue
+		}
+		def, ok := s.Filters[param]
+		if !ok {
+			return nil, models.NewValidationError(param, models.ValidationCodeInvalid, "query.unknown_filter", nil)
+		}
+		value, err := parseFilterValue(def.Type, values.Get(param))
+		if err != nil {
+			return nil, models.NewValidationError(param, models.ValidationCodeInvalid, "query.invalid_filter_value", nil)
+		}
+		spec.Filters = append(spec.Filters, Filter{Field: def.Field, Op: def.Op, Value: value})
+	}
+
+	spec.Sort, err = s.parseSort(values.Get("sort"))
+	if err != nil {
+		return nil, err
+	}
+
+	if q := strings.TrimSpace(values.Get("q")); q != "" {
+		if s.SearchColumn == "" {
+			return nil, models.NewValidationError("q", models.ValidationCodeInvalid, "query.search_unsupported", nil)
+		}
+		spec.Search = q
+	}
+
+	// キーセットカーソルは (created_at, id) の降順にのみ対応
+	if spec.Page.After != nil && !spec.IsDefaultSort() {
+		return nil, models.NewValidationError("after", models.ValidationCodeInvalid, "query.cursor_requires_default_sort", nil)
+	}
+
+	return spec, nil
+}
+
+// parseSort "-created_at,name" 形式のソート指定をパース
+func (s *QuerySchema) parseSort(raw string) ([]SortField, error) {
+	if raw == "" {
+		return s.DefaultSort, nil
+	}
+
+	var fields []SortField
+	for _, part := range strings.Split(raw, ",") {
+		part = strings.TrimSpace(part)
+		field := SortField{Field: strings.TrimPrefix(part, "-"), Desc: strings.HasPrefix(part, "-")}
+		if !s.isSortable(field.Field) {
+			return nil, models.NewValidationError("sort", models.ValidationCodeInvalid, "query.unknown_sort", map[string]interface{}{"name": field.Field})
+		}
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+// isSortable ソート可能なフィールドか判定
+func (s *QuerySchema) isSortable(field string) bool {
+	for _, sortable := range s.Sortable {
+		if sortable == field {
+			return true
+		}
+	}
+	return false
+}
+
+// parseFilterValue フィルター値を型に応じて変換
+func parseFilterValue(fieldType FieldType, raw string) (interface{}, error) {
+	switch fieldType {
+	case FieldTime:
+		for _, layout := range []string{time.RFC3339Nano, "2006-01-02"} {
+			if t, err := time.Parse(layout, raw); err == nil {
+				return t, nil
+			}
+		}
+		return nil, fmt.Errorf("invalid time: %s", raw)
+	case FieldInt:
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			return nil, err
+		}
+		return n, nil
+	default:
+		if raw == "" {
+			return nil, fmt.Errorf("empty value")
+		}
+		return raw, nil
+	}
+}
+
+// IsDefaultSort スキーマのデフォルトの並び順か判定
+func (q *QuerySpec) IsDefaultSort() bool {
+	if len(q.Sort) != len(q.schema.DefaultSort) {
+		return false
+	}
+	for i, field := range q.Sort {
+		if field != q.schema.DefaultSort[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// WhereClause フィルター・全文検索・カーソル条件からWHERE句を生成
+// args に追加されたプレースホルダー引数は $n 形式で参照されます
+func (q *QuerySpec) WhereClause(args *[]interface{}, withCursor bool) string {
+	var conditions []string
+
+	placeholder := func(value interface{}) string {
+		*args = append(*args, value)
+		return fmt.Sprintf("$%d", len(*args))
+	}
+
+	for _, filter := range q.Filters {
+		conditions = append(conditions, fmt.Sprintf("%s %s %s", q.schema.Columns[filter.Field], filter.Op, placeholder(filter.Value)))
+	}
+
+	if q.Search != "" {
+		conditions = append(conditions, fmt.Sprintf("%s @@ websearch_to_tsquery('simple', %s)", q.schema.SearchColumn, placeholder(q.Search)))
+	}
+
+	if withCursor && q.Page.After != nil {
+		conditions = append(conditions, fmt.Sprintf("(%s, %s) < (%s, %s)",
+			q.schema.Columns["created_at"], q.schema.Columns["id"],
+			placeholder(q.Page.After.CreatedAt), placeholder(q.Page.After.ID)))
+	}
+
+	if len(conditions) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(conditions, " AND ")
+}
+
+// OrderClause ソート指定からORDER BY句を生成（同順位はid降順で安定化）
+func (q *QuerySpec) OrderClause() string {
+	var orders []string
+	hasID := false
+	for _, field := range q.Sort {
+		direction := "ASC"
+		if field.Desc {
+			direction = "DESC"
+		}
+		orders = append(orders, q.schema.Columns[field.Field]+" "+direction)
+		hasID = hasID || field.Field == "id"
+	}
+	if !hasID {
+		orders = append(orders, q.schema.Columns["id"]+" DESC")
+	}
+	return " ORDER BY " + strings.Join(orders, ", ")
+}
@@ -0,0 +1,152 @@
+package services
+
+import (
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// applyQuerySpec メモリ上のデータに QuerySpec を適用してページを返す
+//
+// PostgreSQL 実装と同じ結果になるよう、フィルター・全文検索・ソート
+// （同順位はid降順）・カーソル・オフセットを順に評価します。
+// fields はレコードのフィールド名から値への対応を返す関数です。
+func applyQuerySpec[T any](items []T, spec *QuerySpec, fields func(T) map[string]interface{}, cursor func(T) Cursor) *Page[T] {
+	type record struct {
+		item   T
+		fields map[string]interface{}
+	}
+
+	matched := make([]record, 0, len(items))
+	for _, item := range items {
+		values := fields(item)
+		if spec.matchFilters(values) && spec.matchSearch(values) {
+			matched = append(matched, record{item: item, fields: values})
+		}
+	}
+
+	sort.SliceStable(matched, func(i, j int) bool {
+		return spec.less(matched[i].fields, matched[j].fields)
+	})
+
+	total := len(matched)
+
+	if after := spec.Page.After; after != nil {
+		start := len(matched)
+		for i, rec := range matched {
+			c := cursor(rec.item)
+			if c.CreatedAt.Before(after.CreatedAt) || (c.CreatedAt.Equal(after.CreatedAt) && c.ID < after.ID) {
+				start = i
+				break
+			}
+		}
+		matched = matched[start:]
+	}
+
+	if spec.Page.Offset < len(matched) {
+		matched = matched[spec.Page.Offset:]
+	} else {
+		matched = matched[:0]
+	}
+
+	if len(matched) > spec.Page.Limit+1 {
+		matched = matched[:spec.Page.Limit+1]
+	}
+
+	result := make([]T, len(matched))
+	for i, rec := range matched {
+		result[i] = rec.item
+	}
+
+	return newPage(spec, result, total, cursor)
+}
+
+// matchFilters 全てのフィルター条件を満たすか判定
+func (q *QuerySpec) matchFilters(values map[string]interface{}) bool {
+	for _, filter := range q.Filters {
+		cmp := compareValues(values[filter.Field], filter.Value)
+		var ok bool
+		switch filter.Op {
+		case FilterEq:
+			ok = cmp == 0
+		case FilterGt:
+			ok = cmp > 0
+		case FilterGte:
+			ok = cmp >= 0
+		case FilterLt:
+			ok = cmp < 0
+		case FilterLte:
+			ok = cmp <= 0
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// matchSearch websearch_to_tsquery('simple', ...) を近似した全文検索
+// 各語は単語単位で大文字小文字を区別せず一致し、"-" で始まる語は除外条件になります
+func (q *QuerySpec) matchSearch(values map[string]interface{}) bool {
+	if q.Search == "" {
+		return true
+	}
+
+	lexemes := map[string]bool{}
+	for _, field := range q.schema.SearchFields {
+		if text, ok := values[field].(string); ok {
+			for _, word := range tokenize(text) {
+				lexemes[word] = true
+			}
+		}
+	}
+
+	for _, term := range strings.Fields(q.Search) {
+		negate := strings.HasPrefix(term, "-")
+		for _, word := range tokenize(strings.TrimPrefix(term, "-")) {
+			if lexemes[word] == negate {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// less ソート指定に従った比較（同順位はid降順）
+func (q *QuerySpec) less(a, b map[string]interface{}) bool {
+	for _, field := range q.Sort {
+		cmp := compareValues(a[field.Field], b[field.Field])
+		if cmp == 0 {
+			continue
+		}
+		if field.Desc {
+			return cmp > 0
+		}
+		return cmp < 0
+	}
+	return compareValues(a["id"], b["id"]) > 0
+}
+
+// tokenize 'simple' 設定と同様に英数字の連続を小文字の語に分割
+func tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
+
+// compareValues 同じ型の値を比較（a<b: 負, a==b: 0, a>b: 正）
+func compareValues(a, b interface{}) int {
+	switch av := a.(type) {
+	case string:
+		bv, _ := b.(string)
+		return strings.Compare(av, bv)
+	case int:
+		bv, _ := b.(int)
+		return av - bv
+	case time.Time:
+		bv, _ := b.(time.Time)
+		return av.Compare(bv)
+	}
+	return 0
+}
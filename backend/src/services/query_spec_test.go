@@ -0,0 +1,145 @@
+package services
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"backend/models"
+)
+
+// TestQuerySchemaParse 検索条件パースのテスト
+func TestQuerySchemaParse(t *testing.T) {
+	cursor := Cursor{CreatedAt: time.Now().UTC(), ID: 1}.Encode()
+
+	tests := []struct {
+		name       string
+		query      string
+		wantErr    string // 期待するValidationErrorのField（空ならエラーなし）
+		wantFilter int
+	}{
+		{"No params", "", "", 0},
+		{"Name filter", "name=Alice", "", 1},
+		{"Date range", "created_after=2025-01-01&created_before=2025-12-31T00:00:00Z", "", 2},
+		{"Sort and search", "sort=-created_at,name&q=hello", "", 0},
+		{"Cursor with default sort", "sort=-created_at&after=" + cursor, "", 0},
+		{"Unknown filter", "email=a@example.com", "email", 0},
+		{"Unknown sort field", "sort=message", "sort", 0},
+		{"Invalid date", "created_after=yesterday", "created_after", 0},
+		{"Empty name", "name=", "name", 0},
+		{"Cursor with custom sort", "sort=name&after=" + cursor, "after", 0},
+		{"Invalid limit", "limit=0", "limit", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, _ := url.ParseQuery(tt.query)
+			spec, err := HelloWorldMessageQuerySchema.Parse(values)
+
+			if tt.wantErr != "" {
+				validationErr, ok := err.(*models.ValidationError)
+				if !ok {
+					t.Fatalf("Expected *models.ValidationError, got %v", err)
+				}
+				if validationErr.Field != tt.wantErr {
+					t.Errorf("Expected field '%s', got '%s'", tt.wantErr, validationErr.Field)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if len(spec.Filters) != tt.wantFilter {
+				t.Errorf("Expected %d filters, got %d", tt.wantFilter, len(spec.Filters))
+			}
+		})
+	}
+}
+
+// TestQuerySchemaParseInt 整数フィルターのパースのテスト（末尾に数字以外を含む値は拒否する）
+func TestQuerySchemaParseInt(t *testing.T) {
+	for _, raw := range []string{"12abc", "1.5", " 12", ""} {
+		_, err := MailQuerySchema.Parse(url.Values{"thread_id": {raw}})
+		validationErr, ok := err.(*models.ValidationError)
+		if !ok || validationErr.Field != "thread_id" {
+			t.Errorf("thread_id=%q: Expected validation error, got %v", raw, err)
+		}
+	}
+
+	spec, err := MailQuerySchema.Parse(url.Values{"thread_id": {"12"}})
+	if err != nil || len(spec.Filters) != 1 || spec.Filters[0].Value != 12 {
+		t.Errorf("Parse() = %+v, %v", spec, err)
+	}
+}
+
+// TestQuerySpecSQL WHERE句・ORDER BY句生成のテスト
+func TestQuerySpecSQL(t *testing.T) {
+	after := &Cursor{CreatedAt: time.Now().UTC(), ID: 5}
+
+	tests := []struct {
+		name          string
+		query         url.Values
+		withCursor    bool
+		expectedWhere string
+		expectedOrder string
+		expectedArgs  int
+	}{
+		{
+			name:          "Defaults",
+			query:         url.Values{},
+			expectedWhere: "",
+			expectedOrder: " ORDER BY created_at DESC, id DESC",
+		},
+		{
+			name:          "Filters and search",
+			query:         url.Values{"name": {"Alice"}, "created_after": {"2025-01-01"}, "q": {"hello"}},
+			expectedWhere: " WHERE created_at > $1 AND name = $2 AND search_vector @@ websearch_to_tsquery('simple', $3)",
+			expectedOrder: " ORDER BY created_at DESC, id DESC",
+			expectedArgs:  3,
+		},
+		{
+			name:          "Custom sort",
+			query:         url.Values{"sort": {"name,-id"}},
+			expectedWhere: "",
+			expectedOrder: " ORDER BY name ASC, id DESC",
+		},
+		{
+			name:          "Cursor",
+			query:         url.Values{"name": {"Alice"}, "after": {after.Encode()}},
+			withCursor:    true,
+			expectedWhere: " WHERE name = $1 AND (created_at, id) < ($2, $3)",
+			expectedOrder: " ORDER BY created_at DESC, id DESC",
+			expectedArgs:  3,
+		},
+		{
+			name:          "Cursor excluded from count",
+			query:         url.Values{"after": {after.Encode()}},
+			withCursor:    false,
+			expectedWhere: "",
+			expectedOrder: " ORDER BY created_at DESC, id DESC",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec, err := HelloWorldMessageQuerySchema.Parse(tt.query)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+
+			var args []interface{}
+			where := spec.WhereClause(&args, tt.withCursor)
+
+			if where != tt.expectedWhere {
+				t.Errorf("Expected WHERE %q, got %q", tt.expectedWhere, where)
+			}
+			if order := spec.OrderClause(); order != tt.expectedOrder {
+				t.Errorf("Expected ORDER BY %q, got %q", tt.expectedOrder, order)
+			}
+			if len(args) != tt.expectedArgs {
+				t.Errorf("Expected %d args, got %d", tt.expectedArgs, len(args))
+			}
+		})
+	}
+}
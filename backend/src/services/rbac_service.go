@@ -0,0 +1,62 @@
+package services
+
+import "backend/models"
+
+// RBACService ロールの参照と付与・剥奪
+type RBACService struct {
+	roles RoleRepository
+	users UserRepository
+}
+
+// NewRBACService RBACサービスを新規作成
+func NewRBACService(roles RoleRepository, users UserRepository) *RBACService {
+	return &RBACService{
+		roles: roles,
+		users: users,
+	}
+}
+
+// ListRoles 全ロールを権限付きで取得
+func (s *RBACService) ListRoles() ([]models.Role, error) {
+	return s.roles.ListRoles()
+}
+
+// GetUserRoles ユーザーのロールを取得
+func (s *RBACService) GetUserRoles(userID int) (*models.UserRoles, error) {
+	if _, err := s.users.FindByID(userID); err != nil {
+		return nil, err
+	}
+	return s.userRoles(userID)
+}
+
+// AssignRole ユーザーにロールを付与
+func (s *RBACService) AssignRole(userID int, role string) (*models.UserRoles, error) {
+	if _, err := s.users.FindByID(userID); err != nil {
+		return nil, err
+	}
+	if err := s.roles.AssignRole(userID, role); err != nil {
+		return nil, err
+	}
+	return s.userRoles(userID)
+}
+
+// RevokeRole ユーザーからロールを剥奪
+// 誰もロールを管理できなくなるのを防ぐため、最後の owner は剥奪できません（ErrLastOwner）
+func (s *RBACService) RevokeRole(userID int, role string) (*models.UserRoles, error) {
+	if _, err := s.users.FindByID(userID); err != nil {
+		return nil, err
+	}
+	if err := s.roles.RevokeRole(userID, role); err != nil {
+		return nil, err
+	}
+	return s.userRoles(userID)
+}
+
+// userRoles ユーザーのロール一覧を組み立てる
+func (s *RBACService) userRoles(userID int) (*models.UserRoles, error) {
+	roles, err := s.roles.RolesForUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	return &models.UserRoles{UserID: userID, Roles: roles}, nil
+}
@@ -0,0 +1,63 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"backend/models"
+)
+
+// newTestRBACService メモリリポジトリを使用するテスト用RBACサービスと、owner・member のユーザーID
+func newTestRBACService(t *testing.T) (*RBACService, int, int) {
+	t.Helper()
+	users := NewMemoryUserRepository()
+	roles := NewMemoryRoleRepository()
+
+	owner, _ := users.Create("owner@example.com", "Owner", "hash")
+	member, _ := users.Create("member@example.com", "Member", "hash")
+	roles.AssignDefaultRole(owner.ID)
+	roles.AssignDefaultRole(member.ID)
+
+	return NewRBACService(roles, users), owner.ID, member.ID
+}
+
+// TestRBACServiceAssignAndRevoke ロール付与・剥奪のテスト
+func TestRBACServiceAssignAndRevoke(t *testing.T) {
+	service, ownerID, memberID := newTestRBACService(t)
+
+	assigned, err := service.AssignRole(memberID, models.RoleOwner)
+	if err != nil {
+		t.Fatalf("AssignRole() error = %v", err)
+	}
+	if len(assigned.Roles) != 2 {
+		t.Errorf("付与後のロールが不正: %v", assigned.Roles)
+	}
+
+	// owner が2人いれば剥奪できる
+	revoked, err := service.RevokeRole(ownerID, models.RoleOwner)
+	if err != nil {
+		t.Fatalf("RevokeRole() error = %v", err)
+	}
+	if len(revoked.Roles) != 0 {
+		t.Errorf("剥奪後のロールが不正: %v", revoked.Roles)
+	}
+}
+
+// TestRBACServiceErrors ロール操作のエラーテスト
+func TestRBACServiceErrors(t *testing.T) {
+	service, ownerID, memberID := newTestRBACService(t)
+
+	if _, err := service.RevokeRole(ownerID, models.RoleOwner); !errors.Is(err, ErrLastOwner) {
+		t.Errorf("最後の owner を剥奪できてしまう: %v", err)
+	}
+	if _, err := service.AssignRole(memberID, "superuser"); !errors.Is(err, ErrRoleNotFound) {
+		t.Errorf("存在しないロールでErrRoleNotFoundが返らない: %v", err)
+	}
+	if _, err := service.AssignRole(999, models.RoleMember); !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("存在しないユーザーでErrUserNotFoundが返らない: %v", err)
+	}
+	// owner でないユーザーからの owner 剥奪は何もしない
+	if _, err := service.RevokeRole(memberID, models.RoleOwner); err != nil {
+		t.Errorf("未付与ロールの剥奪でエラー: %v", err)
+	}
+}
@@ -0,0 +1,465 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"backend/models"
+	"backend/utils"
+)
+
+// RealtimeHub WebSocket の接続をルームにまとめ、プレゼンス・入力中の表示・イベントを配信するハブ
+//
+// ルームへの参加・退出や入力中の通知はイベントバスを経由して全てのレプリカに届け、
+// 各レプリカはバスから受け取ったイベントでルームの参加者一覧（プレゼンス）を管理します。
+// 起動後に初めて参加があった時と LISTEN の再接続時には、他のレプリカに参加中の接続の再通知を求めます。
+//
+// 接続ごとの送信バッファが溢れた場合（受信の遅いクライアント）は送信を打ち切り、接続を終了させます。
+type RealtimeHub struct {
+	bus EventBus
+	id  string // 接続IDの接頭辞（起動ごとに異なる）
+
+	mu       sync.Mutex
+	nextConn uint64
+	clients  map[*RealtimeClient]struct{}
+	rooms    map[string]map[*RealtimeClient]struct{}   // このレプリカの接続が参加しているルーム
+	presence map[string]map[string]models.RealtimeUser // 全レプリカのルームの参加者（接続ID → ユーザー）
+	synced   bool                                      // 他のレプリカに再通知を求めたか
+	closed   bool
+}
+
+// RealtimeClient ハブに接続した1つのクライアント
+type RealtimeClient struct {
+	id      string
+	user    models.RealtimeUser
+	send    chan []byte
+	rooms   map[string]struct{} // 以下はハブのロックで保護
+	closed  bool
+	evicted bool
+}
+
+// realtimeClientBuffer 接続ごとの未送信メッセージの上限
+const realtimeClientBuffer = 64
+
+// realtimePresence presence.joined / presence.left / typing.* イベントのデータ
+type realtimePresence struct {
+	Conn string              `json:"conn"`
+	User models.RealtimeUser `json:"user"`
+}
+
+// realtimeSyncRequest presence.sync イベントのデータ
+type realtimeSyncRequest struct {
+	Hub string `json:"hub"`
+}
+
+// NewRealtimeHub リアルタイムハブを新規作成
+// bus の Subscribe に Dispatch を登録して使用します
+func NewRealtimeHub(bus EventBus) *RealtimeHub {
+	return &RealtimeHub{
+		bus:      bus,
+		id:       strconv.FormatInt(time.Now().UnixNano(), 36),
+		clients:  make(map[*RealtimeClient]struct{}),
+		rooms:    make(map[string]map[*RealtimeClient]struct{}),
+		presence: make(map[string]map[string]models.RealtimeUser),
+	}
+}
+
+// Connect ユーザーの接続をハブに登録
+// ハブが停止している場合は送信チャネルが閉じたクライアントを返します
+func (h *RealtimeHub) Connect(user *models.User) *RealtimeClient {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.nextConn++
+	client := &RealtimeClient{
+		id:    fmt.Sprintf("%s-%d", h.id, h.nextConn),
+		user:  models.RealtimeUser{ID: user.ID, Name: user.Name},
+		send:  make(chan []byte, realtimeClientBuffer),
+		rooms: make(map[string]struct{}),
+	}
+	if h.closed {
+		h.closeClient(client)
+		return client
+	}
+	h.clients[client] = struct{}{}
+	return client
+}
+
+// Disconnect 接続を解除し、参加中の全てのルームから退出
+func (h *RealtimeHub) Disconnect(client *RealtimeClient) {
+	h.mu.Lock()
+	if _, ok := h.clients[client]; !ok {
+		h.mu.Unlock()
+		return
+	}
+	delete(h.clients, client)
+	rooms := h.leaveAll(client)
+	h.closeClient(client)
+	h.mu.Unlock()
+
+	for _, room := range rooms {
+		h.bus.PublishToRoom(room, models.EventPresenceLeft, client.presence())
+	}
+}
+
+// Close 全ての接続を終了（グレースフルシャットダウン時に使用）
+// 他のレプリカの参加者一覧から外れるよう、参加中のルームからの退出を通知します
+func (h *RealtimeHub) Close() {
+	type membership struct {
+		room   string
+		client *RealtimeClient
+	}
+	var memberships []membership
+
+	h.mu.Lock()
+	h.closed = true
+	for client := range h.clients {
+		for _, room := range h.leaveAll(client) {
+			memberships = append(memberships, membership{room: room, client: client})
+		}
+		delete(h.clients, client)
+		h.closeClient(client)
+	}
+	h.mu.Unlock()
+
+	for _, m := range memberships {
+		h.bus.PublishToRoom(m.room, models.EventPresenceLeft, m.client.presence())
+	}
+}
+
+// Handle クライアントから受信したメッセージを処理
+// 不正なメッセージの場合は *models.ValidationError を返します
+func (h *RealtimeHub) Handle(client *RealtimeClient, message models.RealtimeMessage) error {
+	switch message.Type {
+	case models.RealtimeRoomJoin:
+		return h.join(client, message.Room)
+	case models.RealtimeRoomLeave:
+		return h.leave(client, message.Room)
+	case models.RealtimeTypingStart:
+		return h.typing(client, message.Room, models.EventTypingStarted)
+	case models.RealtimeTypingStop:
+		return h.typing(client, message.Room, models.EventTypingStopped)
+	default:
+		return models.NewValidationError("type", models.ValidationCodeInvalid, "realtime.unknown_type", map[string]interface{}{"type": message.Type})
+	}
+}
+
+// Send クライアントにメッセージを送信（送信バッファが溢れた場合は接続を終了）
+func (h *RealtimeHub) Send(client *RealtimeClient, message models.RealtimeMessage) {
+	payload, err := json.Marshal(message)
+	if err != nil {
+		log.Printf("⚠️  Failed to encode %s message: %v", message.Type, err)
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.send(client, payload)
+}
+
+// Dispatch イベントバスから受け取ったイベントを接続に配信（EventBus.Subscribe に登録して使用）
+func (h *RealtimeHub) Dispatch(event models.Event) {
+	switch {
+	case event.Room == "":
+		h.dispatchUserEvent(event)
+	case event.Type == models.EventPresenceSync:
+		h.handleSync(event)
+	default:
+		h.dispatchRoomEvent(event)
+	}
+}
+
+// join ルームに参加し、参加者一覧を送信
+func (h *RealtimeHub) join(client *RealtimeClient, room string) error {
+	if err := models.ValidateRealtimeRoom(room); err != nil {
+		return err
+	}
+
+	h.mu.Lock()
+	_, joined := client.rooms[room]
+	if !joined && len(client.rooms) >= utils.MaxRealtimeRoomsPerClient {
+		h.mu.Unlock()
+		return models.NewValidationError("room", models.ValidationCodeInvalid, "realtime.too_many_rooms", map[string]interface{}{"max": utils.MaxRealtimeRoomsPerClient})
+	}
+	if !joined {
+		client.rooms[room] = struct{}{}
+		if h.rooms[room] == nil {
+			h.rooms[room] = make(map[*RealtimeClient]struct{})
+		}
+		h.rooms[room][client] = struct{}{}
+	}
+	// 参加済みの場合も参加者一覧を送り直す（stream.reset 後の再取得に使用）
+	h.sendMessage(client, models.RealtimeMessage{
+		Type: models.RealtimePresenceState,
+		Room: room,
+		Data: mustMarshal(models.RealtimePresenceStateData{Users: h.roomUsers(room, client)}),
+	})
+	requestSync := !h.synced
+	h.synced = true
+	h.mu.Unlock()
+
+	if !joined {
+		h.bus.PublishToRoom(room, models.EventPresenceJoined, client.presence())
+	}
+	if requestSync {
+		h.bus.PublishToRoom(models.RoomAll, models.EventPresenceSync, realtimeSyncRequest{Hub: h.id})
+	}
+	return nil
+}
+
+// leave ルームから退出
+func (h *RealtimeHub) leave(client *RealtimeClient, room string) error {
+	if err := h.requireJoined(client, room); err != nil {
+		return err
+	}
+
+	h.mu.Lock()
+	h.removeFromRoom(client, room)
+	h.mu.Unlock()
+
+	h.bus.PublishToRoom(room, models.EventPresenceLeft, client.presence())
+	return nil
+}
+
+// typing 入力中の開始・終了をルームの他の参加者に通知
+func (h *RealtimeHub) typing(client *RealtimeClient, room string, eventType models.EventType) error {
+	if err := h.requireJoined(client, room); err != nil {
+		return err
+	}
+	h.bus.PublishToRoom(room, eventType, client.presence())
+	return nil
+}
+
+// requireJoined クライアントがルームに参加しているか検証
+func (h *RealtimeHub) requireJoined(client *RealtimeClient, room string) error {
+	if err := models.ValidateRealtimeRoom(room); err != nil {
+		return err
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if _, ok := client.rooms[room]; !ok {
+		return models.NewValidationError("room", models.ValidationCodeInvalid, "realtime.not_joined", nil)
+	}
+	return nil
+}
+
+// dispatchUserEvent ユーザー宛て（または全ユーザー宛て）のイベントを配信
+func (h *RealtimeHub) dispatchUserEvent(event models.Event) {
+	payload, err := json.Marshal(models.RealtimeMessage{Type: models.RealtimeMessageType(event.Type), Data: event.Data})
+	if err != nil {
+		log.Printf("⚠️  Failed to encode %s event: %v", event.Type, err)
+		return
+	}
+
+	h.mu.Lock()
+	for client := range h.clients {
+		if event.UserID == 0 || event.UserID == client.user.ID {
+			h.send(client, payload)
+		}
+	}
+
+	// LISTEN の再接続時は切断中の参加・退出が届いていないため、他のレプリカの参加者を再通知してもらう
+	resync := event.Type == models.EventStreamReset && event.UserID == 0 && !h.closed
+	if resync {
+		for room, members := range h.presence {
+			for conn := range members {
+				if !h.isLocalConn(conn) {
+					delete(members, conn)
+				}
+			}
+			if len(members) == 0 {
+				delete(h.presence, room)
+			}
+		}
+	}
+	h.mu.Unlock()
+
+	if resync {
+		h.bus.PublishToRoom(models.RoomAll, models.EventPresenceSync, realtimeSyncRequest{Hub: h.id})
+	}
+}
+
+// dispatchRoomEvent ルーム内のイベントで参加者一覧を更新し、このレプリカの参加者に配信
+func (h *RealtimeHub) dispatchRoomEvent(event models.Event) {
+	var presence realtimePresence
+	if err := json.Unmarshal(event.Data, &presence); err != nil || presence.Conn == "" {
+		log.Printf("⚠️  Ignoring malformed %s event for room %q", event.Type, event.Room)
+		return
+	}
+	message := models.RealtimeMessage{Type: models.RealtimeMessageType(event.Type), Room: event.Room, Data: mustMarshal(presence.User)}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	members := h.presence[event.Room]
+
+	switch event.Type {
+	case models.EventPresenceJoined:
+		if _, ok := members[presence.Conn]; ok {
+			return
+		}
+		if members == nil {
+			members = make(map[string]models.RealtimeUser)
+			h.presence[event.Room] = members
+		}
+		present := hasRealtimeUser(members, presence.User.ID)
+		members[presence.Conn] = presence.User
+		if present {
+			// 同じユーザーの別の接続（複数のタブなど）
+			return
+		}
+	case models.EventPresenceLeft:
+		if _, ok := members[presence.Conn]; !ok {
+			return
+		}
+		delete(members, presence.Conn)
+		if len(members) == 0 {
+			delete(h.presence, event.Room)
+		}
+		if hasRealtimeUser(members, presence.User.ID) {
+			return
+		}
+	case models.EventTypingStarted, models.EventTypingStopped:
+	default:
+		return
+	}
+
+	payload := mustMarshal(message)
+	for client := range h.rooms[event.Room] {
+		if client.id != presence.Conn {
+			h.send(client, payload)
+		}
+	}
+}
+
+// handleSync 他のレプリカからの再通知の要求に応じて、このレプリカの参加中の接続を通知
+func (h *RealtimeHub) handleSync(event models.Event) {
+	var request realtimeSyncRequest
+	if err := json.Unmarshal(event.Data, &request); err != nil || request.Hub == h.id {
+		return
+	}
+
+	type membership struct {
+		room     string
+		presence realtimePresence
+	}
+	var memberships []membership
+	h.mu.Lock()
+	for room, clients := range h.rooms {
+		for client := range clients {
+			memberships = append(memberships, membership{room: room, presence: client.presence()})
+		}
+	}
+	h.mu.Unlock()
+
+	for _, m := range memberships {
+		h.bus.PublishToRoom(m.room, models.EventPresenceJoined, m.presence)
+	}
+}
+
+// roomUsers ルームの参加者一覧（ユーザーID順、self を含む）（呼び出し側でロックを取得すること）
+func (h *RealtimeHub) roomUsers(room string, self *RealtimeClient) []models.RealtimeUser {
+	byID := map[int]models.RealtimeUser{self.user.ID: self.user}
+	for _, user := range h.presence[room] {
+		byID[user.ID] = user
+	}
+	users := make([]models.RealtimeUser, 0, len(byID))
+	for _, user := range byID {
+		users = append(users, user)
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].ID < users[j].ID })
+	return users
+}
+
+// leaveAll クライアントを全てのルームから外し、退出したルームを返す（呼び出し側でロックを取得すること）
+func (h *RealtimeHub) leaveAll(client *RealtimeClient) []string {
+	rooms := make([]string, 0, len(client.rooms))
+	for room := range client.rooms {
+		h.removeFromRoom(client, room)
+		rooms = append(rooms, room)
+	}
+	sort.Strings(rooms)
+	return rooms
+}
+
+// removeFromRoom クライアントをルームから外す（呼び出し側でロックを取得すること）
+func (h *RealtimeHub) removeFromRoom(client *RealtimeClient, room string) {
+	delete(client.rooms, room)
+	delete(h.rooms[room], client)
+	if len(h.rooms[room]) == 0 {
+		delete(h.rooms, room)
+	}
+}
+
+// sendMessage メッセージをエンコードして送信（呼び出し側でロックを取得すること）
+func (h *RealtimeHub) sendMessage(client *RealtimeClient, message models.RealtimeMessage) {
+	h.send(client, mustMarshal(message))
+}
+
+// send 送信バッファに追加（呼び出し側でロックを取得すること）
+func (h *RealtimeHub) send(client *RealtimeClient, payload []byte) {
+	if client.closed {
+		return
+	}
+	select {
+	case client.send <- payload:
+	default:
+		// 受信が追いつかない接続は打ち切る（クライアントは再接続してルームに参加し直す）
+		log.Printf("⚠️  Closing slow WebSocket client %s (user %d)", client.id, client.user.ID)
+		client.evicted = true
+		h.closeClient(client)
+	}
+}
+
+// closeClient 送信チャネルを閉じる（呼び出し側でロックを取得すること）
+func (h *RealtimeHub) closeClient(client *RealtimeClient) {
+	if !client.closed {
+		client.closed = true
+		close(client.send)
+	}
+}
+
+// isLocalConn このレプリカの接続IDか判定
+func (h *RealtimeHub) isLocalConn(conn string) bool {
+	return strings.HasPrefix(conn, h.id+"-")
+}
+
+// Messages 送信するメッセージ（JSON）を受け取るチャネル（接続の終了時に閉じられる）
+func (c *RealtimeClient) Messages() <-chan []byte {
+	return c.send
+}
+
+// Evicted 受信の遅れにより送信を打ち切られたか（Messages が閉じられた後に参照すること）
+func (c *RealtimeClient) Evicted() bool {
+	return c.evicted
+}
+
+// presence バスに流す接続の情報
+func (c *RealtimeClient) presence() realtimePresence {
+	return realtimePresence{Conn: c.id, User: c.user}
+}
+
+// hasRealtimeUser 参加者にユーザーが含まれるか判定
+func hasRealtimeUser(members map[string]models.RealtimeUser, userID int) bool {
+	for _, user := range members {
+		if user.ID == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// mustMarshal 常にエンコードできる値をJSONにエンコード
+func mustMarshal(v interface{}) json.RawMessage {
+	data, err := json.Marshal(v)
+	if err != nil {
+		panic(fmt.Sprintf("failed to encode %T: %v", v, err))
+	}
+	return data
+}
@@ -0,0 +1,218 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"backend/models"
+	"backend/utils"
+)
+
+// newTestRealtimeHub メモリイベントバスから配信を受けるハブを作成
+// 同じバスに複数のハブを登録すると、レプリカ間の配信を再現できます
+func newTestRealtimeHub(bus *MemoryEventBus) *RealtimeHub {
+	hub := NewRealtimeHub(bus)
+	bus.Subscribe(hub.Dispatch)
+	return hub
+}
+
+// receiveRealtime クライアントに送信されたメッセージを1件受信（一定時間内に届かなければ失敗）
+func receiveRealtime(t *testing.T, client *RealtimeClient) models.RealtimeMessage {
+	t.Helper()
+	select {
+	case payload, ok := <-client.Messages():
+		if !ok {
+			t.Fatal("接続が終了している")
+		}
+		var message models.RealtimeMessage
+		if err := json.Unmarshal(payload, &message); err != nil {
+			t.Fatalf("メッセージのデコード失敗: %v", err)
+		}
+		return message
+	case <-time.After(time.Second):
+		t.Fatal("メッセージが送信されない")
+	}
+	return models.RealtimeMessage{}
+}
+
+// expectNoRealtime 未送信のメッセージがないことを確認
+func expectNoRealtime(t *testing.T, client *RealtimeClient) {
+	t.Helper()
+	select {
+	case payload := <-client.Messages():
+		t.Errorf("予期しないメッセージ: %s", payload)
+	default:
+	}
+}
+
+// joinRoom ルームに参加し、参加者一覧を受信
+func joinRoom(t *testing.T, hub *RealtimeHub, client *RealtimeClient, room string) []models.RealtimeUser {
+	t.Helper()
+	if err := hub.Handle(client, models.RealtimeMessage{Type: models.RealtimeRoomJoin, Room: room}); err != nil {
+		t.Fatalf("room.join error = %v", err)
+	}
+	message := receiveRealtime(t, client)
+	var state models.RealtimePresenceStateData
+	if message.Type != models.RealtimePresenceState || message.Room != room || json.Unmarshal(message.Data, &state) != nil {
+		t.Fatalf("参加者一覧が不正: %+v", message)
+	}
+	return state.Users
+}
+
+// TestRealtimeHubPresence レプリカをまたいだプレゼンスと入力中の表示のテスト
+func TestRealtimeHubPresence(t *testing.T) {
+	bus := NewMemoryEventBus()
+	replicaA := newTestRealtimeHub(bus)
+	replicaB := newTestRealtimeHub(bus)
+	alice := replicaA.Connect(&models.User{ID: 1, Name: "Alice"})
+	bob := replicaB.Connect(&models.User{ID: 2, Name: "Bob"})
+
+	if users := joinRoom(t, replicaA, alice, "customer:42"); fmt.Sprint(users) != "[{1 Alice}]" {
+		t.Errorf("alice の参加者一覧が不正: %v", users)
+	}
+	if users := joinRoom(t, replicaB, bob, "customer:42"); fmt.Sprint(users) != "[{1 Alice} {2 Bob}]" {
+		t.Errorf("bob の参加者一覧が不正: %v", users)
+	}
+	if message := receiveRealtime(t, alice); message.Type != models.RealtimeMessageType(models.EventPresenceJoined) || message.Room != "customer:42" || string(message.Data) != `{"id":2,"name":"Bob"}` {
+		t.Errorf("alice への参加通知が不正: %+v", message)
+	}
+	expectNoRealtime(t, bob)
+
+	// 入力中の通知は送信者以外の参加者に届く
+	if err := replicaB.Handle(bob, models.RealtimeMessage{Type: models.RealtimeTypingStart, Room: "customer:42"}); err != nil {
+		t.Fatalf("typing.start error = %v", err)
+	}
+	if message := receiveRealtime(t, alice); message.Type != models.RealtimeMessageType(models.EventTypingStarted) || string(message.Data) != `{"id":2,"name":"Bob"}` {
+		t.Errorf("入力中の通知が不正: %+v", message)
+	}
+	expectNoRealtime(t, bob)
+
+	// 同じユーザーの別の接続では参加・退出を通知しない
+	aliceTab := replicaB.Connect(&models.User{ID: 1, Name: "Alice"})
+	joinRoom(t, replicaB, aliceTab, "customer:42")
+	replicaB.Disconnect(aliceTab)
+	expectNoRealtime(t, alice)
+	expectNoRealtime(t, bob)
+
+	// 切断すると参加中のルームから退出する
+	replicaB.Disconnect(bob)
+	if message := receiveRealtime(t, alice); message.Type != models.RealtimeMessageType(models.EventPresenceLeft) || string(message.Data) != `{"id":2,"name":"Bob"}` {
+		t.Errorf("退出の通知が不正: %+v", message)
+	}
+	if _, ok := <-bob.Messages(); ok {
+		t.Error("切断した接続の送信チャネルが閉じられていない")
+	}
+}
+
+// TestRealtimeHubSync 後から起動したレプリカが既存の参加者を取得するテスト
+func TestRealtimeHubSync(t *testing.T) {
+	bus := NewMemoryEventBus()
+	replicaA := newTestRealtimeHub(bus)
+	alice := replicaA.Connect(&models.User{ID: 1, Name: "Alice"})
+	joinRoom(t, replicaA, alice, "inbox")
+
+	replicaB := newTestRealtimeHub(bus)
+	bob := replicaB.Connect(&models.User{ID: 2, Name: "Bob"})
+	// 参加時点では他のレプリカの参加者を知らないが、再通知により追加される
+	if users := joinRoom(t, replicaB, bob, "inbox"); fmt.Sprint(users) != "[{2 Bob}]" {
+		t.Errorf("bob の参加者一覧が不正: %v", users)
+	}
+	if message := receiveRealtime(t, bob); message.Type != models.RealtimeMessageType(models.EventPresenceJoined) || string(message.Data) != `{"id":1,"name":"Alice"}` {
+		t.Errorf("再通知された参加者が不正: %+v", message)
+	}
+	if users := joinRoom(t, replicaB, bob, "inbox"); fmt.Sprint(users) != "[{1 Alice} {2 Bob}]" {
+		t.Errorf("再参加時の参加者一覧が不正: %v", users)
+	}
+
+	// レプリカの停止時は参加中のルームからの退出を通知する
+	receiveRealtime(t, alice) // bob の参加
+	replicaA.Close()
+	if message := receiveRealtime(t, bob); message.Type != models.RealtimeMessageType(models.EventPresenceLeft) || string(message.Data) != `{"id":1,"name":"Alice"}` {
+		t.Errorf("停止したレプリカの退出の通知が不正: %+v", message)
+	}
+	if _, ok := <-alice.Messages(); ok {
+		t.Error("停止したハブの接続が閉じられていない")
+	}
+	if _, ok := <-replicaA.Connect(&models.User{ID: 3}).Messages(); ok {
+		t.Error("停止後の接続はすぐに閉じられるべき")
+	}
+}
+
+// TestRealtimeHubUserEvents イベントバスのイベントの宛先ごとの配信のテスト
+func TestRealtimeHubUserEvents(t *testing.T) {
+	bus := NewMemoryEventBus()
+	hub := newTestRealtimeHub(bus)
+	alice := hub.Connect(&models.User{ID: 1})
+	bob := hub.Connect(&models.User{ID: 2})
+
+	bus.Publish(models.EventNotificationCreated, 1, map[string]int{"id": 10})
+	bus.Publish(models.EventMessageDeleted, 0, models.EventResourceID{ID: 3})
+
+	if message := receiveRealtime(t, alice); message.Type != "notification.created" || message.Room != "" || string(message.Data) != `{"id":10}` {
+		t.Errorf("alice の1件目が不正: %+v", message)
+	}
+	if message := receiveRealtime(t, alice); message.Type != "message.deleted" {
+		t.Errorf("alice の2件目が不正: %+v", message)
+	}
+	if message := receiveRealtime(t, bob); message.Type != "message.deleted" {
+		t.Errorf("bob に届いたメッセージが不正: %+v", message)
+	}
+	expectNoRealtime(t, bob)
+}
+
+// TestRealtimeHubInvalidMessages 不正なメッセージの検証のテスト
+func TestRealtimeHubInvalidMessages(t *testing.T) {
+	hub := newTestRealtimeHub(NewMemoryEventBus())
+	client := hub.Connect(&models.User{ID: 1})
+
+	tests := []struct {
+		name    string
+		message models.RealtimeMessage
+	}{
+		{"Unknown type", models.RealtimeMessage{Type: "room.destroy", Room: "inbox"}},
+		{"Missing room", models.RealtimeMessage{Type: models.RealtimeRoomJoin}},
+		{"Invalid room", models.RealtimeMessage{Type: models.RealtimeRoomJoin, Room: "Customer 42"}},
+		{"Reserved room", models.RealtimeMessage{Type: models.RealtimeRoomJoin, Room: models.RoomAll}},
+		{"Typing without joining", models.RealtimeMessage{Type: models.RealtimeTypingStart, Room: "inbox"}},
+		{"Leave without joining", models.RealtimeMessage{Type: models.RealtimeRoomLeave, Room: "inbox"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var validationErr *models.ValidationError
+			if err := hub.Handle(client, tt.message); !errors.As(err, &validationErr) {
+				t.Errorf("Expected ValidationError, got %v", err)
+			}
+		})
+	}
+
+	for i := 0; i < utils.MaxRealtimeRoomsPerClient; i++ {
+		joinRoom(t, hub, client, fmt.Sprintf("customer:%d", i))
+	}
+	var validationErr *models.ValidationError
+	if err := hub.Handle(client, models.RealtimeMessage{Type: models.RealtimeRoomJoin, Room: "inbox"}); !errors.As(err, &validationErr) {
+		t.Errorf("ルーム数の上限を超えた参加がエラーにならない: %v", err)
+	}
+}
+
+// TestRealtimeHubSlowClient 受信の遅い接続の打ち切りのテスト
+func TestRealtimeHubSlowClient(t *testing.T) {
+	bus := NewMemoryEventBus()
+	hub := newTestRealtimeHub(bus)
+	slow := hub.Connect(&models.User{ID: 1})
+
+	for i := 0; i <= realtimeClientBuffer; i++ {
+		bus.Publish(models.EventMessageCreated, 0, map[string]int{"id": i})
+	}
+
+	received := 0
+	for range slow.Messages() {
+		received++
+	}
+	if received != realtimeClientBuffer || !slow.Evicted() {
+		t.Errorf("Expected eviction after %d messages, got %d (evicted=%v)", realtimeClientBuffer, received, slow.Evicted())
+	}
+	hub.Disconnect(slow)
+}
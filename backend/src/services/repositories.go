@@ -0,0 +1,75 @@
+package services
+
+import (
+	"database/sql"
+	"fmt"
+
+	"backend/utils"
+)
+
+// Repositories ストレージドライバーに応じたリポジトリ一式
+type Repositories struct {
+	HelloWorld    HelloWorldRepository
+	Users         UserRepository
+	Roles         RoleRepository
+	Accounts      AccountRepository
+	Sessions      SessionRepository
+	MFA           MFARepository
+	APIKeys       APIKeyRepository
+	Customers     CustomerRepository
+	Mails         MailRepository
+	Teams         TeamRepository
+	Notifications NotificationRepository
+	Preferences   NotificationPreferenceRepository
+	Sales         SaleRepository
+}
+
+// NewRepositories STORAGE_DRIVER に応じたリポジトリ一式を生成
+// memory の場合は db を使用せず、初期マイグレーションと同じサンプルデータを投入します
+func NewRepositories(driver string, db *sql.DB) (*Repositories, error) {
+	switch driver {
+	case utils.StorageDriverPostgres:
+		return &Repositories{
+			HelloWorld:    NewPostgresHelloWorldRepository(db),
+			Users:         NewPostgresUserRepository(db),
+			Roles:         NewPostgresRoleRepository(db),
+			Accounts:      NewPostgresAccountRepository(db),
+			Sessions:      NewPostgresSessionRepository(db),
+			MFA:           NewPostgresMFARepository(db),
+			APIKeys:       NewPostgresAPIKeyRepository(db),
+			Customers:     NewPostgresCustomerRepository(db),
+			Mails:         NewPostgresMailRepository(db),
+			Teams:         NewPostgresTeamRepository(db),
+			Notifications: NewPostgresNotificationRepository(db),
+			Preferences:   NewPostgresNotificationPreferenceRepository(db),
+			Sales:         NewPostgresSaleRepository(db),
+		}, nil
+	case utils.StorageDriverMemory:
+		helloWorld := NewMemoryHelloWorldRepository()
+		helloWorld.Create("Alice", "Hello, Alice!")
+		helloWorld.Create("Bob", "Hello, Bob!")
+		helloWorld.Create("Charlie", "Hello, Charlie!")
+
+		users := NewMemoryUserRepository()
+		roles := NewMemoryRoleRepository()
+		teams := NewMemoryTeamRepository(users)
+		return &Repositories{
+			HelloWorld:    helloWorld,
+			Users:         users,
+			Roles:         roles,
+			Accounts:      NewMemoryAccountRepository(users, roles, teams),
+			Sessions:      NewMemorySessionRepository(),
+			MFA:           NewMemoryMFARepository(),
+			APIKeys:       NewMemoryAPIKeyRepository(),
+			Customers:     NewMemoryCustomerRepository(),
+			Mails:         NewMemoryMailRepository(),
+			Teams:         teams,
+			Notifications: NewMemoryNotificationRepository(users),
+			Preferences:   NewMemoryNotificationPreferenceRepository(users),
+			Sales:         NewMemorySaleRepository(),
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown storage driver: %q (expected %q or %q)",
+			driver, utils.StorageDriverPostgres, utils.StorageDriverMemory)
+	}
+}
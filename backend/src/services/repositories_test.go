@@ -0,0 +1,111 @@
+package services
+
+import (
+	"testing"
+
+	"backend/utils"
+)
+
+// TestNewRepositories ストレージドライバーごとのリポジトリ生成テスト
+func TestNewRepositories(t *testing.T) {
+	t.Run("Postgres", func(t *testing.T) {
+		repos, err := NewRepositories(utils.StorageDriverPostgres, nil)
+		if err != nil {
+			t.Fatalf("NewRepositories() error = %v", err)
+		}
+		if _, ok := repos.HelloWorld.(*PostgresHelloWorldRepository); !ok {
+			t.Errorf("Expected *PostgresHelloWorldRepository, got %T", repos.HelloWorld)
+		}
+		if _, ok := repos.Users.(*PostgresUserRepository); !ok {
+			t.Errorf("Expected *PostgresUserRepository, got %T", repos.Users)
+		}
+		if _, ok := repos.Roles.(*PostgresRoleRepository); !ok {
+			t.Errorf("Expected *PostgresRoleRepository, got %T", repos.Roles)
+		}
+		if _, ok := repos.Sessions.(*PostgresSessionRepository); !ok {
+			t.Errorf("Expected *PostgresSessionRepository, got %T", repos.Sessions)
+		}
+		if _, ok := repos.MFA.(*PostgresMFARepository); !ok {
+			t.Errorf("Expected *PostgresMFARepository, got %T", repos.MFA)
+		}
+		if _, ok := repos.APIKeys.(*PostgresAPIKeyRepository); !ok {
+			t.Errorf("Expected *PostgresAPIKeyRepository, got %T", repos.APIKeys)
+		}
+		if _, ok := repos.Customers.(*PostgresCustomerRepository); !ok {
+			t.Errorf("Expected *PostgresCustomerRepository, got %T", repos.Customers)
+		}
+		if _, ok := repos.Mails.(*PostgresMailRepository); !ok {
+			t.Errorf("Expected *PostgresMailRepository, got %T", repos.Mails)
+		}
+		if _, ok := repos.Teams.(*PostgresTeamRepository); !ok {
+			t.Errorf("Expected *PostgresTeamRepository, got %T", repos.Teams)
+		}
+		if _, ok := repos.Notifications.(*PostgresNotificationRepository); !ok {
+			t.Errorf("Expected *PostgresNotificationRepository, got %T", repos.Notifications)
+		}
+		if _, ok := repos.Preferences.(*PostgresNotificationPreferenceRepository); !ok {
+			t.Errorf("Expected *PostgresNotificationPreferenceRepository, got %T", repos.Preferences)
+		}
+		if _, ok := repos.Sales.(*PostgresSaleRepository); !ok {
+			t.Errorf("Expected *PostgresSaleRepository, got %T", repos.Sales)
+		}
+	})
+
+	t.Run("Memory", func(t *testing.T) {
+		repos, err := NewRepositories(utils.StorageDriverMemory, nil)
+		if err != nil {
+			t.Fatalf("NewRepositories() error = %v", err)
+		}
+		if _, ok := repos.HelloWorld.(*MemoryHelloWorldRepository); !ok {
+			t.Errorf("Expected *MemoryHelloWorldRepository, got %T", repos.HelloWorld)
+		}
+		if _, ok := repos.Users.(*MemoryUserRepository); !ok {
+			t.Errorf("Expected *MemoryUserRepository, got %T", repos.Users)
+		}
+		if _, ok := repos.Roles.(*MemoryRoleRepository); !ok {
+			t.Errorf("Expected *MemoryRoleRepository, got %T", repos.Roles)
+		}
+		if _, ok := repos.Sessions.(*MemorySessionRepository); !ok {
+			t.Errorf("Expected *MemorySessionRepository, got %T", repos.Sessions)
+		}
+		if _, ok := repos.MFA.(*MemoryMFARepository); !ok {
+			t.Errorf("Expected *MemoryMFARepository, got %T", repos.MFA)
+		}
+		if _, ok := repos.APIKeys.(*MemoryAPIKeyRepository); !ok {
+			t.Errorf("Expected *MemoryAPIKeyRepository, got %T", repos.APIKeys)
+		}
+		if _, ok := repos.Customers.(*MemoryCustomerRepository); !ok {
+			t.Errorf("Expected *MemoryCustomerRepository, got %T", repos.Customers)
+		}
+		if _, ok := repos.Mails.(*MemoryMailRepository); !ok {
+			t.Errorf("Expected *MemoryMailRepository, got %T", repos.Mails)
+		}
+		if _, ok := repos.Teams.(*MemoryTeamRepository); !ok {
+			t.Errorf("Expected *MemoryTeamRepository, got %T", repos.Teams)
+		}
+		if _, ok := repos.Notifications.(*MemoryNotificationRepository); !ok {
+			t.Errorf("Expected *MemoryNotificationRepository, got %T", repos.Notifications)
+		}
+		if _, ok := repos.Preferences.(*MemoryNotificationPreferenceRepository); !ok {
+			t.Errorf("Expected *MemoryNotificationPreferenceRepository, got %T", repos.Preferences)
+		}
+		if _, ok := repos.Sales.(*MemorySaleRepository); !ok {
+			t.Errorf("Expected *MemorySaleRepository, got %T", repos.Sales)
+		}
+
+		// サンプルデータが投入されている
+		messages, err := repos.HelloWorld.FindAll()
+		if err != nil {
+			t.Fatalf("FindAll() error = %v", err)
+		}
+		if len(messages) != 3 {
+			t.Errorf("Expected 3 seeded messages, got %d", len(messages))
+		}
+	})
+
+	t.Run("Unknown", func(t *testing.T) {
+		if _, err := NewRepositories("sqlite", nil); err == nil {
+			t.Error("Expected error for unknown storage driver")
+		}
+	})
+}
@@ -0,0 +1,61 @@
+package services
+
+import "backend/models"
+
+// ErrRoleNotFound 指定されたロールが存在しない
+var ErrRoleNotFound = newError(ErrNotFound, "role not found", "role.not_found")
+
+// ErrLastOwner 最後の owner からロールを剥奪しようとした
+var ErrLastOwner = newError(ErrConflict, "cannot revoke the last owner", "role.last_owner")
+
+// DefaultRoles 初期ロールと権限の対応（マイグレーション 005・009・010・014 と同じ内容）
+var DefaultRoles = []models.Role{
+	{
+		Name:        models.RoleOwner,
+		Description: "Full access including role management",
+		Permissions: []string{
+			models.PermissionCustomersDelete,
+			models.PermissionCustomersWrite,
+			models.PermissionMailsWrite,
+			models.PermissionMessagesCreate,
+			models.PermissionMessagesDelete,
+			models.PermissionMessagesUpdate,
+			models.PermissionRolesManage,
+			models.PermissionSalesRefund,
+			models.PermissionSalesWrite,
+		},
+	},
+	{
+		Name:        models.RoleMember,
+		Description: "Can create and update messages",
+		Permissions: []string{
+			models.PermissionCustomersWrite,
+			models.PermissionMessagesCreate,
+			models.PermissionMessagesUpdate,
+		},
+	},
+}
+
+// RoleRepository ロール・権限・ユーザーロールの永続化インターフェース
+//
+// ロールは DefaultRoles の順、権限は名前順で返します。
+type RoleRepository interface {
+	// ListRoles 全ロールを権限付きで返す
+	ListRoles() ([]models.Role, error)
+	// RolesForUser ユーザーに付与されたロール名を返す
+	RolesForUser(userID int) ([]string, error)
+	// PermissionsForUser ユーザーのロールから導かれる権限名を重複なく返す
+	PermissionsForUser(userID int) ([]string, error)
+	// AssignRole ユーザーにロールを付与する（付与済みの場合は何もしない）
+	// ロールが存在しない場合は ErrRoleNotFound を返す
+	AssignRole(userID int, role string) error
+	// RevokeRole ユーザーからロールを剥奪する（未付与の場合は何もしない）
+	// ロールが存在しない場合は ErrRoleNotFound、最後の owner から owner を剥奪する場合は ErrLastOwner を返す
+	// owner の判定と剥奪は同時に実行されても owner が0人にならないよう不可分に行う
+	RevokeRole(userID int, role string) error
+	// AssignDefaultRole 新規ユーザーに初期ロールを付与し、そのロール名を返す
+	// owner がまだ存在しなければ owner、存在すれば member を付与する
+	AssignDefaultRole(userID int) (string, error)
+	// CountUsersWithRole ロールを持つユーザー数を返す
+	CountUsersWithRole(role string) (int, error)
+}
@@ -0,0 +1,183 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"backend/models"
+)
+
+// TestMemoryRoleRepositoryConformance メモリロールリポジトリの適合テスト
+func TestMemoryRoleRepositoryConformance(t *testing.T) {
+	runRoleRepositoryConformance(t, func(t *testing.T) (RoleRepository, UserRepository) {
+		return NewMemoryRoleRepository(), NewMemoryUserRepository()
+	})
+}
+
+// TestPostgresRoleRepositoryConformance PostgreSQLロールリポジトリの適合テスト
+func TestPostgresRoleRepositoryConformance(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	runRoleRepositoryConformance(t, func(t *testing.T) (RoleRepository, UserRepository) {
+		return NewPostgresRoleRepository(db), NewPostgresUserRepository(db)
+	})
+}
+
+// runRoleRepositoryConformance 全てのRoleRepository実装が満たすべき振る舞い
+// 共有DBでも動作するよう、ユーザーは一意なメールアドレスで都度作成します
+func runRoleRepositoryConformance(t *testing.T, newRepos func(t *testing.T) (RoleRepository, UserRepository)) {
+	createUser := func(t *testing.T, users UserRepository) int {
+		t.Helper()
+		user, err := users.Create(fmt.Sprintf("role%d@example.com", time.Now().UnixNano()), "Role", "hash")
+		if err != nil {
+			t.Fatalf("ユーザー作成失敗: %v", err)
+		}
+		return user.ID
+	}
+
+	t.Run("ListRoles", func(t *testing.T) {
+		repo, _ := newRepos(t)
+
+		roles, err := repo.ListRoles()
+		if err != nil {
+			t.Fatalf("ListRoles失敗: %v", err)
+		}
+		byName := make(map[string][]string)
+		for _, role := range roles {
+			byName[role.Name] = role.Permissions
+		}
+		wantOwner := []string{"customers:delete", "customers:write", "mails:write", "messages:create", "messages:delete", "messages:update", "roles:manage", "sales:refund", "sales:write"}
+		if !reflect.DeepEqual(byName[models.RoleOwner], wantOwner) {
+			t.Errorf("owner の権限が不正: %v", byName[models.RoleOwner])
+		}
+		wantMember := []string{"customers:write", "messages:create", "messages:update"}
+		if !reflect.DeepEqual(byName[models.RoleMember], wantMember) {
+			t.Errorf("member の権限が不正: %v", byName[models.RoleMember])
+		}
+	})
+
+	t.Run("AssignAndRevoke", func(t *testing.T) {
+		repo, users := newRepos(t)
+		userID := createUser(t, users)
+
+		if roles, err := repo.RolesForUser(userID); err != nil || len(roles) != 0 {
+			t.Errorf("初期ロールが空でない: %v, %v", roles, err)
+		}
+
+		if err := repo.AssignRole(userID, models.RoleMember); err != nil {
+			t.Fatalf("AssignRole失敗: %v", err)
+		}
+		// 二重付与はエラーにならない
+		if err := repo.AssignRole(userID, models.RoleMember); err != nil {
+			t.Fatalf("AssignRole（二重付与）失敗: %v", err)
+		}
+		if err := repo.AssignRole(userID, models.RoleOwner); err != nil {
+			t.Fatalf("AssignRole失敗: %v", err)
+		}
+
+		roles, err := repo.RolesForUser(userID)
+		if err != nil || !reflect.DeepEqual(roles, []string{models.RoleOwner, models.RoleMember}) {
+			t.Errorf("RolesForUserが不正: %v, %v", roles, err)
+		}
+		permissions, err := repo.PermissionsForUser(userID)
+		if err != nil || len(permissions) != 9 {
+			t.Errorf("PermissionsForUserが重複なく返らない: %v, %v", permissions, err)
+		}
+
+		// 最後の owner にならないよう別の owner を用意してから剥奪
+		if err := repo.AssignRole(createUser(t, users), models.RoleOwner); err != nil {
+			t.Fatalf("AssignRole失敗: %v", err)
+		}
+		if err := repo.RevokeRole(userID, models.RoleOwner); err != nil {
+			t.Fatalf("RevokeRole失敗: %v", err)
+		}
+		permissions, err = repo.PermissionsForUser(userID)
+		if err != nil || !reflect.DeepEqual(permissions, []string{"customers:write", "messages:create", "messages:update"}) {
+			t.Errorf("剥奪後の権限が不正: %v, %v", permissions, err)
+		}
+	})
+
+	t.Run("UnknownRole", func(t *testing.T) {
+		repo, users := newRepos(t)
+		userID := createUser(t, users)
+
+		if err := repo.AssignRole(userID, "superuser"); !errors.Is(err, ErrRoleNotFound) {
+			t.Errorf("AssignRoleでErrRoleNotFoundが返らない: %v", err)
+		}
+		if err := repo.RevokeRole(userID, "superuser"); !errors.Is(err, ErrRoleNotFound) {
+			t.Errorf("RevokeRoleでErrRoleNotFoundが返らない: %v", err)
+		}
+		if _, err := repo.CountUsersWithRole("superuser"); !errors.Is(err, ErrRoleNotFound) {
+			t.Errorf("CountUsersWithRoleでErrRoleNotFoundが返らない: %v", err)
+		}
+	})
+
+	t.Run("LastOwner", func(t *testing.T) {
+		repo, users := newRepos(t)
+		first := createUser(t, users)
+		second := createUser(t, users)
+		for _, userID := range []int{first, second} {
+			if err := repo.AssignRole(userID, models.RoleOwner); err != nil {
+				t.Fatalf("AssignRole失敗: %v", err)
+			}
+		}
+		if count, err := repo.CountUsersWithRole(models.RoleOwner); err != nil || count != 2 {
+			t.Skipf("共有DBに他の owner が存在するためスキップ: %d, %v", count, err)
+		}
+
+		// 2人の owner から同時に剥奪しても、どちらか一方は拒否される
+		var wg sync.WaitGroup
+		errs := make([]error, 2)
+		for i, userID := range []int{first, second} {
+			wg.Add(1)
+			go func(i, userID int) {
+				defer wg.Done()
+				errs[i] = repo.RevokeRole(userID, models.RoleOwner)
+			}(i, userID)
+		}
+		wg.Wait()
+
+		failed := 0
+		for _, err := range errs {
+			if errors.Is(err, ErrLastOwner) {
+				failed++
+			} else if err != nil {
+				t.Errorf("予期しないエラー: %v", err)
+			}
+		}
+		if failed != 1 {
+			t.Errorf("ErrLastOwner がちょうど1回返らない: %v", errs)
+		}
+		if count, err := repo.CountUsersWithRole(models.RoleOwner); err != nil || count != 1 {
+			t.Errorf("owner が1人残っていない: %d, %v", count, err)
+		}
+	})
+
+	t.Run("AssignDefaultRole", func(t *testing.T) {
+		repo, users := newRepos(t)
+
+		first, err := repo.AssignDefaultRole(createUser(t, users))
+		if err != nil {
+			t.Fatalf("AssignDefaultRole失敗: %v", err)
+		}
+		if first != models.RoleOwner && first != models.RoleMember {
+			t.Errorf("初期ロールが不正: %s", first)
+		}
+
+		// owner が存在した後のユーザーは member
+		second, err := repo.AssignDefaultRole(createUser(t, users))
+		if err != nil || second != models.RoleMember {
+			t.Errorf("2人目の初期ロールが member でない: %s, %v", second, err)
+		}
+
+		count, err := repo.CountUsersWithRole(models.RoleOwner)
+		if err != nil || count < 1 {
+			t.Errorf("owner が存在しない: %d, %v", count, err)
+		}
+	})
+}
@@ -0,0 +1,165 @@
+package services
+
+import (
+	"sort"
+	"sync"
+
+	"backend/models"
+)
+
+// MemoryRoleRepository メモリ上で動作するロールリポジトリ
+// ロールと権限は DefaultRoles で固定です
+type MemoryRoleRepository struct {
+	mu        sync.RWMutex
+	roles     []models.Role
+	userRoles map[int]map[string]bool
+}
+
+// NewMemoryRoleRepository メモリロールリポジトリを新規作成
+func NewMemoryRoleRepository() *MemoryRoleRepository {
+	roles := make([]models.Role, len(DefaultRoles))
+	for i, role := range DefaultRoles {
+		role.Permissions = append([]string(nil), role.Permissions...)
+		sort.Strings(role.Permissions)
+		roles[i] = role
+	}
+
+	return &MemoryRoleRepository{
+		roles:     roles,
+		userRoles: make(map[int]map[string]bool),
+	}
+}
+
+// ListRoles 全ロールを権限付きで取得
+func (r *MemoryRoleRepository) ListRoles() ([]models.Role, error) {
+	roles := make([]models.Role, len(r.roles))
+	for i, role := range r.roles {
+		role.Permissions = append([]string{}, role.Permissions...)
+		roles[i] = role
+	}
+	return roles, nil
+}
+
+// RolesForUser ユーザーのロール名を取得
+func (r *MemoryRoleRepository) RolesForUser(userID int) ([]string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := []string{}
+	for _, role := range r.roles {
+		if r.userRoles[userID][role.Name] {
+			names = append(names, role.Name)
+		}
+	}
+	return names, nil
+}
+
+// PermissionsForUser ユーザーの権限名を取得
+func (r *MemoryRoleRepository) PermissionsForUser(userID int) ([]string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	seen := make(map[string]bool)
+	permissions := []string{}
+	for _, role := range r.roles {
+		if !r.userRoles[userID][role.Name] {
+			continue
+		}
+		for _, permission := range role.Permissions {
+			if !seen[permission] {
+				seen[permission] = true
+				permissions = append(permissions, permission)
+			}
+		}
+	}
+	sort.Strings(permissions)
+	return permissions, nil
+}
+
+// AssignRole ユーザーにロールを付与
+func (r *MemoryRoleRepository) AssignRole(userID int, role string) error {
+	if !r.roleExists(role) {
+		return ErrRoleNotFound
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.assign(userID, role)
+	return nil
+}
+
+// RevokeRole ユーザーからロールを剥奪
+func (r *MemoryRoleRepository) RevokeRole(userID int, role string) error {
+	if !r.roleExists(role) {
+		return ErrRoleNotFound
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if role == models.RoleOwner && r.userRoles[userID][role] && r.countLocked(role) <= 1 {
+		return ErrLastOwner
+	}
+	delete(r.userRoles[userID], role)
+	return nil
+}
+
+// AssignDefaultRole 新規ユーザーに初期ロールを付与
+func (r *MemoryRoleRepository) AssignDefaultRole(userID int) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.assignDefaultLocked(userID), nil
+}
+
+// assignDefaultLocked 初期ロールを付与してロール名を返す（呼び出し側でロックを保持）
+func (r *MemoryRoleRepository) assignDefaultLocked(userID int) string {
+	role := models.RoleOwner
+	if r.countLocked(models.RoleOwner) > 0 {
+		role = models.RoleMember
+	}
+	r.assign(userID, role)
+	return role
+}
+
+// CountUsersWithRole ロールを持つユーザー数を取得
+func (r *MemoryRoleRepository) CountUsersWithRole(role string) (int, error) {
+	if !r.roleExists(role) {
+		return 0, ErrRoleNotFound
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.countLocked(role), nil
+}
+
+// roleExists ロールが定義されているか判定（ロール定義は不変のためロック不要）
+func (r *MemoryRoleRepository) roleExists(role string) bool {
+	for _, defined := range r.roles {
+		if defined.Name == role {
+			return true
+		}
+	}
+	return false
+}
+
+// assign ロールを付与（呼び出し側で書き込みロックを保持）
+func (r *MemoryRoleRepository) assign(userID int, role string) {
+	if r.userRoles[userID] == nil {
+		r.userRoles[userID] = make(map[string]bool)
+	}
+	r.userRoles[userID][role] = true
+}
+
+// countLocked ロールを持つユーザー数（呼び出し側でロックを保持）
+func (r *MemoryRoleRepository) countLocked(role string) int {
+	count := 0
+	for _, roles := range r.userRoles {
+		if roles[role] {
+			count++
+		}
+	}
+	return count
+}
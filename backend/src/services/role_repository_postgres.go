@@ -0,0 +1,286 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+
+	"backend/models"
+)
+
+// foreignKeyViolation PostgreSQLの外部キー制約違反エラーコード
+const foreignKeyViolation = "23503"
+
+// defaultRoleLockID 初期ロール付与を直列化するトランザクション単位のアドバイザリロックID
+const defaultRoleLockID int64 = 7_245_218_936
+
+// PostgresRoleRepository PostgreSQLによるロールリポジトリ
+type PostgresRoleRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresRoleRepository PostgreSQLロールリポジトリを新規作成
+// db が nil の場合、全ての操作は ErrDatabaseUnavailable を返します
+func NewPostgresRoleRepository(db *sql.DB) *PostgresRoleRepository {
+	return &PostgresRoleRepository{db: db}
+}
+
+// ListRoles 全ロールを権限付きで取得
+func (r *PostgresRoleRepository) ListRoles() ([]models.Role, error) {
+	if r.db == nil {
+		return nil, ErrDatabaseUnavailable
+	}
+
+	query := `
+		SELECT r.name, r.description,
+		       COALESCE(array_agg(p.name ORDER BY p.name) FILTER (WHERE p.name IS NOT NULL), '{}')
+		FROM roles r
+		LEFT JOIN role_permissions rp ON rp.role_id = r.id
+		LEFT JOIN permissions p ON p.id = rp.permission_id
+		GROUP BY r.id
+		ORDER BY r.id
+	`
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list roles: %w", err)
+	}
+	defer rows.Close()
+
+	roles := []models.Role{}
+	for rows.Next() {
+		var role models.Role
+		if err := rows.Scan(&role.Name, &role.Description, pq.Array(&role.Permissions)); err != nil {
+			return nil, fmt.Errorf("failed to scan role: %w", err)
+		}
+		roles = append(roles, role)
+	}
+	return roles, rows.Err()
+}
+
+// RolesForUser ユーザーのロール名を取得
+func (r *PostgresRoleRepository) RolesForUser(userID int) ([]string, error) {
+	return r.queryNames(`
+		SELECT r.name
+		FROM user_roles ur
+		JOIN roles r ON r.id = ur.role_id
+		WHERE ur.user_id = $1
+		ORDER BY r.id
+	`, userID)
+}
+
+// PermissionsForUser ユーザーの権限名を取得
+func (r *PostgresRoleRepository) PermissionsForUser(userID int) ([]string, error) {
+	return r.queryNames(`
+		SELECT DISTINCT p.name
+		FROM user_roles ur
+		JOIN role_permissions rp ON rp.role_id = ur.role_id
+		JOIN permissions p ON p.id = rp.permission_id
+		WHERE ur.user_id = $1
+		ORDER BY p.name
+	`, userID)
+}
+
+// AssignRole ユーザーにロールを付与
+func (r *PostgresRoleRepository) AssignRole(userID int, role string) error {
+	roleID, err := r.roleID(role)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO user_roles (user_id, role_id)
+		VALUES ($1, $2)
+		ON CONFLICT DO NOTHING
+	`
+
+	if _, err := r.db.Exec(query, userID, roleID); err != nil {
+		if isForeignKeyViolation(err) {
+			return ErrUserNotFound
+		}
+		return fmt.Errorf("failed to assign role: %w", err)
+	}
+	return nil
+}
+
+// RevokeRole ユーザーからロールを剥奪
+// ロールの行をロックするため、同時に複数の owner から剥奪しても owner は0人になりません
+func (r *PostgresRoleRepository) RevokeRole(userID int, role string) error {
+	if r.db == nil {
+		return ErrDatabaseUnavailable
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	roleID, err := lockRole(tx, role)
+	if err != nil {
+		return err
+	}
+
+	result, err := tx.Exec(`DELETE FROM user_roles WHERE user_id = $1 AND role_id = $2`, userID, roleID)
+	if err != nil {
+		return fmt.Errorf("failed to revoke role: %w", err)
+	}
+	if affected, err := result.RowsAffected(); err == nil && affected > 0 && role == models.RoleOwner {
+		var remaining int
+		if err := tx.QueryRow(`SELECT COUNT(*) FROM user_roles WHERE role_id = $1`, roleID).Scan(&remaining); err != nil {
+			return fmt.Errorf("failed to count owners: %w", err)
+		}
+		if remaining == 0 {
+			return ErrLastOwner
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit role revocation: %w", err)
+	}
+	return nil
+}
+
+// lockRole ロールの行をロックしてIDを取得（トランザクションの終了まで同じロールの剥奪を直列化する）
+func lockRole(tx *sql.Tx, role string) (int, error) {
+	var id int
+	if err := tx.QueryRow(`SELECT id FROM roles WHERE name = $1 FOR UPDATE`, role).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, ErrRoleNotFound
+		}
+		return 0, fmt.Errorf("failed to lock role: %w", err)
+	}
+	return id, nil
+}
+
+// AssignDefaultRole 新規ユーザーに初期ロールを付与
+// 同時登録で owner が複数生まれないよう、判定と付与をロック付きトランザクションで行います
+func (r *PostgresRoleRepository) AssignDefaultRole(userID int) (string, error) {
+	if r.db == nil {
+		return "", ErrDatabaseUnavailable
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return "", fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	role, err := assignDefaultRole(tx, userID)
+	if err != nil {
+		return "", err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return "", fmt.Errorf("failed to commit default role: %w", err)
+	}
+	return role, nil
+}
+
+// assignDefaultRole トランザクション内で新規ユーザーに初期ロールを付与（ユーザーの作成と同じトランザクションでも使用）
+func assignDefaultRole(tx *sql.Tx, userID int) (string, error) {
+	if _, err := tx.Exec(`SELECT pg_advisory_xact_lock($1)`, defaultRoleLockID); err != nil {
+		return "", fmt.Errorf("failed to acquire role lock: %w", err)
+	}
+
+	var ownerExists bool
+	ownerQuery := `
+		SELECT EXISTS (
+			SELECT 1 FROM user_roles ur JOIN roles r ON r.id = ur.role_id WHERE r.name = $1
+		)
+	`
+	if err := tx.QueryRow(ownerQuery, models.RoleOwner).Scan(&ownerExists); err != nil {
+		return "", fmt.Errorf("failed to check owner: %w", err)
+	}
+
+	role := models.RoleOwner
+	if ownerExists {
+		role = models.RoleMember
+	}
+
+	query := `
+		INSERT INTO user_roles (user_id, role_id)
+		SELECT $1, id FROM roles WHERE name = $2
+		ON CONFLICT DO NOTHING
+	`
+
+	result, err := tx.Exec(query, userID, role)
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return "", ErrUserNotFound
+		}
+		return "", fmt.Errorf("failed to assign default role: %w", err)
+	}
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		// ロールが存在しない、または付与済み
+		var id int
+		if err := tx.QueryRow(`SELECT id FROM roles WHERE name = $1`, role).Scan(&id); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return "", ErrRoleNotFound
+			}
+			return "", fmt.Errorf("failed to get role: %w", err)
+		}
+	}
+	return role, nil
+}
+
+// CountUsersWithRole ロールを持つユーザー数を取得
+func (r *PostgresRoleRepository) CountUsersWithRole(role string) (int, error) {
+	roleID, err := r.roleID(role)
+	if err != nil {
+		return 0, err
+	}
+
+	var count int
+	if err := r.db.QueryRow(`SELECT COUNT(*) FROM user_roles WHERE role_id = $1`, roleID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count users with role: %w", err)
+	}
+	return count, nil
+}
+
+// roleID ロール名からIDを取得
+func (r *PostgresRoleRepository) roleID(role string) (int, error) {
+	if r.db == nil {
+		return 0, ErrDatabaseUnavailable
+	}
+
+	var id int
+	if err := r.db.QueryRow(`SELECT id FROM roles WHERE name = $1`, role).Scan(&id); err != nil {
+		if err == sql.ErrNoRows {
+			return 0, ErrRoleNotFound
+		}
+		return 0, fmt.Errorf("failed to get role: %w", err)
+	}
+	return id, nil
+}
+
+// queryNames 名前の一覧を返すクエリを実行
+func (r *PostgresRoleRepository) queryNames(query string, args ...interface{}) ([]string, error) {
+	if r.db == nil {
+		return nil, ErrDatabaseUnavailable
+	}
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query roles: %w", err)
+	}
+	defer rows.Close()
+
+	names := []string{}
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("failed to scan role: %w", err)
+		}
+		names = append(names, name)
+	}
+	return names, rows.Err()
+}
+
+// isForeignKeyViolation 外部キー制約違反エラーか判定
+func isForeignKeyViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == foreignKeyViolation
+}
@@ -0,0 +1,32 @@
+package services
+
+import "backend/models"
+
+var (
+	// ErrSaleNotFound 指定された売上が存在しない
+	ErrSaleNotFound = newError(ErrNotFound, "sale not found", "sale.not_found")
+
+	// ErrSaleNotRefundable 売上が支払い済み（paid）でないため返金できない
+	ErrSaleNotRefundable = newError(ErrConflict, "sale is not refundable", "sale.not_refundable")
+)
+
+// SaleRepository 売上の永続化と集計のインターフェース
+//
+// 金額は通貨の最小単位の整数で受け渡します。
+// 集計は status = paid の売上のみを対象とし、期間は start 以上 end 未満です。
+type SaleRepository interface {
+	// Create 売上を保存し、採番されたIDを含めて返す（Date がゼロ値の場合は現在日時）
+	Create(sale *models.Sale) (*models.Sale, error)
+	// List 検索条件に一致する売上をページ単位で返す
+	List(spec *QuerySpec) (*Page[models.Sale], error)
+	// FindByID IDで売上を返す
+	FindByID(id int) (*models.Sale, error)
+	// Refund 支払い済みの売上を返金済みにし、返金日時と実行したユーザーを記録する
+	// 状態の確認と更新は1回の操作で行い、paid でない場合は ErrSaleNotRefundable を返す
+	Refund(id, userID int) (*models.Sale, error)
+	// Totals 期間全体の売上・注文数・顧客数を返す（currency の売上のみ）
+	Totals(currency string, r models.StatsRange) (*models.SaleTotals, error)
+	// Buckets 期間を query の単位・タイムゾーンで区切った集計値を返す（query.Currency の売上のみ）
+	// 売上のないバケットも0として含め、query.Buckets() と同じ順序・件数で返す
+	Buckets(query *models.StatsQuery) ([]models.StatsBucket, error)
+}
@@ -0,0 +1,230 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"testing"
+	"time"
+
+	"backend/models"
+)
+
+// TestMemorySaleRepositoryConformance メモリ売上リポジトリの適合テスト
+func TestMemorySaleRepositoryConformance(t *testing.T) {
+	runSaleRepositoryConformance(t, func(t *testing.T) (SaleRepository, UserRepository) {
+		return NewMemorySaleRepository(), NewMemoryUserRepository()
+	})
+}
+
+// TestPostgresSaleRepositoryConformance PostgreSQL売上リポジトリの適合テスト
+func TestPostgresSaleRepositoryConformance(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	runSaleRepositoryConformance(t, func(t *testing.T) (SaleRepository, UserRepository) {
+		return NewPostgresSaleRepository(db), NewPostgresUserRepository(db)
+	})
+}
+
+// runSaleRepositoryConformance 全てのSaleRepository実装が満たすべき振る舞い
+// 共有DBでも動作するよう、集計期間は実行ごとに異なる過去の週とします
+func runSaleRepositoryConformance(t *testing.T, newRepos func(t *testing.T) (SaleRepository, UserRepository)) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatalf("タイムゾーンの読み込み失敗: %v", err)
+	}
+	unique := time.Now().UnixNano()
+	// 東京の月曜0時（UTC では前日の15時）
+	monday := models.StatsPeriodWeekly.Truncate(time.Date(1960, 1, 1+int(unique%15000), 12, 0, 0, 0, tokyo), tokyo)
+	email := func(name string) string {
+		return fmt.Sprintf("%s%d@example.com", name, unique)
+	}
+	createUser := func(t *testing.T, users UserRepository, name string) int {
+		t.Helper()
+		user, err := users.Create(email("sale-"+name), name, "hash")
+		if err != nil {
+			t.Fatalf("ユーザー作成失敗: %v", err)
+		}
+		return user.ID
+	}
+
+	t.Run("CreateAndAggregate", func(t *testing.T) {
+		repo, users := newRepos(t)
+		refunder := createUser(t, users, "aggregate")
+		sales := []models.Sale{
+			{Date: monday.Add(-time.Hour), Status: models.SaleStatusPaid, Email: email("early"), Amount: 10000, Currency: "USD"},
+			{Date: monday.Add(time.Hour), Status: models.SaleStatusPaid, Email: email("alice"), Amount: 1050, Currency: "USD"},
+			{Date: monday.Add(23 * time.Hour), Status: models.SaleStatusPaid, Email: email("bob"), Amount: 2025, Currency: "USD"},
+			{Date: monday.Add(26 * time.Hour), Status: models.SaleStatusFailed, Email: email("alice"), Amount: 9900, Currency: "USD"},
+			{Date: monday.Add(30 * time.Hour), Status: models.SaleStatusPaid, Email: email("bob"), Amount: 4200, Currency: "USD"},
+			{Date: monday.Add(40 * time.Hour), Status: models.SaleStatusPaid, Email: email("carol"), Amount: 70000, Currency: "JPY"},
+			{Date: monday.Add(49 * time.Hour), Status: models.SaleStatusPaid, Email: email("alice"), Amount: 500, Currency: "USD"},
+		}
+		for i := range sales {
+			created, err := repo.Create(&sales[i])
+			if err != nil {
+				t.Fatalf("Create失敗: %v", err)
+			}
+			if created.ID == 0 || !created.Date.Equal(sales[i].Date) || created.Status != sales[i].Status ||
+				created.Amount != sales[i].Amount || created.Currency != sales[i].Currency || created.RefundedAt != nil || created.CreatedAt.IsZero() {
+				t.Errorf("作成結果が不正: %+v", created)
+			}
+			sales[i].ID = created.ID
+		}
+
+		// 返金済みの売上は集計しない
+		if _, err := repo.Refund(sales[4].ID, refunder); err != nil {
+			t.Fatalf("Refund失敗: %v", err)
+		}
+
+		// 支払い済みで同じ通貨のみを集計し、顧客数はメールアドレスの重複を除く
+		totals, err := repo.Totals("USD", models.StatsRange{Start: monday, End: monday.AddDate(0, 0, 3)})
+		if err != nil {
+			t.Fatalf("Totals失敗: %v", err)
+		}
+		if *totals != (models.SaleTotals{Revenue: 3575, Orders: 3, Customers: 2}) {
+			t.Errorf("期間の集計が不正: %+v", *totals)
+		}
+		previous, err := repo.Totals("USD", models.StatsRange{Start: monday.AddDate(0, 0, -3), End: monday})
+		if err != nil {
+			t.Fatalf("Totals失敗: %v", err)
+		}
+		if *previous != (models.SaleTotals{Revenue: 10000, Orders: 1, Customers: 1}) {
+			t.Errorf("直前の期間の集計が不正: %+v", *previous)
+		}
+
+		// 東京の日付で区切り、売上のない日も0で返す
+		daily := &models.StatsQuery{Period: models.StatsPeriodDaily, Location: tokyo, Currency: "USD",
+			Range: models.StatsRange{Start: monday, End: monday.AddDate(0, 0, 3)}}
+		buckets, err := repo.Buckets(daily)
+		if err != nil {
+			t.Fatalf("Buckets失敗: %v", err)
+		}
+		want := []models.StatsBucket{
+			{Date: monday, Revenue: 3075, Orders: 2, Customers: 2},
+			{Date: monday.AddDate(0, 0, 1)},
+			{Date: monday.AddDate(0, 0, 2), Revenue: 500, Orders: 1, Customers: 1},
+		}
+		assertStatsBuckets(t, buckets, want)
+
+		// 週の途中から始まる期間でも、最初のバケットは月曜から
+		weekly := &models.StatsQuery{Period: models.StatsPeriodWeekly, Location: tokyo, Currency: "USD",
+			Range: models.StatsRange{Start: monday.AddDate(0, 0, 1), End: monday.AddDate(0, 0, 8)}}
+		buckets, err = repo.Buckets(weekly)
+		if err != nil {
+			t.Fatalf("Buckets失敗: %v", err)
+		}
+		assertStatsBuckets(t, buckets, []models.StatsBucket{
+			{Date: monday, Revenue: 500, Orders: 1, Customers: 1},
+			{Date: monday.AddDate(0, 0, 7)},
+		})
+
+		// UTC で区切ると月曜1時（UTC では日曜16時）の売上は前日になる
+		utc := &models.StatsQuery{Period: models.StatsPeriodDaily, Location: time.UTC, Currency: "USD",
+			Range: models.StatsRange{Start: monday, End: monday.Add(24 * time.Hour)}}
+		buckets, err = repo.Buckets(utc)
+		if err != nil {
+			t.Fatalf("Buckets失敗: %v", err)
+		}
+		sunday := monday.In(time.UTC).Truncate(24 * time.Hour)
+		assertStatsBuckets(t, buckets, []models.StatsBucket{
+			{Date: sunday, Revenue: 1050, Orders: 1, Customers: 1},
+			{Date: sunday.AddDate(0, 0, 1), Revenue: 2025, Orders: 1, Customers: 1},
+		})
+	})
+
+	t.Run("DefaultDate", func(t *testing.T) {
+		repo, _ := newRepos(t)
+		before := time.Now().Add(-time.Second)
+		created, err := repo.Create(&models.Sale{Status: models.SaleStatusFailed, Email: email("now"), Amount: 1, Currency: "USD"})
+		if err != nil {
+			t.Fatalf("Create失敗: %v", err)
+		}
+		if created.Date.Before(before) {
+			t.Errorf("日時省略時は現在日時になるべき: %v", created.Date)
+		}
+	})
+
+	t.Run("ListAndFind", func(t *testing.T) {
+		repo, _ := newRepos(t)
+		buyer := email("list")
+		for _, amount := range []int64{300, 100, 200} {
+			if _, err := repo.Create(&models.Sale{Date: monday, Status: models.SaleStatusPaid, Email: buyer, Amount: amount, Currency: "EUR"}); err != nil {
+				t.Fatalf("Create失敗: %v", err)
+			}
+		}
+		failed, err := repo.Create(&models.Sale{Date: monday, Status: models.SaleStatusFailed, Email: buyer, Amount: 400, Currency: "EUR"})
+		if err != nil {
+			t.Fatalf("Create失敗: %v", err)
+		}
+
+		spec, err := SaleQuerySchema.Parse(url.Values{"email": {buyer}, "status": {"paid"}, "amount_min": {"150"}, "sort": {"amount"}})
+		if err != nil {
+			t.Fatalf("Parse失敗: %v", err)
+		}
+		page, err := repo.List(spec)
+		if err != nil {
+			t.Fatalf("List失敗: %v", err)
+		}
+		if page.Total != 2 || len(page.Items) != 2 || page.Items[0].Amount != 200 || page.Items[1].Amount != 300 {
+			t.Errorf("一覧が不正: total=%d items=%+v", page.Total, page.Items)
+		}
+
+		found, err := repo.FindByID(failed.ID)
+		if err != nil {
+			t.Fatalf("FindByID失敗: %v", err)
+		}
+		if found.Status != models.SaleStatusFailed || found.Amount != 400 || found.Currency != "EUR" || !found.Date.Equal(monday) {
+			t.Errorf("取得結果が不正: %+v", found)
+		}
+		if _, err := repo.FindByID(999999999); !errors.Is(err, ErrSaleNotFound) {
+			t.Errorf("Expected ErrSaleNotFound, got %v", err)
+		}
+	})
+
+	t.Run("Refund", func(t *testing.T) {
+		repo, users := newRepos(t)
+		refunder := createUser(t, users, "refund")
+		paid, _ := repo.Create(&models.Sale{Date: monday, Status: models.SaleStatusPaid, Email: email("refund"), Amount: 1000, Currency: "USD"})
+		failed, _ := repo.Create(&models.Sale{Date: monday, Status: models.SaleStatusFailed, Email: email("refund"), Amount: 1000, Currency: "USD"})
+
+		refunded, err := repo.Refund(paid.ID, refunder)
+		if err != nil {
+			t.Fatalf("Refund失敗: %v", err)
+		}
+		if refunded.Status != models.SaleStatusRefunded || refunded.RefundedAt == nil ||
+			refunded.RefundedBy == nil || *refunded.RefundedBy != refunder || refunded.Amount != 1000 {
+			t.Errorf("返金結果が不正: %+v", refunded)
+		}
+		found, _ := repo.FindByID(paid.ID)
+		if found.RefundedAt == nil || !found.RefundedAt.Equal(*refunded.RefundedAt) || found.RefundedBy == nil {
+			t.Errorf("返金の記録が保存されていない: %+v", found)
+		}
+
+		// 返金できるのは paid の売上のみ
+		if _, err := repo.Refund(paid.ID, refunder); !errors.Is(err, ErrSaleNotRefundable) {
+			t.Errorf("二重返金: Expected ErrSaleNotRefundable, got %v", err)
+		}
+		if _, err := repo.Refund(failed.ID, refunder); !errors.Is(err, ErrSaleNotRefundable) {
+			t.Errorf("failed の返金: Expected ErrSaleNotRefundable, got %v", err)
+		}
+		if _, err := repo.Refund(999999999, refunder); !errors.Is(err, ErrSaleNotFound) {
+			t.Errorf("Expected ErrSaleNotFound, got %v", err)
+		}
+	})
+}
+
+// assertStatsBuckets バケットの日時（同じ時刻か）と集計値を検証
+func assertStatsBuckets(t *testing.T, got, want []models.StatsBucket) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("バケット数 = %d, want %d: %+v", len(got), len(want), got)
+	}
+	for i := range want {
+		if !got[i].Date.Equal(want[i].Date) || got[i].Revenue != want[i].Revenue ||
+			got[i].Orders != want[i].Orders || got[i].Customers != want[i].Customers {
+			t.Errorf("バケット[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,181 @@
+package services
+
+import (
+	"sync"
+	"time"
+
+	"backend/models"
+)
+
+// MemorySaleRepository メモリ上で動作する売上リポジトリ
+type MemorySaleRepository struct {
+	mu     sync.RWMutex
+	sales  map[int]models.Sale
+	nextID int
+}
+
+// NewMemorySaleRepository メモリ売上リポジトリを新規作成
+func NewMemorySaleRepository() *MemorySaleRepository {
+	return &MemorySaleRepository{
+		sales:  make(map[int]models.Sale),
+		nextID: 1,
+	}
+}
+
+// Create 売上を保存
+func (r *MemorySaleRepository) Create(sale *models.Sale) (*models.Sale, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	stored := copySale(*sale)
+	stored.ID = r.nextID
+	if stored.Date.IsZero() {
+		stored.Date = now
+	}
+	stored.CreatedAt = now
+	stored.UpdatedAt = now
+	r.sales[stored.ID] = stored
+	r.nextID++
+
+	result := copySale(stored)
+	return &result, nil
+}
+
+// List 検索条件に一致する売上をページ単位で取得
+func (r *MemorySaleRepository) List(spec *QuerySpec) (*Page[models.Sale], error) {
+	r.mu.RLock()
+	sales := make([]models.Sale, 0, len(r.sales))
+	for _, sale := range r.sales {
+		sales = append(sales, copySale(sale))
+	}
+	r.mu.RUnlock()
+
+	return applyQuerySpec(sales, spec, saleFields, saleCursor), nil
+}
+
+// FindByID IDで売上を取得
+func (r *MemorySaleRepository) FindByID(id int) (*models.Sale, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	sale, ok := r.sales[id]
+	if !ok {
+		return nil, ErrSaleNotFound
+	}
+	result := copySale(sale)
+	return &result, nil
+}
+
+// Refund 支払い済みの売上を返金済みにする
+func (r *MemorySaleRepository) Refund(id, userID int) (*models.Sale, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	sale, ok := r.sales[id]
+	if !ok {
+		return nil, ErrSaleNotFound
+	}
+	if sale.Status != models.SaleStatusPaid {
+		return nil, ErrSaleNotRefundable
+	}
+
+	now := time.Now()
+	sale.Status = models.SaleStatusRefunded
+	sale.RefundedAt = &now
+	sale.RefundedBy = &userID
+	sale.UpdatedAt = now
+	r.sales[id] = sale
+
+	result := copySale(sale)
+	return &result, nil
+}
+
+// Totals 期間全体の売上を集計
+func (r *MemorySaleRepository) Totals(currency string, rng models.StatsRange) (*models.SaleTotals, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	totals := &models.SaleTotals{}
+	customers := make(map[string]struct{})
+	for _, sale := range r.sales {
+		if !saleCounted(sale, currency, rng) {
+			continue
+		}
+		totals.Revenue += sale.Amount
+		totals.Orders++
+		customers[sale.Email] = struct{}{}
+	}
+	totals.Customers = len(customers)
+	return totals, nil
+}
+
+// Buckets 期間を単位ごとに区切って集計（売上のないバケットは0）
+func (r *MemorySaleRepository) Buckets(query *models.StatsQuery) ([]models.StatsBucket, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	starts := query.Buckets()
+	buckets := make([]models.StatsBucket, len(starts))
+	index := make(map[int64]int, len(starts))
+	customers := make([]map[string]struct{}, len(starts))
+	for i, start := range starts {
+		buckets[i].Date = start
+		index[start.Unix()] = i
+		customers[i] = make(map[string]struct{})
+	}
+
+	for _, sale := range r.sales {
+		if !saleCounted(sale, query.Currency, query.Range) {
+			continue
+		}
+		i, ok := index[query.Period.Truncate(sale.Date, query.Location).Unix()]
+		if !ok {
+			continue
+		}
+		buckets[i].Revenue += sale.Amount
+		buckets[i].Orders++
+		customers[i][sale.Email] = struct{}{}
+	}
+	for i := range buckets {
+		buckets[i].Customers = len(customers[i])
+	}
+	return buckets, nil
+}
+
+// saleCounted 売上が集計対象（支払い済み・同じ通貨・期間内）か判定
+func saleCounted(sale models.Sale, currency string, rng models.StatsRange) bool {
+	return sale.Status == models.SaleStatusPaid && sale.Currency == currency &&
+		!sale.Date.Before(rng.Start) && sale.Date.Before(rng.End)
+}
+
+// copySale 呼び出し側の変更が保存データに影響しないよう売上を複製
+func copySale(sale models.Sale) models.Sale {
+	if sale.RefundedAt != nil {
+		refundedAt := *sale.RefundedAt
+		sale.RefundedAt = &refundedAt
+	}
+	if sale.RefundedBy != nil {
+		refundedBy := *sale.RefundedBy
+		sale.RefundedBy = &refundedBy
+	}
+	return sale
+}
+
+// saleFields QuerySpec評価用のフィールド値
+func saleFields(sale models.Sale) map[string]interface{} {
+	return map[string]interface{}{
+		"id":         sale.ID,
+		"date":       sale.Date,
+		"status":     string(sale.Status),
+		"email":      sale.Email,
+		"amount":     int(sale.Amount),
+		"currency":   sale.Currency,
+		"created_at": sale.CreatedAt,
+	}
+}
+
+// saleCursor 売上の位置を表すカーソル
+func saleCursor(sale models.Sale) Cursor {
+	return Cursor{CreatedAt: sale.CreatedAt, ID: sale.ID}
+}
@@ -0,0 +1,217 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"backend/models"
+)
+
+// saleColumns 売上取得時の列
+const saleColumns = `id, date, status, email, amount, currency, refunded_at, refunded_by, created_at, updated_at`
+
+// PostgresSaleRepository PostgreSQLによる売上リポジトリ
+type PostgresSaleRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresSaleRepository PostgreSQL売上リポジトリを新規作成
+// db が nil の場合、全ての操作は ErrDatabaseUnavailable を返します
+func NewPostgresSaleRepository(db *sql.DB) *PostgresSaleRepository {
+	return &PostgresSaleRepository{db: db}
+}
+
+// Create 売上を保存
+func (r *PostgresSaleRepository) Create(sale *models.Sale) (*models.Sale, error) {
+	if r.db == nil {
+		return nil, ErrDatabaseUnavailable
+	}
+
+	var date *time.Time
+	if !sale.Date.IsZero() {
+		date = &sale.Date
+	}
+	query := `INSERT INTO sales (email, status, amount, currency, date)
+		VALUES ($1, $2, $3, $4, COALESCE($5, CURRENT_TIMESTAMP))
+		RETURNING ` + saleColumns
+
+	created, err := scanSale(r.db.QueryRow(query, sale.Email, sale.Status, sale.Amount, sale.Currency, date))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sale: %w", err)
+	}
+	return created, nil
+}
+
+// List 検索条件に一致する売上をページ単位で取得
+func (r *PostgresSaleRepository) List(spec *QuerySpec) (*Page[models.Sale], error) {
+	if r.db == nil {
+		return nil, ErrDatabaseUnavailable
+	}
+
+	var countArgs []interface{}
+	countQuery := `SELECT COUNT(*) FROM sales` + spec.WhereClause(&countArgs, false)
+
+	var total int
+	if err := r.db.QueryRow(countQuery, countArgs...).Scan(&total); err != nil {
+		return nil, fmt.Errorf("failed to count sales: %w", err)
+	}
+
+	var args []interface{}
+	query := `SELECT ` + saleColumns + ` FROM sales` + spec.WhereClause(&args, true) + spec.OrderClause()
+
+	// 次ページの有無を判定するため1件多く取得
+	page := spec.Page
+	args = append(args, page.Limit+1, page.Offset)
+	query += fmt.Sprintf(` LIMIT $%d OFFSET $%d`, len(args)-1, len(args))
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query sales: %w", err)
+	}
+	defer rows.Close()
+
+	sales := make([]models.Sale, 0, page.Limit+1)
+	for rows.Next() {
+		sale, err := scanSale(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan sale: %w", err)
+		}
+		sales = append(sales, *sale)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating sales: %w", err)
+	}
+
+	return newPage(spec, sales, total, saleCursor), nil
+}
+
+// FindByID IDで売上を取得
+func (r *PostgresSaleRepository) FindByID(id int) (*models.Sale, error) {
+	if r.db == nil {
+		return nil, ErrDatabaseUnavailable
+	}
+
+	sale, err := scanSale(r.db.QueryRow(`SELECT `+saleColumns+` FROM sales WHERE id = $1`, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrSaleNotFound
+		}
+		return nil, fmt.Errorf("failed to get sale: %w", err)
+	}
+	return sale, nil
+}
+
+// Refund 支払い済みの売上を返金済みにする（updated_at はトリガーで更新）
+// 同時に返金された場合も、status = 'paid' の条件により1回だけ成功します
+func (r *PostgresSaleRepository) Refund(id, userID int) (*models.Sale, error) {
+	if r.db == nil {
+		return nil, ErrDatabaseUnavailable
+	}
+
+	query := `
+		UPDATE sales
+		SET status = 'refunded', refunded_at = CURRENT_TIMESTAMP, refunded_by = $2
+		WHERE id = $1 AND status = 'paid'
+		RETURNING ` + saleColumns
+
+	refunded, err := scanSale(r.db.QueryRow(query, id, userID))
+	if err == nil {
+		return refunded, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("failed to refund sale: %w", err)
+	}
+
+	// 更新されなかった理由（存在しない・paid でない）を判別
+	if _, err := r.FindByID(id); err != nil {
+		return nil, err
+	}
+	return nil, ErrSaleNotRefundable
+}
+
+// Totals 期間全体の売上を集計
+func (r *PostgresSaleRepository) Totals(currency string, rng models.StatsRange) (*models.SaleTotals, error) {
+	if r.db == nil {
+		return nil, ErrDatabaseUnavailable
+	}
+
+	query := `SELECT COALESCE(SUM(amount), 0), COUNT(*), COUNT(DISTINCT email)
+		FROM sales WHERE status = 'paid' AND currency = $1 AND date >= $2 AND date < $3`
+
+	var totals models.SaleTotals
+	if err := r.db.QueryRow(query, currency, rng.Start, rng.End).Scan(&totals.Revenue, &totals.Orders, &totals.Customers); err != nil {
+		return nil, fmt.Errorf("failed to aggregate sales: %w", err)
+	}
+	return &totals, nil
+}
+
+// Buckets 期間を単位ごとに区切って集計
+// generate_series で期間内の全てのバケットを生成して結合するため、売上のないバケットも0で返ります。
+// 日時は tz の現地時刻（timestamp）に変換してから date_trunc するため、バケットの境界は tz の0時です。
+func (r *PostgresSaleRepository) Buckets(query *models.StatsQuery) ([]models.StatsBucket, error) {
+	if r.db == nil {
+		return nil, ErrDatabaseUnavailable
+	}
+
+	sqlQuery := `
+		WITH totals AS (
+			SELECT date_trunc($1, date AT TIME ZONE $4) AS bucket,
+				SUM(amount) AS revenue, COUNT(*) AS orders, COUNT(DISTINCT email) AS customers
+			FROM sales
+			WHERE status = 'paid' AND currency = $5 AND date >= $2 AND date < $3
+			GROUP BY 1
+		)
+		SELECT b.bucket, COALESCE(t.revenue, 0), COALESCE(t.orders, 0), COALESCE(t.customers, 0)
+		FROM generate_series(
+			date_trunc($1, $2::timestamptz AT TIME ZONE $4),
+			date_trunc($1, ($3::timestamptz - interval '1 microsecond') AT TIME ZONE $4),
+			('1 ' || $1)::interval
+		) AS b(bucket)
+		LEFT JOIN totals t ON t.bucket = b.bucket
+		ORDER BY b.bucket`
+
+	rows, err := r.db.Query(sqlQuery, query.Period.Unit(), query.Range.Start, query.Range.End, query.Location.String(), query.Currency)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate sales by %s: %w", query.Period.Unit(), err)
+	}
+	defer rows.Close()
+
+	buckets := []models.StatsBucket{}
+	for rows.Next() {
+		var bucket models.StatsBucket
+		var local time.Time
+		if err := rows.Scan(&local, &bucket.Revenue, &bucket.Orders, &bucket.Customers); err != nil {
+			return nil, fmt.Errorf("failed to scan sales bucket: %w", err)
+		}
+		// timestamp（タイムゾーンなし）は UTC として読み込まれるため、同じ現地時刻を tz の日時に戻す
+		year, month, day := local.Date()
+		bucket.Date = time.Date(year, month, day, 0, 0, 0, 0, query.Location)
+		buckets = append(buckets, bucket)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate sales buckets: %w", err)
+	}
+	return buckets, nil
+}
+
+// scanSale 1行を売上に変換
+func scanSale(row rowScanner) (*models.Sale, error) {
+	var sale models.Sale
+	var refundedAt sql.NullTime
+	var refundedBy sql.NullInt64
+	err := row.Scan(&sale.ID, &sale.Date, &sale.Status, &sale.Email, &sale.Amount, &sale.Currency,
+		&refundedAt, &refundedBy, &sale.CreatedAt, &sale.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if refundedAt.Valid {
+		sale.RefundedAt = &refundedAt.Time
+	}
+	if refundedBy.Valid {
+		userID := int(refundedBy.Int64)
+		sale.RefundedBy = &userID
+	}
+	return &sale, nil
+}
@@ -0,0 +1,89 @@
+package services
+
+import (
+	"errors"
+	"time"
+
+	"backend/models"
+)
+
+// SaleQuerySchema 売上一覧の検索・ソート定義
+var SaleQuerySchema = &QuerySchema{
+	Filters: map[string]FilterDef{
+		"status":      {Field: "status", Op: FilterEq, Type: FieldString},
+		"email":       {Field: "email", Op: FilterEq, Type: FieldString},
+		"currency":    {Field: "currency", Op: FilterEq, Type: FieldString},
+		"amount_min":  {Field: "amount", Op: FilterGte, Type: FieldInt},
+		"amount_max":  {Field: "amount", Op: FilterLte, Type: FieldInt},
+		"date_after":  {Field: "date", Op: FilterGt, Type: FieldTime},
+		"date_before": {Field: "date", Op: FilterLt, Type: FieldTime},
+	},
+	Columns: map[string]string{
+		"id":         "id",
+		"date":       "date",
+		"status":     "status",
+		"email":      "email",
+		"amount":     "amount",
+		"currency":   "currency",
+		"created_at": "created_at",
+	},
+	Sortable:    []string{"id", "date", "status", "email", "amount", "created_at"},
+	DefaultSort: []SortField{{Field: "created_at", Desc: true}},
+}
+
+// SaleService 売上台帳サービス構造体
+type SaleService struct {
+	repo SaleRepository
+}
+
+// NewSaleService 売上台帳サービスを新規作成
+func NewSaleService(repo SaleRepository) *SaleService {
+	return &SaleService{repo: repo}
+}
+
+// ListSales 検索条件に一致する売上をページ単位で取得
+func (s *SaleService) ListSales(spec *QuerySpec) (*Page[models.Sale], error) {
+	return s.repo.List(spec)
+}
+
+// GetSale IDで売上を取得
+func (s *SaleService) GetSale(id int) (*models.Sale, error) {
+	return s.repo.FindByID(id)
+}
+
+// CreateSale 売上を登録
+func (s *SaleService) CreateSale(request *models.SaleRequest) (*models.Sale, error) {
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+
+	sale := &models.Sale{
+		Status:   request.Status,
+		Email:    request.Email,
+		Amount:   request.Amount,
+		Currency: request.Currency,
+	}
+	if request.Date != nil {
+		sale.Date = request.Date.Truncate(time.Microsecond)
+	}
+	return s.repo.Create(sale)
+}
+
+// UpdateSaleStatus 売上の決済状態を変更（現在は paid → refunded の返金のみ）
+// 返金では実行したユーザーを記録します。許可されない遷移は ValidationError です
+func (s *SaleService) UpdateSaleStatus(user *models.User, id int, request *models.SaleStatusRequest) (*models.Sale, error) {
+	current, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := request.ValidateTransition(current.Status); err != nil {
+		return nil, err
+	}
+
+	refunded, err := s.repo.Refund(id, user.ID)
+	if errors.Is(err, ErrSaleNotRefundable) {
+		// 確認後に他のリクエストが状態を変更した
+		return nil, models.NewValidationError("status", models.ValidationCodeInvalid, "sale.no_longer_refundable", nil)
+	}
+	return refunded, err
+}
@@ -0,0 +1,57 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"backend/models"
+)
+
+// TestSaleServiceUpdateSaleStatus 決済状態の遷移のテスト
+func TestSaleServiceUpdateSaleStatus(t *testing.T) {
+	service := NewSaleService(NewMemorySaleRepository())
+	user := &models.User{ID: 3}
+	paid, err := service.CreateSale(&models.SaleRequest{Email: "alex@example.com", Amount: 59400})
+	if err != nil {
+		t.Fatalf("CreateSale() error = %v", err)
+	}
+	failed, _ := service.CreateSale(&models.SaleRequest{Email: "alex@example.com", Amount: 100, Status: models.SaleStatusFailed, Currency: "jpy"})
+	if failed.Currency != "JPY" {
+		t.Errorf("通貨コードが正規化されていない: %q", failed.Currency)
+	}
+
+	invalid := []struct {
+		name   string
+		id     int
+		status models.SaleStatus
+	}{
+		{"paid → failed", paid.ID, models.SaleStatusFailed},
+		{"paid → paid", paid.ID, models.SaleStatusPaid},
+		{"failed → refunded", failed.ID, models.SaleStatusRefunded},
+		{"不明な状態", paid.ID, "disputed"},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			var validationErr *models.ValidationError
+			if _, err := service.UpdateSaleStatus(user, tt.id, &models.SaleStatusRequest{Status: tt.status}); !errors.As(err, &validationErr) {
+				t.Errorf("Expected ValidationError, got %v", err)
+			}
+		})
+	}
+
+	refunded, err := service.UpdateSaleStatus(user, paid.ID, &models.SaleStatusRequest{Status: models.SaleStatusRefunded})
+	if err != nil {
+		t.Fatalf("UpdateSaleStatus() error = %v", err)
+	}
+	if refunded.Status != models.SaleStatusRefunded || refunded.RefundedBy == nil || *refunded.RefundedBy != user.ID {
+		t.Errorf("返金結果が不正: %+v", refunded)
+	}
+
+	var validationErr *models.ValidationError
+	if _, err := service.UpdateSaleStatus(user, paid.ID, &models.SaleStatusRequest{Status: models.SaleStatusRefunded}); !errors.As(err, &validationErr) {
+		t.Errorf("refunded → refunded: Expected ValidationError, got %v", err)
+	}
+	if _, err := service.UpdateSaleStatus(user, 999, &models.SaleStatusRequest{Status: models.SaleStatusRefunded}); !errors.Is(err, ErrSaleNotFound) {
+		t.Errorf("Expected ErrSaleNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,43 @@
+package services
+
+import (
+	"time"
+
+	"backend/models"
+)
+
+var (
+	// ErrSessionNotFound セッションが存在しない、失効済み、または期限切れ
+	ErrSessionNotFound = newError(ErrNotFound, "session not found", "session.not_found")
+
+	// ErrRefreshTokenReused ローテーション済みのリフレッシュトークンが再利用された
+	ErrRefreshTokenReused = newError(ErrUnauthorized, "refresh token reused", "auth.refresh_token_reused")
+)
+
+// SessionRepository ログインセッションとリフレッシュトークンの永続化インターフェース
+//
+// リフレッシュトークンは hashToken によるハッシュで受け渡します。
+// セッションは1つのログインから始まるリフレッシュトークンのファミリーで、
+// ローテーションのたびに新しいトークンが追加され、古いトークンは使用済みになります。
+type SessionRepository interface {
+	// Create セッションと最初のリフレッシュトークンを保存し、セッションを返す
+	Create(userID int, client models.ClientInfo, tokenHash string, expiresAt time.Time) (*models.Session, error)
+	// Rotate リフレッシュトークンを使用済みにして新しいトークンを追加し、セッションを返す
+	// 使用済みのトークンが提示された場合はセッション全体を失効させて ErrRefreshTokenReused を返す
+	// 未知・期限切れのトークン、失効・期限切れのセッションの場合は ErrSessionNotFound を返す
+	Rotate(tokenHash, newTokenHash string, client models.ClientInfo, expiresAt time.Time) (*models.Session, error)
+	// FindByID IDでセッションを返す（失効済み・期限切れも含む）
+	FindByID(id string) (*models.Session, error)
+	// ListActive ユーザーの有効なセッションを最終利用日時の新しい順に返す
+	ListActive(userID int) ([]models.Session, error)
+	// Revoke ユーザーのセッションを失効させる
+	// 他ユーザーのセッションや失効済みのセッションは ErrSessionNotFound を返す
+	Revoke(userID int, id string) error
+	// RevokeAllExcept 指定以外のユーザーの有効なセッションを全て失効させ、件数を返す
+	RevokeAllExcept(userID int, keepID string) (int, error)
+}
+
+// newSessionID 推測困難なセッションIDを生成
+func newSessionID() (string, error) {
+	return randomToken(16)
+}
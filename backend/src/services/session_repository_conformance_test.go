@@ -0,0 +1,180 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"backend/models"
+)
+
+// TestMemorySessionRepositoryConformance メモリセッションリポジトリの適合テスト
+func TestMemorySessionRepositoryConformance(t *testing.T) {
+	runSessionRepositoryConformance(t, func(t *testing.T) (SessionRepository, UserRepository) {
+		return NewMemorySessionRepository(), NewMemoryUserRepository()
+	})
+}
+
+// TestPostgresSessionRepositoryConformance PostgreSQLセッションリポジトリの適合テスト
+func TestPostgresSessionRepositoryConformance(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	runSessionRepositoryConformance(t, func(t *testing.T) (SessionRepository, UserRepository) {
+		return NewPostgresSessionRepository(db), NewPostgresUserRepository(db)
+	})
+}
+
+// runSessionRepositoryConformance 全てのSessionRepository実装が満たすべき振る舞い
+// 共有DBでも動作するよう、ユーザーとトークンは都度一意な値で作成します
+func runSessionRepositoryConformance(t *testing.T, newRepos func(t *testing.T) (SessionRepository, UserRepository)) {
+	client := models.ClientInfo{UserAgent: "curl/8.4.0", IP: "192.0.2.1"}
+	expiresAt := time.Now().Add(time.Hour)
+
+	createUser := func(t *testing.T, users UserRepository) int {
+		t.Helper()
+		user, err := users.Create(fmt.Sprintf("session%d@example.com", time.Now().UnixNano()), "Session", "hash")
+		if err != nil {
+			t.Fatalf("ユーザー作成失敗: %v", err)
+		}
+		return user.ID
+	}
+	uniqueHash := func() string {
+		return hashToken(fmt.Sprintf("token-%d", time.Now().UnixNano()))
+	}
+
+	t.Run("CreateAndFind", func(t *testing.T) {
+		repo, users := newRepos(t)
+		userID := createUser(t, users)
+
+		created, err := repo.Create(userID, client, uniqueHash(), expiresAt)
+		if err != nil {
+			t.Fatalf("Create失敗: %v", err)
+		}
+		if created.ID == "" || created.UserID != userID || created.IP != client.IP || created.LastSeenAt.IsZero() {
+			t.Errorf("作成結果が不正: %+v", created)
+		}
+
+		found, err := repo.FindByID(created.ID)
+		if err != nil || found.UserAgent != client.UserAgent || !found.Active(time.Now()) {
+			t.Errorf("FindByIDが不正: %+v, %v", found, err)
+		}
+		if _, err := repo.FindByID("missing"); !errors.Is(err, ErrSessionNotFound) {
+			t.Errorf("ErrSessionNotFoundが返らない: %v", err)
+		}
+	})
+
+	t.Run("RotateAndReuse", func(t *testing.T) {
+		repo, users := newRepos(t)
+		userID := createUser(t, users)
+		first, second, third := uniqueHash(), uniqueHash(), uniqueHash()
+
+		created, _ := repo.Create(userID, client, first, expiresAt)
+
+		moved := models.ClientInfo{UserAgent: "Firefox/121.0", IP: "198.51.100.7"}
+		rotated, err := repo.Rotate(first, second, moved, expiresAt)
+		if err != nil {
+			t.Fatalf("Rotate失敗: %v", err)
+		}
+		if rotated.ID != created.ID || rotated.IP != moved.IP || rotated.UserAgent != moved.UserAgent {
+			t.Errorf("ローテーション結果が不正: %+v", rotated)
+		}
+
+		// 使用済みトークンの再利用でファミリー全体が失効
+		if _, err := repo.Rotate(first, third, client, expiresAt); !errors.Is(err, ErrRefreshTokenReused) {
+			t.Fatalf("ErrRefreshTokenReusedが返らない: %v", err)
+		}
+		if _, err := repo.Rotate(second, third, client, expiresAt); !errors.Is(err, ErrSessionNotFound) {
+			t.Errorf("失効したセッションでローテーションできてしまう: %v", err)
+		}
+		if found, _ := repo.FindByID(created.ID); found == nil || found.RevokedAt == nil {
+			t.Errorf("セッションが失効していない: %+v", found)
+		}
+	})
+
+	t.Run("UnknownOrExpiredToken", func(t *testing.T) {
+		repo, users := newRepos(t)
+		userID := createUser(t, users)
+
+		if _, err := repo.Rotate(uniqueHash(), uniqueHash(), client, expiresAt); !errors.Is(err, ErrSessionNotFound) {
+			t.Errorf("未知のトークンでErrSessionNotFoundが返らない: %v", err)
+		}
+
+		expired := uniqueHash()
+		repo.Create(userID, client, expired, time.Now().Add(-time.Minute))
+		if _, err := repo.Rotate(expired, uniqueHash(), client, expiresAt); !errors.Is(err, ErrSessionNotFound) {
+			t.Errorf("期限切れのトークンでErrSessionNotFoundが返らない: %v", err)
+		}
+	})
+
+	t.Run("ListAndRevoke", func(t *testing.T) {
+		repo, users := newRepos(t)
+		userID := createUser(t, users)
+		otherID := createUser(t, users)
+
+		keep, _ := repo.Create(userID, client, uniqueHash(), expiresAt)
+		target, _ := repo.Create(userID, client, uniqueHash(), expiresAt)
+		repo.Create(userID, client, uniqueHash(), expiresAt)
+		repo.Create(userID, client, uniqueHash(), time.Now().Add(-time.Minute))
+		others, _ := repo.Create(otherID, client, uniqueHash(), expiresAt)
+
+		sessions, err := repo.ListActive(userID)
+		if err != nil || len(sessions) != 3 {
+			t.Fatalf("ListActiveが不正: %d件, %v", len(sessions), err)
+		}
+
+		if err := repo.Revoke(userID, others.ID); !errors.Is(err, ErrSessionNotFound) {
+			t.Errorf("他ユーザーのセッションを失効できてしまう: %v", err)
+		}
+		if err := repo.Revoke(userID, target.ID); err != nil {
+			t.Fatalf("Revoke失敗: %v", err)
+		}
+		if err := repo.Revoke(userID, target.ID); !errors.Is(err, ErrSessionNotFound) {
+			t.Errorf("失効済みセッションでErrSessionNotFoundが返らない: %v", err)
+		}
+
+		revoked, err := repo.RevokeAllExcept(userID, keep.ID)
+		if err != nil || revoked != 1 {
+			t.Errorf("RevokeAllExceptが不正: %d, %v", revoked, err)
+		}
+		sessions, _ = repo.ListActive(userID)
+		if len(sessions) != 1 || sessions[0].ID != keep.ID {
+			t.Errorf("残ったセッションが不正: %+v", sessions)
+		}
+		if sessions, _ := repo.ListActive(otherID); len(sessions) != 1 {
+			t.Errorf("他ユーザーのセッションが影響を受けた: %+v", sessions)
+		}
+	})
+
+	t.Run("ConcurrentRotate", func(t *testing.T) {
+		repo, users := newRepos(t)
+		userID := createUser(t, users)
+		token := uniqueHash()
+		repo.Create(userID, client, token, expiresAt)
+
+		var wg sync.WaitGroup
+		var mu sync.Mutex
+		succeeded := 0
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				_, err := repo.Rotate(token, hashToken(fmt.Sprintf("%s-%d", token, i)), client, expiresAt)
+				if err == nil {
+					mu.Lock()
+					succeeded++
+					mu.Unlock()
+				} else if !errors.Is(err, ErrRefreshTokenReused) && !errors.Is(err, ErrSessionNotFound) {
+					t.Errorf("予期しないエラー: %v", err)
+				}
+			}(i)
+		}
+		wg.Wait()
+
+		if succeeded != 1 {
+			t.Errorf("同じトークンで%d回ローテーションできた", succeeded)
+		}
+	})
+}
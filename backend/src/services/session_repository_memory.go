@@ -0,0 +1,154 @@
+package services
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"backend/models"
+)
+
+// memoryRefreshToken メモリ上のリフレッシュトークン
+type memoryRefreshToken struct {
+	sessionID string
+	expiresAt time.Time
+	used      bool
+}
+
+// MemorySessionRepository メモリ上で動作するセッションリポジトリ
+type MemorySessionRepository struct {
+	mu       sync.RWMutex
+	sessions map[string]*models.Session
+	tokens   map[string]*memoryRefreshToken
+}
+
+// NewMemorySessionRepository メモリセッションリポジトリを新規作成
+func NewMemorySessionRepository() *MemorySessionRepository {
+	return &MemorySessionRepository{
+		sessions: make(map[string]*models.Session),
+		tokens:   make(map[string]*memoryRefreshToken),
+	}
+}
+
+// Create セッションと最初のリフレッシュトークンを保存
+func (r *MemorySessionRepository) Create(userID int, client models.ClientInfo, tokenHash string, expiresAt time.Time) (*models.Session, error) {
+	id, err := newSessionID()
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	session := &models.Session{
+		ID:         id,
+		UserID:     userID,
+		UserAgent:  client.UserAgent,
+		IP:         client.IP,
+		CreatedAt:  now,
+		LastSeenAt: now,
+		ExpiresAt:  expiresAt,
+	}
+	r.sessions[id] = session
+	r.tokens[tokenHash] = &memoryRefreshToken{sessionID: id, expiresAt: expiresAt}
+
+	copied := *session
+	return &copied, nil
+}
+
+// Rotate リフレッシュトークンをローテーション
+func (r *MemorySessionRepository) Rotate(tokenHash, newTokenHash string, client models.ClientInfo, expiresAt time.Time) (*models.Session, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	token, ok := r.tokens[tokenHash]
+	if !ok {
+		return nil, ErrSessionNotFound
+	}
+	session := r.sessions[token.sessionID]
+	if token.used {
+		if session.RevokedAt == nil {
+			session.RevokedAt = &now
+		}
+		return nil, ErrRefreshTokenReused
+	}
+	if !now.Before(token.expiresAt) || !session.Active(now) {
+		return nil, ErrSessionNotFound
+	}
+
+	token.used = true
+	session.LastSeenAt = now
+	session.UserAgent = client.UserAgent
+	session.IP = client.IP
+	session.ExpiresAt = expiresAt
+	r.tokens[newTokenHash] = &memoryRefreshToken{sessionID: session.ID, expiresAt: expiresAt}
+
+	copied := *session
+	return &copied, nil
+}
+
+// FindByID IDでセッションを取得
+func (r *MemorySessionRepository) FindByID(id string) (*models.Session, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	session, ok := r.sessions[id]
+	if !ok {
+		return nil, ErrSessionNotFound
+	}
+	copied := *session
+	return &copied, nil
+}
+
+// ListActive ユーザーの有効なセッションを取得
+func (r *MemorySessionRepository) ListActive(userID int) ([]models.Session, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	now := time.Now()
+	sessions := []models.Session{}
+	for _, session := range r.sessions {
+		if session.UserID == userID && session.Active(now) {
+			sessions = append(sessions, *session)
+		}
+	}
+	sort.Slice(sessions, func(i, j int) bool {
+		if !sessions[i].LastSeenAt.Equal(sessions[j].LastSeenAt) {
+			return sessions[i].LastSeenAt.After(sessions[j].LastSeenAt)
+		}
+		return sessions[i].ID < sessions[j].ID
+	})
+	return sessions, nil
+}
+
+// Revoke ユーザーのセッションを失効
+func (r *MemorySessionRepository) Revoke(userID int, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	session, ok := r.sessions[id]
+	if !ok || session.UserID != userID || session.RevokedAt != nil {
+		return ErrSessionNotFound
+	}
+	now := time.Now()
+	session.RevokedAt = &now
+	return nil
+}
+
+// RevokeAllExcept 指定以外のセッションを全て失効
+func (r *MemorySessionRepository) RevokeAllExcept(userID int, keepID string) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	revoked := 0
+	for id, session := range r.sessions {
+		if session.UserID == userID && id != keepID && session.Active(now) {
+			session.RevokedAt = &now
+			revoked++
+		}
+	}
+	return revoked, nil
+}
@@ -0,0 +1,265 @@
+package services
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"backend/models"
+)
+
+// sessionColumns セッション取得時の列
+const sessionColumns = `id, user_id, user_agent, ip, created_at, last_seen_at, expires_at, revoked_at`
+
+// PostgresSessionRepository PostgreSQLによるセッションリポジトリ
+type PostgresSessionRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresSessionRepository PostgreSQLセッションリポジトリを新規作成
+// db が nil の場合、全ての操作は ErrDatabaseUnavailable を返します
+func NewPostgresSessionRepository(db *sql.DB) *PostgresSessionRepository {
+	return &PostgresSessionRepository{db: db}
+}
+
+// Create セッションと最初のリフレッシュトークンを保存
+func (r *PostgresSessionRepository) Create(userID int, client models.ClientInfo, tokenHash string, expiresAt time.Time) (*models.Session, error) {
+	if r.db == nil {
+		return nil, ErrDatabaseUnavailable
+	}
+
+	id, err := newSessionID()
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO sessions (id, user_id, user_agent, ip, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING ` + sessionColumns
+
+	session, err := scanSession(tx.QueryRow(query, id, userID, client.UserAgent, client.IP, expiresAt))
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to create session: %w", err)
+	}
+
+	if err := insertRefreshToken(tx, id, tokenHash, expiresAt); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit session: %w", err)
+	}
+	return session, nil
+}
+
+// Rotate リフレッシュトークンをローテーション
+// 使用済みへの更新を条件付き UPDATE で行うため、同じトークンによる同時リクエストは1件だけが成功します
+func (r *PostgresSessionRepository) Rotate(tokenHash, newTokenHash string, client models.ClientInfo, expiresAt time.Time) (*models.Session, error) {
+	if r.db == nil {
+		return nil, ErrDatabaseUnavailable
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var sessionID string
+	err = tx.QueryRow(`
+		UPDATE refresh_tokens SET used_at = NOW()
+		WHERE token_hash = $1 AND used_at IS NULL AND expires_at > NOW()
+		RETURNING session_id
+	`, tokenHash).Scan(&sessionID)
+	if err == sql.ErrNoRows {
+		return nil, r.handleUnusableToken(tx, tokenHash)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to use refresh token: %w", err)
+	}
+
+	query := `
+		UPDATE sessions SET last_seen_at = NOW(), user_agent = $2, ip = $3, expires_at = $4
+		WHERE id = $1 AND revoked_at IS NULL AND expires_at > NOW()
+		RETURNING ` + sessionColumns
+
+	session, err := scanSession(tx.QueryRow(query, sessionID, client.UserAgent, client.IP, expiresAt))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrSessionNotFound
+		}
+		return nil, fmt.Errorf("failed to update session: %w", err)
+	}
+
+	if err := insertRefreshToken(tx, sessionID, newTokenHash, expiresAt); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit rotation: %w", err)
+	}
+	return session, nil
+}
+
+// handleUnusableToken 使用できないトークンの原因を判定し、再利用ならセッションを失効させる
+func (r *PostgresSessionRepository) handleUnusableToken(tx *sql.Tx, tokenHash string) error {
+	var sessionID string
+	var used bool
+	err := tx.QueryRow(`
+		SELECT session_id, used_at IS NOT NULL FROM refresh_tokens WHERE token_hash = $1
+	`, tokenHash).Scan(&sessionID, &used)
+	if err == sql.ErrNoRows || (err == nil && !used) {
+		return ErrSessionNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get refresh token: %w", err)
+	}
+
+	if _, err := tx.Exec(`
+		UPDATE sessions SET revoked_at = NOW() WHERE id = $1 AND revoked_at IS NULL
+	`, sessionID); err != nil {
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit revocation: %w", err)
+	}
+	return ErrRefreshTokenReused
+}
+
+// FindByID IDでセッションを取得
+func (r *PostgresSessionRepository) FindByID(id string) (*models.Session, error) {
+	if r.db == nil {
+		return nil, ErrDatabaseUnavailable
+	}
+
+	session, err := scanSession(r.db.QueryRow(`SELECT `+sessionColumns+` FROM sessions WHERE id = $1`, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrSessionNotFound
+		}
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+	return session, nil
+}
+
+// ListActive ユーザーの有効なセッションを取得
+func (r *PostgresSessionRepository) ListActive(userID int) ([]models.Session, error) {
+	if r.db == nil {
+		return nil, ErrDatabaseUnavailable
+	}
+
+	query := `
+		SELECT ` + sessionColumns + `
+		FROM sessions
+		WHERE user_id = $1 AND revoked_at IS NULL AND expires_at > NOW()
+		ORDER BY last_seen_at DESC, id
+	`
+
+	rows, err := r.db.Query(query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+	defer rows.Close()
+
+	sessions := []models.Session{}
+	for rows.Next() {
+		session, err := scanSession(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan session: %w", err)
+		}
+		sessions = append(sessions, *session)
+	}
+	return sessions, rows.Err()
+}
+
+// Revoke ユーザーのセッションを失効
+func (r *PostgresSessionRepository) Revoke(userID int, id string) error {
+	if r.db == nil {
+		return ErrDatabaseUnavailable
+	}
+
+	result, err := r.db.Exec(`
+		UPDATE sessions SET revoked_at = NOW()
+		WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL
+	`, id, userID)
+	if err != nil {
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if affected == 0 {
+		return ErrSessionNotFound
+	}
+	return nil
+}
+
+// RevokeAllExcept 指定以外のセッションを全て失効
+func (r *PostgresSessionRepository) RevokeAllExcept(userID int, keepID string) (int, error) {
+	if r.db == nil {
+		return 0, ErrDatabaseUnavailable
+	}
+
+	result, err := r.db.Exec(`
+		UPDATE sessions SET revoked_at = NOW()
+		WHERE user_id = $1 AND id <> $2 AND revoked_at IS NULL AND expires_at > NOW()
+	`, userID, keepID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	return int(affected), nil
+}
+
+// insertRefreshToken リフレッシュトークンのハッシュを保存
+func insertRefreshToken(tx *sql.Tx, sessionID, tokenHash string, expiresAt time.Time) error {
+	_, err := tx.Exec(`
+		INSERT INTO refresh_tokens (token_hash, session_id, expires_at) VALUES ($1, $2, $3)
+	`, tokenHash, sessionID, expiresAt)
+	if err != nil {
+		return fmt.Errorf("failed to store refresh token: %w", err)
+	}
+	return nil
+}
+
+// rowScanner *sql.Row と *sql.Rows に共通の Scan
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanSession クエリ結果をセッションに変換
+func scanSession(row rowScanner) (*models.Session, error) {
+	var session models.Session
+	var revokedAt sql.NullTime
+	err := row.Scan(
+		&session.ID,
+		&session.UserID,
+		&session.UserAgent,
+		&session.IP,
+		&session.CreatedAt,
+		&session.LastSeenAt,
+		&session.ExpiresAt,
+		&revokedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if revokedAt.Valid {
+		session.RevokedAt = &revokedAt.Time
+	}
+	return &session, nil
+}
@@ -0,0 +1,45 @@
+package services
+
+import "backend/models"
+
+// StatsService 売上統計サービス構造体
+type StatsService struct {
+	sales SaleRepository
+}
+
+// NewStatsService 売上統計サービスを新規作成
+func NewStatsService(sales SaleRepository) *StatsService {
+	return &StatsService{sales: sales}
+}
+
+// GetStats 集計期間の売上・注文数・顧客数を、直前の同じ長さの期間との比較とバケットごとの内訳付きで取得
+func (s *StatsService) GetStats(query *models.StatsQuery) (*models.Stats, error) {
+	previousRange := query.PreviousRange()
+
+	current, err := s.sales.Totals(query.Currency, query.Range)
+	if err != nil {
+		return nil, err
+	}
+	previous, err := s.sales.Totals(query.Currency, previousRange)
+	if err != nil {
+		return nil, err
+	}
+	buckets, err := s.sales.Buckets(query)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.Stats{
+		Period:        query.Period,
+		Timezone:      query.Location.String(),
+		Currency:      query.Currency,
+		Range:         query.Range,
+		PreviousRange: previousRange,
+		Summary: models.StatsSummary{
+			Revenue:   models.NewStatValue(float64(current.Revenue), float64(previous.Revenue)),
+			Orders:    models.NewStatValue(float64(current.Orders), float64(previous.Orders)),
+			Customers: models.NewStatValue(float64(current.Customers), float64(previous.Customers)),
+		},
+		Buckets: buckets,
+	}, nil
+}
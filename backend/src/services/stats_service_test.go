@@ -0,0 +1,54 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"backend/models"
+)
+
+// TestStatsServiceGetStats 直前の期間との比較とバケットごとの内訳のテスト
+func TestStatsServiceGetStats(t *testing.T) {
+	repo := NewMemorySaleRepository()
+	service := NewStatsService(repo)
+	start := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	sales := []models.Sale{
+		{Date: start.AddDate(0, 0, -5), Status: models.SaleStatusPaid, Email: "alex@example.com", Amount: 10000, Currency: "USD"},
+		{Date: start.AddDate(0, 0, -2), Status: models.SaleStatusPaid, Email: "alex@example.com", Amount: 10000, Currency: "USD"},
+		{Date: start.Add(2 * time.Hour), Status: models.SaleStatusPaid, Email: "alex@example.com", Amount: 15000, Currency: "USD"},
+		{Date: start.AddDate(0, 0, 6), Status: models.SaleStatusPaid, Email: "jordan@example.com", Amount: 9000, Currency: "USD"},
+		{Date: start.AddDate(0, 0, 6), Status: models.SaleStatusRefunded, Email: "jordan@example.com", Amount: 50000, Currency: "USD"},
+	}
+	for i := range sales {
+		if _, err := repo.Create(&sales[i]); err != nil {
+			t.Fatalf("Create失敗: %v", err)
+		}
+	}
+
+	stats, err := service.GetStats(&models.StatsQuery{Period: models.StatsPeriodDaily, Location: time.UTC, Currency: "USD",
+		Range: models.StatsRange{Start: start, End: start.AddDate(0, 0, 7)}})
+	if err != nil {
+		t.Fatalf("GetStats() error = %v", err)
+	}
+
+	if !stats.PreviousRange.Start.Equal(start.AddDate(0, 0, -7)) || !stats.PreviousRange.End.Equal(start) {
+		t.Errorf("直前の期間が不正: %+v", stats.PreviousRange)
+	}
+	revenue := stats.Summary.Revenue
+	if revenue.Value != 24000 || revenue.Previous != 20000 || revenue.Variation == nil || *revenue.Variation != 20 {
+		t.Errorf("売上の集計が不正: %+v", revenue)
+	}
+	if orders := stats.Summary.Orders; orders.Value != 2 || orders.Previous != 2 || *orders.Variation != 0 {
+		t.Errorf("注文数の集計が不正: %+v", orders)
+	}
+	if customers := stats.Summary.Customers; customers.Value != 2 || customers.Previous != 1 || *customers.Variation != 100 {
+		t.Errorf("顧客数の集計が不正: %+v", customers)
+	}
+	if len(stats.Buckets) != 7 || stats.Buckets[0].Revenue != 15000 || stats.Buckets[3].Orders != 0 || stats.Buckets[6].Revenue != 9000 {
+		t.Errorf("内訳が不正: %+v", stats.Buckets)
+	}
+	if stats.Period != models.StatsPeriodDaily || stats.Timezone != "UTC" || stats.Currency != "USD" {
+		t.Errorf("集計条件が不正: %s %s %s", stats.Period, stats.Timezone, stats.Currency)
+	}
+}
@@ -0,0 +1,56 @@
+package services
+
+import "backend/models"
+
+var (
+	// ErrTeamNotFound 指定されたチームが存在しない（またはリクエストしたユーザーがメンバーでない）
+	ErrTeamNotFound = newError(ErrNotFound, "team not found", "team.not_found")
+
+	// ErrTeamMemberNotFound 指定されたユーザーがチームのメンバーでない
+	ErrTeamMemberNotFound = newError(ErrNotFound, "team member not found", "team.member_not_found")
+
+	// ErrAlreadyTeamMember ユーザーが既にチームのメンバーである
+	ErrAlreadyTeamMember = newError(ErrConflict, "user is already a team member", "team.already_member")
+
+	// ErrLastTeamOwner 最後の owner を降格・削除しようとした
+	ErrLastTeamOwner = newError(ErrConflict, "cannot remove the last team owner", "team.last_owner")
+
+	// ErrInvitationNotFound 招待が存在しない、または承諾済み
+	ErrInvitationNotFound = newError(ErrNotFound, "invitation not found", "invitation.not_found")
+)
+
+// TeamRepository チーム・メンバー・招待の永続化インターフェース
+//
+// メンバーは参加日時の昇順、招待は作成日時の降順で返します。
+// チームの最後の owner を失う変更は ErrLastTeamOwner で拒否します（同時実行でも owner が0人にならないこと）。
+type TeamRepository interface {
+	// Create チームを作成し、ownerID のユーザーを owner として追加する
+	Create(team *models.Team, ownerID int) (*models.Team, error)
+	// ListForUser ユーザーが参加しているチームをそのユーザーのロール付きで返す
+	ListForUser(userID int) ([]models.Team, error)
+	// FindForUser ユーザーが参加しているチームを返す（メンバーでなければ ErrTeamNotFound）
+	FindForUser(teamID, userID int) (*models.Team, error)
+	// Update チーム名・アバターを更新する
+	Update(team *models.Team) (*models.Team, error)
+	// Delete チームをメンバー・招待ごと削除する
+	Delete(teamID int) error
+
+	// ListMembers チームのメンバーを返す
+	ListMembers(teamID int) ([]models.TeamMember, error)
+	// UpdateMemberRole メンバーのロールを変更する
+	UpdateMemberRole(teamID, userID int, role models.TeamRole) (*models.TeamMember, error)
+	// RemoveMember メンバーをチームから削除する
+	RemoveMember(teamID, userID int) error
+
+	// CreateInvitation 招待を保存する（同じチーム・email の未承諾の招待は置き換える）
+	CreateInvitation(invitation *models.TeamInvitation) (*models.TeamInvitation, error)
+	// ListInvitations チームの未承諾の招待を返す（期限切れを含む）
+	ListInvitations(teamID int) ([]models.TeamInvitation, error)
+	// FindInvitationByTokenHash トークンハッシュで招待を返す（承諾済みを含む）
+	FindInvitationByTokenHash(tokenHash string) (*models.TeamInvitation, error)
+	// DeleteInvitation 未承諾の招待を取り消す
+	DeleteInvitation(teamID, id int) error
+	// AcceptInvitation 未承諾の招待を使用済みにし、ユーザーを招待のロールでメンバーに追加する
+	// 既に承諾済みなら ErrInvitationNotFound、メンバーであれば ErrAlreadyTeamMember を返す
+	AcceptInvitation(id, userID int) (*models.TeamInvitation, error)
+}
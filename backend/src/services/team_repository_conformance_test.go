@@ -0,0 +1,327 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"backend/models"
+)
+
+// TestMemoryTeamRepositoryConformance メモリチームリポジトリの適合テスト
+func TestMemoryTeamRepositoryConformance(t *testing.T) {
+	runTeamRepositoryConformance(t, func(t *testing.T) (TeamRepository, UserRepository) {
+		users := NewMemoryUserRepository()
+		return NewMemoryTeamRepository(users), users
+	})
+}
+
+// TestPostgresTeamRepositoryConformance PostgreSQLチームリポジトリの適合テスト
+func TestPostgresTeamRepositoryConformance(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	runTeamRepositoryConformance(t, func(t *testing.T) (TeamRepository, UserRepository) {
+		return NewPostgresTeamRepository(db), NewPostgresUserRepository(db)
+	})
+}
+
+// runTeamRepositoryConformance 全てのTeamRepository実装が満たすべき振る舞い
+// 共有DBでも動作するよう、ユーザー・トークンは都度一意な値で作成します
+func runTeamRepositoryConformance(t *testing.T, newRepos func(t *testing.T) (TeamRepository, UserRepository)) {
+	unique := func(prefix string) string {
+		return fmt.Sprintf("%s%d", prefix, time.Now().UnixNano())
+	}
+	createUser := func(t *testing.T, users UserRepository, name string) *models.User {
+		t.Helper()
+		user, err := users.Create(unique("team")+"@example.com", name, "hash")
+		if err != nil {
+			t.Fatalf("ユーザー作成失敗: %v", err)
+		}
+		return user
+	}
+	createTeam := func(t *testing.T, repo TeamRepository, ownerID int) *models.Team {
+		t.Helper()
+		team, err := repo.Create(&models.Team{Name: unique("Team ")}, ownerID)
+		if err != nil {
+			t.Fatalf("Create失敗: %v", err)
+		}
+		return team
+	}
+	invite := func(t *testing.T, repo TeamRepository, teamID int, email string, role models.TeamRole) *models.TeamInvitation {
+		t.Helper()
+		invitation, err := repo.CreateInvitation(&models.TeamInvitation{
+			TeamID:    teamID,
+			Email:     email,
+			Role:      role,
+			TokenHash: hashToken(unique("token")),
+			ExpiresAt: time.Now().Add(time.Hour),
+		})
+		if err != nil {
+			t.Fatalf("CreateInvitation失敗: %v", err)
+		}
+		return invitation
+	}
+
+	t.Run("CreateAndFind", func(t *testing.T) {
+		repo, users := newRepos(t)
+		owner := createUser(t, users, "Owner")
+		other := createUser(t, users, "Other")
+
+		team, err := repo.Create(&models.Team{Name: "Nuxt", Avatar: &models.Avatar{Src: "https://github.com/nuxt.png"}}, owner.ID)
+		if err != nil {
+			t.Fatalf("Create失敗: %v", err)
+		}
+		if team.ID == 0 || team.Role != models.TeamRoleOwner || team.Avatar == nil || team.CreatedAt.IsZero() {
+			t.Errorf("作成結果が不正: %+v", team)
+		}
+
+		found, err := repo.FindForUser(team.ID, owner.ID)
+		if err != nil {
+			t.Fatalf("FindForUser失敗: %v", err)
+		}
+		if found.Name != "Nuxt" || found.Role != models.TeamRoleOwner {
+			t.Errorf("取得結果が不正: %+v", found)
+		}
+
+		// メンバーでないユーザーにはチームが存在しない
+		if _, err := repo.FindForUser(team.ID, other.ID); !errors.Is(err, ErrTeamNotFound) {
+			t.Errorf("Expected ErrTeamNotFound, got %v", err)
+		}
+
+		teams, err := repo.ListForUser(owner.ID)
+		if err != nil {
+			t.Fatalf("ListForUser失敗: %v", err)
+		}
+		if len(teams) != 1 || teams[0].ID != team.ID {
+			t.Errorf("Expected only the created team, got %+v", teams)
+		}
+		if teams, _ := repo.ListForUser(other.ID); len(teams) != 0 {
+			t.Errorf("Expected no teams for non-member, got %+v", teams)
+		}
+	})
+
+	t.Run("UpdateAndDelete", func(t *testing.T) {
+		repo, users := newRepos(t)
+		owner := createUser(t, users, "Owner")
+		team := createTeam(t, repo, owner.ID)
+		invite(t, repo, team.ID, unique("invitee")+"@example.com", models.TeamRoleMember)
+
+		updated, err := repo.Update(&models.Team{ID: team.ID, Name: "Renamed"})
+		if err != nil {
+			t.Fatalf("Update失敗: %v", err)
+		}
+		if updated.Name != "Renamed" || updated.Avatar != nil || !updated.CreatedAt.Equal(team.CreatedAt) {
+			t.Errorf("更新結果が不正: %+v", updated)
+		}
+		if _, err := repo.Update(&models.Team{ID: 999999999, Name: "x"}); !errors.Is(err, ErrTeamNotFound) {
+			t.Errorf("Expected ErrTeamNotFound, got %v", err)
+		}
+
+		if err := repo.Delete(team.ID); err != nil {
+			t.Fatalf("Delete失敗: %v", err)
+		}
+		if _, err := repo.FindForUser(team.ID, owner.ID); !errors.Is(err, ErrTeamNotFound) {
+			t.Errorf("Expected ErrTeamNotFound after delete, got %v", err)
+		}
+		if invitations, _ := repo.ListInvitations(team.ID); len(invitations) != 0 {
+			t.Errorf("Expected invitations to be deleted with team, got %+v", invitations)
+		}
+		if err := repo.Delete(team.ID); !errors.Is(err, ErrTeamNotFound) {
+			t.Errorf("Expected ErrTeamNotFound, got %v", err)
+		}
+	})
+
+	t.Run("Members", func(t *testing.T) {
+		repo, users := newRepos(t)
+		owner := createUser(t, users, "Owner")
+		member := createUser(t, users, "Member")
+		team := createTeam(t, repo, owner.ID)
+
+		invitation := invite(t, repo, team.ID, member.Email, models.TeamRoleMember)
+		if _, err := repo.AcceptInvitation(invitation.ID, member.ID); err != nil {
+			t.Fatalf("AcceptInvitation失敗: %v", err)
+		}
+
+		members, err := repo.ListMembers(team.ID)
+		if err != nil {
+			t.Fatalf("ListMembers失敗: %v", err)
+		}
+		if len(members) != 2 || members[0].UserID != owner.ID || members[1].UserID != member.ID {
+			t.Fatalf("Expected owner then member, got %+v", members)
+		}
+		if members[1].Name != "Member" || members[1].Email != member.Email || members[1].Role != models.TeamRoleMember {
+			t.Errorf("メンバー情報が不正: %+v", members[1])
+		}
+
+		promoted, err := repo.UpdateMemberRole(team.ID, member.ID, models.TeamRoleOwner)
+		if err != nil {
+			t.Fatalf("UpdateMemberRole失敗: %v", err)
+		}
+		if promoted.Role != models.TeamRoleOwner || promoted.Email != member.Email {
+			t.Errorf("ロール変更結果が不正: %+v", promoted)
+		}
+
+		if _, err := repo.UpdateMemberRole(team.ID, 999999999, models.TeamRoleOwner); !errors.Is(err, ErrTeamMemberNotFound) {
+			t.Errorf("Expected ErrTeamMemberNotFound, got %v", err)
+		}
+		if err := repo.RemoveMember(999999999, owner.ID); !errors.Is(err, ErrTeamNotFound) {
+			t.Errorf("Expected ErrTeamNotFound, got %v", err)
+		}
+
+		if err := repo.RemoveMember(team.ID, owner.ID); err != nil {
+			t.Fatalf("RemoveMember失敗: %v", err)
+		}
+		if _, err := repo.FindForUser(team.ID, owner.ID); !errors.Is(err, ErrTeamNotFound) {
+			t.Errorf("Expected removed member to lose access, got %v", err)
+		}
+	})
+
+	t.Run("LastOwner", func(t *testing.T) {
+		repo, users := newRepos(t)
+		owner := createUser(t, users, "Owner")
+		team := createTeam(t, repo, owner.ID)
+
+		if _, err := repo.UpdateMemberRole(team.ID, owner.ID, models.TeamRoleMember); !errors.Is(err, ErrLastTeamOwner) {
+			t.Errorf("Expected ErrLastTeamOwner on demote, got %v", err)
+		}
+		if err := repo.RemoveMember(team.ID, owner.ID); !errors.Is(err, ErrLastTeamOwner) {
+			t.Errorf("Expected ErrLastTeamOwner on remove, got %v", err)
+		}
+		// owner のまま変更するのは許可
+		if _, err := repo.UpdateMemberRole(team.ID, owner.ID, models.TeamRoleOwner); err != nil {
+			t.Errorf("UpdateMemberRole to owner failed: %v", err)
+		}
+	})
+
+	t.Run("LastOwnerConcurrent", func(t *testing.T) {
+		repo, users := newRepos(t)
+		first := createUser(t, users, "First")
+		second := createUser(t, users, "Second")
+		team := createTeam(t, repo, first.ID)
+		invitation := invite(t, repo, team.ID, second.Email, models.TeamRoleOwner)
+		if _, err := repo.AcceptInvitation(invitation.ID, second.ID); err != nil {
+			t.Fatalf("AcceptInvitation失敗: %v", err)
+		}
+
+		// 2人の owner が同時に抜けても、どちらか一方は拒否される
+		var wg sync.WaitGroup
+		errs := make([]error, 2)
+		for i, userID := range []int{first.ID, second.ID} {
+			wg.Add(1)
+			go func(i, userID int) {
+				defer wg.Done()
+				errs[i] = repo.RemoveMember(team.ID, userID)
+			}(i, userID)
+		}
+		wg.Wait()
+
+		failed := 0
+		for _, err := range errs {
+			if errors.Is(err, ErrLastTeamOwner) {
+				failed++
+			} else if err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		}
+		if failed != 1 {
+			t.Errorf("Expected exactly one ErrLastTeamOwner, got %v", errs)
+		}
+		members, _ := repo.ListMembers(team.ID)
+		if len(members) != 1 || members[0].Role != models.TeamRoleOwner {
+			t.Errorf("Expected one remaining owner, got %+v", members)
+		}
+	})
+
+	t.Run("Invitations", func(t *testing.T) {
+		repo, users := newRepos(t)
+		owner := createUser(t, users, "Owner")
+		team := createTeam(t, repo, owner.ID)
+		email := unique("invitee") + "@example.com"
+
+		first := invite(t, repo, team.ID, email, models.TeamRoleMember)
+		if first.ID == 0 || first.AcceptedAt != nil || first.CreatedAt.IsZero() {
+			t.Errorf("招待作成結果が不正: %+v", first)
+		}
+
+		// 同じ email への再招待は前の招待を置き換える
+		second := invite(t, repo, team.ID, email, models.TeamRoleOwner)
+		other := invite(t, repo, team.ID, unique("other")+"@example.com", models.TeamRoleMember)
+
+		invitations, err := repo.ListInvitations(team.ID)
+		if err != nil {
+			t.Fatalf("ListInvitations失敗: %v", err)
+		}
+		if len(invitations) != 2 || invitations[0].ID != other.ID || invitations[1].ID != second.ID {
+			t.Fatalf("Expected [other, second], got %+v", invitations)
+		}
+		if _, err := repo.FindInvitationByTokenHash(first.TokenHash); !errors.Is(err, ErrInvitationNotFound) {
+			t.Errorf("Expected replaced invitation to be gone, got %v", err)
+		}
+
+		found, err := repo.FindInvitationByTokenHash(second.TokenHash)
+		if err != nil {
+			t.Fatalf("FindInvitationByTokenHash失敗: %v", err)
+		}
+		if found.ID != second.ID || found.Role != models.TeamRoleOwner || found.Email != email {
+			t.Errorf("取得結果が不正: %+v", found)
+		}
+
+		if err := repo.DeleteInvitation(team.ID+1, other.ID); !errors.Is(err, ErrInvitationNotFound) {
+			t.Errorf("Expected ErrInvitationNotFound for other team, got %v", err)
+		}
+		if err := repo.DeleteInvitation(team.ID, other.ID); err != nil {
+			t.Fatalf("DeleteInvitation失敗: %v", err)
+		}
+		if err := repo.DeleteInvitation(team.ID, other.ID); !errors.Is(err, ErrInvitationNotFound) {
+			t.Errorf("Expected ErrInvitationNotFound, got %v", err)
+		}
+	})
+
+	t.Run("AcceptInvitation", func(t *testing.T) {
+		repo, users := newRepos(t)
+		owner := createUser(t, users, "Owner")
+		invitee := createUser(t, users, "Invitee")
+		team := createTeam(t, repo, owner.ID)
+
+		// 既にメンバーの場合は招待を使用済みにしない
+		own := invite(t, repo, team.ID, owner.Email, models.TeamRoleMember)
+		if _, err := repo.AcceptInvitation(own.ID, owner.ID); !errors.Is(err, ErrAlreadyTeamMember) {
+			t.Errorf("Expected ErrAlreadyTeamMember, got %v", err)
+		}
+		if found, _ := repo.FindInvitationByTokenHash(own.TokenHash); found == nil || found.AcceptedAt != nil {
+			t.Errorf("Expected invitation to stay pending, got %+v", found)
+		}
+
+		invitation := invite(t, repo, team.ID, invitee.Email, models.TeamRoleMember)
+		accepted, err := repo.AcceptInvitation(invitation.ID, invitee.ID)
+		if err != nil {
+			t.Fatalf("AcceptInvitation失敗: %v", err)
+		}
+		if accepted.AcceptedAt == nil || accepted.TeamID != team.ID {
+			t.Errorf("承諾結果が不正: %+v", accepted)
+		}
+
+		joined, err := repo.FindForUser(team.ID, invitee.ID)
+		if err != nil || joined.Role != models.TeamRoleMember {
+			t.Errorf("Expected invitee to join as member, got %+v, %v", joined, err)
+		}
+
+		// 招待は1回のみ使用可能
+		if _, err := repo.AcceptInvitation(invitation.ID, invitee.ID); !errors.Is(err, ErrInvitationNotFound) {
+			t.Errorf("Expected ErrInvitationNotFound on reuse, got %v", err)
+		}
+		found, err := repo.FindInvitationByTokenHash(invitation.TokenHash)
+		if err != nil || found.AcceptedAt == nil {
+			t.Errorf("Expected accepted invitation to be findable, got %+v, %v", found, err)
+		}
+		invitations, _ := repo.ListInvitations(team.ID)
+		for _, pending := range invitations {
+			if pending.ID == invitation.ID {
+				t.Errorf("Accepted invitation should not be listed: %+v", pending)
+			}
+		}
+	})
+}
@@ -0,0 +1,359 @@
+package services
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"backend/models"
+)
+
+// teamMemberKey チームメンバーのキー
+type teamMemberKey struct {
+	teamID int
+	userID int
+}
+
+// memoryTeamMember 保存しているメンバーシップ（名前・email は取得時にユーザーから解決）
+type memoryTeamMember struct {
+	role     models.TeamRole
+	joinedAt time.Time
+	seq      int
+}
+
+// MemoryTeamRepository メモリ上で動作するチームリポジトリ
+type MemoryTeamRepository struct {
+	mu           sync.RWMutex
+	users        UserRepository
+	teams        map[int]models.Team
+	members      map[teamMemberKey]memoryTeamMember
+	invitations  map[int]models.TeamInvitation
+	nextID       int
+	nextInviteID int
+	nextSeq      int
+}
+
+// NewMemoryTeamRepository メモリチームリポジトリを新規作成
+// メンバーの名前・email は users から取得します
+func NewMemoryTeamRepository(users UserRepository) *MemoryTeamRepository {
+	return &MemoryTeamRepository{
+		users:        users,
+		teams:        make(map[int]models.Team),
+		members:      make(map[teamMemberKey]memoryTeamMember),
+		invitations:  make(map[int]models.TeamInvitation),
+		nextID:       1,
+		nextInviteID: 1,
+	}
+}
+
+// Create チームと作成者の owner メンバーシップを保存
+func (r *MemoryTeamRepository) Create(team *models.Team, ownerID int) (*models.Team, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	stored := copyTeam(*team)
+	stored.ID = r.nextID
+	stored.Role = ""
+	stored.CreatedAt = now
+	stored.UpdatedAt = now
+	r.teams[stored.ID] = stored
+	r.nextID++
+	r.addMember(stored.ID, ownerID, models.TeamRoleOwner, now)
+
+	result := copyTeam(stored)
+	result.Role = models.TeamRoleOwner
+	return &result, nil
+}
+
+// ListForUser ユーザーが参加しているチームを名前順で取得
+func (r *MemoryTeamRepository) ListForUser(userID int) ([]models.Team, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	teams := []models.Team{}
+	for key, member := range r.members {
+		if key.userID != userID {
+			continue
+		}
+		team := copyTeam(r.teams[key.teamID])
+		team.Role = member.role
+		teams = append(teams, team)
+	}
+	sort.Slice(teams, func(i, j int) bool {
+		if teams[i].Name != teams[j].Name {
+			return teams[i].Name < teams[j].Name
+		}
+		return teams[i].ID < teams[j].ID
+	})
+	return teams, nil
+}
+
+// FindForUser ユーザーが参加しているチームを取得
+func (r *MemoryTeamRepository) FindForUser(teamID, userID int) (*models.Team, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	member, ok := r.members[teamMemberKey{teamID, userID}]
+	if !ok {
+		return nil, ErrTeamNotFound
+	}
+	team := copyTeam(r.teams[teamID])
+	team.Role = member.role
+	return &team, nil
+}
+
+// Update チーム名・アバターを更新（Role は空）
+func (r *MemoryTeamRepository) Update(team *models.Team) (*models.Team, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current, ok := r.teams[team.ID]
+	if !ok {
+		return nil, ErrTeamNotFound
+	}
+
+	updated := copyTeam(*team)
+	updated.Role = ""
+	updated.CreatedAt = current.CreatedAt
+	updated.UpdatedAt = time.Now()
+	r.teams[team.ID] = updated
+
+	result := copyTeam(updated)
+	return &result, nil
+}
+
+// Delete チームをメンバー・招待ごと削除
+func (r *MemoryTeamRepository) Delete(teamID int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.teams[teamID]; !ok {
+		return ErrTeamNotFound
+	}
+	delete(r.teams, teamID)
+	for key := range r.members {
+		if key.teamID == teamID {
+			delete(r.members, key)
+		}
+	}
+	for id, invitation := range r.invitations {
+		if invitation.TeamID == teamID {
+			delete(r.invitations, id)
+		}
+	}
+	return nil
+}
+
+// ListMembers チームのメンバーを参加日時の昇順で取得
+func (r *MemoryTeamRepository) ListMembers(teamID int) ([]models.TeamMember, error) {
+	r.mu.RLock()
+	type entry struct {
+		member models.TeamMember
+		seq    int
+	}
+	entries := []entry{}
+	for key, member := range r.members {
+		if key.teamID == teamID {
+			entries = append(entries, entry{
+				member: models.TeamMember{UserID: key.userID, Role: member.role, JoinedAt: member.joinedAt},
+				seq:    member.seq,
+			})
+		}
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].seq < entries[j].seq })
+
+	members := make([]models.TeamMember, 0, len(entries))
+	for _, e := range entries {
+		member, err := r.resolveMember(e.member)
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, *member)
+	}
+	return members, nil
+}
+
+// UpdateMemberRole メンバーのロールを変更
+func (r *MemoryTeamRepository) UpdateMemberRole(teamID, userID int, role models.TeamRole) (*models.TeamMember, error) {
+	r.mu.Lock()
+	if err := r.checkOwnerRemains(teamID, userID, role == models.TeamRoleOwner); err != nil {
+		r.mu.Unlock()
+		return nil, err
+	}
+	key := teamMemberKey{teamID, userID}
+	member := r.members[key]
+	member.role = role
+	r.members[key] = member
+	r.mu.Unlock()
+
+	return r.resolveMember(models.TeamMember{UserID: userID, Role: role, JoinedAt: member.joinedAt})
+}
+
+// RemoveMember メンバーをチームから削除
+func (r *MemoryTeamRepository) RemoveMember(teamID, userID int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.checkOwnerRemains(teamID, userID, false); err != nil {
+		return err
+	}
+	delete(r.members, teamMemberKey{teamID, userID})
+	return nil
+}
+
+// CreateInvitation 招待を保存（同じ email の未承諾の招待は削除して置き換える）
+func (r *MemoryTeamRepository) CreateInvitation(invitation *models.TeamInvitation) (*models.TeamInvitation, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.teams[invitation.TeamID]; !ok {
+		return nil, ErrTeamNotFound
+	}
+	for id, existing := range r.invitations {
+		if existing.TeamID == invitation.TeamID && existing.Email == invitation.Email && existing.AcceptedAt == nil {
+			delete(r.invitations, id)
+		}
+	}
+
+	stored := *invitation
+	stored.ID = r.nextInviteID
+	stored.AcceptedAt = nil
+	stored.CreatedAt = time.Now()
+	r.invitations[stored.ID] = stored
+	r.nextInviteID++
+
+	result := stored
+	return &result, nil
+}
+
+// ListInvitations チームの未承諾の招待を作成日時の降順で取得
+func (r *MemoryTeamRepository) ListInvitations(teamID int) ([]models.TeamInvitation, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	invitations := []models.TeamInvitation{}
+	for _, invitation := range r.invitations {
+		if invitation.TeamID == teamID && invitation.AcceptedAt == nil {
+			invitations = append(invitations, invitation)
+		}
+	}
+	sort.Slice(invitations, func(i, j int) bool {
+		if !invitations[i].CreatedAt.Equal(invitations[j].CreatedAt) {
+			return invitations[i].CreatedAt.After(invitations[j].CreatedAt)
+		}
+		return invitations[i].ID > invitations[j].ID
+	})
+	return invitations, nil
+}
+
+// FindInvitationByTokenHash トークンハッシュで招待を取得
+func (r *MemoryTeamRepository) FindInvitationByTokenHash(tokenHash string) (*models.TeamInvitation, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, invitation := range r.invitations {
+		if invitation.TokenHash == tokenHash {
+			result := copyInvitation(invitation)
+			return &result, nil
+		}
+	}
+	return nil, ErrInvitationNotFound
+}
+
+// DeleteInvitation 未承諾の招待を取り消す
+func (r *MemoryTeamRepository) DeleteInvitation(teamID, id int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	invitation, ok := r.invitations[id]
+	if !ok || invitation.TeamID != teamID || invitation.AcceptedAt != nil {
+		return ErrInvitationNotFound
+	}
+	delete(r.invitations, id)
+	return nil
+}
+
+// AcceptInvitation 招待を使用済みにしてメンバーを追加
+func (r *MemoryTeamRepository) AcceptInvitation(id, userID int) (*models.TeamInvitation, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	invitation, ok := r.invitations[id]
+	if !ok || invitation.AcceptedAt != nil {
+		return nil, ErrInvitationNotFound
+	}
+	if _, ok := r.members[teamMemberKey{invitation.TeamID, userID}]; ok {
+		return nil, ErrAlreadyTeamMember
+	}
+
+	now := time.Now()
+	invitation.AcceptedAt = &now
+	r.invitations[id] = invitation
+	r.addMember(invitation.TeamID, userID, invitation.Role, now)
+
+	result := copyInvitation(invitation)
+	return &result, nil
+}
+
+// addMember メンバーシップを追加（呼び出し側でロックを保持すること）
+func (r *MemoryTeamRepository) addMember(teamID, userID int, role models.TeamRole, joinedAt time.Time) {
+	r.members[teamMemberKey{teamID, userID}] = memoryTeamMember{role: role, joinedAt: joinedAt, seq: r.nextSeq}
+	r.nextSeq++
+}
+
+// checkOwnerRemains 変更後もチームに owner が残るか確認（呼び出し側でロックを保持すること）
+func (r *MemoryTeamRepository) checkOwnerRemains(teamID, userID int, staysOwner bool) error {
+	if _, ok := r.teams[teamID]; !ok {
+		return ErrTeamNotFound
+	}
+	member, ok := r.members[teamMemberKey{teamID, userID}]
+	if !ok {
+		return ErrTeamMemberNotFound
+	}
+	if member.role != models.TeamRoleOwner || staysOwner {
+		return nil
+	}
+
+	owners := 0
+	for key, m := range r.members {
+		if key.teamID == teamID && m.role == models.TeamRoleOwner {
+			owners++
+		}
+	}
+	if owners <= 1 {
+		return ErrLastTeamOwner
+	}
+	return nil
+}
+
+// resolveMember ユーザーリポジトリから名前・email を補完
+func (r *MemoryTeamRepository) resolveMember(member models.TeamMember) (*models.TeamMember, error) {
+	user, err := r.users.FindByID(member.UserID)
+	if err != nil {
+		return nil, err
+	}
+	member.Name = user.Name
+	member.Email = user.Email
+	return &member, nil
+}
+
+// copyTeam 呼び出し側の変更が保存データに影響しないようチームを複製
+func copyTeam(team models.Team) models.Team {
+	if team.Avatar != nil {
+		avatar := *team.Avatar
+		team.Avatar = &avatar
+	}
+	return team
+}
+
+// copyInvitation 呼び出し側の変更が保存データに影響しないよう招待を複製
+func copyInvitation(invitation models.TeamInvitation) models.TeamInvitation {
+	if invitation.AcceptedAt != nil {
+		acceptedAt := *invitation.AcceptedAt
+		invitation.AcceptedAt = &acceptedAt
+	}
+	return invitation
+}
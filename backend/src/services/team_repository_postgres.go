@@ -0,0 +1,428 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"backend/models"
+)
+
+// invitationColumns 招待取得時の列
+const invitationColumns = `id, team_id, email, role, token_hash, invited_by, expires_at, accepted_at, created_at`
+
+// PostgresTeamRepository PostgreSQLによるチームリポジトリ
+type PostgresTeamRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresTeamRepository PostgreSQLチームリポジトリを新規作成
+// db が nil の場合、全ての操作は ErrDatabaseUnavailable を返します
+func NewPostgresTeamRepository(db *sql.DB) *PostgresTeamRepository {
+	return &PostgresTeamRepository{db: db}
+}
+
+// Create チームと作成者の owner メンバーシップを1トランザクションで作成
+func (r *PostgresTeamRepository) Create(team *models.Team, ownerID int) (*models.Team, error) {
+	if r.db == nil {
+		return nil, ErrDatabaseUnavailable
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	created, err := scanTeam(tx.QueryRow(`
+		INSERT INTO teams (name, avatar_url) VALUES ($1, $2)
+		RETURNING id, name, avatar_url, '', created_at, updated_at`,
+		team.Name, avatarURL(team.Avatar)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create team: %w", err)
+	}
+	created.Role = models.TeamRoleOwner
+
+	if _, err := tx.Exec(`INSERT INTO team_members (team_id, user_id, role) VALUES ($1, $2, $3)`,
+		created.ID, ownerID, models.TeamRoleOwner); err != nil {
+		return nil, fmt.Errorf("failed to add team owner: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit team: %w", err)
+	}
+	return created, nil
+}
+
+// ListForUser ユーザーが参加しているチームを名前順で取得
+func (r *PostgresTeamRepository) ListForUser(userID int) ([]models.Team, error) {
+	if r.db == nil {
+		return nil, ErrDatabaseUnavailable
+	}
+
+	rows, err := r.db.Query(`
+		SELECT t.id, t.name, t.avatar_url, tm.role, t.created_at, t.updated_at
+		FROM teams t JOIN team_members tm ON tm.team_id = t.id
+		WHERE tm.user_id = $1
+		ORDER BY t.name, t.id`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query teams: %w", err)
+	}
+	defer rows.Close()
+
+	teams := []models.Team{}
+	for rows.Next() {
+		team, err := scanTeam(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan team: %w", err)
+		}
+		teams = append(teams, *team)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating teams: %w", err)
+	}
+	return teams, nil
+}
+
+// FindForUser ユーザーが参加しているチームを取得
+func (r *PostgresTeamRepository) FindForUser(teamID, userID int) (*models.Team, error) {
+	if r.db == nil {
+		return nil, ErrDatabaseUnavailable
+	}
+
+	team, err := scanTeam(r.db.QueryRow(`
+		SELECT t.id, t.name, t.avatar_url, tm.role, t.created_at, t.updated_at
+		FROM teams t JOIN team_members tm ON tm.team_id = t.id
+		WHERE t.id = $1 AND tm.user_id = $2`, teamID, userID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrTeamNotFound
+		}
+		return nil, fmt.Errorf("failed to get team: %w", err)
+	}
+	return team, nil
+}
+
+// Update チーム名・アバターを更新（updated_at はトリガーで更新、Role は空）
+func (r *PostgresTeamRepository) Update(team *models.Team) (*models.Team, error) {
+	if r.db == nil {
+		return nil, ErrDatabaseUnavailable
+	}
+
+	updated, err := scanTeam(r.db.QueryRow(`
+		UPDATE teams SET name = $2, avatar_url = $3 WHERE id = $1
+		RETURNING id, name, avatar_url, '', created_at, updated_at`,
+		team.ID, team.Name, avatarURL(team.Avatar)))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrTeamNotFound
+		}
+		return nil, fmt.Errorf("failed to update team: %w", err)
+	}
+	return updated, nil
+}
+
+// Delete チームを削除（メンバー・招待は ON DELETE CASCADE で削除）
+func (r *PostgresTeamRepository) Delete(teamID int) error {
+	if r.db == nil {
+		return ErrDatabaseUnavailable
+	}
+
+	result, err := r.db.Exec(`DELETE FROM teams WHERE id = $1`, teamID)
+	if err != nil {
+		return fmt.Errorf("failed to delete team: %w", err)
+	}
+	return requireAffected(result, ErrTeamNotFound)
+}
+
+// ListMembers チームのメンバーを参加日時の昇順で取得
+func (r *PostgresTeamRepository) ListMembers(teamID int) ([]models.TeamMember, error) {
+	if r.db == nil {
+		return nil, ErrDatabaseUnavailable
+	}
+
+	rows, err := r.db.Query(`
+		SELECT u.id, u.name, u.email, tm.role, tm.created_at
+		FROM team_members tm JOIN users u ON u.id = tm.user_id
+		WHERE tm.team_id = $1
+		ORDER BY tm.created_at, u.id`, teamID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query team members: %w", err)
+	}
+	defer rows.Close()
+
+	members := []models.TeamMember{}
+	for rows.Next() {
+		member, err := scanTeamMember(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan team member: %w", err)
+		}
+		members = append(members, *member)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating team members: %w", err)
+	}
+	return members, nil
+}
+
+// UpdateMemberRole メンバーのロールを変更
+func (r *PostgresTeamRepository) UpdateMemberRole(teamID, userID int, role models.TeamRole) (*models.TeamMember, error) {
+	if r.db == nil {
+		return nil, ErrDatabaseUnavailable
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := r.checkOwnerRemains(tx, teamID, userID, role == models.TeamRoleOwner); err != nil {
+		return nil, err
+	}
+
+	if _, err := tx.Exec(`UPDATE team_members SET role = $3 WHERE team_id = $1 AND user_id = $2`, teamID, userID, role); err != nil {
+		return nil, fmt.Errorf("failed to update team member: %w", err)
+	}
+
+	member, err := scanTeamMember(tx.QueryRow(`
+		SELECT u.id, u.name, u.email, tm.role, tm.created_at
+		FROM team_members tm JOIN users u ON u.id = tm.user_id
+		WHERE tm.team_id = $1 AND tm.user_id = $2`, teamID, userID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team member: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit team member: %w", err)
+	}
+	return member, nil
+}
+
+// RemoveMember メンバーをチームから削除
+func (r *PostgresTeamRepository) RemoveMember(teamID, userID int) error {
+	if r.db == nil {
+		return ErrDatabaseUnavailable
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := r.checkOwnerRemains(tx, teamID, userID, false); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(`DELETE FROM team_members WHERE team_id = $1 AND user_id = $2`, teamID, userID); err != nil {
+		return fmt.Errorf("failed to remove team member: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit team member removal: %w", err)
+	}
+	return nil
+}
+
+// checkOwnerRemains 変更後もチームに owner が残るか確認
+// チームの行をロックするため、同時に複数の owner を降格・削除しても owner は0人になりません
+func (r *PostgresTeamRepository) checkOwnerRemains(tx *sql.Tx, teamID, userID int, staysOwner bool) error {
+	var id int
+	if err := tx.QueryRow(`SELECT id FROM teams WHERE id = $1 FOR UPDATE`, teamID).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrTeamNotFound
+		}
+		return fmt.Errorf("failed to lock team: %w", err)
+	}
+
+	var role models.TeamRole
+	err := tx.QueryRow(`SELECT role FROM team_members WHERE team_id = $1 AND user_id = $2`, teamID, userID).Scan(&role)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrTeamMemberNotFound
+		}
+		return fmt.Errorf("failed to get team member: %w", err)
+	}
+	if role != models.TeamRoleOwner || staysOwner {
+		return nil
+	}
+
+	var owners int
+	if err := tx.QueryRow(`SELECT COUNT(*) FROM team_members WHERE team_id = $1 AND role = $2`, teamID, models.TeamRoleOwner).Scan(&owners); err != nil {
+		return fmt.Errorf("failed to count team owners: %w", err)
+	}
+	if owners <= 1 {
+		return ErrLastTeamOwner
+	}
+	return nil
+}
+
+// CreateInvitation 招待を保存（同じ email の未承諾の招待は削除して置き換える）
+func (r *PostgresTeamRepository) CreateInvitation(invitation *models.TeamInvitation) (*models.TeamInvitation, error) {
+	if r.db == nil {
+		return nil, ErrDatabaseUnavailable
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM team_invitations WHERE team_id = $1 AND email = $2 AND accepted_at IS NULL`,
+		invitation.TeamID, invitation.Email); err != nil {
+		return nil, fmt.Errorf("failed to replace invitation: %w", err)
+	}
+
+	created, err := scanInvitation(tx.QueryRow(`
+		INSERT INTO team_invitations (team_id, email, role, token_hash, invited_by, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING `+invitationColumns,
+		invitation.TeamID, invitation.Email, invitation.Role, invitation.TokenHash, invitation.InvitedBy, invitation.ExpiresAt))
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return nil, ErrTeamNotFound
+		}
+		return nil, fmt.Errorf("failed to create invitation: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit invitation: %w", err)
+	}
+	return created, nil
+}
+
+// ListInvitations チームの未承諾の招待を作成日時の降順で取得
+func (r *PostgresTeamRepository) ListInvitations(teamID int) ([]models.TeamInvitation, error) {
+	if r.db == nil {
+		return nil, ErrDatabaseUnavailable
+	}
+
+	rows, err := r.db.Query(`
+		SELECT `+invitationColumns+` FROM team_invitations
+		WHERE team_id = $1 AND accepted_at IS NULL
+		ORDER BY created_at DESC, id DESC`, teamID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query invitations: %w", err)
+	}
+	defer rows.Close()
+
+	invitations := []models.TeamInvitation{}
+	for rows.Next() {
+		invitation, err := scanInvitation(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan invitation: %w", err)
+		}
+		invitations = append(invitations, *invitation)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating invitations: %w", err)
+	}
+	return invitations, nil
+}
+
+// FindInvitationByTokenHash トークンハッシュで招待を取得
+func (r *PostgresTeamRepository) FindInvitationByTokenHash(tokenHash string) (*models.TeamInvitation, error) {
+	if r.db == nil {
+		return nil, ErrDatabaseUnavailable
+	}
+
+	invitation, err := scanInvitation(r.db.QueryRow(`SELECT `+invitationColumns+` FROM team_invitations WHERE token_hash = $1`, tokenHash))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrInvitationNotFound
+		}
+		return nil, fmt.Errorf("failed to get invitation: %w", err)
+	}
+	return invitation, nil
+}
+
+// DeleteInvitation 未承諾の招待を取り消す
+func (r *PostgresTeamRepository) DeleteInvitation(teamID, id int) error {
+	if r.db == nil {
+		return ErrDatabaseUnavailable
+	}
+
+	result, err := r.db.Exec(`DELETE FROM team_invitations WHERE id = $1 AND team_id = $2 AND accepted_at IS NULL`, id, teamID)
+	if err != nil {
+		return fmt.Errorf("failed to delete invitation: %w", err)
+	}
+	return requireAffected(result, ErrInvitationNotFound)
+}
+
+// AcceptInvitation 招待を使用済みにしてメンバーを追加（どちらかが失敗した場合は両方とも取り消す）
+func (r *PostgresTeamRepository) AcceptInvitation(id, userID int) (*models.TeamInvitation, error) {
+	if r.db == nil {
+		return nil, ErrDatabaseUnavailable
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	invitation, err := scanInvitation(tx.QueryRow(`
+		UPDATE team_invitations SET accepted_at = NOW()
+		WHERE id = $1 AND accepted_at IS NULL
+		RETURNING `+invitationColumns, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrInvitationNotFound
+		}
+		return nil, fmt.Errorf("failed to accept invitation: %w", err)
+	}
+
+	if _, err := tx.Exec(`INSERT INTO team_members (team_id, user_id, role) VALUES ($1, $2, $3)`,
+		invitation.TeamID, userID, invitation.Role); err != nil {
+		if isUniqueViolation(err) {
+			return nil, ErrAlreadyTeamMember
+		}
+		return nil, fmt.Errorf("failed to add team member: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit invitation: %w", err)
+	}
+	return invitation, nil
+}
+
+// scanTeam 1行をチームに変換（空の avatar_url はアバターなし）
+func scanTeam(row rowScanner) (*models.Team, error) {
+	var team models.Team
+	var avatar string
+	if err := row.Scan(&team.ID, &team.Name, &avatar, &team.Role, &team.CreatedAt, &team.UpdatedAt); err != nil {
+		return nil, err
+	}
+	if avatar != "" {
+		team.Avatar = &models.Avatar{Src: avatar}
+	}
+	return &team, nil
+}
+
+// scanTeamMember 1行をチームメンバーに変換
+func scanTeamMember(row rowScanner) (*models.TeamMember, error) {
+	var member models.TeamMember
+	if err := row.Scan(&member.UserID, &member.Name, &member.Email, &member.Role, &member.JoinedAt); err != nil {
+		return nil, err
+	}
+	return &member, nil
+}
+
+// scanInvitation 1行を招待に変換（招待者が削除されている場合 InvitedBy は0）
+func scanInvitation(row rowScanner) (*models.TeamInvitation, error) {
+	var invitation models.TeamInvitation
+	var invitedBy sql.NullInt64
+	var acceptedAt sql.NullTime
+	err := row.Scan(&invitation.ID, &invitation.TeamID, &invitation.Email, &invitation.Role, &invitation.TokenHash,
+		&invitedBy, &invitation.ExpiresAt, &acceptedAt, &invitation.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	invitation.InvitedBy = int(invitedBy.Int64)
+	if acceptedAt.Valid {
+		invitation.AcceptedAt = &acceptedAt.Time
+	}
+	return &invitation, nil
+}
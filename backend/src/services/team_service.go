@@ -0,0 +1,246 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"backend/models"
+)
+
+var (
+	// ErrNotTeamOwner チームの owner のみ許可された操作
+	ErrNotTeamOwner = newError(ErrForbidden, "team owner role required", "team.owner_required")
+
+	// ErrInvitationExpired 招待の有効期限切れ
+	ErrInvitationExpired = newError(ErrGone, "invitation has expired", "invitation.expired")
+
+	// ErrInvitationEmailMismatch 招待先と異なるメールアドレスのユーザーが承諾しようとした
+	ErrInvitationEmailMismatch = newError(ErrForbidden, "invitation was sent to a different email address", "invitation.email_mismatch")
+)
+
+// invitationTokenBytes 招待トークンの乱数バイト数
+const invitationTokenBytes = 32
+
+// TeamInvitationConfig 招待メールの設定
+type TeamInvitationConfig struct {
+	AcceptURL string        // 承諾ページのURL（?token= を付与してメールに記載）
+	TTL       time.Duration // 招待の有効期間
+}
+
+// TeamService チームサービス構造体
+//
+// 全ての操作はリクエストしたユーザーのメンバーシップで認可します。
+// メンバーでないチームは ErrTeamNotFound（存在を明かさない）、owner 限定の操作は ErrNotTeamOwner を返します。
+type TeamService struct {
+	teams  TeamRepository
+	users  UserRepository
+	mailer Mailer
+	config TeamInvitationConfig
+	now    func() time.Time
+}
+
+// NewTeamService チームサービスを新規作成
+func NewTeamService(teams TeamRepository, users UserRepository, mailer Mailer, config TeamInvitationConfig) *TeamService {
+	return &TeamService{
+		teams:  teams,
+		users:  users,
+		mailer: mailer,
+		config: config,
+		now:    time.Now,
+	}
+}
+
+// ListTeams ユーザーが参加しているチームを取得
+func (s *TeamService) ListTeams(user *models.User) ([]models.Team, error) {
+	return s.teams.ListForUser(user.ID)
+}
+
+// CreateTeam チームを作成し、作成者を owner にする
+func (s *TeamService) CreateTeam(user *models.User, request *models.TeamRequest) (*models.Team, error) {
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+	return s.teams.Create(&models.Team{Name: request.Name, Avatar: request.Avatar}, user.ID)
+}
+
+// GetTeam ユーザーが参加しているチームを取得
+func (s *TeamService) GetTeam(user *models.User, teamID int) (*models.Team, error) {
+	return s.teams.FindForUser(teamID, user.ID)
+}
+
+// UpdateTeam チーム名・アバターを更新（owner のみ）
+func (s *TeamService) UpdateTeam(user *models.User, teamID int, request *models.TeamRequest) (*models.Team, error) {
+	team, err := s.requireOwner(user, teamID)
+	if err != nil {
+		return nil, err
+	}
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.teams.Update(&models.Team{ID: teamID, Name: request.Name, Avatar: request.Avatar})
+	if err != nil {
+		return nil, err
+	}
+	updated.Role = team.Role
+	return updated, nil
+}
+
+// DeleteTeam チームを削除（owner のみ）
+func (s *TeamService) DeleteTeam(user *models.User, teamID int) error {
+	if _, err := s.requireOwner(user, teamID); err != nil {
+		return err
+	}
+	return s.teams.Delete(teamID)
+}
+
+// ListMembers チームのメンバーを取得（メンバーのみ）
+func (s *TeamService) ListMembers(user *models.User, teamID int) ([]models.TeamMember, error) {
+	if _, err := s.teams.FindForUser(teamID, user.ID); err != nil {
+		return nil, err
+	}
+	return s.teams.ListMembers(teamID)
+}
+
+// UpdateMemberRole メンバーのロールを変更（owner のみ）
+func (s *TeamService) UpdateMemberRole(user *models.User, teamID, memberID int, request *models.TeamMemberRoleRequest) (*models.TeamMember, error) {
+	if _, err := s.requireOwner(user, teamID); err != nil {
+		return nil, err
+	}
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+	return s.teams.UpdateMemberRole(teamID, memberID, request.Role)
+}
+
+// RemoveMember メンバーをチームから削除
+// owner は任意のメンバーを削除でき、それ以外のメンバーは自分自身の脱退のみ可能です
+func (s *TeamService) RemoveMember(user *models.User, teamID, memberID int) error {
+	team, err := s.teams.FindForUser(teamID, user.ID)
+	if err != nil {
+		return err
+	}
+	if memberID != user.ID && team.Role != models.TeamRoleOwner {
+		return ErrNotTeamOwner
+	}
+	return s.teams.RemoveMember(teamID, memberID)
+}
+
+// ListInvitations 未承諾の招待を取得（owner のみ）
+func (s *TeamService) ListInvitations(user *models.User, teamID int) ([]models.TeamInvitation, error) {
+	if _, err := s.requireOwner(user, teamID); err != nil {
+		return nil, err
+	}
+	return s.teams.ListInvitations(teamID)
+}
+
+// InviteMember 招待を作成し、承諾用のトークンをメールで送信（owner のみ）
+// トークンはメールでのみ通知し、レスポンスには含めません
+func (s *TeamService) InviteMember(user *models.User, teamID int, request *models.TeamInvitationRequest) (*models.TeamInvitation, error) {
+	team, err := s.requireOwner(user, teamID)
+	if err != nil {
+		return nil, err
+	}
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+
+	// 登録済みユーザーが既にメンバーであれば招待しない
+	invitee, err := s.users.FindByEmail(request.Email)
+	switch {
+	case err == nil:
+		if _, err := s.teams.FindForUser(teamID, invitee.ID); err == nil {
+			return nil, ErrAlreadyTeamMember
+		} else if !errors.Is(err, ErrTeamNotFound) {
+			return nil, err
+		}
+	case !errors.Is(err, ErrUserNotFound):
+		return nil, err
+	}
+
+	token, err := randomToken(invitationTokenBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	invitation, err := s.teams.CreateInvitation(&models.TeamInvitation{
+		TeamID:    teamID,
+		Email:     request.Email,
+		Role:      request.Role,
+		TokenHash: hashToken(token),
+		InvitedBy: user.ID,
+		ExpiresAt: s.now().Add(s.config.TTL),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.mailer.Send(s.invitationEmail(user, team, invitation, token)); err != nil {
+		// 届かない招待を残さない
+		if deleteErr := s.teams.DeleteInvitation(teamID, invitation.ID); deleteErr != nil {
+			return nil, errors.Join(err, deleteErr)
+		}
+		return nil, err
+	}
+	return invitation, nil
+}
+
+// RevokeInvitation 未承諾の招待を取り消す（owner のみ）
+func (s *TeamService) RevokeInvitation(user *models.User, teamID, invitationID int) error {
+	if _, err := s.requireOwner(user, teamID); err != nil {
+		return err
+	}
+	return s.teams.DeleteInvitation(teamID, invitationID)
+}
+
+// AcceptInvitation 招待を承諾してチームに参加
+// 招待先と同じメールアドレスのユーザーのみ承諾でき、トークンは1回のみ使用可能です
+func (s *TeamService) AcceptInvitation(user *models.User, request *models.AcceptInvitationRequest) (*models.Team, error) {
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+
+	invitation, err := s.teams.FindInvitationByTokenHash(hashToken(request.Token))
+	if err != nil {
+		return nil, err
+	}
+	if invitation.AcceptedAt != nil {
+		return nil, ErrInvitationNotFound
+	}
+	if !invitation.Pending(s.now()) {
+		return nil, ErrInvitationExpired
+	}
+	if invitation.Email != models.NormalizeEmail(user.Email) {
+		return nil, ErrInvitationEmailMismatch
+	}
+
+	if _, err := s.teams.AcceptInvitation(invitation.ID, user.ID); err != nil {
+		return nil, err
+	}
+	return s.teams.FindForUser(invitation.TeamID, user.ID)
+}
+
+// requireOwner ユーザーがチームの owner であることを確認
+func (s *TeamService) requireOwner(user *models.User, teamID int) (*models.Team, error) {
+	team, err := s.teams.FindForUser(teamID, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if team.Role != models.TeamRoleOwner {
+		return nil, ErrNotTeamOwner
+	}
+	return team, nil
+}
+
+// invitationEmail 招待メールを組み立てる
+func (s *TeamService) invitationEmail(inviter *models.User, team *models.Team, invitation *models.TeamInvitation, token string) *EmailMessage {
+	link := s.config.AcceptURL + "?token=" + url.QueryEscape(token)
+	return &EmailMessage{
+		To:      invitation.Email,
+		Subject: fmt.Sprintf("You have been invited to join %s", team.Name),
+		Body: fmt.Sprintf("%s has invited you to join %s as %s.\n\nAccept the invitation:\n%s\n\nThis link expires at %s.\n",
+			inviter.Name, team.Name, invitation.Role, link, invitation.ExpiresAt.UTC().Format(time.RFC1123)),
+	}
+}
@@ -0,0 +1,204 @@
+package services
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"backend/models"
+)
+
+// recordingMailer 送信したメールを記録するテスト用 Mailer
+type recordingMailer struct {
+	messages []*EmailMessage
+	err      error
+}
+
+func (m *recordingMailer) Send(message *EmailMessage) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.messages = append(m.messages, message)
+	return nil
+}
+
+// linkToken 最後に送信したメール（招待・確認）のリンクからトークンを取り出す
+func (m *recordingMailer) linkToken(t *testing.T) string {
+	t.Helper()
+	if len(m.messages) == 0 {
+		t.Fatal("メールが送信されていない")
+	}
+	body := m.messages[len(m.messages)-1].Body
+	start := strings.Index(body, "?token=")
+	if start < 0 {
+		t.Fatalf("メールにトークンがない: %s", body)
+	}
+	token, err := url.QueryUnescape(strings.Fields(body[start+len("?token="):])[0])
+	if err != nil {
+		t.Fatalf("トークンのデコード失敗: %v", err)
+	}
+	return token
+}
+
+// newTestTeamService テスト用のチームサービスとユーザーを作成
+func newTestTeamService(t *testing.T) (*TeamService, *recordingMailer, func(email string) *models.User) {
+	t.Helper()
+	users := NewMemoryUserRepository()
+	mailer := &recordingMailer{}
+	service := NewTeamService(NewMemoryTeamRepository(users), users, mailer, TeamInvitationConfig{
+		AcceptURL: "http://localhost:3000/invitations/accept",
+		TTL:       time.Hour,
+	})
+	createUser := func(email string) *models.User {
+		user, err := users.Create(email, strings.Split(email, "@")[0], "hash")
+		if err != nil {
+			t.Fatalf("ユーザー作成失敗: %v", err)
+		}
+		return user
+	}
+	return service, mailer, createUser
+}
+
+// TestTeamServiceAuthorization メンバーシップによる認可のテスト
+func TestTeamServiceAuthorization(t *testing.T) {
+	service, mailer, createUser := newTestTeamService(t)
+	owner, member, outsider := createUser("owner@example.com"), createUser("member@example.com"), createUser("outsider@example.com")
+
+	team, err := service.CreateTeam(owner, &models.TeamRequest{Name: " Nuxt "})
+	if err != nil {
+		t.Fatalf("CreateTeam() error = %v", err)
+	}
+	if team.Name != "Nuxt" || team.Role != models.TeamRoleOwner {
+		t.Errorf("作成結果が不正: %+v", team)
+	}
+	if _, err := service.InviteMember(owner, team.ID, &models.TeamInvitationRequest{Email: "member@example.com"}); err != nil {
+		t.Fatalf("InviteMember() error = %v", err)
+	}
+	if _, err := service.AcceptInvitation(member, &models.AcceptInvitationRequest{Token: mailer.linkToken(t)}); err != nil {
+		t.Fatalf("AcceptInvitation() error = %v", err)
+	}
+
+	// メンバーでないユーザーにはチームの存在を明かさない
+	if _, err := service.GetTeam(outsider, team.ID); !errors.Is(err, ErrTeamNotFound) {
+		t.Errorf("GetTeam(outsider) = %v, want ErrTeamNotFound", err)
+	}
+	if _, err := service.ListMembers(outsider, team.ID); !errors.Is(err, ErrTeamNotFound) {
+		t.Errorf("ListMembers(outsider) = %v, want ErrTeamNotFound", err)
+	}
+
+	// member は閲覧のみ
+	if members, err := service.ListMembers(member, team.ID); err != nil || len(members) != 2 {
+		t.Errorf("ListMembers(member) = %+v, %v", members, err)
+	}
+	if _, err := service.UpdateTeam(member, team.ID, &models.TeamRequest{Name: "x"}); !errors.Is(err, ErrNotTeamOwner) {
+		t.Errorf("UpdateTeam(member) = %v, want ErrNotTeamOwner", err)
+	}
+	if err := service.DeleteTeam(member, team.ID); !errors.Is(err, ErrNotTeamOwner) {
+		t.Errorf("DeleteTeam(member) = %v, want ErrNotTeamOwner", err)
+	}
+	if _, err := service.UpdateMemberRole(member, team.ID, member.ID, &models.TeamMemberRoleRequest{Role: models.TeamRoleOwner}); !errors.Is(err, ErrNotTeamOwner) {
+		t.Errorf("UpdateMemberRole(member) = %v, want ErrNotTeamOwner", err)
+	}
+	if _, err := service.ListInvitations(member, team.ID); !errors.Is(err, ErrNotTeamOwner) {
+		t.Errorf("ListInvitations(member) = %v, want ErrNotTeamOwner", err)
+	}
+	if err := service.RemoveMember(member, team.ID, owner.ID); !errors.Is(err, ErrNotTeamOwner) {
+		t.Errorf("RemoveMember(member, owner) = %v, want ErrNotTeamOwner", err)
+	}
+
+	// 最後の owner は脱退できない
+	if err := service.RemoveMember(owner, team.ID, owner.ID); !errors.Is(err, ErrLastTeamOwner) {
+		t.Errorf("RemoveMember(last owner) = %v, want ErrLastTeamOwner", err)
+	}
+
+	// member は自分自身の脱退が可能
+	if err := service.RemoveMember(member, team.ID, member.ID); err != nil {
+		t.Errorf("RemoveMember(self) error = %v", err)
+	}
+	if _, err := service.GetTeam(member, team.ID); !errors.Is(err, ErrTeamNotFound) {
+		t.Errorf("脱退後もチームを取得できる: %v", err)
+	}
+}
+
+// TestTeamServiceInvitations 招待の送信・承諾のテスト
+func TestTeamServiceInvitations(t *testing.T) {
+	service, mailer, createUser := newTestTeamService(t)
+	owner, invitee, other := createUser("owner@example.com"), createUser("invitee@example.com"), createUser("other@example.com")
+	team, _ := service.CreateTeam(owner, &models.TeamRequest{Name: "Nuxt"})
+
+	var validationErr *models.ValidationError
+	if _, err := service.InviteMember(owner, team.ID, &models.TeamInvitationRequest{Email: "invalid"}); !errors.As(err, &validationErr) {
+		t.Errorf("Expected validation error, got %v", err)
+	}
+	if _, err := service.InviteMember(owner, team.ID, &models.TeamInvitationRequest{Email: "owner@example.com"}); !errors.Is(err, ErrAlreadyTeamMember) {
+		t.Errorf("既存メンバーの招待 = %v, want ErrAlreadyTeamMember", err)
+	}
+
+	invitation, err := service.InviteMember(owner, team.ID, &models.TeamInvitationRequest{Email: " Invitee@Example.com "})
+	if err != nil {
+		t.Fatalf("InviteMember() error = %v", err)
+	}
+	if invitation.Email != "invitee@example.com" || invitation.Role != models.TeamRoleMember || invitation.InvitedBy != owner.ID {
+		t.Errorf("招待が不正: %+v", invitation)
+	}
+	sent := mailer.messages[len(mailer.messages)-1]
+	if sent.To != "invitee@example.com" || !strings.Contains(sent.Subject, "Nuxt") ||
+		!strings.Contains(sent.Body, "http://localhost:3000/invitations/accept?token=") {
+		t.Errorf("招待メールが不正: %+v", sent)
+	}
+	token := mailer.linkToken(t)
+	if invitation.TokenHash != hashToken(token) {
+		t.Error("トークンのハッシュが保存されていない")
+	}
+
+	// 別のユーザーは承諾できない
+	if _, err := service.AcceptInvitation(other, &models.AcceptInvitationRequest{Token: token}); !errors.Is(err, ErrInvitationEmailMismatch) {
+		t.Errorf("AcceptInvitation(other) = %v, want ErrInvitationEmailMismatch", err)
+	}
+	if _, err := service.AcceptInvitation(invitee, &models.AcceptInvitationRequest{Token: "unknown"}); !errors.Is(err, ErrInvitationNotFound) {
+		t.Errorf("AcceptInvitation(unknown) = %v, want ErrInvitationNotFound", err)
+	}
+
+	joined, err := service.AcceptInvitation(invitee, &models.AcceptInvitationRequest{Token: token})
+	if err != nil {
+		t.Fatalf("AcceptInvitation() error = %v", err)
+	}
+	if joined.ID != team.ID || joined.Role != models.TeamRoleMember {
+		t.Errorf("参加結果が不正: %+v", joined)
+	}
+
+	// トークンは1回のみ使用可能
+	if _, err := service.AcceptInvitation(invitee, &models.AcceptInvitationRequest{Token: token}); !errors.Is(err, ErrInvitationNotFound) {
+		t.Errorf("再使用 = %v, want ErrInvitationNotFound", err)
+	}
+}
+
+// TestTeamServiceInvitationExpiry 期限切れの招待と送信失敗のテスト
+func TestTeamServiceInvitationExpiry(t *testing.T) {
+	service, mailer, createUser := newTestTeamService(t)
+	owner, invitee := createUser("owner@example.com"), createUser("invitee@example.com")
+	team, _ := service.CreateTeam(owner, &models.TeamRequest{Name: "Nuxt"})
+
+	if _, err := service.InviteMember(owner, team.ID, &models.TeamInvitationRequest{Email: invitee.Email}); err != nil {
+		t.Fatalf("InviteMember() error = %v", err)
+	}
+	token := mailer.linkToken(t)
+
+	service.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+	if _, err := service.AcceptInvitation(invitee, &models.AcceptInvitationRequest{Token: token}); !errors.Is(err, ErrInvitationExpired) {
+		t.Errorf("AcceptInvitation(expired) = %v, want ErrInvitationExpired", err)
+	}
+	service.now = time.Now
+
+	// 送信に失敗した招待は残さない
+	mailer.err = errors.New("smtp unavailable")
+	if _, err := service.InviteMember(owner, team.ID, &models.TeamInvitationRequest{Email: "new@example.com"}); err == nil {
+		t.Error("Expected mailer error")
+	}
+	invitations, _ := service.ListInvitations(owner, team.ID)
+	if len(invitations) != 1 || invitations[0].Email != invitee.Email {
+		t.Errorf("送信失敗の招待が残っている: %+v", invitations)
+	}
+}
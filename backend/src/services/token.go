@@ -0,0 +1,214 @@
+package services
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"backend/models"
+	"backend/utils"
+)
+
+// ErrInvalidToken トークンが不正・期限切れ・種別違い
+var ErrInvalidToken = newError(ErrUnauthorized, "invalid token", "auth.invalid_token")
+
+// トークン種別（用途の異なるJWTの取り違えを防ぐ）
+const (
+	TokenTypeAccess     = "access"
+	TokenTypeMFAPending = "mfa_pending" // パスワード認証済みで二要素認証待ちのトークン
+)
+
+// refreshTokenBytes リフレッシュトークンの乱数バイト数
+const refreshTokenBytes = 32
+
+// TokenConfig トークン発行・検証の設定
+type TokenConfig struct {
+	Algorithm     string        // 署名アルゴリズム（HS256 | RS256）
+	Secret        string        // HS256 の共有秘密鍵
+	PrivateKeyPEM []byte        // RS256 の秘密鍵（PEM）。発行に使用
+	PublicKeyPEM  []byte        // RS256 の公開鍵（PEM）。省略時は秘密鍵から導出
+	Issuer        string        // iss クレーム
+	AccessTTL     time.Duration // アクセストークンの有効期間
+	RefreshTTL    time.Duration // リフレッシュトークン（セッション）の有効期間
+}
+
+// TokenClaims JWTのクレーム
+type TokenClaims struct {
+	jwt.RegisteredClaims
+	TokenType string `json:"token_type"`
+	Email     string `json:"email,omitempty"`
+	SessionID string `json:"sid,omitempty"`
+}
+
+// UserID sub クレームのユーザーID
+func (c *TokenClaims) UserID() (int, error) {
+	id, err := strconv.Atoi(c.Subject)
+	if err != nil {
+		return 0, ErrInvalidToken
+	}
+	return id, nil
+}
+
+// TokenManager JWTの発行と検証
+type TokenManager struct {
+	method     jwt.SigningMethod
+	signKey    interface{}
+	verifyKey  interface{}
+	issuer     string
+	accessTTL  time.Duration
+	refreshTTL time.Duration
+	now        func() time.Time
+}
+
+// NewTokenManager 設定からトークンマネージャーを新規作成
+func NewTokenManager(cfg TokenConfig) (*TokenManager, error) {
+	m := &TokenManager{
+		issuer:     cfg.Issuer,
+		accessTTL:  cfg.AccessTTL,
+		refreshTTL: cfg.RefreshTTL,
+		now:        time.Now,
+	}
+	if m.issuer == "" {
+		m.issuer = utils.DefaultJWTIssuer
+	}
+	if m.accessTTL <= 0 || m.refreshTTL <= 0 {
+		return nil, errors.New("token TTLs must be positive")
+	}
+
+	switch cfg.Algorithm {
+	case utils.JWTAlgorithmHS256:
+		if cfg.Secret == "" {
+			return nil, errors.New("JWT secret is required for HS256")
+		}
+		m.method = jwt.SigningMethodHS256
+		m.signKey = []byte(cfg.Secret)
+		m.verifyKey = []byte(cfg.Secret)
+	case utils.JWTAlgorithmRS256:
+		if len(cfg.PrivateKeyPEM) == 0 {
+			return nil, errors.New("JWT private key is required for RS256")
+		}
+		privateKey, err := jwt.ParseRSAPrivateKeyFromPEM(cfg.PrivateKeyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse JWT private key: %w", err)
+		}
+		publicKey := &privateKey.PublicKey
+		if len(cfg.PublicKeyPEM) > 0 {
+			if publicKey, err = jwt.ParseRSAPublicKeyFromPEM(cfg.PublicKeyPEM); err != nil {
+				return nil, fmt.Errorf("failed to parse JWT public key: %w", err)
+			}
+		}
+		if !privateKey.PublicKey.Equal(publicKey) {
+			return nil, errors.New("JWT public key does not match the private key")
+		}
+		m.method = jwt.SigningMethodRS256
+		m.signKey = privateKey
+		m.verifyKey = publicKey
+	default:
+		return nil, fmt.Errorf("unknown JWT algorithm: %q (expected %q or %q)",
+			cfg.Algorithm, utils.JWTAlgorithmHS256, utils.JWTAlgorithmRS256)
+	}
+
+	return m, nil
+}
+
+// AccessTTL アクセストークンの有効期間
+func (m *TokenManager) AccessTTL() time.Duration {
+	return m.accessTTL
+}
+
+// RefreshTTL リフレッシュトークンの有効期間
+func (m *TokenManager) RefreshTTL() time.Duration {
+	return m.refreshTTL
+}
+
+// IssueAccessToken セッションに紐づくアクセストークンを発行
+func (m *TokenManager) IssueAccessToken(user *models.User, sessionID string) (string, error) {
+	return m.sign(user, TokenTypeAccess, sessionID, m.accessTTL)
+}
+
+// IssueMFAToken パスワード認証済み・二要素認証待ちの短命なトークンを発行
+func (m *TokenManager) IssueMFAToken(user *models.User) (string, error) {
+	return m.sign(user, TokenTypeMFAPending, "", utils.MFATokenTTLSeconds*time.Second)
+}
+
+// NewRefreshToken 不透明なリフレッシュトークンと保存用ハッシュを生成
+// リフレッシュトークンはJWTではなく乱数で、サーバー側にはハッシュのみを保存します
+func (m *TokenManager) NewRefreshToken() (token, hash string, err error) {
+	token, err = randomToken(refreshTokenBytes)
+	if err != nil {
+		return "", "", err
+	}
+	return token, hashToken(token), nil
+}
+
+// Parse トークンの署名・有効期限・発行者・種別を検証してクレームを返す
+// 設定されたアルゴリズム以外（alg=none や HS/RS の取り違え）は拒否します
+func (m *TokenManager) Parse(tokenString, tokenType string) (*TokenClaims, error) {
+	claims := &TokenClaims{}
+	_, err := jwt.ParseWithClaims(tokenString, claims,
+		func(*jwt.Token) (interface{}, error) { return m.verifyKey, nil },
+		jwt.WithValidMethods([]string{m.method.Alg()}),
+		jwt.WithIssuer(m.issuer),
+		jwt.WithExpirationRequired(),
+		jwt.WithTimeFunc(m.now),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	if claims.TokenType != tokenType {
+		return nil, fmt.Errorf("%w: unexpected token type %q", ErrInvalidToken, claims.TokenType)
+	}
+
+	return claims, nil
+}
+
+// sign 指定種別のトークンに署名
+func (m *TokenManager) sign(user *models.User, tokenType, sessionID string, ttl time.Duration) (string, error) {
+	jti := make([]byte, 16)
+	if _, err := rand.Read(jti); err != nil {
+		return "", fmt.Errorf("failed to generate token id: %w", err)
+	}
+
+	now := m.now()
+	claims := TokenClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    m.issuer,
+			Subject:   strconv.Itoa(user.ID),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+			ID:        hex.EncodeToString(jti),
+		},
+		TokenType: tokenType,
+		Email:     user.Email,
+		SessionID: sessionID,
+	}
+
+	signed, err := jwt.NewWithClaims(m.method, claims).SignedString(m.signKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign token: %w", err)
+	}
+	return signed, nil
+}
+
+// randomToken 暗号論的乱数から URL セーフなトークンを生成
+func randomToken(size int) (string, error) {
+	b := make([]byte, size)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashToken 保存・照合用のトークンハッシュ（SHA-256 の16進表記）
+// トークン自体が高エントロピーのため、パスワードと異なりソルトやストレッチングは不要です
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,185 @@
+package services
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"backend/models"
+	"backend/utils"
+)
+
+// newTestTokenManager テスト用のHS256トークンマネージャー
+func newTestTokenManager(t *testing.T) *TokenManager {
+	t.Helper()
+	m, err := NewTokenManager(TokenConfig{
+		Algorithm:  utils.JWTAlgorithmHS256,
+		Secret:     "test-secret",
+		AccessTTL:  15 * time.Minute,
+		RefreshTTL: 24 * time.Hour,
+	})
+	if err != nil {
+		t.Fatalf("NewTokenManager() error = %v", err)
+	}
+	return m
+}
+
+// generateRSAKeyPEM テスト用RSA鍵ペアをPEMで生成
+func generateRSAKeyPEM(t *testing.T) (privatePEM, publicPEM []byte) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("rsa.GenerateKey() error = %v", err)
+	}
+	publicDER, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatalf("MarshalPKIXPublicKey() error = %v", err)
+	}
+	privatePEM = pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	publicPEM = pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER})
+	return privatePEM, publicPEM
+}
+
+// TestTokenManagerRoundTrip 発行したトークンの検証テスト
+func TestTokenManagerRoundTrip(t *testing.T) {
+	privatePEM, publicPEM := generateRSAKeyPEM(t)
+
+	configs := map[string]TokenConfig{
+		"HS256": {Algorithm: utils.JWTAlgorithmHS256, Secret: "test-secret"},
+		"RS256": {Algorithm: utils.JWTAlgorithmRS256, PrivateKeyPEM: privatePEM, PublicKeyPEM: publicPEM},
+	}
+
+	for name, cfg := range configs {
+		t.Run(name, func(t *testing.T) {
+			cfg.AccessTTL = 15 * time.Minute
+			cfg.RefreshTTL = 24 * time.Hour
+			m, err := NewTokenManager(cfg)
+			if err != nil {
+				t.Fatalf("NewTokenManager() error = %v", err)
+			}
+
+			accessToken, err := m.IssueAccessToken(&models.User{ID: 42, Email: "alice@example.com"}, "session-1")
+			if err != nil {
+				t.Fatalf("IssueAccessToken() error = %v", err)
+			}
+
+			claims, err := m.Parse(accessToken, TokenTypeAccess)
+			if err != nil {
+				t.Fatalf("Parse(access) error = %v", err)
+			}
+			if id, _ := claims.UserID(); id != 42 || claims.Email != "alice@example.com" || claims.ID == "" || claims.SessionID != "session-1" {
+				t.Errorf("クレームが不正: %+v", claims)
+			}
+
+			// 種別の取り違えは拒否
+			if _, err := m.Parse(accessToken, "mfa"); !errors.Is(err, ErrInvalidToken) {
+				t.Errorf("種別の異なるトークンとして受理された: %v", err)
+			}
+		})
+	}
+}
+
+// TestTokenManagerRejectsInvalidTokens 不正なトークンの拒否テスト
+func TestTokenManagerRejectsInvalidTokens(t *testing.T) {
+	m := newTestTokenManager(t)
+	user := &models.User{ID: 1, Email: "alice@example.com"}
+
+	t.Run("Expired", func(t *testing.T) {
+		m.now = func() time.Time { return time.Now().Add(-time.Hour) }
+		accessToken, _ := m.IssueAccessToken(user, "session-1")
+		m.now = time.Now
+
+		if _, err := m.Parse(accessToken, TokenTypeAccess); !errors.Is(err, ErrInvalidToken) {
+			t.Errorf("期限切れトークンが受理された: %v", err)
+		}
+	})
+
+	t.Run("WrongSecret", func(t *testing.T) {
+		other, _ := NewTokenManager(TokenConfig{Algorithm: utils.JWTAlgorithmHS256, Secret: "other", AccessTTL: time.Minute, RefreshTTL: time.Minute})
+		accessToken, _ := other.IssueAccessToken(user, "session-1")
+
+		if _, err := m.Parse(accessToken, TokenTypeAccess); !errors.Is(err, ErrInvalidToken) {
+			t.Errorf("別の鍵で署名されたトークンが受理された: %v", err)
+		}
+	})
+
+	t.Run("AlgNone", func(t *testing.T) {
+		token := jwt.NewWithClaims(jwt.SigningMethodNone, TokenClaims{
+			RegisteredClaims: jwt.RegisteredClaims{
+				Issuer:    utils.DefaultJWTIssuer,
+				Subject:   "1",
+				ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+			},
+			TokenType: TokenTypeAccess,
+		})
+		signed, err := token.SignedString(jwt.UnsafeAllowNoneSignatureType)
+		if err != nil {
+			t.Fatalf("SignedString() error = %v", err)
+		}
+
+		if _, err := m.Parse(signed, TokenTypeAccess); !errors.Is(err, ErrInvalidToken) {
+			t.Errorf("alg=none のトークンが受理された: %v", err)
+		}
+	})
+
+	t.Run("Garbage", func(t *testing.T) {
+		if _, err := m.Parse("not.a.token", TokenTypeAccess); !errors.Is(err, ErrInvalidToken) {
+			t.Errorf("不正な文字列が受理された: %v", err)
+		}
+	})
+}
+
+// TestNewRefreshToken 不透明なリフレッシュトークン生成のテスト
+func TestNewRefreshToken(t *testing.T) {
+	m := newTestTokenManager(t)
+
+	token, hash, err := m.NewRefreshToken()
+	if err != nil {
+		t.Fatalf("NewRefreshToken() error = %v", err)
+	}
+	if len(token) < 43 || hash != hashToken(token) || len(hash) != 64 {
+		t.Errorf("トークンまたはハッシュが不正: %q, %q", token, hash)
+	}
+	if _, err := m.Parse(token, TokenTypeAccess); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("リフレッシュトークンがアクセストークンとして受理された: %v", err)
+	}
+
+	other, _, _ := m.NewRefreshToken()
+	if other == token {
+		t.Error("同じリフレッシュトークンが生成された")
+	}
+}
+
+// TestNewTokenManagerErrors 設定エラーのテスト
+func TestNewTokenManagerErrors(t *testing.T) {
+	privatePEM, _ := generateRSAKeyPEM(t)
+	_, otherPublicPEM := generateRSAKeyPEM(t)
+
+	tests := map[string]struct {
+		cfg  TokenConfig
+		want string
+	}{
+		"UnknownAlgorithm": {TokenConfig{Algorithm: "ES256"}, "unknown JWT algorithm"},
+		"MissingSecret":    {TokenConfig{Algorithm: utils.JWTAlgorithmHS256}, "secret is required"},
+		"MissingKey":       {TokenConfig{Algorithm: utils.JWTAlgorithmRS256}, "private key is required"},
+		"InvalidKey":       {TokenConfig{Algorithm: utils.JWTAlgorithmRS256, PrivateKeyPEM: []byte("invalid")}, "failed to parse"},
+		"MismatchedKeys":   {TokenConfig{Algorithm: utils.JWTAlgorithmRS256, PrivateKeyPEM: privatePEM, PublicKeyPEM: otherPublicPEM}, "does not match"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			tt.cfg.AccessTTL = time.Minute
+			tt.cfg.RefreshTTL = time.Minute
+			if _, err := NewTokenManager(tt.cfg); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
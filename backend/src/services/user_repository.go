@@ -0,0 +1,50 @@
+package services
+
+import (
+	"time"
+
+	"backend/models"
+)
+
+var (
+	// ErrUserNotFound 指定されたユーザーが存在しない
+	ErrUserNotFound = newError(ErrNotFound, "user not found", "user.not_found")
+
+	// ErrEmailAlreadyExists メールアドレスが既に登録されている
+	ErrEmailAlreadyExists = newError(ErrConflict, "email already exists", "user.email_taken")
+
+	// ErrUsernameAlreadyExists ユーザー名が既に使用されている
+	ErrUsernameAlreadyExists = newError(ErrConflict, "username already exists", "user.username_taken")
+
+	// ErrEmailChangeNotFound 確認トークンに一致する有効期限内のメールアドレス変更がない
+	ErrEmailChangeNotFound = newError(ErrValidation, "email change not found or expired", "profile.email_change_invalid")
+)
+
+// UserRepository ユーザーの永続化インターフェース
+//
+// email は呼び出し側で models.NormalizeEmail により正規化された値を渡します。
+// 該当するユーザーが存在しない場合は ErrUserNotFound を返します。
+// 返すユーザーの PendingEmail には、有効期限内の確認待ちのメールアドレス変更のみを設定します。
+type UserRepository interface {
+	// Create ユーザーを保存し、採番されたIDとタイムスタンプを含めて返す
+	// email が登録済みの場合は ErrEmailAlreadyExists を返す
+	Create(email, name, passwordHash string) (*models.User, error)
+	// FindByID IDでユーザーを返す
+	FindByID(id int) (*models.User, error)
+	// FindByEmail メールアドレスでユーザーを返す
+	FindByEmail(email string) (*models.User, error)
+	// ListIDs 全ユーザーのIDを昇順で返す（通知の宛先解決に使用）
+	ListIDs() ([]int, error)
+	// UpdateProfile 名前・ユーザー名・アバター・自己紹介を更新して返す（空の username は未設定）
+	// username が他のユーザーに使用されている場合は ErrUsernameAlreadyExists を返す
+	UpdateProfile(id int, profile *models.User) (*models.User, error)
+	// RequestEmailChange 確認待ちのメールアドレス変更を保存する（未確認の変更は置き換える）
+	RequestEmailChange(id int, email, tokenHash string, expiresAt time.Time) error
+	// CancelEmailChange 確認待ちのメールアドレス変更を削除する（変更がない場合は何もしない）
+	CancelEmailChange(id int) error
+	// ConfirmEmailChange トークンに一致する有効期限内の変更を適用し、更新後のユーザーを返す
+	// 一致する変更がない場合は ErrEmailChangeNotFound、新しいメールアドレスが登録済みの場合は ErrEmailAlreadyExists を返す
+	ConfirmEmailChange(id int, tokenHash string, now time.Time) (*models.User, error)
+	// Delete ユーザーを削除する
+	Delete(id int) error
+}
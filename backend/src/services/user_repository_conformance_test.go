@@ -0,0 +1,259 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"backend/models"
+)
+
+// TestMemoryUserRepositoryConformance メモリユーザーリポジトリの適合テスト
+func TestMemoryUserRepositoryConformance(t *testing.T) {
+	runUserRepositoryConformance(t, func(t *testing.T) UserRepository {
+		return NewMemoryUserRepository()
+	})
+}
+
+// TestPostgresUserRepositoryConformance PostgreSQLユーザーリポジトリの適合テスト
+func TestPostgresUserRepositoryConformance(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	runUserRepositoryConformance(t, func(t *testing.T) UserRepository {
+		return NewPostgresUserRepository(db)
+	})
+}
+
+// runUserRepositoryConformance 全てのUserRepository実装が満たすべき振る舞い
+// 共有DBでも動作するよう、メールアドレスは一意な値を使用します
+func runUserRepositoryConformance(t *testing.T, newRepo func(t *testing.T) UserRepository) {
+	uniqueEmail := func(prefix string) string {
+		return fmt.Sprintf("%s%d@example.com", prefix, time.Now().UnixNano())
+	}
+
+	t.Run("CreateAndFind", func(t *testing.T) {
+		repo := newRepo(t)
+		email := uniqueEmail("create")
+
+		created, err := repo.Create(email, "Alice", "hash")
+		if err != nil {
+			t.Fatalf("Create失敗: %v", err)
+		}
+		if created.ID <= 0 || created.CreatedAt.IsZero() || created.PasswordHash != "hash" {
+			t.Errorf("作成結果が不正: %+v", created)
+		}
+
+		byID, err := repo.FindByID(created.ID)
+		if err != nil || byID.Email != email {
+			t.Errorf("FindByIDが不正: %+v, %v", byID, err)
+		}
+
+		byEmail, err := repo.FindByEmail(email)
+		if err != nil || byEmail.ID != created.ID || byEmail.PasswordHash != "hash" {
+			t.Errorf("FindByEmailが不正: %+v, %v", byEmail, err)
+		}
+	})
+
+	t.Run("DuplicateEmail", func(t *testing.T) {
+		repo := newRepo(t)
+		email := uniqueEmail("dup")
+
+		if _, err := repo.Create(email, "First", "hash"); err != nil {
+			t.Fatalf("Create失敗: %v", err)
+		}
+		if _, err := repo.Create(email, "Second", "hash"); !errors.Is(err, ErrEmailAlreadyExists) {
+			t.Errorf("ErrEmailAlreadyExistsが返らない: %v", err)
+		}
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		repo := newRepo(t)
+
+		if _, err := repo.FindByID(2147483000); !errors.Is(err, ErrUserNotFound) {
+			t.Errorf("FindByIDでErrUserNotFoundが返らない: %v", err)
+		}
+		if _, err := repo.FindByEmail(uniqueEmail("missing")); !errors.Is(err, ErrUserNotFound) {
+			t.Errorf("FindByEmailでErrUserNotFoundが返らない: %v", err)
+		}
+	})
+
+	t.Run("ListIDs", func(t *testing.T) {
+		repo := newRepo(t)
+		first, err := repo.Create(uniqueEmail("list"), "First", "hash")
+		if err != nil {
+			t.Fatalf("Create失敗: %v", err)
+		}
+		second, err := repo.Create(uniqueEmail("list"), "Second", "hash")
+		if err != nil {
+			t.Fatalf("Create失敗: %v", err)
+		}
+
+		ids, err := repo.ListIDs()
+		if err != nil {
+			t.Fatalf("ListIDs失敗: %v", err)
+		}
+		if !slices.IsSorted(ids) || !slices.Contains(ids, first.ID) || !slices.Contains(ids, second.ID) {
+			t.Errorf("作成したユーザーが昇順で含まれない: %v", ids)
+		}
+	})
+
+	t.Run("UpdateProfile", func(t *testing.T) {
+		repo := newRepo(t)
+		user, err := repo.Create(uniqueEmail("profile"), "Alice", "hash")
+		if err != nil {
+			t.Fatalf("Create失敗: %v", err)
+		}
+		username := fmt.Sprintf("alice%d", time.Now().UnixNano()%1000000000)
+
+		updated, err := repo.UpdateProfile(user.ID, &models.User{
+			Name:     "Alice Smith",
+			Username: username,
+			Avatar:   &models.Avatar{Src: "https://i.pravatar.cc/128?u=1"},
+			Bio:      "Hello",
+		})
+		if err != nil {
+			t.Fatalf("UpdateProfile失敗: %v", err)
+		}
+		if updated.Name != "Alice Smith" || updated.Username != username || updated.Avatar == nil || updated.Bio != "Hello" || updated.Email != user.Email {
+			t.Errorf("更新結果が不正: %+v", updated)
+		}
+
+		// 空の username・avatar は未設定に戻す
+		cleared, err := repo.UpdateProfile(user.ID, &models.User{Name: "Alice"})
+		if err != nil || cleared.Username != "" || cleared.Avatar != nil || cleared.Bio != "" {
+			t.Errorf("未設定に戻らない: %+v, %v", cleared, err)
+		}
+
+		other, err := repo.Create(uniqueEmail("profile"), "Bob", "hash")
+		if err != nil {
+			t.Fatalf("Create失敗: %v", err)
+		}
+		if _, err := repo.UpdateProfile(user.ID, &models.User{Name: "Alice", Username: username}); err != nil {
+			t.Fatalf("UpdateProfile失敗: %v", err)
+		}
+		if _, err := repo.UpdateProfile(other.ID, &models.User{Name: "Bob", Username: username}); !errors.Is(err, ErrUsernameAlreadyExists) {
+			t.Errorf("ErrUsernameAlreadyExistsが返らない: %v", err)
+		}
+		// 未設定のユーザー名は重複しない
+		if _, err := repo.UpdateProfile(other.ID, &models.User{Name: "Bob"}); err != nil {
+			t.Errorf("username 未設定のユーザーが複数存在できない: %v", err)
+		}
+		if _, err := repo.UpdateProfile(2147483000, &models.User{Name: "Nobody"}); !errors.Is(err, ErrUserNotFound) {
+			t.Errorf("ErrUserNotFoundが返らない: %v", err)
+		}
+	})
+
+	t.Run("EmailChange", func(t *testing.T) {
+		repo := newRepo(t)
+		user, err := repo.Create(uniqueEmail("change"), "Alice", "hash")
+		if err != nil {
+			t.Fatalf("Create失敗: %v", err)
+		}
+		newEmail := uniqueEmail("changed")
+		tokenHash := hashToken(newEmail)
+		expiresAt := time.Now().Add(time.Hour)
+
+		if err := repo.RequestEmailChange(user.ID, newEmail, tokenHash, expiresAt); err != nil {
+			t.Fatalf("RequestEmailChange失敗: %v", err)
+		}
+		pending, err := repo.FindByID(user.ID)
+		if err != nil || pending.PendingEmail != newEmail || pending.Email != user.Email {
+			t.Errorf("確認待ちのメールアドレスが不正: %+v, %v", pending, err)
+		}
+
+		if _, err := repo.ConfirmEmailChange(user.ID, hashToken("wrong"), time.Now()); !errors.Is(err, ErrEmailChangeNotFound) {
+			t.Errorf("不正なトークンでErrEmailChangeNotFoundが返らない: %v", err)
+		}
+		if _, err := repo.ConfirmEmailChange(user.ID, tokenHash, expiresAt.Add(time.Second)); !errors.Is(err, ErrEmailChangeNotFound) {
+			t.Errorf("期限切れでErrEmailChangeNotFoundが返らない: %v", err)
+		}
+
+		confirmed, err := repo.ConfirmEmailChange(user.ID, tokenHash, time.Now())
+		if err != nil || confirmed.Email != newEmail || confirmed.PendingEmail != "" {
+			t.Fatalf("ConfirmEmailChangeが不正: %+v, %v", confirmed, err)
+		}
+		if found, err := repo.FindByEmail(newEmail); err != nil || found.ID != user.ID {
+			t.Errorf("新しいメールアドレスで取得できない: %+v, %v", found, err)
+		}
+		// トークンは1回のみ使用可能
+		if _, err := repo.ConfirmEmailChange(user.ID, tokenHash, time.Now()); !errors.Is(err, ErrEmailChangeNotFound) {
+			t.Errorf("使用済みトークンでErrEmailChangeNotFoundが返らない: %v", err)
+		}
+
+		// 確認までに他のユーザーが登録したメールアドレスには変更できない
+		takenEmail := uniqueEmail("taken")
+		if err := repo.RequestEmailChange(user.ID, takenEmail, tokenHash, expiresAt); err != nil {
+			t.Fatalf("RequestEmailChange失敗: %v", err)
+		}
+		if _, err := repo.Create(takenEmail, "Bob", "hash"); err != nil {
+			t.Fatalf("Create失敗: %v", err)
+		}
+		if _, err := repo.ConfirmEmailChange(user.ID, tokenHash, time.Now()); !errors.Is(err, ErrEmailAlreadyExists) {
+			t.Errorf("ErrEmailAlreadyExistsが返らない: %v", err)
+		}
+
+		if err := repo.CancelEmailChange(user.ID); err != nil {
+			t.Fatalf("CancelEmailChange失敗: %v", err)
+		}
+		if cancelled, err := repo.FindByID(user.ID); err != nil || cancelled.PendingEmail != "" {
+			t.Errorf("取り消し後も確認待ちのメールアドレスが残る: %+v, %v", cancelled, err)
+		}
+		if err := repo.RequestEmailChange(2147483000, newEmail, tokenHash, expiresAt); !errors.Is(err, ErrUserNotFound) {
+			t.Errorf("ErrUserNotFoundが返らない: %v", err)
+		}
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		repo := newRepo(t)
+		email := uniqueEmail("delete")
+		user, err := repo.Create(email, "Alice", "hash")
+		if err != nil {
+			t.Fatalf("Create失敗: %v", err)
+		}
+
+		if err := repo.Delete(user.ID); err != nil {
+			t.Fatalf("Delete失敗: %v", err)
+		}
+		if _, err := repo.FindByID(user.ID); !errors.Is(err, ErrUserNotFound) {
+			t.Errorf("削除後にFindByIDでErrUserNotFoundが返らない: %v", err)
+		}
+		if err := repo.Delete(user.ID); !errors.Is(err, ErrUserNotFound) {
+			t.Errorf("二重削除でErrUserNotFoundが返らない: %v", err)
+		}
+		// 削除したユーザーのメールアドレスは再登録できる
+		if _, err := repo.Create(email, "Alice", "hash"); err != nil {
+			t.Errorf("削除後の再登録に失敗: %v", err)
+		}
+	})
+
+	t.Run("ConcurrentDuplicateCreate", func(t *testing.T) {
+		repo := newRepo(t)
+		email := uniqueEmail("race")
+
+		var wg sync.WaitGroup
+		var mu sync.Mutex
+		succeeded := 0
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if _, err := repo.Create(email, "Race", "hash"); err == nil {
+					mu.Lock()
+					succeeded++
+					mu.Unlock()
+				} else if !errors.Is(err, ErrEmailAlreadyExists) {
+					t.Errorf("予期しないエラー: %v", err)
+				}
+			}()
+		}
+		wg.Wait()
+
+		if succeeded != 1 {
+			t.Errorf("同じメールアドレスで%d件作成された", succeeded)
+		}
+	})
+}
@@ -0,0 +1,215 @@
+package services
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"backend/models"
+)
+
+// memoryEmailChange 確認待ちのメールアドレス変更
+type memoryEmailChange struct {
+	email     string
+	tokenHash string
+	expiresAt time.Time
+}
+
+// MemoryUserRepository メモリ上で動作するユーザーリポジトリ
+type MemoryUserRepository struct {
+	mu           sync.RWMutex
+	users        map[int]models.User
+	emailChanges map[int]memoryEmailChange
+	nextID       int
+}
+
+// NewMemoryUserRepository メモリユーザーリポジトリを新規作成
+func NewMemoryUserRepository() *MemoryUserRepository {
+	return &MemoryUserRepository{
+		users:        make(map[int]models.User),
+		emailChanges: make(map[int]memoryEmailChange),
+		nextID:       1,
+	}
+}
+
+// Create ユーザーを保存
+func (r *MemoryUserRepository) Create(email, name, passwordHash string) (*models.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.createLocked(email, name, passwordHash)
+}
+
+// createLocked ユーザーを保存（呼び出し側でロックを保持すること）
+func (r *MemoryUserRepository) createLocked(email, name, passwordHash string) (*models.User, error) {
+	if r.emailTaken(email) {
+		return nil, ErrEmailAlreadyExists
+	}
+
+	now := time.Now()
+	user := models.User{
+		ID:           r.nextID,
+		Email:        email,
+		Name:         name,
+		PasswordHash: passwordHash,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	r.users[user.ID] = user
+	r.nextID++
+
+	return r.copyUser(user), nil
+}
+
+// FindByID IDでユーザーを取得
+func (r *MemoryUserRepository) FindByID(id int) (*models.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	user, ok := r.users[id]
+	if !ok {
+		return nil, ErrUserNotFound
+	}
+	return r.copyUser(user), nil
+}
+
+// FindByEmail メールアドレスでユーザーを取得
+func (r *MemoryUserRepository) FindByEmail(email string) (*models.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, user := range r.users {
+		if user.Email == email {
+			return r.copyUser(user), nil
+		}
+	}
+	return nil, ErrUserNotFound
+}
+
+// ListIDs 全ユーザーのIDを昇順で取得
+func (r *MemoryUserRepository) ListIDs() ([]int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	ids := make([]int, 0, len(r.users))
+	for id := range r.users {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	return ids, nil
+}
+
+// UpdateProfile プロフィールを更新
+func (r *MemoryUserRepository) UpdateProfile(id int, profile *models.User) (*models.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user, ok := r.users[id]
+	if !ok {
+		return nil, ErrUserNotFound
+	}
+	if profile.Username != "" {
+		for otherID, other := range r.users {
+			if otherID != id && other.Username == profile.Username {
+				return nil, ErrUsernameAlreadyExists
+			}
+		}
+	}
+
+	user.Name = profile.Name
+	user.Username = profile.Username
+	user.Avatar = nil
+	if profile.Avatar != nil {
+		user.Avatar = &models.Avatar{Src: profile.Avatar.Src}
+	}
+	user.Bio = profile.Bio
+	user.UpdatedAt = time.Now()
+	r.users[id] = user
+
+	return r.copyUser(user), nil
+}
+
+// RequestEmailChange 確認待ちのメールアドレス変更を保存
+func (r *MemoryUserRepository) RequestEmailChange(id int, email, tokenHash string, expiresAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.users[id]; !ok {
+		return ErrUserNotFound
+	}
+	r.emailChanges[id] = memoryEmailChange{email: email, tokenHash: tokenHash, expiresAt: expiresAt}
+	return nil
+}
+
+// CancelEmailChange 確認待ちのメールアドレス変更を削除
+func (r *MemoryUserRepository) CancelEmailChange(id int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.users[id]; !ok {
+		return ErrUserNotFound
+	}
+	delete(r.emailChanges, id)
+	return nil
+}
+
+// ConfirmEmailChange 確認待ちのメールアドレス変更を適用
+func (r *MemoryUserRepository) ConfirmEmailChange(id int, tokenHash string, now time.Time) (*models.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user, ok := r.users[id]
+	change, pending := r.emailChanges[id]
+	if !ok || !pending || change.tokenHash != tokenHash || !change.expiresAt.After(now) {
+		return nil, ErrEmailChangeNotFound
+	}
+	if r.emailTaken(change.email) {
+		return nil, ErrEmailAlreadyExists
+	}
+
+	user.Email = change.email
+	user.UpdatedAt = time.Now()
+	r.users[id] = user
+	delete(r.emailChanges, id)
+
+	return r.copyUser(user), nil
+}
+
+// Delete ユーザーを削除
+func (r *MemoryUserRepository) Delete(id int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.users[id]; !ok {
+		return ErrUserNotFound
+	}
+	r.deleteLocked(id)
+	return nil
+}
+
+// deleteLocked ユーザーと確認待ちのメールアドレス変更を削除（呼び出し側で書き込みロックを保持）
+func (r *MemoryUserRepository) deleteLocked(id int) {
+	delete(r.users, id)
+	delete(r.emailChanges, id)
+}
+
+// emailTaken メールアドレスが登録済みか判定（ロック取得済みで呼び出す）
+func (r *MemoryUserRepository) emailTaken(email string) bool {
+	for _, user := range r.users {
+		if user.Email == email {
+			return true
+		}
+	}
+	return false
+}
+
+// copyUser 保存データを複製し、有効期限内の確認待ちメールアドレスを設定（ロック取得済みで呼び出す）
+func (r *MemoryUserRepository) copyUser(user models.User) *models.User {
+	if user.Avatar != nil {
+		user.Avatar = &models.Avatar{Src: user.Avatar.Src}
+	}
+	if change, ok := r.emailChanges[user.ID]; ok && change.expiresAt.After(time.Now()) {
+		user.PendingEmail = change.email
+	}
+	return &user
+}
@@ -0,0 +1,241 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+
+	"backend/models"
+)
+
+// uniqueViolation PostgreSQLの一意制約違反エラーコード
+const uniqueViolation = "23505"
+
+// userColumns ユーザー取得時の列（確認待ちのメールアドレスは有効期限内のみ）
+const userColumns = `id, email, name, username, avatar_src, bio,
+	CASE WHEN email_change_expires_at > CURRENT_TIMESTAMP THEN pending_email END,
+	password_hash, created_at, updated_at`
+
+// PostgresUserRepository PostgreSQLによるユーザーリポジトリ
+type PostgresUserRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresUserRepository PostgreSQLユーザーリポジトリを新規作成
+// db が nil の場合、全ての操作は ErrDatabaseUnavailable を返します
+func NewPostgresUserRepository(db *sql.DB) *PostgresUserRepository {
+	return &PostgresUserRepository{db: db}
+}
+
+// Create ユーザーを保存
+func (r *PostgresUserRepository) Create(email, name, passwordHash string) (*models.User, error) {
+	if r.db == nil {
+		return nil, ErrDatabaseUnavailable
+	}
+
+	query := `
+		INSERT INTO users (email, name, password_hash)
+		VALUES ($1, $2, $3)
+		RETURNING ` + userColumns
+
+	user, err := scanUser(r.db.QueryRow(query, email, name, passwordHash))
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, ErrEmailAlreadyExists
+		}
+		return nil, fmt.Errorf("failed to create user: %w", err)
+	}
+
+	return user, nil
+}
+
+// FindByID IDでユーザーを取得
+func (r *PostgresUserRepository) FindByID(id int) (*models.User, error) {
+	return r.findOne(`WHERE id = $1`, id)
+}
+
+// FindByEmail メールアドレスでユーザーを取得
+func (r *PostgresUserRepository) FindByEmail(email string) (*models.User, error) {
+	return r.findOne(`WHERE email = $1`, email)
+}
+
+// ListIDs 全ユーザーのIDを昇順で取得
+func (r *PostgresUserRepository) ListIDs() ([]int, error) {
+	if r.db == nil {
+		return nil, ErrDatabaseUnavailable
+	}
+
+	rows, err := r.db.Query(`SELECT id FROM users ORDER BY id`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query user ids: %w", err)
+	}
+	defer rows.Close()
+
+	ids := []int{}
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan user id: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating user ids: %w", err)
+	}
+	return ids, nil
+}
+
+// UpdateProfile プロフィールを更新（updated_at はトリガーで更新）
+func (r *PostgresUserRepository) UpdateProfile(id int, profile *models.User) (*models.User, error) {
+	if r.db == nil {
+		return nil, ErrDatabaseUnavailable
+	}
+
+	var avatarSrc string
+	if profile.Avatar != nil {
+		avatarSrc = profile.Avatar.Src
+	}
+	query := `
+		UPDATE users
+		SET name = $2, username = NULLIF($3, ''), avatar_src = NULLIF($4, ''), bio = $5
+		WHERE id = $1
+		RETURNING ` + userColumns
+
+	user, err := scanUser(r.db.QueryRow(query, id, profile.Name, profile.Username, avatarSrc, profile.Bio))
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, ErrUserNotFound
+		case isUniqueViolation(err):
+			return nil, ErrUsernameAlreadyExists
+		}
+		return nil, fmt.Errorf("failed to update profile: %w", err)
+	}
+	return user, nil
+}
+
+// RequestEmailChange 確認待ちのメールアドレス変更を保存
+func (r *PostgresUserRepository) RequestEmailChange(id int, email, tokenHash string, expiresAt time.Time) error {
+	if r.db == nil {
+		return ErrDatabaseUnavailable
+	}
+
+	result, err := r.db.Exec(`
+		UPDATE users
+		SET pending_email = $2, email_change_token_hash = $3, email_change_expires_at = $4
+		WHERE id = $1`, id, email, tokenHash, expiresAt)
+	if err != nil {
+		return fmt.Errorf("failed to request email change: %w", err)
+	}
+	return requireAffected(result, ErrUserNotFound)
+}
+
+// CancelEmailChange 確認待ちのメールアドレス変更を削除
+func (r *PostgresUserRepository) CancelEmailChange(id int) error {
+	if r.db == nil {
+		return ErrDatabaseUnavailable
+	}
+
+	result, err := r.db.Exec(`
+		UPDATE users
+		SET pending_email = NULL, email_change_token_hash = NULL, email_change_expires_at = NULL
+		WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to cancel email change: %w", err)
+	}
+	return requireAffected(result, ErrUserNotFound)
+}
+
+// ConfirmEmailChange 確認待ちのメールアドレス変更を適用
+// トークンと有効期限を条件に更新するため、同じトークンで確認できるのは1回のみです
+func (r *PostgresUserRepository) ConfirmEmailChange(id int, tokenHash string, now time.Time) (*models.User, error) {
+	if r.db == nil {
+		return nil, ErrDatabaseUnavailable
+	}
+
+	query := `
+		UPDATE users
+		SET email = pending_email, pending_email = NULL, email_change_token_hash = NULL, email_change_expires_at = NULL
+		WHERE id = $1 AND email_change_token_hash = $2 AND email_change_expires_at > $3
+		RETURNING ` + userColumns
+
+	user, err := scanUser(r.db.QueryRow(query, id, tokenHash, now))
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, ErrEmailChangeNotFound
+		case isUniqueViolation(err):
+			return nil, ErrEmailAlreadyExists
+		}
+		return nil, fmt.Errorf("failed to confirm email change: %w", err)
+	}
+	return user, nil
+}
+
+// Delete ユーザーを削除（セッション・ロールなどは外部キーの ON DELETE CASCADE で削除）
+func (r *PostgresUserRepository) Delete(id int) error {
+	if r.db == nil {
+		return ErrDatabaseUnavailable
+	}
+
+	result, err := r.db.Exec(`DELETE FROM users WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete user: %w", err)
+	}
+	return requireAffected(result, ErrUserNotFound)
+}
+
+// findOne 条件に一致するユーザーを1件取得
+func (r *PostgresUserRepository) findOne(where string, args ...interface{}) (*models.User, error) {
+	if r.db == nil {
+		return nil, ErrDatabaseUnavailable
+	}
+
+	query := `SELECT ` + userColumns + ` FROM users ` + where
+
+	user, err := scanUser(r.db.QueryRow(query, args...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	return user, nil
+}
+
+// scanUser クエリ結果をユーザーに変換
+func scanUser(row rowScanner) (*models.User, error) {
+	var user models.User
+	var username, avatarSrc, pendingEmail sql.NullString
+	err := row.Scan(
+		&user.ID,
+		&user.Email,
+		&user.Name,
+		&username,
+		&avatarSrc,
+		&user.Bio,
+		&pendingEmail,
+		&user.PasswordHash,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	user.Username = username.String
+	if avatarSrc.Valid {
+		user.Avatar = &models.Avatar{Src: avatarSrc.String}
+	}
+	user.PendingEmail = pendingEmail.String
+	return &user, nil
+}
+
+// isUniqueViolation 一意制約違反エラーか判定
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == uniqueViolation
+}
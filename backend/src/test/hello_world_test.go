@@ -14,7 +14,7 @@ type MockDatabase struct{}
 func TestGetHelloWorld(t *testing.T) {
 	service := services.NewHelloWorldService(nil)
 
-	response := service.GetHelloWorld()
+	response := service.GetHelloWorld(models.LocaleEN)
 
 	if response.Message != "Hello, World!" {
 		t.Errorf("Expected 'Hello, World!', got '%s'", response.Message)
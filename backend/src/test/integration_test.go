@@ -1,23 +1,153 @@
 package test
 
 import (
+	"bufio"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"backend/handler"
 	"backend/router"
+	"backend/services"
+	"backend/utils"
 	httpExpect "github.com/gavv/httpexpect/v2"
+	"github.com/gorilla/websocket"
+	"github.com/pquerna/otp/totp"
 )
 
+// newTestHandlers 指定のHello Worldハンドラーと、メモリリポジトリによる認証を組み合わせたハンドラー一式
+func newTestHandlers(t *testing.T, helloWorldHandler *handler.HelloWorldHandler) router.Handlers {
+	t.Helper()
+	return newTestHandlersWithEvents(t, helloWorldHandler, services.NewMemoryEventBus())
+}
+
+// newTestHandlersWithEvents 指定のイベントバスでイベントを発行・配信するハンドラー一式
+func newTestHandlersWithEvents(t *testing.T, helloWorldHandler *handler.HelloWorldHandler, eventBus services.EventBus) router.Handlers {
+	t.Helper()
+	handlers, _ := newTestHandlersWithNotifications(t, helloWorldHandler, eventBus)
+	return handlers
+}
+
+// newTestHandlersWithNotifications ハンドラー一式と、通知メールの送信を待つための通知サービス
+func newTestHandlersWithNotifications(t *testing.T, helloWorldHandler *handler.HelloWorldHandler, eventBus services.EventBus) (router.Handlers, *services.NotificationService) {
+	t.Helper()
+
+	hasher, err := services.NewPasswordHasher(utils.PasswordHashBcrypt)
+	if err != nil {
+		t.Fatalf("NewPasswordHasher失敗: %v", err)
+	}
+	tokens, err := services.NewTokenManager(services.TokenConfig{
+		Algorithm:  utils.JWTAlgorithmHS256,
+		Secret:     "test-secret",
+		AccessTTL:  15 * time.Minute,
+		RefreshTTL: time.Hour,
+	})
+	if err != nil {
+		t.Fatalf("NewTokenManager失敗: %v", err)
+	}
+	repos, err := services.NewRepositories(utils.StorageDriverMemory, nil)
+	if err != nil {
+		t.Fatalf("NewRepositories失敗: %v", err)
+	}
+	authService, err := services.NewAuthService(repos, hasher, tokens)
+	if err != nil {
+		t.Fatalf("NewAuthService失敗: %v", err)
+	}
+
+	eventBroker := services.NewEventBroker(100)
+	eventBus.Subscribe(eventBroker.Dispatch)
+	realtimeHub := services.NewRealtimeHub(eventBus)
+	eventBus.Subscribe(realtimeHub.Dispatch)
+	notificationService := services.NewNotificationService(repos.Notifications, repos.Preferences, repos.Users, eventBus, services.NotificationEmailConfig{
+		Mailer:  testMailbox,
+		BaseURL: "http://localhost:3000",
+	})
+	t.Cleanup(notificationService.Close)
+	teamService := services.NewTeamService(repos.Teams, repos.Users, testMailbox, services.TeamInvitationConfig{
+		AcceptURL: "http://localhost:3000/invitations/accept",
+		TTL:       time.Hour,
+	})
+	profileService := services.NewProfileService(repos.Users, repos.Accounts, hasher, testMailbox, services.EmailChangeConfig{
+		ConfirmURL: "http://localhost:3000/settings/confirm-email",
+		TTL:        time.Hour,
+	})
+
+	return router.Handlers{
+		Health:        handler.NewHealthHandler(nil),
+		HelloWorld:    helloWorldHandler,
+		Auth:          handler.NewAuthHandler(authService),
+		Profile:       handler.NewProfileHandler(profileService),
+		RBAC:          handler.NewRBACHandler(services.NewRBACService(repos.Roles, repos.Users)),
+		Customers:     handler.NewCustomerHandler(services.NewCustomerService(repos.Customers, notificationService)),
+		Mails:         handler.NewMailHandler(services.NewMailService(repos.Mails, notificationService)),
+		Teams:         handler.NewTeamHandler(teamService),
+		Notifications: handler.NewNotificationHandler(notificationService),
+		Sales:         handler.NewSaleHandler(services.NewSaleService(repos.Sales)),
+		Stats:         handler.NewStatsHandler(services.NewStatsService(repos.Sales)),
+		Events:        handler.NewEventsHandler(eventBroker, 100*time.Millisecond),
+		Realtime:      handler.NewWebSocketHandler(realtimeHub, time.Second),
+		Authenticator: authService,
+	}, notificationService
+}
+
+// testMailbox テスト用ハンドラーが送信したメールの記録先
+var testMailbox = &recordingMailer{}
+
+// recordingMailer 送信したメールを記録するテスト用 Mailer
+type recordingMailer struct {
+	mu       sync.Mutex
+	messages []services.EmailMessage
+}
+
+func (m *recordingMailer) Send(message *services.EmailMessage) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.messages = append(m.messages, *message)
+	return nil
+}
+
+// linkToken 指定したアドレスへ最後に送信したメール（招待・確認）のリンクに含まれるトークンを返す
+func (m *recordingMailer) linkToken(t *testing.T, to string) string {
+	t.Helper()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i := len(m.messages) - 1; i >= 0; i-- {
+		if m.messages[i].To != to {
+			continue
+		}
+		if _, rest, found := strings.Cut(m.messages[i].Body, "?token="); found {
+			token, err := url.QueryUnescape(strings.Fields(rest)[0])
+			if err != nil {
+				t.Fatalf("トークンのデコード失敗: %v", err)
+			}
+			return token
+		}
+	}
+	t.Fatalf("%s へのトークン付きのメールが送信されていない", to)
+	return ""
+}
+
+// registerTestUser ユーザーを登録してアクセストークンを返す
+func registerTestUser(e *httpExpect.Expect, email string) string {
+	return e.POST("/api/auth/register").
+		WithJSON(map[string]string{"email": email, "password": "password123", "name": "Test User"}).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object().Value("data").Object().Value("access_token").String().Raw()
+}
+
 // TestHealthCheckIntegration ヘルスチェックエンドポイントの統合テスト
 func TestHealthCheckIntegration(t *testing.T) {
 	// ハンドラー初期化
-	healthHandler := handler.NewHealthHandler(nil)
-	helloWorldHandler := handler.NewHelloWorldHandler(nil)
+	handlers := newTestHandlers(t, handler.NewHelloWorldHandler(nil))
 
 	// ルーターを設定
-	r := router.NewRouter(healthHandler, helloWorldHandler)
+	r := router.NewRouter(handlers)
 
 	// テストサーバーを作成
 	server := httptest.NewServer(r)
@@ -38,11 +168,10 @@ func TestHealthCheckIntegration(t *testing.T) {
 // TestHelloWorldIntegration Hello Worldエンドポイントの統合テスト
 func TestHelloWorldIntegration(t *testing.T) {
 	// ハンドラー初期化
-	healthHandler := handler.NewHealthHandler(nil)
-	helloWorldHandler := handler.NewHelloWorldHandler(nil)
+	handlers := newTestHandlers(t, handler.NewHelloWorldHandler(nil))
 
 	// ルーターを設定
-	r := router.NewRouter(healthHandler, helloWorldHandler)
+	r := router.NewRouter(handlers)
 
 	// テストサーバーを作成
 	server := httptest.NewServer(r)
@@ -60,16 +189,25 @@ func TestHelloWorldIntegration(t *testing.T) {
 		ValueEqual("message", "Hello World message retrieved successfully").
 		ContainsKey("timestamp").
 		ContainsKey("data")
+
+	// Accept-Language の言語で挨拶とメッセージを返す
+	ja := e.GET("/api/hello-world").
+		WithHeader("Accept-Language", "ja-JP,ja;q=0.9,en;q=0.8").
+		Expect().
+		Status(http.StatusOK)
+	ja.Header("Content-Language").Equal("ja")
+	ja.JSON().Object().
+		ValueEqual("message", "Hello Worldメッセージを取得しました").
+		Value("data").Object().ValueEqual("message", "こんにちは、世界！")
 }
 
 // TestHelloWorldMessagesIntegration Hello Worldメッセージエンドポイントの統合テスト
 func TestHelloWorldMessagesIntegration(t *testing.T) {
 	// ハンドラー初期化
-	healthHandler := handler.NewHealthHandler(nil)
-	helloWorldHandler := handler.NewHelloWorldHandler(nil)
+	handlers := newTestHandlers(t, handler.NewHelloWorldHandler(nil))
 
 	// ルーターを設定
-	r := router.NewRouter(healthHandler, helloWorldHandler)
+	r := router.NewRouter(handlers)
 
 	// テストサーバーを作成
 	server := httptest.NewServer(r)
@@ -78,20 +216,19 @@ func TestHelloWorldMessagesIntegration(t *testing.T) {
 	// HTTPExpectを使用してテスト
 	e := httpExpect.New(t, server.URL)
 
-	// GET /api/hello-world/messages をテスト（データベースエラーを期待）
+	// GET /api/hello-world/messages をテスト（データベースが利用できないエラーを期待）
 	e.GET("/api/hello-world/messages").
 		Expect().
-		Status(http.StatusInternalServerError)
+		Status(http.StatusServiceUnavailable)
 }
 
 // TestNotFoundIntegration 404エンドポイントの統合テスト
 func TestNotFoundIntegration(t *testing.T) {
 	// ハンドラー初期化
-	healthHandler := handler.NewHealthHandler(nil)
-	helloWorldHandler := handler.NewHelloWorldHandler(nil)
+	handlers := newTestHandlers(t, handler.NewHelloWorldHandler(nil))
 
 	// ルーターを設定
-	r := router.NewRouter(healthHandler, helloWorldHandler)
+	r := router.NewRouter(handlers)
 
 	// テストサーバーを作成
 	server := httptest.NewServer(r)
@@ -109,11 +246,10 @@ func TestNotFoundIntegration(t *testing.T) {
 // TestMethodNotAllowedIntegration 405エンドポイントの統合テスト
 func TestMethodNotAllowedIntegration(t *testing.T) {
 	// ハンドラー初期化
-	healthHandler := handler.NewHealthHandler(nil)
-	helloWorldHandler := handler.NewHelloWorldHandler(nil)
+	handlers := newTestHandlers(t, handler.NewHelloWorldHandler(nil))
 
 	// ルーターを設定
-	r := router.NewRouter(healthHandler, helloWorldHandler)
+	r := router.NewRouter(handlers)
 
 	// テストサーバーを作成
 	server := httptest.NewServer(r)
@@ -131,11 +267,10 @@ func TestMethodNotAllowedIntegration(t *testing.T) {
 // TestRootEndpointIntegration ルートエンドポイントの統合テスト
 func TestRootEndpointIntegration(t *testing.T) {
 	// ハンドラー初期化
-	healthHandler := handler.NewHealthHandler(nil)
-	helloWorldHandler := handler.NewHelloWorldHandler(nil)
+	handlers := newTestHandlers(t, handler.NewHelloWorldHandler(nil))
 
 	// ルーターを設定
-	r := router.NewRouter(healthHandler, helloWorldHandler)
+	r := router.NewRouter(handlers)
 
 	// テストサーバーを作成
 	server := httptest.NewServer(r)
@@ -152,4 +287,1379 @@ func TestRootEndpointIntegration(t *testing.T) {
 		ValueEqual("status", "success").
 		ValueEqual("message", "Go + Chi Starter Project API").
 		ContainsKey("timestamp")
-} 
\ No newline at end of file
+} 
+// TestHelloWorldMemoryStorageIntegration メモリストレージでのCRUD統合テスト（PostgreSQL不要）
+func TestHelloWorldMemoryStorageIntegration(t *testing.T) {
+	// メモリリポジトリでハンドラー初期化
+	repos, err := services.NewRepositories(utils.StorageDriverMemory, nil)
+	if err != nil {
+		t.Fatalf("NewRepositories失敗: %v", err)
+	}
+	handlers := newTestHandlers(t, handler.NewHelloWorldHandlerWithService(services.NewHelloWorldServiceWithRepository(repos.HelloWorld)))
+
+	// ルーターを設定
+	r := router.NewRouter(handlers)
+
+	// テストサーバーを作成
+	server := httptest.NewServer(r)
+	defer server.Close()
+
+	e := httpExpect.New(t, server.URL)
+	auth := "Bearer " + registerTestUser(e, "memory@example.com")
+
+	// 作成
+	id := e.POST("/api/hello-world").
+		WithHeader("Authorization", auth).
+		WithJSON(map[string]string{"name": "Memory"}).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object().Value("data").Object().Value("id").Number().Raw()
+
+	path := fmt.Sprintf("/api/hello-world/messages/%d", int(id))
+
+	// 一覧（サンプルデータ3件 + 作成分）
+	e.GET("/api/hello-world/messages").
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value("pagination").Object().ValueEqual("total", 4)
+
+	// 部分更新
+	e.PATCH(path).
+		WithHeader("Authorization", auth).
+		WithHeader("Content-Type", "application/merge-patch+json").
+		WithBytes([]byte(`{"message":"Patched"}`)).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value("data").Object().
+		ValueEqual("name", "Memory").
+		ValueEqual("message", "Patched")
+
+	// 削除
+	e.DELETE(path).
+		WithHeader("Authorization", auth).
+		Expect().
+		Status(http.StatusOK)
+
+	e.GET(path).
+		Expect().
+		Status(http.StatusNotFound)
+}
+
+// TestAuthIntegration 登録・ログイン・トークン再発行と認証必須ルートの統合テスト
+func TestAuthIntegration(t *testing.T) {
+	repos, err := services.NewRepositories(utils.StorageDriverMemory, nil)
+	if err != nil {
+		t.Fatalf("NewRepositories失敗: %v", err)
+	}
+	handlers := newTestHandlers(t, handler.NewHelloWorldHandlerWithService(services.NewHelloWorldServiceWithRepository(repos.HelloWorld)))
+
+	server := httptest.NewServer(router.NewRouter(handlers))
+	defer server.Close()
+
+	e := httpExpect.New(t, server.URL)
+
+	// 登録
+	registerTestUser(e, "auth@example.com")
+
+	// 重複登録
+	e.POST("/api/auth/register").
+		WithJSON(map[string]string{"email": "AUTH@example.com", "password": "password123", "name": "Dup"}).
+		Expect().
+		Status(http.StatusConflict).
+		JSON().Object().ValueEqual("error", "conflict")
+
+	// パスワード誤り
+	e.POST("/api/auth/login").
+		WithJSON(map[string]string{"email": "auth@example.com", "password": "wrong-password"}).
+		Expect().
+		Status(http.StatusUnauthorized).
+		JSON().Object().ValueEqual("error", "unauthorized")
+
+	// ログイン
+	login := e.POST("/api/auth/login").
+		WithJSON(map[string]string{"email": "auth@example.com", "password": "password123"}).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value("data").Object()
+	login.ValueEqual("token_type", "Bearer")
+	login.Value("user").Object().NotContainsKey("password_hash")
+	refreshToken := login.Value("refresh_token").String().Raw()
+
+	// トークン再発行
+	accessToken := e.POST("/api/auth/refresh").
+		WithJSON(map[string]string{"refresh_token": refreshToken}).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value("data").Object().Value("access_token").String().Raw()
+
+	// 認証なし・不正トークンでは作成できない
+	e.POST("/api/hello-world").
+		WithJSON(map[string]string{"name": "NoAuth"}).
+		Expect().
+		Status(http.StatusUnauthorized).
+		Header("WWW-Authenticate").NotEmpty()
+
+	e.POST("/api/hello-world").
+		WithHeader("Authorization", "Bearer "+refreshToken).
+		WithJSON(map[string]string{"name": "RefreshToken"}).
+		Expect().
+		Status(http.StatusUnauthorized)
+
+	// 再発行したアクセストークンで作成できる
+	e.POST("/api/hello-world").
+		WithHeader("Authorization", "Bearer "+accessToken).
+		WithJSON(map[string]string{"name": "Authed"}).
+		Expect().
+		Status(http.StatusCreated)
+
+	// 認証 API のボディは 16KB まで
+	e.POST("/api/auth/login").
+		WithJSON(map[string]string{"email": "auth@example.com", "password": strings.Repeat("a", 16<<10)}).
+		Expect().
+		Status(http.StatusRequestEntityTooLarge).
+		JSON().Object().
+		ValueEqual("error", "payload_too_large").
+		ValueEqual("message", "Request body must be at most 16384 bytes")
+
+	// JSON 以外の Content-Type・JSON の値の後のデータは受け付けない
+	e.POST("/api/hello-world").
+		WithHeader("Authorization", "Bearer "+accessToken).
+		WithHeader("Content-Type", "text/plain").
+		WithText(`{"name":"Text"}`).
+		Expect().
+		Status(http.StatusUnsupportedMediaType).
+		JSON().Object().ValueEqual("error", "unsupported_media_type")
+	e.POST("/api/hello-world").
+		WithHeader("Authorization", "Bearer "+accessToken).
+		WithHeader("Content-Type", "application/json").
+		WithText(`{"name":"Trailing"} {}`).
+		Expect().
+		Status(http.StatusBadRequest).
+		JSON().Object().Value("errors").Array().Element(0).Object().ValueEqual("field", "body")
+
+	// 参照系は認証不要
+	e.GET("/api/hello-world/messages").
+		Expect().
+		Status(http.StatusOK)
+}
+
+// TestProfileIntegration プロフィール・アカウント設定APIの統合テスト
+func TestProfileIntegration(t *testing.T) {
+	handlers := newTestHandlers(t, handler.NewHelloWorldHandler(nil))
+
+	server := httptest.NewServer(router.NewRouter(handlers))
+	defer server.Close()
+
+	e := httpExpect.New(t, server.URL)
+	registerTestUser(e, "profile-owner@example.com")
+	auth := "Bearer " + registerTestUser(e, "profile@example.com")
+
+	me := e.GET("/api/me").
+		WithHeader("Authorization", auth).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value("data").Object()
+	me.Value("email").String().Equal("profile@example.com")
+	me.Value("roles").Array().ContainsOnly("member")
+	me.NotContainsKey("password_hash")
+
+	// 全てのフィールドのエラーをコード付きで返す
+	invalid := e.PATCH("/api/me").
+		WithHeader("Authorization", auth).
+		WithHeader("Content-Type", "application/merge-patch+json").
+		WithBytes([]byte(`{"name":"","email":"invalid","username":"ab"}`)).
+		Expect().
+		Status(http.StatusBadRequest).
+		JSON().Object()
+	invalid.Value("error").String().Equal("validation_error")
+	invalid.Value("errors").Array().Length().Equal(3)
+	invalid.Value("errors").Array().Element(2).Object().Value("code").String().Equal("too_short")
+
+	updated := e.PATCH("/api/me").
+		WithHeader("Authorization", auth).
+		WithHeader("Content-Type", "application/merge-patch+json").
+		WithBytes([]byte(`{"name":"Benjamin Canac","username":"benjamincanac","bio":"Hello","email":"profile-new@example.com"}`)).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value("data").Object()
+	updated.Value("username").String().Equal("benjamincanac")
+	updated.Value("email").String().Equal("profile@example.com")
+	updated.Value("pending_email").String().Equal("profile-new@example.com")
+
+	// 確認トークンで変更を確定すると、新しいアドレスでログインできる
+	e.POST("/api/me/email/confirm").
+		WithHeader("Authorization", auth).
+		WithJSON(map[string]string{"token": testMailbox.linkToken(t, "profile-new@example.com")}).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value("data").Object().
+		NotContainsKey("pending_email").
+		Value("email").String().Equal("profile-new@example.com")
+	e.POST("/api/auth/login").
+		WithJSON(map[string]string{"email": "profile-new@example.com", "password": "password123"}).
+		Expect().
+		Status(http.StatusOK)
+
+	// APIキーではアカウントを削除できない
+	key := e.POST("/api/auth/api-keys").
+		WithHeader("Authorization", auth).
+		WithJSON(map[string]interface{}{"name": "CI", "scopes": []string{"messages:create"}}).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object().Value("data").Object().Value("key").String().Raw()
+	e.GET("/api/me").
+		WithHeader("X-API-Key", key).
+		Expect().
+		Status(http.StatusOK)
+	e.DELETE("/api/me").
+		WithHeader("X-API-Key", key).
+		WithJSON(map[string]string{"password": "password123"}).
+		Expect().
+		Status(http.StatusForbidden)
+
+	e.DELETE("/api/me").
+		WithHeader("Authorization", auth).
+		WithJSON(map[string]string{"password": "wrong-password"}).
+		Expect().
+		Status(http.StatusBadRequest).
+		JSON().Object().Value("errors").Array().Element(0).Object().Value("field").String().Equal("password")
+	e.DELETE("/api/me").
+		WithHeader("Authorization", auth).
+		WithJSON(map[string]string{"password": "password123"}).
+		Expect().
+		Status(http.StatusOK)
+
+	// 削除後はトークンもAPIキーも使えない
+	e.GET("/api/me").
+		WithHeader("Authorization", auth).
+		Expect().
+		Status(http.StatusUnauthorized)
+	e.GET("/api/me").
+		WithHeader("X-API-Key", key).
+		Expect().
+		Status(http.StatusUnauthorized)
+}
+
+// TestRBACIntegration 権限による拒否とロール付与の統合テスト
+func TestRBACIntegration(t *testing.T) {
+	repos, err := services.NewRepositories(utils.StorageDriverMemory, nil)
+	if err != nil {
+		t.Fatalf("NewRepositories失敗: %v", err)
+	}
+	handlers := newTestHandlers(t, handler.NewHelloWorldHandlerWithService(services.NewHelloWorldServiceWithRepository(repos.HelloWorld)))
+
+	server := httptest.NewServer(router.NewRouter(handlers))
+	defer server.Close()
+
+	e := httpExpect.New(t, server.URL)
+
+	// 最初の登録者は owner、2人目は member
+	owner := "Bearer " + registerTestUser(e, "owner@example.com")
+	member := "Bearer " + registerTestUser(e, "member@example.com")
+
+	// member は削除できない
+	e.DELETE("/api/hello-world/messages/1").
+		WithHeader("Authorization", member).
+		Expect().
+		Status(http.StatusForbidden).
+		JSON().Object().ValueEqual("error", "forbidden")
+
+	// member はロールを管理できない
+	e.PUT("/api/users/2/roles/owner").
+		WithHeader("Authorization", member).
+		Expect().
+		Status(http.StatusForbidden)
+
+	// 最後の owner は剥奪できない
+	e.DELETE("/api/users/1/roles/owner").
+		WithHeader("Authorization", owner).
+		Expect().
+		Status(http.StatusConflict)
+
+	// owner が member に owner を付与すると、即座に削除できるようになる
+	e.PUT("/api/users/2/roles/owner").
+		WithHeader("Authorization", owner).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value("data").Object().Value("roles").Array().ContainsOnly("owner", "member")
+
+	e.DELETE("/api/hello-world/messages/1").
+		WithHeader("Authorization", member).
+		Expect().
+		Status(http.StatusOK)
+}
+
+// TestSessionIntegration リフレッシュトークンのローテーション・再利用検知とセッション管理の統合テスト
+func TestSessionIntegration(t *testing.T) {
+	handlers := newTestHandlers(t, handler.NewHelloWorldHandler(nil))
+
+	server := httptest.NewServer(router.NewRouter(handlers))
+	defer server.Close()
+
+	e := httpExpect.New(t, server.URL)
+
+	login := func(userAgent, forwardedFor string) *httpExpect.Object {
+		return e.POST("/api/auth/login").
+			WithHeader("User-Agent", userAgent).
+			WithHeader("X-Forwarded-For", forwardedFor).
+			WithJSON(map[string]string{"email": "session@example.com", "password": "password123"}).
+			Expect().
+			Status(http.StatusOK).
+			JSON().Object().Value("data").Object()
+	}
+
+	registerTestUser(e, "session@example.com")
+	laptop := login("Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36", "203.0.113.10")
+	phone := login("Mozilla/5.0 (iPhone; CPU iPhone OS 17_0 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.0 Mobile/15E148 Safari/604.1", "203.0.113.20")
+	laptopAuth := "Bearer " + laptop.Value("access_token").String().Raw()
+
+	// ローテーション
+	oldRefresh := phone.Value("refresh_token").String().Raw()
+	e.POST("/api/auth/refresh").
+		WithJSON(map[string]string{"refresh_token": oldRefresh}).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value("data").Object().Value("refresh_token").String().NotEqual(oldRefresh)
+
+	// 再利用でセッションが失効
+	e.POST("/api/auth/refresh").
+		WithJSON(map[string]string{"refresh_token": oldRefresh}).
+		Expect().
+		Status(http.StatusUnauthorized)
+	e.GET("/api/auth/sessions").
+		WithHeader("Authorization", "Bearer "+phone.Value("access_token").String().Raw()).
+		Expect().
+		Status(http.StatusUnauthorized)
+
+	// 一覧（登録時のセッション + laptop）
+	sessions := e.GET("/api/auth/sessions").
+		WithHeader("Authorization", laptopAuth).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value("data").Array()
+	sessions.Length().Equal(2)
+	current := sessions.Element(0).Object()
+	current.ValueEqual("current", true).
+		ValueEqual("device", "Chrome on macOS").
+		ValueEqual("ip", "203.0.113.10")
+	current.ContainsKey("last_seen_at")
+
+	// 他のセッションを一括失効
+	e.DELETE("/api/auth/sessions").
+		WithHeader("Authorization", laptopAuth).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value("data").Object().ValueEqual("revoked", 1)
+
+	// 現在のセッションを失効すると以降のリクエストは401
+	e.DELETE("/api/auth/sessions/"+current.Value("id").String().Raw()).
+		WithHeader("Authorization", laptopAuth).
+		Expect().
+		Status(http.StatusOK)
+	e.GET("/api/auth/sessions").
+		WithHeader("Authorization", laptopAuth).
+		Expect().
+		Status(http.StatusUnauthorized)
+}
+
+// TestMFAIntegration TOTP登録・二段階ログイン・リカバリーコード・無効化の統合テスト
+func TestMFAIntegration(t *testing.T) {
+	handlers := newTestHandlers(t, handler.NewHelloWorldHandler(nil))
+
+	server := httptest.NewServer(router.NewRouter(handlers))
+	defer server.Close()
+
+	e := httpExpect.New(t, server.URL)
+	auth := "Bearer " + registerTestUser(e, "mfa@example.com")
+	credentials := map[string]string{"email": "mfa@example.com", "password": "password123"}
+
+	// 登録開始（QRコードはPNGのdata URI）
+	enrollment := e.POST("/api/auth/mfa/totp/enroll").
+		WithHeader("Authorization", auth).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value("data").Object()
+	enrollment.Value("otpauth_uri").String().HasPrefix("otpauth://totp/")
+	enrollment.Value("qr_code").String().HasPrefix("data:image/png;base64,")
+	secret := enrollment.Value("secret").String().Raw()
+
+	code, err := totp.GenerateCode(secret, time.Now())
+	if err != nil {
+		t.Fatalf("GenerateCode失敗: %v", err)
+	}
+	recoveryCodes := e.POST("/api/auth/mfa/totp/verify").
+		WithHeader("Authorization", auth).
+		WithJSON(map[string]string{"code": code}).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value("data").Object().Value("recovery_codes").Array()
+	recoveryCodes.Length().Equal(utils.RecoveryCodeCount)
+
+	// パスワードだけではトークンが発行されない
+	challenge := e.POST("/api/auth/login").
+		WithJSON(credentials).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value("data").Object()
+	challenge.ValueEqual("mfa_required", true).NotContainsKey("access_token")
+	mfaToken := challenge.Value("mfa_token").String().Raw()
+
+	// mfa_token は保護されたAPIに使えない
+	e.GET("/api/auth/mfa").
+		WithHeader("Authorization", "Bearer "+mfaToken).
+		Expect().
+		Status(http.StatusUnauthorized)
+
+	// リカバリーコードでログイン
+	e.POST("/api/auth/login/mfa").
+		WithJSON(map[string]string{"mfa_token": mfaToken, "code": recoveryCodes.Element(0).String().Raw()}).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value("data").Object().ContainsKey("access_token")
+
+	e.GET("/api/auth/mfa").
+		WithHeader("Authorization", auth).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value("data").Object().
+		ValueEqual("totp_enabled", true).
+		ValueEqual("recovery_codes_remaining", utils.RecoveryCodeCount-1)
+
+	// 無効化後は通常のログインに戻る
+	e.DELETE("/api/auth/mfa/totp").
+		WithHeader("Authorization", auth).
+		WithJSON(map[string]string{"code": recoveryCodes.Element(1).String().Raw()}).
+		Expect().
+		Status(http.StatusOK)
+	e.POST("/api/auth/login").
+		WithJSON(credentials).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value("data").Object().ContainsKey("access_token")
+}
+
+// TestAPIKeyIntegration APIキーの作成・両ヘッダーでの認証・スコープ・失効の統合テスト
+func TestAPIKeyIntegration(t *testing.T) {
+	handlers := newTestHandlers(t, handler.NewHelloWorldHandler(nil))
+
+	server := httptest.NewServer(router.NewRouter(handlers))
+	defer server.Close()
+
+	e := httpExpect.New(t, server.URL)
+	auth := "Bearer " + registerTestUser(e, "apikey@example.com")
+
+	created := e.POST("/api/auth/api-keys").
+		WithHeader("Authorization", auth).
+		WithJSON(map[string]interface{}{"name": "CI", "scopes": []string{"roles:manage"}}).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object().Value("data").Object()
+	key := created.Value("key").String().Raw()
+	id := int(created.Value("id").Number().Raw())
+
+	// Authorization: Bearer と X-API-Key のどちらでも認証できる
+	e.GET("/api/roles").
+		WithHeader("Authorization", "Bearer "+key).
+		Expect().
+		Status(http.StatusOK)
+	e.GET("/api/roles").
+		WithHeader("X-API-Key", key).
+		Expect().
+		Status(http.StatusOK)
+
+	// スコープ外の権限は使えない
+	e.DELETE("/api/hello-world/messages/1").
+		WithHeader("X-API-Key", key).
+		Expect().
+		Status(http.StatusForbidden)
+
+	// APIキーでは認証情報を管理できない
+	e.POST("/api/auth/api-keys").
+		WithHeader("X-API-Key", key).
+		WithJSON(map[string]interface{}{"name": "Nested", "scopes": []string{"roles:manage"}}).
+		Expect().
+		Status(http.StatusForbidden)
+
+	// チームはスコープで絞り込めないため、APIキーでは参照のみ
+	e.GET("/api/teams").
+		WithHeader("X-API-Key", key).
+		Expect().
+		Status(http.StatusOK)
+	e.POST("/api/teams").
+		WithHeader("X-API-Key", key).
+		WithJSON(map[string]interface{}{"name": "Key Team"}).
+		Expect().
+		Status(http.StatusForbidden)
+	e.POST("/api/invitations/accept").
+		WithHeader("X-API-Key", key).
+		WithJSON(map[string]string{"token": "any"}).
+		Expect().
+		Status(http.StatusForbidden)
+
+	// プロフィール・通知設定もAPIキーでは参照のみ
+	e.GET("/api/me/notification-preferences").
+		WithHeader("X-API-Key", key).
+		Expect().
+		Status(http.StatusOK)
+	e.PUT("/api/me/notification-preferences").
+		WithHeader("X-API-Key", key).
+		WithJSON(map[string]interface{}{"preferences": []interface{}{}}).
+		Expect().
+		Status(http.StatusForbidden)
+	e.GET("/api/me").
+		WithHeader("X-API-Key", key).
+		Expect().
+		Status(http.StatusOK)
+	e.PATCH("/api/me").
+		WithHeader("X-API-Key", key).
+		WithJSON(map[string]interface{}{"name": "Renamed by key"}).
+		Expect().
+		Status(http.StatusForbidden)
+
+	// 受信箱・通知の既読状態もAPIキーでは変更できない
+	e.GET("/api/mails").
+		WithHeader("X-API-Key", key).
+		Expect().
+		Status(http.StatusOK)
+	e.PATCH("/api/mails/1").
+		WithHeader("X-API-Key", key).
+		WithJSON(map[string]interface{}{"unread": false}).
+		Expect().
+		Status(http.StatusForbidden)
+	e.GET("/api/notifications").
+		WithHeader("X-API-Key", key).
+		Expect().
+		Status(http.StatusOK)
+	e.POST("/api/notifications/read-all").
+		WithHeader("X-API-Key", key).
+		Expect().
+		Status(http.StatusForbidden)
+	e.POST("/api/notifications/1/read").
+		WithHeader("X-API-Key", key).
+		Expect().
+		Status(http.StatusForbidden)
+
+	// ブラウザからも X-API-Key を送信できる
+	e.OPTIONS("/api/roles").
+		Expect().
+		Header("Access-Control-Allow-Headers").Contains("X-API-Key")
+
+	// 一覧にキー本体は含まれず、最終利用日時が記録される
+	listed := e.GET("/api/auth/api-keys").
+		WithHeader("Authorization", auth).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value("data").Array()
+	listed.Length().Equal(1)
+	listed.Element(0).Object().NotContainsKey("key").ContainsKey("last_used_at")
+
+	e.DELETE(fmt.Sprintf("/api/auth/api-keys/%d", id)).
+		WithHeader("Authorization", auth).
+		Expect().
+		Status(http.StatusOK)
+	e.GET("/api/roles").
+		WithHeader("X-API-Key", key).
+		Expect().
+		Status(http.StatusUnauthorized)
+}
+
+// TestCustomerIntegration 顧客APIの統合テスト
+func TestCustomerIntegration(t *testing.T) {
+	handlers := newTestHandlers(t, handler.NewHelloWorldHandler(nil))
+
+	server := httptest.NewServer(router.NewRouter(handlers))
+	defer server.Close()
+
+	e := httpExpect.New(t, server.URL)
+	owner := "Bearer " + registerTestUser(e, "customer-owner@example.com")
+	member := "Bearer " + registerTestUser(e, "customer-member@example.com")
+
+	// 認証なしでは参照できない
+	e.GET("/api/customers").
+		Expect().
+		Status(http.StatusUnauthorized)
+
+	created := e.POST("/api/customers").
+		WithHeader("Authorization", member).
+		WithJSON(map[string]interface{}{
+			"name":     "Alex Smith",
+			"email":    "alex.smith@example.com",
+			"avatar":   map[string]string{"src": "https://i.pravatar.cc/128?u=1"},
+			"location": "New York, USA",
+		}).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object().Value("data").Object()
+	created.Value("status").String().Equal("subscribed")
+	created.Value("avatar").Object().Value("src").String().Equal("https://i.pravatar.cc/128?u=1")
+	id := int(created.Value("id").Number().Raw())
+
+	e.POST("/api/customers").
+		WithHeader("Authorization", owner).
+		WithJSON(map[string]interface{}{"name": "Jordan Brown", "email": "jordan.brown@example.com", "status": "bounced"}).
+		Expect().
+		Status(http.StatusCreated)
+
+	e.POST("/api/customers").
+		WithHeader("Authorization", owner).
+		WithJSON(map[string]interface{}{"name": "Duplicate", "email": "Alex.Smith@example.com"}).
+		Expect().
+		Status(http.StatusConflict)
+
+	// 全ての不正なフィールドをコード付きで返す
+	invalid := e.POST("/api/customers").
+		WithHeader("Authorization", owner).
+		WithJSON(map[string]interface{}{"name": "", "email": "not-an-email", "status": "deleted"}).
+		Expect().
+		Status(http.StatusBadRequest).
+		JSON().Object()
+	invalid.Value("errors").Array().Length().Equal(3)
+	invalid.Value("errors").Array().Element(0).Object().ValueEqual("field", "name").ValueEqual("code", "required")
+	invalid.Value("errors").Array().Element(2).Object().Value("params").Object().Value("values").Array().Length().Equal(3)
+
+	// 定義されていないフィールドは拒否する
+	e.POST("/api/customers").
+		WithHeader("Authorization", owner).
+		WithJSON(map[string]interface{}{"name": "Eve", "email": "eve@example.com", "owner_id": 1}).
+		Expect().
+		Status(http.StatusBadRequest).
+		JSON().Object().Value("errors").Array().Element(0).Object().
+		ValueEqual("field", "owner_id").ValueEqual("code", "unknown_field")
+
+	// ステータスで絞り込み
+	listed := e.GET("/api/customers").
+		WithHeader("Authorization", member).
+		WithQuery("status", "bounced").
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object()
+	listed.Value("data").Array().Length().Equal(1)
+	listed.Value("data").Array().Element(0).Object().Value("email").String().Equal("jordan.brown@example.com")
+
+	e.PATCH(fmt.Sprintf("/api/customers/%d", id)).
+		WithHeader("Authorization", member).
+		WithHeader("Content-Type", "application/merge-patch+json").
+		WithBytes([]byte(`{"status":"unsubscribed","avatar":null}`)).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value("data").Object().
+		NotContainsKey("avatar").
+		Value("status").String().Equal("unsubscribed")
+
+	// member は削除できない
+	e.DELETE(fmt.Sprintf("/api/customers/%d", id)).
+		WithHeader("Authorization", member).
+		Expect().
+		Status(http.StatusForbidden)
+
+	e.DELETE(fmt.Sprintf("/api/customers/%d", id)).
+		WithHeader("Authorization", owner).
+		Expect().
+		Status(http.StatusOK)
+	e.GET(fmt.Sprintf("/api/customers/%d", id)).
+		WithHeader("Authorization", owner).
+		Expect().
+		Status(http.StatusNotFound).
+		JSON().Object().ValueEqual("error", "not_found")
+
+	// Accept で Problem Details 形式を要求できる
+	problem := e.GET(fmt.Sprintf("/api/customers/%d", id)).
+		WithHeader("Authorization", owner).
+		WithHeader("Accept", "application/problem+json").
+		Expect().
+		Status(http.StatusNotFound).
+		JSON(httpExpect.ContentOpts{MediaType: "application/problem+json"}).Object()
+	problem.ValueEqual("type", "urn:problem-type:not_found")
+	problem.ValueEqual("title", "Not Found")
+	problem.ValueEqual("status", http.StatusNotFound)
+	problem.ValueEqual("detail", "Customer not found")
+	problem.ValueEqual("instance", fmt.Sprintf("/api/customers/%d", id))
+	problem.Value("request_id").String().NotEmpty()
+
+	e.POST("/api/customers").
+		WithHeader("Authorization", owner).
+		WithHeader("Accept", "application/problem+json").
+		WithJSON(map[string]interface{}{"name": "", "email": "eve@example.com"}).
+		Expect().
+		Status(http.StatusBadRequest).
+		JSON(httpExpect.ContentOpts{MediaType: "application/problem+json"}).Object().Value("errors").Array().Element(0).Object().ValueEqual("field", "name")
+
+	// Accept-Language: ja ではフィールドの表示名を含む日本語のメッセージを返す
+	e.GET(fmt.Sprintf("/api/customers/%d", id)).
+		WithHeader("Authorization", owner).
+		WithHeader("Accept-Language", "ja").
+		Expect().
+		Status(http.StatusNotFound).
+		JSON().Object().ValueEqual("message", "顧客が見つかりません")
+	e.POST("/api/customers").
+		WithHeader("Authorization", owner).
+		WithHeader("Accept-Language", "ja").
+		WithJSON(map[string]interface{}{"name": "", "email": "eve@example.com"}).
+		Expect().
+		Status(http.StatusBadRequest).
+		JSON().Object().Value("errors").Array().Element(0).Object().
+		ValueEqual("field", "name").
+		ValueEqual("message", "名前は必須です")
+}
+
+// TestMailIntegration 受信箱APIの統合テスト
+func TestMailIntegration(t *testing.T) {
+	handlers := newTestHandlers(t, handler.NewHelloWorldHandler(nil))
+
+	server := httptest.NewServer(router.NewRouter(handlers))
+	defer server.Close()
+
+	e := httpExpect.New(t, server.URL)
+	owner := "Bearer " + registerTestUser(e, "mail-owner@example.com")
+	member := "Bearer " + registerTestUser(e, "mail-member@example.com")
+
+	// メールの登録は mails:write 権限が必要
+	e.POST("/api/mails").
+		WithHeader("Authorization", member).
+		WithJSON(map[string]interface{}{"from": map[string]string{"email": "alex@example.com"}, "subject": "Hello"}).
+		Expect().
+		Status(http.StatusForbidden)
+
+	root := e.POST("/api/mails").
+		WithHeader("Authorization", owner).
+		WithJSON(map[string]interface{}{
+			"from":       map[string]interface{}{"name": "Jordan Brown", "email": "jordan.brown@example.com", "avatar": map[string]string{"src": "https://i.pravatar.cc/128?u=2"}},
+			"subject":    "Project Phoenix - Sprint 3 Update",
+			"body":       "Quick update on Sprint 3 deliverables",
+			"message_id": "<sprint3@example.com>",
+		}).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object().Value("data").Object()
+	rootID := int(root.Value("id").Number().Raw())
+
+	e.POST("/api/mails").
+		WithHeader("Authorization", owner).
+		WithJSON(map[string]interface{}{
+			"from":        map[string]string{"name": "Taylor Green", "email": "taylor.green@example.com"},
+			"subject":     "Sprint 3 blockers",
+			"in_reply_to": "<sprint3@example.com>",
+		}).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object().Value("data").Object().
+		Value("thread_id").Number().Equal(rootID)
+
+	e.GET("/api/mails/unread-count").
+		WithHeader("Authorization", member).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value("data").Object().Value("unread").Number().Equal(2)
+
+	// 既読はユーザーごと
+	e.PATCH(fmt.Sprintf("/api/mails/%d", rootID)).
+		WithHeader("Authorization", member).
+		WithJSON(map[string]bool{"unread": false}).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value("data").Object().Value("unread").Boolean().False()
+
+	e.GET("/api/mails").
+		WithHeader("Authorization", member).
+		WithQuery("filter", "unread").
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value("data").Array().Length().Equal(1)
+	e.GET("/api/mails").
+		WithHeader("Authorization", owner).
+		WithQuery("filter", "unread").
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value("data").Array().Length().Equal(2)
+
+	e.GET(fmt.Sprintf("/api/mails/%d/thread", rootID)).
+		WithHeader("Authorization", member).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value("data").Array().Length().Equal(2)
+}
+
+// TestTeamIntegration チーム作成・招待・承諾・脱退の統合テスト
+func TestTeamIntegration(t *testing.T) {
+	handlers := newTestHandlers(t, handler.NewHelloWorldHandler(nil))
+
+	server := httptest.NewServer(router.NewRouter(handlers))
+	defer server.Close()
+
+	e := httpExpect.New(t, server.URL)
+	owner := "Bearer " + registerTestUser(e, "team-owner@example.com")
+	member := "Bearer " + registerTestUser(e, "team-member@example.com")
+	outsider := "Bearer " + registerTestUser(e, "team-outsider@example.com")
+
+	team := e.POST("/api/teams").
+		WithHeader("Authorization", owner).
+		WithJSON(map[string]interface{}{"name": "Nuxt", "avatar": map[string]string{"src": "https://github.com/nuxt.png"}}).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object().Value("data").Object()
+	team.Value("role").String().Equal("owner")
+	teamPath := fmt.Sprintf("/api/teams/%d", int(team.Value("id").Number().Raw()))
+
+	// 招待のトークンはメールでのみ送信される
+	e.POST(teamPath+"/invitations").
+		WithHeader("Authorization", owner).
+		WithJSON(map[string]string{"email": "team-member@example.com"}).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object().Value("data").Object().NotContainsKey("token")
+	token := testMailbox.linkToken(t, "team-member@example.com")
+
+	e.POST("/api/invitations/accept").
+		WithHeader("Authorization", outsider).
+		WithJSON(map[string]string{"token": token}).
+		Expect().
+		Status(http.StatusForbidden)
+	e.POST("/api/invitations/accept").
+		WithHeader("Authorization", member).
+		WithJSON(map[string]string{"token": token}).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value("data").Object().Value("role").String().Equal("member")
+	e.POST("/api/invitations/accept").
+		WithHeader("Authorization", member).
+		WithJSON(map[string]string{"token": token}).
+		Expect().
+		Status(http.StatusNotFound)
+
+	members := e.GET(teamPath+"/members").
+		WithHeader("Authorization", member).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value("data").Array()
+	members.Length().Equal(2)
+	memberID := int(members.Element(1).Object().Value("user_id").Number().Raw())
+	ownerID := int(members.Element(0).Object().Value("user_id").Number().Raw())
+
+	// メンバーでないユーザーにはチームの存在を明かさない
+	e.GET(teamPath).
+		WithHeader("Authorization", outsider).
+		Expect().
+		Status(http.StatusNotFound)
+
+	// owner のみの操作
+	e.PATCH(teamPath).
+		WithHeader("Authorization", member).
+		WithJSON(map[string]string{"name": "Renamed"}).
+		Expect().
+		Status(http.StatusForbidden)
+
+	// 最後の owner は脱退できない
+	e.DELETE(fmt.Sprintf("%s/members/%d", teamPath, ownerID)).
+		WithHeader("Authorization", owner).
+		Expect().
+		Status(http.StatusConflict)
+
+	// member を owner に昇格すれば元の owner は脱退できる
+	e.PATCH(fmt.Sprintf("%s/members/%d", teamPath, memberID)).
+		WithHeader("Authorization", owner).
+		WithJSON(map[string]string{"role": "owner"}).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value("data").Object().Value("role").String().Equal("owner")
+	e.DELETE(fmt.Sprintf("%s/members/%d", teamPath, ownerID)).
+		WithHeader("Authorization", owner).
+		Expect().
+		Status(http.StatusOK)
+
+	e.GET("/api/teams").
+		WithHeader("Authorization", owner).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value("data").Array().Length().Equal(0)
+	e.GET("/api/teams").
+		WithHeader("Authorization", member).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value("data").Array().Length().Equal(1)
+}
+
+// TestNotificationIntegration 顧客登録による通知と既読化の統合テスト
+func TestNotificationIntegration(t *testing.T) {
+	handlers := newTestHandlers(t, handler.NewHelloWorldHandler(nil))
+
+	server := httptest.NewServer(router.NewRouter(handlers))
+	defer server.Close()
+
+	e := httpExpect.New(t, server.URL)
+	owner := "Bearer " + registerTestUser(e, "notify-owner@example.com")
+	member := "Bearer " + registerTestUser(e, "notify-member@example.com")
+
+	e.GET("/api/notifications").
+		Expect().
+		Status(http.StatusUnauthorized)
+
+	// 顧客の登録は全ユーザーに通知される
+	e.POST("/api/customers").
+		WithHeader("Authorization", owner).
+		WithJSON(map[string]interface{}{"name": "Alex Smith", "email": "alex.smith@example.com"}).
+		Expect().
+		Status(http.StatusCreated)
+	e.POST("/api/customers").
+		WithHeader("Authorization", owner).
+		WithJSON(map[string]interface{}{"name": "Jordan Brown", "email": "jordan.brown@example.com"}).
+		Expect().
+		Status(http.StatusCreated)
+
+	notifications := e.GET("/api/notifications").
+		WithHeader("Authorization", member).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value("data").Array()
+	notifications.Length().Equal(2)
+	latest := notifications.Element(0).Object()
+	latest.Value("type").String().Equal("customer.created")
+	latest.Value("unread").Boolean().True()
+	latest.Value("sender").Object().Value("name").String().Equal("Jordan Brown")
+	latest.NotContainsKey("read_at")
+	id := int(latest.Value("id").Number().Raw())
+
+	// 他のユーザー宛ての通知は既読にできない
+	e.POST(fmt.Sprintf("/api/notifications/%d/read", id)).
+		WithHeader("Authorization", owner).
+		Expect().
+		Status(http.StatusNotFound)
+	e.POST(fmt.Sprintf("/api/notifications/%d/read", id)).
+		WithHeader("Authorization", member).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value("data").Object().ContainsKey("read_at").Value("unread").Boolean().False()
+
+	e.GET("/api/notifications").
+		WithHeader("Authorization", member).
+		WithQuery("filter", "unread").
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value("data").Array().Length().Equal(1)
+	e.GET("/api/notifications/unread-count").
+		WithHeader("Authorization", member).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value("data").Object().Value("unread").Number().Equal(1)
+
+	e.POST("/api/notifications/read-all").
+		WithHeader("Authorization", member).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value("data").Object().Value("updated").Number().Equal(1)
+	e.GET("/api/notifications/unread-count").
+		WithHeader("Authorization", member).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value("data").Object().Value("unread").Number().Equal(0)
+	e.GET("/api/notifications/unread-count").
+		WithHeader("Authorization", owner).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value("data").Object().Value("unread").Number().Equal(2)
+}
+
+// TestNotificationPreferencesIntegration 通知設定による配信チャネルの切り替えの統合テスト
+func TestNotificationPreferencesIntegration(t *testing.T) {
+	handlers, notifications := newTestHandlersWithNotifications(t, handler.NewHelloWorldHandler(nil), services.NewMemoryEventBus())
+
+	server := httptest.NewServer(router.NewRouter(handlers))
+	defer server.Close()
+
+	e := httpExpect.New(t, server.URL)
+	owner := "Bearer " + registerTestUser(e, "prefs-owner@example.com")
+	member := "Bearer " + registerTestUser(e, "prefs-member@example.com")
+
+	e.GET("/api/me/notification-preferences").
+		Expect().
+		Status(http.StatusUnauthorized)
+
+	defaultSettings := e.GET("/api/me/notification-preferences").
+		WithHeader("Authorization", member).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value("data").Object()
+	defaultSettings.ValueEqual("weekly_digest", false).ValueEqual("product_updates", true).ValueEqual("important_updates", true)
+	defaults := defaultSettings.Value("preferences").Array()
+	defaults.Length().Equal(6)
+	defaults.ContainsAny(map[string]interface{}{"type": "customer.created", "channel": "email", "enabled": false})
+	defaults.ContainsAny(map[string]interface{}{"type": "mail.received", "channel": "desktop", "enabled": false})
+
+	// 顧客登録はメールのみで受け取り、週次ダイジェストを購読する
+	updated := e.PUT("/api/me/notification-preferences").
+		WithHeader("Authorization", member).
+		WithJSON(map[string]interface{}{"preferences": []map[string]interface{}{
+			{"type": "customer.created", "channel": "in_app", "enabled": false},
+			{"type": "customer.created", "channel": "email", "enabled": true},
+		}, "weekly_digest": true}).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value("data").Object()
+	updated.Value("preferences").Array().
+		ContainsAny(map[string]interface{}{"type": "customer.created", "channel": "in_app", "enabled": false})
+	updated.ValueEqual("weekly_digest", true)
+	e.GET("/api/me/notification-preferences").
+		WithHeader("Authorization", member).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value("data").Object().ValueEqual("weekly_digest", true)
+	e.PUT("/api/me/notification-preferences").
+		WithHeader("Authorization", member).
+		WithJSON(map[string]interface{}{"preferences": []map[string]interface{}{{"type": "sale.created", "channel": "email", "enabled": true}}}).
+		Expect().
+		Status(http.StatusBadRequest).
+		JSON().Object().Value("errors").Array().Element(0).Object().Value("field").String().Equal("preferences[0].type")
+
+	e.POST("/api/customers").
+		WithHeader("Authorization", owner).
+		WithJSON(map[string]interface{}{"name": "Casey Jones", "email": "casey.jones@example.com"}).
+		Expect().
+		Status(http.StatusCreated)
+
+	e.GET("/api/notifications/unread-count").
+		WithHeader("Authorization", member).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value("data").Object().Value("unread").Number().Equal(0)
+	e.GET("/api/notifications/unread-count").
+		WithHeader("Authorization", owner).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value("data").Object().Value("unread").Number().Equal(1)
+
+	notifications.Flush()
+	testMailbox.mu.Lock()
+	received := map[string]bool{}
+	for _, message := range testMailbox.messages {
+		if strings.HasPrefix(message.Subject, "Casey Jones ") {
+			received[message.To] = true
+		}
+	}
+	testMailbox.mu.Unlock()
+	if !received["prefs-member@example.com"] || received["prefs-owner@example.com"] {
+		t.Errorf("顧客登録の通知メールの宛先が不正: %v", received)
+	}
+}
+
+// TestStatsIntegration ルーター経由の売上統計の統合テスト
+func TestStatsIntegration(t *testing.T) {
+	handlers := newTestHandlers(t, handler.NewHelloWorldHandler(nil))
+
+	server := httptest.NewServer(router.NewRouter(handlers))
+	defer server.Close()
+
+	e := httpExpect.New(t, server.URL)
+	token := "Bearer " + registerTestUser(e, "stats@example.com")
+
+	e.GET("/api/stats").
+		Expect().
+		Status(http.StatusUnauthorized)
+
+	// 売上がなくても期間内の全てのバケットを0で返す
+	stats := e.GET("/api/stats").
+		WithHeader("Authorization", token).
+		WithQuery("period", "monthly").
+		WithQuery("start", "2024-01-01").
+		WithQuery("end", "2024-06-30").
+		WithQuery("tz", "Asia/Tokyo").
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value("data").Object()
+	stats.Value("period").String().Equal("monthly")
+	stats.Value("range").Object().Value("start").String().Equal("2024-01-01T00:00:00+09:00")
+	stats.Value("previous_range").Object().Value("start").String().Equal("2023-07-03T00:00:00+09:00")
+	stats.Value("summary").Object().Value("revenue").Object().Value("variation").Number().Equal(0)
+	buckets := stats.Value("buckets").Array()
+	buckets.Length().Equal(6)
+	buckets.Element(5).Object().Value("date").String().Equal("2024-06-01T00:00:00+09:00")
+	buckets.Element(5).Object().Value("orders").Number().Equal(0)
+
+	e.GET("/api/stats").
+		WithHeader("Authorization", token).
+		WithQuery("period", "daily").
+		WithQuery("start", "2020-01-01").
+		Expect().
+		Status(http.StatusBadRequest).
+		JSON().Object().Value("error").String().Equal("validation_error")
+}
+
+// TestSalesIntegration 売上台帳APIの統合テスト
+func TestSalesIntegration(t *testing.T) {
+	handlers := newTestHandlers(t, handler.NewHelloWorldHandler(nil))
+
+	server := httptest.NewServer(router.NewRouter(handlers))
+	defer server.Close()
+
+	e := httpExpect.New(t, server.URL)
+	owner := "Bearer " + registerTestUser(e, "sales-owner@example.com")
+	member := "Bearer " + registerTestUser(e, "sales-member@example.com")
+
+	// member は登録できない
+	e.POST("/api/sales").
+		WithHeader("Authorization", member).
+		WithJSON(map[string]interface{}{"email": "buyer@example.com", "amount": 59400}).
+		Expect().
+		Status(http.StatusForbidden)
+
+	created := e.POST("/api/sales").
+		WithHeader("Authorization", owner).
+		WithJSON(map[string]interface{}{"email": "buyer@example.com", "amount": 59400, "date": "2024-03-05T10:00:00Z"}).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object().Value("data").Object()
+	created.Value("status").String().Equal("paid")
+	created.Value("currency").String().Equal("USD")
+	created.NotContainsKey("refunded_at")
+	id := int(created.Value("id").Number().Raw())
+
+	// 返金前は統計に含まれる
+	e.GET("/api/stats").
+		WithHeader("Authorization", member).
+		WithQuery("start", "2024-03-01").
+		WithQuery("end", "2024-03-31").
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value("data").Object().
+		Value("summary").Object().Value("revenue").Object().Value("value").Number().Equal(59400)
+
+	// member は返金できない
+	e.PATCH(fmt.Sprintf("/api/sales/%d", id)).
+		WithHeader("Authorization", member).
+		WithJSON(map[string]string{"status": "refunded"}).
+		Expect().
+		Status(http.StatusForbidden)
+
+	refunded := e.PATCH(fmt.Sprintf("/api/sales/%d", id)).
+		WithHeader("Authorization", owner).
+		WithJSON(map[string]string{"status": "refunded"}).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value("data").Object()
+	refunded.Value("status").String().Equal("refunded")
+	refunded.Value("refunded_by").Number().Equal(1)
+	refunded.ContainsKey("refunded_at")
+
+	// 返金済みの売上は再度返金できない
+	e.PATCH(fmt.Sprintf("/api/sales/%d", id)).
+		WithHeader("Authorization", owner).
+		WithJSON(map[string]string{"status": "refunded"}).
+		Expect().
+		Status(http.StatusBadRequest).
+		JSON().Object().Value("error").String().Equal("validation_error")
+
+	e.GET("/api/sales").
+		WithHeader("Authorization", member).
+		WithQuery("status", "refunded").
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value("data").Array().Length().Equal(1)
+
+	// 返金後は統計から除外される
+	e.GET("/api/stats").
+		WithHeader("Authorization", member).
+		WithQuery("start", "2024-03-01").
+		WithQuery("end", "2024-03-31").
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value("data").Object().
+		Value("summary").Object().Value("revenue").Object().Value("value").Number().Equal(0)
+}
+
+// TestEventsIntegration ルーター経由のイベントストリームの統合テスト
+func TestEventsIntegration(t *testing.T) {
+	repos, err := services.NewRepositories(utils.StorageDriverMemory, nil)
+	if err != nil {
+		t.Fatalf("NewRepositories失敗: %v", err)
+	}
+	eventBus := services.NewMemoryEventBus()
+	helloWorldService := services.NewHelloWorldServiceWithEvents(repos.HelloWorld, eventBus)
+	handlers := newTestHandlersWithEvents(t, handler.NewHelloWorldHandlerWithService(helloWorldService), eventBus)
+
+	r := router.NewRouter(handlers)
+	server := httptest.NewServer(r)
+	defer server.Close()
+	// ストリーム用に、本番と同じく WriteTimeout を設定したサーバー
+	streamServer := httptest.NewUnstartedServer(r)
+	streamServer.Config.WriteTimeout = 300 * time.Millisecond
+	streamServer.Start()
+	defer streamServer.Close()
+
+	e := httpExpect.New(t, server.URL)
+	auth := "Bearer " + registerTestUser(e, "events@example.com")
+
+	e.GET("/api/events").
+		Expect().
+		Status(http.StatusUnauthorized)
+
+	req, _ := http.NewRequest("GET", streamServer.URL+"/api/events", nil)
+	req.Header.Set("Authorization", auth)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("接続失敗: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", resp.StatusCode)
+	}
+
+	// id 行と event 行の組を "id event" 形式で受け取る
+	events := make(chan string, 10)
+	go func() {
+		defer close(events)
+		scanner := bufio.NewScanner(resp.Body)
+		id := ""
+		for scanner.Scan() {
+			line := scanner.Text()
+			switch {
+			case strings.HasPrefix(line, "id: "):
+				id = strings.TrimPrefix(line, "id: ")
+			case strings.HasPrefix(line, "event: "):
+				events <- id + " " + strings.TrimPrefix(line, "event: ")
+			}
+		}
+	}()
+
+	// WriteTimeout を過ぎても接続が維持される
+	time.Sleep(500 * time.Millisecond)
+	e.POST("/api/hello-world").
+		WithHeader("Authorization", auth).
+		WithJSON(map[string]string{"name": "Events"}).
+		Expect().
+		Status(http.StatusCreated)
+	e.POST("/api/customers").
+		WithHeader("Authorization", auth).
+		WithJSON(map[string]interface{}{"name": "Alex Smith", "email": "alex.smith@example.com"}).
+		Expect().
+		Status(http.StatusCreated)
+
+	ids := []string{}
+	for _, expected := range []string{"message.created", "notification.created"} {
+		select {
+		case received, ok := <-events:
+			if !ok {
+				t.Fatalf("%s の受信前にストリームが閉じられた", expected)
+			}
+			id, event, _ := strings.Cut(received, " ")
+			if event != expected {
+				t.Errorf("受信イベント = %s, want %s", event, expected)
+			}
+			ids = append(ids, id)
+		case <-time.After(2 * time.Second):
+			t.Fatalf("%s が配信されない", expected)
+		}
+	}
+
+	// 再接続時は Last-Event-ID 以降のイベントを再送する
+	req, _ = http.NewRequest("GET", streamServer.URL+"/api/events", nil)
+	req.Header.Set("Authorization", auth)
+	req.Header.Set("Last-Event-ID", ids[0])
+	resumed, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("再接続失敗: %v", err)
+	}
+	defer resumed.Body.Close()
+	reader := bufio.NewReader(resumed.Body)
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			t.Fatalf("再送イベントの読み取り失敗: %v", err)
+		}
+		if strings.HasPrefix(line, "id: ") {
+			if line != "id: "+ids[1]+"\n" {
+				t.Errorf("再送イベントのID = %q, want %s", line, ids[1])
+			}
+			break
+		}
+	}
+}
+
+// TestWebSocketIntegration ルーター経由の WebSocket の統合テスト
+func TestWebSocketIntegration(t *testing.T) {
+	repos, err := services.NewRepositories(utils.StorageDriverMemory, nil)
+	if err != nil {
+		t.Fatalf("NewRepositories失敗: %v", err)
+	}
+	eventBus := services.NewMemoryEventBus()
+	helloWorldService := services.NewHelloWorldServiceWithEvents(repos.HelloWorld, eventBus)
+	handlers := newTestHandlersWithEvents(t, handler.NewHelloWorldHandlerWithService(helloWorldService), eventBus)
+
+	r := router.NewRouter(handlers)
+	server := httptest.NewServer(r)
+	defer server.Close()
+	// WebSocket 用に、本番と同じく WriteTimeout を設定したサーバー
+	wsServer := httptest.NewUnstartedServer(r)
+	wsServer.Config.WriteTimeout = 300 * time.Millisecond
+	wsServer.Start()
+	defer wsServer.Close()
+
+	e := httpExpect.New(t, server.URL)
+	alice := registerTestUser(e, "ws-alice@example.com")
+	bob := registerTestUser(e, "ws-bob@example.com")
+	url := "ws" + strings.TrimPrefix(wsServer.URL, "http") + "/api/ws"
+
+	if _, resp, err := websocket.DefaultDialer.Dial(url, nil); err == nil || resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("認証なしの接続が拒否されない: %v", err)
+	}
+
+	// ブラウザと同じくサブプロトコルでトークンを渡す
+	browser := websocket.Dialer{Subprotocols: []string{utils.WebSocketSubprotocol, utils.WebSocketTokenProtocolPrefix + alice}}
+	aliceConn, _, err := browser.Dial(url, nil)
+	if err != nil {
+		t.Fatalf("alice の接続失敗: %v", err)
+	}
+	defer aliceConn.Close()
+	bobConn, _, err := websocket.DefaultDialer.Dial(url, http.Header{"Authorization": {"Bearer " + bob}})
+	if err != nil {
+		t.Fatalf("bob の接続失敗: %v", err)
+	}
+	defer bobConn.Close()
+
+	read := func(conn *websocket.Conn) string {
+		t.Helper()
+		var message struct {
+			Type string `json:"type"`
+			Room string `json:"room"`
+		}
+		_ = conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+		if err := conn.ReadJSON(&message); err != nil {
+			t.Fatalf("受信失敗: %v", err)
+		}
+		return message.Type + " " + message.Room
+	}
+
+	aliceConn.WriteJSON(map[string]string{"type": "room.join", "room": "customer:1"})
+	if got := read(aliceConn); got != "presence.state customer:1" {
+		t.Errorf("alice: %s", got)
+	}
+	bobConn.WriteJSON(map[string]string{"type": "room.join", "room": "customer:1"})
+	if got := read(bobConn); got != "presence.state customer:1" {
+		t.Errorf("bob: %s", got)
+	}
+	if got := read(aliceConn); got != "presence.joined customer:1" {
+		t.Errorf("alice: %s", got)
+	}
+
+	// WriteTimeout を過ぎても接続が維持される
+	time.Sleep(500 * time.Millisecond)
+	bobConn.WriteJSON(map[string]string{"type": "typing.start", "room": "customer:1"})
+	if got := read(aliceConn); got != "typing.started customer:1" {
+		t.Errorf("alice: %s", got)
+	}
+
+	// サービスが発行したイベントも配信される
+	e.POST("/api/hello-world").
+		WithHeader("Authorization", "Bearer "+alice).
+		WithJSON(map[string]string{"name": "WebSocket"}).
+		Expect().
+		Status(http.StatusCreated)
+	if got := read(bobConn); got != "message.created " {
+		t.Errorf("bob: %s", got)
+	}
+
+	bobConn.Close()
+	if got := read(aliceConn); got != "message.created " {
+		t.Errorf("alice: %s", got)
+	}
+	if got := read(aliceConn); got != "presence.left customer:1" {
+		t.Errorf("alice: %s", got)
+	}
+}
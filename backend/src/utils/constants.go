@@ -17,8 +17,52 @@ const (
 	DefaultDBPassword = "samplepass"
 	DefaultDBName     = "sampledb"
 
+	// ストレージ設定
+	StorageDriverPostgres = "postgres"
+	StorageDriverMemory   = "memory"
+	DefaultStorageDriver  = StorageDriverPostgres
+
 	// JWT設定
-	DefaultJWTSecret = "your_jwt_secret"
+	DefaultJWTSecret    = "your_jwt_secret"
+	JWTAlgorithmHS256   = "HS256"
+	JWTAlgorithmRS256   = "RS256"
+	DefaultJWTAlgorithm = JWTAlgorithmHS256
+	DefaultJWTIssuer    = "go-chi-starter"
+	TokenTypeBearer     = "Bearer"
+
+	// パスワードハッシュ設定
+	PasswordHashBcrypt           = "bcrypt"
+	PasswordHashArgon2id         = "argon2id"
+	DefaultPasswordHashAlgorithm = PasswordHashBcrypt
+
+	// 二要素認証設定
+	TOTPIssuer              = "Go Chi Starter" // 認証アプリに表示される発行者名
+	MFATokenTTLSeconds      = 300              // ログイン2段階目までの猶予（mfa_token の有効期間）
+	MaxMFAAttempts          = 5                // mfa_token 1つあたりのコード入力回数の上限
+	MaxMFAFailures          = 10               // ユーザーごとのコードの失敗回数の上限（MFAFailureWindowSeconds の間）
+	MFAFailureWindowSeconds = 900              // 最初の失敗から失敗回数を数える期間
+	RecoveryCodeCount       = 10
+	TOTPQRCodeImageSize     = 256
+
+	// APIキー設定
+	APIKeyPrefix              = "gcs_" // APIキーの先頭文字列（アクセストークンとの判別用）
+	APIKeyHeader              = "X-API-Key"
+	MaxAPIKeysPerUser         = 20
+	APIKeyLastUsedGranularity = 60 // 最終利用日時を更新する最小間隔（秒）
+
+	// メール設定
+	MailMessageIDDomain        = "mail.localhost" // Message-ID 省略時に採番する ID のドメイン部
+	NotificationEmailQueueSize = 1000             // 送信待ちの通知メールの上限（宛先ごとではなく通知ごと）
+
+	// イベント設定
+	EventBusChannel     = "app_events" // LISTEN/NOTIFY のチャネル名
+	MaxEventPayloadSize = 7900         // NOTIFY のペイロード上限（PostgreSQL の上限 8000 バイト未満）
+
+	// WebSocket設定
+	WebSocketSubprotocol         = "realtime.v1"
+	WebSocketTokenProtocolPrefix = "bearer." // Sec-WebSocket-Protocol でトークンを渡す場合の接頭辞（ブラウザ用）
+	MaxWebSocketMessageSize      = 4096      // クライアントから受信するメッセージの上限（バイト）
+	MaxRealtimeRoomsPerClient    = 20        // 1つの接続が同時に参加できるルーム数の上限
 
 	// タイムアウト設定
 	DefaultTimeout = 30
@@ -29,7 +73,12 @@ const (
 	LogLevelError = "ERROR"
 
 	// HTTP設定
-	MaxRequestSize = 1 << 20 // 1MB
+	MaxRequestSize     = 1 << 20  // 1MB（リクエストボディの上限）
+	MaxAuthRequestSize = 16 << 10 // 16KB（認証 API のリクエストボディの上限）
+
+	// ページネーション設定
+	DefaultPageLimit = 20
+	MaxPageLimit     = 100
 
 	// エラーメッセージ
 	ErrInvalidRequest     = "Invalid request"
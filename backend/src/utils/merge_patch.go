@@ -0,0 +1,52 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// ErrInvalidMergePatch JSON Merge Patchドキュメントが不正な場合のエラー
+var ErrInvalidMergePatch = errors.New("invalid merge patch document")
+
+// ApplyMergePatch RFC 7396 (JSON Merge Patch) に従ってパッチを適用します
+//
+// パッチ内のnullはキーの削除、オブジェクトは再帰的なマージ、
+// それ以外の値は置き換えとして扱います。
+func ApplyMergePatch(original, patch []byte) ([]byte, error) {
+	var patchValue interface{}
+	if err := json.Unmarshal(patch, &patchValue); err != nil {
+		return nil, ErrInvalidMergePatch
+	}
+
+	var originalValue interface{}
+	if len(original) > 0 {
+		if err := json.Unmarshal(original, &originalValue); err != nil {
+			return nil, err
+		}
+	}
+
+	return json.Marshal(mergePatch(originalValue, patchValue))
+}
+
+// mergePatch RFC 7396 の MergePatch 関数
+func mergePatch(target, patch interface{}) interface{} {
+	patchObject, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+
+	targetObject, ok := target.(map[string]interface{})
+	if !ok {
+		targetObject = map[string]interface{}{}
+	}
+
+	for key, value := range patchObject {
+		if value == nil {
+			delete(targetObject, key)
+			continue
+		}
+		targetObject[key] = mergePatch(targetObject[key], value)
+	}
+
+	return targetObject
+}
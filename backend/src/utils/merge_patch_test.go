@@ -0,0 +1,59 @@
+package utils
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// TestApplyMergePatch RFC 7396 の付録Aに記載された例のテスト
+func TestApplyMergePatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		original string
+		patch    string
+		expected string
+	}{
+		{"Replace value", `{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{"Add value", `{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{"Remove value", `{"a":"b"}`, `{"a":null}`, `{}`},
+		{"Remove one of many", `{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+		{"Array replaces value", `{"a":["b"]}`, `{"a":"c"}`, `{"a":"c"}`},
+		{"Value replaces array", `{"a":"c"}`, `{"a":["b"]}`, `{"a":["b"]}`},
+		{"Nested merge", `{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`, `{"a":{"b":"d"}}`},
+		{"Array is replaced", `{"a":[{"b":"c"}]}`, `{"a":[1]}`, `{"a":[1]}`},
+		{"Non-object patch replaces", `{"a":"foo"}`, `"bar"`, `"bar"`},
+		{"Null in nested new object", `{"e":null}`, `{"a":1}`, `{"e":null,"a":1}`},
+		{"Non-object target", `[1,2]`, `{"a":"b","c":null}`, `{"a":"b"}`},
+		{"Empty patch", `{"a":"b"}`, `{}`, `{"a":"b"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ApplyMergePatch([]byte(tt.original), []byte(tt.patch))
+			if err != nil {
+				t.Fatalf("ApplyMergePatch() error = %v", err)
+			}
+
+			var got, want interface{}
+			if err := json.Unmarshal(result, &got); err != nil {
+				t.Fatalf("Failed to decode result: %v", err)
+			}
+			if err := json.Unmarshal([]byte(tt.expected), &want); err != nil {
+				t.Fatalf("Failed to decode expected: %v", err)
+			}
+
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("Expected %s, got %s", tt.expected, string(result))
+			}
+		})
+	}
+}
+
+// TestApplyMergePatchInvalid 不正なパッチのテスト
+func TestApplyMergePatchInvalid(t *testing.T) {
+	_, err := ApplyMergePatch([]byte(`{"a":"b"}`), []byte(`{invalid`))
+	if err != ErrInvalidMergePatch {
+		t.Errorf("Expected ErrInvalidMergePatch, got %v", err)
+	}
+}